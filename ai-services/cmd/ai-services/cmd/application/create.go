@@ -46,6 +46,7 @@ var (
 	rawArgParams []string
 	argParams    map[string]string
 	legacyCreate bool
+	dryRun       bool
 
 	// podman flags.
 	skipModelDownload     bool
@@ -91,6 +92,11 @@ Arguments:
 		// Once precheck passes, silence usage for any *later* internal errors.
 		cmd.SilenceUsage = true
 
+		// A dry run only asks the catalog API server, so the host is not validated
+		if dryRun {
+			return planApp(appName)
+		}
+
 		if err := doBootstrapValidate(); err != nil {
 			return err
 		}
@@ -138,6 +144,9 @@ func createExample() string {
   # Deploy with legacy mode
   ai-services application create rag --template rag --runtime podman --legacy
 
+  # Show what would be deployed without deploying
+  ai-services application create rag --template rag --runtime podman --dry-run
+
   For Openshift:
   # Deploy with default mode (5 Spyre cards)
   ai-services application create rag --template rag --runtime openshift`
@@ -196,6 +205,14 @@ func initCreateCommonFlags() {
 	)
 
 	createCmd.Flags().BoolVar(&legacyCreate, appFlags.Create.Legacy, false, "Use legacy application create implementation")
+	createCmd.Flags().BoolVar(
+		&dryRun,
+		appFlags.Create.DryRun,
+		false,
+		"Print the deployment plan without deploying the application\n\n"+
+			"Shows the components and services, their deployment order, required and available resources,\n"+
+			"and the images and models to download. Not supported with --legacy.\n",
+	)
 }
 
 func initCreatePodmanFlags() {
@@ -273,7 +290,8 @@ func buildFlagValidator() *flagvalidator.FlagValidator {
 		AddCommonFlag(appFlags.Create.Template, validateTemplateFlag).
 		AddCommonFlag(appFlags.Create.Params, validateParamsFlag).
 		AddCommonFlag(appFlags.Create.Values, validateValuesFlag).
-		AddCommonFlag(appFlags.Create.Legacy, nil)
+		AddCommonFlag(appFlags.Create.Legacy, nil).
+		AddCommonFlag(appFlags.Create.DryRun, validateDryRunFlag)
 
 	// Register Podman-specific flags
 	builder.
@@ -288,6 +306,15 @@ func buildFlagValidator() *flagvalidator.FlagValidator {
 	return builder.Build()
 }
 
+// validateDryRunFlag rejects --dry-run in legacy mode, which does not use the catalog API.
+func validateDryRunFlag(cmd *cobra.Command) error {
+	if dryRun && legacyCreate {
+		return fmt.Errorf("--%s is not supported with --%s", appFlags.Create.DryRun, appFlags.Create.Legacy)
+	}
+
+	return nil
+}
+
 // validateTemplateFlag validates the template flag.
 func validateTemplateFlag(cmd *cobra.Command) error {
 	// do template validation in legacy mode
@@ -417,8 +444,8 @@ func createApp(appName string) error {
 
 	logger.Infof("Application creation initiated (ID: %s)\n", resp.ID)
 
-	// 5. Poll for application status
-	return pollApplicationStatus(appClient, appName, resp.ID)
+	// 5. Follow the deployment until the application is ready or fails
+	return followDeployment(appClient, appName, resp.ID)
 }
 
 // checkApplicationExists checks if an application with the given name already exists.
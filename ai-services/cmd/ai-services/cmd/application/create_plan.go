@@ -0,0 +1,110 @@
+package application
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	apiModels "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+	catalogClient "github.com/project-ai-services/ai-services/internal/pkg/catalog/client"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+// planApp prints what creating the application would deploy, without creating it.
+func planApp(appName string) error {
+	appClient, err := catalogClient.NewApplicationClient()
+	if err != nil {
+		return fmt.Errorf("failed to create application client: %w", err)
+	}
+
+	payload, err := buildCatalogPayload(appName)
+	if err != nil {
+		return err
+	}
+
+	plan, err := appClient.PlanApplication(payload)
+	if err != nil {
+		return err
+	}
+
+	printPlan(plan)
+
+	return nil
+}
+
+// printPlan prints a deployment plan preview.
+func printPlan(plan *apiModels.PlanApplicationResponse) {
+	host := "the local runtime"
+	if plan.Worker != nil {
+		host = "worker " + plan.Worker.Name
+	}
+	logger.Infof("Dry run: application '%s' from '%s' would be deployed on %s (%s)\n\n", plan.Name, plan.CatalogID, host, plan.Runtime)
+
+	printer := utils.NewTableWriter()
+	printer.SetHeaders("COMPONENT", "PROVIDER", "USED BY", "SPYRE CARDS")
+	for _, comp := range plan.Components {
+		usedBy := strings.Join(comp.UsedBy, ", ")
+		if comp.Shared {
+			usedBy += " (shared)"
+		}
+		printer.AppendRow(comp.Type, comp.Provider, usedBy, strconv.Itoa(comp.SpyreCards))
+	}
+	printer.CloseTableWriter()
+
+	logger.Infoln("\nDeployment order:")
+	for i, layer := range plan.DeploymentOrder {
+		logger.Infof("  %d. %s\n", i+1, strings.Join(layer, ", "))
+	}
+	logger.Infoln("")
+
+	printPlanResources(plan.Resources)
+	printPlanList("Images to pull unless present", plan.Images)
+	printPlanList("Models to download unless present", plan.Models)
+
+	for _, warning := range plan.Warnings {
+		logger.Warningf("%s\n", warning)
+	}
+}
+
+// printPlanResources prints the required and available resources of a plan.
+func printPlanResources(res apiModels.PlanResources) {
+	available := func(format func(apiModels.ResourceAmounts) string) string {
+		if res.Available == nil {
+			return "unknown"
+		}
+
+		return format(*res.Available)
+	}
+
+	printer := utils.NewTableWriter()
+	printer.SetHeaders("RESOURCE", "REQUIRED", "AVAILABLE")
+
+	cpu := func(r apiModels.ResourceAmounts) string { return strconv.FormatFloat(r.CPU, 'f', 1, 64) }
+	printer.AppendRow("CPU (cores)", cpu(res.Required), available(cpu))
+
+	memory := func(r apiModels.ResourceAmounts) string { return utils.FormatBytes(r.MemoryBytes) }
+	printer.AppendRow("Memory", memory(res.Required), available(memory))
+
+	for _, name := range slices.Sorted(maps.Keys(res.Required.Accelerators)) {
+		count := func(r apiModels.ResourceAmounts) string { return strconv.Itoa(r.Accelerators[name]) }
+		printer.AppendRow(name, count(res.Required), available(count))
+	}
+	printer.CloseTableWriter()
+}
+
+// printPlanList prints a titled list, or nothing when it is empty.
+func printPlanList(title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+
+	logger.Infof("\n%s:\n", title)
+	for _, item := range items {
+		logger.Infof("  %s\n", item)
+	}
+}
+
+// Made with Bob
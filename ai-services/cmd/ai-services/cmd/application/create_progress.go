@@ -0,0 +1,122 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiModels "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+	catalogClient "github.com/project-ai-services/ai-services/internal/pkg/catalog/client"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+// eventFormats describes, per step and status, how the progress view shows a deployment
+// event; %s is the event's target. Events without a format are not shown: the outcome of
+// the deployment is reported from the application status once it ends.
+var eventFormats = map[apiModels.EventStep]map[apiModels.EventStatus]string{
+	apiModels.EventStepDeployment: {
+		apiModels.EventStatusStarted: "%s",
+	},
+	apiModels.EventStepImagePull: {
+		apiModels.EventStatusStarted:   "Pulling image %s",
+		apiModels.EventStatusCompleted: "Pulled image %s",
+		apiModels.EventStatusFailed:    "Failed to pull image %s",
+	},
+	apiModels.EventStepModelDownload: {
+		apiModels.EventStatusStarted:   "Downloading model %s",
+		apiModels.EventStatusCompleted: "Downloaded model %s",
+		apiModels.EventStatusFailed:    "Failed to download model %s",
+	},
+	apiModels.EventStepPodCreated: {
+		apiModels.EventStatusCompleted: "Created pod %s",
+		apiModels.EventStatusFailed:    "Failed to create pod %s",
+	},
+	apiModels.EventStepReadinessWait: {
+		apiModels.EventStatusStarted:   "Waiting for %s to become ready",
+		apiModels.EventStatusCompleted: "%s is ready",
+		apiModels.EventStatusFailed:    "%s did not become ready",
+	},
+	apiModels.EventStepRouteRegistered: {
+		apiModels.EventStatusCompleted: "Registered route %s",
+	},
+}
+
+// followDeployment shows the deployment events of the application as they happen until
+// the deployment ends, then reports its outcome. It falls back to polling the application
+// status when the server cannot stream events or the stream ends early.
+func followDeployment(appClient *catalogClient.ApplicationClient, appName, id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), pollTimeout)
+	defer cancel()
+
+	finished := false
+	_, err := appClient.FollowApplicationEvents(ctx, id, 0, func(event apiModels.ApplicationEvent) bool {
+		printEvent(event)
+		finished = event.IsFinal()
+
+		return !finished
+	})
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timeout waiting for application '%s' to be ready", appName)
+	}
+	if !finished {
+		if err != nil {
+			logger.Warningf("Deployment progress is unavailable: %v\n", err)
+		}
+
+		return pollApplicationStatus(appClient, appName, id)
+	}
+
+	app, err := appClient.GetApplicationWithRefresh(id)
+	if err != nil {
+		return fmt.Errorf("failed to get application status: %w", err)
+	}
+
+	done, err := handleApplicationStatus(app, appName)
+	if err != nil || done {
+		return err
+	}
+
+	return pollApplicationStatus(appClient, appName, id)
+}
+
+// printEvent prints one line of the progress view for event.
+func printEvent(event apiModels.ApplicationEvent) {
+	line, ok := formatEvent(event)
+	if !ok {
+		return
+	}
+
+	timestamp := event.Time.Local().Format(time.TimeOnly)
+	if event.Status == apiModels.EventStatusFailed {
+		logger.Warningf("%s  %s\n", timestamp, line)
+
+		return
+	}
+	logger.Infof("%s  %s\n", timestamp, line)
+}
+
+// formatEvent describes event for the progress view, or returns false when it is not shown.
+func formatEvent(event apiModels.ApplicationEvent) (string, bool) {
+	format, ok := eventFormats[event.Step][event.Status]
+	if !ok {
+		return "", false
+	}
+
+	target := event.Target
+	if event.Step == apiModels.EventStepDeployment {
+		target = event.Message
+	}
+
+	line := fmt.Sprintf(format, target)
+	if event.Total > 0 {
+		line = fmt.Sprintf("[%d/%d] %s", event.Current, event.Total, line)
+	}
+	if event.Status == apiModels.EventStatusFailed && event.Message != "" {
+		line += ": " + event.Message
+	}
+
+	return line, true
+}
+
+// Made with Bob
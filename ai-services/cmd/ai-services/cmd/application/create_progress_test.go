@@ -0,0 +1,50 @@
+package application
+
+import (
+	"testing"
+
+	apiModels "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+)
+
+func TestFormatEvent(t *testing.T) {
+	tests := []struct {
+		name  string
+		event apiModels.ApplicationEvent
+		want  string
+		shown bool
+	}{
+		{
+			name:  "counted step",
+			event: apiModels.ApplicationEvent{Step: apiModels.EventStepImagePull, Status: apiModels.EventStatusStarted, Target: "quay.io/vllm:1", Current: 2, Total: 3},
+			want:  "[2/3] Pulling image quay.io/vllm:1",
+			shown: true,
+		},
+		{
+			name:  "failed step shows cause",
+			event: apiModels.ApplicationEvent{Step: apiModels.EventStepReadinessWait, Status: apiModels.EventStatusFailed, Target: "chat", Message: "timed out"},
+			want:  "chat did not become ready: timed out",
+			shown: true,
+		},
+		{
+			name:  "deployment start shows message",
+			event: apiModels.ApplicationEvent{Step: apiModels.EventStepDeployment, Status: apiModels.EventStatusStarted, Message: "Deploying application 'rag'"},
+			want:  "Deploying application 'rag'",
+			shown: true,
+		},
+		{
+			name:  "deployment outcome is hidden",
+			event: apiModels.ApplicationEvent{Step: apiModels.EventStepDeployment, Status: apiModels.EventStatusFailed, Message: "boom"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, shown := formatEvent(tt.event)
+			if got != tt.want || shown != tt.shown {
+				t.Errorf("formatEvent() = %q, %v; want %q, %v", got, shown, tt.want, tt.shown)
+			}
+		})
+	}
+}
+
+// Made with Bob
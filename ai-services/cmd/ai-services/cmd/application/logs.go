@@ -2,6 +2,7 @@ package application
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/project-ai-services/ai-services/internal/pkg/application"
@@ -19,6 +20,9 @@ var (
 	podName           string
 	containerNameOrID string
 	legacyLogs        bool
+	followLogs        bool
+	tailLines         int
+	logsSince         time.Duration
 )
 
 var logsCmd = &cobra.Command{
@@ -35,6 +39,9 @@ Arguments:
   # Display logs from a specific container in a pod
   ai-services application logs rag --pod mypod --container mycontainer --runtime podman
 
+  # Display the last 100 lines of the past hour without following
+  ai-services application logs rag --pod mypod --follow=false --tail 100 --since 1h --runtime podman
+
   # Display logs using legacy implementation
   ai-services application logs rag --pod mypod --legacy --runtime podman
 
@@ -58,6 +65,10 @@ Arguments:
 			return fmt.Errorf("pod name must be specified using --pod flag")
 		}
 
+		if tailLines < 0 || logsSince < 0 {
+			return fmt.Errorf("--%s and --%s must not be negative", appFlags.Logs.Tail, appFlags.Logs.Since)
+		}
+
 		return nil
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -96,6 +107,9 @@ Arguments:
 		opts := appTypes.LogsOptions{
 			PodName:           podName,
 			ContainerNameOrID: containerNameOrID,
+			Follow:            followLogs,
+			Tail:              tailLines,
+			Since:             logsSince,
 		}
 
 		return app.Logs(opts)
@@ -110,6 +124,9 @@ func initLogsCommonFlags() {
 	logsCmd.Flags().BoolVar(&legacyLogs, appFlags.Logs.Legacy, false, "Use legacy application logs implementation")
 	logsCmd.Flags().StringVar(&podName, appFlags.Logs.Pod, "", "Pod name to show logs from (required)")
 	logsCmd.Flags().StringVar(&containerNameOrID, appFlags.Logs.Container, "", "Container logs to show logs from (Optional)")
+	logsCmd.Flags().BoolVarP(&followLogs, appFlags.Logs.Follow, "f", true, "Keep streaming new log lines until interrupted")
+	logsCmd.Flags().IntVar(&tailLines, appFlags.Logs.Tail, 0, "Number of lines to show from the end of the logs (0 shows all)")
+	logsCmd.Flags().DurationVar(&logsSince, appFlags.Logs.Since, 0, "Only show logs newer than a relative duration like 30s or 1h")
 	_ = logsCmd.MarkFlagRequired(appFlags.Logs.Pod)
 }
 
@@ -123,7 +140,10 @@ func buildLogsFlagValidator() *flagvalidator.FlagValidator {
 	builder.
 		AddCommonFlag(appFlags.Logs.Pod, nil).
 		AddCommonFlag(appFlags.Logs.Container, nil).
-		AddCommonFlag(appFlags.Logs.Legacy, nil)
+		AddCommonFlag(appFlags.Logs.Legacy, nil).
+		AddCommonFlag(appFlags.Logs.Follow, nil).
+		AddCommonFlag(appFlags.Logs.Tail, nil).
+		AddCommonFlag(appFlags.Logs.Since, nil)
 
 	return builder.Build()
 }
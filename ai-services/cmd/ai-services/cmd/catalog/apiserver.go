@@ -9,26 +9,42 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/catalog/common"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver"
 	apirepository "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository"
+	apikeysvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/apikey"
+	auditsvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/audit"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/auth"
 	bundlesvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/bundle"
+	connectorsvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/connector"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/jobs"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/scheduler"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/sync"
+	usersvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/user"
+	webhooksvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/webhook"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/connectors"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/constants"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/miq"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/oidc"
+	catalogutils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 	"github.com/project-ai-services/ai-services/internal/pkg/utils"
 	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+	"github.com/project-ai-services/ai-services/internal/pkg/worker/pki"
 	workerregistry "github.com/project-ai-services/ai-services/internal/pkg/worker/registry"
 	"github.com/spf13/cobra"
 )
 
-const defaultRandomSecretKeyLength = 32
+// oidcOptions are the --oidc-* flags of the apiserver command.
+type oidcOptions struct {
+	config     oidc.Config
+	groupRoles map[string]string
+}
 
 // loadDBConfig loads database configuration from environment variables.
 func loadDBConfig() (db.Config, error) {
@@ -54,26 +70,14 @@ func loadDBConfig() (db.Config, error) {
 	return dbConfig, nil
 }
 
-// getOrGenerateSecretKey retrieves the JWT secret key from environment or generates a random one.
-func getOrGenerateSecretKey() (string, error) {
-	secretKey := os.Getenv("AUTH_JWT_SECRET")
-	if len(secretKey) == 0 {
-		logger.DebuglnCtx(context.Background(), "** WARNING: AUTH_JWT_SECRET environment variable not set. This is not recommended for production use. **")
-		byteSecretKey, err := auth.GenerateRandomSecretKey(defaultRandomSecretKeyLength)
-		if err != nil {
-			return "", err
-		}
-		secretKey = string(byteSecretKey)
-	}
-
-	return secretKey, nil
-}
-
 // buildAPIServerOptions wires all service dependencies and returns the options
 // needed to start the API server. pool.Close() and the returned cleanup func
 // must be called by the caller.
-func buildAPIServerOptions(ctx context.Context, pool *pgxpool.Pool, secretKey, adminUser, adminPassHash string, accessTTL, refreshTTL time.Duration, workerGatewayPort int, manageiqURL string, manageiqInsecure bool) (apiserver.APIServerOptions, func(), error) {
-	userRepo := apirepository.NewInMemoryUserRepoWithAdminHash("uid_1", adminUser, "Admin", adminPassHash)
+func buildAPIServerOptions(ctx context.Context, pool *pgxpool.Pool, legacySecret, adminUser, adminPassHash string, accessTTL, refreshTTL time.Duration, workerGatewayPort int, workerGatewayHosts []string, manageiqURL string, manageiqInsecure bool, manageiqGroupRoles map[string]string, oidcOpts oidcOptions) (apiserver.APIServerOptions, func(), error) {
+	userRepo, err := newUserRepo(ctx, pool, adminUser, adminPassHash)
+	if err != nil {
+		return apiserver.APIServerOptions{}, nil, err
+	}
 	tokenBlacklistRepo := repository.NewTokenBlacklistRepository(pool)
 	blacklist := apirepository.NewDBTokenBlacklist(tokenBlacklistRepo)
 
@@ -84,12 +88,25 @@ func buildAPIServerOptions(ctx context.Context, pool *pgxpool.Pool, secretKey, a
 	compRepo := repository.NewComponentRepository(pool)
 	svcDepRepo := repository.NewServiceDependencyRepository(pool)
 
+	// Connector credentials, webhook secrets, JWT signing keys and the worker CA key are
+	// protected with the same keys.
+	encryptionKeys, err := catalogutils.KeyringFromEnv()
+	if err != nil {
+		return apiserver.APIServerOptions{}, nil, err
+	}
+
+	// Webhooks are notified of application status changes, finished deployments and
+	// disconnected workers.
+	webhookRepo := repository.NewWebhookRepository(pool)
+	webhooks := webhooksvc.NewDispatcher(webhookRepo, encryptionKeys)
+
 	// Initialize sync service for background DB-Pod synchronization
 	// TODO: implement sync service on remote machines
 	syncService, err := sync.NewSyncService(appRepo, svcRepo, compRepo, svcDepRepo, sync.DefaultSyncInterval)
 	if err != nil {
 		return apiserver.APIServerOptions{}, nil, fmt.Errorf("failed to initialize sync service: %w", err)
 	}
+	syncService.SetNotifier(webhooks)
 	syncService.Start(ctx)
 
 	catalogProvider, err := catalog.NewCatalogProvider()
@@ -99,45 +116,160 @@ func buildAPIServerOptions(ctx context.Context, pool *pgxpool.Pool, secretKey, a
 		return apiserver.APIServerOptions{}, nil, fmt.Errorf("failed to initialize catalog provider: %w", err)
 	}
 
-	tokenMgr := auth.NewTokenManager(secretKey, accessTTL, refreshTTL)
+	tokenMgr, err := newTokenManager(ctx, pool, encryptionKeys, legacySecret, accessTTL, refreshTTL)
+	if err != nil {
+		syncService.Stop(ctx)
+
+		return apiserver.APIServerOptions{}, nil, err
+	}
 	workerRepo := repository.NewWorkerRepository(pool)
-	workerReg := workerregistry.New(workerRepo)
+	workerReg := workerregistry.New(workerRepo, repository.NewWorkerTokenRepository(pool))
+	// Let runtimes created for a worker name reach it through the gateway command stream,
+	// and resolve the worker an application was placed on by its ID.
+	vars.RuntimeFactory.SetWorkerDispatcher(workerReg)
+	vars.RuntimeFactory.SetWorkerResolver(workerReg)
+	workerReg.SetDisconnectHook(func(ctx context.Context, workerID uuid.UUID, workerName string) {
+		webhooks.Notify(ctx, webhooksvc.EventWorkerDisconnected, webhooksvc.WorkerEventData{WorkerID: workerID, WorkerName: workerName})
+	})
+
+	// The worker CA is shared by all replicas through the database.
+	workerCA, err := pki.LoadOrCreate(ctx, repository.NewWorkerCARepository(pool), encryptionKeys)
+	if err != nil {
+		syncService.Stop(ctx)
+
+		return apiserver.APIServerOptions{}, nil, fmt.Errorf("failed to initialize worker certificate authority: %w", err)
+	}
 
 	var authSvc auth.Service
 	if manageiqURL != "" {
 		logger.Infof("ManageIQ integration enabled: %s (insecure TLS: %v)\n", manageiqURL, manageiqInsecure)
 		miqClient := miq.NewHTTPClient(manageiqURL, manageiqInsecure)
-		authSvc = auth.NewAuthServiceWithMIQ(userRepo, tokenMgr, blacklist, miqClient)
+		var groupRoles auth.GroupRoles
+		if len(manageiqGroupRoles) > 0 {
+			groupRoles, err = auth.ParseGroupRoles(manageiqGroupRoles)
+			if err != nil {
+				syncService.Stop(ctx)
+
+				return apiserver.APIServerOptions{}, nil, fmt.Errorf("invalid --manageiq-group-roles: %w", err)
+			}
+		}
+		authSvc = auth.NewAuthServiceWithMIQ(userRepo, tokenMgr, blacklist, miqClient, groupRoles)
 	} else {
 		logger.Infoln("Using the default auth service")
 		authSvc = auth.NewAuthService(userRepo, tokenMgr, blacklist)
 	}
 
+	oidcSvc, err := newOIDCService(ctx, userRepo, tokenMgr, oidcOpts)
+	if err != nil {
+		syncService.Stop(ctx)
+
+		return apiserver.APIServerOptions{}, nil, err
+	}
+
+	// Deployments and deletions run as jobs. Claiming the jobs the previous shutdown
+	// interrupted starts once the application service registered its job handlers.
+	jobRunner := jobs.NewRunner(repository.NewJobRepository(pool), jobs.DefaultLeaseDuration)
+	appService := apirepository.NewApplicationService(appRepo, svcRepo, compRepo, svcDepRepo, catalogProvider, scheduler.New(workerReg, scheduler.RuntimeProber), jobRunner, webhooks, vars.RuntimeFactory.GetRuntimeType())
+	jobRunner.Start(ctx)
+	webhooks.Start(ctx)
+
 	opts := apiserver.APIServerOptions{
 		Port:               0, // set by caller
 		AuthService:        authSvc,
+		OIDCService:        oidcSvc,
 		TokenManager:       tokenMgr,
 		Blacklist:          blacklist,
-		ApplicationService: apirepository.NewApplicationService(appRepo, svcRepo, compRepo, svcDepRepo, catalogProvider, vars.RuntimeFactory.GetRuntimeType()),
+		ApplicationService: appService,
 		BundleService:      bundlesvc.NewBundleService(bundleRepo),
+		UserService:        usersvc.NewUserService(userRepo),
+		APIKeyService:      apikeysvc.NewAPIKeyService(repository.NewAPIKeyRepository(pool)),
+		AuditService:       auditsvc.NewAuditService(repository.NewAuditRepository(pool)),
+		ConnectorService:   connectorsvc.NewConnectorService(repository.NewConnectorRepository(pool), svcDepRepo, catalogProvider, connectors.DefaultCheckers(), encryptionKeys),
+		WebhookService:     webhooksvc.NewWebhookService(webhookRepo, encryptionKeys, webhooks),
 		WorkerGatewayPort:  workerGatewayPort,
 		WorkerRegistry:     workerReg,
+		WorkerCA:           workerCA,
+		WorkerGatewayHosts: workerGatewayHosts,
 	}
 	cleanup := func() {
 		blacklist.Stop()
 		syncService.Stop(ctx)
+		jobRunner.Stop(ctx)
+		webhooks.Stop(ctx)
 	}
 
 	return opts, cleanup, nil
 }
 
-// runAPIServer initializes and starts the API server with the provided configuration.
-func runAPIServer(port int, accessTTL, refreshTTL time.Duration, adminUser, adminPassHash string, workerGatewayPort int, manageiqURL string, manageiqInsecure bool) error {
-	secretKey, err := getOrGenerateSecretKey()
+// newUserRepo returns the database-backed user repository, seeding the admin user
+// from --admin-username and --admin-password-hash when a hash is given.
+func newUserRepo(ctx context.Context, pool *pgxpool.Pool, adminUser, adminPassHash string) (apirepository.UserRepository, error) {
+	users := repository.NewUserRepository(pool)
+	if adminPassHash != "" {
+		if err := users.EnsureBootstrapAdmin(ctx, adminUser, adminPassHash); err != nil {
+			return nil, err
+		}
+	}
+
+	return apirepository.NewDBUserRepo(users), nil
+}
+
+// newTokenManager returns a token manager that signs with the signing keys stored in
+// the database, generating the first key on first start. HS256 tokens signed with
+// legacySecret (AUTH_JWT_SECRET) by earlier versions are accepted if they were issued
+// before the first key, for one refresh token TTL after it.
+func newTokenManager(ctx context.Context, pool *pgxpool.Pool, encryptionKeys *catalogutils.Keyring, legacySecret string, accessTTL, refreshTTL time.Duration) (*auth.TokenManager, error) {
+	source, err := auth.NewDBKeySource(repository.NewSigningKeyRepository(pool), encryptionKeys)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if err := source.EnsureSigningKey(ctx, auth.SigningAlgRS256); err != nil {
+		return nil, fmt.Errorf("failed to initialize JWT signing key: %w", err)
+	}
+	keys, err := auth.NewKeySet(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+	createdAt, err := source.CreatedAt(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if legacySecret != "" && auth.LegacySecretExpired(createdAt, refreshTTL) {
+		logger.Warningf("AUTH_JWT_SECRET is set but no longer used: HS256 tokens were only accepted until %s. Remove it from the server environment.\n", createdAt.Add(refreshTTL).Format(time.RFC3339))
+		legacySecret = ""
+	}
+
+	return auth.NewKeySetTokenManager(keys, legacySecret, createdAt, accessTTL, refreshTTL), nil
+}
+
+// newOIDCService discovers the OIDC provider configured with the --oidc-* flags.
+// It returns nil when --oidc-issuer-url is not set.
+func newOIDCService(ctx context.Context, users apirepository.UserRepository, tokenMgr *auth.TokenManager, opts oidcOptions) (auth.OIDCService, error) {
+	if opts.config.IssuerURL == "" {
+		return nil, nil
+	}
+	if opts.config.ClientID == "" || opts.config.RedirectURL == "" {
+		return nil, fmt.Errorf("--oidc-client-id and --oidc-redirect-url are required with --oidc-issuer-url")
+	}
+	if opts.config.ClientSecret == "" {
+		opts.config.ClientSecret = os.Getenv("AUTH_OIDC_CLIENT_SECRET")
 	}
+	groupRoles, err := auth.ParseGroupRoles(opts.groupRoles)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --oidc-group-roles: %w", err)
+	}
+
+	provider, err := oidc.Discover(ctx, opts.config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize OIDC provider: %w", err)
+	}
+	logger.Infof("OIDC login enabled: %s (device login: %v)\n", opts.config.IssuerURL, provider.SupportsDeviceFlow())
+
+	return auth.NewOIDCService(users, tokenMgr, provider, groupRoles), nil
+}
 
+// runAPIServer initializes and starts the API server with the provided configuration.
+func runAPIServer(port int, accessTTL, refreshTTL time.Duration, adminUser, adminPassHash string, workerGatewayPort int, workerGatewayHosts []string, manageiqURL string, manageiqInsecure bool, manageiqGroupRoles map[string]string, oidcOpts oidcOptions) error {
 	dbConfig, err := loadDBConfig()
 	if err != nil {
 		return err
@@ -155,7 +287,7 @@ func runAPIServer(port int, accessTTL, refreshTTL time.Duration, adminUser, admi
 	defer pool.Close()
 	logger.Infoln("Connected to database successfully")
 
-	opts, cleanup, err := buildAPIServerOptions(ctx, pool, secretKey, adminUser, adminPassHash, accessTTL, refreshTTL, workerGatewayPort, manageiqURL, manageiqInsecure)
+	opts, cleanup, err := buildAPIServerOptions(ctx, pool, os.Getenv("AUTH_JWT_SECRET"), adminUser, adminPassHash, accessTTL, refreshTTL, workerGatewayPort, workerGatewayHosts, manageiqURL, manageiqInsecure, manageiqGroupRoles, oidcOpts)
 	if err != nil {
 		return err
 	}
@@ -166,6 +298,17 @@ func runAPIServer(port int, accessTTL, refreshTTL time.Duration, adminUser, admi
 	return apiserver.NewAPIserver(opts).Start(ctx)
 }
 
+// defaultWorkerGatewayHosts returns the names a worker on the same host can use to
+// reach the gateway.
+func defaultWorkerGatewayHosts() []string {
+	hosts := []string{"localhost", "127.0.0.1"}
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		hosts = append([]string{hostname}, hosts...)
+	}
+
+	return hosts
+}
+
 func NewAPIServerCmd() *cobra.Command {
 	var (
 		port = 8080
@@ -176,8 +319,11 @@ func NewAPIServerCmd() *cobra.Command {
 		adminPasswordHash      string
 		manageiqURL            string
 		manageiqInsecure       bool
+		manageiqGroupRoles     map[string]string
+		oidcOpts               oidcOptions
 		runtimeType            string
 		workerGatewayPort      int
+		workerGatewayHosts     []string
 	)
 
 	apiserverCmd := &cobra.Command{
@@ -196,17 +342,27 @@ func NewAPIServerCmd() *cobra.Command {
 	 # Start with custom token TTL settings
 	 ai-services catalog apiserver --access-token-ttl 30m --refresh-token-ttl 48h --admin-password-hash <PASSWORD_HASH> --runtime podman
 
+	 # Start with login through an OpenID Connect provider
+	 AUTH_OIDC_CLIENT_SECRET=<SECRET> ai-services catalog apiserver --admin-password-hash <PASSWORD_HASH> --runtime podman \
+	   --oidc-issuer-url https://idp.example.com/realms/ai --oidc-client-id catalog \
+	   --oidc-redirect-url https://catalog.example.com/api/v1/auth/oidc/callback --oidc-group-roles ai-admins=admin
+
 	 # Start with all custom settings
 	 ai-services catalog apiserver --port 9090 --admin-username myadmin --admin-password-hash <PASSWORD_HASH> --access-token-ttl 30m --refresh-token-ttl 48h --runtime podman
 
 Note:
   - Requires database connection via environment variables (DB_HOST, DB_PORT, DB_USER, DB_PASSWORD, DB_NAME)
-  - AUTH_JWT_SECRET environment variable is recommended for production use`,
+  - DB_ENCRYPTION_KEY environment variable is required; it protects connector credentials, the JWT signing keys and the worker gateway CA key.
+    DB_ENCRYPTION_PREVIOUS_KEYS, if set, lists comma-separated keys that still decrypt values written before
+    the last key rotation (see: ai-services catalog rotate-encryption-key)
+  - Tokens are signed with an RS256 key generated on first start (see: ai-services catalog rotate-signing-key);
+    AUTH_JWT_SECRET, if set, keeps HS256 tokens issued by earlier versions valid for one
+    refresh token TTL after the first signing key was generated; remove it afterwards`,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			return common.InitAndValidateRuntimeFlag(runtimeType)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runAPIServer(port, defaultAccessTokenTTL, defaultRefreshTokenTTL, adminUserName, adminPasswordHash, workerGatewayPort, manageiqURL, manageiqInsecure)
+			return runAPIServer(port, defaultAccessTokenTTL, defaultRefreshTokenTTL, adminUserName, adminPasswordHash, workerGatewayPort, workerGatewayHosts, manageiqURL, manageiqInsecure, manageiqGroupRoles, oidcOpts)
 		},
 	}
 
@@ -214,14 +370,33 @@ Note:
 	apiserverCmd.Flags().DurationVarP(&defaultAccessTokenTTL, "access-token-ttl", "", defaultAccessTokenTTL, "Time-to-live for access tokens")
 	apiserverCmd.Flags().DurationVarP(&defaultRefreshTokenTTL, "refresh-token-ttl", "", defaultRefreshTokenTTL, "Time-to-live for refresh tokens")
 	apiserverCmd.Flags().StringVar(&adminUserName, "admin-username", "admin", "Username for the default admin user")
-	apiserverCmd.Flags().StringVar(&adminPasswordHash, "admin-password-hash", "", "Precomputed hash of the password for the default admin user")
+	apiserverCmd.Flags().StringVar(&adminPasswordHash, "admin-password-hash", "", "Precomputed hash of the password for the default admin user; "+
+		"the user is created on first start and its password reset whenever the hash changes")
 	apiserverCmd.Flags().IntVar(&workerGatewayPort, "workergateway-port", defaultWorkerGatewayPort, "Port for the gRPC worker gateway (always active, default 9090)")
+	apiserverCmd.Flags().StringSliceVar(&workerGatewayHosts, "workergateway-hosts", defaultWorkerGatewayHosts(), "Host names and IPs workers use to reach the gateway; added to the gateway TLS certificate")
 	apiserverCmd.Flags().StringVar(&manageiqURL, "manageiq-url", "", "ManageIQ base URL for AuthN/AuthZ, e.g. https://9.20.202.144:8443")
 	apiserverCmd.Flags().BoolVar(&manageiqInsecure, "manageiq-insecure-tls", false, "Skip TLS verification for ManageIQ (self-signed certs)")
+	apiserverCmd.Flags().StringToStringVar(&manageiqGroupRoles, "manageiq-group-roles", nil,
+		"Catalog role (admin, operator, viewer) granted to members of a ManageIQ group, as group=role (repeatable). "+
+			"Replaces the default mapping of the EvmGroup-super_administrator, EvmGroup-administrator and EvmGroup-operator groups")
+	addOIDCFlags(apiserverCmd, &oidcOpts)
 	// Hide the ManageIQ flags
 	_ = apiserverCmd.Flags().MarkHidden("manageiq-url")
 	_ = apiserverCmd.Flags().MarkHidden("manageiq-insecure-tls")
+	_ = apiserverCmd.Flags().MarkHidden("manageiq-group-roles")
 	common.ConfigureRuntimeFlag(apiserverCmd, &runtimeType)
 
 	return apiserverCmd
 }
+
+func addOIDCFlags(cmd *cobra.Command, opts *oidcOptions) {
+	cmd.Flags().StringVar(&opts.config.IssuerURL, "oidc-issuer-url", "", "Issuer URL of an OpenID Connect provider; enables login through /api/v1/auth/oidc and 'catalog login --oidc'")
+	cmd.Flags().StringVar(&opts.config.ClientID, "oidc-client-id", "", "OIDC client ID of the catalog server")
+	cmd.Flags().StringVar(&opts.config.ClientSecret, "oidc-client-secret", "", "OIDC client secret; defaults to the AUTH_OIDC_CLIENT_SECRET environment variable, leave both empty for a public client")
+	cmd.Flags().StringVar(&opts.config.RedirectURL, "oidc-redirect-url", "", "Callback URL registered with the OIDC provider, e.g. https://catalog.example.com/api/v1/auth/oidc/callback")
+	cmd.Flags().StringSliceVar(&opts.config.Scopes, "oidc-scopes", oidc.DefaultScopes, "Scopes requested from the OIDC provider")
+	cmd.Flags().StringVar(&opts.config.UsernameClaim, "oidc-username-claim", oidc.DefaultUsernameClaim, "ID token claim used as the catalog username")
+	cmd.Flags().StringVar(&opts.config.GroupsClaim, "oidc-groups-claim", oidc.DefaultGroupsClaim, "ID token claim listing the user's groups")
+	cmd.Flags().StringToStringVar(&opts.groupRoles, "oidc-group-roles", nil,
+		"Catalog role (admin, operator, viewer) granted to members of an OIDC group, as group=role (repeatable). Users in no mapped group are viewers")
+}
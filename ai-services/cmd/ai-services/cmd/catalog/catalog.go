@@ -21,6 +21,10 @@ deploy them, and handle service metadata`,
 	catalogCMD.AddCommand(NewLoginCmd())
 	catalogCMD.AddCommand(NewLogoutCmd())
 	catalogCMD.AddCommand(NewWhoamiCmd())
+	catalogCMD.AddCommand(NewUserCmd())
+	catalogCMD.AddCommand(NewAPIKeyCmd())
+	catalogCMD.AddCommand(NewRotateSigningKeyCmd())
+	catalogCMD.AddCommand(NewRotateEncryptionKeyCmd())
 	catalogCMD.AddCommand(NewMigrateCmd())
 	catalogCMD.AddCommand(NewInfoCmd())
 
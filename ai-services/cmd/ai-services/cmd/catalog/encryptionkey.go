@@ -0,0 +1,138 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/catalog/common"
+	catalogconstants "github.com/project-ai-services/ai-services/internal/pkg/catalog/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	catalogutils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+const (
+	// encryptionKeySecretKey holds DB_ENCRYPTION_KEY in the encryption secret.
+	encryptionKeySecretKey = "db-encryption-key"
+	// previousEncryptionKeysSecretKey holds DB_ENCRYPTION_PREVIOUS_KEYS in the encryption secret.
+	previousEncryptionKeysSecretKey = "db-encryption-previous-keys"
+)
+
+// NewRotateEncryptionKeyCmd returns the cobra command that rotates the DB encryption key.
+func NewRotateEncryptionKeyCmd() *cobra.Command {
+	var runtimeType string
+
+	cmd := &cobra.Command{
+		Use:   "rotate-encryption-key",
+		Short: "Rotate the key that encrypts secrets stored in the catalog database",
+		Long: `Generate a new DB encryption key and re-encrypt connector credentials, the JWT signing
+keys and the worker CA key with it.
+
+Every row is re-encrypted in a single transaction, and the catalog-db-encryption-secret
+is updated before it commits. The replaced key is kept in the secret as a previous key,
+so values that running API servers write with it before they restart stay readable.
+
+The command connects to the catalog database directly, so run it where the API server
+runs, with the same DB_* and DB_ENCRYPTION_* environment variables. Restart the API
+server afterwards to encrypt new values with the new key.`,
+		Example: `  # Rotate the key of a catalog running on podman
+  podman exec ai-services--catalog-backend ai-services catalog rotate-encryption-key --runtime podman
+
+  # Rotate the key of a catalog running on OpenShift
+  oc exec -n ai-services deploy/catalog-backend -- ai-services catalog rotate-encryption-key --runtime openshift`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return common.InitAndValidateRuntimeFlag(runtimeType)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			return runRotateEncryptionKey(cmd.Context())
+		},
+	}
+
+	common.ConfigureRuntimeFlag(cmd, &runtimeType)
+
+	return cmd
+}
+
+func runRotateEncryptionKey(ctx context.Context) error {
+	current := os.Getenv(catalogutils.EncryptionKeyEnv)
+	if current == "" {
+		return fmt.Errorf("%s environment variable is required", catalogutils.EncryptionKeyEnv)
+	}
+	newKey, err := catalogutils.GenerateEncryptionKey()
+	if err != nil {
+		return err
+	}
+	// The new keyring decrypts everything the current one does.
+	previous := append([]string{current}, strings.Split(os.Getenv(catalogutils.PreviousEncryptionKeysEnv), ",")...)
+	keys, err := catalogutils.NewKeyring(newKey, previous...)
+	if err != nil {
+		return err
+	}
+
+	rt, err := vars.RuntimeFactory.Create(catalogconstants.CatalogAppName)
+	if err != nil {
+		return fmt.Errorf("failed to create runtime client: %w", err)
+	}
+
+	dbConfig, err := loadDBConfig()
+	if err != nil {
+		return err
+	}
+	pool, err := db.ConnectPool(ctx, dbConfig)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer pool.Close()
+
+	result, err := repository.NewEncryptedSecretRepository(pool).Reencrypt(ctx, repository.Reencryption{
+		Value:    keys.Reencrypt,
+		Metadata: keys.ReencryptMetadata,
+		BeforeCommit: func(context.Context) error {
+			// Every row now uses the new key, so only the replaced key is kept for
+			// API servers that write with it until they are restarted.
+			data := map[string][]byte{
+				encryptionKeySecretKey:          []byte(newKey),
+				previousEncryptionKeysSecretKey: []byte(current),
+			}
+			if err := rt.UpdateSecret(catalogconstants.CatalogConnectorSecretName, "", data); err != nil {
+				return fmt.Errorf("failed to update %s: %w", catalogconstants.CatalogConnectorSecretName, err)
+			}
+
+			return nil
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to rotate encryption key: %w", err)
+	}
+
+	logger.Infof("New encryption key ID: %s\n", keys.KeyID())
+	logger.Infof("Re-encrypted %d connector(s), %d signing key(s) and %d worker CA key(s)\n", result.Connectors, result.SigningKeys, result.WorkerCAs)
+	logRestartHint(rt.Type())
+
+	return nil
+}
+
+// logRestartHint tells how to restart the API server so it encrypts with the new key.
+func logRestartHint(rt types.RuntimeType) {
+	switch rt {
+	case types.RuntimeTypeOpenShift:
+		logger.Infof("Restart the API server to use the new key:\n  oc rollout restart deployment/%s -n %s\n",
+			catalogconstants.CatalogDeploymentName, catalogconstants.CatalogAppName)
+	default:
+		// Podman writes secrets into the pod when it is played, not when it restarts.
+		logger.Infof("Recreate the catalog pod on the host to use the new key, passing the options it was configured with:\n"+
+			"  podman pod rm -f %s--catalog && ai-services catalog configure --runtime podman\n", catalogconstants.CatalogAppName)
+	}
+}
+
+// Made with Bob
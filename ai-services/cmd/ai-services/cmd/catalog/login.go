@@ -23,6 +23,8 @@ func NewLoginCmd() *cobra.Command {
 		username      string
 		passwordStdin bool
 		miqToken      string
+		apiKeyStdin   bool
+		useOIDC       bool
 		insecure      bool
 		runtimeType   string
 	)
@@ -30,11 +32,14 @@ func NewLoginCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "login",
 		Short: "Log in to the catalog API server",
-		Long: `Authenticate with the catalog API server using a username and password.
+		Long: `Authenticate with the catalog API server using a username and password,
+with an API key read from stdin (see: ai-services catalog apikey create), or through
+the server's OpenID Connect provider with --oidc. OIDC login prints a URL and a code
+to enter in a browser, on this or any other machine, and waits until sign-in completes.
 
 The generated access and refresh tokens are stored in the OS user config directory
 and are used automatically by subsequent catalog commands. The exact path is
-printed after a successful login.
+printed after a successful login. A stored API key is sent as is and never refreshed.
 
 The stored access token is reused for subsequent commands as long as it is still
 valid. It is refreshed automatically only when it is about to expire, avoiding
@@ -48,15 +53,27 @@ To get the Catalog backend endpoint, use: ai-services catalog info`,
   echo "$MY_PASSWORD" | ai-services catalog login --server <catalog_backend_endpoint> --username admin --password-stdin --runtime podman
 
    # Login with insecure TLS (skip certificate verification)
-  ai-services catalog login --server <catalog_backend_endpoint> --username admin --insecure --runtime podman`,
+  ai-services catalog login --server <catalog_backend_endpoint> --username admin --insecure --runtime podman
+
+  # Login with an API key, e.g. from a CI secret
+  echo "$CATALOG_API_KEY" | ai-services catalog login --server <catalog_backend_endpoint> --api-key-stdin --runtime podman
+
+  # Login through the server's OpenID Connect provider
+  ai-services catalog login --server <catalog_backend_endpoint> --oidc --runtime podman`,
 
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			return validateLoginFlags(runtimeType, serverURL, username, miqToken, passwordStdin)
+			return validateLoginFlags(runtimeType, serverURL, username, miqToken, passwordStdin, apiKeyStdin, useOIDC)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if miqToken != "" {
 				return runLoginWithMIQToken(serverURL, miqToken, insecure)
 			}
+			if apiKeyStdin {
+				return runLoginWithAPIKey(serverURL, insecure)
+			}
+			if useOIDC {
+				return runLoginWithOIDC(serverURL, insecure)
+			}
 
 			return runLogin(serverURL, username, passwordStdin, insecure)
 		},
@@ -67,6 +84,8 @@ To get the Catalog backend endpoint, use: ai-services catalog info`,
 	cmd.Flags().BoolVar(&passwordStdin, "password-stdin", false, "Read password from stdin instead of an interactive prompt")
 	cmd.Flags().StringVar(&miqToken, "miq-token", "", "ManageIQ token for token passthrough login")
 	_ = cmd.Flags().MarkHidden("miq-token")
+	cmd.Flags().BoolVar(&apiKeyStdin, "api-key-stdin", false, "Read an API key from stdin and log in with it")
+	cmd.Flags().BoolVar(&useOIDC, "oidc", false, "Log in through the server's OpenID Connect provider with a device code")
 	cmd.Flags().BoolVar(&insecure, "insecure", false, "Skip TLS certificate verification (NOT for production use)")
 	common.ConfigureRuntimeFlag(cmd, &runtimeType)
 
@@ -92,6 +111,59 @@ func runLoginWithMIQToken(serverURL, miqToken string, insecure bool) error {
 	return nil
 }
 
+// runLoginWithAPIKey reads an API key from stdin and stores it after checking it with the server.
+func runLoginWithAPIKey(serverURL string, insecure bool) error {
+	scanner := bufio.NewScanner(os.Stdin)
+	var apiKey string
+	if scanner.Scan() {
+		apiKey = strings.TrimSpace(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read API key from stdin: %w", err)
+	}
+	if apiKey == "" {
+		return fmt.Errorf("API key must not be empty")
+	}
+
+	if insecure {
+		logger.Warningln("WARNING: TLS certificate verification is disabled. This should NOT be used in production environments.")
+	}
+
+	logger.Infof("Logging in to %s using an API key...\n", serverURL)
+
+	if _, err := client.NewWithAPIKey(serverURL, apiKey, insecure); err != nil {
+		return fmt.Errorf("login failed: %w", err)
+	}
+
+	logger.Infoln("Login successful.")
+
+	return nil
+}
+
+// runLoginWithOIDC signs in through the server's OIDC provider with the device authorization grant.
+func runLoginWithOIDC(serverURL string, insecure bool) error {
+	if insecure {
+		logger.Warningln("WARNING: TLS certificate verification is disabled. This should NOT be used in production environments.")
+	}
+
+	logger.Infof("Logging in to %s using OIDC...\n", serverURL)
+
+	prompt := func(device client.DeviceAuthorization) {
+		logger.Infof("To sign in, open %s and enter the code %s\n", device.VerificationURI, device.UserCode)
+		if device.VerificationURIComplete != "" {
+			logger.Infof("or open %s\n", device.VerificationURIComplete)
+		}
+		logger.Infoln("Waiting for sign-in to complete...")
+	}
+	if _, err := client.NewWithOIDCDevice(serverURL, insecure, prompt); err != nil {
+		return fmt.Errorf("login failed: %w", err)
+	}
+
+	logger.Infoln("Login successful.")
+
+	return nil
+}
+
 // runLogin executes Flow A: authenticate with username and password.
 func runLogin(serverURL, username string, passwordStdin, insecure bool) error {
 	password, err := promptPassword(passwordStdin)
@@ -145,7 +217,7 @@ func promptPassword(passwordStdin bool) (string, error) {
 }
 
 // validateLoginFlags validates all PreRunE checks for the login command.
-func validateLoginFlags(runtimeType, serverURL, username, miqToken string, passwordStdin bool) error {
+func validateLoginFlags(runtimeType, serverURL, username, miqToken string, passwordStdin, apiKeyStdin, useOIDC bool) error {
 	if err := common.InitAndValidateRuntimeFlag(runtimeType); err != nil {
 		return err
 	}
@@ -153,11 +225,17 @@ func validateLoginFlags(runtimeType, serverURL, username, miqToken string, passw
 		return err
 	}
 	// Exactly one auth method must be provided.
-	if miqToken == "" && username == "" {
-		return fmt.Errorf("one of --username or --miq-token is required")
+	methods := 0
+	for _, used := range []bool{username != "" || passwordStdin, apiKeyStdin, miqToken != "", useOIDC} {
+		if used {
+			methods++
+		}
+	}
+	if methods != 1 {
+		return fmt.Errorf("exactly one of --username, --api-key-stdin, --oidc or --miq-token is required")
 	}
-	if miqToken != "" && (username != "" || passwordStdin) {
-		return fmt.Errorf("--miq-token cannot be used with --username or --password-stdin")
+	if passwordStdin && username == "" {
+		return fmt.Errorf("--password-stdin requires --username")
 	}
 
 	return nil
@@ -0,0 +1,91 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/auth"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	catalogutils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+// defaultSigningKeyRetireAfter matches the default refresh token TTL of the API server.
+const defaultSigningKeyRetireAfter = 24 * time.Hour
+
+// NewRotateSigningKeyCmd returns the cobra command that rotates the JWT signing key.
+func NewRotateSigningKeyCmd() *cobra.Command {
+	var (
+		algorithm   string
+		retireAfter time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "rotate-signing-key",
+		Short: "Rotate the key that signs catalog API tokens",
+		Long: `Generate a new key that signs catalog API access and refresh tokens from now on.
+
+The previous keys keep verifying tokens, and stay published at /.well-known/jwks.json,
+for --retire-after, so signed-in users are not logged out. Set it to at least the
+--refresh-token-ttl of the API server. Keys retired earlier are deleted.
+
+API server replicas pick up the new key within a minute. The command connects to the
+catalog database directly, so run it where the API server runs, with the same
+DB_* and DB_ENCRYPTION_* environment variables.`,
+		Example: `  # Rotate to a new RS256 key
+  ai-services catalog rotate-signing-key
+
+  # Switch to Ed25519 keys, keeping the old key valid for two days
+  ai-services catalog rotate-signing-key --algorithm EdDSA --retire-after 48h`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			return runRotateSigningKey(cmd.Context(), algorithm, retireAfter)
+		},
+	}
+
+	cmd.Flags().StringVar(&algorithm, "algorithm", auth.SigningAlgRS256, "Signing algorithm of the new key: RS256 or EdDSA")
+	cmd.Flags().DurationVar(&retireAfter, "retire-after", defaultSigningKeyRetireAfter, "How long the previous keys keep verifying tokens")
+
+	return cmd
+}
+
+func runRotateSigningKey(ctx context.Context, algorithm string, retireAfter time.Duration) error {
+	if retireAfter <= 0 {
+		return fmt.Errorf("--retire-after must be positive")
+	}
+
+	dbConfig, err := loadDBConfig()
+	if err != nil {
+		return err
+	}
+	pool, err := db.ConnectPool(ctx, dbConfig)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer pool.Close()
+
+	encryptionKeys, err := catalogutils.KeyringFromEnv()
+	if err != nil {
+		return err
+	}
+	source, err := auth.NewDBKeySource(repository.NewSigningKeyRepository(pool), encryptionKeys)
+	if err != nil {
+		return err
+	}
+	key, err := source.Rotate(ctx, algorithm, retireAfter)
+	if err != nil {
+		return fmt.Errorf("failed to rotate signing key: %w", err)
+	}
+
+	logger.Infof("New %s signing key: %s\n", key.Algorithm, key.ID)
+	logger.Infof("Previous keys stop verifying tokens at %s\n", key.CreatedAt.Add(retireAfter).Format(time.RFC3339))
+
+	return nil
+}
+
+// Made with Bob
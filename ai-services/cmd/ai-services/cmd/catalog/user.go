@@ -0,0 +1,278 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/catalog/common"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/client"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+// NewUserCmd returns the cobra command that groups the user management subcommands.
+func NewUserCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "user",
+		Short: "Manage catalog API users",
+		Long: `Create, list, disable, enable and delete catalog API users and reset their passwords.
+All subcommands except 'passwd' require the admin role.
+
+Users are addressed by user ID or username.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newUserCreateCmd())
+	cmd.AddCommand(newUserListCmd())
+	cmd.AddCommand(newUserSetDisabledCmd(true))
+	cmd.AddCommand(newUserSetDisabledCmd(false))
+	cmd.AddCommand(newUserDeleteCmd())
+	cmd.AddCommand(newUserResetPasswordCmd())
+	cmd.AddCommand(newUserPasswdCmd())
+
+	return cmd
+}
+
+func newUserCreateCmd() *cobra.Command {
+	var (
+		name        string
+		role        string
+		fromStdin   bool
+		runtimeType string
+	)
+	cmd := &cobra.Command{
+		Use:   "create <username>",
+		Short: "Create a user",
+		Example: `  # Create an operator, prompting for the password
+  ai-services catalog user create alice --name "Alice" --role operator --runtime podman
+
+  # Non-interactive (CI): read the password from stdin
+  printf '%s\n' 'S3cureP@ss!' | ai-services catalog user create bob --stdin --runtime podman`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return common.InitAndValidateRuntimeFlag(runtimeType)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pw, err := getPassword(fromStdin, false, cmd)
+			if err != nil {
+				return err
+			}
+			cmd.SilenceUsage = true
+
+			c, err := client.NewUserClient()
+			if err != nil {
+				return err
+			}
+
+			u, err := c.CreateUser(&client.CreateUserRequest{Username: args[0], Password: pw, Name: name, Role: role})
+			if err != nil {
+				return err
+			}
+			logger.Infof("User %q created with role %s (ID: %s)\n", u.Username, u.Role, u.ID)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "Display name of the user")
+	cmd.Flags().StringVar(&role, "role", "viewer", "Role of the user: viewer, operator or admin")
+	cmd.Flags().BoolVar(&fromStdin, "stdin", false, "read password from stdin (non-interactive)")
+	common.ConfigureRuntimeFlag(cmd, &runtimeType)
+
+	return cmd
+}
+
+func newUserListCmd() *cobra.Command {
+	var runtimeType string
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List users",
+		Args:  cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return common.InitAndValidateRuntimeFlag(runtimeType)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			c, err := client.NewUserClient()
+			if err != nil {
+				return err
+			}
+
+			users, err := c.ListUsers()
+			if err != nil {
+				return err
+			}
+
+			printer := utils.NewTableWriter()
+			defer printer.CloseTableWriter()
+			printer.SetHeaders("USERNAME", "NAME", "ROLE", "DISABLED", "ID")
+			for _, u := range users {
+				printer.AppendRow(u.Username, u.Name, u.Role, strconv.FormatBool(u.Disabled), u.ID)
+			}
+
+			return nil
+		},
+	}
+
+	common.ConfigureRuntimeFlag(cmd, &runtimeType)
+
+	return cmd
+}
+
+// newUserSetDisabledCmd returns the disable command, or the enable command when disabled is false.
+func newUserSetDisabledCmd(disabled bool) *cobra.Command {
+	var runtimeType string
+	use, short, done := "enable", "Re-enable a disabled user", "enabled"
+	if disabled {
+		use, short, done = "disable", "Disable a user so it can no longer log in", "disabled"
+	}
+
+	cmd := &cobra.Command{
+		Use:   use + " <user>",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return common.InitAndValidateRuntimeFlag(runtimeType)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			c, err := client.NewUserClient()
+			if err != nil {
+				return err
+			}
+
+			u, err := c.SetDisabled(args[0], disabled)
+			if err != nil {
+				return err
+			}
+			logger.Infof("User %q %s\n", u.Username, done)
+
+			return nil
+		},
+	}
+
+	common.ConfigureRuntimeFlag(cmd, &runtimeType)
+
+	return cmd
+}
+
+func newUserDeleteCmd() *cobra.Command {
+	var runtimeType string
+	cmd := &cobra.Command{
+		Use:   "delete <user>",
+		Short: "Delete a user",
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return common.InitAndValidateRuntimeFlag(runtimeType)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			c, err := client.NewUserClient()
+			if err != nil {
+				return err
+			}
+
+			if err := c.DeleteUser(args[0]); err != nil {
+				return err
+			}
+			logger.Infof("User %q deleted\n", args[0])
+
+			return nil
+		},
+	}
+
+	common.ConfigureRuntimeFlag(cmd, &runtimeType)
+
+	return cmd
+}
+
+func newUserResetPasswordCmd() *cobra.Command {
+	var (
+		fromStdin   bool
+		runtimeType string
+	)
+	cmd := &cobra.Command{
+		Use:   "reset-password <user>",
+		Short: "Set a new password for a user",
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return common.InitAndValidateRuntimeFlag(runtimeType)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pw, err := getPassword(fromStdin, false, cmd)
+			if err != nil {
+				return err
+			}
+			cmd.SilenceUsage = true
+
+			c, err := client.NewUserClient()
+			if err != nil {
+				return err
+			}
+
+			if err := c.ResetPassword(args[0], pw); err != nil {
+				return err
+			}
+			logger.Infof("Password of user %q reset\n", args[0])
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&fromStdin, "stdin", false, "read password from stdin (non-interactive)")
+	common.ConfigureRuntimeFlag(cmd, &runtimeType)
+
+	return cmd
+}
+
+func newUserPasswdCmd() *cobra.Command {
+	var runtimeType string
+	cmd := &cobra.Command{
+		Use:   "passwd",
+		Short: "Change the password of the logged-in user",
+		Args:  cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return common.InitAndValidateRuntimeFlag(runtimeType)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			current, err := readHidden("Current password: ")
+			if err != nil {
+				return fmt.Errorf("read password: %w", err)
+			}
+			if current == "" {
+				return errors.New("empty password")
+			}
+			pw, err := getPasswordInteractive(false)
+			if err != nil {
+				return err
+			}
+			cmd.SilenceUsage = true
+
+			c, err := client.NewUserClient()
+			if err != nil {
+				return err
+			}
+
+			if err := c.ChangePassword(current, pw); err != nil {
+				return err
+			}
+			logger.Infoln("Password changed")
+
+			return nil
+		},
+	}
+
+	common.ConfigureRuntimeFlag(cmd, &runtimeType)
+
+	return cmd
+}
+
+// Made with Bob
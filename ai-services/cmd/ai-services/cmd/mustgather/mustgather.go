@@ -26,7 +26,8 @@ func MustGatherCmd() *cobra.Command {
 for support and troubleshooting purposes.
 
 Gathered data includes pod details, container logs, network and volume
-information. All sensitive values are automatically redacted.`,
+information, and the most recent catalog audit events when logged in to the
+catalog as an admin. All sensitive values are automatically redacted.`,
 		Example: `  # Collect from all applications (podman)
   ai-services must-gather --runtime podman
 
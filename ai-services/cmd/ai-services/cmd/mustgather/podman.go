@@ -25,7 +25,9 @@ const (
 	dirPerm          = 0755
 	filePerm         = 0644
 	maxLogLines      = "1000"
-	modelsSeparatorW = 60 // width of the separator line in models.txt
+	maxAuditEvents   = 500 // most recent audit events written to audit-events.json
+	auditPageSize    = 100 // largest page the audit API serves
+	modelsSeparatorW = 60  // width of the separator line in models.txt
 )
 
 // gatherOptions carries options forwarded from the cobra command.
@@ -325,6 +327,7 @@ func (g *podmanGatherer) collectContainerLogs(ctx context.Context, podDir, name
 //   - catalog pods (ai-services--catalog, ai-services--db, ai-services--caddy)
 //   - Caddyfile from <BaseDir>/common/caddy/ (reverse-proxy route config)
 //   - catalog-credentials.json with tokens redacted
+//   - audit-events.json with the most recent audit events
 func (g *podmanGatherer) collectCatalogArtifacts(ctx context.Context, outDir string) {
 	logger.InfolnCtx(ctx, "Collecting catalog artifacts…")
 
@@ -338,6 +341,7 @@ func (g *podmanGatherer) collectCatalogArtifacts(ctx context.Context, outDir str
 	g.collectCatalogPods(ctx, catDir)
 	g.collectCaddyfile(ctx, catDir)
 	g.collectCatalogCredentials(ctx, catDir)
+	g.collectAuditEvents(ctx, catDir)
 }
 
 // collectCatalogPods lists all pods labelled ai-services.io/application=ai-services
@@ -448,6 +452,45 @@ func (g *podmanGatherer) collectCatalogCredentials(ctx context.Context, catDir s
 	g.writeFile(ctx, catDir, "catalog-credentials.json", g.sanitizer.SanitizeJSON(data))
 }
 
+// collectAuditEvents saves the most recent audit events (up to maxAuditEvents)
+// from the catalog API. Reading the audit log requires the admin role.
+func (g *podmanGatherer) collectAuditEvents(ctx context.Context, catDir string) {
+	auditClient, err := catalogClient.NewAuditClient()
+	if err != nil {
+		logger.WarningfCtx(ctx, "Catalog client unavailable, skipping audit events: %v\n", err)
+
+		return
+	}
+
+	events := make([]catalogClient.AuditEvent, 0, auditPageSize)
+	for page := 1; len(events) < maxAuditEvents; page++ {
+		resp, err := auditClient.ListAuditEvents(&catalogClient.ListAuditEventsParams{Page: page, PageSize: auditPageSize})
+		if err != nil {
+			logger.WarningfCtx(ctx, "Failed to fetch audit events: %v\n", err)
+
+			break
+		}
+
+		events = append(events, resp.Events...)
+		if !resp.Pagination.HasNext {
+			break
+		}
+	}
+
+	if len(events) == 0 {
+		return
+	}
+
+	data, err := json.MarshalIndent(events, "", "  ")
+	if err != nil {
+		logger.WarningfCtx(ctx, "Failed to encode audit events: %v\n", err)
+
+		return
+	}
+
+	g.writeFile(ctx, catDir, "audit-events.json", g.sanitizer.SanitizeJSON(data))
+}
+
 // ── models info collection ────────────────────────────────────────────────────
 
 // collectModelsInfo records which models are present under <BaseDir>/models/
@@ -11,6 +11,7 @@ import (
 	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/catalog"
 	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/mustgather"
 	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/version"
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/worker"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 )
 
@@ -48,4 +49,5 @@ func init() {
 	RootCmd.AddCommand(application.ApplicationCmd)
 	RootCmd.AddCommand(catalog.CatalogCmd())
 	RootCmd.AddCommand(mustgather.MustGatherCmd())
+	RootCmd.AddCommand(worker.WorkerCmd())
 }
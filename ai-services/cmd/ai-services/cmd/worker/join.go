@@ -0,0 +1,179 @@
+package worker
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	goruntime "runtime"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/catalog/common"
+	"github.com/project-ai-services/ai-services/cmd/ai-services/cmd/version"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/worker/agent"
+)
+
+// NewJoinCmd returns the cobra command that registers this host with a WorkerGateway.
+func NewJoinCmd() *cobra.Command {
+	var (
+		token              string
+		gatewayAddr        string
+		runtimeType        string
+		caCertPath         string
+		insecureSkipVerify bool
+		labels             map[string]string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "join",
+		Short: "Register this host with a catalog control plane",
+		Long: `Exchange a single-use bootstrap token for a worker identity.
+
+The token is issued by the control plane when an admin pre-registers the worker
+(POST /api/v1/workers). The control plane answers with a client certificate for
+this worker; it is stored together with the assigned worker name and gateway
+address in the OS user config directory and reused by 'ai-services worker run'.
+
+The gateway is verified against the CA certificate returned alongside the token
+(--ca-cert). --insecure-skip-tls-verify skips that check for the join only.
+
+Labels (--label key=value) are stored with the worker's metadata and can be
+matched by the worker_selector of an application create request.`,
+		Example: `  # Join the control plane listening on port 9090
+  ai-services worker join --token <bootstrap_token> --ca-cert ca.pem --gateway catalog.example.com:9090 --runtime podman
+
+  # Join with labels used for application placement
+  ai-services worker join --token <bootstrap_token> --ca-cert ca.pem --gateway catalog.example.com:9090 --runtime podman --label zone=lab-a --label spyre=true`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if caCertPath == "" && !insecureSkipVerify {
+				return errors.New("either --ca-cert or --insecure-skip-tls-verify is required")
+			}
+
+			return common.InitAndValidateRuntimeFlag(runtimeType)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tlsConfig, err := joinTLSConfig(caCertPath, insecureSkipVerify)
+			if err != nil {
+				return err
+			}
+
+			return runJoin(cmd.Context(), token, gatewayAddr, runtimeType, labels, tlsConfig)
+		},
+	}
+
+	cmd.Flags().StringVar(&token, "token", "", "Bootstrap token issued by the control plane (required)")
+	cmd.Flags().StringVar(&gatewayAddr, "gateway", "", "WorkerGateway address as host:port (required)")
+	cmd.Flags().StringVar(&caCertPath, "ca-cert", "", "Path to the control plane CA certificate (ca_cert_pem from the worker registration response)")
+	cmd.Flags().BoolVar(&insecureSkipVerify, "insecure-skip-tls-verify", false, "Do not verify the gateway certificate during join (not recommended)")
+	cmd.Flags().StringToStringVar(&labels, "label", nil, "Label to attach to this worker as key=value (repeatable); matched by application worker selectors")
+	common.ConfigureRuntimeFlag(cmd, &runtimeType)
+
+	_ = cmd.MarkFlagRequired("token")
+	_ = cmd.MarkFlagRequired("gateway")
+
+	return cmd
+}
+
+func runJoin(ctx context.Context, token, gatewayAddr, runtimeType string, labels map[string]string, tlsConfig *tls.Config) error {
+	conn, err := dialGateway(gatewayAddr, tlsConfig)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	// The runtime is only needed to learn which commands this build executes on it.
+	rt, err := runtime.CreateRuntime(types.RuntimeType(runtimeType), "")
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Joining control plane at %s...\n", gatewayAddr)
+
+	resp, err := agent.Join(ctx, conn, agent.JoinOptions{
+		Token:        token,
+		RuntimeType:  runtimeType,
+		Metadata:     hostMetadata(labels),
+		Capabilities: agent.NewExecutor(rt).Capabilities(),
+	})
+	if err != nil {
+		return fmt.Errorf("join failed: %w", err)
+	}
+	if resp.GetTlsCertPem() == "" || resp.GetCaCertPem() == "" {
+		return errors.New("join failed: the control plane did not issue a client certificate")
+	}
+
+	state := agent.State{
+		WorkerName:  resp.GetWorkerName(),
+		GatewayAddr: gatewayAddr,
+		RuntimeType: runtimeType,
+		TLSCertPEM:  resp.GetTlsCertPem(),
+		TLSKeyPEM:   resp.GetTlsKeyPem(),
+		CACertPEM:   resp.GetCaCertPem(),
+	}
+	if err := agent.SaveState(state); err != nil {
+		return fmt.Errorf("failed to save worker state: %w", err)
+	}
+
+	logger.Infof("Joined as worker %q. Start serving commands with: ai-services worker run\n", state.WorkerName)
+
+	return nil
+}
+
+// joinTLSConfig returns the TLS configuration used for Register. The worker has no
+// client certificate yet, so only the gateway is authenticated.
+func joinTLSConfig(caCertPath string, insecureSkipVerify bool) (*tls.Config, error) {
+	if insecureSkipVerify {
+		logger.Warningln("Gateway certificate verification is disabled for this join.")
+
+		return &tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: true}, nil //nolint:gosec
+	}
+
+	caPEM, err := os.ReadFile(caCertPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no valid CA certificate found in %s", caCertPath)
+	}
+
+	return &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: pool}, nil
+}
+
+// dialGateway creates a gRPC client connection to the WorkerGateway.
+func dialGateway(addr string, tlsConfig *tls.Config) (*grpc.ClientConn, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to worker gateway %s: %w", addr, err)
+	}
+
+	return conn, nil
+}
+
+// hostMetadata describes this host to the control plane; it is stored on the worker row.
+// User labels are applied last and take precedence over the built-in keys.
+func hostMetadata(labels map[string]string) map[string]string {
+	md := map[string]string{
+		"os":      goruntime.GOOS,
+		"arch":    goruntime.GOARCH,
+		"version": version.GetVersion(),
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		md["hostname"] = hostname
+	}
+	maps.Copy(md, labels)
+
+	return md
+}
+
+// Made with Bob
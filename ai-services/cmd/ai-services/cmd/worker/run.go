@@ -0,0 +1,105 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/worker/agent"
+	"github.com/project-ai-services/ai-services/internal/pkg/worker/pki"
+	workerpb "github.com/project-ai-services/ai-services/internal/pkg/worker/proto"
+)
+
+// NewRunCmd returns the cobra command that serves gateway commands until interrupted.
+func NewRunCmd() *cobra.Command {
+	var gatewayAddr string
+
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Serve commands from the control plane",
+		Long: `Open the command stream to the WorkerGateway and execute every command it
+sends against the local runtime. Heartbeats are sent periodically so the control
+plane can tell the worker is alive. Transient disconnects are retried with backoff.
+The connection is authenticated with the client certificate issued at join time,
+which is renewed and saved automatically before it expires.
+
+Requires a prior 'ai-services worker join'.`,
+		Example: `  # Serve commands using the identity saved by 'worker join'
+  ai-services worker run
+
+  # Override the gateway address saved at join time
+  ai-services worker run --gateway catalog.example.com:9090`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWorker(cmd.Context(), gatewayAddr)
+		},
+	}
+
+	cmd.Flags().StringVar(&gatewayAddr, "gateway", "", "WorkerGateway address as host:port (defaults to the address used at join time)")
+
+	return cmd
+}
+
+func runWorker(ctx context.Context, gatewayAddr string) error {
+	state, err := agent.LoadState()
+	if err != nil {
+		return err
+	}
+	if gatewayAddr == "" {
+		gatewayAddr = state.GatewayAddr
+	}
+
+	rt, err := runtime.CreateRuntime(types.RuntimeType(state.RuntimeType), "")
+	if err != nil {
+		return err
+	}
+
+	if state.TLSCertPEM == "" || state.CACertPEM == "" {
+		return errors.New("no client certificate found: issue a new token and run 'ai-services worker join' again")
+	}
+
+	keyPair, err := pki.NewKeyPair([]byte(state.TLSCertPEM), []byte(state.TLSKeyPEM))
+	if err != nil {
+		return err
+	}
+
+	tlsConfig, err := pki.ClientTLSConfig([]byte(state.CACertPEM), keyPair.GetClientCertificate)
+	if err != nil {
+		return err
+	}
+
+	conn, err := dialGateway(gatewayAddr, tlsConfig)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	logger.Infof("Worker %q serving commands from %s (runtime: %s)\n", state.WorkerName, gatewayAddr, rt.Type())
+
+	a := agent.New(conn, state.WorkerName, agent.NewExecutor(rt))
+	a.EnableCertificateRenewal(keyPair, func(resp *workerpb.RegisterResponse) error {
+		state.TLSCertPEM = resp.GetTlsCertPem()
+		state.TLSKeyPEM = resp.GetTlsKeyPem()
+
+		return agent.SaveState(state)
+	})
+
+	if err := a.Run(ctx); err != nil {
+		return fmt.Errorf("worker stopped: %w", err)
+	}
+
+	logger.Infoln("Worker stopped.")
+
+	return nil
+}
+
+// Made with Bob
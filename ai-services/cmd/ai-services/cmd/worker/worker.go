@@ -0,0 +1,28 @@
+package worker
+
+import "github.com/spf13/cobra"
+
+// WorkerCmd returns the cobra command for running this host as a worker of a catalog control plane.
+func WorkerCmd() *cobra.Command {
+	workerCMD := &cobra.Command{
+		Use:   "worker",
+		Short: "Run this host as a worker for the AI Services catalog",
+		Long: `A worker connects to the WorkerGateway of a catalog API server and executes
+runtime operations (pods, images, secrets, …) on behalf of the control plane.
+
+Typical flow:
+  1. An admin pre-registers the worker on the control plane and receives a bootstrap token.
+  2. 'ai-services worker join' exchanges the token for the worker identity.
+  3. 'ai-services worker run' keeps the command stream open and serves requests.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	workerCMD.AddCommand(NewJoinCmd())
+	workerCMD.AddCommand(NewRunCmd())
+
+	return workerCMD
+}
+
+// Made with Bob
@@ -1,19 +1,32 @@
 package podman
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/project-ai-services/ai-services/internal/pkg/application/types"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
 )
 
 // Logs displays logs from an application pod.
 func (p *PodmanApplication) Logs(opts types.LogsOptions) error {
-	logger.Warningln("Press Ctrl+C to exit the logs and return to the terminal.")
+	if opts.Follow {
+		logger.Warningln("Press Ctrl+C to exit the logs and return to the terminal.")
+	}
 	logger.Infof("Fetching logs for application pod: %s", opts.PodName)
 
+	// Ctrl+C stops following the logs.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logOpts := runtimeTypes.LogOptions{Follow: opts.Follow, Tail: opts.Tail, Since: opts.Since}
+
 	if opts.ContainerNameOrID == "" {
-		if err := p.runtime.PodLogs(opts.PodName); err != nil {
+		if err := p.runtime.PodLogs(ctx, opts.PodName, os.Stdout, logOpts); err != nil {
 			return fmt.Errorf("failed to fetch pod: %s logs; err: %w", opts.PodName, err)
 		}
 
@@ -30,7 +43,7 @@ func (p *PodmanApplication) Logs(opts types.LogsOptions) error {
 	}
 
 	logger.Infof("Fetching logs for container: %s", opts.ContainerNameOrID)
-	if err := p.runtime.ContainerLogs(opts.ContainerNameOrID); err != nil {
+	if err := p.runtime.ContainerLogs(ctx, opts.ContainerNameOrID, os.Stdout, logOpts); err != nil {
 		return fmt.Errorf("failed to fetch container: %s logs; err: %w", opts.ContainerNameOrID, err)
 	}
 
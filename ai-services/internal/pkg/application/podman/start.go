@@ -1,8 +1,12 @@
 package podman
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 
 	appTypes "github.com/project-ai-services/ai-services/internal/pkg/application/types"
 	cliutils "github.com/project-ai-services/ai-services/internal/pkg/cli/utils"
@@ -154,7 +158,11 @@ func (p *PodmanApplication) startPods(podsToStart []types.Pod) error {
 func (p *PodmanApplication) printPodLogs(podsToStart []types.Pod) error {
 	logger.Infof("\n--- Following logs for pod: %s ---\n", podsToStart[0].Name)
 
-	if err := p.runtime.PodLogs(podsToStart[0].Name); err != nil {
+	// Ctrl+C stops following the logs.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := p.runtime.PodLogs(ctx, podsToStart[0].Name, os.Stdout, types.LogOptions{Follow: true}); err != nil {
 		if strings.Contains(err.Error(), "signal: interrupt") || strings.Contains(err.Error(), "context canceled") {
 			logger.Infoln("Log following stopped.")
 
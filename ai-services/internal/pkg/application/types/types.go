@@ -69,6 +69,9 @@ type InfoOptions struct {
 type LogsOptions struct {
 	PodName           string
 	ContainerNameOrID string
+	Follow            bool
+	Tail              int
+	Since             time.Duration
 }
 
 // RestoreOptions contains parameters for restoring application data.
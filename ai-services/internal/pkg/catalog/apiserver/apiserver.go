@@ -25,10 +25,22 @@
 //	@tag.name					Catalog
 //	@tag.description			Catalog endpoints for architectures and services
 //
+//	@tag.name					Connectors
+//	@tag.description			Data-source connector instances (admin role for changes)
+//
+//	@tag.name					Users
+//	@tag.description			User management endpoints (admin role)
+//
+//	@tag.name					API Keys
+//	@tag.description			Long-lived API keys for automation
+//
+//	@tag.name					Audit
+//	@tag.description			Audit log of mutating API calls (admin role)
+//
 //	@securityDefinitions.apikey	BearerAuth
 //	@in							header
 //	@name						Authorization
-//	@description				Type "Bearer" followed by a space and JWT token.
+//	@description				Type "Bearer" followed by a space and JWT token, or "ApiKey" followed by a space and an API key.
 package apiserver
 
 import (
@@ -36,22 +48,35 @@ import (
 	"fmt"
 
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository"
+	apikeysvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/apikey"
+	auditsvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/audit"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/auth"
 	bundlesvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/bundle"
+	connectorsvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/connector"
+	usersvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/user"
+	webhooksvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/webhook"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 	"github.com/project-ai-services/ai-services/internal/pkg/worker/gateway"
+	"github.com/project-ai-services/ai-services/internal/pkg/worker/pki"
 	"github.com/project-ai-services/ai-services/internal/pkg/worker/registry"
 )
 
 // APIServerOptions defines the configuration options for the API server such as the port to listen
 // on and the authentication provider.
 type APIServerOptions struct {
-	Port               int
-	AuthService        auth.Service
+	Port        int
+	AuthService auth.Service
+	// OIDCService enables login through an OpenID Connect provider; nil disables it.
+	OIDCService        auth.OIDCService
 	TokenManager       *auth.TokenManager
 	Blacklist          repository.TokenBlacklist
 	ApplicationService repository.ApplicationServiceInterface
 	BundleService      bundlesvc.BundleServiceInterface
+	UserService        usersvc.UserServiceInterface
+	APIKeyService      apikeysvc.APIKeyServiceInterface
+	AuditService       auditsvc.AuditServiceInterface
+	ConnectorService   connectorsvc.ConnectorServiceInterface
+	WebhookService     webhooksvc.WebhookServiceInterface
 
 	// WorkerGatewayPort is the port the gRPC worker gateway listens on.
 	// Defaults to 9090 when zero.
@@ -59,19 +84,33 @@ type APIServerOptions struct {
 	// WorkerRegistry holds the in-memory state of all connected workers and owns
 	// the bootstrap token store.
 	WorkerRegistry *registry.Registry
+	// WorkerCA issues worker client certificates and the gateway server certificate.
+	// When nil the gateway serves without TLS.
+	WorkerCA *pki.CA
+	// WorkerGatewayHosts are the DNS names and IPs workers use to reach the gateway;
+	// they become the subject alternative names of the gateway server certificate.
+	WorkerGatewayHosts []string
 }
 
 // APIserver represents the API server instance, holding the configuration and authentication provider.
 type APIserver struct {
 	port               int
 	authService        auth.Service
+	oidcService        auth.OIDCService
 	tokenManager       *auth.TokenManager
 	blacklist          repository.TokenBlacklist
 	applicationService repository.ApplicationServiceInterface
 	bundleService      bundlesvc.BundleServiceInterface
+	userService        usersvc.UserServiceInterface
+	apiKeyService      apikeysvc.APIKeyServiceInterface
+	auditService       auditsvc.AuditServiceInterface
+	connectorService   connectorsvc.ConnectorServiceInterface
+	webhookService     webhooksvc.WebhookServiceInterface
 
-	workerGatewayPort int
-	workerRegistry    *registry.Registry
+	workerGatewayPort  int
+	workerRegistry     *registry.Registry
+	workerCA           *pki.CA
+	workerGatewayHosts []string
 }
 
 // NewAPIserver creates a new instance of the API server with the provided options, setting default values where necessary.
@@ -87,12 +126,20 @@ func NewAPIserver(options APIServerOptions) *APIserver {
 	return &APIserver{
 		port:               options.Port,
 		authService:        options.AuthService,
+		oidcService:        options.OIDCService,
 		tokenManager:       options.TokenManager,
 		blacklist:          options.Blacklist,
 		applicationService: options.ApplicationService,
 		bundleService:      options.BundleService,
+		userService:        options.UserService,
+		apiKeyService:      options.APIKeyService,
+		auditService:       options.AuditService,
+		connectorService:   options.ConnectorService,
+		webhookService:     options.WebhookService,
 		workerGatewayPort:  options.WorkerGatewayPort,
 		workerRegistry:     options.WorkerRegistry,
+		workerCA:           options.WorkerCA,
+		workerGatewayHosts: options.WorkerGatewayHosts,
 	}
 }
 
@@ -106,14 +153,14 @@ func (a *APIserver) Start(ctx context.Context) error {
 	defer cancel(nil)
 
 	// Start the gRPC worker gateway.
-	gw := gateway.New(a.workerRegistry)
+	gw := gateway.New(a.workerRegistry, a.workerCA, a.workerGatewayHosts)
 	gatewayAddr := fmt.Sprintf(":%d", a.workerGatewayPort)
 	if err := gw.Start(ctx, cancel, gatewayAddr); err != nil {
 		return fmt.Errorf("failed to start worker gateway: %w", err)
 	}
 	logger.InfofCtx(ctx, "Worker gateway started on %s", gatewayAddr)
 
-	r := CreateRouter(a.authService, a.tokenManager, a.blacklist, a.applicationService, a.workerRegistry, a.bundleService)
+	r := CreateRouter(a.authService, a.oidcService, a.tokenManager, a.blacklist, a.applicationService, a.workerRegistry, a.workerCA, a.bundleService, a.userService, a.apiKeyService, a.auditService, a.connectorService, a.webhookService)
 
 	if err := r.Run(fmt.Sprintf(":%d", a.port)); err != nil {
 		return err
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/middleware"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+	apikeysvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/apikey"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+)
+
+// APIKeyHandler handles creation, listing and revocation of API keys.
+type APIKeyHandler struct {
+	apiKeyService apikeysvc.APIKeyServiceInterface
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler backed by the given APIKeyServiceInterface.
+func NewAPIKeyHandler(svc apikeysvc.APIKeyServiceInterface) *APIKeyHandler {
+	return &APIKeyHandler{apiKeyService: svc}
+}
+
+// CreateAPIKey godoc
+//
+//	@Summary		Create an API key
+//	@Description	Creates an API key owned by the caller. Send it as "Authorization: ApiKey <key>"; it acts with the
+//	@Description	owner's current role, restricted to its scopes. The key is only returned in this response.
+//	@Description	When the request authenticates with an API key, scopes must be set and granted by that key.
+//	@Tags			API Keys
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			apikey	body		apikeysvc.CreateAPIKeyRequest	true	"API key to create"
+//	@Success		201		{object}	apikeysvc.CreateAPIKeyResponse
+//	@Failure		400		{object}	ErrorResponse	"Invalid payload, scope or expiry"
+//	@Failure		401		{object}	ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse	"Scopes exceed those of the calling API key"
+//	@Failure		409		{object}	ErrorResponse	"An active key with the same name exists"
+//	@Router			/apikeys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	var req apikeysvc.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid payload: " + err.Error()})
+
+		return
+	}
+
+	resp, err := h.apiKeyService.CreateKey(c.Request.Context(), c.GetString(middleware.CtxUserIDKey), c.GetStringSlice(middleware.CtxScopesKey), req)
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	middleware.SetAuditResourceID(c, resp.ID.String())
+	c.JSON(http.StatusCreated, resp)
+}
+
+// ListAPIKeys godoc
+//
+//	@Summary		List API keys
+//	@Description	Lists the caller's API keys, newest first. Admins can pass all=true to list the keys of every user.
+//	@Tags			API Keys
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			all	query		bool	false	"List the keys of every user (admin only)"
+//	@Success		200	{object}	apikeysvc.APIKeyListResponse
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse	"all=true requires the admin role"
+//	@Router			/apikeys [get]
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	all := c.Query("all") == "true"
+	if all && !models.Role(c.GetString(middleware.CtxRoleKey)).Allows(models.RoleAdmin) {
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: "insufficient role: requires admin"})
+
+		return
+	}
+
+	resp, err := h.apiKeyService.ListKeys(c.Request.Context(), c.GetString(middleware.CtxUserIDKey), all)
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// RevokeAPIKey godoc
+//
+//	@Summary		Revoke an API key
+//	@Description	Revokes one of the caller's API keys. Admins can revoke any key.
+//	@Tags			API Keys
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"API key ID"
+//	@Success		204
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse	"API key not found"
+//	@Failure		409	{object}	ErrorResponse	"API key already revoked"
+//	@Router			/apikeys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	role := models.Role(c.GetString(middleware.CtxRoleKey))
+	if err := h.apiKeyService.RevokeKey(c.Request.Context(), c.GetString(middleware.CtxUserIDKey), role, c.Param("id")); err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// mapServiceError translates a validators.ValidationError into the appropriate
+// HTTP status, and falls back to 500 for all other errors.
+func (h *APIKeyHandler) mapServiceError(c *gin.Context, err error) {
+	var valErr *validators.ValidationError
+	if errors.As(err, &valErr) {
+		c.JSON(valErr.Code, ErrorResponse{Error: valErr.Message})
+
+		return
+	}
+	c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+}
+
+// Made with Bob
@@ -1,11 +1,16 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/google/uuid"
 
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/middleware"
@@ -14,12 +19,17 @@ import (
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository"
 	dbmodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
 )
 
 var (
 	ErrInvalidIDParameter = ErrorResponse{Error: "Invalid application ID format"}
 )
 
+// eventStreamKeepAlive is how often an idle event stream sends a comment to keep the connection open.
+const eventStreamKeepAlive = 15 * time.Second
+
 // Ensure types package is imported for Swagger documentation.
 var _ types.ApplicationListResponse
 var _ types.ApplicationPSResponse
@@ -29,7 +39,8 @@ type ApplicationHandler struct {
 	appService repository.ApplicationServiceInterface
 }
 
-type UpdateApplicationRequest struct {
+// RenameApplicationRequest is an update request that only renames an application.
+type RenameApplicationRequest struct {
 	Name string `json:"name" binding:"required,min=3,max=100"`
 }
 
@@ -106,19 +117,27 @@ func (h *ApplicationHandler) ListApplications(c *gin.Context) {
 // UpdateApplication godoc
 //
 //	@Summary		Update application
-//	@Description	Updates the display name of an existing application
+//	@Description	Reconfigures an existing application in place. The body has the shape of a create request
+//	@Description	and describes the complete new configuration; catalog_id must stay the same, and worker_id
+//	@Description	and worker_selector are ignored because the application stays on its worker.
+//	@Description	Only services and components whose configuration changed are redeployed, components that
+//	@Description	are no longer used are deleted, and the response lists the change to each of them.
+//	@Description	A body with only a name renames the application.
 //	@Tags			Applications
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			id		path		string						true	"Application ID (UUID)"
-//	@Param			body	body		UpdateApplicationRequest	true	"Update request"
-//	@Success		200		{object}	types.Application
-//	@Failure		400		{object}	ErrorResponse	"Invalid request body or name validation failed"
-//	@Failure		401		{object}	ErrorResponse	"Unauthorized"
-//	@Failure		403		{object}	ErrorResponse	"User doesn't own this application"
-//	@Failure		404		{object}	ErrorResponse	"Application not found"
-//	@Failure		500		{object}	ErrorResponse	"Internal Server Error"
+//	@Param			id		path		string								true	"Application ID (UUID)"
+//	@Param			body	body		models.CreateApplicationRequest		true	"New configuration, or only a name"
+//	@Success		200		{object}	types.Application					"Application renamed"
+//	@Success		202		{object}	models.UpdateApplicationResponse	"Reconfiguration initiated"
+//	@Failure		400		{object}	ErrorResponse						"Invalid request body or catalog_id changed"
+//	@Failure		401		{object}	ErrorResponse						"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse						"User doesn't own this application"
+//	@Failure		404		{object}	ErrorResponse						"Application not found"
+//	@Failure		409		{object}	ErrorResponse						"Application name already exists, the application is being deployed or deleted, or a component deployed by an earlier version would be deleted"
+//	@Failure		422		{object}	ErrorResponse						"Parameter validation failed or invalid template"
+//	@Failure		500		{object}	ErrorResponse						"Internal Server Error"
 //	@Router			/applications/{id} [put]
 func (h *ApplicationHandler) UpdateApplication(c *gin.Context) {
 	appID, err := uuid.Parse(c.Param("id"))
@@ -127,12 +146,6 @@ func (h *ApplicationHandler) UpdateApplication(c *gin.Context) {
 
 		return
 	}
-	var req UpdateApplicationRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("Invalid request body: %v", err)})
-
-		return
-	}
 	// Get authenticated user ID
 	userID := c.GetString(middleware.CtxUserIDKey)
 	if userID == "" {
@@ -140,29 +153,76 @@ func (h *ApplicationHandler) UpdateApplication(c *gin.Context) {
 
 		return
 	}
-	updatedApp, err := h.appService.UpdateApplication(c.Request.Context(), appID, userID, req.Name)
+	// A body without catalog_id is a rename
+	var target struct {
+		CatalogID string `json:"catalog_id"`
+	}
+	if err := c.ShouldBindBodyWith(&target, binding.JSON); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("Invalid request body: %v", err)})
+
+		return
+	}
+	if target.CatalogID == "" {
+		h.renameApplication(c, appID, userID)
+
+		return
+	}
+
+	var req models.CreateApplicationRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("Invalid request body: %v", err)})
+
+		return
+	}
+	req.CreatedBy = userID
+
+	response, err := h.appService.UpdateApplication(c.Request.Context(), appID, req)
 	if err != nil {
-		// Check if it's a validation error with specific status code
-		if valErr, ok := err.(*repository.ValidationError); ok {
-			c.JSON(valErr.Code, ErrorResponse{
-				Error: valErr.Message,
-			})
+		respondUpdateError(c, err)
 
-			return
-		}
+		return
+	}
+	c.JSON(http.StatusAccepted, response)
+}
 
-		// Default to internal server error
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("Failed to update application: %v", err)})
+// renameApplication handles an update request that only sets the name of an application.
+func (h *ApplicationHandler) renameApplication(c *gin.Context, appID uuid.UUID, userID string) {
+	var req RenameApplicationRequest
+	if err := c.ShouldBindBodyWith(&req, binding.JSON); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("Invalid request body: %v", err)})
+
+		return
+	}
+	updatedApp, err := h.appService.RenameApplication(c.Request.Context(), appID, userID, req.Name)
+	if err != nil {
+		respondUpdateError(c, err)
 
 		return
 	}
 	c.JSON(http.StatusOK, updatedApp)
 }
 
+// respondUpdateError writes the response for an error from updating an application.
+func respondUpdateError(c *gin.Context, err error) {
+	// Check if it's a validation error with specific status code
+	if valErr, ok := err.(*repository.ValidationError); ok {
+		c.JSON(valErr.Code, ErrorResponse{
+			Error: valErr.Message,
+		})
+
+		return
+	}
+
+	// Default to internal server error
+	c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("Failed to update application: %v", err)})
+}
+
 // CreateApplication godoc
 //
 //	@Summary		Create new application
-//	@Description	Creates a new application (architecture or service) with optional custom parameters
+//	@Description	Creates a new application (architecture or service) with optional custom parameters.
+//	@Description	The application is placed on a ready worker with enough free CPU, memory and accelerators;
+//	@Description	worker_id or worker_selector restrict placement. Without workers it runs on the local runtime.
 //	@Tags			Applications
 //	@Accept			json
 //	@Produce		json
@@ -172,7 +232,7 @@ func (h *ApplicationHandler) UpdateApplication(c *gin.Context) {
 //	@Failure		400		{object}	ErrorResponse						"Invalid request body or validation errors"
 //	@Failure		401		{object}	ErrorResponse						"Unauthorized"
 //	@Failure		409		{object}	ErrorResponse						"Application name already exists"
-//	@Failure		422		{object}	ErrorResponse						"Parameter validation failed or invalid template"
+//	@Failure		422		{object}	ErrorResponse						"Parameter validation failed, invalid template, or no worker can host the application"
 //	@Failure		500		{object}	ErrorResponse						"Internal Server Error"
 //	@Router			/applications [post]
 func (h *ApplicationHandler) CreateApplication(c *gin.Context) {
@@ -218,9 +278,60 @@ func (h *ApplicationHandler) CreateApplication(c *gin.Context) {
 		return
 	}
 
+	middleware.SetAuditResourceID(c, response.ID)
 	c.JSON(http.StatusAccepted, response)
 }
 
+// PlanApplication godoc
+//
+//	@Summary		Preview application deployment
+//	@Description	Validates and plans a create request without deploying it: no records are inserted and no
+//	@Description	Spyre cards are allocated. Returns the components (shared between services where their
+//	@Description	configuration matches), services, deployment order, required and available resources of the
+//	@Description	chosen host, the images and models to download, and warnings about problems that would make
+//	@Description	the create fail, such as a taken name or insufficient resources.
+//	@Tags			Applications
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		models.CreateApplicationRequest	true	"Application creation request"
+//	@Success		200		{object}	models.PlanApplicationResponse
+//	@Failure		400		{object}	ErrorResponse	"Invalid request body or validation errors"
+//	@Failure		401		{object}	ErrorResponse	"Unauthorized"
+//	@Failure		422		{object}	ErrorResponse	"Parameter validation failed or invalid template"
+//	@Failure		500		{object}	ErrorResponse	"Internal Server Error"
+//	@Router			/applications/plan [post]
+func (h *ApplicationHandler) PlanApplication(c *gin.Context) {
+	var req models.CreateApplicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: fmt.Sprintf("Invalid request body: %v", err),
+		})
+
+		return
+	}
+	req.CreatedBy = c.GetString(middleware.CtxUserIDKey)
+
+	response, err := h.appService.PlanApplication(c.Request.Context(), req)
+	if err != nil {
+		if valErr, ok := err.(*repository.ValidationError); ok {
+			c.JSON(valErr.Code, ErrorResponse{
+				Error: valErr.Message,
+			})
+
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: fmt.Sprintf("Failed to plan application: %v", err),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // GetApplicationByID godoc
 //
 //	@Summary		Get application by ID
@@ -375,6 +486,119 @@ func (h *ApplicationHandler) DeleteApplication(c *gin.Context) {
 	c.JSON(http.StatusAccepted, response)
 }
 
+// StartApplication godoc
+//
+//	@Summary		Start application
+//	@Description	Starts the stopped pods of an application, or of the services and components the body selects. Components start before services, and the pods of each in their podTemplateExecutions order. The application is Running again once no pods are stopped except those of services and components that are still Stopped. Podman only.
+//	@Tags			Applications
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string								true	"Application ID (UUID)"
+//	@Param			body	body		models.ApplicationLifecycleRequest	false	"Services and components to start; all when omitted"
+//	@Success		200		{object}	models.ApplicationLifecycleResponse
+//	@Failure		400		{object}	ErrorResponse	"Invalid application ID or request body"
+//	@Failure		401		{object}	ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse	"User doesn't own this application"
+//	@Failure		404		{object}	ErrorResponse	"Application, service or component not found"
+//	@Failure		409		{object}	ErrorResponse	"Application is being deployed or deleted"
+//	@Failure		500		{object}	ErrorResponse	"Internal Server Error"
+//	@Failure		501		{object}	ErrorResponse	"Not supported on the OpenShift runtime"
+//	@Router			/applications/{id}/start [post]
+func (h *ApplicationHandler) StartApplication(c *gin.Context) {
+	h.handleLifecycle(c, h.appService.StartApplication)
+}
+
+// StopApplication godoc
+//
+//	@Summary		Stop application
+//	@Description	Stops the pods of an application, or of the services and components the body selects, in the reverse of the start order. Components that other applications which are not stopped use keep running and are reported as skipped. The stopped services and components become Stopped and the status sync keeps checking the rest. The application becomes Stopped, which the status sync leaves alone, once all of its services and components are. Podman only.
+//	@Tags			Applications
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string								true	"Application ID (UUID)"
+//	@Param			body	body		models.ApplicationLifecycleRequest	false	"Services and components to stop; all when omitted"
+//	@Success		200		{object}	models.ApplicationLifecycleResponse
+//	@Failure		400		{object}	ErrorResponse	"Invalid application ID or request body"
+//	@Failure		401		{object}	ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse	"User doesn't own this application"
+//	@Failure		404		{object}	ErrorResponse	"Application, service or component not found"
+//	@Failure		409		{object}	ErrorResponse	"Application is being deployed or deleted"
+//	@Failure		500		{object}	ErrorResponse	"Internal Server Error"
+//	@Failure		501		{object}	ErrorResponse	"Not supported on the OpenShift runtime"
+//	@Router			/applications/{id}/stop [post]
+func (h *ApplicationHandler) StopApplication(c *gin.Context) {
+	h.handleLifecycle(c, h.appService.StopApplication)
+}
+
+// RestartApplication godoc
+//
+//	@Summary		Restart application
+//	@Description	Stops the pods of an application, or of the services and components the body selects, and starts them again in podTemplateExecutions order. Podman only.
+//	@Tags			Applications
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string								true	"Application ID (UUID)"
+//	@Param			body	body		models.ApplicationLifecycleRequest	false	"Services and components to restart; all when omitted"
+//	@Success		200		{object}	models.ApplicationLifecycleResponse
+//	@Failure		400		{object}	ErrorResponse	"Invalid application ID or request body"
+//	@Failure		401		{object}	ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse	"User doesn't own this application"
+//	@Failure		404		{object}	ErrorResponse	"Application, service or component not found"
+//	@Failure		409		{object}	ErrorResponse	"Application is being deployed or deleted"
+//	@Failure		500		{object}	ErrorResponse	"Internal Server Error"
+//	@Failure		501		{object}	ErrorResponse	"Not supported on the OpenShift runtime"
+//	@Router			/applications/{id}/restart [post]
+func (h *ApplicationHandler) RestartApplication(c *gin.Context) {
+	h.handleLifecycle(c, h.appService.RestartApplication)
+}
+
+// lifecycleAction is a service method that starts, stops or restarts an application.
+type lifecycleAction func(ctx context.Context, id uuid.UUID, user string, req models.ApplicationLifecycleRequest) (*models.ApplicationLifecycleResponse, error)
+
+// handleLifecycle parses a start, stop or restart request, whose body is optional, and runs action.
+func (h *ApplicationHandler) handleLifecycle(c *gin.Context, action lifecycleAction) {
+	appID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrInvalidIDParameter)
+
+		return
+	}
+
+	userID := c.GetString(middleware.CtxUserIDKey)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "User not authenticated"})
+
+		return
+	}
+
+	var req models.ApplicationLifecycleRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("Invalid request body: %v", err)})
+
+			return
+		}
+	}
+
+	response, err := action(c.Request.Context(), appID, userID, req)
+	if err != nil {
+		if valErr, ok := err.(*repository.ValidationError); ok {
+			c.JSON(valErr.Code, ErrorResponse{Error: valErr.Message})
+
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("Failed to change application state: %v", err)})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // ApplicationPS godoc
 //
 //	@Summary		Get application process status
@@ -419,4 +643,223 @@ func (h *ApplicationHandler) ApplicationPS(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// ApplicationEvents godoc
+//
+//	@Summary		Stream application deployment events
+//	@Description	Streams the deployment progress events of an application as Server-Sent Events: image pulls and model downloads, pods created, readiness waits, routes registered, and the completion or failure of the deployment with its cause. Recorded events are replayed first, then new ones follow as they happen. Each message carries the event's sequence number as its id, its step as its event name and the event as JSON data. The stream stays open until the client disconnects or the application is deleted.
+//	@Tags			Applications
+//	@Produce		text/event-stream
+//	@Security		BearerAuth
+//	@Param			id				path		string	true	"Application ID (UUID)"
+//	@Param			after			query		int		false	"Replay only events with a greater sequence number"
+//	@Param			Last-Event-ID	header		int		false	"Sequence number of the last event received; takes precedence over after"
+//	@Success		200				{object}	models.ApplicationEvent	"Stream of events"
+//	@Failure		400				{object}	ErrorResponse			"Invalid application ID or sequence number"
+//	@Failure		401				{object}	ErrorResponse			"Unauthorized"
+//	@Failure		404				{object}	ErrorResponse			"Application not found"
+//	@Failure		500				{object}	ErrorResponse			"Internal Server Error"
+//	@Router			/applications/{id}/events [get]
+func (h *ApplicationHandler) ApplicationEvents(c *gin.Context) {
+	appID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrInvalidIDParameter)
+
+		return
+	}
+
+	afterSeq, err := lastEventSeq(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+
+		return
+	}
+
+	backlog, live, cancel, err := h.appService.SubscribeApplicationEvents(c.Request.Context(), appID, afterSeq)
+	if err != nil {
+		if valErr, ok := err.(*repository.ValidationError); ok {
+			c.JSON(valErr.Code, ErrorResponse{Error: valErr.Message})
+
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error: fmt.Sprintf("Failed to subscribe to application events: %v", err),
+		})
+
+		return
+	}
+	defer cancel()
+
+	streamApplicationEvents(c, backlog, live)
+}
+
+// lastEventSeq returns the sequence number after which events are replayed, read from the
+// Last-Event-ID header a reconnecting EventSource sends or else from the after query parameter.
+func lastEventSeq(c *gin.Context) (int64, error) {
+	value := c.GetHeader("Last-Event-ID")
+	if value == "" {
+		value = c.Query("after")
+	}
+	if value == "" {
+		return 0, nil
+	}
+
+	seq, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seq < 0 {
+		return 0, fmt.Errorf("invalid event sequence number %q", value)
+	}
+
+	return seq, nil
+}
+
+// streamApplicationEvents writes backlog and then every event received from live as
+// Server-Sent Events until live is closed or the client goes away. A comment is sent
+// periodically so that idle connections are not dropped by proxies.
+func streamApplicationEvents(c *gin.Context, backlog []models.ApplicationEvent, live <-chan models.ApplicationEvent) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	for _, event := range backlog {
+		writeApplicationEvent(c.Writer, event)
+	}
+	c.Writer.Flush()
+
+	keepAlive := time.NewTicker(eventStreamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, ok := <-live:
+			if !ok {
+				return
+			}
+			writeApplicationEvent(c.Writer, event)
+		case <-keepAlive.C:
+			_, _ = io.WriteString(c.Writer, ": keep-alive\n\n")
+		}
+		c.Writer.Flush()
+	}
+}
+
+// writeApplicationEvent writes event as one Server-Sent Events message.
+func writeApplicationEvent(w io.Writer, event models.ApplicationEvent) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Seq, event.Step, data)
+}
+
+// PodLogs godoc
+//
+//	@Summary		Read pod logs
+//	@Description	Streams the logs of a pod of the application as chunked plain text, each container's preceded by a header line unless container selects one. With follow, new lines are streamed until the container exits or the client disconnects; for pods with several containers select one, as the containers are followed one after another. Only pods of the application's services and components can be read.
+//	@Tags			Applications
+//	@Produce		plain
+//	@Security		BearerAuth
+//	@Param			id			path		string	true	"Application ID (UUID)"
+//	@Param			pod			path		string	true	"Pod name or ID, as listed by GET /applications/{id}/ps, or a unique prefix of one"
+//	@Param			container	query		string	false	"Container name or ID; all containers when omitted"
+//	@Param			tail		query		int		false	"Number of lines to show from the end of the logs; all when omitted"
+//	@Param			since		query		string	false	"Only show lines newer than a relative duration like 30s or 1h"
+//	@Param			follow		query		bool	false	"Keep streaming new lines"	default(false)
+//	@Success		200			{string}	string	"Log lines"
+//	@Failure		400			{object}	ErrorResponse	"Invalid application ID or query parameter, or ambiguous pod"
+//	@Failure		401			{object}	ErrorResponse	"Unauthorized"
+//	@Failure		404			{object}	ErrorResponse	"Application, pod or container not found"
+//	@Failure		500			{object}	ErrorResponse	"Internal Server Error"
+//	@Failure		503			{object}	ErrorResponse	"Runtime of the application unreachable"
+//	@Router			/applications/{id}/pods/{pod}/logs [get]
+func (h *ApplicationHandler) PodLogs(c *gin.Context) {
+	appID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrInvalidIDParameter)
+
+		return
+	}
+
+	opts, err := logOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+
+		return
+	}
+
+	stream, err := h.appService.PodLogs(c.Request.Context(), appID, c.Param("pod"), c.Query("container"))
+	if err != nil {
+		if valErr, ok := err.(*repository.ValidationError); ok {
+			c.JSON(valErr.Code, ErrorResponse{Error: valErr.Message})
+
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("Failed to get pod logs: %v", err)})
+
+		return
+	}
+
+	w := &logWriter{c: c}
+	if err := stream(c.Request.Context(), w, opts); err != nil {
+		if !w.started {
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("Failed to get pod logs: %v", err)})
+
+			return
+		}
+		logger.ErrorfCtx(c.Request.Context(), "Log stream of pod %s of application %s ended: %v", c.Param("pod"), appID, err)
+	}
+}
+
+// logOptions reads the tail, since and follow query parameters of a logs request.
+func logOptions(c *gin.Context) (runtimeTypes.LogOptions, error) {
+	var opts runtimeTypes.LogOptions
+	var err error
+
+	if value := c.Query("tail"); value != "" {
+		if opts.Tail, err = strconv.Atoi(value); err != nil || opts.Tail < 0 {
+			return opts, fmt.Errorf("invalid tail %q: must be a non-negative number of lines", value)
+		}
+	}
+	if value := c.Query("since"); value != "" {
+		if opts.Since, err = time.ParseDuration(value); err != nil || opts.Since < 0 {
+			return opts, fmt.Errorf("invalid since %q: must be a non-negative duration like 30s or 1h", value)
+		}
+	}
+	if value := c.Query("follow"); value != "" {
+		if opts.Follow, err = strconv.ParseBool(value); err != nil {
+			return opts, fmt.Errorf("invalid follow %q: must be true or false", value)
+		}
+	}
+
+	return opts, nil
+}
+
+// logWriter writes log lines to the response as they arrive. The response headers are
+// sent with the first line, so a stream that fails before writing anything can still
+// be answered with an error.
+type logWriter struct {
+	c       *gin.Context
+	started bool
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.c.Header("Content-Type", "text/plain; charset=utf-8")
+		w.c.Header("Cache-Control", "no-cache")
+		w.c.Header("X-Content-Type-Options", "nosniff")
+		w.c.Header("X-Accel-Buffering", "no")
+		w.c.Status(http.StatusOK)
+	}
+
+	n, err := w.c.Writer.Write(p)
+	w.c.Writer.Flush()
+
+	return n, err
+}
+
 // Made with Bob
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+)
+
+func TestLogOptions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name    string
+		query   string
+		want    runtimeTypes.LogOptions
+		wantErr bool
+	}{
+		{name: "defaults", query: "", want: runtimeTypes.LogOptions{}},
+		{name: "all set", query: "tail=100&since=1h&follow=true", want: runtimeTypes.LogOptions{Tail: 100, Since: time.Hour, Follow: true}},
+		{name: "negative tail", query: "tail=-1", wantErr: true},
+		{name: "invalid since", query: "since=yesterday", wantErr: true},
+		{name: "invalid follow", query: "follow=maybe", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("GET", "/api/v1/applications/id/pods/pod/logs?"+tt.query, nil)
+
+			got, err := logOptions(c)
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// Made with Bob
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository"
+	auditsvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/audit"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+)
+
+// AuditHandler serves the audit log.
+type AuditHandler struct {
+	auditService auditsvc.AuditServiceInterface
+}
+
+// NewAuditHandler creates a new AuditHandler backed by the given AuditServiceInterface.
+func NewAuditHandler(svc auditsvc.AuditServiceInterface) *AuditHandler {
+	return &AuditHandler{auditService: svc}
+}
+
+// ListAuditEvents godoc
+//
+//	@Summary		List audit events
+//	@Description	Returns a paginated list of recorded mutating API calls, newest first. Every filter is optional.
+//	@Tags			Audit
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			user_id			query		string	false	"Filter by caller user ID"
+//	@Param			action			query		string	false	"Filter by action, e.g. application.delete"
+//	@Param			resource_type	query		string	false	"Filter by resource type, e.g. application"
+//	@Param			resource_id		query		string	false	"Filter by resource ID"
+//	@Param			outcome			query		string	false	"Filter by outcome"	Enums(success, denied, failure)
+//	@Param			since			query		string	false	"Only events at or after this time (RFC 3339)"
+//	@Param			until			query		string	false	"Only events before this time (RFC 3339)"
+//	@Param			page			query		int		false	"Page number (1-indexed)"				default(1)
+//	@Param			page_size		query		int		false	"Number of items per page (max: 100)"	default(20)
+//	@Success		200				{object}	auditsvc.AuditListResponse
+//	@Failure		400				{object}	ErrorResponse	"Invalid filter or pagination parameters"
+//	@Failure		401				{object}	ErrorResponse
+//	@Failure		403				{object}	ErrorResponse	"Forbidden — admin role required"
+//	@Failure		500				{object}	ErrorResponse
+//	@Router			/audit [get]
+func (h *AuditHandler) ListAuditEvents(c *gin.Context) {
+	page, _ := strconv.Atoi(c.Query("page"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size"))
+
+	page, pageSize, err := repository.ValidatePaginationParams(page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+
+		return
+	}
+
+	req := auditsvc.AuditListRequest{
+		UserID:       c.Query("user_id"),
+		Action:       c.Query("action"),
+		ResourceType: c.Query("resource_type"),
+		ResourceID:   c.Query("resource_id"),
+		Outcome:      c.Query("outcome"),
+		Page:         page,
+		PageSize:     pageSize,
+	}
+	if req.Since, err = parseTimeQuery(c, "since"); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+
+		return
+	}
+	if req.Until, err = parseTimeQuery(c, "until"); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+
+		return
+	}
+
+	resp, err := h.auditService.ListEvents(c.Request.Context(), req)
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// parseTimeQuery parses the RFC 3339 query parameter name, returning nil when it is absent.
+func parseTimeQuery(c *gin.Context, name string) (*time.Time, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, errors.New("invalid " + name + " parameter: must be an RFC 3339 time")
+	}
+
+	return &t, nil
+}
+
+// mapServiceError translates a validators.ValidationError into the appropriate
+// HTTP status, and falls back to 500 for all other errors.
+func (h *AuditHandler) mapServiceError(c *gin.Context, err error) {
+	var valErr *validators.ValidationError
+	if errors.As(err, &valErr) {
+		c.JSON(valErr.Code, ErrorResponse{Error: valErr.Message})
+
+		return
+	}
+	c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+}
+
+// Made with Bob
@@ -7,8 +7,10 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/middleware"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/auth"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/miq"
+	catalogutils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
 )
 
 type AuthHandler struct {
@@ -35,6 +37,7 @@ type loginReq struct {
 //	@Success		200			{object}	map[string]interface{}	"Returns access_token, refresh_token, and token_type"
 //	@Failure		400			{object}	map[string]interface{}	"Invalid payload"
 //	@Failure		401			{object}	map[string]interface{}	"Invalid credentials"
+//	@Failure		403			{object}	map[string]interface{}	"User is disabled"
 //	@Router			/auth/login [post].
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req loginReq
@@ -46,6 +49,11 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	access, refresh, err := h.svc.Login(c.Request.Context(), req.UserName, req.Password)
 	if err != nil {
+		if errors.Is(err, auth.ErrUserDisabled) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "user is disabled"})
+
+			return
+		}
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
 
 		return
@@ -133,7 +141,7 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 //	@Tags			Authentication
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Success		200	{object}	map[string]interface{}	"Returns user id, username, and name"
+//	@Success		200	{object}	map[string]interface{}	"Returns user id, username, name, and role"
 //	@Failure		401	{object}	map[string]interface{}	"Unauthorized"
 //	@Failure		404	{object}	map[string]interface{}	"User not found"
 //	@Router			/auth/me [get]
@@ -154,9 +162,55 @@ func (h *AuthHandler) Me(c *gin.Context) {
 		"id":       u.ID,
 		"username": u.UserName,
 		"name":     u.Name,
+		"role":     c.GetString(middleware.CtxRoleKey),
 	})
 }
 
+type changePasswordReq struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+// ChangePassword godoc
+//
+//	@Summary		Change own password
+//	@Description	Replace the password of the currently authenticated user. The current password is required.
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			password	body		changePasswordReq		true	"Current and new password"
+//	@Success		200			{object}	map[string]interface{}	"Password changed"
+//	@Failure		400			{object}	map[string]interface{}	"Invalid payload or new password"
+//	@Failure		401			{object}	map[string]interface{}	"Unauthorized or wrong current password"
+//	@Failure		404			{object}	map[string]interface{}	"User not found"
+//	@Router			/auth/password [post]
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	var req changePasswordReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+
+		return
+	}
+
+	userID := c.GetString(middleware.CtxUserIDKey)
+	middleware.SetAuditResourceID(c, userID)
+	err := h.svc.ChangePassword(c.Request.Context(), userID, req.CurrentPassword, req.NewPassword)
+	var pwErr *catalogutils.PasswordError
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, gin.H{"message": "password changed"})
+	case errors.Is(err, auth.ErrInvalidCredentials):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "current password is incorrect"})
+	case errors.As(err, &pwErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": pwErr.Error()})
+	case errors.Is(err, repository.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to change password"})
+	}
+}
+
 // TokenLogin godoc
 //
 //	@Summary		Exchange a ManageIQ token for a Catalog API JWT
@@ -169,8 +223,9 @@ func (h *AuthHandler) Me(c *gin.Context) {
 //	@Security		BearerAuth
 //	@Success		200	{object}	map[string]interface{}	"Returns access_token, refresh_token, and token_type"
 //	@Failure		401	{object}	map[string]interface{}	"Invalid or expired ManageIQ token"
-//	@Failure		403	{object}	map[string]interface{}	"ManageIQ token does not have required permissions"
+//	@Failure		403	{object}	map[string]interface{}	"ManageIQ token does not have required permissions, or the user is disabled"
 //	@Failure		404	{object}	map[string]interface{}	"ManageIQ resource not found"
+//	@Failure		409	{object}	map[string]interface{}	"Username is already used by another user"
 //	@Failure		503	{object}	map[string]interface{}	"ManageIQ unavailable or returned an unexpected server error"
 //	@Router			/auth/token [post]
 func (h *AuthHandler) TokenLogin(c *gin.Context) {
@@ -189,6 +244,16 @@ func (h *AuthHandler) TokenLogin(c *gin.Context) {
 
 			return
 		}
+		if errors.Is(err, auth.ErrUserDisabled) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "user is disabled"})
+
+			return
+		}
+		if errors.Is(err, auth.ErrUsernameTaken) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+
+			return
+		}
 		var manageIQErr *miq.ManageIQError
 		if errors.As(err, &manageIQErr) && manageIQErr.StatusCode >= 400 && manageIQErr.StatusCode < 500 {
 			c.JSON(manageIQErr.StatusCode, gin.H{"error": manageIQErr.Message})
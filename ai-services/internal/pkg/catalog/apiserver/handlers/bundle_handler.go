@@ -74,6 +74,7 @@ func (h *BundleHandler) CreateBundle(c *gin.Context) {
 		return
 	}
 
+	middleware.SetAuditResourceID(c, resp.ID)
 	c.Header("Location", fmt.Sprintf("/api/v1/catalog/bundles/%s", resp.ID))
 	c.JSON(http.StatusCreated, resp)
 }
@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/middleware"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository"
+	connectorsvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/connector"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+)
+
+// ConnectorHandler handles the connector instances configured from the catalog's
+// connector providers.
+type ConnectorHandler struct {
+	connectorService connectorsvc.ConnectorServiceInterface
+}
+
+// NewConnectorHandler creates a new ConnectorHandler backed by the given ConnectorServiceInterface.
+func NewConnectorHandler(svc connectorsvc.ConnectorServiceInterface) *ConnectorHandler {
+	return &ConnectorHandler{connectorService: svc}
+}
+
+// CreateConnector godoc
+//
+//	@Summary		Create a connector
+//	@Description	Creates a connector instance of a catalog connector provider. The metadata is validated
+//	@Description	against the provider's schema (GET /connectors/{connector_type}/providers/{provider_id}/params)
+//	@Description	and its password fields are stored encrypted. New connectors are offline until tested.
+//	@Tags			Connectors
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			connector	body		connectorsvc.CreateConnectorRequest	true	"Connector to create"
+//	@Success		201			{object}	connectorsvc.ConnectorResponse
+//	@Failure		400			{object}	ErrorResponse	"Invalid payload, provider or metadata"
+//	@Failure		401			{object}	ErrorResponse	"Unauthorized"
+//	@Failure		403			{object}	ErrorResponse	"Forbidden — admin role required"
+//	@Failure		409			{object}	ErrorResponse	"Connector name already exists"
+//	@Router			/connectors/instances [post]
+func (h *ConnectorHandler) CreateConnector(c *gin.Context) {
+	var req connectorsvc.CreateConnectorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid payload: " + err.Error()})
+
+		return
+	}
+
+	resp, err := h.connectorService.CreateConnector(c.Request.Context(), c.GetString(middleware.CtxUserIDKey), req)
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	middleware.SetAuditResourceID(c, resp.ID.String())
+	c.JSON(http.StatusCreated, resp)
+}
+
+// ListConnectors godoc
+//
+//	@Summary		List connectors
+//	@Description	Returns a paginated list of connector instances, newest first. Metadata is not included.
+//	@Tags			Connectors
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			page		query		int		false	"Page number (1-indexed)"				default(1)
+//	@Param			page_size	query		int		false	"Number of items per page (max: 100)"	default(20)
+//	@Param			status		query		string	false	"Filter by status (connected or offline)"
+//	@Param			provider	query		string	false	"Filter by provider, e.g. object_storage"
+//	@Success		200			{object}	connectorsvc.ConnectorListResponse
+//	@Failure		400			{object}	ErrorResponse	"Invalid pagination parameters or status"
+//	@Failure		401			{object}	ErrorResponse
+//	@Failure		500			{object}	ErrorResponse
+//	@Router			/connectors/instances [get]
+func (h *ConnectorHandler) ListConnectors(c *gin.Context) {
+	page, _ := strconv.Atoi(c.Query("page"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size"))
+
+	page, pageSize, err := repository.ValidatePaginationParams(page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+
+		return
+	}
+
+	resp, err := h.connectorService.ListConnectors(c.Request.Context(), connectorsvc.ConnectorListRequest{
+		Page:     page,
+		PageSize: pageSize,
+		Status:   models.ConnectorStatus(c.Query("status")),
+		Provider: c.Query("provider"),
+	})
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetConnector godoc
+//
+//	@Summary		Get a connector
+//	@Description	Returns a connector instance with its metadata. Password fields are never returned.
+//	@Tags			Connectors
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Connector ID"
+//	@Success		200	{object}	connectorsvc.ConnectorResponse
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse	"Connector not found"
+//	@Router			/connectors/instances/{id} [get]
+func (h *ConnectorHandler) GetConnector(c *gin.Context) {
+	resp, err := h.connectorService.GetConnector(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// UpdateConnector godoc
+//
+//	@Summary		Update a connector
+//	@Description	Replaces the metadata of a connector instance. Password fields that are left out keep
+//	@Description	their stored value. The connector is offline until it is tested again.
+//	@Tags			Connectors
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		string								true	"Connector ID"
+//	@Param			connector	body		connectorsvc.UpdateConnectorRequest	true	"New metadata"
+//	@Success		200			{object}	connectorsvc.ConnectorResponse
+//	@Failure		400			{object}	ErrorResponse	"Invalid payload or metadata"
+//	@Failure		401			{object}	ErrorResponse
+//	@Failure		403			{object}	ErrorResponse	"Forbidden — admin role required"
+//	@Failure		404			{object}	ErrorResponse	"Connector not found"
+//	@Router			/connectors/instances/{id} [put]
+func (h *ConnectorHandler) UpdateConnector(c *gin.Context) {
+	var req connectorsvc.UpdateConnectorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid payload: " + err.Error()})
+
+		return
+	}
+
+	resp, err := h.connectorService.UpdateConnector(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteConnector godoc
+//
+//	@Summary		Delete a connector
+//	@Description	Deletes a connector instance that no application service uses.
+//	@Tags			Connectors
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"Connector ID"
+//	@Success		204
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse	"Forbidden — admin role required"
+//	@Failure		404	{object}	ErrorResponse	"Connector not found"
+//	@Failure		409	{object}	ErrorResponse	"Connector is in use"
+//	@Router			/connectors/instances/{id} [delete]
+func (h *ConnectorHandler) DeleteConnector(c *gin.Context) {
+	if err := h.connectorService.DeleteConnector(c.Request.Context(), c.Param("id")); err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// TestConnector godoc
+//
+//	@Summary		Test a connector
+//	@Description	Checks that the connector reaches its content: object_storage connectors list the bucket
+//	@Description	and file_system connectors list the remote path over SFTP. The connector status is set to
+//	@Description	connected or offline; a failed check still returns 200 with the reason in message.
+//	@Tags			Connectors
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Connector ID"
+//	@Success		200	{object}	connectorsvc.ConnectorTestResponse
+//	@Failure		400	{object}	ErrorResponse	"Provider cannot be tested"
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse	"Forbidden — admin role required"
+//	@Failure		404	{object}	ErrorResponse	"Connector not found"
+//	@Router			/connectors/instances/{id}/test [post]
+func (h *ConnectorHandler) TestConnector(c *gin.Context) {
+	resp, err := h.connectorService.TestConnection(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// mapServiceError translates a validators.ValidationError into the appropriate
+// HTTP status, and falls back to 500 for all other errors.
+func (h *ConnectorHandler) mapServiceError(c *gin.Context, err error) {
+	var valErr *validators.ValidationError
+	if errors.As(err, &valErr) {
+		c.JSON(valErr.Code, ErrorResponse{Error: valErr.Message})
+
+		return
+	}
+	c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+}
+
+// Made with Bob
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/middleware"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+)
+
+// JobHandler handles the deployment and deletion jobs of applications.
+type JobHandler struct {
+	appService repository.ApplicationServiceInterface
+}
+
+// NewJobHandler creates a new JobHandler backed by the given ApplicationServiceInterface.
+func NewJobHandler(appService repository.ApplicationServiceInterface) *JobHandler {
+	return &JobHandler{appService: appService}
+}
+
+// GetJob godoc
+//
+//	@Summary		Get a job
+//	@Description	Returns a deployment or deletion job started by the caller. Creating, reconfiguring and deleting
+//	@Description	an application return the ID of its job. Jobs survive apiserver restarts: an interrupted job is
+//	@Description	resumed from its last checkpoint, or rolled back when it was cancelled or interrupted too often.
+//	@Tags			Jobs
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Job ID (UUID)"
+//	@Success		200	{object}	models.Job
+//	@Failure		400	{object}	ErrorResponse	"Invalid job ID"
+//	@Failure		401	{object}	ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	ErrorResponse	"User doesn't own this job"
+//	@Failure		404	{object}	ErrorResponse	"Job not found"
+//	@Failure		500	{object}	ErrorResponse	"Internal Server Error"
+//	@Router			/jobs/{id} [get]
+func (h *JobHandler) GetJob(c *gin.Context) {
+	h.handleJob(c, h.appService.GetJob)
+}
+
+// CancelJob godoc
+//
+//	@Summary		Cancel a job
+//	@Description	Cancels a running create or reconfigure job started by the caller. The deployment stops at its
+//	@Description	next step and the application is set to Error; what was deployed so far stays until the
+//	@Description	application is reconfigured or deleted. Deletion jobs cannot be cancelled.
+//	@Tags			Jobs
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Job ID (UUID)"
+//	@Success		202	{object}	models.Job
+//	@Failure		400	{object}	ErrorResponse	"Invalid job ID"
+//	@Failure		401	{object}	ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	ErrorResponse	"User doesn't own this job"
+//	@Failure		404	{object}	ErrorResponse	"Job not found"
+//	@Failure		409	{object}	ErrorResponse	"Job already finished, or is a deletion job"
+//	@Failure		500	{object}	ErrorResponse	"Internal Server Error"
+//	@Router			/jobs/{id}/cancel [post]
+func (h *JobHandler) CancelJob(c *gin.Context) {
+	h.handleJob(c, h.appService.CancelJob)
+}
+
+// handleJob calls op with the job ID from the path and the caller, and writes the job it returns.
+func (h *JobHandler) handleJob(c *gin.Context, op func(ctx context.Context, id uuid.UUID, user string) (*models.Job, error)) {
+	jobID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid job ID"})
+
+		return
+	}
+
+	job, err := op(c.Request.Context(), jobID, c.GetString(middleware.CtxUserIDKey))
+	if err != nil {
+		if valErr, ok := err.(*repository.ValidationError); ok {
+			c.JSON(valErr.Code, ErrorResponse{Error: valErr.Message})
+
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "internal server error"})
+
+		return
+	}
+
+	status := http.StatusOK
+	if c.Request.Method == http.MethodPost {
+		middleware.SetAuditResourceID(c, jobID.String())
+		status = http.StatusAccepted
+	}
+	c.JSON(status, job)
+}
+
+// Made with Bob
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/auth"
+)
+
+// jwksMaxAge is how long clients may cache the key set. A verifier that sees an
+// unknown key ID should fetch it again.
+const jwksCacheControl = "public, max-age=300"
+
+type JWKSHandler struct {
+	tokens *auth.TokenManager
+}
+
+func NewJWKSHandler(tokens *auth.TokenManager) *JWKSHandler {
+	return &JWKSHandler{tokens: tokens}
+}
+
+// JWKS serves the public keys that verify catalog access and refresh tokens as a
+// JSON Web Key Set, so other components can verify tokens without a shared secret.
+func (h *JWKSHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", jwksCacheControl)
+	c.JSON(http.StatusOK, h.tokens.JWKS())
+}
+
+// Made with Bob
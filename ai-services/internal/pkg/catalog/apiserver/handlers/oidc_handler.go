@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/oauth2"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/auth"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/oidc"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+const (
+	// oidcCookieName holds the state, nonce and PKCE verifier of a browser login
+	// between /auth/oidc/login and /auth/oidc/callback.
+	oidcCookieName   = "catalog_oidc"
+	oidcCookiePath   = "/api/v1/auth/oidc"
+	oidcCookieMaxAge = 600 // seconds
+)
+
+type OIDCHandler struct {
+	svc auth.OIDCService
+}
+
+func NewOIDCHandler(svc auth.OIDCService) *OIDCHandler {
+	return &OIDCHandler{svc: svc}
+}
+
+// OIDCLogin godoc
+//
+//	@Summary		Start OIDC login
+//	@Description	Redirect the browser to the identity provider to sign in with the authorization code flow and PKCE
+//	@Tags			Authentication
+//	@Success		302	"Redirect to the identity provider"
+//	@Router			/auth/oidc/login [get]
+func (h *OIDCHandler) OIDCLogin(c *gin.Context) {
+	// GenerateVerifier returns 32 random bytes, base64url encoded; it is also
+	// suitable for the state and nonce.
+	state, nonce, verifier := oauth2.GenerateVerifier(), oauth2.GenerateVerifier(), oauth2.GenerateVerifier()
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcCookieName, strings.Join([]string{state, nonce, verifier}, "."), oidcCookieMaxAge, oidcCookiePath, "", isHTTPS(c), true)
+	c.Redirect(http.StatusFound, h.svc.AuthCodeURL(state, nonce, verifier))
+}
+
+// OIDCCallback godoc
+//
+//	@Summary		Complete OIDC login
+//	@Description	Exchange the authorization code returned by the identity provider for access and refresh tokens
+//	@Tags			Authentication
+//	@Produce		json
+//	@Param			code	query		string					true	"Authorization code"
+//	@Param			state	query		string					true	"State from the login redirect"
+//	@Success		200		{object}	map[string]interface{}	"Returns access_token, refresh_token, and token_type"
+//	@Failure		400		{object}	map[string]interface{}	"Missing or mismatched state"
+//	@Failure		401		{object}	map[string]interface{}	"Login rejected by the identity provider"
+//	@Failure		403		{object}	map[string]interface{}	"User is disabled"
+//	@Failure		409		{object}	map[string]interface{}	"Username is already used by another user"
+//	@Router			/auth/oidc/callback [get]
+func (h *OIDCHandler) OIDCCallback(c *gin.Context) {
+	if idpErr := c.Query("error"); idpErr != "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "identity provider returned " + idpErr})
+
+		return
+	}
+
+	cookie, err := c.Cookie(oidcCookieName)
+	// The cookie is single use.
+	c.SetCookie(oidcCookieName, "", -1, oidcCookiePath, "", isHTTPS(c), true)
+	parts := strings.Split(cookie, ".")
+	if err != nil || len(parts) != 3 || subtle.ConstantTimeCompare([]byte(parts[0]), []byte(c.Query("state"))) != 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or expired login state"})
+
+		return
+	}
+
+	access, refresh, err := h.svc.LoginWithCode(c.Request.Context(), c.Query("code"), parts[2], parts[1])
+	if err != nil {
+		h.loginError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"access_token":  access,
+		"refresh_token": refresh,
+		"token_type":    "Bearer",
+	})
+}
+
+// OIDCDeviceStart godoc
+//
+//	@Summary		Start OIDC device login
+//	@Description	Request a device and user code from the identity provider for CLI login
+//	@Tags			Authentication
+//	@Produce		json
+//	@Success		200	{object}	oidc.DeviceAuthorization
+//	@Failure		501	{object}	map[string]interface{}	"Identity provider does not support device login"
+//	@Failure		502	{object}	map[string]interface{}	"Identity provider error"
+//	@Router			/auth/oidc/device [post]
+func (h *OIDCHandler) OIDCDeviceStart(c *gin.Context) {
+	device, err := h.svc.StartDeviceLogin(c.Request.Context())
+	if err != nil {
+		if errors.Is(err, oidc.ErrDeviceNotSupported) {
+			c.JSON(http.StatusNotImplemented, gin.H{"error": err.Error()})
+
+			return
+		}
+		logger.ErrorfCtx(c.Request.Context(), "OIDC device authorization failed: %v", err)
+		c.JSON(http.StatusBadGateway, gin.H{"error": "identity provider error"})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, device)
+}
+
+type deviceTokenReq struct {
+	DeviceCode string `json:"device_code" binding:"required"`
+}
+
+// OIDCDeviceToken godoc
+//
+//	@Summary		Complete OIDC device login
+//	@Description	Poll for the tokens of a device login. Returns 400 with error authorization_pending or slow_down until the user has signed in.
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		deviceTokenReq			true	"Device code"
+//	@Success		200		{object}	map[string]interface{}	"Returns access_token, refresh_token, and token_type"
+//	@Failure		400		{object}	map[string]interface{}	"authorization_pending, slow_down or invalid payload"
+//	@Failure		401		{object}	map[string]interface{}	"Login denied or device code expired"
+//	@Failure		403		{object}	map[string]interface{}	"User is disabled"
+//	@Failure		409		{object}	map[string]interface{}	"Username is already used by another user"
+//	@Router			/auth/oidc/device/token [post]
+func (h *OIDCHandler) OIDCDeviceToken(c *gin.Context) {
+	var req deviceTokenReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid payload"})
+
+		return
+	}
+
+	access, refresh, err := h.svc.LoginWithDeviceCode(c.Request.Context(), req.DeviceCode)
+	if err != nil {
+		h.loginError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"access_token":  access,
+		"refresh_token": refresh,
+		"token_type":    "Bearer",
+	})
+}
+
+// loginError maps an OIDC login error to a response.
+func (h *OIDCHandler) loginError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, oidc.ErrAuthorizationPending), errors.Is(err, oidc.ErrSlowDown):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, oidc.ErrAccessDenied), errors.Is(err, oidc.ErrDeviceCodeExpired):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, auth.ErrUserDisabled):
+		c.JSON(http.StatusForbidden, gin.H{"error": "user is disabled"})
+	case errors.Is(err, auth.ErrUsernameTaken):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		logger.WarningfCtx(c.Request.Context(), "OIDC login failed: %v", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "OIDC login failed"})
+	}
+}
+
+// isHTTPS reports whether the client reached the server over HTTPS, directly or
+// through a TLS-terminating proxy.
+func isHTTPS(c *gin.Context) bool {
+	return c.Request.TLS != nil || strings.EqualFold(c.GetHeader("X-Forwarded-Proto"), "https")
+}
+
+// Made with Bob
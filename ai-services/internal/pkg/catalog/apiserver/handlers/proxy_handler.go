@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/httpproxy"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+// ProxyHandler forwards HTTP requests to the services of an application.
+type ProxyHandler struct {
+	appService repository.ApplicationServiceInterface
+	forwarder  *httpproxy.Forwarder
+}
+
+// NewProxyHandler creates a new proxy handler.
+func NewProxyHandler(appService repository.ApplicationServiceInterface, forwarder *httpproxy.Forwarder) *ProxyHandler {
+	return &ProxyHandler{appService: appService, forwarder: forwarder}
+}
+
+// ProxyService godoc
+//
+//	@Summary		Proxy a request to an application service
+//	@Description	Forwards the request (any method, query string and body) to the API port of a running pod of the service and
+//	@Description	streams the response back, so clients can call the chat, similarity or digitize APIs through the catalog
+//	@Description	server. Pods on a worker are reached through the worker's command stream; there the request body is limited to 2 MiB.
+//	@Description	The Authorization header is not forwarded.
+//	@Tags			Applications
+//	@Security		BearerAuth
+//	@Param			id		path	string	true	"Application ID (UUID)"
+//	@Param			service	path	string	true	"Service ID (UUID) or catalog ID (e.g., 'chat')"
+//	@Param			path	path	string	true	"Path on the service, e.g. 'v1/chat/completions'"
+//	@Success		200		"Response of the service, passed through unchanged"
+//	@Failure		400		{object}	ErrorResponse	"Invalid application ID format"
+//	@Failure		401		{object}	ErrorResponse	"Unauthorized"
+//	@Failure		404		{object}	ErrorResponse	"Application or service not found"
+//	@Failure		413		{object}	ErrorResponse	"Request body too large for the worker tunnel"
+//	@Failure		501		{object}	ErrorResponse	"Not supported on this runtime"
+//	@Failure		502		{object}	ErrorResponse	"Service pod could not be reached"
+//	@Failure		503		{object}	ErrorResponse	"No running pod exposes the service"
+//	@Router			/applications/{id}/services/{service}/proxy/{path} [get]
+//	@Router			/applications/{id}/services/{service}/proxy/{path} [post]
+//	@Router			/applications/{id}/services/{service}/proxy/{path} [put]
+//	@Router			/applications/{id}/services/{service}/proxy/{path} [patch]
+//	@Router			/applications/{id}/services/{service}/proxy/{path} [delete]
+func (h *ProxyHandler) ProxyService(c *gin.Context) {
+	appID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrInvalidIDParameter)
+
+		return
+	}
+
+	target, err := h.appService.ResolveServiceProxy(c.Request.Context(), appID, c.Param("service"))
+	if err != nil {
+		if valErr, ok := err.(*repository.ValidationError); ok {
+			c.JSON(valErr.Code, ErrorResponse{Error: valErr.Message})
+
+			return
+		}
+
+		logger.ErrorfCtx(c.Request.Context(), "Failed to resolve service %s of application %s: %v", c.Param("service"), appID, err)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: fmt.Sprintf("Failed to resolve service: %v", err)})
+
+		return
+	}
+
+	h.forwarder.Forward(c.Writer, c.Request, *target, c.Param("path"))
+}
+
+// Made with Bob
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/middleware"
+	usersvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/user"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+)
+
+// UserHandler handles management of catalog API users.
+type UserHandler struct {
+	userService usersvc.UserServiceInterface
+}
+
+// NewUserHandler creates a new UserHandler backed by the given UserServiceInterface.
+func NewUserHandler(svc usersvc.UserServiceInterface) *UserHandler {
+	return &UserHandler{userService: svc}
+}
+
+// CreateUser godoc
+//
+//	@Summary		Create a user
+//	@Description	Creates a local user that logs in with a password. The role defaults to viewer.
+//	@Tags			Users
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			user	body		usersvc.CreateUserRequest	true	"User to create"
+//	@Success		201		{object}	usersvc.UserResponse
+//	@Failure		400		{object}	ErrorResponse	"Invalid payload, role or password"
+//	@Failure		401		{object}	ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse	"Forbidden — admin role required"
+//	@Failure		409		{object}	ErrorResponse	"Username already exists"
+//	@Router			/users [post]
+func (h *UserHandler) CreateUser(c *gin.Context) {
+	var req usersvc.CreateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid payload: " + err.Error()})
+
+		return
+	}
+
+	resp, err := h.userService.CreateUser(c.Request.Context(), req)
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	middleware.SetAuditResourceID(c, resp.ID)
+	c.JSON(http.StatusCreated, resp)
+}
+
+// ListUsers godoc
+//
+//	@Summary		List users
+//	@Description	Returns all users ordered by username, including users signed in through ManageIQ.
+//	@Tags			Users
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	usersvc.UserListResponse
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/users [get]
+func (h *UserHandler) ListUsers(c *gin.Context) {
+	resp, err := h.userService.ListUsers(c.Request.Context())
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetUser godoc
+//
+//	@Summary		Get a user
+//	@Tags			Users
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"User ID or username"
+//	@Success		200	{object}	usersvc.UserResponse
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse	"User not found"
+//	@Router			/users/{id} [get]
+func (h *UserHandler) GetUser(c *gin.Context) {
+	resp, err := h.userService.GetUser(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// DisableUser godoc
+//
+//	@Summary		Disable a user
+//	@Description	Disables a user so it can no longer log in or refresh its tokens.
+//	@Description	Access tokens already issued stay valid until they expire.
+//	@Tags			Users
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"User ID or username"
+//	@Success		200	{object}	usersvc.UserResponse
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse	"User not found"
+//	@Failure		409	{object}	ErrorResponse	"Cannot disable your own user"
+//	@Router			/users/{id}/disable [post]
+func (h *UserHandler) DisableUser(c *gin.Context) {
+	h.setDisabled(c, true)
+}
+
+// EnableUser godoc
+//
+//	@Summary		Enable a user
+//	@Description	Re-enables a disabled user.
+//	@Tags			Users
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"User ID or username"
+//	@Success		200	{object}	usersvc.UserResponse
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse	"User not found"
+//	@Router			/users/{id}/enable [post]
+func (h *UserHandler) EnableUser(c *gin.Context) {
+	h.setDisabled(c, false)
+}
+
+func (h *UserHandler) setDisabled(c *gin.Context, disabled bool) {
+	resp, err := h.userService.SetDisabled(c.Request.Context(), c.GetString(middleware.CtxUserIDKey), c.Param("id"), disabled)
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteUser godoc
+//
+//	@Summary		Delete a user
+//	@Description	Deletes a user. Access tokens already issued stay valid until they expire.
+//	@Tags			Users
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"User ID or username"
+//	@Success		204
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse	"User not found"
+//	@Failure		409	{object}	ErrorResponse	"Cannot delete your own user"
+//	@Router			/users/{id} [delete]
+func (h *UserHandler) DeleteUser(c *gin.Context) {
+	if err := h.userService.DeleteUser(c.Request.Context(), c.GetString(middleware.CtxUserIDKey), c.Param("id")); err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ResetPassword godoc
+//
+//	@Summary		Reset the password of a user
+//	@Description	Sets a new password for a user without requiring the current one.
+//	@Tags			Users
+//	@Accept			json
+//	@Security		BearerAuth
+//	@Param			id			path	string							true	"User ID or username"
+//	@Param			password	body	usersvc.ResetPasswordRequest	true	"New password"
+//	@Success		204
+//	@Failure		400	{object}	ErrorResponse	"Invalid payload or password"
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse
+//	@Failure		404	{object}	ErrorResponse	"User not found"
+//	@Router			/users/{id}/password [post]
+func (h *UserHandler) ResetPassword(c *gin.Context) {
+	var req usersvc.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid payload: " + err.Error()})
+
+		return
+	}
+
+	if err := h.userService.ResetPassword(c.Request.Context(), c.Param("id"), req.Password); err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// mapServiceError translates a validators.ValidationError into the appropriate
+// HTTP status, and falls back to 500 for all other errors.
+func (h *UserHandler) mapServiceError(c *gin.Context, err error) {
+	var valErr *validators.ValidationError
+	if errors.As(err, &valErr) {
+		c.JSON(valErr.Code, ErrorResponse{Error: valErr.Message})
+
+		return
+	}
+	c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+}
+
+// Made with Bob
@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/middleware"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository"
+	webhooksvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/webhook"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+)
+
+// WebhookHandler handles the webhooks notified of catalog events and their delivery logs.
+type WebhookHandler struct {
+	webhookService webhooksvc.WebhookServiceInterface
+}
+
+// NewWebhookHandler creates a new WebhookHandler backed by the given WebhookServiceInterface.
+func NewWebhookHandler(svc webhooksvc.WebhookServiceInterface) *WebhookHandler {
+	return &WebhookHandler{webhookService: svc}
+}
+
+// CreateWebhook godoc
+//
+//	@Summary		Create a webhook
+//	@Description	Registers an http or https endpoint that the subscribed events are POSTed to:
+//	@Description	application.failed, application.recovered, service.failed, deployment.finished and
+//	@Description	worker.disconnected. Payloads are signed with HMAC-SHA256 using the webhook secret,
+//	@Description	which is generated when not given and only returned by this call.
+//	@Tags			Webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			webhook	body		webhooksvc.CreateWebhookRequest	true	"Webhook to create"
+//	@Success		201		{object}	webhooksvc.CreateWebhookResponse
+//	@Failure		400		{object}	ErrorResponse	"Invalid payload, URL, events or secret"
+//	@Failure		401		{object}	ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	ErrorResponse	"Forbidden — admin role required"
+//	@Failure		409		{object}	ErrorResponse	"Webhook name already exists"
+//	@Router			/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var req webhooksvc.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid payload: " + err.Error()})
+
+		return
+	}
+
+	resp, err := h.webhookService.CreateWebhook(c.Request.Context(), c.GetString(middleware.CtxUserIDKey), req)
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	middleware.SetAuditResourceID(c, resp.ID.String())
+	c.JSON(http.StatusCreated, resp)
+}
+
+// ListWebhooks godoc
+//
+//	@Summary		List webhooks
+//	@Description	Returns every webhook, newest first. Secrets are not included.
+//	@Tags			Webhooks
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	webhooksvc.WebhookListResponse
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse	"Forbidden — admin role required"
+//	@Failure		500	{object}	ErrorResponse
+//	@Router			/webhooks [get]
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	resp, err := h.webhookService.ListWebhooks(c.Request.Context())
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetWebhook godoc
+//
+//	@Summary		Get a webhook
+//	@Description	Returns a webhook. Its secret is never returned.
+//	@Tags			Webhooks
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Webhook ID"
+//	@Success		200	{object}	webhooksvc.WebhookResponse
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse	"Forbidden — admin role required"
+//	@Failure		404	{object}	ErrorResponse	"Webhook not found"
+//	@Router			/webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	resp, err := h.webhookService.GetWebhook(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// UpdateWebhook godoc
+//
+//	@Summary		Update a webhook
+//	@Description	Changes the fields set in the request; the others keep their value. Setting secret
+//	@Description	rotates the key payloads are signed with.
+//	@Tags			Webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string							true	"Webhook ID"
+//	@Param			webhook	body		webhooksvc.UpdateWebhookRequest	true	"Fields to change"
+//	@Success		200		{object}	webhooksvc.WebhookResponse
+//	@Failure		400		{object}	ErrorResponse	"Invalid payload, URL, events or secret"
+//	@Failure		401		{object}	ErrorResponse
+//	@Failure		403		{object}	ErrorResponse	"Forbidden — admin role required"
+//	@Failure		404		{object}	ErrorResponse	"Webhook not found"
+//	@Failure		409		{object}	ErrorResponse	"Webhook name already exists"
+//	@Router			/webhooks/{id} [put]
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	var req webhooksvc.UpdateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid payload: " + err.Error()})
+
+		return
+	}
+
+	resp, err := h.webhookService.UpdateWebhook(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteWebhook godoc
+//
+//	@Summary		Delete a webhook
+//	@Description	Deletes a webhook together with its delivery log. Pending deliveries are not sent.
+//	@Tags			Webhooks
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"Webhook ID"
+//	@Success		204
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse	"Forbidden — admin role required"
+//	@Failure		404	{object}	ErrorResponse	"Webhook not found"
+//	@Router			/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	if err := h.webhookService.DeleteWebhook(c.Request.Context(), c.Param("id")); err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// TestWebhook godoc
+//
+//	@Summary		Send a test event to a webhook
+//	@Description	Queues a ping event for the webhook, whatever events it subscribes to, and returns its
+//	@Description	delivery. Follow the outcome in GET /webhooks/{id}/deliveries.
+//	@Tags			Webhooks
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Webhook ID"
+//	@Success		202	{object}	webhooksvc.DeliveryResponse
+//	@Failure		401	{object}	ErrorResponse
+//	@Failure		403	{object}	ErrorResponse	"Forbidden — admin role required"
+//	@Failure		404	{object}	ErrorResponse	"Webhook not found"
+//	@Failure		409	{object}	ErrorResponse	"Webhook is disabled"
+//	@Router			/webhooks/{id}/test [post]
+func (h *WebhookHandler) TestWebhook(c *gin.Context) {
+	resp, err := h.webhookService.TestWebhook(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusAccepted, resp)
+}
+
+// ListWebhookDeliveries godoc
+//
+//	@Summary		List the deliveries of a webhook
+//	@Description	Returns the delivery log of a webhook, newest first: every event sent or to be sent,
+//	@Description	with its payload, attempts and the outcome of the last attempt. Failed attempts are
+//	@Description	retried with exponential backoff until the delivery succeeds or is marked failed.
+//	@Tags			Webhooks
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		string	true	"Webhook ID"
+//	@Param			page		query		int		false	"Page number (1-indexed)"				default(1)
+//	@Param			page_size	query		int		false	"Number of items per page (max: 100)"	default(20)
+//	@Success		200			{object}	webhooksvc.DeliveryListResponse
+//	@Failure		400			{object}	ErrorResponse	"Invalid pagination parameters"
+//	@Failure		401			{object}	ErrorResponse
+//	@Failure		403			{object}	ErrorResponse	"Forbidden — admin role required"
+//	@Failure		404			{object}	ErrorResponse	"Webhook not found"
+//	@Router			/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) ListWebhookDeliveries(c *gin.Context) {
+	page, _ := strconv.Atoi(c.Query("page"))
+	pageSize, _ := strconv.Atoi(c.Query("page_size"))
+
+	page, pageSize, err := repository.ValidatePaginationParams(page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+
+		return
+	}
+
+	resp, err := h.webhookService.ListDeliveries(c.Request.Context(), c.Param("id"), page, pageSize)
+	if err != nil {
+		h.mapServiceError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// mapServiceError translates a validators.ValidationError into the appropriate
+// HTTP status, and falls back to 500 for all other errors.
+func (h *WebhookHandler) mapServiceError(c *gin.Context, err error) {
+	var valErr *validators.ValidationError
+	if errors.As(err, &valErr) {
+		c.JSON(valErr.Code, ErrorResponse{Error: valErr.Message})
+
+		return
+	}
+	c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+}
+
+// Made with Bob
@@ -1,12 +1,21 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/middleware"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/scheduler"
 	dbmodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	rtmodels "github.com/project-ai-services/ai-services/internal/pkg/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/worker/pki"
 	"github.com/project-ai-services/ai-services/internal/pkg/worker/registry"
 )
 
@@ -15,12 +24,16 @@ var _ dbmodels.Worker
 
 // WorkerHandler handles worker management endpoints.
 type WorkerHandler struct {
-	reg *registry.Registry
+	reg        *registry.Registry
+	ca         *pki.CA
+	appService repository.ApplicationServiceInterface
 }
 
-// NewWorkerHandler creates a new WorkerHandler.
-func NewWorkerHandler(reg *registry.Registry) *WorkerHandler {
-	return &WorkerHandler{reg: reg}
+// NewWorkerHandler creates a new WorkerHandler. ca may be nil when the worker
+// gateway runs without TLS. appService lists and drains the applications hosted
+// on a worker.
+func NewWorkerHandler(reg *registry.Registry, ca *pki.CA, appService repository.ApplicationServiceInterface) *WorkerHandler {
+	return &WorkerHandler{reg: reg, ca: ca, appService: appService}
 }
 
 // createWorkerReq is the request body for registering a new worker.
@@ -32,12 +45,16 @@ type createWorkerReq struct {
 type createWorkerResp struct {
 	WorkerName string `json:"worker_name"`
 	Token      string `json:"token"`
+	// CACertPEM is the worker gateway CA certificate. The operator passes it to
+	// `worker join --ca-cert` so the worker can verify the gateway.
+	CACertPEM string `json:"ca_cert_pem,omitempty"`
 }
 
 // CreateWorker godoc
 //
 //	@Summary		Register a new worker
-//	@Description	Pre-registers a worker by name, creates a pending DB row, and returns a single-use bootstrap token.
+//	@Description	Pre-registers a worker by name, creates a pending DB row, and returns a single-use bootstrap token
+//	@Description	together with the CA certificate the worker uses to verify the gateway.
 //	@Description	The operator passes this token when starting the worker daemon (`worker start --token <token>`).
 //	@Tags			Workers
 //	@Accept			json
@@ -74,16 +91,24 @@ func (h *WorkerHandler) CreateWorker(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusCreated, createWorkerResp{
+	middleware.SetAuditResourceID(c, req.WorkerName)
+	resp := createWorkerResp{
 		WorkerName: req.WorkerName,
 		Token:      token,
-	})
+	}
+	if h.ca != nil {
+		resp.CACertPEM = string(h.ca.CertPEM())
+	}
+
+	c.JSON(http.StatusCreated, resp)
 }
 
 // ListWorkers godoc
 //
 //	@Summary		List all workers
 //	@Description	Returns all registered workers and their current status from the database.
+//	@Description	Workers that speak an older protocol version than the control plane are flagged with outdated=true;
+//	@Description	they are refused the command types missing from their supported_commands.
 //	@Tags			Workers
 //	@Produce		json
 //	@Success		200	{array}		dbmodels.Worker			"List of workers"
@@ -101,6 +126,196 @@ func (h *WorkerHandler) ListWorkers(c *gin.Context) {
 	c.JSON(http.StatusOK, workers)
 }
 
+// workerDetailResp is the response body for a single worker.
+type workerDetailResp struct {
+	dbmodels.Worker
+	// Connected reports whether the worker holds an open command stream to this control plane.
+	Connected bool `json:"connected"`
+	// InFlightCommands is the number of commands dispatched to the worker that are awaiting a result.
+	InFlightCommands int `json:"in_flight_commands"`
+	// SystemInfo is the capacity the worker reports right now; omitted when it is not connected.
+	SystemInfo      *rtmodels.SystemInfo `json:"system_info,omitempty"`
+	SystemInfoError string               `json:"system_info_error,omitempty"`
+	// Applications are the applications placed on the worker.
+	Applications []types.Application `json:"applications"`
+}
+
+// GetWorker godoc
+//
+//	@Summary		Get a worker
+//	@Description	Returns the worker row together with its connection state, the number of commands in flight,
+//	@Description	the system information it reports live and the applications placed on it.
+//	@Tags			Workers
+//	@Produce		json
+//	@Param			id	path		string					true	"Worker ID (UUID)"
+//	@Success		200	{object}	workerDetailResp		"Worker details"
+//	@Failure		400	{object}	map[string]interface{}	"Invalid worker ID"
+//	@Failure		404	{object}	map[string]interface{}	"Worker not found"
+//	@Failure		500	{object}	map[string]interface{}	"Internal error"
+//	@Security		BearerAuth
+//	@Router			/workers/{id} [get]
+func (h *WorkerHandler) GetWorker(c *gin.Context) {
+	workerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid worker id"})
+
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	w, err := h.reg.GetWorker(ctx, workerID)
+	if err != nil {
+		respondWorkerLookupError(c, err)
+
+		return
+	}
+
+	apps, err := h.appService.ListWorkerApplications(ctx, workerID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list worker applications"})
+
+		return
+	}
+
+	resp := workerDetailResp{
+		Worker:           *w,
+		Connected:        h.reg.IsConnected(w.Name),
+		InFlightCommands: h.reg.InFlight(w.Name),
+		Applications:     apps,
+	}
+	if resp.Connected {
+		resp.SystemInfo, err = scheduler.ProbeWithTimeout(ctx, scheduler.RuntimeProber, *w)
+		if err != nil {
+			logger.WarningfCtx(ctx, "Failed to read system info of worker %s: %v", w.Name, err)
+			resp.SystemInfoError = err.Error()
+		}
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// CordonWorker godoc
+//
+//	@Summary		Cordon a worker
+//	@Description	Marks the worker unschedulable. It keeps running the applications it hosts,
+//	@Description	but the scheduler places no new applications on it until it is uncordoned.
+//	@Tags			Workers
+//	@Produce		json
+//	@Param			id	path		string					true	"Worker ID (UUID)"
+//	@Success		200	{object}	dbmodels.Worker			"Worker cordoned"
+//	@Failure		400	{object}	map[string]interface{}	"Invalid worker ID"
+//	@Failure		404	{object}	map[string]interface{}	"Worker not found"
+//	@Failure		500	{object}	map[string]interface{}	"Internal error"
+//	@Security		BearerAuth
+//	@Router			/workers/{id}/cordon [post]
+func (h *WorkerHandler) CordonWorker(c *gin.Context) {
+	h.setSchedulable(c, false)
+}
+
+// UncordonWorker godoc
+//
+//	@Summary		Uncordon a worker
+//	@Description	Marks a cordoned worker schedulable again so new applications can be placed on it.
+//	@Tags			Workers
+//	@Produce		json
+//	@Param			id	path		string					true	"Worker ID (UUID)"
+//	@Success		200	{object}	dbmodels.Worker			"Worker uncordoned"
+//	@Failure		400	{object}	map[string]interface{}	"Invalid worker ID"
+//	@Failure		404	{object}	map[string]interface{}	"Worker not found"
+//	@Failure		500	{object}	map[string]interface{}	"Internal error"
+//	@Security		BearerAuth
+//	@Router			/workers/{id}/uncordon [post]
+func (h *WorkerHandler) UncordonWorker(c *gin.Context) {
+	h.setSchedulable(c, true)
+}
+
+// setSchedulable implements CordonWorker and UncordonWorker.
+func (h *WorkerHandler) setSchedulable(c *gin.Context, schedulable bool) {
+	workerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid worker id"})
+
+		return
+	}
+
+	w, err := h.reg.SetSchedulable(c.Request.Context(), workerID, schedulable)
+	if err != nil {
+		respondWorkerLookupError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, w)
+}
+
+// drainWorkerResp is the response body for a drained worker.
+type drainWorkerResp struct {
+	Worker       dbmodels.Worker            `json:"worker"`
+	Applications []types.DrainedApplication `json:"applications"`
+}
+
+// DrainWorker godoc
+//
+//	@Summary		Drain a worker
+//	@Description	Cordons the worker and stops every application placed on it, cancelling deployments in progress,
+//	@Description	so the host can be taken down for maintenance. Applications are not moved to another worker:
+//	@Description	their data stays on this host. The result of each application is reported; a failure to stop
+//	@Description	one application does not stop the drain. Draining is not supported on OpenShift.
+//	@Tags			Workers
+//	@Produce		json
+//	@Param			id	path		string					true	"Worker ID (UUID)"
+//	@Success		200	{object}	drainWorkerResp			"Worker drained"
+//	@Failure		400	{object}	map[string]interface{}	"Invalid worker ID"
+//	@Failure		404	{object}	map[string]interface{}	"Worker not found"
+//	@Failure		500	{object}	map[string]interface{}	"Internal error"
+//	@Failure		501	{object}	map[string]interface{}	"Draining not supported by the runtime"
+//	@Security		BearerAuth
+//	@Router			/workers/{id}/drain [post]
+func (h *WorkerHandler) DrainWorker(c *gin.Context) {
+	workerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid worker id"})
+
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Cordon first so the scheduler does not place new applications on the worker
+	// while its current ones are being stopped.
+	w, err := h.reg.SetSchedulable(ctx, workerID, false)
+	if err != nil {
+		respondWorkerLookupError(c, err)
+
+		return
+	}
+
+	drained, err := h.appService.DrainWorker(ctx, workerID, w.Name)
+	if err != nil {
+		if valErr, ok := err.(*repository.ValidationError); ok {
+			c.JSON(valErr.Code, gin.H{"error": valErr.Message})
+
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to drain worker"})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, drainWorkerResp{Worker: *w, Applications: drained})
+}
+
+// respondWorkerLookupError maps an error from a registry call addressing a worker by ID.
+func respondWorkerLookupError(c *gin.Context, err error) {
+	if errors.Is(err, registry.ErrWorkerNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "worker not found"})
+
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to look up worker"})
+}
+
 // DeleteWorker godoc
 //
 //	@Summary		Deregister a worker
@@ -140,3 +355,146 @@ func (h *WorkerHandler) DeleteWorker(c *gin.Context) {
 
 	c.Status(http.StatusNoContent)
 }
+
+// workerTokenResp is the response body for a re-issued bootstrap token.
+type workerTokenResp struct {
+	WorkerName string    `json:"worker_name"`
+	TokenID    uuid.UUID `json:"token_id"`
+	Token      string    `json:"token"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	CACertPEM  string    `json:"ca_cert_pem,omitempty"`
+}
+
+// ListWorkerTokens godoc
+//
+//	@Summary		List a worker's bootstrap tokens
+//	@Description	Returns every bootstrap token issued for the worker, newest first, with its status
+//	@Description	(active, used, revoked or expired). Token values are never returned.
+//	@Tags			Workers
+//	@Produce		json
+//	@Param			id	path		string					true	"Worker ID (UUID)"
+//	@Success		200	{array}		dbmodels.WorkerToken	"Bootstrap tokens"
+//	@Failure		400	{object}	map[string]interface{}	"Invalid worker ID"
+//	@Failure		404	{object}	map[string]interface{}	"Worker not found"
+//	@Failure		500	{object}	map[string]interface{}	"Internal error"
+//	@Security		BearerAuth
+//	@Router			/workers/{id}/tokens [get]
+func (h *WorkerHandler) ListWorkerTokens(c *gin.Context) {
+	workerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid worker id"})
+
+		return
+	}
+
+	tokens, err := h.reg.ListTokens(c.Request.Context(), workerID)
+	if err != nil {
+		if errors.Is(err, registry.ErrWorkerNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "worker not found"})
+
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list worker tokens"})
+
+		return
+	}
+
+	if tokens == nil {
+		tokens = []dbmodels.WorkerToken{}
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// ReissueWorkerToken godoc
+//
+//	@Summary		Re-issue a worker bootstrap token
+//	@Description	Revokes the worker's outstanding bootstrap tokens and returns a new single-use token.
+//	@Description	Use it to recover a pending worker whose token expired or was lost, without deleting the worker.
+//	@Description	A registered worker goes back to pending: its command stream is closed and its client certificate
+//	@Description	is refused until it registers with the new token.
+//	@Tags			Workers
+//	@Produce		json
+//	@Param			id	path		string					true	"Worker ID (UUID)"
+//	@Success		201	{object}	workerTokenResp			"Token issued; valid for 24 hours"
+//	@Failure		400	{object}	map[string]interface{}	"Invalid worker ID"
+//	@Failure		404	{object}	map[string]interface{}	"Worker not found"
+//	@Failure		500	{object}	map[string]interface{}	"Internal error"
+//	@Security		BearerAuth
+//	@Router			/workers/{id}/tokens [post]
+func (h *WorkerHandler) ReissueWorkerToken(c *gin.Context) {
+	workerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid worker id"})
+
+		return
+	}
+
+	w, token, rec, err := h.reg.ReissueToken(c.Request.Context(), workerID)
+	if err != nil {
+		if errors.Is(err, registry.ErrWorkerNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "worker not found"})
+
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to issue worker token"})
+
+		return
+	}
+
+	resp := workerTokenResp{
+		WorkerName: w.Name,
+		TokenID:    rec.ID,
+		Token:      token,
+		ExpiresAt:  rec.ExpiresAt,
+	}
+	if h.ca != nil {
+		resp.CACertPEM = string(h.ca.CertPEM())
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// RevokeWorkerToken godoc
+//
+//	@Summary		Revoke a worker bootstrap token
+//	@Description	Invalidates an unused bootstrap token so it can no longer be used to register the worker.
+//	@Tags			Workers
+//	@Produce		json
+//	@Param			id		path	string	true	"Worker ID (UUID)"
+//	@Param			token_id	path	string	true	"Token ID (UUID)"
+//	@Success		204		"Token revoked"
+//	@Failure		400		{object}	map[string]interface{}	"Invalid worker or token ID"
+//	@Failure		404		{object}	map[string]interface{}	"No active token with this ID"
+//	@Failure		500		{object}	map[string]interface{}	"Internal error"
+//	@Security		BearerAuth
+//	@Router			/workers/{id}/tokens/{token_id} [delete]
+func (h *WorkerHandler) RevokeWorkerToken(c *gin.Context) {
+	workerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid worker id"})
+
+		return
+	}
+	tokenID, err := uuid.Parse(c.Param("token_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid token id"})
+
+		return
+	}
+
+	revoked, err := h.reg.RevokeToken(c.Request.Context(), workerID, tokenID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke worker token"})
+
+		return
+	}
+
+	if !revoked {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no active token with this id"})
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	dbmodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+const (
+	// CtxAuditResourceIDKey holds the ID of the resource affected by a request when it
+	// is not the :id route parameter, e.g. the ID of a resource the request created.
+	CtxAuditResourceIDKey = "audit_resource_id"
+
+	// auditWriteTimeout bounds how long recording an event may delay the response.
+	auditWriteTimeout = 5 * time.Second
+)
+
+// AuditRecorder stores audit events.
+type AuditRecorder interface {
+	Record(ctx context.Context, event *dbmodels.AuditEvent) error
+}
+
+// AuditAction is what an audited route is recorded as.
+type AuditAction struct {
+	// Action is <resource>.<verb>, e.g. "application.delete".
+	Action       string
+	ResourceType string
+}
+
+// SetAuditResourceID records id as the resource affected by the request. Handlers
+// that create a resource call it, since the new ID is not part of the route.
+func SetAuditResourceID(c *gin.Context, id string) {
+	c.Set(CtxAuditResourceIDKey, id)
+}
+
+// AuditMiddleware records an audit event for every request to a route in actions,
+// keyed by method and route pattern (e.g. "DELETE /api/v1/applications/:id"). The
+// event is written once the request has been handled, so it captures the caller
+// set by AuthMiddleware and the response status, including rejected requests.
+// A failure to record is logged and does not affect the response.
+func AuditMiddleware(recorder AuditRecorder, actions map[string]AuditAction) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		action, ok := actions[c.Request.Method+" "+c.FullPath()]
+		if recorder == nil || !ok {
+			c.Next()
+
+			return
+		}
+
+		c.Next()
+
+		event := newAuditEvent(c, action)
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(c.Request.Context()), auditWriteTimeout)
+		defer cancel()
+		if err := recorder.Record(ctx, event); err != nil {
+			logger.WarningfCtx(ctx, "failed to record audit event %s: %v", event.Action, err)
+		}
+	}
+}
+
+// newAuditEvent builds the event of a handled request.
+func newAuditEvent(c *gin.Context, action AuditAction) *dbmodels.AuditEvent {
+	resourceID := c.GetString(CtxAuditResourceIDKey)
+	if resourceID == "" {
+		resourceID = c.Param("id")
+	}
+
+	event := &dbmodels.AuditEvent{
+		UserID:       c.GetString(CtxUserIDKey),
+		Action:       action.Action,
+		ResourceType: action.ResourceType,
+		ResourceID:   resourceID,
+		Method:       c.Request.Method,
+		Path:         c.Request.URL.Path,
+		StatusCode:   c.Writer.Status(),
+		Outcome:      auditOutcome(c.Writer.Status()),
+		RequestID:    c.GetString(CtxRequestIDKey),
+	}
+	if keyID, err := uuid.Parse(c.GetString(CtxAPIKeyIDKey)); err == nil {
+		event.APIKeyID = &keyID
+	}
+
+	return event
+}
+
+// auditOutcome classifies a response status.
+func auditOutcome(status int) dbmodels.AuditOutcome {
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return dbmodels.AuditOutcomeDenied
+	case status >= http.StatusBadRequest:
+		return dbmodels.AuditOutcomeFailure
+	default:
+		return dbmodels.AuditOutcomeSuccess
+	}
+}
+
+// Made with Bob
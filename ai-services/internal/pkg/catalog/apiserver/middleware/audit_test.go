@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	dbmodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+)
+
+type fakeAuditRecorder struct {
+	events []*dbmodels.AuditEvent
+}
+
+func (r *fakeAuditRecorder) Record(_ context.Context, event *dbmodels.AuditEvent) error {
+	r.events = append(r.events, event)
+
+	return nil
+}
+
+func TestAuditMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := &fakeAuditRecorder{}
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(CtxRequestIDKey, "req-1")
+		c.Set(CtxUserIDKey, "alice")
+	})
+	r.Use(AuditMiddleware(rec, map[string]AuditAction{
+		"POST /apps":       {Action: "application.create", ResourceType: "application"},
+		"DELETE /apps/:id": {Action: "application.delete", ResourceType: "application"},
+	}))
+	r.POST("/apps", func(c *gin.Context) {
+		SetAuditResourceID(c, "app-1")
+		c.Status(http.StatusAccepted)
+	})
+	r.DELETE("/apps/:id", RequireRole("admin"), func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	r.GET("/apps", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/apps", nil),
+		httptest.NewRequest(http.MethodDelete, "/apps/app-2", nil),
+		httptest.NewRequest(http.MethodGet, "/apps", nil),
+	} {
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	if len(rec.events) != 2 {
+		t.Fatalf("recorded %d events, want 2 (reads are not audited)", len(rec.events))
+	}
+
+	created := rec.events[0]
+	if created.Action != "application.create" || created.ResourceID != "app-1" || created.UserID != "alice" ||
+		created.RequestID != "req-1" || created.StatusCode != http.StatusAccepted || created.Outcome != dbmodels.AuditOutcomeSuccess {
+		t.Errorf("create event = %+v", created)
+	}
+
+	deleted := rec.events[1]
+	if deleted.ResourceID != "app-2" || deleted.Path != "/apps/app-2" || deleted.Outcome != dbmodels.AuditOutcomeDenied {
+		t.Errorf("rejected delete event = %+v", deleted)
+	}
+}
+
+func TestAuditOutcome(t *testing.T) {
+	tests := map[int]dbmodels.AuditOutcome{
+		http.StatusOK:                  dbmodels.AuditOutcomeSuccess,
+		http.StatusNoContent:           dbmodels.AuditOutcomeSuccess,
+		http.StatusUnauthorized:        dbmodels.AuditOutcomeDenied,
+		http.StatusForbidden:           dbmodels.AuditOutcomeDenied,
+		http.StatusConflict:            dbmodels.AuditOutcomeFailure,
+		http.StatusInternalServerError: dbmodels.AuditOutcomeFailure,
+	}
+
+	for status, want := range tests {
+		if got := auditOutcome(status); got != want {
+			t.Errorf("auditOutcome(%d) = %s, want %s", status, got, want)
+		}
+	}
+}
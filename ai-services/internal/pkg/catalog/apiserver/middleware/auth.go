@@ -1,29 +1,56 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/apikey"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/auth"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 )
 
 const (
 	CtxUserIDKey   = "user_id"
 	CtxRawTokenKey = "raw_token"
+	// CtxRoleKey holds the models.Role of the caller as a string, checked by RequireRole.
+	CtxRoleKey = "role"
+	// CtxAPIKeyIDKey holds the ID of the API key a request authenticated with.
+	CtxAPIKeyIDKey = "api_key_id"
+	// CtxScopesKey holds the []string scopes of the API key a request authenticated
+	// with, checked by RequireScope. It is unset for JWT-authenticated requests.
+	CtxScopesKey = "scopes"
+
+	apiKeyScheme = "ApiKey "
 )
 
+// APIKeyAuthenticator resolves the key of an "Authorization: ApiKey <key>" header.
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (*apikey.Principal, error)
+}
+
 // AuthMiddleware is a Gin middleware function that validates JWT access tokens for protected routes.
 // It checks for the presence of a Bearer token in the Authorization header, validates it using the
 // provided TokenManager, and checks against the blacklist to ensure the token has not been revoked.
-// If the token is valid, it extracts the user ID and token expiry time, sets them in the Gin context
+// If the token is valid, it extracts the user ID, role and token expiry time, sets them in the Gin context
 // for downstream handlers, and allows the request to proceed. If any validation step fails, it aborts
 // the request with a 401 Unauthorized response and an appropriate error message.
-func AuthMiddleware(tokenMgr *auth.TokenManager, blacklist repository.TokenBlacklist) gin.HandlerFunc {
+//
+// When apiKeys is not nil, an "Authorization: ApiKey <key>" header is accepted as well;
+// the request then acts as the key's owner with the owner's current role, restricted
+// to the key's scopes.
+func AuthMiddleware(tokenMgr *auth.TokenManager, blacklist repository.TokenBlacklist, apiKeys APIKeyAuthenticator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ah := c.GetHeader("Authorization")
+		if apiKeys != nil && strings.HasPrefix(ah, apiKeyScheme) {
+			authenticateAPIKey(c, apiKeys, strings.TrimPrefix(ah, apiKeyScheme))
+
+			return
+		}
 		if ah == "" || !strings.HasPrefix(ah, "Bearer ") {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing bearer token"})
 
@@ -41,17 +68,40 @@ func AuthMiddleware(tokenMgr *auth.TokenManager, blacklist repository.TokenBlack
 			return
 		}
 
-		uid, exp, err := tokenMgr.ValidateAccessToken(raw)
-		if err != nil || uid == "" {
+		id, err := tokenMgr.ValidateAccessToken(raw)
+		if err != nil || id.UserID == "" {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
 
 			return
 		}
 
 		// Propagate context
-		c.Set(CtxUserIDKey, uid)
+		c.Set(CtxUserIDKey, id.UserID)
+		c.Set(CtxRoleKey, string(id.Role))
 		c.Set(CtxRawTokenKey, raw)
-		c.Header("X-Token-Exp", exp.UTC().Format("2006-01-02T15:04:05Z"))
+		c.Header("X-Token-Exp", id.ExpiresAt.UTC().Format("2006-01-02T15:04:05Z"))
 		c.Next()
 	}
 }
+
+// authenticateAPIKey validates key and propagates the identity of its owner.
+func authenticateAPIKey(c *gin.Context, apiKeys APIKeyAuthenticator, key string) {
+	p, err := apiKeys.Authenticate(c.Request.Context(), key)
+	if err != nil {
+		if errors.Is(err, apikey.ErrInvalidAPIKey) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid API key"})
+
+			return
+		}
+		logger.ErrorfCtx(c.Request.Context(), "failed to authenticate API key: %v", err)
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to authenticate API key"})
+
+		return
+	}
+
+	c.Set(CtxUserIDKey, p.UserID)
+	c.Set(CtxRoleKey, string(p.Role))
+	c.Set(CtxAPIKeyIDKey, p.KeyID.String())
+	c.Set(CtxScopesKey, p.Scopes)
+	c.Next()
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+)
+
+// newRBACRouter serves /apps behind RequireRoleForWrites(operator) and /admin behind
+// RequireRole(admin), for a caller with the given role.
+func newRBACRouter(role models.Role) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set(CtxRoleKey, string(role)) })
+
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	r.GET("/apps", RequireRoleForWrites(models.RoleOperator), ok)
+	r.DELETE("/apps", RequireRoleForWrites(models.RoleOperator), ok)
+	r.GET("/admin", RequireRole(models.RoleAdmin), ok)
+
+	return r
+}
+
+func TestRequireRole(t *testing.T) {
+	tests := []struct {
+		role   models.Role
+		method string
+		path   string
+		want   int
+	}{
+		{models.RoleViewer, http.MethodGet, "/apps", http.StatusNoContent},
+		{models.RoleViewer, http.MethodDelete, "/apps", http.StatusForbidden},
+		{models.RoleOperator, http.MethodDelete, "/apps", http.StatusNoContent},
+		{models.RoleOperator, http.MethodGet, "/admin", http.StatusForbidden},
+		{models.RoleAdmin, http.MethodDelete, "/apps", http.StatusNoContent},
+		{models.RoleAdmin, http.MethodGet, "/admin", http.StatusNoContent},
+		{"", http.MethodGet, "/admin", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		newRBACRouter(tt.role).ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+		if rec.Code != tt.want {
+			t.Errorf("%s %s as %q = %d, want %d", tt.method, tt.path, tt.role, rec.Code, tt.want)
+		}
+	}
+}
+
+func TestRequireScope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+
+	tests := []struct {
+		scopes []string
+		method string
+		want   int
+	}{
+		{nil, http.MethodDelete, http.StatusNoContent},
+		{[]string{"applications:read"}, http.MethodGet, http.StatusNoContent},
+		{[]string{"applications:read"}, http.MethodDelete, http.StatusForbidden},
+		{[]string{"applications:write"}, http.MethodGet, http.StatusNoContent},
+		{[]string{"catalog:write"}, http.MethodGet, http.StatusForbidden},
+		{[]string{"*"}, http.MethodDelete, http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		r := gin.New()
+		if tt.scopes != nil {
+			r.Use(func(c *gin.Context) { c.Set(CtxScopesKey, tt.scopes) })
+		}
+		r.Handle(tt.method, "/apps", RequireScope(models.AreaApplications), ok)
+
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(tt.method, "/apps", nil))
+
+		if rec.Code != tt.want {
+			t.Errorf("%s with scopes %v = %d, want %d", tt.method, tt.scopes, rec.Code, tt.want)
+		}
+	}
+}
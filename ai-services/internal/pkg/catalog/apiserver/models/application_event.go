@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// EventStep names the deployment step an ApplicationEvent reports on.
+type EventStep string
+
+const (
+	EventStepDeployment      EventStep = "deployment"       // The deployment as a whole; its completed or failed event is the last of a deployment
+	EventStepImagePull       EventStep = "image_pull"       // Pulling one container image (Podman only)
+	EventStepModelDownload   EventStep = "model_download"   // Downloading one model (Podman only)
+	EventStepPodCreated      EventStep = "pod_created"      // A component or service pod was created
+	EventStepReadinessWait   EventStep = "readiness_wait"   // Waiting for a pod, or on OpenShift a Helm release, to become ready
+	EventStepRouteRegistered EventStep = "route_registered" // An external route of a service was registered
+)
+
+// EventStatus is the state of the step an ApplicationEvent reports on.
+type EventStatus string
+
+const (
+	EventStatusStarted   EventStatus = "started"
+	EventStatusCompleted EventStatus = "completed"
+	EventStatusFailed    EventStatus = "failed"
+)
+
+// ApplicationEvent is one structured progress event of an application's deployment,
+// streamed by GET /api/v1/applications/:id/events.
+type ApplicationEvent struct {
+	Seq     int64       `json:"seq"` // Increases by one per event of the application; sent as the SSE event ID
+	Time    time.Time   `json:"time"`
+	Step    EventStep   `json:"step"`
+	Status  EventStatus `json:"status"`
+	Target  string      `json:"target,omitempty"`  // Image, model, pod, Helm release or route the step acts on
+	Message string      `json:"message,omitempty"` // Human-readable detail; the cause of the error for failed events
+	Current int         `json:"current,omitempty"` // Position of the target among the Total targets of the step, when known
+	Total   int         `json:"total,omitempty"`
+}
+
+// IsFinal reports whether e ends a deployment.
+func (e ApplicationEvent) IsFinal() bool {
+	return e.Step == EventStepDeployment && e.Status != EventStatusStarted
+}
+
+// Made with Bob
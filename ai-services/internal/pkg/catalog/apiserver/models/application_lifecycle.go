@@ -0,0 +1,23 @@
+package models
+
+// ApplicationLifecycleRequest selects the services and components of an application that
+// start, stop or restart act on. An empty request acts on the whole application.
+type ApplicationLifecycleRequest struct {
+	Services   []string `json:"services,omitempty"`   // Service catalog IDs or database IDs
+	Components []string `json:"components,omitempty"` // Component types, "<component type>/<provider>", or database IDs
+}
+
+// ApplicationLifecycleResponse reports what starting, stopping or restarting an application did.
+type ApplicationLifecycleResponse struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Status      string   `json:"status"`
+	Message     string   `json:"message,omitempty"`
+	StoppedPods []string `json:"stopped_pods"`
+	StartedPods []string `json:"started_pods"`
+	// Skipped lists the selected components left running because applications that
+	// are not stopped use them too.
+	Skipped []string `json:"skipped,omitempty"`
+}
+
+// Made with Bob
@@ -7,6 +7,12 @@ type CreateApplicationRequest struct {
 	Version   string    `json:"version" binding:"required"`
 	Services  []Service `json:"services" binding:"required,dive"`
 	CreatedBy string    `json:"-"` // Set from auth context, not from request body
+
+	// WorkerID pins the application to a registered worker. Optional.
+	WorkerID string `json:"worker_id,omitempty"`
+	// WorkerSelector restricts placement to workers whose metadata contains every
+	// key/value pair. Optional.
+	WorkerSelector map[string]string `json:"worker_selector,omitempty"`
 }
 
 // Service represents a service configuration in the application.
@@ -27,7 +33,8 @@ type Component struct {
 
 // CreateApplicationResponse represents the response after creating an application.
 type CreateApplicationResponse struct {
-	ID string `json:"id"`
+	ID    string `json:"id"`
+	JobID string `json:"job_id"` // Deployment job; follow it with GET /api/v1/jobs/:id
 }
 
 // Made with Bob
@@ -0,0 +1,59 @@
+package models
+
+// PlanApplicationResponse previews what creating an application from a
+// CreateApplicationRequest would deploy, without deploying anything.
+type PlanApplicationResponse struct {
+	Name            string             `json:"name"`
+	CatalogID       string             `json:"catalog_id"`
+	IsArchitecture  bool               `json:"is_architecture"`
+	Runtime         string             `json:"runtime"`
+	Worker          *PlannedWorker     `json:"worker,omitempty"` // Worker the application would be placed on; omitted for the local runtime
+	Components      []PlannedComponent `json:"components"`
+	Services        []PlannedService   `json:"services"`
+	DeploymentOrder [][]string         `json:"deployment_order"` // Service catalog IDs in layers; each layer is deployed after the previous one
+	Resources       PlanResources      `json:"resources"`
+	Images          []string           `json:"images"` // Container images pulled unless present (Podman only)
+	Models          []string           `json:"models"` // Models downloaded unless present (Podman only)
+	Warnings        []string           `json:"warnings"`
+}
+
+// PlannedWorker identifies the worker an application would be placed on.
+type PlannedWorker struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// PlannedComponent is one component deployment of a plan. Services that request the
+// same component type, provider and params share a single deployment.
+type PlannedComponent struct {
+	Type       string   `json:"type"`
+	Provider   string   `json:"provider"`
+	Version    string   `json:"version,omitempty"`
+	Hash       string   `json:"hash"`
+	UsedBy     []string `json:"used_by"` // Catalog IDs of the services using the component
+	Shared     bool     `json:"shared"`  // true when more than one service uses the component
+	SpyreCards int      `json:"spyre_cards,omitempty"`
+}
+
+// PlannedService is one service deployment of a plan.
+type PlannedService struct {
+	CatalogID  string   `json:"catalog_id"`
+	Version    string   `json:"version,omitempty"`
+	Components []string `json:"components"` // "<component type>/<provider>" of the components the service uses
+}
+
+// PlanResources compares the resources a plan requires with those its host has free.
+type PlanResources struct {
+	Required  ResourceAmounts  `json:"required"`
+	Available *ResourceAmounts `json:"available,omitempty"` // Omitted when the host's capacity could not be read
+}
+
+// ResourceAmounts is an amount of CPU, memory, storage and accelerators.
+type ResourceAmounts struct {
+	CPU          float64        `json:"cpu"` // CPU cores
+	MemoryBytes  int64          `json:"memory_bytes"`
+	StorageBytes int64          `json:"storage_bytes,omitempty"`
+	Accelerators map[string]int `json:"accelerators,omitempty"`
+}
+
+// Made with Bob
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Area is a group of API endpoints that an API key scope can grant access to.
+type Area string
+
+const (
+	AreaCatalog      Area = "catalog"
+	AreaApplications Area = "applications"
+	AreaBundles      Area = "bundles"
+	AreaConnectors   Area = "connectors"
+	AreaWorkers      Area = "workers"
+	AreaUsers        Area = "users"
+	AreaAPIKeys      Area = "apikeys"
+	AreaAudit        Area = "audit"
+	AreaWebhooks     Area = "webhooks"
+)
+
+// Areas lists every area in the order they are documented.
+var Areas = []Area{AreaCatalog, AreaApplications, AreaBundles, AreaConnectors, AreaWorkers, AreaUsers, AreaAPIKeys, AreaAudit, AreaWebhooks}
+
+const (
+	// ScopeAll grants every area, read and write.
+	ScopeAll = "*"
+
+	scopeRead  = "read"
+	scopeWrite = "write"
+)
+
+// ParseScope validates s as an API key scope: "*" or "<area>:read" or "<area>:write".
+func ParseScope(s string) (string, error) {
+	if s == ScopeAll {
+		return s, nil
+	}
+
+	area, access, ok := strings.Cut(s, ":")
+	if ok && (access == scopeRead || access == scopeWrite) {
+		for _, a := range Areas {
+			if Area(area) == a {
+				return s, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("invalid scope %q: must be %q or <area>:read or <area>:write, where area is one of %v", s, ScopeAll, Areas)
+}
+
+// ScopesAllow reports whether scopes grant access to area. A write scope
+// includes read access. An empty list grants everything, so keys without
+// scopes act with the full role of their owner.
+func ScopesAllow(scopes []string, area Area, write bool) bool {
+	if len(scopes) == 0 {
+		return true
+	}
+
+	for _, s := range scopes {
+		if s == ScopeAll || s == string(area)+":"+scopeWrite || (!write && s == string(area)+":"+scopeRead) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ScopesGrant reports whether scopes grant at least the access of scope, which must
+// have been validated with ParseScope. "*" is only granted by scopes granting write
+// access to every area.
+func ScopesGrant(scopes []string, scope string) bool {
+	if scope == ScopeAll {
+		for _, a := range Areas {
+			if !ScopesAllow(scopes, a, true) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	area, access, _ := strings.Cut(scope, ":")
+
+	return ScopesAllow(scopes, Area(area), access == scopeWrite)
+}
@@ -0,0 +1,35 @@
+package models
+
+// ChangeType tells what reconfiguring an application does to one of its services or components.
+type ChangeType string
+
+const (
+	// ChangeTypeAdded items are deployed.
+	ChangeTypeAdded ChangeType = "added"
+	// ChangeTypeChanged items are redeployed with the new configuration.
+	ChangeTypeChanged ChangeType = "changed"
+	// ChangeTypeUnchanged items keep running untouched.
+	ChangeTypeUnchanged ChangeType = "unchanged"
+	// ChangeTypeRemoved items are deleted together with their data.
+	ChangeTypeRemoved ChangeType = "removed"
+)
+
+// ApplicationChange describes the change to one service or component of an application.
+type ApplicationChange struct {
+	Kind   string     `json:"kind"`         // "service" or "component"
+	Name   string     `json:"name"`         // Service catalog ID, or "<component type>/<provider>" for components
+	ID     string     `json:"id,omitempty"` // Database ID of the service or component after the change
+	Change ChangeType `json:"change"`
+}
+
+// UpdateApplicationResponse represents the response after reconfiguring an application.
+// Status is "Deploying" while changed items are redeployed in the background.
+type UpdateApplicationResponse struct {
+	ID      string              `json:"id"`
+	Name    string              `json:"name"`
+	Status  string              `json:"status"`
+	Changes []ApplicationChange `json:"changes"`
+	JobID   string              `json:"job_id,omitempty"` // Deployment job, unless nothing had to be deployed
+}
+
+// Made with Bob
@@ -1,8 +1,65 @@
 package models
 
+import (
+	"fmt"
+	"time"
+)
+
+// Role grants access to a set of API endpoints. Roles are ordered: every role is
+// allowed what the roles below it are allowed.
+type Role string
+
+const (
+	// RoleViewer may call the read-only endpoints.
+	RoleViewer Role = "viewer"
+	// RoleOperator may additionally manage the lifecycle of applications.
+	RoleOperator Role = "operator"
+	// RoleAdmin may additionally manage bundles, workers and users.
+	RoleAdmin Role = "admin"
+)
+
+// roleRank orders the roles from least to most privileged.
+var roleRank = map[Role]int{
+	RoleViewer:   1,
+	RoleOperator: 2,
+	RoleAdmin:    3,
+}
+
+// ParseRole validates s as a role name.
+func ParseRole(s string) (Role, error) {
+	r := Role(s)
+	if _, ok := roleRank[r]; !ok {
+		return "", fmt.Errorf("invalid role %q: must be one of %s, %s, %s", s, RoleViewer, RoleOperator, RoleAdmin)
+	}
+
+	return r, nil
+}
+
+// Allows reports whether r grants at least the privileges of required.
+// An unknown role allows nothing.
+func (r Role) Allows(required Role) bool {
+	rank, ok := roleRank[r]
+
+	return ok && rank >= roleRank[required]
+}
+
+// HigherOf returns the more privileged of r and other.
+func (r Role) HigherOf(other Role) Role {
+	if roleRank[other] > roleRank[r] {
+		return other
+	}
+
+	return r
+}
+
+// User is a catalog API user. A disabled user cannot log in or refresh tokens.
 type User struct {
 	ID           string
 	UserName     string
 	PasswordHash string
 	Name         string
+	Role         Role
+	Disabled     bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
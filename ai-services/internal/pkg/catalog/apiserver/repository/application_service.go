@@ -7,6 +7,9 @@ import (
 	appservice "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository/application_service"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/deletion"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/deployment"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/jobs"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/scheduler"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/webhook"
 	dbrepo "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
 	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
@@ -22,6 +25,9 @@ type ListApplicationsRequest = appservice.ListApplicationsRequest
 // DeleteApplicationResponse re-exported from the applicationservice subpackage.
 type DeleteApplicationResponse = appservice.DeleteApplicationResponse
 
+// PodLogStream re-exported from the applicationservice subpackage.
+type PodLogStream = appservice.PodLogStream
+
 // ValidatePaginationParams re-exported from the applicationservice subpackage.
 func ValidatePaginationParams(page, pageSize int) (int, int, error) {
 	return appservice.ValidatePaginationParams(page, pageSize)
@@ -29,12 +35,19 @@ func ValidatePaginationParams(page, pageSize int) (int, int, error) {
 
 // NewApplicationService creates the appropriate ApplicationServiceInterface implementation
 // based on the runtime type. It is the single construction point for the apiserver.
+// sched places applications onto workers; nil deploys everything on the local runtime.
+// jobRunner runs deployments and deletions; the service registers its job handlers with it,
+// so it must be started after this returns. webhooks is notified when deployments finish;
+// nil sends no webhook events.
 func NewApplicationService(
 	appRepo dbrepo.ApplicationRepository,
 	serviceRepo dbrepo.ServiceRepository,
 	componentRepo dbrepo.ComponentRepository,
 	serviceDependencyRepo dbrepo.ServiceDependencyRepository,
 	provider *catalog.CatalogProvider,
+	sched *scheduler.Scheduler,
+	jobRunner *jobs.Runner,
+	webhooks webhook.Notifier,
 	runtimeType runtimeTypes.RuntimeType,
 ) ApplicationServiceInterface {
 	base := appservice.ApplicationServiceBase{
@@ -47,6 +60,9 @@ func NewApplicationService(
 		DeploymentExecutor:    deployment.NewDeploymentExecutor(provider, appRepo, serviceRepo, componentRepo),
 		DeletionExecutor:      deletion.NewDeletionExecutor(appRepo, serviceRepo, componentRepo, serviceDependencyRepo),
 		Validator:             validators.NewApplicationValidator(provider),
+		Scheduler:             sched,
+		Jobs:                  jobRunner,
+		Webhooks:              webhooks,
 	}
 
 	switch runtimeType {
@@ -2,6 +2,7 @@ package applicationservice
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"net/http"
@@ -12,19 +13,23 @@ import (
 	apimodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/deletion"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/deployment"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/events"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/jobs"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/scheduler"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/webhook"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/constants"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
 	dbrepo "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
 	catalogutils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
 	clitemplates "github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
 	consts "github.com/project-ai-services/ai-services/internal/pkg/constants"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
 	"github.com/project-ai-services/ai-services/internal/pkg/runtime/common"
 	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
-	"github.com/project-ai-services/ai-services/internal/pkg/vars"
 )
 
 // ValidationError represents a validation error with HTTP status code.
@@ -43,6 +48,7 @@ type DeleteApplicationResponse struct {
 	ID      string `json:"id"`
 	Status  string `json:"status"`
 	Message string `json:"message"`
+	JobID   string `json:"job_id"` // Deletion job; follow it with GET /api/v1/jobs/:id
 }
 
 // resourceTotals holds aggregated resource information.
@@ -91,9 +97,21 @@ type ApplicationServiceBase struct {
 	DeletionExecutor      *deletion.DeletionExecutor
 	Validator             *validators.ApplicationValidator
 
-	// DeploymentRegistry tracks in-flight deployments so they can be cancelled
-	// by a concurrent delete request. Nil means no cancellation (e.g. OpenShift stub).
-	DeploymentRegistry *DeploymentRegistry
+	// Jobs runs deployments and deletions as durable jobs, which an apiserver restart
+	// resumes or rolls back, and cancels deployments when their application is deleted.
+	Jobs *jobs.Runner
+
+	// Scheduler places new applications onto registered workers.
+	// Nil means every application is deployed on the local runtime.
+	Scheduler *scheduler.Scheduler
+
+	// Events records the progress events deployments emit, for GET /applications/:id/events.
+	// Nil means deployments emit no events.
+	Events *events.Recorder
+
+	// Webhooks is sent a deployment.finished event when a deployment ends.
+	// Nil means no webhook events are sent.
+	Webhooks webhook.Notifier
 }
 
 // ListApplications retrieves a paginated list of applications with filters.
@@ -168,8 +186,8 @@ func (s *ApplicationServiceBase) getApplicationType(catalogID string, deployment
 	return service.Name, nil
 }
 
-// UpdateApplication updates the display name of an existing application.
-func (s *ApplicationServiceBase) UpdateApplication(ctx context.Context, id uuid.UUID, userID, newName string) (*types.Application, error) {
+// RenameApplication updates the display name of an existing application.
+func (s *ApplicationServiceBase) RenameApplication(ctx context.Context, id uuid.UUID, userID, newName string) (*types.Application, error) {
 	existingApp, err := s.AppRepo.GetByName(ctx, newName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check for existing application: %w", err)
@@ -463,6 +481,7 @@ func (s *ApplicationServiceBase) insertApplicationRecord(
 		Message:        "Initializing deployment",
 		Version:        plan.Version,
 		CreatedBy:      createdBy,
+		WorkerID:       plan.WorkerID,
 	}
 
 	if err := s.AppRepo.Insert(ctx, app); err != nil {
@@ -480,32 +499,41 @@ func (s *ApplicationServiceBase) insertComponentRecords(
 	componentIDMap := make(map[string]uuid.UUID)
 
 	for hash, comp := range plan.Components {
-		instanceUUID := uuid.New()
-
-		// Filter metadata to exclude sensitive data based on schema
-		metadata, err := s.filterComponentMetadata(ctx, comp.ComponentType, comp.ProviderID, comp.Params)
-		if err != nil {
-			return nil, fmt.Errorf("failed to filter component metadata for %s: %w", hash, err)
+		if err := s.insertComponentRecord(ctx, comp); err != nil {
+			return nil, err
 		}
 
-		component := &models.Component{
-			ID:       instanceUUID,
-			Type:     comp.ComponentType,
-			Provider: comp.ProviderID,
-			Status:   models.ComponentStatusInitializing,
-			Version:  comp.Version,
-			Metadata: metadata,
-		}
+		componentIDMap[hash] = comp.DatabaseID
+	}
 
-		if err := s.ComponentRepo.Insert(ctx, component); err != nil {
-			return nil, fmt.Errorf("failed to insert component %s: %w", hash, err)
-		}
+	return componentIDMap, nil
+}
 
-		componentIDMap[hash] = instanceUUID
-		comp.DatabaseID = instanceUUID
+// insertComponentRecord inserts the record of a planned component and sets its DatabaseID.
+func (s *ApplicationServiceBase) insertComponentRecord(ctx context.Context, comp *deployment.ComponentPlan) error {
+	// Filter metadata to exclude sensitive data based on schema
+	metadata, err := s.filterComponentMetadata(ctx, comp.ComponentType, comp.ProviderID, comp.Params)
+	if err != nil {
+		return fmt.Errorf("failed to filter component metadata for %s: %w", comp.Hash, err)
 	}
 
-	return componentIDMap, nil
+	component := &models.Component{
+		ID:         uuid.New(),
+		Type:       comp.ComponentType,
+		Provider:   comp.ProviderID,
+		Status:     models.ComponentStatusInitializing,
+		Version:    comp.Version,
+		Metadata:   metadata,
+		ConfigHash: comp.Hash,
+	}
+
+	if err := s.ComponentRepo.Insert(ctx, component); err != nil {
+		return fmt.Errorf("failed to insert component %s: %w", comp.Hash, err)
+	}
+
+	comp.DatabaseID = component.ID
+
+	return nil
 }
 
 // insertServiceRecords inserts service records and their dependencies.
@@ -514,27 +542,39 @@ func (s *ApplicationServiceBase) insertServiceRecords(
 	plan *deployment.DeploymentPlan,
 	componentIDMap map[string]uuid.UUID,
 ) error {
-	for serviceID, svc := range plan.Services {
-		service := &models.Service{
-			ID:        uuid.Nil,
-			AppID:     plan.ApplicationID,
-			CatalogID: svc.CatalogID,
-			Status:    models.ServiceStatusInitializing,
-			Version:   svc.Version,
+	for _, svc := range plan.Services {
+		if err := s.insertServiceRecord(ctx, plan.ApplicationID, svc, componentIDMap); err != nil {
+			return err
 		}
+	}
 
-		if err := s.ServiceRepo.Insert(ctx, service); err != nil {
-			return fmt.Errorf("failed to insert service %s: %w", serviceID, err)
-		}
+	return nil
+}
 
-		svc.DatabaseID = service.ID
+// insertServiceRecord inserts the record of a planned service and its dependencies,
+// and sets its DatabaseID.
+func (s *ApplicationServiceBase) insertServiceRecord(
+	ctx context.Context,
+	appID uuid.UUID,
+	svc *deployment.ServicePlan,
+	componentIDMap map[string]uuid.UUID,
+) error {
+	service := &models.Service{
+		ID:         uuid.Nil,
+		AppID:      appID,
+		CatalogID:  svc.CatalogID,
+		Status:     models.ServiceStatusInitializing,
+		Version:    svc.Version,
+		ConfigHash: svc.Hash,
+	}
 
-		if err := s.insertServiceDependencies(ctx, service.ID, svc.ComponentRefs, componentIDMap); err != nil {
-			return err
-		}
+	if err := s.ServiceRepo.Insert(ctx, service); err != nil {
+		return fmt.Errorf("failed to insert service %s: %w", svc.CatalogID, err)
 	}
 
-	return nil
+	svc.DatabaseID = service.ID
+
+	return s.insertServiceDependencies(ctx, service.ID, svc.ComponentRefs, componentIDMap)
 }
 
 // insertServiceDependencies inserts dependencies between services and components.
@@ -638,64 +678,137 @@ func (s *ApplicationServiceBase) CreateApplication(ctx context.Context, req apim
 		return nil, err
 	}
 
-	// Phase 3: create deployment plan
+	// Phase 3: create deployment plan and place it on a host
 	plan, err := s.DeploymentPlanner.PlanDeployment(ctx, req, runtimeType.String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create deployment plan: %w", err)
 	}
 
+	if err := s.placeDeployment(ctx, req, plan, runtimeType); err != nil {
+		return nil, err
+	}
+
 	// Phase 4: persist DB records
 	if err := s.InsertDeploymentRecords(ctx, plan, req.CreatedBy); err != nil {
 		return nil, fmt.Errorf("failed to insert deployment records: %w", err)
 	}
 
-	// Phase 5: async deployment.
-	// Build the deployment context here, before launching the goroutine, so that
-	// Register is called synchronously. This closes the race where a concurrent
-	// DeleteApplication could call Cancel before the goroutine has had a chance
-	// to call Register, causing cancellation to be silently missed.
-	deployCtx := context.Background()
-	if id, ok := ctx.Value(logger.RequestIDKey).(string); ok && id != "" {
-		deployCtx = context.WithValue(deployCtx, logger.RequestIDKey, id)
+	// Phase 5: async deployment as a durable job
+	job, err := s.Jobs.Submit(ctx, models.JobKindCreate, plan.ApplicationID, req.CreatedBy, deploymentJob{Plan: plan, Request: req})
+	if err != nil {
+		err = fmt.Errorf("failed to start deployment: %w", err)
+
+		return nil, s.failJob(ctx, &models.Job{ApplicationID: plan.ApplicationID}, err)
 	}
 
-	if s.DeploymentRegistry != nil {
-		deployCtx = s.DeploymentRegistry.Register(deployCtx, plan.ApplicationID)
+	return &apimodels.CreateApplicationResponse{ID: plan.ApplicationID.String(), JobID: job.ID.String()}, nil
+}
+
+// placeDeployment chooses the worker the plan runs on and, for Podman, allocates Spyre
+// cards from that host. Without a scheduler, or when no worker is available and the
+// request does not ask for one, the plan stays on the local runtime.
+func (s *ApplicationServiceBase) placeDeployment(ctx context.Context, req apimodels.CreateApplicationRequest, plan *deployment.DeploymentPlan, runtimeType runtimeTypes.RuntimeType) error {
+	placement, err := s.scheduleDeployment(ctx, req, plan, runtimeType)
+	if err != nil {
+		return err
 	}
 
-	go s.executeDeploymentAsync(deployCtx, plan, req, runtimeType)
+	findFreeCards := helpers.FindFreeSpyreCards
+	if placement != nil {
+		plan.WorkerID = &placement.Worker.ID
+		plan.WorkerName = placement.Worker.Name
+		findFreeCards = func(context.Context) ([]string, error) {
+			if acc := placement.SystemInfo.Accelerators[consts.SpyreResourceName]; acc != nil {
+				return acc.Devices, nil
+			}
 
-	return &apimodels.CreateApplicationResponse{ID: plan.ApplicationID.String()}, nil
+			return nil, nil
+		}
+	}
+
+	if runtimeType == runtimeTypes.RuntimeTypePodman {
+		if err := s.DeploymentPlanner.AllocateSpyreCards(ctx, plan, findFreeCards); err != nil {
+			return fmt.Errorf("failed to allocate Spyre cards: %w", err)
+		}
+	}
+
+	return nil
 }
 
-// executeDeploymentAsync runs the deployment in a background goroutine for the given runtime type.
-// deployCtx is already derived and registered with the DeploymentRegistry by the caller.
-func (s *ApplicationServiceBase) executeDeploymentAsync(deployCtx context.Context, plan *deployment.DeploymentPlan, req apimodels.CreateApplicationRequest, runtimeType runtimeTypes.RuntimeType) {
-	ctx := deployCtx
+// scheduleDeployment asks the scheduler for a worker that satisfies the request's
+// worker_id / worker_selector and the plan's resources. It returns nil for the local runtime.
+func (s *ApplicationServiceBase) scheduleDeployment(ctx context.Context, req apimodels.CreateApplicationRequest, plan *deployment.DeploymentPlan, runtimeType runtimeTypes.RuntimeType) (*scheduler.Placement, error) {
+	schedReq := scheduler.Request{
+		Selector:    req.WorkerSelector,
+		RuntimeType: runtimeType,
+		Resources:   plan.Resources,
+	}
+	if req.WorkerID != "" {
+		workerID, err := uuid.Parse(req.WorkerID)
+		if err != nil {
+			return nil, &ValidationError{Code: http.StatusBadRequest, Message: fmt.Sprintf("invalid worker_id %q: must be a UUID", req.WorkerID)}
+		}
+		schedReq.WorkerID = &workerID
+	}
 
-	// Deregister on any exit path — success, error, or panic.
-	if s.DeploymentRegistry != nil {
-		defer s.DeploymentRegistry.Deregister(plan.ApplicationID)
+	if s.Scheduler == nil {
+		if schedReq.WorkerID != nil || len(schedReq.Selector) > 0 {
+			return nil, &ValidationError{Code: http.StatusUnprocessableEntity, Message: "worker placement is not available on this server"}
+		}
+
+		return nil, nil
+	}
+
+	placement, err := s.Scheduler.Schedule(ctx, schedReq)
+	if err != nil {
+		var placementErr *scheduler.PlacementError
+		if errors.As(err, &placementErr) {
+			return nil, &ValidationError{Code: http.StatusUnprocessableEntity, Message: placementErr.Error()}
+		}
+
+		return nil, fmt.Errorf("failed to schedule application: %w", err)
 	}
 
+	return placement, nil
+}
+
+// executeDeployment runs the deployment of a create or reconfigure job for the given
+// runtime type. prepare, when set, runs before the plan is executed; a reconfiguration
+// uses it to remove what the new plan no longer contains.
+func (s *ApplicationServiceBase) executeDeployment(
+	ctx context.Context,
+	plan *deployment.DeploymentPlan,
+	req apimodels.CreateApplicationRequest,
+	runtimeType runtimeTypes.RuntimeType,
+	prepare func(ctx context.Context) error,
+) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
-			logger.ErrorfCtx(ctx, "Panic recovered in deployment goroutine for application %s: %v", plan.ApplicationName, r)
+			logger.ErrorfCtx(ctx, "Panic recovered in deployment job for application %s: %v", plan.ApplicationName, r)
 
 			errMsg := fmt.Sprintf("Deployment panic: %v", r)
 			if updateErr := catalogutils.UpdateApplicationStatus(ctx, s.AppRepo, plan.ApplicationID.String(), models.ApplicationStatusError, errMsg); updateErr != nil {
 				logger.ErrorfCtx(ctx, "Failed to update application status after panic: %v", updateErr)
 			}
+			emitDeploymentEvent(ctx, apimodels.EventStatusFailed, errMsg)
+			s.notifyDeploymentFinished(ctx, plan.ApplicationID, plan.ApplicationName, webhook.DeploymentFailed, errMsg)
+			err = errors.New(errMsg)
 		}
 	}()
 
-	err := s.DeploymentExecutor.ExecuteWithPlan(ctx, plan, req, runtimeType)
+	emitDeploymentEvent(ctx, apimodels.EventStatusStarted, fmt.Sprintf("Deploying application '%s'", plan.ApplicationName))
+
+	if prepare != nil {
+		err = prepare(ctx)
+	}
+	if err == nil {
+		err = s.DeploymentExecutor.ExecuteWithPlan(ctx, plan, req, runtimeType)
+	}
 	if err != nil {
-		// Context cancelled — deletion is in charge of status, exit silently.
 		if ctx.Err() != nil {
-			logger.InfofCtx(ctx, "Deployment cancelled for application %s (deletion in progress)", plan.ApplicationName)
+			s.handleInterruptedDeployment(ctx, plan)
 
-			return
+			return err
 		}
 
 		logger.ErrorfCtx(ctx, "Deployment failed for application %s: %v", plan.ApplicationName, err)
@@ -703,11 +816,65 @@ func (s *ApplicationServiceBase) executeDeploymentAsync(deployCtx context.Contex
 		if updateErr := catalogutils.UpdateApplicationStatus(ctx, s.AppRepo, plan.ApplicationID.String(), models.ApplicationStatusError, err.Error()); updateErr != nil {
 			logger.ErrorfCtx(ctx, "Failed to update application status to Error: %v", updateErr)
 		}
+		emitDeploymentEvent(ctx, apimodels.EventStatusFailed, err.Error())
+		s.notifyDeploymentFinished(ctx, plan.ApplicationID, plan.ApplicationName, webhook.DeploymentFailed, err.Error())
 
-		return
+		return err
 	}
 
 	logger.InfolnCtx(ctx, fmt.Sprintf("Deployment completed successfully for application %s", plan.ApplicationName))
+	message := fmt.Sprintf("Application '%s' is running", plan.ApplicationName)
+	emitDeploymentEvent(ctx, apimodels.EventStatusCompleted, message)
+	s.notifyDeploymentFinished(ctx, plan.ApplicationID, plan.ApplicationName, webhook.DeploymentSucceeded, message)
+
+	return nil
+}
+
+// handleInterruptedDeployment settles the status of a deployment whose job context was
+// cancelled. A job interrupted by a shutdown or by losing its lease keeps the status, as
+// the job is resumed. A deletion is in charge of the status of the application it
+// cancelled the deployment of; any other cancellation leaves the application in Error.
+func (s *ApplicationServiceBase) handleInterruptedDeployment(ctx context.Context, plan *deployment.DeploymentPlan) {
+	if !jobs.Cancelled(ctx) {
+		logger.InfofCtx(ctx, "Deployment interrupted for application %s; it resumes once its job is claimed again", plan.ApplicationName)
+
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	logger.InfofCtx(ctx, "Deployment cancelled for application %s", plan.ApplicationName)
+
+	app, err := s.AppRepo.GetByID(ctx, plan.ApplicationID)
+	if err != nil {
+		logger.ErrorfCtx(ctx, "Failed to get application after cancellation: %v", err)
+	}
+	if app != nil && app.Status != models.ApplicationStatusDeleting {
+		if updateErr := catalogutils.UpdateApplicationStatus(ctx, s.AppRepo, app.ID, models.ApplicationStatusError, "Deployment cancelled"); updateErr != nil {
+			logger.ErrorfCtx(ctx, "Failed to update application status to Error: %v", updateErr)
+		}
+	}
+	emitDeploymentEvent(ctx, apimodels.EventStatusFailed, "deployment cancelled")
+	s.notifyDeploymentFinished(ctx, plan.ApplicationID, plan.ApplicationName, webhook.DeploymentCancelled, "deployment cancelled")
+}
+
+// emitDeploymentEvent emits the event of a deployment as a whole. Its final event is emitted
+// after the application status has been updated, so a client reacting to it reads the outcome.
+func emitDeploymentEvent(ctx context.Context, status apimodels.EventStatus, message string) {
+	events.Emit(ctx, apimodels.ApplicationEvent{Step: apimodels.EventStepDeployment, Status: status, Message: message})
+}
+
+// notifyDeploymentFinished sends the deployment.finished webhook event of an application.
+func (s *ApplicationServiceBase) notifyDeploymentFinished(ctx context.Context, appID uuid.UUID, appName string, outcome webhook.DeploymentOutcome, message string) {
+	if s.Webhooks == nil {
+		return
+	}
+
+	s.Webhooks.Notify(ctx, webhook.EventDeploymentFinished, webhook.DeploymentEventData{
+		ApplicationID:   appID,
+		ApplicationName: appName,
+		Outcome:         outcome,
+		Message:         message,
+	})
 }
 
 // GetApplicationResources retrieves CPU, memory, and Spyre-card usage for an application.
@@ -724,7 +891,7 @@ func (s *ApplicationServiceBase) GetApplicationResources(ctx context.Context, id
 		}
 	}
 
-	runtimeClient, err := vars.RuntimeFactory.Create(namespace)
+	runtimeClient, err := catalogutils.AppRuntime(ctx, app.WorkerID, namespace)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create runtime client: %w", err)
 	}
@@ -932,7 +1099,7 @@ func (s *ApplicationServiceBase) ApplicationsPs(ctx context.Context, appID uuid.
 		}
 	}
 
-	rt, err := vars.RuntimeFactory.Create(namespace)
+	rt, err := catalogutils.AppRuntime(ctx, app.WorkerID, namespace)
 	if err != nil {
 		return nil, fmt.Errorf("failed to init runtime client: %w", err)
 	}
@@ -1090,9 +1257,8 @@ func (s *ApplicationServiceBase) DeleteApplication(ctx context.Context, id uuid.
 	}
 
 	// Cancel any in-flight deployment before transitioning to Deleting.
-	// No-op when DeploymentRegistry is nil (e.g. OpenShift stub).
-	if s.DeploymentRegistry != nil {
-		s.DeploymentRegistry.Cancel(id)
+	if err := s.Jobs.CancelApplication(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to cancel deployment: %w", err)
 	}
 
 	if err := catalogutils.UpdateApplicationStatus(ctx, s.AppRepo, id, models.ApplicationStatusDeleting, "Deleting deployment..."); err != nil {
@@ -1104,54 +1270,49 @@ func (s *ApplicationServiceBase) DeleteApplication(ctx context.Context, id uuid.
 		return nil, fmt.Errorf("failed to get application components: %w", err)
 	}
 
-	var requestID string
-	if reqID, ok := ctx.Value(logger.RequestIDKey).(string); ok {
-		requestID = reqID
-	}
+	job, err := s.Jobs.Submit(ctx, models.JobKindDelete, id, user, deletionJob{
+		WorkerID:             app.WorkerID,
+		Services:             app.Services,
+		OrphanedComponentIDs: orphanedComponentIDs,
+		KeepData:             keepData,
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to start deletion: %w", err)
 
-	deletionCtx := context.Background()
-	if requestID != "" {
-		deletionCtx = context.WithValue(deletionCtx, logger.RequestIDKey, requestID)
+		return nil, s.failJob(ctx, &models.Job{ApplicationID: id}, err)
 	}
 
-	go s.executeDeletionAsync(deletionCtx, id, app.Services, orphanedComponentIDs, keepData, runtimeType)
-
 	return &DeleteApplicationResponse{
 		ID:      id.String(),
 		Status:  string(models.ApplicationStatusDeleting),
 		Message: "Deletion initiated successfully",
+		JobID:   job.ID.String(),
 	}, nil
 }
 
-func (s *ApplicationServiceBase) executeDeletionAsync(
-	parentCtx context.Context,
+// executeDeletion runs the deletion of a delete job for the given runtime type.
+func (s *ApplicationServiceBase) executeDeletion(
+	ctx context.Context,
 	appID uuid.UUID,
+	workerID *uuid.UUID,
 	services []models.Service,
 	orphanedComponentIDs []uuid.UUID,
 	keepData bool,
 	runtimeType runtimeTypes.RuntimeType,
-) {
-	var requestID string
-	if id, ok := parentCtx.Value(logger.RequestIDKey).(string); ok {
-		requestID = id
-	}
-
-	ctx := context.Background()
-	if requestID != "" {
-		ctx = context.WithValue(ctx, logger.RequestIDKey, requestID)
-	}
+) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
-			logger.ErrorfCtx(ctx, "Panic recovered in deletion goroutine for application %s: %v", appID, r)
+			logger.ErrorfCtx(ctx, "Panic recovered in deletion job for application %s: %v", appID, r)
 
 			errMsg := fmt.Sprintf("Deletion panic: %v", r)
 			if updateErr := catalogutils.UpdateApplicationStatus(ctx, s.AppRepo, appID.String(), models.ApplicationStatusError, errMsg); updateErr != nil {
 				logger.ErrorfCtx(ctx, "Failed to update application status after panic: %v", updateErr)
 			}
+			err = errors.New(errMsg)
 		}
 	}()
 
-	err := s.DeletionExecutor.Execute(ctx, appID, services, orphanedComponentIDs, keepData, runtimeType)
+	err = s.DeletionExecutor.Execute(ctx, appID, workerID, services, orphanedComponentIDs, keepData, runtimeType)
 	if err != nil {
 		logger.ErrorfCtx(ctx, "Deletion failed for application %s: %v", appID.String(), err)
 
@@ -1159,10 +1320,16 @@ func (s *ApplicationServiceBase) executeDeletionAsync(
 			logger.ErrorfCtx(ctx, "Failed to update application status to Error: %v", updateErr)
 		}
 
-		return
+		return err
 	}
 
 	logger.InfolnCtx(ctx, fmt.Sprintf("Deletion completed successfully for application id '%s'", appID.String()))
+
+	if s.Events != nil {
+		s.Events.Forget(appID)
+	}
+
+	return nil
 }
 
 // identifyOrphanedComponents identifies components that will become orphaned after service deletion.
@@ -13,4 +13,56 @@ const (
 
 	// ErrMsgApplicationNameExists is returned when an application with the given name already exists.
 	ErrMsgApplicationNameExists = "application with name '%s' already exists"
+
+	// ErrMsgApplicationBusy is returned when an application cannot be reconfigured while it is being deployed.
+	ErrMsgApplicationBusy = "application is being deployed; wait until it is Running or Error"
+
+	// ErrMsgCatalogIDImmutable is returned when a reconfiguration names another catalog item.
+	ErrMsgCatalogIDImmutable = "catalog_id cannot change: application was created from '%s'"
+
+	// ErrMsgUnmatchedLegacyComponent is returned when a component deployed before configuration hashes
+	// were recorded matches no planned component, and a reconfiguration would delete it with its data.
+	ErrMsgUnmatchedLegacyComponent = "component '%s' was deployed by an earlier version and the new configuration has no component of that type and provider; reconfiguring would delete it and its data"
+
+	// ErrMsgDrainUnsupported is returned when the runtime cannot stop the applications of a worker.
+	ErrMsgDrainUnsupported = "stopping the applications of a worker is not supported on the OpenShift runtime"
+
+	// ErrMsgServiceNotFound is returned when an application has no service with the given ID.
+	ErrMsgServiceNotFound = "service '%s' does not exist in this application"
+
+	// ErrMsgComponentNotFound is returned when an application has no component with the given type or ID.
+	ErrMsgComponentNotFound = "component '%s' does not exist in this application"
+
+	// ErrMsgApplicationNotSettled is returned when an application is started or stopped while it is being deployed or deleted.
+	ErrMsgApplicationNotSettled = "application is %s; wait until it is Running, Stopped or Error"
+
+	// ErrMsgLifecycleUnsupported is returned when the runtime cannot start or stop the pods of an application.
+	ErrMsgLifecycleUnsupported = "starting and stopping applications is not supported on the OpenShift runtime"
+
+	// ErrMsgPodNotFound is returned when an application has no pod with the given name or ID.
+	ErrMsgPodNotFound = "pod '%s' does not belong to this application"
+
+	// ErrMsgPodAmbiguous is returned when a pod name or ID prefix matches several pods of an application.
+	ErrMsgPodAmbiguous = "pod '%s' is ambiguous: it matches pods %s"
+
+	// ErrMsgContainerNotFound is returned when a pod has no container with the given name or ID.
+	ErrMsgContainerNotFound = "container '%s' does not exist in pod '%s'"
+
+	// ErrMsgServiceNotReachable is returned when no running pod of a service exposes a route.
+	ErrMsgServiceNotReachable = "service '%s' has no running pod that exposes an endpoint"
+
+	// ErrMsgProxyUnsupported is returned when service requests cannot be proxied on the runtime.
+	ErrMsgProxyUnsupported = "proxying requests to services is not supported on the OpenShift runtime; use the service route instead"
+
+	// ErrMsgJobNotFound is returned when a job does not exist.
+	ErrMsgJobNotFound = "job does not exist"
+
+	// ErrMsgUserNotJobOwner is returned when a user did not start the job.
+	ErrMsgUserNotJobOwner = "user does not own this job"
+
+	// ErrMsgJobFinished is returned when a finished job is cancelled.
+	ErrMsgJobFinished = "job has already finished with status '%s'"
+
+	// ErrMsgDeletionNotCancellable is returned when a deletion job is cancelled.
+	ErrMsgDeletionNotCancellable = "deletion jobs cannot be cancelled"
 )
@@ -0,0 +1,39 @@
+package applicationservice
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	apimodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+)
+
+// SubscribeApplicationEvents returns the recorded deployment events of the application
+// numbered after afterSeq and a channel receiving the ones emitted from then on. The
+// channel is closed when the subscriber falls behind or the application is deleted;
+// cancel must be called once the subscriber stops reading.
+func (s *ApplicationServiceBase) SubscribeApplicationEvents(ctx context.Context, id uuid.UUID, afterSeq int64) ([]apimodels.ApplicationEvent, <-chan apimodels.ApplicationEvent, func(), error) {
+	app, err := s.AppRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get application: %w", err)
+	}
+	if app == nil {
+		return nil, nil, nil, &ValidationError{
+			Code:    http.StatusNotFound,
+			Message: ErrMsgApplicationNotFound,
+		}
+	}
+
+	if s.Events == nil {
+		ch := make(chan apimodels.ApplicationEvent)
+
+		return nil, ch, func() { close(ch) }, nil
+	}
+
+	backlog, ch, cancel := s.Events.Subscribe(id, afterSeq)
+
+	return backlog, ch, cancel, nil
+}
+
+// Made with Bob
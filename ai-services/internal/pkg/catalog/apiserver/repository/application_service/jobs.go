@@ -0,0 +1,214 @@
+package applicationservice
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	apimodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/deployment"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/events"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/jobs"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/webhook"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	catalogutils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+)
+
+// deploymentJob is the payload of create and reconfigure jobs.
+type deploymentJob struct {
+	Plan    *deployment.DeploymentPlan         `json:"plan"`
+	Request apimodels.CreateApplicationRequest `json:"request"`
+	// Reconfiguration is set for reconfigure jobs.
+	Reconfiguration *reconfigurationJob `json:"reconfiguration,omitempty"`
+}
+
+// reconfigurationJob is the part of a reconfiguration its deployment acts on.
+type reconfigurationJob struct {
+	NewComponents     []string           `json:"new_components"` // Hashes of the planned components deployed anew
+	RemovedComponents []models.Component `json:"removed_components"`
+	ChangedServices   []models.Service   `json:"changed_services"`
+	RemovedServices   []models.Service   `json:"removed_services"`
+}
+
+// deletionJob is the payload of delete jobs.
+type deletionJob struct {
+	WorkerID             *uuid.UUID       `json:"worker_id,omitempty"`
+	Services             []models.Service `json:"services"`
+	OrphanedComponentIDs []uuid.UUID      `json:"orphaned_component_ids"`
+	KeepData             bool             `json:"keep_data"`
+}
+
+// newReconfigurationJob returns the payload part of a reconfiguration.
+func newReconfigurationJob(r *reconfiguration) *reconfigurationJob {
+	rj := &reconfigurationJob{
+		RemovedComponents: r.removedComponents,
+		ChangedServices:   r.changedServices,
+		RemovedServices:   r.removedServices,
+	}
+	for _, comp := range r.newComponents {
+		rj.NewComponents = append(rj.NewComponents, comp.Hash)
+	}
+
+	return rj
+}
+
+// reconfiguration rebuilds the reconfiguration of the plan a job deploys.
+func (rj *reconfigurationJob) reconfiguration(plan *deployment.DeploymentPlan) *reconfiguration {
+	r := &reconfiguration{
+		removedComponents: rj.RemovedComponents,
+		changedServices:   rj.ChangedServices,
+		removedServices:   rj.RemovedServices,
+	}
+	for _, hash := range rj.NewComponents {
+		if comp, ok := plan.Components[hash]; ok {
+			r.newComponents = append(r.newComponents, comp)
+		}
+	}
+
+	return r
+}
+
+// registerJobHandlers registers the handlers of application jobs with the job runner.
+func (s *ApplicationServiceBase) registerJobHandlers(runtimeType runtimeTypes.RuntimeType) {
+	deploy := jobs.Handler{
+		Run: func(ctx context.Context, job *models.Job) error {
+			return s.runDeploymentJob(ctx, job, runtimeType)
+		},
+		RollBack: s.rollBackJob,
+	}
+	s.Jobs.Register(models.JobKindCreate, deploy)
+	s.Jobs.Register(models.JobKindReconfigure, deploy)
+
+	s.Jobs.Register(models.JobKindDelete, jobs.Handler{
+		Run: func(ctx context.Context, job *models.Job) error {
+			return s.runDeletionJob(ctx, job, runtimeType)
+		},
+		RollBack: s.rollBackJob,
+	})
+}
+
+// runDeploymentJob deploys the plan of a create or reconfigure job.
+func (s *ApplicationServiceBase) runDeploymentJob(ctx context.Context, job *models.Job, runtimeType runtimeTypes.RuntimeType) error {
+	var payload deploymentJob
+	if err := decodeJobPayload(job.Payload, &payload); err != nil {
+		return s.failJob(ctx, job, err)
+	}
+	// The request's owner is not serialized.
+	payload.Request.CreatedBy = job.CreatedBy
+
+	var prepare func(ctx context.Context) error
+	if payload.Reconfiguration != nil {
+		r := payload.Reconfiguration.reconfiguration(payload.Plan)
+		prepare = func(ctx context.Context) error {
+			return s.pruneReconfiguration(ctx, payload.Plan, r, runtimeType)
+		}
+	}
+
+	ctx = events.NewContext(ctx, s.Events, job.ApplicationID)
+
+	return s.executeDeployment(ctx, payload.Plan, payload.Request, runtimeType, prepare)
+}
+
+// runDeletionJob deletes the services and components of a delete job.
+func (s *ApplicationServiceBase) runDeletionJob(ctx context.Context, job *models.Job, runtimeType runtimeTypes.RuntimeType) error {
+	var payload deletionJob
+	if err := decodeJobPayload(job.Payload, &payload); err != nil {
+		return s.failJob(ctx, job, err)
+	}
+
+	return s.executeDeletion(ctx, job.ApplicationID, payload.WorkerID, payload.Services, payload.OrphanedComponentIDs, payload.KeepData, runtimeType)
+}
+
+// rollBackJob settles the application of an interrupted job that is not resumed. The
+// application is set to Error, from where it can be reconfigured to deploy it again, or
+// deleted. An application being deleted is left to its deletion.
+func (s *ApplicationServiceBase) rollBackJob(ctx context.Context, job *models.Job, reason string) error {
+	app, err := s.AppRepo.GetByID(ctx, job.ApplicationID)
+	if err != nil {
+		return fmt.Errorf("failed to get application: %w", err)
+	}
+	if app == nil || (app.Status == models.ApplicationStatusDeleting && job.Kind != models.JobKindDelete) {
+		return nil
+	}
+
+	message := fmt.Sprintf("%s job rolled back: %s", job.Kind, reason)
+	if err := catalogutils.UpdateApplicationStatus(ctx, s.AppRepo, job.ApplicationID, models.ApplicationStatusError, message); err != nil {
+		return err
+	}
+	if job.Kind != models.JobKindDelete {
+		s.notifyDeploymentFinished(ctx, app.ID, app.Name, webhook.DeploymentFailed, message)
+	}
+
+	return nil
+}
+
+// failJob sets the application of a job that cannot run to Error and returns err.
+func (s *ApplicationServiceBase) failJob(ctx context.Context, job *models.Job, err error) error {
+	if updateErr := catalogutils.UpdateApplicationStatus(ctx, s.AppRepo, job.ApplicationID, models.ApplicationStatusError, err.Error()); updateErr != nil {
+		return fmt.Errorf("%w (and failed to update application status: %w)", err, updateErr)
+	}
+
+	return err
+}
+
+// decodeJobPayload decodes a job payload. Numbers are kept as json.Number, so that the
+// values of a plan render as they were planned.
+func decodeJobPayload(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("failed to decode job payload: %w", err)
+	}
+
+	return nil
+}
+
+// GetJob returns a job started by the user.
+func (s *ApplicationServiceBase) GetJob(ctx context.Context, id uuid.UUID, user string) (*models.Job, error) {
+	job, err := s.Jobs.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	if job == nil {
+		return nil, &ValidationError{Code: http.StatusNotFound, Message: ErrMsgJobNotFound}
+	}
+	if job.CreatedBy != user {
+		return nil, &ValidationError{Code: http.StatusForbidden, Message: ErrMsgUserNotJobOwner}
+	}
+
+	return job, nil
+}
+
+// CancelJob cancels a running create or reconfigure job started by the user. The job
+// stops at its next step and leaves its application in Error; what it deployed so far
+// stays until the application is reconfigured or deleted.
+func (s *ApplicationServiceBase) CancelJob(ctx context.Context, id uuid.UUID, user string) (*models.Job, error) {
+	job, err := s.GetJob(ctx, id, user)
+	if err != nil {
+		return nil, err
+	}
+	if job.Kind == models.JobKindDelete {
+		return nil, &ValidationError{Code: http.StatusConflict, Message: ErrMsgDeletionNotCancellable}
+	}
+	if job.Status != models.JobStatusRunning {
+		return nil, &ValidationError{Code: http.StatusConflict, Message: fmt.Sprintf(ErrMsgJobFinished, job.Status)}
+	}
+
+	cancelled, err := s.Jobs.Cancel(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel job: %w", err)
+	}
+	if !cancelled {
+		// The job finished since it was read.
+		return s.GetJob(ctx, id, user)
+	}
+	job.CancelRequested = true
+
+	return job, nil
+}
+
+// Made with Bob
@@ -0,0 +1,599 @@
+package applicationservice
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/google/uuid"
+	k8syaml "sigs.k8s.io/yaml"
+
+	apimodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	dbrepo "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	catalogutils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
+	clipodman "github.com/project-ai-services/ai-services/internal/pkg/cli/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	podmodels "github.com/project-ai-services/ai-services/internal/pkg/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/common"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+// podStatusRunning is the status the runtime lists running pods with.
+const podStatusRunning = "Running"
+
+// lifecycleTarget is a service or component of an application that start, stop and
+// restart act on.
+type lifecycleTarget struct {
+	kind       string // "service" or "component"
+	name       string // Service catalog ID, or "<component type>/<provider>" for components
+	templateID uuid.UUID
+	stopped    bool // The service or component is Stopped: its pods were stopped on purpose
+	// templates maps the name of each pod the target's templates create to the
+	// podTemplateExecutions layer that creates it.
+	templates map[string]podTemplate
+}
+
+// podTemplate is the rendered pod template a pod of a lifecycle target was created from.
+type podTemplate struct {
+	name  string
+	layer int
+	spec  *podmodels.PodSpec
+}
+
+// layer returns the podTemplateExecutions layer of pod, or math.MaxInt when the pod
+// matches none of the target's templates.
+func (t *lifecycleTarget) layer(pod types.Pod) int {
+	if tmpl, ok := t.templates[pod.Name]; ok {
+		return tmpl.layer
+	}
+
+	return math.MaxInt
+}
+
+// pods returns the pods of the target in podTemplateExecutions order. Pods that match
+// no template follow, oldest first.
+func (t *lifecycleTarget) pods(rt runtime.Runtime) ([]types.Pod, error) {
+	pods, err := common.FetchFilteredPods(rt, t.templateID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(pods, func(a, b types.Pod) int {
+		return cmp.Or(cmp.Compare(t.layer(a), t.layer(b)), a.Created.Compare(b.Created))
+	})
+
+	return pods, nil
+}
+
+// StartApplication starts the stopped pods of an application, or of the selected services
+// and components. Components start before services, and the pods of each in their
+// podTemplateExecutions order: a layer starts once the pods of the previous one are
+// ready. The started services and components that were Stopped are Running again, and
+// so is the application once no pods are stopped but those of the ones still Stopped.
+func (s *ApplicationServiceBase) StartApplication(ctx context.Context, id uuid.UUID, user string, req apimodels.ApplicationLifecycleRequest) (*apimodels.ApplicationLifecycleResponse, error) {
+	app, targets, err := s.getLifecycleTargets(ctx, id, user, req)
+	if err != nil {
+		return nil, err
+	}
+
+	rt, err := catalogutils.AppRuntime(ctx, app.WorkerID, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to init runtime client: %w", err)
+	}
+
+	resp := newLifecycleResponse(app)
+	if err := startTargets(ctx, rt, targets, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, s.recordStarted(ctx, rt, app, targets, resp)
+}
+
+// StopApplication stops the pods of an application, or of the selected services and
+// components, in the reverse of the order StartApplication starts them. Components that
+// applications which are not stopped use too keep running. The stopped services and
+// components become Stopped, so that the sync service expects their pods not to run and
+// keeps checking the rest of the application. The application itself becomes Stopped,
+// which the sync service leaves alone, once all of its services and components are.
+func (s *ApplicationServiceBase) StopApplication(ctx context.Context, id uuid.UUID, user string, req apimodels.ApplicationLifecycleRequest) (*apimodels.ApplicationLifecycleResponse, error) {
+	app, targets, err := s.getLifecycleTargets(ctx, id, user, req)
+	if err != nil {
+		return nil, err
+	}
+
+	rt, err := catalogutils.AppRuntime(ctx, app.WorkerID, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to init runtime client: %w", err)
+	}
+
+	resp := newLifecycleResponse(app)
+	if err := s.stopTargets(ctx, rt, app, targets, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, s.recordStopped(ctx, app, targets, resp)
+}
+
+// RestartApplication stops and starts again the pods of an application, or of the selected
+// services and components.
+func (s *ApplicationServiceBase) RestartApplication(ctx context.Context, id uuid.UUID, user string, req apimodels.ApplicationLifecycleRequest) (*apimodels.ApplicationLifecycleResponse, error) {
+	app, targets, err := s.getLifecycleTargets(ctx, id, user, req)
+	if err != nil {
+		return nil, err
+	}
+
+	rt, err := catalogutils.AppRuntime(ctx, app.WorkerID, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to init runtime client: %w", err)
+	}
+
+	resp := newLifecycleResponse(app)
+	if err := s.stopTargets(ctx, rt, app, targets, resp); err != nil {
+		return nil, err
+	}
+	if err := startTargets(ctx, rt, targets, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, s.recordStarted(ctx, rt, app, targets, resp)
+}
+
+// getLifecycleTargets returns the application after checking that the caller owns it and
+// that it is not being deployed or deleted, and the targets req selects, components first.
+func (s *ApplicationServiceBase) getLifecycleTargets(ctx context.Context, id uuid.UUID, user string, req apimodels.ApplicationLifecycleRequest) (*models.Application, []lifecycleTarget, error) {
+	app, err := s.AppRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get application: %w", err)
+	}
+	if app == nil {
+		return nil, nil, &ValidationError{Code: http.StatusNotFound, Message: ErrMsgApplicationNotFound}
+	}
+	if app.CreatedBy != user {
+		return nil, nil, &ValidationError{Code: http.StatusForbidden, Message: ErrMsgUserNotOwner}
+	}
+
+	switch app.Status {
+	case models.ApplicationStatusDownloading, models.ApplicationStatusDeploying, models.ApplicationStatusDeleting:
+		return nil, nil, &ValidationError{Code: http.StatusConflict, Message: fmt.Sprintf(ErrMsgApplicationNotSettled, app.Status)}
+	}
+
+	deployedComponents, err := s.loadDeployedComponents(ctx, app.Services)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	services, components, err := selectLifecycleTargets(app.Services, deployedComponents, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	targets := make([]lifecycleTarget, 0, len(components)+len(services))
+	for _, comp := range components {
+		target := lifecycleTarget{
+			kind:       "component",
+			name:       comp.Type + "/" + comp.Provider,
+			templateID: comp.ID,
+			stopped:    comp.Status == models.ComponentStatusStopped,
+		}
+		if target.templates, err = s.componentPodTemplates(comp); err != nil {
+			logger.WarningfCtx(ctx, "Pods of component %s are not ordered by podTemplateExecutions: %v", target.name, err)
+		}
+		targets = append(targets, target)
+	}
+	for _, svc := range services {
+		target := lifecycleTarget{
+			kind:       "service",
+			name:       svc.CatalogID,
+			templateID: svc.ID,
+			stopped:    svc.Status == models.ServiceStatusStopped,
+		}
+		if target.templates, err = s.servicePodTemplates(app.ID, svc); err != nil {
+			logger.WarningfCtx(ctx, "Pods of service %s are not ordered by podTemplateExecutions: %v", target.name, err)
+		}
+		targets = append(targets, target)
+	}
+
+	return app, targets, nil
+}
+
+// selectLifecycleTargets returns the services and components req selects. Services are
+// selected by catalog ID or ID, components by type, "<type>/<provider>" or ID. An empty
+// request selects all of them.
+func selectLifecycleTargets(services []models.Service, components []models.Component, req apimodels.ApplicationLifecycleRequest) ([]models.Service, []models.Component, error) {
+	if len(req.Services) == 0 && len(req.Components) == 0 {
+		return services, components, nil
+	}
+
+	var selectedServices []models.Service
+	for _, name := range req.Services {
+		i := slices.IndexFunc(services, func(svc models.Service) bool {
+			return svc.CatalogID == name || svc.ID.String() == name
+		})
+		if i < 0 {
+			return nil, nil, &ValidationError{Code: http.StatusNotFound, Message: fmt.Sprintf(ErrMsgServiceNotFound, name)}
+		}
+		if !slices.ContainsFunc(selectedServices, func(svc models.Service) bool { return svc.ID == services[i].ID }) {
+			selectedServices = append(selectedServices, services[i])
+		}
+	}
+
+	var selectedComponents []models.Component
+	for _, name := range req.Components {
+		matched := false
+		for _, comp := range components {
+			if comp.Type != name && comp.Type+"/"+comp.Provider != name && comp.ID.String() != name {
+				continue
+			}
+			matched = true
+			if !slices.ContainsFunc(selectedComponents, func(c models.Component) bool { return c.ID == comp.ID }) {
+				selectedComponents = append(selectedComponents, comp)
+			}
+		}
+		if !matched {
+			return nil, nil, &ValidationError{Code: http.StatusNotFound, Message: fmt.Sprintf(ErrMsgComponentNotFound, name)}
+		}
+	}
+
+	return selectedServices, selectedComponents, nil
+}
+
+// componentPodTemplates renders the pod templates of a deployed component.
+func (s *ApplicationServiceBase) componentPodTemplates(comp models.Component) (map[string]podTemplate, error) {
+	metadata, err := s.Provider.LoadComponentRuntimeMetadata(comp.Type, comp.Provider)
+	if err != nil {
+		return nil, err
+	}
+	tmpls, err := s.Provider.LoadComponentTemplates(comp.Type, comp.Provider)
+	if err != nil {
+		return nil, err
+	}
+	values, err := s.Provider.LoadComponentValues(comp.Type, comp.Provider, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderPodTemplates(metadata, tmpls, podTemplateParams(comp.ID, comp.ID, values)), nil
+}
+
+// servicePodTemplates renders the pod templates of a deployed service of an application.
+func (s *ApplicationServiceBase) servicePodTemplates(appID uuid.UUID, svc models.Service) (map[string]podTemplate, error) {
+	metadata, err := s.Provider.LoadServiceRuntimeMetadata(svc.CatalogID)
+	if err != nil {
+		return nil, err
+	}
+	tmpls, err := s.Provider.LoadServiceTemplates(svc.CatalogID)
+	if err != nil {
+		return nil, err
+	}
+	values, err := s.Provider.LoadServiceValues(svc.CatalogID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderPodTemplates(metadata, tmpls, podTemplateParams(appID, svc.ID, values)), nil
+}
+
+// podTemplateParams returns the parameters the deployers render pod templates with.
+// Components are named after their own ID and services after their application's.
+func podTemplateParams(instanceID, templateID uuid.UUID, values map[string]any) map[string]any {
+	return map[string]any{
+		"InstanceSlug": catalogutils.GenerateInstanceSlug(instanceID.String()),
+		"TemplateID":   templateID,
+		"BaseDir":      utils.GetBaseDir(),
+		"Values":       values,
+		"env":          map[string]map[string]string{},
+	}
+}
+
+// renderPodTemplates renders the templates of every podTemplateExecutions layer and maps
+// the name of the pod each one creates to its layer. Pod names only depend on the instance
+// slug, so the default values render them. Templates that fail to render, and all of them
+// when metadata defines no layers, are left out.
+func renderPodTemplates(metadata *templates.AppMetadata, tmpls map[string]*template.Template, params map[string]any) map[string]podTemplate {
+	rendered := make(map[string]podTemplate)
+
+	for layer, names := range metadata.PodTemplateExecutions {
+		for _, name := range names {
+			tmpl, ok := tmpls[name]
+			if !ok {
+				continue
+			}
+
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, params); err != nil {
+				continue
+			}
+
+			var spec podmodels.PodSpec
+			if err := k8syaml.Unmarshal(buf.Bytes(), &spec); err != nil || spec.Kind != "Pod" || spec.Name == "" {
+				continue
+			}
+			rendered[spec.Name] = podTemplate{name: name, layer: layer, spec: &spec}
+		}
+	}
+
+	return rendered
+}
+
+// startTargets starts the pods of targets that are not running, in order. Before a pod
+// of a later layer starts, the pods started from earlier layers of the target are ready.
+func startTargets(ctx context.Context, rt runtime.Runtime, targets []lifecycleTarget, resp *apimodels.ApplicationLifecycleResponse) error {
+	for i := range targets {
+		target := &targets[i]
+		pods, err := target.pods(rt)
+		if err != nil {
+			return err
+		}
+
+		var pending []types.Pod
+		for _, pod := range pods {
+			if pod.Status == podStatusRunning {
+				continue
+			}
+			if len(pending) > 0 && target.layer(pending[0]) != target.layer(pod) {
+				if err := waitForPodsReady(ctx, rt, target, pending); err != nil {
+					return err
+				}
+				pending = nil
+			}
+
+			if err := rt.StartPod(pod.ID); err != nil {
+				return fmt.Errorf("failed to start pod %s: %w", pod.Name, err)
+			}
+			resp.StartedPods = append(resp.StartedPods, pod.Name)
+			pending = append(pending, pod)
+		}
+	}
+
+	return nil
+}
+
+// waitForPodsReady waits until the started pods of a target that were created from one of
+// its templates are ready.
+func waitForPodsReady(ctx context.Context, rt runtime.Runtime, target *lifecycleTarget, pods []types.Pod) error {
+	for _, pod := range pods {
+		tmpl, ok := target.templates[pod.Name]
+		if !ok {
+			continue
+		}
+		if err := clipodman.WaitForPodsReady(ctx, rt, tmpl.spec, tmpl.name, []types.Pod{pod}); err != nil {
+			return fmt.Errorf("pod %s of %s %s did not become ready: %w", pod.Name, target.kind, target.name, err)
+		}
+	}
+
+	return nil
+}
+
+// stopTargets stops the running pods of targets in reverse order. Components used by
+// applications that are not stopped are skipped and reported in resp.
+func (s *ApplicationServiceBase) stopTargets(ctx context.Context, rt runtime.Runtime, app *models.Application, targets []lifecycleTarget, resp *apimodels.ApplicationLifecycleResponse) error {
+	active, err := s.activeServiceIDs(ctx, app.ID)
+	if err != nil {
+		return err
+	}
+
+	for i := len(targets) - 1; i >= 0; i-- {
+		target := &targets[i]
+		if target.kind == "component" {
+			shared, err := s.isComponentShared(ctx, target.templateID, active)
+			if err != nil {
+				return err
+			}
+			if shared {
+				resp.Skipped = append(resp.Skipped, target.name)
+
+				continue
+			}
+		}
+
+		pods, err := target.pods(rt)
+		if err != nil {
+			return err
+		}
+		for _, pod := range slices.Backward(pods) {
+			if pod.Status != podStatusRunning {
+				continue
+			}
+			if err := rt.StopPod(pod.ID); err != nil {
+				return fmt.Errorf("failed to stop pod %s: %w", pod.Name, err)
+			}
+			resp.StoppedPods = append(resp.StoppedPods, pod.Name)
+		}
+	}
+
+	return nil
+}
+
+// activeServiceIDs returns the services of the applications other than appID that are
+// not stopped, either on their own or with their application.
+func (s *ApplicationServiceBase) activeServiceIDs(ctx context.Context, appID uuid.UUID) (map[uuid.UUID]bool, error) {
+	applications, err := s.AppRepo.GetAll(ctx, &dbrepo.ApplicationFilters{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve applications: %w", err)
+	}
+
+	active := make(map[uuid.UUID]bool)
+	for _, app := range applications {
+		if app.ID == appID || app.Status == models.ApplicationStatusStopped {
+			continue
+		}
+		for _, svc := range app.Services {
+			if svc.Status != models.ServiceStatusStopped {
+				active[svc.ID] = true
+			}
+		}
+	}
+
+	return active, nil
+}
+
+// isComponentShared reports whether any of the active services depends on a component.
+func (s *ApplicationServiceBase) isComponentShared(ctx context.Context, componentID uuid.UUID, active map[uuid.UUID]bool) (bool, error) {
+	dependents, err := s.ServiceDependencyRepo.GetServicesByDependency(ctx, componentID, models.DependencyTypeComponent)
+	if err != nil {
+		return false, fmt.Errorf("failed to get services using component %s: %w", componentID, err)
+	}
+
+	return slices.ContainsFunc(dependents, func(id uuid.UUID) bool { return active[id] }), nil
+}
+
+// recordStopped marks the targets that were not skipped Stopped and names them in the
+// response message. The application becomes Stopped once all of its services are, and all
+// of its components but those kept running for other applications. Until then it keeps
+// its status, and the sync service keeps checking the services and components that run.
+func (s *ApplicationServiceBase) recordStopped(ctx context.Context, app *models.Application, targets []lifecycleTarget, resp *apimodels.ApplicationLifecycleResponse) error {
+	names := make([]string, 0, len(targets))
+	done := make(map[uuid.UUID]bool, len(targets))
+	for i := range targets {
+		target := &targets[i]
+		done[target.templateID] = true
+		if slices.Contains(resp.Skipped, target.name) {
+			continue
+		}
+		if err := s.setTargetStopped(ctx, target, true); err != nil {
+			return err
+		}
+		names = append(names, target.kind+" "+target.name)
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+	resp.Message = "Stopped " + strings.Join(names, ", ")
+
+	components, err := s.loadDeployedComponents(ctx, app.Services)
+	if err != nil {
+		return err
+	}
+	if !applicationStopped(app.Services, components, done) {
+		return nil
+	}
+
+	resp.Status = string(models.ApplicationStatusStopped)
+
+	return catalogutils.UpdateApplicationStatus(ctx, s.AppRepo, app.ID, models.ApplicationStatusStopped, resp.Message)
+}
+
+// applicationStopped reports whether every service and component of an application is
+// Stopped or was acted on by the lifecycle action that done holds the IDs of.
+func applicationStopped(services []models.Service, components []models.Component, done map[uuid.UUID]bool) bool {
+	for _, svc := range services {
+		if svc.Status != models.ServiceStatusStopped && !done[svc.ID] {
+			return false
+		}
+	}
+	for _, comp := range components {
+		if comp.Status != models.ComponentStatusStopped && !done[comp.ID] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// setTargetStopped records whether the pods of a target are stopped on purpose. A target
+// that is started again is Running until the sync service checks its pods.
+func (s *ApplicationServiceBase) setTargetStopped(ctx context.Context, target *lifecycleTarget, stopped bool) error {
+	if target.kind == "component" {
+		status := models.ComponentStatusRunning
+		if stopped {
+			status = models.ComponentStatusStopped
+		}
+
+		return catalogutils.UpdateComponentStatus(ctx, s.ComponentRepo, target.templateID, status, "")
+	}
+
+	status := models.ServiceStatusRunning
+	if stopped {
+		status = models.ServiceStatusStopped
+	}
+
+	return catalogutils.UpdateServiceStatus(ctx, s.ServiceRepo, target.templateID, status, "")
+}
+
+// recordStarted marks the started targets that were Stopped Running again, and the
+// application Running once none of its pods is stopped but those of the services and
+// components that are still Stopped; the sync service then reports its health again.
+// Otherwise the application keeps its status and the message names the pods that are
+// stopped.
+func (s *ApplicationServiceBase) recordStarted(ctx context.Context, rt runtime.Runtime, app *models.Application, targets []lifecycleTarget, resp *apimodels.ApplicationLifecycleResponse) error {
+	started := make(map[uuid.UUID]bool, len(targets))
+	for i := range targets {
+		target := &targets[i]
+		started[target.templateID] = true
+		if !target.stopped {
+			continue
+		}
+		if err := s.setTargetStopped(ctx, target, false); err != nil {
+			return err
+		}
+	}
+
+	components, err := s.loadDeployedComponents(ctx, app.Services)
+	if err != nil {
+		return err
+	}
+
+	templateIDs := make([]uuid.UUID, 0, len(app.Services)+len(components))
+	for _, svc := range app.Services {
+		if svc.Status != models.ServiceStatusStopped || started[svc.ID] {
+			templateIDs = append(templateIDs, svc.ID)
+		}
+	}
+	for _, comp := range components {
+		if comp.Status != models.ComponentStatusStopped {
+			templateIDs = append(templateIDs, comp.ID)
+		}
+	}
+
+	var stopped []string
+	for _, templateID := range templateIDs {
+		pods, err := common.FetchFilteredPods(rt, templateID.String())
+		if err != nil {
+			return err
+		}
+		for _, pod := range pods {
+			if pod.Status != podStatusRunning {
+				stopped = append(stopped, pod.Name)
+			}
+		}
+	}
+
+	if len(stopped) > 0 {
+		if app.Status != models.ApplicationStatusStopped {
+			return nil
+		}
+		resp.Message = "Stopped pods: " + strings.Join(stopped, ", ")
+
+		return catalogutils.UpdateApplicationStatus(ctx, s.AppRepo, app.ID, models.ApplicationStatusStopped, resp.Message)
+	}
+
+	resp.Status = string(models.ApplicationStatusRunning)
+	resp.Message = ""
+
+	return catalogutils.UpdateApplicationStatus(ctx, s.AppRepo, app.ID, models.ApplicationStatusRunning, "")
+}
+
+// newLifecycleResponse returns the response of a lifecycle action on app before it acts.
+func newLifecycleResponse(app *models.Application) *apimodels.ApplicationLifecycleResponse {
+	return &apimodels.ApplicationLifecycleResponse{
+		ID:          app.ID.String(),
+		Name:        app.Name,
+		Status:      string(app.Status),
+		Message:     app.Message,
+		StoppedPods: []string{},
+		StartedPods: []string{},
+	}
+}
+
+// Made with Bob
@@ -0,0 +1,148 @@
+package applicationservice
+
+import (
+	"net/http"
+	"testing"
+	"text/template"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apimodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+)
+
+func TestSelectLifecycleTargets(t *testing.T) {
+	chat := models.Service{ID: uuid.New(), CatalogID: "chat"}
+	digitize := models.Service{ID: uuid.New(), CatalogID: "digitize"}
+	llm := models.Component{ID: uuid.New(), Type: "llm", Provider: "vllm-cpu"}
+	embedding := models.Component{ID: uuid.New(), Type: "embedding", Provider: "vllm-cpu"}
+	services := []models.Service{chat, digitize}
+	components := []models.Component{llm, embedding}
+
+	tests := []struct {
+		name           string
+		req            apimodels.ApplicationLifecycleRequest
+		wantServices   []models.Service
+		wantComponents []models.Component
+		wantErr        string
+	}{
+		{
+			name:           "empty request selects everything",
+			wantServices:   services,
+			wantComponents: components,
+		},
+		{
+			name:         "services by catalog ID or ID",
+			req:          apimodels.ApplicationLifecycleRequest{Services: []string{digitize.ID.String(), "digitize", "chat"}},
+			wantServices: []models.Service{digitize, chat},
+		},
+		{
+			name:           "components by type, type and provider, or ID",
+			req:            apimodels.ApplicationLifecycleRequest{Components: []string{"embedding/vllm-cpu", "llm", llm.ID.String()}},
+			wantComponents: []models.Component{embedding, llm},
+		},
+		{
+			name:    "unknown service",
+			req:     apimodels.ApplicationLifecycleRequest{Services: []string{"summarize"}},
+			wantErr: "service 'summarize' does not exist in this application",
+		},
+		{
+			name:    "unknown component",
+			req:     apimodels.ApplicationLifecycleRequest{Components: []string{"llm/watsonx"}},
+			wantErr: "component 'llm/watsonx' does not exist in this application",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotServices, gotComponents, err := selectLifecycleTargets(services, components, tt.req)
+			if tt.wantErr != "" {
+				var valErr *ValidationError
+				require.ErrorAs(t, err, &valErr)
+				assert.Equal(t, http.StatusNotFound, valErr.Code)
+				assert.Equal(t, tt.wantErr, valErr.Message)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantServices, gotServices)
+			assert.Equal(t, tt.wantComponents, gotComponents)
+		})
+	}
+}
+
+func TestApplicationStopped(t *testing.T) {
+	chat := models.Service{ID: uuid.New(), CatalogID: "chat", Status: models.ServiceStatusRunning}
+	digitize := models.Service{ID: uuid.New(), CatalogID: "digitize", Status: models.ServiceStatusStopped}
+	llm := models.Component{ID: uuid.New(), Type: "llm", Status: models.ComponentStatusRunning}
+	embedding := models.Component{ID: uuid.New(), Type: "embedding", Status: models.ComponentStatusStopped}
+	services := []models.Service{chat, digitize}
+	components := []models.Component{llm, embedding}
+
+	tests := []struct {
+		name string
+		done []uuid.UUID
+		want bool
+	}{
+		{
+			name: "nothing acted on",
+			want: false,
+		},
+		{
+			name: "running service left",
+			done: []uuid.UUID{llm.ID},
+			want: false,
+		},
+		{
+			name: "running component left",
+			done: []uuid.UUID{chat.ID},
+			want: false,
+		},
+		{
+			name: "the rest was already stopped",
+			done: []uuid.UUID{chat.ID, llm.ID},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done := make(map[uuid.UUID]bool)
+			for _, id := range tt.done {
+				done[id] = true
+			}
+			assert.Equal(t, tt.want, applicationStopped(services, components, done))
+		})
+	}
+}
+
+func TestRenderPodTemplates(t *testing.T) {
+	parse := func(text string) *template.Template {
+		return template.Must(template.New("").Parse(text))
+	}
+	tmpls := map[string]*template.Template{
+		"secret.yaml.tmpl": parse("apiVersion: v1\nkind: Secret\nmetadata:\n  name: db-secret-{{ .InstanceSlug }}\n"),
+		"db.yaml.tmpl":     parse("apiVersion: v1\nkind: Pod\nmetadata:\n  name: db-{{ .InstanceSlug }}\n"),
+		"api.yaml.tmpl":    parse("apiVersion: v1\nkind: Pod\nmetadata:\n  name: api-{{ .InstanceSlug }}\n  labels:\n    port: \"{{ .Values.api.port }}\"\n"),
+		"broken.yaml.tmpl": parse("apiVersion: v1\nkind: Pod\nmetadata:\n  name: broken-{{ template \"undefined\" }}\n"),
+	}
+	metadata := &templates.AppMetadata{PodTemplateExecutions: [][]string{
+		{"secret.yaml.tmpl"},
+		{"db.yaml.tmpl"},
+		{"api.yaml.tmpl", "broken.yaml.tmpl"},
+	}}
+	params := map[string]any{"InstanceSlug": "abc", "Values": map[string]any{"api": map[string]any{"port": 8000}}}
+
+	rendered := renderPodTemplates(metadata, tmpls, params)
+
+	require.Len(t, rendered, 2)
+	assert.Equal(t, 1, rendered["db-abc"].layer)
+	assert.Equal(t, "api.yaml.tmpl", rendered["api-abc"].name)
+	assert.Equal(t, 2, rendered["api-abc"].layer)
+}
+
+// Made with Bob
@@ -0,0 +1,102 @@
+package applicationservice
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	catalogutils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/common"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+)
+
+// PodLogStream writes the logs of a pod, or of one of its containers, to w. With
+// opts.Follow it keeps writing until the container exits or ctx is cancelled.
+type PodLogStream func(ctx context.Context, w io.Writer, opts runtimeTypes.LogOptions) error
+
+// PodLogs returns the log stream of a pod of an application. podRef is the pod name or ID,
+// or a unique prefix of one, and container, if set, the name or ID of one of its containers. Only the pods labelled
+// with the ID of one of the application's services or components are found, so the logs
+// of other applications and of the catalog itself cannot be read.
+func (s *ApplicationServiceBase) PodLogs(ctx context.Context, appID uuid.UUID, podRef, container, namespace string) (PodLogStream, error) {
+	app, err := s.AppRepo.GetByID(ctx, appID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+	if app == nil {
+		return nil, &ValidationError{Code: http.StatusNotFound, Message: ErrMsgApplicationNotFound}
+	}
+
+	rt, err := catalogutils.AppRuntime(ctx, app.WorkerID, namespace)
+	if err != nil {
+		return nil, &ValidationError{Code: http.StatusServiceUnavailable, Message: fmt.Sprintf("failed to init runtime client: %v", err)}
+	}
+
+	templateIDs, err := s.applicationTemplateIDs(ctx, app.Services)
+	if err != nil {
+		return nil, err
+	}
+
+	pod, err := findApplicationPod(rt, templateIDs, podRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if container == "" {
+		return func(ctx context.Context, w io.Writer, opts runtimeTypes.LogOptions) error {
+			return rt.PodLogs(ctx, pod.Name, w, opts)
+		}, nil
+	}
+
+	for _, c := range pod.Containers {
+		if c.Name == container || c.ID == container {
+			return func(ctx context.Context, w io.Writer, opts runtimeTypes.LogOptions) error {
+				return rt.ContainerLogs(ctx, c.Name, w, opts)
+			}, nil
+		}
+	}
+
+	return nil, &ValidationError{Code: http.StatusNotFound, Message: fmt.Sprintf(ErrMsgContainerNotFound, container, podRef)}
+}
+
+// findApplicationPod returns the pod podRef refers to among the pods labelled with one of
+// templateIDs. podRef is a pod name or ID, or a prefix of exactly one of them, such as a
+// short ID or a pod name without its instance slug. A prefix of several is ambiguous.
+func findApplicationPod(rt runtime.Runtime, templateIDs []string, podRef string) (*runtimeTypes.Pod, error) {
+	var matches []runtimeTypes.Pod
+	for _, templateID := range templateIDs {
+		pods, err := common.FetchFilteredPods(rt, templateID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range pods {
+			if pods[i].Name == podRef || pods[i].ID == podRef {
+				return &pods[i], nil
+			}
+			if strings.HasPrefix(pods[i].Name, podRef) || strings.HasPrefix(pods[i].ID, podRef) {
+				matches = append(matches, pods[i])
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, &ValidationError{Code: http.StatusNotFound, Message: fmt.Sprintf(ErrMsgPodNotFound, podRef)}
+	case 1:
+		return &matches[0], nil
+	}
+
+	names := make([]string, 0, len(matches))
+	for _, pod := range matches {
+		names = append(names, pod.Name)
+	}
+
+	return nil, &ValidationError{Code: http.StatusBadRequest, Message: fmt.Sprintf(ErrMsgPodAmbiguous, podRef, strings.Join(names, ", "))}
+}
+
+// Made with Bob
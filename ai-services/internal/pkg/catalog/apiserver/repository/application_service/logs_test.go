@@ -0,0 +1,116 @@
+package applicationservice
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+)
+
+// labelledPodsRuntime lists the pods labelled with each template ID. Other runtime calls
+// panic via the nil embedded interface.
+type labelledPodsRuntime struct {
+	runtime.Runtime
+
+	pods map[string][]runtimeTypes.Pod
+}
+
+func (r *labelledPodsRuntime) ListPods(filters map[string][]string) ([]runtimeTypes.Pod, error) {
+	var pods []runtimeTypes.Pod
+	for _, label := range filters["label"] {
+		templateID, ok := strings.CutPrefix(label, constants.ApplicationTemplateKey+"=")
+		if ok {
+			pods = append(pods, r.pods[templateID]...)
+		}
+	}
+
+	return pods, nil
+}
+
+func TestFindApplicationPod(t *testing.T) {
+	chat := runtimeTypes.Pod{ID: "4b1f0c2e9d7a", Name: "chat-bot-3e65b964ae"}
+	chatUI := runtimeTypes.Pod{ID: "a9e3d5f1c7b2", Name: "chat-ui-3e65b964ae"}
+	llm := runtimeTypes.Pod{ID: "c2d8e6a4f0b9", Name: "llm-7f41a2c9d3"}
+	otherLLM := runtimeTypes.Pod{ID: "e7c1b3a5d9f2", Name: "llm-b5d0e8f6a1"}
+	catalogDB := runtimeTypes.Pod{ID: "f3a7c9e1b5d8", Name: "catalog-db"}
+
+	rt := &labelledPodsRuntime{pods: map[string][]runtimeTypes.Pod{
+		"service-chat":      {chat, chatUI},
+		"component-llm":     {llm},
+		"other-service":     {otherLLM},
+		"catalog-templates": {catalogDB},
+	}}
+	templateIDs := []string{"service-chat", "component-llm"}
+
+	tests := []struct {
+		name     string
+		podRef   string
+		want     runtimeTypes.Pod
+		wantCode int
+		wantErr  string
+	}{
+		{
+			name:   "name",
+			podRef: "chat-bot-3e65b964ae",
+			want:   chat,
+		},
+		{
+			name:   "ID",
+			podRef: "c2d8e6a4f0b9",
+			want:   llm,
+		},
+		{
+			name:   "name prefix",
+			podRef: "llm",
+			want:   llm,
+		},
+		{
+			name:   "ID prefix",
+			podRef: "a9e3",
+			want:   chatUI,
+		},
+		{
+			name:     "pod of another application",
+			podRef:   "llm-b5d0e8f6a1",
+			wantCode: http.StatusNotFound,
+			wantErr:  "pod 'llm-b5d0e8f6a1' does not belong to this application",
+		},
+		{
+			name:     "pod outside any application",
+			podRef:   "catalog",
+			wantCode: http.StatusNotFound,
+			wantErr:  "pod 'catalog' does not belong to this application",
+		},
+		{
+			name:     "ambiguous prefix",
+			podRef:   "chat",
+			wantCode: http.StatusBadRequest,
+			wantErr:  "pod 'chat' is ambiguous: it matches pods chat-bot-3e65b964ae, chat-ui-3e65b964ae",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod, err := findApplicationPod(rt, templateIDs, tt.podRef)
+			if tt.wantErr != "" {
+				var valErr *ValidationError
+				require.ErrorAs(t, err, &valErr)
+				assert.Equal(t, tt.wantCode, valErr.Code)
+				assert.Equal(t, tt.wantErr, valErr.Message)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, *pod)
+		})
+	}
+}
+
+// Made with Bob
@@ -2,9 +2,12 @@ package applicationservice
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/google/uuid"
 	apimodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/events"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/httpproxy"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
 	catalogutils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
 	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
@@ -16,12 +19,16 @@ type OpenShiftApplicationService struct {
 	ApplicationServiceBase
 }
 
-// NewOpenShiftApplicationService creates a new OpenShiftApplicationService with a fresh DeploymentRegistry
-// wired into the base. This makes in-flight deployments cancellable by a concurrent DeleteApplication.
+// NewOpenShiftApplicationService creates a new OpenShiftApplicationService with a fresh event Recorder wired into the
+// base, and registers the handlers of its deployment and deletion jobs with base.Jobs. This makes
+// the progress events of deployments available to GET /applications/:id/events.
 func NewOpenShiftApplicationService(base ApplicationServiceBase) *OpenShiftApplicationService {
-	base.DeploymentRegistry = NewDeploymentRegistry()
+	base.Events = events.NewRecorder(events.DefaultCapacity)
 
-	return &OpenShiftApplicationService{ApplicationServiceBase: base}
+	s := &OpenShiftApplicationService{ApplicationServiceBase: base}
+	s.registerJobHandlers(runtimeTypes.RuntimeTypeOpenShift)
+
+	return s
 }
 
 func (s *OpenShiftApplicationService) DeleteApplication(ctx context.Context, id uuid.UUID, user string, keepData bool) (*DeleteApplicationResponse, error) {
@@ -34,6 +41,18 @@ func (s *OpenShiftApplicationService) CreateApplication(ctx context.Context, req
 	return s.ApplicationServiceBase.CreateApplication(ctx, req, runtimeTypes.RuntimeTypeOpenShift)
 }
 
+// PlanApplication satisfies ApplicationServiceInterface by delegating to the base with
+// the OpenShift runtime type fixed.
+func (s *OpenShiftApplicationService) PlanApplication(ctx context.Context, req apimodels.CreateApplicationRequest) (*apimodels.PlanApplicationResponse, error) {
+	return s.ApplicationServiceBase.PlanApplication(ctx, req, runtimeTypes.RuntimeTypeOpenShift)
+}
+
+// UpdateApplication satisfies ApplicationServiceInterface by delegating to the base with
+// the OpenShift runtime type fixed.
+func (s *OpenShiftApplicationService) UpdateApplication(ctx context.Context, id uuid.UUID, req apimodels.CreateApplicationRequest) (*apimodels.UpdateApplicationResponse, error) {
+	return s.ApplicationServiceBase.UpdateApplication(ctx, id, req, runtimeTypes.RuntimeTypeOpenShift)
+}
+
 // GetApplicationResources retrieves CPU, memory, and Spyre-card usage for an application
 // using the OpenShift runtime. Each application is deployed into its own namespace
 // (ai-services-<first 8 chars of UUID>), so the runtime client is created with that namespace.
@@ -45,3 +64,35 @@ func (s *OpenShiftApplicationService) GetApplicationResources(ctx context.Contex
 func (s *OpenShiftApplicationService) ApplicationsPs(ctx context.Context, appID uuid.UUID) (*types.ApplicationPSResponse, error) {
 	return s.ApplicationServiceBase.ApplicationsPs(ctx, appID, catalogutils.AppNamespace(appID))
 }
+
+// PodLogs returns the log stream of a pod in the application's OpenShift namespace.
+func (s *OpenShiftApplicationService) PodLogs(ctx context.Context, appID uuid.UUID, pod, container string) (PodLogStream, error) {
+	return s.ApplicationServiceBase.PodLogs(ctx, appID, pod, container, catalogutils.AppNamespace(appID))
+}
+
+// DrainWorker is not supported on OpenShift: the runtime cannot stop individual pods,
+// so the applications of a drained OpenShift worker keep running.
+func (s *OpenShiftApplicationService) DrainWorker(context.Context, uuid.UUID, string) ([]types.DrainedApplication, error) {
+	return nil, &ValidationError{Code: http.StatusNotImplemented, Message: ErrMsgDrainUnsupported}
+}
+
+// StartApplication is not supported on OpenShift: the runtime cannot start individual pods.
+func (s *OpenShiftApplicationService) StartApplication(context.Context, uuid.UUID, string, apimodels.ApplicationLifecycleRequest) (*apimodels.ApplicationLifecycleResponse, error) {
+	return nil, &ValidationError{Code: http.StatusNotImplemented, Message: ErrMsgLifecycleUnsupported}
+}
+
+// StopApplication is not supported on OpenShift: the runtime cannot stop individual pods.
+func (s *OpenShiftApplicationService) StopApplication(context.Context, uuid.UUID, string, apimodels.ApplicationLifecycleRequest) (*apimodels.ApplicationLifecycleResponse, error) {
+	return nil, &ValidationError{Code: http.StatusNotImplemented, Message: ErrMsgLifecycleUnsupported}
+}
+
+// RestartApplication is not supported on OpenShift: the runtime cannot stop individual pods.
+func (s *OpenShiftApplicationService) RestartApplication(context.Context, uuid.UUID, string, apimodels.ApplicationLifecycleRequest) (*apimodels.ApplicationLifecycleResponse, error) {
+	return nil, &ValidationError{Code: http.StatusNotImplemented, Message: ErrMsgLifecycleUnsupported}
+}
+
+// ResolveServiceProxy is not supported on OpenShift: services are exposed through
+// cluster routes, which clients reach directly.
+func (s *OpenShiftApplicationService) ResolveServiceProxy(context.Context, uuid.UUID, string) (*httpproxy.Target, error) {
+	return nil, &ValidationError{Code: http.StatusNotImplemented, Message: ErrMsgProxyUnsupported}
+}
@@ -0,0 +1,188 @@
+package applicationservice
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+
+	apimodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/deployment"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/scheduler"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	rtmodels "github.com/project-ai-services/ai-services/internal/pkg/models"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// PlanApplication previews what CreateApplication would do with req: it validates and plans
+// the deployment and chooses its host, but inserts no records and allocates no Spyre cards.
+// Problems that would make the create fail after planning, such as a taken name or a host
+// without enough free resources, are reported as warnings.
+func (s *ApplicationServiceBase) PlanApplication(ctx context.Context, req apimodels.CreateApplicationRequest, runtimeType runtimeTypes.RuntimeType) (*apimodels.PlanApplicationResponse, error) {
+	if err := s.Validator.ValidateDeploymentRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	plan, err := s.DeploymentPlanner.PlanDeployment(ctx, req, runtimeType.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create deployment plan: %w", err)
+	}
+
+	resp := newPlanResponse(plan, runtimeType)
+
+	existingApp, err := s.AppRepo.GetByName(ctx, req.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for existing application: %w", err)
+	}
+	if existingApp != nil {
+		resp.Warnings = append(resp.Warnings, fmt.Sprintf(ErrMsgApplicationNameExists, req.Name))
+	}
+
+	resp.DeploymentOrder, err = s.Provider.GetDeploymentOrder(slices.Sorted(maps.Keys(plan.Services)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment order: %w", err)
+	}
+
+	if err := s.previewPlacement(ctx, req, plan, runtimeType, resp); err != nil {
+		return nil, err
+	}
+
+	if runtimeType == runtimeTypes.RuntimeTypePodman {
+		resp.Images, resp.Models, err = s.DeploymentPlanner.Downloads(ctx, plan)
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect images and models: %w", err)
+		}
+	}
+
+	return resp, nil
+}
+
+// newPlanResponse describes the components, services and required resources of plan.
+func newPlanResponse(plan *deployment.DeploymentPlan, runtimeType runtimeTypes.RuntimeType) *apimodels.PlanApplicationResponse {
+	resp := &apimodels.PlanApplicationResponse{
+		Name:           plan.ApplicationName,
+		CatalogID:      plan.CatalogID,
+		IsArchitecture: plan.IsArchitecture,
+		Runtime:        runtimeType.String(),
+		Components:     make([]apimodels.PlannedComponent, 0, len(plan.Components)),
+		Services:       make([]apimodels.PlannedService, 0, len(plan.Services)),
+		Resources:      apimodels.PlanResources{Required: requiredResources(plan.Resources)},
+		Images:         []string{},
+		Models:         []string{},
+		Warnings:       []string{},
+	}
+
+	for _, comp := range plan.Components {
+		usedBy := slices.Sorted(slices.Values(comp.UsedByServices))
+		resp.Components = append(resp.Components, apimodels.PlannedComponent{
+			Type:       comp.ComponentType,
+			Provider:   comp.ProviderID,
+			Version:    comp.Version,
+			Hash:       comp.Hash,
+			UsedBy:     usedBy,
+			Shared:     len(usedBy) > 1,
+			SpyreCards: comp.SpyreCards,
+		})
+	}
+	slices.SortFunc(resp.Components, func(a, b apimodels.PlannedComponent) int {
+		return cmp.Or(cmp.Compare(componentName(a.Type, a.Provider), componentName(b.Type, b.Provider)), cmp.Compare(a.Hash, b.Hash))
+	})
+
+	for _, catalogID := range slices.Sorted(maps.Keys(plan.Services)) {
+		svc := plan.Services[catalogID]
+		components := make([]string, 0, len(svc.ComponentRefs))
+		for _, hash := range svc.ComponentRefs {
+			comp := plan.Components[hash]
+			components = append(components, componentName(comp.ComponentType, comp.ProviderID))
+		}
+		resp.Services = append(resp.Services, apimodels.PlannedService{
+			CatalogID:  catalogID,
+			Version:    svc.Version,
+			Components: components,
+		})
+	}
+
+	return resp
+}
+
+// previewPlacement chooses the host of plan the way CreateApplication does and records it
+// with its free resources in resp. A placement that fails for lack of a suitable worker
+// is reported as a warning.
+func (s *ApplicationServiceBase) previewPlacement(ctx context.Context, req apimodels.CreateApplicationRequest, plan *deployment.DeploymentPlan, runtimeType runtimeTypes.RuntimeType, resp *apimodels.PlanApplicationResponse) error {
+	placement, err := s.scheduleDeployment(ctx, req, plan, runtimeType)
+	if err != nil {
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) || valErr.Code != http.StatusUnprocessableEntity {
+			return err
+		}
+		resp.Warnings = append(resp.Warnings, valErr.Message)
+
+		return nil
+	}
+
+	if placement != nil {
+		resp.Worker = &apimodels.PlannedWorker{ID: placement.Worker.ID.String(), Name: placement.Worker.Name}
+		resp.Resources.Available = availableResources(placement.SystemInfo)
+
+		return nil
+	}
+
+	// Without a worker the application runs on the local runtime, which the scheduler
+	// does not check for capacity.
+	info, err := localSystemInfo()
+	if err != nil {
+		resp.Warnings = append(resp.Warnings, fmt.Sprintf("could not read the free resources of the local runtime: %v", err))
+
+		return nil
+	}
+	resp.Resources.Available = availableResources(info)
+	if reason := scheduler.InsufficientReason(info, plan.Resources); reason != "" {
+		resp.Warnings = append(resp.Warnings, "local runtime: "+reason)
+	}
+
+	return nil
+}
+
+// localSystemInfo reads the capacity of the control plane's local runtime.
+func localSystemInfo() (*rtmodels.SystemInfo, error) {
+	rt, err := vars.RuntimeFactory.Create("")
+	if err != nil {
+		return nil, err
+	}
+
+	return rt.GetSystemInfo()
+}
+
+// requiredResources converts the aggregated requirements of a plan.
+func requiredResources(r templates.RuntimeResources) apimodels.ResourceAmounts {
+	return apimodels.ResourceAmounts{
+		CPU:          float64(r.CPU),
+		MemoryBytes:  int64(r.Memory),
+		StorageBytes: int64(r.Storage),
+		Accelerators: r.Accelerators,
+	}
+}
+
+// availableResources converts the free capacity a host reports.
+func availableResources(info *rtmodels.SystemInfo) *apimodels.ResourceAmounts {
+	available := &apimodels.ResourceAmounts{Accelerators: make(map[string]int, len(info.Accelerators))}
+	if info.CPU != nil {
+		available.CPU = info.CPU.Available
+	}
+	if info.Memory != nil {
+		available.MemoryBytes = info.Memory.AvailableBytes
+	}
+	for name, acc := range info.Accelerators {
+		if acc != nil {
+			available.Accelerators[name] = acc.Available
+		}
+	}
+
+	return available
+}
+
+// Made with Bob
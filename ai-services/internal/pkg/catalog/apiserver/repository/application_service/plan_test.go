@@ -0,0 +1,56 @@
+package applicationservice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apimodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/deployment"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	consts "github.com/project-ai-services/ai-services/internal/pkg/constants"
+	rtmodels "github.com/project-ai-services/ai-services/internal/pkg/models"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+)
+
+func TestNewPlanResponse(t *testing.T) {
+	plan := &deployment.DeploymentPlan{
+		ApplicationName: "assistant",
+		CatalogID:       "rag",
+		IsArchitecture:  true,
+		Components: map[string]*deployment.ComponentPlan{
+			"vs":  {Hash: "vs", ComponentType: "vector_store", ProviderID: "opensearch", UsedByServices: []string{"digitize", "chat"}},
+			"llm": {Hash: "llm", ComponentType: "llm", ProviderID: "vllm", UsedByServices: []string{"chat"}, SpyreCards: 4},
+		},
+		Services: map[string]*deployment.ServicePlan{
+			"digitize": {CatalogID: "digitize", ComponentRefs: []string{"vs"}},
+			"chat":     {CatalogID: "chat", ComponentRefs: []string{"llm", "vs"}},
+		},
+		Resources: templates.RuntimeResources{CPU: 8, Memory: 1 << 30, Accelerators: map[string]int{consts.SpyreResourceName: 4}},
+	}
+
+	resp := newPlanResponse(plan, runtimeTypes.RuntimeTypePodman)
+
+	assert.Equal(t, "podman", resp.Runtime)
+	assert.Equal(t, []apimodels.PlannedComponent{
+		{Type: "llm", Provider: "vllm", Hash: "llm", UsedBy: []string{"chat"}, SpyreCards: 4},
+		{Type: "vector_store", Provider: "opensearch", Hash: "vs", UsedBy: []string{"chat", "digitize"}, Shared: true},
+	}, resp.Components)
+	assert.Equal(t, []apimodels.PlannedService{
+		{CatalogID: "chat", Components: []string{"llm/vllm", "vector_store/opensearch"}},
+		{CatalogID: "digitize", Components: []string{"vector_store/opensearch"}},
+	}, resp.Services)
+	assert.Equal(t, apimodels.ResourceAmounts{CPU: 8, MemoryBytes: 1 << 30, Accelerators: map[string]int{consts.SpyreResourceName: 4}}, resp.Resources.Required)
+	assert.Empty(t, resp.Warnings)
+}
+
+func TestAvailableResources(t *testing.T) {
+	info := &rtmodels.SystemInfo{
+		CPU:          &rtmodels.CPUInfo{Total: 16, Available: 12.5},
+		Accelerators: map[string]*rtmodels.AcceleratorInfo{consts.SpyreResourceName: {Total: 8, Available: 3}},
+	}
+
+	assert.Equal(t, &apimodels.ResourceAmounts{CPU: 12.5, Accelerators: map[string]int{consts.SpyreResourceName: 3}}, availableResources(info))
+}
+
+// Made with Bob
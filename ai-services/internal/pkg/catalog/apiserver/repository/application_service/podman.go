@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 	apimodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/events"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
 	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
 )
@@ -16,12 +17,16 @@ type PodmanApplicationService struct {
 	ApplicationServiceBase
 }
 
-// NewPodmanApplicationService creates a new PodmanApplicationService with a fresh DeploymentRegistry
-// wired into the base. This makes in-flight deployments cancellable by a concurrent DeleteApplication.
+// NewPodmanApplicationService creates a new PodmanApplicationService with a fresh event Recorder wired into the
+// base, and registers the handlers of its deployment and deletion jobs with base.Jobs. This makes
+// the progress events of deployments available to GET /applications/:id/events.
 func NewPodmanApplicationService(base ApplicationServiceBase) *PodmanApplicationService {
-	base.DeploymentRegistry = NewDeploymentRegistry()
+	base.Events = events.NewRecorder(events.DefaultCapacity)
 
-	return &PodmanApplicationService{ApplicationServiceBase: base}
+	s := &PodmanApplicationService{ApplicationServiceBase: base}
+	s.registerJobHandlers(runtimeTypes.RuntimeTypePodman)
+
+	return s
 }
 
 func (s *PodmanApplicationService) DeleteApplication(ctx context.Context, id uuid.UUID, user string, keepData bool) (*DeleteApplicationResponse, error) {
@@ -34,11 +39,28 @@ func (s *PodmanApplicationService) CreateApplication(ctx context.Context, req ap
 	return s.ApplicationServiceBase.CreateApplication(ctx, req, runtimeTypes.RuntimeTypePodman)
 }
 
+// PlanApplication satisfies ApplicationServiceInterface by delegating to the base with
+// the Podman runtime type fixed.
+func (s *PodmanApplicationService) PlanApplication(ctx context.Context, req apimodels.CreateApplicationRequest) (*apimodels.PlanApplicationResponse, error) {
+	return s.ApplicationServiceBase.PlanApplication(ctx, req, runtimeTypes.RuntimeTypePodman)
+}
+
+// UpdateApplication satisfies ApplicationServiceInterface by delegating to the base with
+// the Podman runtime type fixed.
+func (s *PodmanApplicationService) UpdateApplication(ctx context.Context, id uuid.UUID, req apimodels.CreateApplicationRequest) (*apimodels.UpdateApplicationResponse, error) {
+	return s.ApplicationServiceBase.UpdateApplication(ctx, id, req, runtimeTypes.RuntimeTypePodman)
+}
+
 // ApplicationsPs retrieves pod/container status by querying Podman.
 func (s *PodmanApplicationService) ApplicationsPs(ctx context.Context, appID uuid.UUID) (*types.ApplicationPSResponse, error) {
 	return s.ApplicationServiceBase.ApplicationsPs(ctx, appID, "")
 }
 
+// PodLogs returns the log stream of a pod of the application from Podman.
+func (s *PodmanApplicationService) PodLogs(ctx context.Context, appID uuid.UUID, pod, container string) (PodLogStream, error) {
+	return s.ApplicationServiceBase.PodLogs(ctx, appID, pod, container, "")
+}
+
 // GetApplicationResources retrieves CPU, memory, and Spyre-card usage by querying Podman pods.
 func (s *PodmanApplicationService) GetApplicationResources(ctx context.Context, id uuid.UUID) (*types.ApplicationResourcesResponse, error) {
 	// Podman has no per-app namespace; pass empty string so the runtime factory
@@ -0,0 +1,132 @@
+package applicationservice
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/httpproxy"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	catalogutils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/proxy"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/common"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/remote"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+)
+
+// routeTypeAPI is the route type of the pod port that serves a service's API.
+const routeTypeAPI = "api"
+
+// ResolveServiceProxy finds the pod port that requests to a service of an application
+// are proxied to. serviceRef is the service ID or its catalog ID (e.g. "chat"). The
+// port is taken from the routes annotation of the service's running pods: the "api"
+// route is preferred, otherwise the first route is used.
+func (s *ApplicationServiceBase) ResolveServiceProxy(ctx context.Context, appID uuid.UUID, serviceRef string) (*httpproxy.Target, error) {
+	app, err := s.AppRepo.GetByID(ctx, appID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+	if app == nil {
+		return nil, &ValidationError{Code: http.StatusNotFound, Message: ErrMsgApplicationNotFound}
+	}
+
+	service := findService(app.Services, serviceRef)
+	if service == nil {
+		return nil, &ValidationError{Code: http.StatusNotFound, Message: fmt.Sprintf(ErrMsgServiceNotFound, serviceRef)}
+	}
+
+	rt, err := catalogutils.AppRuntime(ctx, app.WorkerID, "")
+	if err != nil {
+		return nil, &ValidationError{Code: http.StatusServiceUnavailable, Message: fmt.Sprintf("failed to init runtime client: %v", err)}
+	}
+
+	pods, err := common.FetchFilteredPods(rt, service.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	target := findRouteTarget(rt, pods)
+	if target == nil {
+		return nil, &ValidationError{Code: http.StatusServiceUnavailable, Message: fmt.Sprintf(ErrMsgServiceNotReachable, serviceRef)}
+	}
+	if remoteRT, ok := rt.(*remote.RemoteRuntime); ok {
+		target.WorkerName = remoteRT.WorkerName()
+	}
+
+	return target, nil
+}
+
+// findService returns the service whose ID or catalog ID is ref, or nil.
+func findService(services []models.Service, ref string) *models.Service {
+	for i := range services {
+		if services[i].ID.String() == ref || services[i].CatalogID == ref {
+			return &services[i]
+		}
+	}
+
+	return nil
+}
+
+// findRouteTarget returns the route of the first running pod that declares one,
+// preferring an "api" route, or nil when no running pod exposes a route.
+func findRouteTarget(rt runtime.Runtime, pods []types.Pod) *httpproxy.Target {
+	var fallback *httpproxy.Target
+
+	for _, pod := range pods {
+		if !strings.EqualFold(pod.Status, "running") {
+			continue
+		}
+
+		for _, route := range podRoutes(rt, pod) {
+			target := &httpproxy.Target{Pod: pod.Name, Port: route.Port}
+			if route.Type == routeTypeAPI {
+				return target
+			}
+			if fallback == nil {
+				fallback = target
+			}
+		}
+	}
+
+	return fallback
+}
+
+// podRoutes parses the routes annotation of a pod. Pod annotations are read from its
+// containers, which inherit them.
+func podRoutes(rt runtime.Runtime, pod types.Pod) []proxy.RouteEntryParts {
+	for _, c := range pod.Containers {
+		info, err := rt.InspectContainer(c.ID)
+		if err != nil {
+			logger.Warningf("Failed to inspect container %s of pod %s: %v", c.Name, pod.Name, err)
+
+			continue
+		}
+
+		annotation := info.Annotations[constants.PodRoutesAnnotationKey]
+		if annotation == "" {
+			continue
+		}
+
+		var routes []proxy.RouteEntryParts
+		for _, entry := range strings.Split(annotation, ",") {
+			parts, err := proxy.ParseRouteEntry(entry)
+			if err != nil {
+				logger.Warningf("Invalid route '%s' in pod %s: %v", entry, pod.Name, err)
+
+				continue
+			}
+			routes = append(routes, *parts)
+		}
+
+		return routes
+	}
+
+	return nil
+}
+
+// Made with Bob
@@ -0,0 +1,484 @@
+package applicationservice
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+
+	"github.com/google/uuid"
+	apimodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/deployment"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/jobs"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	catalogutils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	consts "github.com/project-ai-services/ai-services/internal/pkg/constants"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+)
+
+const (
+	changeKindService   = "service"
+	changeKindComponent = "component"
+)
+
+// reconfiguration is the difference between what an application runs and the plan
+// of its new configuration. Services are matched by catalog ID and components by
+// configuration hash, or by type and provider for components deployed before hashes
+// were recorded.
+type reconfiguration struct {
+	// newComponents are planned components no deployed component matches.
+	newComponents []*deployment.ComponentPlan
+	// removedComponents are deployed components no planned component matches.
+	removedComponents []models.Component
+	// adoptedComponents are planned components that take over a component deployed
+	// before configuration hashes were recorded, which keeps running with its data.
+	adoptedComponents []*deployment.ComponentPlan
+	// newServices are planned services the application does not have yet.
+	newServices []*deployment.ServicePlan
+	// changedServices are deployed services whose configuration hash changed.
+	changedServices []models.Service
+	// removedServices are deployed services the plan no longer contains.
+	removedServices []models.Service
+	// changes reports the change to every service and component.
+	changes []apimodels.ApplicationChange
+}
+
+// hasChanges reports whether anything has to be deployed or removed.
+func (r *reconfiguration) hasChanges() bool {
+	return len(r.newComponents)+len(r.removedComponents)+len(r.newServices)+len(r.changedServices)+len(r.removedServices) > 0
+}
+
+// UpdateApplication reconfigures an application in place. req describes the complete new
+// configuration, like a create request. The new plan is compared with the deployed services
+// and components: unchanged ones keep running with their data, changed services are
+// redeployed, and components whose configuration changed are deployed anew while the ones
+// no longer used are deleted. The application stays on the worker it runs on.
+func (s *ApplicationServiceBase) UpdateApplication(
+	ctx context.Context,
+	id uuid.UUID,
+	req apimodels.CreateApplicationRequest,
+	runtimeType runtimeTypes.RuntimeType,
+) (*apimodels.UpdateApplicationResponse, error) {
+	app, err := s.getReconfigurableApplication(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Validator.ValidateDeploymentRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	plan, err := s.DeploymentPlanner.PlanDeployment(ctx, req, runtimeType.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create deployment plan: %w", err)
+	}
+	plan.ApplicationID = app.ID
+	plan.WorkerID = app.WorkerID
+
+	deployedComponents, err := s.loadDeployedComponents(ctx, app.Services)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := diffDeployment(plan, app.Services, deployedComponents)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.recordReconfiguration(ctx, app, plan, r); err != nil {
+		return nil, fmt.Errorf("failed to record reconfiguration: %w", err)
+	}
+	r.summarizeAdditions()
+
+	resp := &apimodels.UpdateApplicationResponse{
+		ID:      app.ID.String(),
+		Name:    plan.ApplicationName,
+		Status:  string(app.Status),
+		Changes: r.changes,
+	}
+
+	// A failed deployment is retried even when the configuration did not change.
+	if r.hasChanges() || app.Status == models.ApplicationStatusError {
+		resp.Status = string(models.ApplicationStatusDeploying)
+		if err := catalogutils.UpdateApplicationStatus(ctx, s.AppRepo, app.ID, models.ApplicationStatusDeploying, "Reconfiguring application"); err != nil {
+			return nil, err
+		}
+
+		payload := deploymentJob{Plan: plan, Request: req, Reconfiguration: newReconfigurationJob(r)}
+		job, err := s.Jobs.Submit(ctx, models.JobKindReconfigure, app.ID, req.CreatedBy, payload)
+		if err != nil {
+			err = fmt.Errorf("failed to start reconfiguration: %w", err)
+
+			return nil, s.failJob(ctx, &models.Job{ApplicationID: app.ID}, err)
+		}
+		resp.JobID = job.ID.String()
+	}
+
+	return resp, nil
+}
+
+// getReconfigurableApplication returns the application to reconfigure after checking that
+// the caller owns it, that it is not being deployed or deleted, and that the request keeps
+// its catalog item and uses a free name.
+func (s *ApplicationServiceBase) getReconfigurableApplication(ctx context.Context, id uuid.UUID, req apimodels.CreateApplicationRequest) (*models.Application, error) {
+	app, err := s.AppRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+	if app == nil {
+		return nil, &ValidationError{Code: http.StatusNotFound, Message: ErrMsgApplicationNotFound}
+	}
+	if app.CreatedBy != req.CreatedBy {
+		return nil, &ValidationError{Code: http.StatusForbidden, Message: ErrMsgUserNotOwner}
+	}
+
+	switch app.Status {
+	case models.ApplicationStatusDeleting:
+		return nil, &ValidationError{Code: http.StatusConflict, Message: ErrMsgApplicationAlreadyDeleting}
+	case models.ApplicationStatusDownloading, models.ApplicationStatusDeploying:
+		return nil, &ValidationError{Code: http.StatusConflict, Message: ErrMsgApplicationBusy}
+	}
+
+	if req.CatalogID != app.CatalogID {
+		return nil, &ValidationError{Code: http.StatusBadRequest, Message: fmt.Sprintf(ErrMsgCatalogIDImmutable, app.CatalogID)}
+	}
+
+	if req.Name != app.Name {
+		existingApp, err := s.AppRepo.GetByName(ctx, req.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check for existing application: %w", err)
+		}
+		if existingApp != nil {
+			return nil, &ValidationError{Code: http.StatusConflict, Message: fmt.Sprintf(ErrMsgApplicationNameExists, req.Name)}
+		}
+	}
+
+	return app, nil
+}
+
+// loadDeployedComponents returns the components the services of an application depend on.
+func (s *ApplicationServiceBase) loadDeployedComponents(ctx context.Context, services []models.Service) ([]models.Component, error) {
+	seen := make(map[uuid.UUID]bool)
+	var components []models.Component
+
+	for _, svc := range services {
+		deps, err := s.ServiceDependencyRepo.GetDependenciesByServiceID(ctx, svc.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dependencies of service %s: %w", svc.ID, err)
+		}
+
+		for _, dep := range deps {
+			if dep.DependencyType != models.DependencyTypeComponent || seen[dep.DependencyID] {
+				continue
+			}
+			seen[dep.DependencyID] = true
+
+			component, err := s.ComponentRepo.GetByID(ctx, dep.DependencyID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get component %s: %w", dep.DependencyID, err)
+			}
+			if component != nil {
+				components = append(components, *component)
+			}
+		}
+	}
+
+	return components, nil
+}
+
+// diffDeployment compares a plan with the deployed services and components. Planned
+// items that match a deployed one take over its DatabaseID.
+func diffDeployment(plan *deployment.DeploymentPlan, services []models.Service, components []models.Component) (*reconfiguration, error) {
+	r := &reconfiguration{}
+	if err := r.diffComponents(plan, components); err != nil {
+		return nil, err
+	}
+	r.diffServices(plan, services)
+
+	return r, nil
+}
+
+// diffComponents matches the planned components with the deployed ones by configuration
+// hash. A component deployed before hashes were recorded is taken over by a planned
+// component of the same type and provider that matches nothing else, since deleting it
+// would delete its data; if there is none, the reconfiguration is refused.
+func (r *reconfiguration) diffComponents(plan *deployment.DeploymentPlan, components []models.Component) error {
+	deployed := make(map[string]models.Component, len(components))
+	var legacy []models.Component
+	for _, c := range components {
+		if c.ConfigHash == "" {
+			legacy = append(legacy, c)
+		} else {
+			deployed[c.ConfigHash] = c
+		}
+	}
+
+	kept := make(map[uuid.UUID]bool)
+	var unmatched []*deployment.ComponentPlan
+	for _, hash := range slices.Sorted(maps.Keys(plan.Components)) {
+		comp := plan.Components[hash]
+		match, ok := deployed[comp.Hash]
+		if !ok {
+			unmatched = append(unmatched, comp)
+
+			continue
+		}
+		comp.DatabaseID = match.ID
+		kept[match.ID] = true
+		r.addChange(changeKindComponent, componentName(comp.ComponentType, comp.ProviderID), match.ID, apimodels.ChangeTypeUnchanged)
+	}
+
+	for _, c := range legacy {
+		i := slices.IndexFunc(unmatched, func(comp *deployment.ComponentPlan) bool {
+			return comp.ComponentType == c.Type && comp.ProviderID == c.Provider
+		})
+		if i < 0 {
+			return &ValidationError{Code: http.StatusConflict, Message: fmt.Sprintf(ErrMsgUnmatchedLegacyComponent, componentName(c.Type, c.Provider))}
+		}
+		comp := unmatched[i]
+		unmatched = slices.Delete(unmatched, i, i+1)
+		comp.DatabaseID = c.ID
+		kept[c.ID] = true
+		r.adoptedComponents = append(r.adoptedComponents, comp)
+		r.addChange(changeKindComponent, componentName(comp.ComponentType, comp.ProviderID), c.ID, apimodels.ChangeTypeUnchanged)
+	}
+	r.newComponents = append(r.newComponents, unmatched...)
+
+	for _, c := range components {
+		if !kept[c.ID] {
+			r.removedComponents = append(r.removedComponents, c)
+		}
+	}
+
+	return nil
+}
+
+// diffServices matches the planned services with the deployed ones by catalog ID and
+// compares their configuration hashes.
+func (r *reconfiguration) diffServices(plan *deployment.DeploymentPlan, services []models.Service) {
+	deployed := make(map[string]models.Service, len(services))
+	for _, svc := range services {
+		deployed[svc.CatalogID] = svc
+	}
+
+	for catalogID, svc := range plan.Services {
+		match, ok := deployed[catalogID]
+		if !ok {
+			r.newServices = append(r.newServices, svc)
+
+			continue
+		}
+		delete(deployed, catalogID)
+		svc.DatabaseID = match.ID
+
+		change := apimodels.ChangeTypeUnchanged
+		if match.ConfigHash != svc.Hash {
+			change = apimodels.ChangeTypeChanged
+			r.changedServices = append(r.changedServices, match)
+		}
+		r.addChange(changeKindService, catalogID, match.ID, change)
+	}
+
+	for _, svc := range deployed {
+		r.removedServices = append(r.removedServices, svc)
+		r.addChange(changeKindService, svc.CatalogID, svc.ID, apimodels.ChangeTypeRemoved)
+	}
+}
+
+// summarizeAdditions reports the new services and components once their records exist.
+// A new component that takes the place of a removed one of the same type is reported as
+// that type's component having changed.
+func (r *reconfiguration) summarizeAdditions() {
+	for _, svc := range r.newServices {
+		r.addChange(changeKindService, svc.CatalogID, svc.DatabaseID, apimodels.ChangeTypeAdded)
+	}
+
+	removedByType := make(map[string][]models.Component)
+	for _, c := range r.removedComponents {
+		removedByType[c.Type] = append(removedByType[c.Type], c)
+	}
+	for _, comp := range r.newComponents {
+		change := apimodels.ChangeTypeAdded
+		if replaced := removedByType[comp.ComponentType]; len(replaced) > 0 {
+			change = apimodels.ChangeTypeChanged
+			removedByType[comp.ComponentType] = replaced[1:]
+		}
+		r.addChange(changeKindComponent, componentName(comp.ComponentType, comp.ProviderID), comp.DatabaseID, change)
+	}
+	for _, removed := range removedByType {
+		for _, c := range removed {
+			r.addChange(changeKindComponent, componentName(c.Type, c.Provider), c.ID, apimodels.ChangeTypeRemoved)
+		}
+	}
+
+	slices.SortFunc(r.changes, func(a, b apimodels.ApplicationChange) int {
+		return cmp.Or(cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Name, b.Name), cmp.Compare(a.ID, b.ID))
+	})
+}
+
+// addChange appends the change to one service or component.
+func (r *reconfiguration) addChange(kind, name string, id uuid.UUID, change apimodels.ChangeType) {
+	r.changes = append(r.changes, apimodels.ApplicationChange{Kind: kind, Name: name, ID: id.String(), Change: change})
+}
+
+// componentName names a component in the change summary.
+func componentName(componentType, providerID string) string {
+	return componentType + "/" + providerID
+}
+
+// recordReconfiguration renames the application and updates the records of its services
+// and components to the new plan. Records of removed items are deleted with their
+// resources, once the deployment starts.
+func (s *ApplicationServiceBase) recordReconfiguration(ctx context.Context, app *models.Application, plan *deployment.DeploymentPlan, r *reconfiguration) error {
+	if plan.ApplicationName != app.Name {
+		if err := s.AppRepo.UpdateDeploymentName(ctx, app.ID, plan.ApplicationName); err != nil {
+			return fmt.Errorf("failed to update name: %w", err)
+		}
+	}
+
+	for _, comp := range r.newComponents {
+		if err := s.insertComponentRecord(ctx, comp); err != nil {
+			return err
+		}
+	}
+
+	for _, comp := range r.adoptedComponents {
+		if err := s.ComponentRepo.UpdateConfigHash(ctx, comp.DatabaseID, comp.Hash); err != nil {
+			return fmt.Errorf("failed to record configuration of component %s: %w", comp.DatabaseID, err)
+		}
+	}
+
+	componentIDMap := make(map[string]uuid.UUID, len(plan.Components))
+	for hash, comp := range plan.Components {
+		componentIDMap[hash] = comp.DatabaseID
+	}
+
+	for _, svc := range r.newServices {
+		if err := s.insertServiceRecord(ctx, app.ID, svc, componentIDMap); err != nil {
+			return err
+		}
+	}
+
+	for _, deployed := range r.changedServices {
+		svc := plan.Services[deployed.CatalogID]
+		if err := s.ServiceRepo.UpdateVersion(ctx, deployed.ID, svc.Version, svc.Hash); err != nil {
+			return fmt.Errorf("failed to update service %s: %w", deployed.CatalogID, err)
+		}
+		if err := s.ServiceDependencyRepo.RemoveAllDependenciesForService(ctx, deployed.ID); err != nil {
+			return fmt.Errorf("failed to remove dependencies of service %s: %w", deployed.CatalogID, err)
+		}
+		if err := s.insertServiceDependencies(ctx, deployed.ID, svc.ComponentRefs, componentIDMap); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pruneReconfiguration removes what the new plan no longer contains before it is deployed:
+// removed services and components are deleted with their data, and on Podman the pods of
+// changed services are deleted so that they are recreated with the new configuration.
+// A resumed job that already pruned only allocates the Spyre cards again.
+func (s *ApplicationServiceBase) pruneReconfiguration(ctx context.Context, plan *deployment.DeploymentPlan, r *reconfiguration, runtimeType runtimeTypes.RuntimeType) error {
+	if !jobs.Completed(ctx, jobs.StepPruned) {
+		if err := s.pruneRemoved(ctx, plan, r, runtimeType); err != nil {
+			return err
+		}
+		jobs.Checkpoint(ctx, jobs.StepPruned)
+	}
+
+	if runtimeType == runtimeTypes.RuntimeTypePodman {
+		return s.allocateSpyreCardsForNewComponents(ctx, plan, r)
+	}
+
+	return nil
+}
+
+// pruneRemoved deletes the removed services and components and the pods of changed services.
+func (s *ApplicationServiceBase) pruneRemoved(ctx context.Context, plan *deployment.DeploymentPlan, r *reconfiguration, runtimeType runtimeTypes.RuntimeType) error {
+	pruned, replaced := splitRemovedComponents(plan, r.removedComponents, runtimeType)
+
+	if err := s.DeletionExecutor.Prune(ctx, plan.ApplicationID, plan.WorkerID, r.removedServices, pruned, runtimeType); err != nil {
+		return fmt.Errorf("failed to remove services and components: %w", err)
+	}
+
+	for _, id := range replaced {
+		if err := s.ComponentRepo.Delete(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete component %s: %w", id, err)
+		}
+	}
+
+	if err := s.DeletionExecutor.RemoveServicePods(ctx, plan.WorkerID, r.changedServices, runtimeType); err != nil {
+		return fmt.Errorf("failed to remove pods of changed services: %w", err)
+	}
+
+	return nil
+}
+
+// splitRemovedComponents returns the IDs of the removed components whose resources must
+// be deleted, and of those whose resources the new plan takes over. On OpenShift each
+// component type has one Helm release per application, which the deployment upgrades to
+// the new component of that type.
+func splitRemovedComponents(plan *deployment.DeploymentPlan, removed []models.Component, runtimeType runtimeTypes.RuntimeType) ([]uuid.UUID, []uuid.UUID) {
+	plannedTypes := make(map[string]bool, len(plan.Components))
+	for _, comp := range plan.Components {
+		plannedTypes[comp.ComponentType] = true
+	}
+
+	var pruned, replaced []uuid.UUID
+	for _, c := range removed {
+		if runtimeType == runtimeTypes.RuntimeTypeOpenShift && plannedTypes[c.Type] {
+			replaced = append(replaced, c.ID)
+		} else {
+			pruned = append(pruned, c.ID)
+		}
+	}
+
+	return pruned, replaced
+}
+
+// allocateSpyreCardsForNewComponents allocates Spyre cards to the components that are
+// deployed anew. The pods of unchanged components keep the cards they hold, and the cards
+// of removed components are free again by now.
+func (s *ApplicationServiceBase) allocateSpyreCardsForNewComponents(ctx context.Context, plan *deployment.DeploymentPlan, r *reconfiguration) error {
+	required := 0
+	for _, comp := range r.newComponents {
+		required += comp.SpyreCards
+	}
+	if required == 0 {
+		delete(plan.Resources.Accelerators, consts.SpyreResourceName)
+
+		return nil
+	}
+	plan.Resources.Accelerators[consts.SpyreResourceName] = required
+
+	findFreeCards := helpers.FindFreeSpyreCards
+	if plan.WorkerID != nil {
+		workerID := *plan.WorkerID
+		findFreeCards = func(ctx context.Context) ([]string, error) {
+			rt, err := catalogutils.AppRuntime(ctx, &workerID, "")
+			if err != nil {
+				return nil, err
+			}
+			info, err := rt.GetSystemInfo()
+			if err != nil {
+				return nil, err
+			}
+			if acc := info.Accelerators[consts.SpyreResourceName]; acc != nil {
+				return acc.Devices, nil
+			}
+
+			return nil, nil
+		}
+	}
+
+	if err := s.DeploymentPlanner.AllocateSpyreCards(ctx, plan, findFreeCards); err != nil {
+		return fmt.Errorf("failed to allocate Spyre cards: %w", err)
+	}
+
+	return nil
+}
+
+// Made with Bob
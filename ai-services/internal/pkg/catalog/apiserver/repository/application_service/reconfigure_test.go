@@ -0,0 +1,139 @@
+package applicationservice
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apimodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/deployment"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+)
+
+func TestDiffDeployment(t *testing.T) {
+	vectorStore := models.Component{ID: uuid.New(), Type: "vector_store", Provider: "opensearch", ConfigHash: "vs"}
+	oldLLM := models.Component{ID: uuid.New(), Type: "llm", Provider: "vllm", ConfigHash: "llm-old"}
+	reranker := models.Component{ID: uuid.New(), Type: "reranker", Provider: "vllm", ConfigHash: "rr"}
+	chat := models.Service{ID: uuid.New(), CatalogID: "chat", ConfigHash: "chat-old"}
+	digitize := models.Service{ID: uuid.New(), CatalogID: "digitize", ConfigHash: "digitize"}
+	summarize := models.Service{ID: uuid.New(), CatalogID: "summarize", ConfigHash: "summarize"}
+
+	plan := &deployment.DeploymentPlan{
+		Components: map[string]*deployment.ComponentPlan{
+			"vs":      {Hash: "vs", ComponentType: "vector_store", ProviderID: "opensearch"},
+			"llm-new": {Hash: "llm-new", ComponentType: "llm", ProviderID: "vllm"},
+		},
+		Services: map[string]*deployment.ServicePlan{
+			"chat":     {Hash: "chat-new", CatalogID: "chat"},
+			"digitize": {Hash: "digitize", CatalogID: "digitize"},
+			"search":   {Hash: "search", CatalogID: "search"},
+		},
+	}
+
+	r, err := diffDeployment(plan, []models.Service{chat, digitize, summarize}, []models.Component{vectorStore, oldLLM, reranker})
+	require.NoError(t, err)
+
+	assert.Equal(t, vectorStore.ID, plan.Components["vs"].DatabaseID)
+	assert.Equal(t, chat.ID, plan.Services["chat"].DatabaseID)
+	assert.Equal(t, digitize.ID, plan.Services["digitize"].DatabaseID)
+	assert.Equal(t, []*deployment.ComponentPlan{plan.Components["llm-new"]}, r.newComponents)
+	assert.ElementsMatch(t, []models.Component{oldLLM, reranker}, r.removedComponents)
+	assert.Equal(t, []*deployment.ServicePlan{plan.Services["search"]}, r.newServices)
+	assert.Equal(t, []models.Service{chat}, r.changedServices)
+	assert.Equal(t, []models.Service{summarize}, r.removedServices)
+	assert.True(t, r.hasChanges())
+
+	// The new LLM and search service get their IDs when their records are inserted.
+	plan.Components["llm-new"].DatabaseID = uuid.New()
+	plan.Services["search"].DatabaseID = uuid.New()
+	r.summarizeAdditions()
+
+	changes := make(map[string]apimodels.ChangeType, len(r.changes))
+	for _, c := range r.changes {
+		changes[c.Kind+":"+c.Name] = c.Change
+	}
+	assert.Equal(t, map[string]apimodels.ChangeType{
+		"component:vector_store/opensearch": apimodels.ChangeTypeUnchanged,
+		"component:llm/vllm":                apimodels.ChangeTypeChanged,
+		"component:reranker/vllm":           apimodels.ChangeTypeRemoved,
+		"service:chat":                      apimodels.ChangeTypeChanged,
+		"service:digitize":                  apimodels.ChangeTypeUnchanged,
+		"service:search":                    apimodels.ChangeTypeAdded,
+		"service:summarize":                 apimodels.ChangeTypeRemoved,
+	}, changes)
+	require.Len(t, r.changes, len(changes))
+	assert.Equal(t, "llm/vllm", r.changes[0].Name)
+	assert.Equal(t, plan.Components["llm-new"].DatabaseID.String(), r.changes[0].ID)
+}
+
+func TestDiffDeployment_Unchanged(t *testing.T) {
+	vectorStore := models.Component{ID: uuid.New(), Type: "vector_store", Provider: "opensearch", ConfigHash: "vs"}
+	chat := models.Service{ID: uuid.New(), CatalogID: "chat", ConfigHash: "chat"}
+	plan := &deployment.DeploymentPlan{
+		Components: map[string]*deployment.ComponentPlan{"vs": {Hash: "vs", ComponentType: "vector_store", ProviderID: "opensearch"}},
+		Services:   map[string]*deployment.ServicePlan{"chat": {Hash: "chat", CatalogID: "chat"}},
+	}
+
+	r, err := diffDeployment(plan, []models.Service{chat}, []models.Component{vectorStore})
+	require.NoError(t, err)
+
+	assert.False(t, r.hasChanges())
+}
+
+func TestDiffDeployment_ComponentsWithoutHashAreAdopted(t *testing.T) {
+	legacyStore := models.Component{ID: uuid.New(), Type: "vector_store", Provider: "opensearch"}
+	legacyLLM := models.Component{ID: uuid.New(), Type: "llm", Provider: "vllm"}
+	plan := &deployment.DeploymentPlan{
+		Components: map[string]*deployment.ComponentPlan{
+			"vs":  {Hash: "vs", ComponentType: "vector_store", ProviderID: "opensearch"},
+			"llm": {Hash: "llm", ComponentType: "llm", ProviderID: "vllm"},
+			"emb": {Hash: "emb", ComponentType: "embedding", ProviderID: "vllm"},
+		},
+	}
+
+	r, err := diffDeployment(plan, nil, []models.Component{legacyStore, legacyLLM})
+	require.NoError(t, err)
+
+	assert.Equal(t, legacyStore.ID, plan.Components["vs"].DatabaseID)
+	assert.Equal(t, legacyLLM.ID, plan.Components["llm"].DatabaseID)
+	assert.ElementsMatch(t, []*deployment.ComponentPlan{plan.Components["vs"], plan.Components["llm"]}, r.adoptedComponents)
+	assert.Equal(t, []*deployment.ComponentPlan{plan.Components["emb"]}, r.newComponents)
+	assert.Empty(t, r.removedComponents, "components without a hash must not be deleted")
+}
+
+func TestDiffDeployment_UnmatchedComponentWithoutHashIsRefused(t *testing.T) {
+	legacy := models.Component{ID: uuid.New(), Type: "vector_store", Provider: "opensearch"}
+	plan := &deployment.DeploymentPlan{
+		Components: map[string]*deployment.ComponentPlan{"vs": {Hash: "vs", ComponentType: "vector_store", ProviderID: "milvus"}},
+	}
+
+	_, err := diffDeployment(plan, nil, []models.Component{legacy})
+
+	var valErr *ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, http.StatusConflict, valErr.Code)
+	assert.Contains(t, valErr.Message, "vector_store/opensearch")
+}
+
+func TestSplitRemovedComponents(t *testing.T) {
+	llm := models.Component{ID: uuid.New(), Type: "llm"}
+	reranker := models.Component{ID: uuid.New(), Type: "reranker"}
+	plan := &deployment.DeploymentPlan{
+		Components: map[string]*deployment.ComponentPlan{"llm-new": {ComponentType: "llm"}},
+	}
+	removed := []models.Component{llm, reranker}
+
+	pruned, replaced := splitRemovedComponents(plan, removed, runtimeTypes.RuntimeTypeOpenShift)
+	assert.Equal(t, []uuid.UUID{reranker.ID}, pruned)
+	assert.Equal(t, []uuid.UUID{llm.ID}, replaced)
+
+	pruned, replaced = splitRemovedComponents(plan, removed, runtimeTypes.RuntimeTypePodman)
+	assert.Equal(t, []uuid.UUID{llm.ID, reranker.ID}, pruned)
+	assert.Empty(t, replaced)
+}
+
+// Made with Bob
@@ -0,0 +1,183 @@
+package applicationservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	dbrepo "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	catalogutils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/common"
+)
+
+// ListWorkerApplications returns the applications placed on a worker.
+func (s *ApplicationServiceBase) ListWorkerApplications(ctx context.Context, workerID uuid.UUID) ([]types.Application, error) {
+	applications, err := s.AppRepo.GetAll(ctx, &dbrepo.ApplicationFilters{WorkerID: &workerID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve applications: %w", err)
+	}
+
+	apps := make([]types.Application, 0, len(applications))
+	for _, app := range applications {
+		appData, err := s.buildApplication(app)
+		if err != nil {
+			return nil, err
+		}
+
+		apps = append(apps, appData)
+	}
+
+	return apps, nil
+}
+
+// DrainWorker stops every application placed on a worker so the host can be taken down
+// for maintenance. In-flight deployments are cancelled first; applications that are
+// being deleted are left to the deletion. Applications are stopped rather than moved:
+// their volumes and Spyre card allocations belong to the worker, so they come back
+// when their pods are started again there, or the operator redeploys them elsewhere.
+// A failure to stop one application is reported in its result and does not stop the drain.
+func (s *ApplicationServiceBase) DrainWorker(ctx context.Context, workerID uuid.UUID, workerName string) ([]types.DrainedApplication, error) {
+	applications, err := s.AppRepo.GetAll(ctx, &dbrepo.ApplicationFilters{WorkerID: &workerID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve applications: %w", err)
+	}
+
+	// Components can be shared between applications; stop each pod only once.
+	stopped := make(map[string]bool)
+	drained := make([]types.DrainedApplication, 0, len(applications))
+
+	for i := range applications {
+		app := &applications[i]
+		if app.Status == models.ApplicationStatusDeleting {
+			continue
+		}
+
+		drained = append(drained, s.drainApplication(ctx, app, workerName, stopped))
+	}
+
+	return drained, nil
+}
+
+// drainApplication cancels the deployment of app, if any, stops its service and
+// component pods, and marks the application, its services and its components Stopped.
+func (s *ApplicationServiceBase) drainApplication(ctx context.Context, app *models.Application, workerName string, stopped map[string]bool) types.DrainedApplication {
+	result := types.DrainedApplication{ID: app.ID.String(), Name: app.Name, StoppedPods: []string{}}
+
+	if err := s.Jobs.CancelApplication(ctx, app.ID); err != nil {
+		result.Error = fmt.Sprintf("failed to cancel deployment: %v", err)
+
+		return result
+	}
+
+	rt, err := catalogutils.AppRuntime(ctx, app.WorkerID, "")
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to init runtime client: %v", err)
+
+		return result
+	}
+
+	templateIDs, err := s.applicationTemplateIDs(ctx, app.Services)
+	if err != nil {
+		result.Error = err.Error()
+
+		return result
+	}
+
+	for _, templateID := range templateIDs {
+		names, err := stopTemplatePods(rt, templateID, stopped)
+		result.StoppedPods = append(result.StoppedPods, names...)
+		if err != nil {
+			result.Error = err.Error()
+
+			return result
+		}
+	}
+
+	s.markDrained(ctx, app)
+
+	message := fmt.Sprintf("Worker %s was drained", workerName)
+	if err := catalogutils.UpdateApplicationStatus(ctx, s.AppRepo, app.ID, models.ApplicationStatusStopped, message); err != nil {
+		logger.ErrorfCtx(ctx, "Failed to update status of drained application %s: %v", app.Name, err)
+	}
+	logger.InfofCtx(ctx, "Drained application %s from worker %s: %d pods stopped", app.Name, workerName, len(result.StoppedPods))
+
+	return result
+}
+
+// markDrained marks the services and components of a drained application Stopped, so
+// that starting part of it again leaves the rest stopped on purpose.
+func (s *ApplicationServiceBase) markDrained(ctx context.Context, app *models.Application) {
+	for _, svc := range app.Services {
+		if err := catalogutils.UpdateServiceStatus(ctx, s.ServiceRepo, svc.ID, models.ServiceStatusStopped, ""); err != nil {
+			logger.ErrorfCtx(ctx, "Failed to update status of drained service %s: %v", svc.CatalogID, err)
+		}
+	}
+
+	components, err := s.loadDeployedComponents(ctx, app.Services)
+	if err != nil {
+		logger.ErrorfCtx(ctx, "Failed to update status of drained components of %s: %v", app.Name, err)
+
+		return
+	}
+	for _, comp := range components {
+		if err := catalogutils.UpdateComponentStatus(ctx, s.ComponentRepo, comp.ID, models.ComponentStatusStopped, ""); err != nil {
+			logger.ErrorfCtx(ctx, "Failed to update status of drained component %s/%s: %v", comp.Type, comp.Provider, err)
+		}
+	}
+}
+
+// applicationTemplateIDs returns the IDs the pods of an application are labelled with:
+// one per service and one per component the services depend on.
+func (s *ApplicationServiceBase) applicationTemplateIDs(ctx context.Context, services []models.Service) ([]string, error) {
+	ids := make([]string, 0, len(services))
+	seen := make(map[string]bool)
+
+	for _, service := range services {
+		ids = append(ids, service.ID.String())
+
+		dependencies, err := s.ServiceDependencyRepo.GetDependenciesByServiceID(ctx, service.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get dependencies for service %s: %w", service.ID, err)
+		}
+
+		for _, dependency := range dependencies {
+			id := dependency.DependencyID.String()
+			if dependency.DependencyType != models.DependencyTypeComponent || seen[id] {
+				continue
+			}
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
+
+// stopTemplatePods stops the pods labelled with templateID that are not in stopped yet
+// and returns their names.
+func stopTemplatePods(rt runtime.Runtime, templateID string, stopped map[string]bool) ([]string, error) {
+	pods, err := common.FetchFilteredPods(rt, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, pod := range pods {
+		if stopped[pod.ID] {
+			continue
+		}
+		if err := rt.StopPod(pod.ID); err != nil {
+			return names, fmt.Errorf("failed to stop pod %s: %w", pod.Name, err)
+		}
+		stopped[pod.ID] = true
+		names = append(names, pod.Name)
+	}
+
+	return names, nil
+}
+
+// Made with Bob
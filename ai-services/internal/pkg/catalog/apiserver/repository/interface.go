@@ -5,6 +5,8 @@ import (
 
 	"github.com/google/uuid"
 	apimodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/httpproxy"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
 )
 
@@ -13,15 +15,25 @@ type ApplicationServiceInterface interface {
 	// ListApplications retrieves a paginated list of applications with filters.
 	ListApplications(ctx context.Context, req ListApplicationsRequest) (*types.ApplicationListResponse, error)
 
-	// UpdateApplication updates the display name of an existing application.
-	UpdateApplication(ctx context.Context, id uuid.UUID, userID, newName string) (*types.Application, error)
+	// RenameApplication updates the display name of an existing application.
+	RenameApplication(ctx context.Context, id uuid.UUID, userID, newName string) (*types.Application, error)
+
+	// UpdateApplication reconfigures an existing application in place and redeploys what changed.
+	UpdateApplication(ctx context.Context, id uuid.UUID, req apimodels.CreateApplicationRequest) (*apimodels.UpdateApplicationResponse, error)
 
 	// CreateApplication creates a new application and initiates async deployment.
 	CreateApplication(ctx context.Context, req apimodels.CreateApplicationRequest) (*apimodels.CreateApplicationResponse, error)
 
+	// PlanApplication previews the deployment CreateApplication would start, without deploying.
+	PlanApplication(ctx context.Context, req apimodels.CreateApplicationRequest) (*apimodels.PlanApplicationResponse, error)
+
 	// GetApplicationByID retrieves a single application by ID including its services and components.
 	GetApplicationByID(ctx context.Context, id uuid.UUID) (*types.Application, error)
 
+	// SubscribeApplicationEvents returns the recorded deployment events of an application after
+	// afterSeq and a channel of the ones that follow; cancel stops the subscription.
+	SubscribeApplicationEvents(ctx context.Context, id uuid.UUID, afterSeq int64) (backlog []apimodels.ApplicationEvent, live <-chan apimodels.ApplicationEvent, cancel func(), err error)
+
 	// GetApplicationResources retrieves CPU, memory, and accelerator usage for an application.
 	GetApplicationResources(ctx context.Context, id uuid.UUID) (*types.ApplicationResourcesResponse, error)
 
@@ -30,6 +42,33 @@ type ApplicationServiceInterface interface {
 
 	// ApplicationsPs retrieves runtime pod/container status for an application.
 	ApplicationsPs(ctx context.Context, appID uuid.UUID) (*types.ApplicationPSResponse, error)
+
+	// PodLogs returns the log stream of a pod of an application, or of one of its containers.
+	PodLogs(ctx context.Context, appID uuid.UUID, pod, container string) (PodLogStream, error)
+
+	// StartApplication starts the stopped pods of an application, or of the selected services and components.
+	StartApplication(ctx context.Context, id uuid.UUID, user string, req apimodels.ApplicationLifecycleRequest) (*apimodels.ApplicationLifecycleResponse, error)
+
+	// StopApplication stops the pods of an application, or of the selected services and components.
+	StopApplication(ctx context.Context, id uuid.UUID, user string, req apimodels.ApplicationLifecycleRequest) (*apimodels.ApplicationLifecycleResponse, error)
+
+	// RestartApplication stops and starts again the pods of an application, or of the selected services and components.
+	RestartApplication(ctx context.Context, id uuid.UUID, user string, req apimodels.ApplicationLifecycleRequest) (*apimodels.ApplicationLifecycleResponse, error)
+
+	// ListWorkerApplications retrieves the applications placed on a worker.
+	ListWorkerApplications(ctx context.Context, workerID uuid.UUID) ([]types.Application, error)
+
+	// DrainWorker stops the applications placed on a worker and reports the outcome per application.
+	DrainWorker(ctx context.Context, workerID uuid.UUID, workerName string) ([]types.DrainedApplication, error)
+
+	// GetJob retrieves a deployment or deletion job started by the user.
+	GetJob(ctx context.Context, id uuid.UUID, user string) (*models.Job, error)
+
+	// CancelJob cancels a running deployment job started by the user.
+	CancelJob(ctx context.Context, id uuid.UUID, user string) (*models.Job, error)
+
+	// ResolveServiceProxy finds the pod port that requests to a service of an application are proxied to.
+	ResolveServiceProxy(ctx context.Context, appID uuid.UUID, serviceRef string) (*httpproxy.Target, error)
 }
 
 // Made with Bob
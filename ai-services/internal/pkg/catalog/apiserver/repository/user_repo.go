@@ -5,16 +5,38 @@ package repository
 import (
 	"context"
 	"errors"
+	"sort"
 	"sync"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
 )
 
-var ErrUserNotFound = errors.New("user not found")
+var (
+	ErrUserNotFound = errors.New("user not found")
+	ErrUserExists   = errors.New("username already exists")
+)
 
 type UserRepository interface {
 	GetByUserName(ctx context.Context, username string) (*models.User, error)
 	GetByID(ctx context.Context, id string) (*models.User, error)
+	// List returns all users ordered by username.
+	List(ctx context.Context) ([]*models.User, error)
+	// Create adds a user, generating its ID when empty. It returns ErrUserExists if the
+	// username is taken.
+	Create(ctx context.Context, u *models.User) error
+	// Upsert adds the user or updates the username, name and role of the user with the
+	// same ID, keeping its password hash and disabled flag. It is used to record users
+	// signed in through ManageIQ.
+	Upsert(ctx context.Context, u *models.User) error
+	// UpdatePassword replaces the password hash of a user.
+	UpdatePassword(ctx context.Context, id, passwordHash string) error
+	// SetDisabled disables or re-enables a user.
+	SetDisabled(ctx context.Context, id string, disabled bool) error
+	// Delete removes a user.
+	Delete(ctx context.Context, id string) error
 }
 
 type InMemoryUserRepo struct {
@@ -31,28 +53,63 @@ func NewInMemoryUserRepo() *InMemoryUserRepo {
 	}
 }
 
-// NewInMemoryUserRepoWithAdminHash creates a repo and seeds a single admin user
-// with a precomputed hash.
+// NewInMemoryUserRepoWithAdminHash creates a repo and seeds a single user with the
+// admin role and a precomputed hash.
 func NewInMemoryUserRepoWithAdminHash(id, username, name, passwordHash string) *InMemoryUserRepo {
 	r := NewInMemoryUserRepo()
-	r.Upsert(&models.User{
+	_ = r.Upsert(context.Background(), &models.User{
 		ID:           id,
 		UserName:     username,
 		PasswordHash: passwordHash,
 		Name:         name,
+		Role:         models.RoleAdmin,
 	})
 
 	return r
 }
 
-// Upsert inserts or replaces a user entry. Safe for concurrent use.
+// Create adds a user. Safe for concurrent use.
+func (r *InMemoryUserRepo) Create(_ context.Context, u *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.byUserName[u.UserName]; ok {
+		return ErrUserExists
+	}
+	if u.ID == "" {
+		u.ID = uuid.NewString()
+	}
+	u.CreatedAt = time.Now()
+	u.UpdatedAt = u.CreatedAt
+	r.put(u)
+
+	return nil
+}
+
+// Upsert inserts or updates a user entry. Safe for concurrent use.
 // Used both for seeding the admin user and for populating entries after a
 // ManageIQ token exchange (Flow B / IBM Power Mission Control passthrough).
-func (r *InMemoryUserRepo) Upsert(u *models.User) {
+func (r *InMemoryUserRepo) Upsert(_ context.Context, u *models.User) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.users[u.ID] = u
-	r.byUserName[u.UserName] = u
+	if other, ok := r.byUserName[u.UserName]; ok && other.ID != u.ID {
+		return ErrUserExists
+	}
+	now := time.Now()
+	u.CreatedAt, u.UpdatedAt = now, now
+	if old, ok := r.users[u.ID]; ok {
+		u.PasswordHash, u.Disabled, u.CreatedAt = old.PasswordHash, old.Disabled, old.CreatedAt
+		delete(r.byUserName, old.UserName)
+	}
+	r.put(u)
+
+	return nil
+}
+
+// put stores a copy of u. The caller must hold the write lock.
+func (r *InMemoryUserRepo) put(u *models.User) {
+	cp := *u
+	r.users[cp.ID] = &cp
+	r.byUserName[cp.UserName] = &cp
 }
 
 // GetByUserName retrieves a user by their username. It returns ErrUserNotFound if no user with the given username exists.
@@ -63,8 +120,9 @@ func (r *InMemoryUserRepo) GetByUserName(ctx context.Context, username string) (
 	if !ok {
 		return nil, ErrUserNotFound
 	}
+	cp := *u
 
-	return u, nil
+	return &cp, nil
 }
 
 // GetByID retrieves a user by their ID. It returns ErrUserNotFound if no user with the given ID exists.
@@ -75,6 +133,58 @@ func (r *InMemoryUserRepo) GetByID(ctx context.Context, id string) (*models.User
 	if !ok {
 		return nil, ErrUserNotFound
 	}
+	cp := *u
+
+	return &cp, nil
+}
+
+// List returns all users ordered by username.
+func (r *InMemoryUserRepo) List(_ context.Context) ([]*models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	users := make([]*models.User, 0, len(r.users))
+	for _, u := range r.users {
+		cp := *u
+		users = append(users, &cp)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].UserName < users[j].UserName })
+
+	return users, nil
+}
+
+// UpdatePassword replaces the password hash of a user.
+func (r *InMemoryUserRepo) UpdatePassword(_ context.Context, id, passwordHash string) error {
+	return r.update(id, func(u *models.User) { u.PasswordHash = passwordHash })
+}
+
+// SetDisabled disables or re-enables a user.
+func (r *InMemoryUserRepo) SetDisabled(_ context.Context, id string, disabled bool) error {
+	return r.update(id, func(u *models.User) { u.Disabled = disabled })
+}
+
+func (r *InMemoryUserRepo) update(id string, fn func(*models.User)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u, ok := r.users[id]
+	if !ok {
+		return ErrUserNotFound
+	}
+	fn(u)
+	u.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// Delete removes a user.
+func (r *InMemoryUserRepo) Delete(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u, ok := r.users[id]
+	if !ok {
+		return ErrUserNotFound
+	}
+	delete(r.users, id)
+	delete(r.byUserName, u.UserName)
 
-	return u, nil
+	return nil
 }
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+	dbmodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+)
+
+// DBUserRepo is a database-backed implementation of UserRepository, shared by all
+// API server replicas.
+type DBUserRepo struct {
+	repo repository.UserRepository
+}
+
+// NewDBUserRepo creates a UserRepository backed by the users table.
+func NewDBUserRepo(repo repository.UserRepository) *DBUserRepo {
+	return &DBUserRepo{repo: repo}
+}
+
+// GetByUserName retrieves a user by their username. It returns ErrUserNotFound if no user with the given username exists.
+func (r *DBUserRepo) GetByUserName(ctx context.Context, username string) (*models.User, error) {
+	return found(r.repo.GetByUsername(ctx, username))
+}
+
+// GetByID retrieves a user by their ID. It returns ErrUserNotFound if no user with the given ID exists.
+func (r *DBUserRepo) GetByID(ctx context.Context, id string) (*models.User, error) {
+	return found(r.repo.GetByID(ctx, id))
+}
+
+// List returns all users ordered by username.
+func (r *DBUserRepo) List(ctx context.Context) ([]*models.User, error) {
+	rows, err := r.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	users := make([]*models.User, 0, len(rows))
+	for i := range rows {
+		users = append(users, fromDBUser(&rows[i]))
+	}
+
+	return users, nil
+}
+
+// Create adds a user, generating its ID when empty.
+func (r *DBUserRepo) Create(ctx context.Context, u *models.User) error {
+	row := toDBUser(u)
+	if err := r.repo.Create(ctx, row); err != nil {
+		return mapUserError(err)
+	}
+	u.ID, u.CreatedAt, u.UpdatedAt = row.ID, row.CreatedAt, row.UpdatedAt
+
+	return nil
+}
+
+// Upsert adds the user or updates the username, name and role of the user with the same ID.
+func (r *DBUserRepo) Upsert(ctx context.Context, u *models.User) error {
+	row := toDBUser(u)
+	if err := r.repo.Upsert(ctx, row); err != nil {
+		return mapUserError(err)
+	}
+	*u = *fromDBUser(row)
+
+	return nil
+}
+
+// UpdatePassword replaces the password hash of a user.
+func (r *DBUserRepo) UpdatePassword(ctx context.Context, id, passwordHash string) error {
+	return affected(r.repo.UpdatePassword(ctx, id, passwordHash))
+}
+
+// SetDisabled disables or re-enables a user.
+func (r *DBUserRepo) SetDisabled(ctx context.Context, id string, disabled bool) error {
+	return affected(r.repo.SetDisabled(ctx, id, disabled))
+}
+
+// Delete removes a user.
+func (r *DBUserRepo) Delete(ctx context.Context, id string) error {
+	return affected(r.repo.Delete(ctx, id))
+}
+
+// found maps a (nil, nil) lookup result to ErrUserNotFound.
+func found(u *dbmodels.User, err error) (*models.User, error) {
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, ErrUserNotFound
+	}
+
+	return fromDBUser(u), nil
+}
+
+// affected maps a write that matched no row to ErrUserNotFound.
+func affected(ok bool, err error) error {
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+func mapUserError(err error) error {
+	if errors.Is(err, repository.ErrUsernameTaken) {
+		return ErrUserExists
+	}
+
+	return err
+}
+
+func toDBUser(u *models.User) *dbmodels.User {
+	return &dbmodels.User{
+		ID:           u.ID,
+		Username:     u.UserName,
+		Name:         u.Name,
+		PasswordHash: u.PasswordHash,
+		Role:         string(u.Role),
+		Disabled:     u.Disabled,
+		CreatedAt:    u.CreatedAt,
+		UpdatedAt:    u.UpdatedAt,
+	}
+}
+
+func fromDBUser(u *dbmodels.User) *models.User {
+	return &models.User{
+		ID:           u.ID,
+		UserName:     u.Username,
+		Name:         u.Name,
+		PasswordHash: u.PasswordHash,
+		Role:         models.Role(u.Role),
+		Disabled:     u.Disabled,
+		CreatedAt:    u.CreatedAt,
+		UpdatedAt:    u.UpdatedAt,
+	}
+}
+
+// Made with Bob
@@ -8,16 +8,30 @@ import (
 	_ "github.com/project-ai-services/ai-services/docs" // Import generated docs
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/handlers"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/middleware"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository"
+	apikeysvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/apikey"
+	auditsvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/audit"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/auth"
 	bundlesvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/bundle"
+	connectorsvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/connector"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/httpproxy"
+	usersvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/user"
+	webhooksvc "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/webhook"
+	"github.com/project-ai-services/ai-services/internal/pkg/worker/pki"
 	"github.com/project-ai-services/ai-services/internal/pkg/worker/registry"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
 // CreateRouter sets up the Gin router with the necessary routes and authentication middleware for the API server.
-func CreateRouter(authSvc auth.Service, tokenMgr *auth.TokenManager, blacklist repository.TokenBlacklist, appService repository.ApplicationServiceInterface, workerReg *registry.Registry, bundleService bundlesvc.BundleServiceInterface) *gin.Engine {
+// Routes are authorized by role: every authenticated user may call the read-only
+// endpoints, operators manage applications and admins manage bundles, connectors, workers,
+// users and webhooks.
+// Requests authenticated with an API key are further restricted to the key's scopes.
+// Calls to the routes in auditedRoutes are recorded in the audit log.
+// The OIDC login routes are only registered when oidcService is not nil.
+func CreateRouter(authSvc auth.Service, oidcService auth.OIDCService, tokenMgr *auth.TokenManager, blacklist repository.TokenBlacklist, appService repository.ApplicationServiceInterface, workerReg *registry.Registry, workerCA *pki.CA, bundleService bundlesvc.BundleServiceInterface, userService usersvc.UserServiceInterface, apiKeyService apikeysvc.APIKeyServiceInterface, auditService auditsvc.AuditServiceInterface, connectorService connectorsvc.ConnectorServiceInterface, webhookService webhooksvc.WebhookServiceInterface) *gin.Engine {
 	if mode := os.Getenv("GIN_MODE"); mode != "" {
 		gin.SetMode(mode)
 	}
@@ -30,31 +44,99 @@ func CreateRouter(authSvc auth.Service, tokenMgr *auth.TokenManager, blacklist r
 	// Expose /health for liveness probes
 	router.GET("/health", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"message": "ok"}) })
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	// Public keys for verifying catalog tokens
+	router.GET("/.well-known/jwks.json", handlers.NewJWKSHandler(tokenMgr).JWKS)
 
 	v1 := router.Group("/api/v1")
-	registerAuthRoutes(v1, handlers.NewAuthHandler(authSvc), tokenMgr, blacklist)
-
-	auth := middleware.AuthMiddleware(tokenMgr, blacklist)
+	v1.Use(middleware.AuditMiddleware(auditService, auditedRoutes))
+	auth := middleware.AuthMiddleware(tokenMgr, blacklist, apiKeyService)
+	registerAuthRoutes(v1, handlers.NewAuthHandler(authSvc), auth)
+	if oidcService != nil {
+		registerOIDCRoutes(v1, handlers.NewOIDCHandler(oidcService))
+	}
 	registerCatalogRoutes(v1, handlers.NewCatalogHandler(), handlers.NewResourcesHandler(), auth)
-	registerApplicationRoutes(v1, handlers.NewApplicationHandler(appService), auth)
-	registerWorkerRoutes(v1, handlers.NewWorkerHandler(workerReg), auth)
+	proxy := handlers.NewProxyHandler(appService, httpproxy.NewForwarder(workerReg))
+	registerApplicationRoutes(v1, handlers.NewApplicationHandler(appService), proxy, auth)
+	registerJobRoutes(v1, handlers.NewJobHandler(appService), auth)
+	registerWorkerRoutes(v1, handlers.NewWorkerHandler(workerReg, workerCA, appService), auth)
 	registerBundleRoutes(v1, handlers.NewBundleHandler(bundleService), auth)
+	registerConnectorRoutes(v1, handlers.NewConnectorHandler(connectorService), auth)
+	registerUserRoutes(v1, handlers.NewUserHandler(userService), auth)
+	registerAPIKeyRoutes(v1, handlers.NewAPIKeyHandler(apiKeyService), auth)
+	registerAuditRoutes(v1, handlers.NewAuditHandler(auditService), auth)
+	registerWebhookRoutes(v1, handlers.NewWebhookHandler(webhookService), auth)
 
 	return router
 }
 
-func registerAuthRoutes(v1 *gin.RouterGroup, h *handlers.AuthHandler, tokenMgr *auth.TokenManager, blacklist repository.TokenBlacklist) {
-	authMw := middleware.AuthMiddleware(tokenMgr, blacklist)
+// auditedRoutes lists the mutating routes recorded in the audit log, keyed by method
+// and route pattern. Session calls (login, OIDC login, refresh, logout), bundle validation and
+// requests proxied to application services are not recorded.
+var auditedRoutes = map[string]middleware.AuditAction{
+	"POST /api/v1/auth/password": {Action: "user.change_password", ResourceType: "user"},
+
+	"POST /api/v1/catalog/bundles":       {Action: "bundle.create", ResourceType: "bundle"},
+	"PUT /api/v1/catalog/bundles/:id":    {Action: "bundle.update", ResourceType: "bundle"},
+	"DELETE /api/v1/catalog/bundles/:id": {Action: "bundle.delete", ResourceType: "bundle"},
+
+	"POST /api/v1/connectors/instances":          {Action: "connector.create", ResourceType: "connector"},
+	"PUT /api/v1/connectors/instances/:id":       {Action: "connector.update", ResourceType: "connector"},
+	"DELETE /api/v1/connectors/instances/:id":    {Action: "connector.delete", ResourceType: "connector"},
+	"POST /api/v1/connectors/instances/:id/test": {Action: "connector.test", ResourceType: "connector"},
+
+	"POST /api/v1/applications/":      {Action: "application.create", ResourceType: "application"},
+	"PUT /api/v1/applications/:id":    {Action: "application.update", ResourceType: "application"},
+	"DELETE /api/v1/applications/:id": {Action: "application.delete", ResourceType: "application"},
+
+	"POST /api/v1/applications/:id/start":   {Action: "application.start", ResourceType: "application"},
+	"POST /api/v1/applications/:id/stop":    {Action: "application.stop", ResourceType: "application"},
+	"POST /api/v1/applications/:id/restart": {Action: "application.restart", ResourceType: "application"},
+
+	"POST /api/v1/jobs/:id/cancel": {Action: "job.cancel", ResourceType: "job"},
+
+	"POST /api/v1/workers":                        {Action: "worker.create", ResourceType: "worker"},
+	"DELETE /api/v1/workers/:id":                  {Action: "worker.delete", ResourceType: "worker"},
+	"POST /api/v1/workers/:id/cordon":             {Action: "worker.cordon", ResourceType: "worker"},
+	"POST /api/v1/workers/:id/uncordon":           {Action: "worker.uncordon", ResourceType: "worker"},
+	"POST /api/v1/workers/:id/drain":              {Action: "worker.drain", ResourceType: "worker"},
+	"POST /api/v1/workers/:id/tokens":             {Action: "worker.reissue_token", ResourceType: "worker"},
+	"DELETE /api/v1/workers/:id/tokens/:token_id": {Action: "worker.revoke_token", ResourceType: "worker"},
+
+	"POST /api/v1/users":              {Action: "user.create", ResourceType: "user"},
+	"DELETE /api/v1/users/:id":        {Action: "user.delete", ResourceType: "user"},
+	"POST /api/v1/users/:id/disable":  {Action: "user.disable", ResourceType: "user"},
+	"POST /api/v1/users/:id/enable":   {Action: "user.enable", ResourceType: "user"},
+	"POST /api/v1/users/:id/password": {Action: "user.reset_password", ResourceType: "user"},
+
+	"POST /api/v1/apikeys":       {Action: "apikey.create", ResourceType: "apikey"},
+	"DELETE /api/v1/apikeys/:id": {Action: "apikey.revoke", ResourceType: "apikey"},
+
+	"POST /api/v1/webhooks":          {Action: "webhook.create", ResourceType: "webhook"},
+	"PUT /api/v1/webhooks/:id":       {Action: "webhook.update", ResourceType: "webhook"},
+	"DELETE /api/v1/webhooks/:id":    {Action: "webhook.delete", ResourceType: "webhook"},
+	"POST /api/v1/webhooks/:id/test": {Action: "webhook.test", ResourceType: "webhook"},
+}
+
+func registerAuthRoutes(v1 *gin.RouterGroup, h *handlers.AuthHandler, authMw gin.HandlerFunc) {
 	v1.POST("/auth/login", h.Login)
 	v1.POST("/auth/token", h.TokenLogin)
 	v1.POST("/auth/logout", authMw, h.Logout)
 	v1.POST("/auth/refresh", h.Refresh)
 	v1.GET("/auth/me", authMw, h.Me)
+	v1.POST("/auth/password", authMw, middleware.RequireScope(models.AreaUsers), h.ChangePassword)
+}
+
+func registerOIDCRoutes(v1 *gin.RouterGroup, h *handlers.OIDCHandler) {
+	g := v1.Group("/auth/oidc")
+	g.GET("/login", h.OIDCLogin)
+	g.GET("/callback", h.OIDCCallback)
+	g.POST("/device", h.OIDCDeviceStart)
+	g.POST("/device/token", h.OIDCDeviceToken)
 }
 
 func registerCatalogRoutes(v1 *gin.RouterGroup, catalog *handlers.CatalogHandler, resources *handlers.ResourcesHandler, authMw gin.HandlerFunc) {
 	g := v1.Group("")
-	g.Use(authMw)
+	g.Use(authMw, middleware.RequireScope(models.AreaCatalog))
 	{
 		g.GET("/resources", resources.GetResources)
 		g.GET("/architectures", catalog.ListArchitectures)
@@ -72,7 +154,7 @@ func registerCatalogRoutes(v1 *gin.RouterGroup, catalog *handlers.CatalogHandler
 
 func registerBundleRoutes(v1 *gin.RouterGroup, h *handlers.BundleHandler, authMw gin.HandlerFunc) {
 	g := v1.Group("catalog/bundles")
-	g.Use(authMw)
+	g.Use(authMw, middleware.RequireScope(models.AreaBundles), middleware.RequireRoleForWrites(models.RoleAdmin))
 	{
 		// POST /api/v1/catalog/bundles — create a new bundle
 		g.POST("", h.CreateBundle)
@@ -89,26 +171,109 @@ func registerBundleRoutes(v1 *gin.RouterGroup, h *handlers.BundleHandler, authMw
 	}
 }
 
-func registerApplicationRoutes(v1 *gin.RouterGroup, h *handlers.ApplicationHandler, authMw gin.HandlerFunc) {
+func registerConnectorRoutes(v1 *gin.RouterGroup, h *handlers.ConnectorHandler, authMw gin.HandlerFunc) {
+	g := v1.Group("connectors/instances")
+	g.Use(authMw, middleware.RequireScope(models.AreaConnectors), middleware.RequireRoleForWrites(models.RoleAdmin))
+	{
+		g.POST("", h.CreateConnector)
+		g.GET("", h.ListConnectors)
+		g.GET("/:id", h.GetConnector)
+		g.PUT("/:id", h.UpdateConnector)
+		g.DELETE("/:id", h.DeleteConnector)
+		g.POST("/:id/test", h.TestConnector)
+	}
+}
+
+func registerApplicationRoutes(v1 *gin.RouterGroup, h *handlers.ApplicationHandler, proxy *handlers.ProxyHandler, authMw gin.HandlerFunc) {
 	g := v1.Group("applications")
-	g.Use(authMw)
+	g.Use(authMw, middleware.RequireScope(models.AreaApplications), middleware.RequireRoleForWrites(models.RoleOperator))
 	{
 		g.GET("/", h.ListApplications)
 		g.GET("/:id", h.GetApplicationByID)
 		g.GET("/:id/resources", h.GetApplicationResources)
 		g.POST("/", h.CreateApplication)
+		g.POST("/plan", h.PlanApplication)
 		g.PUT("/:id", h.UpdateApplication)
 		g.DELETE("/:id", h.DeleteApplication)
+		g.POST("/:id/start", h.StartApplication)
+		g.POST("/:id/stop", h.StopApplication)
+		g.POST("/:id/restart", h.RestartApplication)
 		g.GET("/:id/ps", h.ApplicationPS)
+		g.GET("/:id/events", h.ApplicationEvents)
+		g.GET("/:id/pods/:pod/logs", h.PodLogs)
+		g.Any("/:id/services/:service/proxy/*path", proxy.ProxyService)
+	}
+}
+
+func registerJobRoutes(v1 *gin.RouterGroup, h *handlers.JobHandler, authMw gin.HandlerFunc) {
+	g := v1.Group("jobs")
+	g.Use(authMw, middleware.RequireScope(models.AreaApplications), middleware.RequireRoleForWrites(models.RoleOperator))
+	{
+		g.GET("/:id", h.GetJob)
+		g.POST("/:id/cancel", h.CancelJob)
 	}
 }
 
 func registerWorkerRoutes(v1 *gin.RouterGroup, h *handlers.WorkerHandler, authMw gin.HandlerFunc) {
 	g := v1.Group("workers")
-	g.Use(authMw)
+	g.Use(authMw, middleware.RequireScope(models.AreaWorkers), middleware.RequireRoleForWrites(models.RoleAdmin))
+	admin := middleware.RequireRole(models.RoleAdmin)
 	{
 		g.POST("", h.CreateWorker)
 		g.GET("", h.ListWorkers)
+		g.GET("/:id", h.GetWorker)
 		g.DELETE("/:id", h.DeleteWorker)
+		g.POST("/:id/cordon", h.CordonWorker)
+		g.POST("/:id/uncordon", h.UncordonWorker)
+		g.POST("/:id/drain", h.DrainWorker)
+		g.GET("/:id/tokens", admin, h.ListWorkerTokens)
+		g.POST("/:id/tokens", h.ReissueWorkerToken)
+		g.DELETE("/:id/tokens/:token_id", h.RevokeWorkerToken)
+	}
+}
+
+func registerUserRoutes(v1 *gin.RouterGroup, h *handlers.UserHandler, authMw gin.HandlerFunc) {
+	g := v1.Group("users")
+	g.Use(authMw, middleware.RequireScope(models.AreaUsers), middleware.RequireRole(models.RoleAdmin))
+	{
+		g.POST("", h.CreateUser)
+		g.GET("", h.ListUsers)
+		g.GET("/:id", h.GetUser)
+		g.DELETE("/:id", h.DeleteUser)
+		g.POST("/:id/disable", h.DisableUser)
+		g.POST("/:id/enable", h.EnableUser)
+		g.POST("/:id/password", h.ResetPassword)
+	}
+}
+
+func registerAPIKeyRoutes(v1 *gin.RouterGroup, h *handlers.APIKeyHandler, authMw gin.HandlerFunc) {
+	g := v1.Group("apikeys")
+	g.Use(authMw, middleware.RequireScope(models.AreaAPIKeys))
+	{
+		g.POST("", h.CreateAPIKey)
+		g.GET("", h.ListAPIKeys)
+		g.DELETE("/:id", h.RevokeAPIKey)
+	}
+}
+
+func registerAuditRoutes(v1 *gin.RouterGroup, h *handlers.AuditHandler, authMw gin.HandlerFunc) {
+	g := v1.Group("audit")
+	g.Use(authMw, middleware.RequireScope(models.AreaAudit), middleware.RequireRole(models.RoleAdmin))
+	{
+		g.GET("", h.ListAuditEvents)
+	}
+}
+
+func registerWebhookRoutes(v1 *gin.RouterGroup, h *handlers.WebhookHandler, authMw gin.HandlerFunc) {
+	g := v1.Group("webhooks")
+	g.Use(authMw, middleware.RequireScope(models.AreaWebhooks), middleware.RequireRole(models.RoleAdmin))
+	{
+		g.POST("", h.CreateWebhook)
+		g.GET("", h.ListWebhooks)
+		g.GET("/:id", h.GetWebhook)
+		g.PUT("/:id", h.UpdateWebhook)
+		g.DELETE("/:id", h.DeleteWebhook)
+		g.POST("/:id/test", h.TestWebhook)
+		g.GET("/:id/deliveries", h.ListWebhookDeliveries)
 	}
 }
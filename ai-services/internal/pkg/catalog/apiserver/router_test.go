@@ -0,0 +1,50 @@
+package apiserver
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/auth"
+)
+
+// unauditedRoutes are the mutating routes deliberately left out of the audit log.
+var unauditedRoutes = map[string]bool{
+	"POST /api/v1/auth/login":                                        true,
+	"POST /api/v1/auth/token":                                        true,
+	"POST /api/v1/auth/logout":                                       true,
+	"POST /api/v1/auth/refresh":                                      true,
+	"POST /api/v1/auth/oidc/device":                                  true,
+	"POST /api/v1/auth/oidc/device/token":                            true,
+	"POST /api/v1/catalog/bundles/validate":                          true,
+	"POST /api/v1/applications/plan":                                 true,
+	"POST /api/v1/applications/:id/services/:service/proxy/*path":    true,
+	"PUT /api/v1/applications/:id/services/:service/proxy/*path":     true,
+	"PATCH /api/v1/applications/:id/services/:service/proxy/*path":   true,
+	"DELETE /api/v1/applications/:id/services/:service/proxy/*path":  true,
+	"CONNECT /api/v1/applications/:id/services/:service/proxy/*path": true,
+	"TRACE /api/v1/applications/:id/services/:service/proxy/*path":   true,
+}
+
+func TestAuditedRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	routes := CreateRouter(nil, auth.NewOIDCService(nil, nil, nil, nil), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil).Routes()
+
+	registered := make(map[string]bool, len(routes))
+	for _, r := range routes {
+		key := r.Method + " " + r.Path
+		registered[key] = true
+
+		readOnly := r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions
+		if _, audited := auditedRoutes[key]; !readOnly && !audited && !unauditedRoutes[key] {
+			t.Errorf("mutating route %s is not in auditedRoutes", key)
+		}
+	}
+
+	for key := range auditedRoutes {
+		if !registered[key] {
+			t.Errorf("auditedRoutes lists %s, which is not a registered route", key)
+		}
+	}
+}
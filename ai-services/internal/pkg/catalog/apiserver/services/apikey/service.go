@@ -0,0 +1,233 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+	apirepository "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository"
+	dbmodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+const (
+	// keyRandomBytes is the amount of randomness in a key (256 bits).
+	keyRandomBytes = 32
+	// displayPrefixLen is how much of the key is stored in clear to tell keys apart.
+	displayPrefixLen = len(KeyPrefix) + 6
+)
+
+// apiKeyService implements APIKeyServiceInterface.
+type apiKeyService struct {
+	repo repository.APIKeyRepository
+	now  func() time.Time
+}
+
+// NewAPIKeyService creates a new apiKeyService backed by the given APIKeyRepository.
+func NewAPIKeyService(repo repository.APIKeyRepository) APIKeyServiceInterface {
+	return &apiKeyService{repo: repo, now: time.Now}
+}
+
+// CreateKey validates the request, generates a key and stores its hash.
+func (s *apiKeyService) CreateKey(ctx context.Context, userID string, callerScopes []string, req CreateAPIKeyRequest) (*CreateAPIKeyResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, &validators.ValidationError{Code: http.StatusBadRequest, Message: "name is required"}
+	}
+	scopes, err := parseScopes(req.Scopes)
+	if err != nil {
+		return nil, &validators.ValidationError{Code: http.StatusBadRequest, Message: err.Error()}
+	}
+	if err := checkScopesGranted(callerScopes, scopes); err != nil {
+		return nil, err
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(s.now()) {
+		return nil, &validators.ValidationError{Code: http.StatusBadRequest, Message: "expires_at must be in the future"}
+	}
+
+	key, err := generateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	row := &dbmodels.APIKey{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    key[:displayPrefixLen],
+		KeyHash:   apirepository.HashToken(key),
+		Scopes:    scopes,
+		ExpiresAt: req.ExpiresAt,
+	}
+	if err := s.repo.Create(ctx, row); err != nil {
+		if errors.Is(err, repository.ErrAPIKeyNameTaken) {
+			return nil, &validators.ValidationError{Code: http.StatusConflict, Message: fmt.Sprintf("an active API key named %q already exists", name)}
+		}
+
+		return nil, fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	return &CreateAPIKeyResponse{APIKeyResponse: s.toResponse(row), Key: key}, nil
+}
+
+// ListKeys returns the keys of userID, or of every user when all is true.
+func (s *apiKeyService) ListKeys(ctx context.Context, userID string, all bool) (*APIKeyListResponse, error) {
+	if all {
+		userID = ""
+	}
+
+	rows, err := s.repo.List(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+
+	resp := &APIKeyListResponse{APIKeys: make([]APIKeyResponse, 0, len(rows))}
+	for i := range rows {
+		resp.APIKeys = append(resp.APIKeys, s.toResponse(&rows[i]))
+	}
+
+	return resp, nil
+}
+
+// RevokeKey revokes a key of the caller, or any key when the caller is an admin.
+// Keys of other users are reported as not found to non-admins.
+func (s *apiKeyService) RevokeKey(ctx context.Context, callerID string, callerRole models.Role, id string) error {
+	notFound := &validators.ValidationError{Code: http.StatusNotFound, Message: fmt.Sprintf("API key %q not found", id)}
+
+	keyID, err := uuid.Parse(id)
+	if err != nil {
+		return notFound
+	}
+	key, err := s.repo.GetByID(ctx, keyID)
+	if err != nil {
+		return fmt.Errorf("failed to get API key: %w", err)
+	}
+	if key == nil || (key.UserID != callerID && !callerRole.Allows(models.RoleAdmin)) {
+		return notFound
+	}
+
+	revoked, err := s.repo.Revoke(ctx, keyID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+	if !revoked {
+		return &validators.ValidationError{Code: http.StatusConflict, Message: fmt.Sprintf("API key %q is already revoked", id)}
+	}
+
+	return nil
+}
+
+// Authenticate resolves a plaintext key to the identity it acts as.
+func (s *apiKeyService) Authenticate(ctx context.Context, key string) (*Principal, error) {
+	if !strings.HasPrefix(key, KeyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	row, err := s.repo.GetByHash(ctx, apirepository.HashToken(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up API key: %w", err)
+	}
+	if row == nil || statusAt(row, s.now()) != APIKeyStatusActive || row.UserDisabled {
+		return nil, ErrInvalidAPIKey
+	}
+
+	if err := s.repo.TouchLastUsed(ctx, row.ID); err != nil {
+		logger.WarningfCtx(ctx, "%v", err)
+	}
+
+	return &Principal{KeyID: row.ID, UserID: row.UserID, Role: models.Role(row.UserRole), Scopes: row.Scopes}, nil
+}
+
+func (s *apiKeyService) toResponse(k *dbmodels.APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:         k.ID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		UserID:     k.UserID,
+		Username:   k.Username,
+		Scopes:     nonNil(k.Scopes),
+		Status:     statusAt(k, s.now()),
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+		CreatedAt:  k.CreatedAt,
+	}
+}
+
+// statusAt derives the key status at now from its timestamps.
+func statusAt(k *dbmodels.APIKey, now time.Time) APIKeyStatus {
+	switch {
+	case k.RevokedAt != nil:
+		return APIKeyStatusRevoked
+	case k.ExpiresAt != nil && !now.Before(*k.ExpiresAt):
+		return APIKeyStatusExpired
+	default:
+		return APIKeyStatusActive
+	}
+}
+
+// parseScopes validates and de-duplicates scopes.
+func parseScopes(scopes []string) ([]string, error) {
+	seen := make(map[string]bool, len(scopes))
+	parsed := make([]string, 0, len(scopes))
+	for _, s := range scopes {
+		scope, err := models.ParseScope(strings.TrimSpace(s))
+		if err != nil {
+			return nil, err
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			parsed = append(parsed, scope)
+		}
+	}
+
+	return parsed, nil
+}
+
+// checkScopesGranted rejects scopes that callerScopes do not grant. Empty callerScopes
+// do not restrict the caller. Empty scopes grant every area, so a restricted caller
+// must list them.
+func checkScopesGranted(callerScopes, scopes []string) error {
+	if len(callerScopes) == 0 {
+		return nil
+	}
+	if len(scopes) == 0 {
+		return &validators.ValidationError{Code: http.StatusForbidden, Message: fmt.Sprintf("scopes are required when creating a key with an API key; allowed: %v", callerScopes)}
+	}
+	for _, scope := range scopes {
+		if !models.ScopesGrant(callerScopes, scope) {
+			return &validators.ValidationError{Code: http.StatusForbidden, Message: fmt.Sprintf("scope %q exceeds the scopes of the calling API key: %v", scope, callerScopes)}
+		}
+	}
+
+	return nil
+}
+
+// generateKey returns a new random key starting with KeyPrefix.
+func generateKey() (string, error) {
+	b := make([]byte, keyRandomBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+
+	return KeyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+
+	return s
+}
+
+// Made with Bob
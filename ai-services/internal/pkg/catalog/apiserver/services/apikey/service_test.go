@@ -0,0 +1,216 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+	apirepository "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository"
+	dbmodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+)
+
+// fakeAPIKeyRepo is an in-memory repository.APIKeyRepository. Every key belongs to
+// a user with role operator unless the user is listed in disabled.
+type fakeAPIKeyRepo struct {
+	keys     []*dbmodels.APIKey
+	disabled map[string]bool
+	touched  int
+}
+
+func (r *fakeAPIKeyRepo) Create(_ context.Context, key *dbmodels.APIKey) error {
+	for _, k := range r.keys {
+		if k.UserID == key.UserID && k.Name == key.Name && k.RevokedAt == nil {
+			return repository.ErrAPIKeyNameTaken
+		}
+	}
+	key.ID = uuid.New()
+	key.CreatedAt = time.Now()
+	stored := *key
+	r.keys = append(r.keys, &stored)
+
+	return nil
+}
+
+func (r *fakeAPIKeyRepo) GetByHash(_ context.Context, keyHash string) (*dbmodels.APIKey, error) {
+	for _, k := range r.keys {
+		if k.KeyHash == keyHash {
+			out := *k
+			out.UserRole = string(models.RoleOperator)
+			out.UserDisabled = r.disabled[k.UserID]
+
+			return &out, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *fakeAPIKeyRepo) GetByID(_ context.Context, id uuid.UUID) (*dbmodels.APIKey, error) {
+	for _, k := range r.keys {
+		if k.ID == id {
+			out := *k
+
+			return &out, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *fakeAPIKeyRepo) List(_ context.Context, userID string) ([]dbmodels.APIKey, error) {
+	var out []dbmodels.APIKey
+	for _, k := range r.keys {
+		if userID == "" || k.UserID == userID {
+			out = append(out, *k)
+		}
+	}
+
+	return out, nil
+}
+
+func (r *fakeAPIKeyRepo) Revoke(_ context.Context, id uuid.UUID) (bool, error) {
+	for _, k := range r.keys {
+		if k.ID == id && k.RevokedAt == nil {
+			now := time.Now()
+			k.RevokedAt = &now
+
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *fakeAPIKeyRepo) TouchLastUsed(context.Context, uuid.UUID) error {
+	r.touched++
+
+	return nil
+}
+
+// validationCode returns the HTTP code of a *validators.ValidationError, or 0.
+func validationCode(err error) int {
+	var valErr *validators.ValidationError
+	if errors.As(err, &valErr) {
+		return valErr.Code
+	}
+
+	return 0
+}
+
+func TestCreateKey(t *testing.T) {
+	repo := &fakeAPIKeyRepo{}
+	svc := NewAPIKeyService(repo)
+	ctx := context.Background()
+
+	resp, err := svc.CreateKey(ctx, "alice", nil, CreateAPIKeyRequest{Name: "ci", Scopes: []string{"applications:write", "applications:write"}})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(resp.Key, KeyPrefix))
+	assert.Equal(t, resp.Key[:displayPrefixLen], resp.Prefix)
+	assert.Equal(t, []string{"applications:write"}, resp.Scopes, "scopes must be de-duplicated")
+	assert.Equal(t, APIKeyStatusActive, resp.Status)
+
+	require.Len(t, repo.keys, 1)
+	assert.Equal(t, apirepository.HashToken(resp.Key), repo.keys[0].KeyHash, "only the hash must be stored")
+
+	_, err = svc.CreateKey(ctx, "alice", nil, CreateAPIKeyRequest{Name: "ci"})
+	assert.Equal(t, http.StatusConflict, validationCode(err))
+
+	_, err = svc.CreateKey(ctx, "alice", nil, CreateAPIKeyRequest{Name: "bad", Scopes: []string{"applications:delete"}})
+	assert.Equal(t, http.StatusBadRequest, validationCode(err))
+
+	past := time.Now().Add(-time.Hour)
+	_, err = svc.CreateKey(ctx, "alice", nil, CreateAPIKeyRequest{Name: "old", ExpiresAt: &past})
+	assert.Equal(t, http.StatusBadRequest, validationCode(err))
+}
+
+func TestCreateKey_FromScopedKey(t *testing.T) {
+	svc := NewAPIKeyService(&fakeAPIKeyRepo{})
+	ctx := context.Background()
+	caller := []string{"apikeys:write", "applications:read"}
+
+	_, err := svc.CreateKey(ctx, "alice", caller, CreateAPIKeyRequest{Name: "unscoped"})
+	assert.Equal(t, http.StatusForbidden, validationCode(err), "a scoped key must not create a key with full access")
+
+	for _, scope := range []string{"*", "applications:write", "workers:read"} {
+		_, err = svc.CreateKey(ctx, "alice", caller, CreateAPIKeyRequest{Name: "wider", Scopes: []string{scope}})
+		assert.Equal(t, http.StatusForbidden, validationCode(err), scope)
+	}
+
+	resp, err := svc.CreateKey(ctx, "alice", caller, CreateAPIKeyRequest{Name: "narrower", Scopes: []string{"applications:read", "apikeys:read"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"applications:read", "apikeys:read"}, resp.Scopes)
+
+	_, err = svc.CreateKey(ctx, "alice", []string{"*"}, CreateAPIKeyRequest{Name: "all", Scopes: []string{"*"}})
+	require.NoError(t, err)
+}
+
+func TestAuthenticate(t *testing.T) {
+	repo := &fakeAPIKeyRepo{disabled: map[string]bool{}}
+	svc := NewAPIKeyService(repo).(*apiKeyService)
+	ctx := context.Background()
+
+	exp := time.Now().Add(time.Hour)
+	created, err := svc.CreateKey(ctx, "alice", nil, CreateAPIKeyRequest{Name: "ci", Scopes: []string{"catalog:read"}, ExpiresAt: &exp})
+	require.NoError(t, err)
+
+	p, err := svc.Authenticate(ctx, created.Key)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", p.UserID)
+	assert.Equal(t, models.RoleOperator, p.Role)
+	assert.Equal(t, []string{"catalog:read"}, p.Scopes)
+	assert.Equal(t, 1, repo.touched)
+
+	_, err = svc.Authenticate(ctx, "not-a-key")
+	require.ErrorIs(t, err, ErrInvalidAPIKey)
+	_, err = svc.Authenticate(ctx, KeyPrefix+"unknown")
+	require.ErrorIs(t, err, ErrInvalidAPIKey)
+
+	repo.disabled["alice"] = true
+	_, err = svc.Authenticate(ctx, created.Key)
+	require.ErrorIs(t, err, ErrInvalidAPIKey, "keys of disabled users must be rejected")
+	repo.disabled["alice"] = false
+
+	svc.now = func() time.Time { return exp }
+	_, err = svc.Authenticate(ctx, created.Key)
+	require.ErrorIs(t, err, ErrInvalidAPIKey, "expired keys must be rejected")
+	svc.now = time.Now
+
+	require.NoError(t, svc.RevokeKey(ctx, "alice", models.RoleViewer, created.ID.String()))
+	_, err = svc.Authenticate(ctx, created.Key)
+	require.ErrorIs(t, err, ErrInvalidAPIKey, "revoked keys must be rejected")
+}
+
+func TestRevokeKey(t *testing.T) {
+	svc := NewAPIKeyService(&fakeAPIKeyRepo{})
+	ctx := context.Background()
+
+	created, err := svc.CreateKey(ctx, "alice", nil, CreateAPIKeyRequest{Name: "ci"})
+	require.NoError(t, err)
+	id := created.ID.String()
+
+	assert.Equal(t, http.StatusNotFound, validationCode(svc.RevokeKey(ctx, "bob", models.RoleOperator, id)),
+		"keys of other users must look missing")
+	assert.Equal(t, http.StatusNotFound, validationCode(svc.RevokeKey(ctx, "alice", models.RoleViewer, "not-a-uuid")))
+
+	require.NoError(t, svc.RevokeKey(ctx, "root", models.RoleAdmin, id), "admins may revoke any key")
+	assert.Equal(t, http.StatusConflict, validationCode(svc.RevokeKey(ctx, "alice", models.RoleViewer, id)))
+
+	list, err := svc.ListKeys(ctx, "alice", false)
+	require.NoError(t, err)
+	require.Len(t, list.APIKeys, 1)
+	assert.Equal(t, APIKeyStatusRevoked, list.APIKeys[0].Status)
+
+	// A revoked key frees its name.
+	_, err = svc.CreateKey(ctx, "alice", nil, CreateAPIKeyRequest{Name: "ci"})
+	require.NoError(t, err)
+}
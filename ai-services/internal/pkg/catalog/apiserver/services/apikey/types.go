@@ -0,0 +1,93 @@
+// Package apikey defines the service layer for long-lived catalog API keys.
+package apikey
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+)
+
+// KeyPrefix starts every API key, so keys are recognisable in configuration and logs.
+const KeyPrefix = "aisk_"
+
+// ErrInvalidAPIKey is returned by Authenticate for unknown, revoked or expired keys
+// and for keys whose owner is disabled.
+var ErrInvalidAPIKey = errors.New("invalid API key")
+
+// APIKeyServiceInterface is the interface fulfilled by apiKeyService.
+// Errors meant for the caller are returned as *validators.ValidationError.
+type APIKeyServiceInterface interface {
+	// CreateKey creates a key owned by userID and returns it together with the
+	// plaintext key, which is not stored and cannot be retrieved again. callerScopes
+	// are the scopes of the API key the request authenticated with, if any; a key
+	// cannot be given access the caller's key does not have.
+	CreateKey(ctx context.Context, userID string, callerScopes []string, req CreateAPIKeyRequest) (*CreateAPIKeyResponse, error)
+	// ListKeys returns the keys of userID, or of every user when all is true, newest first.
+	ListKeys(ctx context.Context, userID string, all bool) (*APIKeyListResponse, error)
+	// RevokeKey revokes a key. Only its owner or an admin may revoke it.
+	RevokeKey(ctx context.Context, callerID string, callerRole models.Role, id string) error
+	// Authenticate resolves a plaintext key to the identity it acts as.
+	Authenticate(ctx context.Context, key string) (*Principal, error)
+}
+
+// Principal is the identity a request authenticated with an API key acts as.
+type Principal struct {
+	KeyID  uuid.UUID
+	UserID string
+	// Role is the current role of the key's owner.
+	Role models.Role
+	// Scopes restricts the areas the key may use; empty means no restriction.
+	Scopes []string
+}
+
+// CreateAPIKeyRequest is the payload of POST /apikeys.
+type CreateAPIKeyRequest struct {
+	Name string `json:"name" binding:"required"`
+	// Scopes restricts the key to API areas, e.g. ["applications:write", "catalog:read"].
+	// Empty grants every area the owner's role allows.
+	Scopes []string `json:"scopes"`
+	// ExpiresAt is when the key stops working. Empty means it never expires.
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// APIKeyStatus is the derived state of an API key.
+type APIKeyStatus string
+
+const (
+	APIKeyStatusActive  APIKeyStatus = "active"
+	APIKeyStatusExpired APIKeyStatus = "expired"
+	APIKeyStatusRevoked APIKeyStatus = "revoked"
+)
+
+// APIKeyResponse is an API key as returned by the API. The key itself is never included.
+type APIKeyResponse struct {
+	ID         uuid.UUID    `json:"id"`
+	Name       string       `json:"name"`
+	Prefix     string       `json:"prefix"`
+	UserID     string       `json:"user_id"`
+	Username   string       `json:"username"`
+	Scopes     []string     `json:"scopes"`
+	Status     APIKeyStatus `json:"status"`
+	ExpiresAt  *time.Time   `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time   `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time   `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+// CreateAPIKeyResponse is the response of POST /apikeys.
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	// Key is the plaintext API key. It is only returned once.
+	Key string `json:"key"`
+}
+
+// APIKeyListResponse is the response of GET /apikeys.
+type APIKeyListResponse struct {
+	APIKeys []APIKeyResponse `json:"api_keys"`
+}
+
+// Made with Bob
@@ -0,0 +1,121 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	dbmodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+)
+
+// auditService implements AuditServiceInterface.
+type auditService struct {
+	repo repository.AuditRepository
+}
+
+// NewAuditService creates a new auditService backed by the given AuditRepository.
+func NewAuditService(repo repository.AuditRepository) AuditServiceInterface {
+	return &auditService{repo: repo}
+}
+
+// Record stores an audit event.
+func (s *auditService) Record(ctx context.Context, event *dbmodels.AuditEvent) error {
+	return s.repo.Insert(ctx, event)
+}
+
+// ListEvents validates the filters and returns one page of matching events.
+func (s *auditService) ListEvents(ctx context.Context, req AuditListRequest) (*AuditListResponse, error) {
+	if req.Page < 1 || req.PageSize < 1 {
+		return nil, fmt.Errorf("page and pageSize must be greater than 0")
+	}
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+
+	filters := &repository.AuditFilters{
+		UserID:       req.UserID,
+		Action:       req.Action,
+		ResourceType: req.ResourceType,
+		ResourceID:   req.ResourceID,
+		Outcome:      dbmodels.AuditOutcome(req.Outcome),
+		Since:        req.Since,
+		Until:        req.Until,
+	}
+
+	totalCount, err := s.repo.GetCount(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit event count: %w", err)
+	}
+
+	filters.Limit = req.PageSize
+	filters.Offset = (req.Page - 1) * req.PageSize
+
+	rows, err := s.repo.GetAll(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve audit events: %w", err)
+	}
+
+	events := make([]AuditEventResponse, 0, len(rows))
+	for i := range rows {
+		events = append(events, toResponse(&rows[i]))
+	}
+
+	totalPages := 0
+	if totalCount > 0 {
+		totalPages = (totalCount + req.PageSize - 1) / req.PageSize
+	}
+
+	return &AuditListResponse{
+		Events: events,
+		Pagination: types.PaginationMetadata{
+			Page:       req.Page,
+			PageSize:   req.PageSize,
+			TotalItems: totalCount,
+			TotalPages: totalPages,
+			HasNext:    req.Page < totalPages,
+			HasPrev:    req.Page > 1,
+		},
+	}, nil
+}
+
+// validateListRequest checks the outcome filter and the time range.
+func validateListRequest(req AuditListRequest) error {
+	switch dbmodels.AuditOutcome(req.Outcome) {
+	case "", dbmodels.AuditOutcomeSuccess, dbmodels.AuditOutcomeDenied, dbmodels.AuditOutcomeFailure:
+	default:
+		return &validators.ValidationError{
+			Code: http.StatusBadRequest,
+			Message: fmt.Sprintf("invalid outcome %q: must be one of %s, %s, %s",
+				req.Outcome, dbmodels.AuditOutcomeSuccess, dbmodels.AuditOutcomeDenied, dbmodels.AuditOutcomeFailure),
+		}
+	}
+
+	if req.Since != nil && req.Until != nil && !req.Since.Before(*req.Until) {
+		return &validators.ValidationError{Code: http.StatusBadRequest, Message: "since must be before until"}
+	}
+
+	return nil
+}
+
+func toResponse(e *dbmodels.AuditEvent) AuditEventResponse {
+	return AuditEventResponse{
+		ID:           e.ID,
+		UserID:       e.UserID,
+		Username:     e.Username,
+		APIKeyID:     e.APIKeyID,
+		Action:       e.Action,
+		ResourceType: e.ResourceType,
+		ResourceID:   e.ResourceID,
+		Method:       e.Method,
+		Path:         e.Path,
+		StatusCode:   e.StatusCode,
+		Outcome:      string(e.Outcome),
+		RequestID:    e.RequestID,
+		CreatedAt:    e.CreatedAt,
+	}
+}
+
+// Made with Bob
@@ -0,0 +1,68 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbmodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+)
+
+// fakeAuditRepo serves count events and records the filters it was called with.
+type fakeAuditRepo struct {
+	count   int
+	filters *repository.AuditFilters
+}
+
+func (r *fakeAuditRepo) Insert(context.Context, *dbmodels.AuditEvent) error { return nil }
+
+func (r *fakeAuditRepo) GetCount(context.Context, *repository.AuditFilters) (int, error) {
+	return r.count, nil
+}
+
+func (r *fakeAuditRepo) GetAll(_ context.Context, filters *repository.AuditFilters) ([]dbmodels.AuditEvent, error) {
+	r.filters = filters
+	n := min(filters.Limit, r.count-filters.Offset)
+	events := make([]dbmodels.AuditEvent, n)
+	for i := range events {
+		events[i] = dbmodels.AuditEvent{Action: "application.delete", Outcome: dbmodels.AuditOutcomeSuccess}
+	}
+
+	return events, nil
+}
+
+func TestListEvents(t *testing.T) {
+	repo := &fakeAuditRepo{count: 45}
+	svc := NewAuditService(repo)
+
+	resp, err := svc.ListEvents(context.Background(), AuditListRequest{ResourceType: "application", Outcome: "success", Page: 3, PageSize: 20})
+	require.NoError(t, err)
+	assert.Len(t, resp.Events, 5)
+	assert.Equal(t, 3, resp.Pagination.TotalPages)
+	assert.False(t, resp.Pagination.HasNext)
+	assert.Equal(t, 40, repo.filters.Offset)
+	assert.Equal(t, "application", repo.filters.ResourceType)
+	assert.Equal(t, dbmodels.AuditOutcomeSuccess, repo.filters.Outcome)
+}
+
+func TestListEvents_InvalidFilters(t *testing.T) {
+	svc := NewAuditService(&fakeAuditRepo{})
+	now := time.Now()
+
+	for _, req := range []AuditListRequest{
+		{Outcome: "maybe", Page: 1, PageSize: 20},
+		{Since: &now, Until: &now, Page: 1, PageSize: 20},
+	} {
+		_, err := svc.ListEvents(context.Background(), req)
+		var valErr *validators.ValidationError
+		require.True(t, errors.As(err, &valErr), "%+v: got %v", req, err)
+		assert.Equal(t, http.StatusBadRequest, valErr.Code)
+	}
+}
@@ -0,0 +1,60 @@
+// Package audit defines the service layer for the audit log of mutating API calls.
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	dbmodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+)
+
+// AuditServiceInterface is the interface fulfilled by auditService.
+// Errors meant for the caller are returned as *validators.ValidationError.
+type AuditServiceInterface interface {
+	// Record stores an audit event.
+	Record(ctx context.Context, event *dbmodels.AuditEvent) error
+	// ListEvents returns one page of the events matching req, newest first.
+	ListEvents(ctx context.Context, req AuditListRequest) (*AuditListResponse, error)
+}
+
+// AuditListRequest holds the filters and validated pagination inputs for ListEvents.
+// Empty filters match every event.
+type AuditListRequest struct {
+	UserID       string
+	Action       string
+	ResourceType string
+	ResourceID   string
+	Outcome      string
+	Since        *time.Time
+	Until        *time.Time
+	Page         int
+	PageSize     int
+}
+
+// AuditEventResponse is an audit event as returned by the API.
+type AuditEventResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	UserID       string     `json:"user_id"`
+	Username     string     `json:"username,omitempty"`
+	APIKeyID     *uuid.UUID `json:"api_key_id,omitempty"`
+	Action       string     `json:"action"`
+	ResourceType string     `json:"resource_type"`
+	ResourceID   string     `json:"resource_id"`
+	Method       string     `json:"method"`
+	Path         string     `json:"path"`
+	StatusCode   int        `json:"status_code"`
+	Outcome      string     `json:"outcome"`
+	RequestID    string     `json:"request_id"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// AuditListResponse is the response of GET /audit.
+type AuditListResponse struct {
+	Events     []AuditEventResponse     `json:"events"`
+	Pagination types.PaginationMetadata `json:"pagination"`
+}
+
+// Made with Bob
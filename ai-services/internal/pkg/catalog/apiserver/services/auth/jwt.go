@@ -2,17 +2,29 @@ package auth
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
+	"github.com/go-jose/go-jose/v4"
 	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
 )
 
+// TokenManager issues and validates catalog JWTs. Tokens are signed with HS256 and
+// a shared secret, or, when the manager has a KeySet, with the set's signing key.
 type TokenManager struct {
-	secret     []byte
-	accessTTL  time.Duration
-	refreshTTL time.Duration
+	secret []byte
+	keys   *KeySet
+	// legacyIssuedBefore and legacyUntil bound the HS256 tokens a key set manager
+	// accepts: issued before the key set was created, and only until legacyUntil.
+	legacyIssuedBefore time.Time
+	legacyUntil        time.Time
+	accessTTL          time.Duration
+	refreshTTL         time.Duration
 }
 
+// NewTokenManager creates a token manager that signs with HS256 and secret.
 func NewTokenManager(secret string, accessTTL, refreshTTL time.Duration) *TokenManager {
 	return &TokenManager{
 		secret:     []byte(secret),
@@ -21,16 +33,59 @@ func NewTokenManager(secret string, accessTTL, refreshTTL time.Duration) *TokenM
 	}
 }
 
+// NewKeySetTokenManager creates a token manager that signs with the signing key of
+// keys and accepts tokens signed by any key in the set. When legacySecret is not
+// empty, HS256 tokens issued with it before keySetCreatedAt, the switch to
+// asymmetric keys, are still accepted until keySetCreatedAt plus refreshTTL, by when
+// every session started before the switch has been refreshed or has expired.
+func NewKeySetTokenManager(keys *KeySet, legacySecret string, keySetCreatedAt time.Time, accessTTL, refreshTTL time.Duration) *TokenManager {
+	return &TokenManager{
+		secret:             []byte(legacySecret),
+		keys:               keys,
+		legacyIssuedBefore: keySetCreatedAt,
+		legacyUntil:        keySetCreatedAt.Add(refreshTTL),
+		accessTTL:          accessTTL,
+		refreshTTL:         refreshTTL,
+	}
+}
+
+// LegacySecretExpired reports whether a manager created with NewKeySetTokenManager
+// no longer accepts any HS256 token, so AUTH_JWT_SECRET can be removed.
+func LegacySecretExpired(keySetCreatedAt time.Time, refreshTTL time.Duration) bool {
+	return !time.Now().Before(keySetCreatedAt.Add(refreshTTL))
+}
+
+// JWKS returns the public keys that verify catalog tokens. It is empty for a
+// manager that signs with a shared secret.
+func (t *TokenManager) JWKS() jose.JSONWebKeySet {
+	if t.keys == nil {
+		return jose.JSONWebKeySet{Keys: []jose.JSONWebKey{}}
+	}
+
+	return t.keys.JWKS()
+}
+
+// Identity is the subject of a validated token.
+type Identity struct {
+	UserID string
+	// Role is the role granted when the token was issued. Tokens issued before roles
+	// existed carry none and are treated as RoleViewer.
+	Role      models.Role
+	ExpiresAt time.Time
+}
+
 type customClaims struct {
 	UserID string `json:"uid"`
+	Role   string `json:"role,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func (t *TokenManager) newToken(uid string, ttl time.Duration, tokenType string) (string, time.Time, error) {
+func (t *TokenManager) newToken(uid string, role models.Role, ttl time.Duration, tokenType string) (string, time.Time, error) {
 	now := time.Now()
 	exp := now.Add(ttl)
 	claims := customClaims{
 		UserID: uid,
+		Role:   string(role),
 		RegisteredClaims: jwt.RegisteredClaims{
 			Issuer:    "ai-services-catalog-server",
 			Subject:   uid,
@@ -40,48 +95,59 @@ func (t *TokenManager) newToken(uid string, ttl time.Duration, tokenType string)
 			NotBefore: jwt.NewNumericDate(now),
 		},
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signed, err := token.SignedString(t.secret)
+	if t.keys == nil {
+		signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(t.secret)
+
+		return signed, exp, err
+	}
+
+	key, err := t.keys.SigningKey()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	token := jwt.NewWithClaims(key.method(), claims)
+	token.Header["kid"] = key.ID
+	signed, err := token.SignedString(key.Private)
 
 	return signed, exp, err
 }
 
-func (t *TokenManager) GenerateAccessToken(uid string) (string, time.Time, error) {
-	return t.newToken(uid, t.accessTTL, "access")
+func (t *TokenManager) GenerateAccessToken(uid string, role models.Role) (string, time.Time, error) {
+	return t.newToken(uid, role, t.accessTTL, "access")
 }
 
-func (t *TokenManager) GenerateRefreshToken(uid string) (string, time.Time, error) {
-	return t.newToken(uid, t.refreshTTL, "refresh")
+func (t *TokenManager) GenerateRefreshToken(uid string, role models.Role) (string, time.Time, error) {
+	return t.newToken(uid, role, t.refreshTTL, "refresh")
 }
 
-func (t *TokenManager) ValidateAccessToken(raw string) (string, time.Time, error) {
-	claims, err := t.parse(raw)
-	if err != nil {
-		return "", time.Time{}, err
-	}
-	if !contains(claims.Audience, "access") {
-		return "", time.Time{}, errors.New("not an access token")
-	}
+func (t *TokenManager) ValidateAccessToken(raw string) (*Identity, error) {
+	return t.validate(raw, "access")
+}
 
-	return claims.UserID, claims.ExpiresAt.Time, nil
+func (t *TokenManager) ValidateRefreshToken(raw string) (*Identity, error) {
+	return t.validate(raw, "refresh")
 }
 
-func (t *TokenManager) ValidateRefreshToken(raw string) (string, time.Time, error) {
+// validate parses raw and checks that it is a token of tokenType.
+func (t *TokenManager) validate(raw, tokenType string) (*Identity, error) {
 	claims, err := t.parse(raw)
 	if err != nil {
-		return "", time.Time{}, err
+		return nil, err
 	}
-	if !contains(claims.Audience, "refresh") {
-		return "", time.Time{}, errors.New("not a refresh token")
+	if !contains(claims.Audience, tokenType) {
+		return nil, fmt.Errorf("token audience is not %q", tokenType)
 	}
 
-	return claims.UserID, claims.ExpiresAt.Time, nil
+	role := models.Role(claims.Role)
+	if _, err := models.ParseRole(claims.Role); err != nil {
+		role = models.RoleViewer
+	}
+
+	return &Identity{UserID: claims.UserID, Role: role, ExpiresAt: claims.ExpiresAt.Time}, nil
 }
 
 func (t *TokenManager) parse(raw string) (*customClaims, error) {
-	token, err := jwt.ParseWithClaims(raw, &customClaims{}, func(token *jwt.Token) (interface{}, error) {
-		return t.secret, nil
-	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	token, err := jwt.ParseWithClaims(raw, &customClaims{}, t.verificationKey, jwt.WithValidMethods(t.validMethods()))
 	if err != nil {
 		return nil, err
 	}
@@ -96,6 +162,51 @@ func (t *TokenManager) parse(raw string) (*customClaims, error) {
 	return claims, nil
 }
 
+// validMethods returns the signing algorithms accepted by the manager.
+func (t *TokenManager) validMethods() []string {
+	var methods []string
+	if len(t.secret) > 0 && (t.keys == nil || time.Now().Before(t.legacyUntil)) {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if t.keys != nil {
+		methods = append(methods, SigningAlgRS256, SigningAlgEdDSA)
+	}
+
+	return methods
+}
+
+// verificationKey returns the key that verifies token: the shared secret for HS256
+// tokens, otherwise the key set's key with the token's key ID. The parser has
+// already checked the algorithm against validMethods, and rejects a key whose type
+// does not match it.
+func (t *TokenManager) verificationKey(token *jwt.Token) (interface{}, error) {
+	if token.Method == jwt.SigningMethodHS256 {
+		if t.keys != nil && !t.issuedBeforeKeySet(token) {
+			return nil, errLegacyTokenTooNew
+		}
+
+		return t.secret, nil
+	}
+	kid, _ := token.Header["kid"].(string)
+
+	return t.keys.VerificationKey(kid)
+}
+
+// errLegacyTokenTooNew rejects HS256 tokens minted with the legacy secret after the
+// switch to asymmetric keys.
+var errLegacyTokenTooNew = errors.New("HS256 token was not issued before the switch to signing keys")
+
+// issuedBeforeKeySet reports whether an HS256 token was issued before the key set was
+// created. Tokens without an issue time are not.
+func (t *TokenManager) issuedBeforeKeySet(token *jwt.Token) bool {
+	claims, ok := token.Claims.(*customClaims)
+	if !ok || claims.IssuedAt == nil {
+		return false
+	}
+
+	return claims.IssuedAt.Before(t.legacyIssuedBefore)
+}
+
 func contains(ss []string, s string) bool {
 	for _, v := range ss {
 		if v == s {
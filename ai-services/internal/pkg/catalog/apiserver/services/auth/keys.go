@@ -0,0 +1,282 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Signing algorithms supported for catalog JWTs.
+const (
+	SigningAlgRS256 = "RS256"
+	SigningAlgEdDSA = "EdDSA"
+)
+
+const (
+	rsaKeyBits = 2048
+
+	// keySetRefreshInterval is how often a KeySet reloads its keys, so tokens signed
+	// by a key added on another replica are accepted and retired keys are dropped.
+	keySetRefreshInterval = time.Minute
+	// unknownKeyReloadInterval limits how often a token with an unknown key ID
+	// triggers a reload.
+	unknownKeyReloadInterval = 10 * time.Second
+	// keySetLoadTimeout bounds a reload triggered while validating a token.
+	keySetLoadTimeout = 5 * time.Second
+
+	pemTypePublicKey  = "PUBLIC KEY"
+	pemTypePrivateKey = "PRIVATE KEY"
+)
+
+// ErrUnknownSigningKey is returned for a token signed by a key that is not in the key set.
+var ErrUnknownSigningKey = errors.New("unknown signing key")
+
+// SigningKey is a key pair that signs catalog JWTs.
+type SigningKey struct {
+	ID        string
+	Algorithm string
+	Private   crypto.Signer
+	CreatedAt time.Time
+	// RetiresAt is when the key stops verifying tokens; nil while it is the signing key.
+	RetiresAt *time.Time
+}
+
+// GenerateSigningKey creates a new key pair for algorithm. The key ID is the
+// RFC 7638 thumbprint of the public key.
+func GenerateSigningKey(algorithm string) (*SigningKey, error) {
+	var (
+		private crypto.Signer
+		err     error
+	)
+	switch algorithm {
+	case SigningAlgRS256:
+		private, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case SigningAlgEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %q (supported: %s, %s)", algorithm, SigningAlgRS256, SigningAlgEdDSA)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+
+	return newSigningKey(algorithm, private)
+}
+
+func newSigningKey(algorithm string, private crypto.Signer) (*SigningKey, error) {
+	thumbprint, err := (&jose.JSONWebKey{Key: private.Public()}).Thumbprint(crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute key ID: %w", err)
+	}
+
+	return &SigningKey{
+		ID:        base64.RawURLEncoding.EncodeToString(thumbprint),
+		Algorithm: algorithm,
+		Private:   private,
+	}, nil
+}
+
+// PublicKeyPEM returns the PEM-encoded PKIX public key.
+func (k *SigningKey) PublicKeyPEM() ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(k.Private.Public())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode public key: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: pemTypePublicKey, Bytes: der}), nil
+}
+
+// PrivateKeyPEM returns the PEM-encoded PKCS #8 private key.
+func (k *SigningKey) PrivateKeyPEM() ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(k.Private)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode private key: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: pemTypePrivateKey, Bytes: der}), nil
+}
+
+// ParseSigningKey parses a PEM-encoded PKCS #8 private key stored for algorithm.
+func ParseSigningKey(algorithm string, keyPEM []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil || block.Type != pemTypePrivateKey {
+		return nil, errors.New("signing key is not a PEM-encoded private key")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key: %w", err)
+	}
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if algorithm == SigningAlgRS256 {
+			return newSigningKey(algorithm, k)
+		}
+	case ed25519.PrivateKey:
+		if algorithm == SigningAlgEdDSA {
+			return newSigningKey(algorithm, k)
+		}
+	}
+
+	return nil, fmt.Errorf("signing key type %T does not match algorithm %q", key, algorithm)
+}
+
+// method returns the JWT signing method of the key.
+func (k *SigningKey) method() jwt.SigningMethod {
+	if k.Algorithm == SigningAlgEdDSA {
+		return jwt.SigningMethodEdDSA
+	}
+
+	return jwt.SigningMethodRS256
+}
+
+// KeySource loads the signing keys that are not retired, newest first.
+type KeySource interface {
+	SigningKeys(ctx context.Context) ([]*SigningKey, error)
+}
+
+// KeySet caches the keys of a KeySource. The newest key without a retirement time
+// signs tokens; every key in the set verifies them.
+type KeySet struct {
+	source KeySource
+
+	mu       sync.Mutex
+	keys     []*SigningKey
+	loadedAt time.Time
+}
+
+// NewKeySet loads the keys of source. It fails when source has no signing key.
+func NewKeySet(ctx context.Context, source KeySource) (*KeySet, error) {
+	s := &KeySet{source: source}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(ctx); err != nil {
+		return nil, err
+	}
+	if s.signingKeyLocked() == nil {
+		return nil, errors.New("no active signing key")
+	}
+
+	return s, nil
+}
+
+// load replaces the cached keys. The caller must hold the lock.
+func (s *KeySet) load(ctx context.Context) error {
+	keys, err := s.source.SigningKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load signing keys: %w", err)
+	}
+	s.keys, s.loadedAt = keys, time.Now()
+
+	return nil
+}
+
+// refreshLocked reloads the keys when they are older than keySetRefreshInterval, or
+// unconditionally when force is set. A failed reload keeps the cached keys.
+// The caller must hold the lock.
+func (s *KeySet) refreshLocked(force bool) {
+	if !force && time.Since(s.loadedAt) < keySetRefreshInterval {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), keySetLoadTimeout)
+	defer cancel()
+	if err := s.load(ctx); err != nil {
+		// Retry on the next call rather than on every one.
+		s.loadedAt = time.Now()
+	}
+}
+
+func (s *KeySet) signingKeyLocked() *SigningKey {
+	for _, k := range s.keys {
+		if k.RetiresAt == nil {
+			return k
+		}
+	}
+
+	return nil
+}
+
+// SigningKey returns the key that signs new tokens.
+func (s *KeySet) SigningKey() (*SigningKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.refreshLocked(false)
+	if k := s.signingKeyLocked(); k != nil {
+		return k, nil
+	}
+
+	return nil, errors.New("no active signing key")
+}
+
+// VerificationKey returns the public key with ID kid. An unknown key ID triggers a
+// reload, at most once per unknownKeyReloadInterval.
+func (s *KeySet) VerificationKey(kid string) (crypto.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.refreshLocked(false)
+	if k := s.findLocked(kid); k != nil {
+		return k.Private.Public(), nil
+	}
+	if time.Since(s.loadedAt) >= unknownKeyReloadInterval {
+		s.refreshLocked(true)
+		if k := s.findLocked(kid); k != nil {
+			return k.Private.Public(), nil
+		}
+	}
+
+	return nil, ErrUnknownSigningKey
+}
+
+func (s *KeySet) findLocked(kid string) *SigningKey {
+	for _, k := range s.keys {
+		if k.ID == kid && k.active() {
+			return k
+		}
+	}
+
+	return nil
+}
+
+// active reports whether the key has not been retired yet.
+func (k *SigningKey) active() bool {
+	return k.RetiresAt == nil || time.Now().Before(*k.RetiresAt)
+}
+
+// JWKS returns the public keys of the set as a JSON Web Key Set.
+func (s *KeySet) JWKS() jose.JSONWebKeySet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.refreshLocked(false)
+	set := jose.JSONWebKeySet{Keys: make([]jose.JSONWebKey, 0, len(s.keys))}
+	for _, k := range s.keys {
+		if !k.active() {
+			continue
+		}
+		set.Keys = append(set.Keys, jose.JSONWebKey{
+			Key:       k.Private.Public(),
+			KeyID:     k.ID,
+			Algorithm: k.Algorithm,
+			Use:       "sig",
+		})
+	}
+
+	return set
+}
+
+// Made with Bob
@@ -0,0 +1,200 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+)
+
+// memoryKeySource is a KeySource shared by the key sets of several "replicas".
+type memoryKeySource struct {
+	mu   sync.Mutex
+	keys []*SigningKey
+}
+
+func (m *memoryKeySource) SigningKeys(_ context.Context) ([]*SigningKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]*SigningKey(nil), m.keys...), nil
+}
+
+// rotate adds a new signing key and retires the current ones at retiresAt.
+func (m *memoryKeySource) rotate(t *testing.T, algorithm string, retiresAt time.Time) *SigningKey {
+	t.Helper()
+	key, err := GenerateSigningKey(algorithm)
+	require.NoError(t, err)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, k := range m.keys {
+		if k.RetiresAt == nil {
+			k.RetiresAt = &retiresAt
+		}
+	}
+	m.keys = append([]*SigningKey{key}, m.keys...)
+
+	return key
+}
+
+func newKeySetTokenManager(t *testing.T, source KeySource, legacySecret string) (*TokenManager, *KeySet) {
+	t.Helper()
+	keys, err := NewKeySet(context.Background(), source)
+	require.NoError(t, err)
+
+	return NewKeySetTokenManager(keys, legacySecret, time.Now(), 15*time.Minute, 24*time.Hour), keys
+}
+
+func TestKeySetTokenManager_SignsWithKeyID(t *testing.T) {
+	for _, alg := range []string{SigningAlgRS256, SigningAlgEdDSA} {
+		t.Run(alg, func(t *testing.T) {
+			source := &memoryKeySource{}
+			key := source.rotate(t, alg, time.Time{})
+			tm, _ := newKeySetTokenManager(t, source, "")
+
+			access, _, err := tm.GenerateAccessToken("1", models.RoleOperator)
+			require.NoError(t, err)
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(access, jwt.MapClaims{})
+			require.NoError(t, err)
+			assert.Equal(t, alg, parsed.Method.Alg())
+			assert.Equal(t, key.ID, parsed.Header["kid"])
+
+			id, err := tm.ValidateAccessToken(access)
+			require.NoError(t, err)
+			assert.Equal(t, "1", id.UserID)
+			assert.Equal(t, models.RoleOperator, id.Role)
+
+			jwks := tm.JWKS()
+			require.Len(t, jwks.Keys, 1)
+			assert.Equal(t, key.ID, jwks.Keys[0].KeyID)
+			assert.True(t, jwks.Keys[0].IsPublic())
+		})
+	}
+}
+
+func TestKeySetTokenManager_Rotation(t *testing.T) {
+	source := &memoryKeySource{}
+	oldKey := source.rotate(t, SigningAlgRS256, time.Time{})
+	replica1, keys1 := newKeySetTokenManager(t, source, "")
+	replica2, keys2 := newKeySetTokenManager(t, source, "")
+
+	oldToken, _, err := replica1.GenerateAccessToken("1", models.RoleViewer)
+	require.NoError(t, err)
+
+	retiresAt := time.Now().Add(time.Hour)
+	newKey := source.rotate(t, SigningAlgEdDSA, retiresAt)
+	// replica1 reaches its refresh interval and signs with the new key.
+	keys1.loadedAt = time.Time{}
+	newToken, _, err := replica1.GenerateAccessToken("1", models.RoleViewer)
+	require.NoError(t, err)
+	parsed, _, err := jwt.NewParser().ParseUnverified(newToken, jwt.MapClaims{})
+	require.NoError(t, err)
+	assert.Equal(t, newKey.ID, parsed.Header["kid"])
+
+	// replica2 still has the old key set; the unknown key ID triggers a reload.
+	keys2.loadedAt = time.Now().Add(-unknownKeyReloadInterval)
+	_, err = replica2.ValidateAccessToken(newToken)
+	require.NoError(t, err)
+
+	// The retiring key verifies existing tokens until it retires.
+	_, err = replica2.ValidateAccessToken(oldToken)
+	require.NoError(t, err)
+	assert.Len(t, replica2.JWKS().Keys, 2)
+
+	past := time.Now().Add(-time.Second)
+	oldKey.RetiresAt = &past
+	_, err = replica2.ValidateAccessToken(oldToken)
+	assert.ErrorIs(t, err, ErrUnknownSigningKey)
+	assert.Len(t, replica2.JWKS().Keys, 1)
+}
+
+func TestKeySetTokenManager_LegacySecret(t *testing.T) {
+	source := &memoryKeySource{}
+	source.rotate(t, SigningAlgRS256, time.Time{})
+
+	legacy, _, err := NewTokenManager("legacy-secret", time.Minute, time.Hour).GenerateAccessToken("1", models.RoleAdmin)
+	require.NoError(t, err)
+
+	withSecret, _ := newKeySetTokenManager(t, source, "legacy-secret")
+	_, err = withSecret.ValidateAccessToken(legacy)
+	assert.NoError(t, err)
+
+	withoutSecret, _ := newKeySetTokenManager(t, source, "")
+	_, err = withoutSecret.ValidateAccessToken(legacy)
+	assert.Error(t, err)
+}
+
+func TestKeySetTokenManager_LegacySecretWindow(t *testing.T) {
+	source := &memoryKeySource{}
+	source.rotate(t, SigningAlgRS256, time.Time{})
+	keys, err := NewKeySet(context.Background(), source)
+	require.NoError(t, err)
+	legacyToken := func(issuedAt time.Time) string {
+		claims := jwt.MapClaims{"uid": "1", "role": "admin", "aud": "access", "iat": issuedAt.Unix(), "exp": time.Now().Add(time.Hour).Unix()}
+		raw, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("legacy-secret"))
+		require.NoError(t, err)
+
+		return raw
+	}
+	created := time.Now().Add(-30 * time.Hour)
+	before := legacyToken(created.Add(-time.Hour))
+
+	tm := NewKeySetTokenManager(keys, "legacy-secret", created, 15*time.Minute, 48*time.Hour)
+	_, err = tm.ValidateAccessToken(before)
+	assert.NoError(t, err, "tokens issued before the key set are accepted within one refresh TTL")
+	_, err = tm.ValidateAccessToken(legacyToken(created.Add(time.Hour)))
+	assert.Error(t, err, "tokens minted with the secret after the switch must be rejected")
+
+	expired := NewKeySetTokenManager(keys, "legacy-secret", created, 15*time.Minute, 24*time.Hour)
+	_, err = expired.ValidateAccessToken(before)
+	assert.Error(t, err, "no HS256 token is accepted once the refresh TTL has passed")
+
+	assert.False(t, LegacySecretExpired(created, 48*time.Hour))
+	assert.True(t, LegacySecretExpired(created, 24*time.Hour))
+}
+
+func TestKeySetTokenManager_RejectsPublicKeyAsHMACSecret(t *testing.T) {
+	source := &memoryKeySource{}
+	key := source.rotate(t, SigningAlgRS256, time.Time{})
+	tm, _ := newKeySetTokenManager(t, source, "")
+
+	publicPEM, err := key.PublicKeyPEM()
+	require.NoError(t, err)
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"uid": "1", "aud": "access", "exp": time.Now().Add(time.Hour).Unix()})
+	forged.Header["kid"] = key.ID
+	raw, err := forged.SignedString(publicPEM)
+	require.NoError(t, err)
+
+	_, err = tm.ValidateAccessToken(raw)
+	assert.Error(t, err)
+}
+
+func TestParseSigningKey(t *testing.T) {
+	key, err := GenerateSigningKey(SigningAlgEdDSA)
+	require.NoError(t, err)
+	keyPEM, err := key.PrivateKeyPEM()
+	require.NoError(t, err)
+
+	parsed, err := ParseSigningKey(SigningAlgEdDSA, keyPEM)
+	require.NoError(t, err)
+	assert.Equal(t, key.ID, parsed.ID)
+
+	_, err = ParseSigningKey(SigningAlgRS256, keyPEM)
+	assert.Error(t, err)
+
+	_, err = GenerateSigningKey("HS256")
+	assert.Error(t, err)
+}
+
+func TestNewKeySet_RequiresSigningKey(t *testing.T) {
+	_, err := NewKeySet(context.Background(), &memoryKeySource{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	dbmodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	dbrepository "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	catalogutils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+// DBKeySource stores signing keys in the database, shared by every control-plane
+// replica. Private keys are encrypted with the DB encryption keys.
+type DBKeySource struct {
+	repo dbrepository.SigningKeyRepository
+	keys *catalogutils.Keyring
+}
+
+// NewDBKeySource creates a key source backed by repo.
+func NewDBKeySource(repo dbrepository.SigningKeyRepository, keys *catalogutils.Keyring) (*DBKeySource, error) {
+	if keys == nil {
+		return nil, errors.New("an encryption key is required to protect the JWT signing keys")
+	}
+
+	return &DBKeySource{repo: repo, keys: keys}, nil
+}
+
+// SigningKeys returns the keys that are not retired, newest first.
+func (s *DBKeySource) SigningKeys(ctx context.Context) ([]*SigningKey, error) {
+	stored, err := s.repo.ListActive(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*SigningKey, 0, len(stored))
+	for _, row := range stored {
+		keyPEM, err := s.keys.Decrypt(row.KeyPEMEncrypted)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt signing key %s: %w", row.KID, err)
+		}
+		key, err := ParseSigningKey(row.Algorithm, []byte(keyPEM))
+		if err != nil {
+			return nil, fmt.Errorf("signing key %s: %w", row.KID, err)
+		}
+		key.CreatedAt, key.RetiresAt = row.CreatedAt, row.RetiresAt
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// CreatedAt returns when the first signing key was stored, which is when the
+// catalog stopped issuing HS256 tokens.
+func (s *DBKeySource) CreatedAt(ctx context.Context) (time.Time, error) {
+	return s.repo.KeySetCreatedAt(ctx)
+}
+
+// EnsureSigningKey generates and stores a key for algorithm when there is no
+// signing key yet, e.g. on first start.
+func (s *DBKeySource) EnsureSigningKey(ctx context.Context, algorithm string) error {
+	keys, err := s.repo.ListActive(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		if k.RetiresAt == nil {
+			return nil
+		}
+	}
+
+	key, err := GenerateSigningKey(algorithm)
+	if err != nil {
+		return err
+	}
+	row, err := s.encode(key)
+	if err != nil {
+		return err
+	}
+	inserted, err := s.repo.InsertFirst(ctx, row)
+	if err != nil {
+		return err
+	}
+	if inserted {
+		logger.InfofCtx(ctx, "Generated a new %s JWT signing key %s", algorithm, key.ID)
+	}
+
+	return nil
+}
+
+// Rotate stores a new key for algorithm that signs tokens from now on. The previous
+// keys keep verifying tokens for retireAfter, which should be at least the refresh
+// token TTL so no session is cut short. Keys retired earlier are deleted.
+func (s *DBKeySource) Rotate(ctx context.Context, algorithm string, retireAfter time.Duration) (*SigningKey, error) {
+	key, err := GenerateSigningKey(algorithm)
+	if err != nil {
+		return nil, err
+	}
+	row, err := s.encode(key)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := s.repo.Rotate(ctx, row, now.Add(retireAfter)); err != nil {
+		return nil, err
+	}
+	key.CreatedAt = row.CreatedAt
+
+	deleted, err := s.repo.DeleteRetired(ctx, now)
+	if err != nil {
+		// The new key is already in place; retired keys are ignored until the next rotation.
+		logger.WarningfCtx(ctx, "failed to delete retired signing keys: %v", err)
+	} else if deleted > 0 {
+		logger.InfofCtx(ctx, "Deleted %d retired signing key(s)", deleted)
+	}
+
+	return key, nil
+}
+
+// encode converts key to its stored form.
+func (s *DBKeySource) encode(key *SigningKey) (*dbmodels.SigningKey, error) {
+	publicPEM, err := key.PublicKeyPEM()
+	if err != nil {
+		return nil, err
+	}
+	privatePEM, err := key.PrivateKeyPEM()
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := s.keys.Encrypt(string(privatePEM))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt signing key: %w", err)
+	}
+
+	return &dbmodels.SigningKey{
+		KID:             key.ID,
+		Algorithm:       key.Algorithm,
+		PublicKeyPEM:    string(publicPEM),
+		KeyPEMEncrypted: encrypted,
+	}, nil
+}
+
+// Made with Bob
@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/oidc"
+)
+
+// oidcUserIDPrefix prefixes the OIDC subject in the catalog user ID so it cannot
+// collide with local or ManageIQ user IDs.
+const oidcUserIDPrefix = "oidc:"
+
+// OIDCProvider is the identity provider used by OIDCService; *oidc.Provider implements it.
+type OIDCProvider interface {
+	AuthCodeURL(state, nonce, verifier string) string
+	Exchange(ctx context.Context, code, verifier, nonce string) (*oidc.Identity, error)
+	SupportsDeviceFlow() bool
+	StartDeviceAuth(ctx context.Context) (*oidc.DeviceAuthorization, error)
+	PollDeviceToken(ctx context.Context, deviceCode string) (*oidc.Identity, error)
+}
+
+// OIDCService signs users in through an OpenID Connect provider and issues the
+// same internal JWT pair as password login.
+type OIDCService interface {
+	// AuthCodeURL returns the provider URL that starts a browser login.
+	AuthCodeURL(state, nonce, verifier string) string
+	// LoginWithCode completes a browser login with the authorization code from the callback.
+	LoginWithCode(ctx context.Context, code, verifier, nonce string) (accessToken, refreshToken string, err error)
+	// StartDeviceLogin starts a device login for the CLI. It returns oidc.ErrDeviceNotSupported
+	// when the provider has no device authorization endpoint.
+	StartDeviceLogin(ctx context.Context) (*oidc.DeviceAuthorization, error)
+	// LoginWithDeviceCode polls the provider once for deviceCode; it returns
+	// oidc.ErrAuthorizationPending or oidc.ErrSlowDown until the user has signed in.
+	LoginWithDeviceCode(ctx context.Context, deviceCode string) (accessToken, refreshToken string, err error)
+}
+
+type oidcService struct {
+	users      repository.UserRepository
+	tokens     *TokenManager
+	provider   OIDCProvider
+	groupRoles GroupRoles
+}
+
+// NewOIDCService creates an OIDC login service. groupRoles maps the groups claim of
+// the ID token to a catalog role; users in no mapped group are viewers.
+func NewOIDCService(users repository.UserRepository, tokens *TokenManager, provider OIDCProvider, groupRoles GroupRoles) OIDCService {
+	return &oidcService{users: users, tokens: tokens, provider: provider, groupRoles: groupRoles}
+}
+
+func (s *oidcService) AuthCodeURL(state, nonce, verifier string) string {
+	return s.provider.AuthCodeURL(state, nonce, verifier)
+}
+
+func (s *oidcService) LoginWithCode(ctx context.Context, code, verifier, nonce string) (string, string, error) {
+	id, err := s.provider.Exchange(ctx, code, verifier, nonce)
+	if err != nil {
+		return "", "", err
+	}
+
+	return s.login(ctx, id)
+}
+
+func (s *oidcService) StartDeviceLogin(ctx context.Context) (*oidc.DeviceAuthorization, error) {
+	if !s.provider.SupportsDeviceFlow() {
+		return nil, oidc.ErrDeviceNotSupported
+	}
+
+	return s.provider.StartDeviceAuth(ctx)
+}
+
+func (s *oidcService) LoginWithDeviceCode(ctx context.Context, deviceCode string) (string, string, error) {
+	if deviceCode == "" {
+		return "", "", errors.New("device code is required")
+	}
+	id, err := s.provider.PollDeviceToken(ctx, deviceCode)
+	if err != nil {
+		return "", "", err
+	}
+
+	return s.login(ctx, id)
+}
+
+// login records the OIDC user and issues a token pair, as LoginWithToken does for
+// ManageIQ users. The role is mapped from the groups claim on every login.
+func (s *oidcService) login(ctx context.Context, id *oidc.Identity) (string, string, error) {
+	role := s.groupRoles.RoleFor(id.Groups)
+	u := &models.User{
+		ID:       oidcUserIDPrefix + id.Subject,
+		UserName: id.Username,
+		Name:     id.Name,
+		Role:     role,
+	}
+	if err := recordUser(ctx, s.users, u); err != nil {
+		return "", "", err
+	}
+	if u.Disabled {
+		return "", "", ErrUserDisabled
+	}
+
+	return issueTokens(s.tokens, u.ID, role)
+}
+
+// Made with Bob
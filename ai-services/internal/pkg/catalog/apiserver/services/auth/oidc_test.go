@@ -0,0 +1,107 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/auth"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/oidc"
+)
+
+type stubOIDCProvider struct {
+	identity *oidc.Identity
+	err      error
+	device   bool
+}
+
+func (s *stubOIDCProvider) AuthCodeURL(state, _, _ string) string {
+	return "https://idp.example.com/authorize?state=" + state
+}
+
+func (s *stubOIDCProvider) Exchange(_ context.Context, _, _, _ string) (*oidc.Identity, error) {
+	return s.identity, s.err
+}
+
+func (s *stubOIDCProvider) SupportsDeviceFlow() bool { return s.device }
+
+func (s *stubOIDCProvider) StartDeviceAuth(_ context.Context) (*oidc.DeviceAuthorization, error) {
+	return &oidc.DeviceAuthorization{DeviceCode: "dev", UserCode: "ABCD"}, nil
+}
+
+func (s *stubOIDCProvider) PollDeviceToken(_ context.Context, _ string) (*oidc.Identity, error) {
+	return s.identity, s.err
+}
+
+func newOIDCService(provider auth.OIDCProvider) (auth.OIDCService, *auth.TokenManager, *repository.InMemoryUserRepo) {
+	tokenMgr := auth.NewTokenManager("test-secret-32-bytes-long-enough!", 15*60*1000000000, 24*3600*1000000000)
+	users := repository.NewInMemoryUserRepo()
+	groupRoles := auth.GroupRoles{"ai-ops": models.RoleOperator}
+
+	return auth.NewOIDCService(users, tokenMgr, provider, groupRoles), tokenMgr, users
+}
+
+func TestOIDCLoginWithCode_RecordsUser(t *testing.T) {
+	provider := &stubOIDCProvider{identity: &oidc.Identity{Subject: "abc", Username: "jdoe", Name: "J Doe", Groups: []string{"ai-ops"}}}
+	svc, tokenMgr, users := newOIDCService(provider)
+
+	access, refresh, err := svc.LoginWithCode(context.Background(), "code", "verifier", "nonce")
+	require.NoError(t, err)
+	assert.NotEmpty(t, refresh)
+
+	id, err := tokenMgr.ValidateAccessToken(access)
+	require.NoError(t, err)
+	assert.Equal(t, "oidc:abc", id.UserID)
+	assert.Equal(t, models.RoleOperator, id.Role)
+
+	u, err := users.GetByID(context.Background(), "oidc:abc")
+	require.NoError(t, err)
+	assert.Equal(t, "jdoe", u.UserName)
+	assert.Equal(t, "J Doe", u.Name)
+}
+
+func TestOIDCLoginWithCode_ProviderError(t *testing.T) {
+	svc, _, _ := newOIDCService(&stubOIDCProvider{err: oidc.ErrInvalidIDToken})
+
+	_, _, err := svc.LoginWithCode(context.Background(), "code", "verifier", "nonce")
+	assert.ErrorIs(t, err, oidc.ErrInvalidIDToken)
+}
+
+func TestOIDCLogin_DisabledUser(t *testing.T) {
+	provider := &stubOIDCProvider{identity: &oidc.Identity{Subject: "abc", Username: "jdoe"}, device: true}
+	svc, _, users := newOIDCService(provider)
+	require.NoError(t, users.Upsert(context.Background(), &models.User{ID: "oidc:abc", UserName: "jdoe"}))
+	require.NoError(t, users.SetDisabled(context.Background(), "oidc:abc", true))
+
+	_, _, err := svc.LoginWithDeviceCode(context.Background(), "dev")
+	assert.ErrorIs(t, err, auth.ErrUserDisabled)
+}
+
+func TestOIDCStartDeviceLogin(t *testing.T) {
+	svc, _, _ := newOIDCService(&stubOIDCProvider{})
+
+	_, err := svc.StartDeviceLogin(context.Background())
+	assert.ErrorIs(t, err, oidc.ErrDeviceNotSupported)
+
+	svc, _, _ = newOIDCService(&stubOIDCProvider{device: true})
+	device, err := svc.StartDeviceLogin(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ABCD", device.UserCode)
+}
+
+func TestOIDCLogin_UsernameTaken(t *testing.T) {
+	provider := &stubOIDCProvider{identity: &oidc.Identity{Subject: "abc", Username: "admin"}}
+	svc, _, users := newOIDCService(provider)
+	require.NoError(t, users.Upsert(context.Background(), &models.User{ID: "1", UserName: "admin"}))
+
+	access, _, err := svc.LoginWithCode(context.Background(), "code", "verifier", "nonce")
+	assert.ErrorIs(t, err, auth.ErrUsernameTaken)
+	assert.Empty(t, access, "no tokens may be issued for a user that was not recorded")
+
+	_, err = users.GetByID(context.Background(), "oidc:abc")
+	assert.ErrorIs(t, err, repository.ErrUserNotFound)
+}
@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"fmt"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+)
+
+// GroupRoles maps ManageIQ group descriptions to catalog roles.
+type GroupRoles map[string]models.Role
+
+// DefaultMIQGroupRoles maps the built-in ManageIQ groups. Members of any other group
+// are viewers.
+var DefaultMIQGroupRoles = GroupRoles{
+	"EvmGroup-super_administrator": models.RoleAdmin,
+	"EvmGroup-administrator":       models.RoleAdmin,
+	"EvmGroup-operator":            models.RoleOperator,
+}
+
+// RoleFor returns the most privileged role mapped from groups, or RoleViewer when
+// none of them is mapped.
+func (g GroupRoles) RoleFor(groups []string) models.Role {
+	role := models.RoleViewer
+	for _, group := range groups {
+		if r, ok := g[group]; ok {
+			role = role.HigherOf(r)
+		}
+	}
+
+	return role
+}
+
+// ParseGroupRoles validates a group=role mapping, e.g. from the --manageiq-group-roles flag.
+func ParseGroupRoles(m map[string]string) (GroupRoles, error) {
+	roles := make(GroupRoles, len(m))
+	for group, name := range m {
+		role, err := models.ParseRole(name)
+		if err != nil {
+			return nil, fmt.Errorf("group %q: %w", group, err)
+		}
+		roles[group] = role
+	}
+
+	return roles, nil
+}
+
+// Made with Bob
@@ -17,6 +17,7 @@ import (
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository"
 	catalogconstants "github.com/project-ai-services/ai-services/internal/pkg/catalog/constants"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/miq"
+	catalogutils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
 	"github.com/project-ai-services/ai-services/internal/pkg/constants"
 )
 
@@ -32,13 +33,16 @@ type Service interface {
 	Logout(ctx context.Context, accessToken, refreshToken string) error
 	RefreshTokens(ctx context.Context, refreshToken string) (newAccess, newRefresh string, err error)
 	GetUser(ctx context.Context, id string) (*models.User, error)
+	// ChangePassword replaces the password of the user after verifying the current one.
+	ChangePassword(ctx context.Context, userID, currentPassword, newPassword string) error
 }
 
 type service struct {
-	users     repository.UserRepository
-	tokens    *TokenManager
-	blacklist repository.TokenBlacklist
-	miqClient miq.Client
+	users      repository.UserRepository
+	tokens     *TokenManager
+	blacklist  repository.TokenBlacklist
+	miqClient  miq.Client
+	groupRoles GroupRoles
 }
 
 // NewAuthService creates an auth service without a ManageIQ client (local password mode).
@@ -47,12 +51,26 @@ func NewAuthService(users repository.UserRepository, tokens *TokenManager, black
 }
 
 // NewAuthServiceWithMIQ creates an auth service backed by ManageIQ for token passthrough.
-func NewAuthServiceWithMIQ(users repository.UserRepository, tokens *TokenManager, blacklist repository.TokenBlacklist, miqClient miq.Client) Service {
-	return &service{users: users, tokens: tokens, blacklist: blacklist, miqClient: miqClient}
+// groupRoles maps the caller's ManageIQ groups to a catalog role; nil uses DefaultMIQGroupRoles.
+func NewAuthServiceWithMIQ(users repository.UserRepository, tokens *TokenManager, blacklist repository.TokenBlacklist, miqClient miq.Client, groupRoles GroupRoles) Service {
+	if groupRoles == nil {
+		groupRoles = DefaultMIQGroupRoles
+	}
+
+	return &service{users: users, tokens: tokens, blacklist: blacklist, miqClient: miqClient, groupRoles: groupRoles}
 }
 
-var ErrInvalidCredentials = errors.New("invalid credentials")
+var (
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	// ErrUserDisabled is returned when a disabled user logs in or refreshes tokens.
+	ErrUserDisabled = errors.New("user is disabled")
+	// ErrUsernameTaken is returned when a user signing in through an external identity
+	// provider cannot be recorded because another user already has the username.
+	ErrUsernameTaken = errors.New("username is already used by another user")
+)
 
+// Login verifies the password of username and issues a token pair. A disabled user
+// is only reported as such once the password has been verified.
 func (s *service) Login(ctx context.Context, username, password string) (string, string, error) {
 	u, err := s.users.GetByUserName(ctx, username)
 	if err != nil {
@@ -61,11 +79,24 @@ func (s *service) Login(ctx context.Context, username, password string) (string,
 	if !verifyPassword(password, u.PasswordHash) {
 		return "", "", ErrInvalidCredentials
 	}
-	access, _, err := s.tokens.GenerateAccessToken(u.ID)
+	if u.Disabled {
+		return "", "", ErrUserDisabled
+	}
+
+	return s.issueTokens(u.ID, u.Role)
+}
+
+// issueTokens generates an access and refresh token pair for uid with role.
+func (s *service) issueTokens(uid string, role models.Role) (string, string, error) {
+	return issueTokens(s.tokens, uid, role)
+}
+
+func issueTokens(tokens *TokenManager, uid string, role models.Role) (string, string, error) {
+	access, _, err := tokens.GenerateAccessToken(uid, role)
 	if err != nil {
 		return "", "", err
 	}
-	refresh, _, err := s.tokens.GenerateRefreshToken(u.ID)
+	refresh, _, err := tokens.GenerateRefreshToken(uid, role)
 	if err != nil {
 		return "", "", err
 	}
@@ -74,7 +105,8 @@ func (s *service) Login(ctx context.Context, username, password string) (string,
 }
 
 // LoginWithToken validates a ManageIQ token by calling the MIQ API, resolves the caller's
-// identity and group membership, and issues an internal Catalog API JWT pair.
+// identity and group membership, and issues an internal Catalog API JWT pair. The role
+// in the tokens is mapped from the caller's ManageIQ groups.
 // This is Flow B: used by IBM Power Mission Control which already holds a MIQ token.
 func (s *service) LoginWithToken(ctx context.Context, miqToken string) (string, string, error) {
 	if s.miqClient == nil {
@@ -86,26 +118,39 @@ func (s *service) LoginWithToken(ctx context.Context, miqToken string) (string,
 		return "", "", err
 	}
 
-	// Seed the in-memory user repo so /auth/me works after token exchange.
+	role := s.groupRoles.RoleFor(info.Groups)
+
+	// Record the user so /auth/me works after token exchange and an admin can disable it.
 	// ID must match the JWT subject (info.ExternalID) so GetByID resolves correctly.
-	if repo, ok := s.users.(*repository.InMemoryUserRepo); ok {
-		repo.Upsert(&models.User{
-			ID:       info.ExternalID,
-			UserName: info.UserName,
-			Name:     info.FullName,
-		})
+	u := &models.User{
+		ID:       info.ExternalID,
+		UserName: info.UserName,
+		Name:     info.FullName,
+		Role:     role,
 	}
-
-	access, _, err := s.tokens.GenerateAccessToken(info.ExternalID)
-	if err != nil {
+	if err := recordUser(ctx, s.users, u); err != nil {
 		return "", "", err
 	}
-	refresh, _, err := s.tokens.GenerateRefreshToken(info.ExternalID)
-	if err != nil {
-		return "", "", err
+	if u.Disabled {
+		return "", "", ErrUserDisabled
 	}
 
-	return access, refresh, nil
+	return s.issueTokens(info.ExternalID, role)
+}
+
+// recordUser adds or updates a user signing in through an external identity provider.
+// Tokens must not be issued when it fails: without the row, the disabled flag cannot
+// be checked and the tokens cannot be refreshed.
+func recordUser(ctx context.Context, users repository.UserRepository, u *models.User) error {
+	err := users.Upsert(ctx, u)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, repository.ErrUserExists):
+		return fmt.Errorf("%w: %q", ErrUsernameTaken, u.UserName)
+	default:
+		return fmt.Errorf("failed to record user %q: %w", u.UserName, err)
+	}
 }
 
 // Logout invalidates both the refresh token and access token by adding them to the blacklist until their
@@ -114,16 +159,16 @@ func (s *service) LoginWithToken(ctx context.Context, miqToken string) (string,
 func (s *service) Logout(ctx context.Context, accessToken, refreshToken string) error {
 	// If refresh token exists, try to validate and blacklist it
 	if refreshToken != "" {
-		_, refreshExp, err := s.tokens.ValidateRefreshToken(refreshToken)
+		refreshID, err := s.tokens.ValidateRefreshToken(refreshToken)
 		if err == nil {
-			s.blacklist.Add(ctx, refreshToken, catalogconstants.TokenTypeRefresh, refreshExp)
+			s.blacklist.Add(ctx, refreshToken, catalogconstants.TokenTypeRefresh, refreshID.ExpiresAt)
 		}
 	}
 
 	// validate and blacklist access token
-	_, accessExp, err := s.tokens.ValidateAccessToken(accessToken)
+	accessID, err := s.tokens.ValidateAccessToken(accessToken)
 	if err == nil {
-		s.blacklist.Add(ctx, accessToken, catalogconstants.TokenTypeAccess, accessExp)
+		s.blacklist.Add(ctx, accessToken, catalogconstants.TokenTypeAccess, accessID.ExpiresAt)
 	}
 
 	return nil
@@ -131,25 +176,26 @@ func (s *service) Logout(ctx context.Context, accessToken, refreshToken string)
 
 // RefreshTokens validates the provided refresh token and, if valid, generates and returns a new access token
 // and refresh token pair. It also blacklists the old refresh token to prevent reuse.
+// The new tokens carry the user's current role, so a role change takes effect at the
+// next refresh. Deleted and disabled users cannot refresh.
 func (s *service) RefreshTokens(ctx context.Context, refreshToken string) (string, string, error) {
-	uid, exp, err := s.tokens.ValidateRefreshToken(refreshToken)
+	id, err := s.tokens.ValidateRefreshToken(refreshToken)
 	if err != nil {
 		return "", "", err
 	}
 
 	// Blacklist the old refresh token to prevent reuse
-	s.blacklist.Add(ctx, refreshToken, catalogconstants.TokenTypeRefresh, exp)
+	s.blacklist.Add(ctx, refreshToken, catalogconstants.TokenTypeRefresh, id.ExpiresAt)
 
-	access, _, err := s.tokens.GenerateAccessToken(uid)
+	u, err := s.users.GetByID(ctx, id.UserID)
 	if err != nil {
 		return "", "", err
 	}
-	newRefresh, _, err := s.tokens.GenerateRefreshToken(uid)
-	if err != nil {
-		return "", "", err
+	if u.Disabled {
+		return "", "", ErrUserDisabled
 	}
 
-	return access, newRefresh, nil
+	return s.issueTokens(u.ID, u.Role)
 }
 
 // GetUser retrieves a user by their unique ID. This can be used in various contexts, such as fetching user details.
@@ -157,6 +203,28 @@ func (s *service) GetUser(ctx context.Context, id string) (*models.User, error)
 	return s.users.GetByID(ctx, id)
 }
 
+// ChangePassword verifies currentPassword and replaces it with newPassword.
+// It returns ErrInvalidCredentials if currentPassword is wrong.
+func (s *service) ChangePassword(ctx context.Context, userID, currentPassword, newPassword string) error {
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !verifyPassword(currentPassword, u.PasswordHash) {
+		return ErrInvalidCredentials
+	}
+	if err := catalogutils.ValidatePassword(newPassword); err != nil {
+		return err
+	}
+
+	hash, err := catalogutils.HashPasswordPBKDF2(newPassword, catalogutils.DefaultPasswordIterations)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	return s.users.UpdatePassword(ctx, userID, hash)
+}
+
 // GenerateRandomSecretKey generates a random secret key of the specified length for signing JWT tokens.
 func GenerateRandomSecretKey(length int) ([]byte, error) {
 	key := make([]byte, length)
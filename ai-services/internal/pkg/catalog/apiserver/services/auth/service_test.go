@@ -3,14 +3,17 @@ package auth_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/auth"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/miq"
+	catalogutils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
 )
 
 // ---------------------------------------------------------------------------
@@ -34,7 +37,7 @@ func newService(t *testing.T, miqClient miq.Client) auth.Service {
 	t.Helper()
 	tokenMgr := auth.NewTokenManager("test-secret-32-bytes-long-enough!", 15*60*1000000000 /* 15m */, 24*3600*1000000000 /* 24h */)
 	users := repository.NewInMemoryUserRepo()
-	return auth.NewAuthServiceWithMIQ(users, tokenMgr, &repository.NoopTokenBlacklist{}, miqClient)
+	return auth.NewAuthServiceWithMIQ(users, tokenMgr, &repository.NoopTokenBlacklist{}, miqClient, nil)
 }
 
 // ---------------------------------------------------------------------------
@@ -103,7 +106,7 @@ func TestLoginWithToken_UserSeededInRepo(t *testing.T) {
 	}
 	tokenMgr := auth.NewTokenManager("test-secret-32-bytes-long-enough!", 15*60*1000000000, 24*3600*1000000000)
 	users := repository.NewInMemoryUserRepo()
-	svc := auth.NewAuthServiceWithMIQ(users, tokenMgr, &repository.NoopTokenBlacklist{}, stub)
+	svc := auth.NewAuthServiceWithMIQ(users, tokenMgr, &repository.NoopTokenBlacklist{}, stub, nil)
 
 	_, _, err := svc.LoginWithToken(context.Background(), "valid-token")
 	require.NoError(t, err)
@@ -114,4 +117,148 @@ func TestLoginWithToken_UserSeededInRepo(t *testing.T) {
 	assert.Equal(t, "42", u.ID)
 	assert.Equal(t, "operator1", u.UserName)
 	assert.Equal(t, "Op User", u.Name)
+	assert.Equal(t, models.RoleOperator, u.Role)
+}
+
+// ---------------------------------------------------------------------------
+// Role tests
+// ---------------------------------------------------------------------------
+
+func TestLoginWithToken_RoleFromGroupMapping(t *testing.T) {
+	tokenMgr := auth.NewTokenManager("test-secret-32-bytes-long-enough!", 15*60*1000000000, 24*3600*1000000000)
+	groupRoles := auth.GroupRoles{"ai-admins": models.RoleAdmin, "ai-ops": models.RoleOperator}
+
+	for groups, want := range map[string]models.Role{
+		"ai-ops,ai-admins":             models.RoleAdmin,
+		"ai-ops":                       models.RoleOperator,
+		"EvmGroup-super_administrator": models.RoleViewer, // replaced by the custom mapping
+	} {
+		stub := &stubMIQClient{info: &miq.UserInfo{ExternalID: "7", UserName: "u", Groups: strings.Split(groups, ",")}}
+		svc := auth.NewAuthServiceWithMIQ(repository.NewInMemoryUserRepo(), tokenMgr, &repository.NoopTokenBlacklist{}, stub, groupRoles)
+
+		access, _, err := svc.LoginWithToken(context.Background(), "valid-token")
+		require.NoError(t, err)
+
+		id, err := tokenMgr.ValidateAccessToken(access)
+		require.NoError(t, err)
+		assert.Equal(t, want, id.Role, "groups %s", groups)
+	}
+}
+
+func TestRefreshTokens_UsesCurrentRole(t *testing.T) {
+	tokenMgr := auth.NewTokenManager("test-secret-32-bytes-long-enough!", 15*60*1000000000, 24*3600*1000000000)
+	users := repository.NewInMemoryUserRepo()
+	require.NoError(t, users.Upsert(context.Background(), &models.User{ID: "1", UserName: "alice", Role: models.RoleViewer}))
+	svc := auth.NewAuthService(users, tokenMgr, &repository.NoopTokenBlacklist{})
+
+	refresh, _, err := tokenMgr.GenerateRefreshToken("1", models.RoleViewer)
+	require.NoError(t, err)
+
+	// The user is promoted while holding a refresh token.
+	require.NoError(t, users.Upsert(context.Background(), &models.User{ID: "1", UserName: "alice", Role: models.RoleOperator}))
+
+	access, _, err := svc.RefreshTokens(context.Background(), refresh)
+	require.NoError(t, err)
+
+	id, err := tokenMgr.ValidateAccessToken(access)
+	require.NoError(t, err)
+	assert.Equal(t, models.RoleOperator, id.Role)
+}
+
+func TestRefreshTokens_DisabledOrDeletedUser(t *testing.T) {
+	tokenMgr := auth.NewTokenManager("test-secret-32-bytes-long-enough!", 15*60*1000000000, 24*3600*1000000000)
+	users := repository.NewInMemoryUserRepo()
+	require.NoError(t, users.Upsert(context.Background(), &models.User{ID: "1", UserName: "alice", Role: models.RoleViewer}))
+	svc := auth.NewAuthService(users, tokenMgr, &repository.NoopTokenBlacklist{})
+
+	refresh, _, err := tokenMgr.GenerateRefreshToken("1", models.RoleViewer)
+	require.NoError(t, err)
+	require.NoError(t, users.SetDisabled(context.Background(), "1", true))
+
+	_, _, err = svc.RefreshTokens(context.Background(), refresh)
+	assert.ErrorIs(t, err, auth.ErrUserDisabled)
+
+	require.NoError(t, users.Delete(context.Background(), "1"))
+
+	_, _, err = svc.RefreshTokens(context.Background(), refresh)
+	assert.ErrorIs(t, err, repository.ErrUserNotFound)
+}
+
+func TestLoginWithToken_DisabledUser(t *testing.T) {
+	tokenMgr := auth.NewTokenManager("test-secret-32-bytes-long-enough!", 15*60*1000000000, 24*3600*1000000000)
+	users := repository.NewInMemoryUserRepo()
+	require.NoError(t, users.Upsert(context.Background(), &models.User{ID: "42", UserName: "operator1"}))
+	require.NoError(t, users.SetDisabled(context.Background(), "42", true))
+	stub := &stubMIQClient{info: &miq.UserInfo{ExternalID: "42", UserName: "operator1", Groups: []string{"EvmGroup-operator"}}}
+	svc := auth.NewAuthServiceWithMIQ(users, tokenMgr, &repository.NoopTokenBlacklist{}, stub, nil)
+
+	_, _, err := svc.LoginWithToken(context.Background(), "valid-token")
+
+	assert.ErrorIs(t, err, auth.ErrUserDisabled)
+}
+
+func TestLoginWithToken_UsernameTaken(t *testing.T) {
+	tokenMgr := auth.NewTokenManager("test-secret-32-bytes-long-enough!", 15*60*1000000000, 24*3600*1000000000)
+	users := repository.NewInMemoryUserRepo()
+	require.NoError(t, users.Upsert(context.Background(), &models.User{ID: "local-1", UserName: "operator1"}))
+	stub := &stubMIQClient{info: &miq.UserInfo{ExternalID: "42", UserName: "operator1", Groups: []string{"EvmGroup-operator"}}}
+	svc := auth.NewAuthServiceWithMIQ(users, tokenMgr, &repository.NoopTokenBlacklist{}, stub, nil)
+
+	access, _, err := svc.LoginWithToken(context.Background(), "valid-token")
+
+	assert.ErrorIs(t, err, auth.ErrUsernameTaken)
+	assert.Empty(t, access, "no tokens may be issued for a user that was not recorded")
+}
+
+// ---------------------------------------------------------------------------
+// Password tests
+// ---------------------------------------------------------------------------
+
+func newPasswordService(t *testing.T) (auth.Service, *repository.InMemoryUserRepo) {
+	t.Helper()
+	tokenMgr := auth.NewTokenManager("test-secret-32-bytes-long-enough!", 15*60*1000000000, 24*3600*1000000000)
+	hash, err := catalogutils.HashPasswordPBKDF2("old-password", catalogutils.DefaultPasswordIterations)
+	require.NoError(t, err)
+	users := repository.NewInMemoryUserRepoWithAdminHash("1", "alice", "Alice", hash)
+
+	return auth.NewAuthService(users, tokenMgr, &repository.NoopTokenBlacklist{}), users
+}
+
+func TestLogin_DisabledUser(t *testing.T) {
+	svc, users := newPasswordService(t)
+	require.NoError(t, users.SetDisabled(context.Background(), "1", true))
+
+	_, _, err := svc.Login(context.Background(), "alice", "wrong-password")
+	assert.ErrorIs(t, err, auth.ErrInvalidCredentials, "a wrong password must not reveal that the user is disabled")
+
+	_, _, err = svc.Login(context.Background(), "alice", "old-password")
+	assert.ErrorIs(t, err, auth.ErrUserDisabled)
+}
+
+func TestChangePassword(t *testing.T) {
+	svc, _ := newPasswordService(t)
+	ctx := context.Background()
+
+	assert.ErrorIs(t, svc.ChangePassword(ctx, "1", "wrong-password", "new-password"), auth.ErrInvalidCredentials)
+
+	var pwErr *catalogutils.PasswordError
+	assert.ErrorAs(t, svc.ChangePassword(ctx, "1", "old-password", "short"), &pwErr)
+
+	require.NoError(t, svc.ChangePassword(ctx, "1", "old-password", "new-password"))
+
+	_, _, err := svc.Login(ctx, "alice", "old-password")
+	assert.ErrorIs(t, err, auth.ErrInvalidCredentials)
+	_, _, err = svc.Login(ctx, "alice", "new-password")
+	assert.NoError(t, err)
+}
+
+func TestValidateAccessToken_MissingRoleIsViewer(t *testing.T) {
+	tokenMgr := auth.NewTokenManager("test-secret-32-bytes-long-enough!", 15*60*1000000000, 24*3600*1000000000)
+
+	access, _, err := tokenMgr.GenerateAccessToken("1", "")
+	require.NoError(t, err)
+
+	id, err := tokenMgr.ValidateAccessToken(access)
+	require.NoError(t, err)
+	assert.Equal(t, models.RoleViewer, id.Role)
 }
@@ -0,0 +1,356 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/connectors"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	catalogtypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+)
+
+const (
+	// maxNameLength matches the connectors.name column.
+	maxNameLength = 255
+	// checkTimeout bounds a connection test.
+	checkTimeout = 30 * time.Second
+	// secretFormat marks the schema.json properties that hold credentials.
+	secretFormat = "password"
+	// changedMessage is the status message of a connector whose metadata changed.
+	changedMessage = "configuration changed; test the connection to update the status"
+)
+
+// connectorService implements ConnectorServiceInterface.
+type connectorService struct {
+	repo     repository.ConnectorRepository
+	deps     repository.ServiceDependencyRepository
+	schemas  SchemaProvider
+	checkers map[string]connectors.Checker
+	keys     *utils.Keyring
+}
+
+// NewConnectorService creates a new connectorService. Secret metadata fields are
+// encrypted with the primary key of keys; checkers test connections, keyed by provider ID.
+func NewConnectorService(repo repository.ConnectorRepository, deps repository.ServiceDependencyRepository, schemas SchemaProvider, checkers map[string]connectors.Checker, keys *utils.Keyring) ConnectorServiceInterface {
+	return &connectorService{repo: repo, deps: deps, schemas: schemas, checkers: checkers, keys: keys}
+}
+
+// CreateConnector validates and stores a new connector.
+func (s *connectorService) CreateConnector(ctx context.Context, userID string, req CreateConnectorRequest) (*ConnectorResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > maxNameLength {
+		return nil, &validators.ValidationError{Code: http.StatusBadRequest, Message: fmt.Sprintf("name must be between 1 and %d characters", maxNameLength)}
+	}
+
+	schema, err := s.schema(ctx, req.Type, req.Provider)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := s.sealMetadata(req.Metadata, schema, req.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	row := &models.Connector{
+		Name:      name,
+		Type:      req.Type,
+		Provider:  req.Provider,
+		Status:    models.ConnectorStatusOffline,
+		Message:   "connection not tested",
+		Metadata:  metadata,
+		CreatedBy: userID,
+	}
+	if err := s.repo.Insert(ctx, row); err != nil {
+		if errors.Is(err, repository.ErrConnectorNameTaken) {
+			return nil, &validators.ValidationError{Code: http.StatusConflict, Message: fmt.Sprintf("a connector named %q already exists", name)}
+		}
+
+		return nil, fmt.Errorf("failed to create connector: %w", err)
+	}
+
+	return toResponse(row, schema), nil
+}
+
+// GetConnector returns a connector with its non-secret metadata.
+func (s *connectorService) GetConnector(ctx context.Context, id string) (*ConnectorResponse, error) {
+	row, err := s.get(ctx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := s.schema(ctx, row.Type, row.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	return toResponse(row, schema), nil
+}
+
+// ListConnectors returns one page of connectors, newest first.
+func (s *connectorService) ListConnectors(ctx context.Context, req ConnectorListRequest) (*ConnectorListResponse, error) {
+	if req.Status != "" && req.Status != models.ConnectorStatusConnected && req.Status != models.ConnectorStatusOffline {
+		return nil, &validators.ValidationError{Code: http.StatusBadRequest, Message: fmt.Sprintf("invalid status %q: must be %q or %q", req.Status, models.ConnectorStatusConnected, models.ConnectorStatusOffline)}
+	}
+
+	filters := &repository.ConnectorFilters{Status: req.Status, Provider: req.Provider}
+	total, err := s.repo.GetCount(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count connectors: %w", err)
+	}
+	filters.Limit = req.PageSize
+	filters.Offset = (req.Page - 1) * req.PageSize
+	rows, err := s.repo.List(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list connectors: %w", err)
+	}
+
+	resp := &ConnectorListResponse{Connectors: make([]ConnectorResponse, 0, len(rows))}
+	for i := range rows {
+		resp.Connectors = append(resp.Connectors, *toResponse(&rows[i], nil))
+	}
+
+	totalPages := 0
+	if total > 0 {
+		totalPages = (total + req.PageSize - 1) / req.PageSize
+	}
+	resp.Pagination = catalogtypes.PaginationMetadata{
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalItems: total,
+		TotalPages: totalPages,
+		HasNext:    req.Page < totalPages,
+		HasPrev:    req.Page > 1,
+	}
+
+	return resp, nil
+}
+
+// UpdateConnector replaces the metadata of a connector and marks it offline until
+// the connection is tested again.
+func (s *connectorService) UpdateConnector(ctx context.Context, id string, req UpdateConnectorRequest) (*ConnectorResponse, error) {
+	row, err := s.get(ctx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := s.schema(ctx, row.Type, row.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep stored secrets the caller did not send again.
+	stored, err := s.openMetadata(row.Metadata, schema)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := s.sealMetadata(withStoredSecrets(req.Metadata, stored, schema), schema, row.Provider)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := s.repo.Update(ctx, row.ID, repository.ConnectorUpdateFields{Metadata: metadata})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update connector: %w", err)
+	}
+	if err := s.repo.UpdateStatus(ctx, row.ID, models.ConnectorStatusOffline, changedMessage); err != nil {
+		return nil, err
+	}
+	updated.Status, updated.Message, updated.Metadata = models.ConnectorStatusOffline, changedMessage, metadata
+
+	return toResponse(updated, schema), nil
+}
+
+// DeleteConnector deletes a connector unless an application service depends on it.
+func (s *connectorService) DeleteConnector(ctx context.Context, id string) error {
+	row, err := s.get(ctx, id, false)
+	if err != nil {
+		return err
+	}
+
+	dependents, err := s.deps.GetServicesByDependency(ctx, row.ID, models.DependencyTypeConnector)
+	if err != nil {
+		return fmt.Errorf("failed to check connector dependencies: %w", err)
+	}
+	if len(dependents) > 0 {
+		return &validators.ValidationError{Code: http.StatusConflict, Message: fmt.Sprintf("connector %q is used by %d application service(s)", row.Name, len(dependents))}
+	}
+
+	if err := s.repo.Delete(ctx, row.ID); err != nil {
+		return fmt.Errorf("failed to delete connector: %w", err)
+	}
+
+	return nil
+}
+
+// TestConnection runs the provider's checker with the decrypted metadata and stores
+// the outcome as the connector status. A failed check is not an error.
+func (s *connectorService) TestConnection(ctx context.Context, id string) (*ConnectorTestResponse, error) {
+	row, err := s.get(ctx, id, true)
+	if err != nil {
+		return nil, err
+	}
+	checker, ok := s.checkers[row.Provider]
+	if !ok {
+		return nil, &validators.ValidationError{Code: http.StatusBadRequest, Message: fmt.Sprintf("connection tests are not supported for provider %q", row.Provider)}
+	}
+	schema, err := s.schema(ctx, row.Type, row.Provider)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := s.openMetadata(row.Metadata, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	resp := &ConnectorTestResponse{Status: models.ConnectorStatusConnected}
+	if err := checker.Check(checkCtx, metadata); err != nil {
+		resp.Status, resp.Message = models.ConnectorStatusOffline, err.Error()
+	}
+	if err := s.repo.UpdateStatus(ctx, row.ID, resp.Status, resp.Message); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// get returns the connector with the given ID, or a 404 error.
+func (s *connectorService) get(ctx context.Context, id string, includeCreds bool) (*models.Connector, error) {
+	notFound := &validators.ValidationError{Code: http.StatusNotFound, Message: fmt.Sprintf("connector %q not found", id)}
+
+	connectorID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, notFound
+	}
+	row, err := s.repo.GetByID(ctx, connectorID, includeCreds)
+	if errors.Is(err, repository.ErrConnectorNotFound) {
+		return nil, notFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return row, nil
+}
+
+// schema returns the schema.json of a connector provider.
+func (s *connectorService) schema(ctx context.Context, connectorType, provider string) (map[string]any, error) {
+	schema, err := s.schemas.GetConnectorProviderParams(ctx, connectorType, provider)
+	if err != nil {
+		return nil, &validators.ValidationError{Code: http.StatusBadRequest, Message: fmt.Sprintf("unknown connector provider %q of type %q: %v", provider, connectorType, err)}
+	}
+
+	return schema, nil
+}
+
+// sealMetadata validates plaintext metadata against schema and returns a copy with
+// the secret fields encrypted.
+func (s *connectorService) sealMetadata(metadata, schema map[string]any, provider string) (map[string]any, error) {
+	if err := validators.ValidateDocument(metadata, schema, "connector provider "+provider); err != nil {
+		return nil, err
+	}
+
+	sealed := make(map[string]any, len(metadata))
+	for k, v := range metadata {
+		sealed[k] = v
+	}
+	for _, field := range secretFields(schema) {
+		plaintext, ok := sealed[field].(string)
+		if !ok {
+			continue
+		}
+		ciphertext, err := s.keys.Encrypt(plaintext)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt connector field %s: %w", field, err)
+		}
+		sealed[field] = ciphertext
+	}
+
+	return sealed, nil
+}
+
+// openMetadata returns a copy of stored metadata with the secret fields decrypted.
+func (s *connectorService) openMetadata(metadata, schema map[string]any) (map[string]any, error) {
+	opened := make(map[string]any, len(metadata))
+	for k, v := range metadata {
+		opened[k] = v
+	}
+	for _, field := range secretFields(schema) {
+		ciphertext, ok := opened[field].(string)
+		if !ok {
+			continue
+		}
+		plaintext, err := s.keys.Decrypt(ciphertext)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt connector field %s: %w", field, err)
+		}
+		opened[field] = plaintext
+	}
+
+	return opened, nil
+}
+
+// withStoredSecrets returns a copy of metadata with the secret fields it lacks taken from stored.
+func withStoredSecrets(metadata, stored, schema map[string]any) map[string]any {
+	merged := make(map[string]any, len(metadata))
+	for k, v := range metadata {
+		merged[k] = v
+	}
+	for _, field := range secretFields(schema) {
+		if _, ok := merged[field]; !ok && stored[field] != nil {
+			merged[field] = stored[field]
+		}
+	}
+
+	return merged
+}
+
+// secretFields returns the schema properties with format "password".
+func secretFields(schema map[string]any) []string {
+	props, _ := schema["properties"].(map[string]any)
+	var fields []string
+	for name, raw := range props {
+		if prop, ok := raw.(map[string]any); ok && prop["format"] == secretFormat {
+			fields = append(fields, name)
+		}
+	}
+
+	return fields
+}
+
+// toResponse maps a DB row to a ConnectorResponse. Metadata is only included when
+// schema is given, without the secret fields.
+func toResponse(c *models.Connector, schema map[string]any) *ConnectorResponse {
+	resp := &ConnectorResponse{
+		ID:        c.ID,
+		Name:      c.Name,
+		Type:      c.Type,
+		Provider:  c.Provider,
+		Status:    c.Status,
+		Message:   c.Message,
+		CreatedBy: c.CreatedBy,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+	if schema != nil && c.Metadata != nil {
+		resp.Metadata = make(map[string]any, len(c.Metadata))
+		for k, v := range c.Metadata {
+			resp.Metadata[k] = v
+		}
+		for _, field := range secretFields(schema) {
+			delete(resp.Metadata, field)
+		}
+	}
+
+	return resp
+}
+
+// Made with Bob
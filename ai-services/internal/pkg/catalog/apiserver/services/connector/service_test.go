@@ -0,0 +1,283 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/connectors"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+)
+
+const testKey = "test-encryption-key"
+
+// testSchema is a reduced object_storage schema.json.
+var testSchema = map[string]any{
+	"type":                 "object",
+	"additionalProperties": false,
+	"required":             []any{"bucket_name", "secret_access_key"},
+	"properties": map[string]any{
+		"bucket_name":       map[string]any{"type": "string"},
+		"prefix":            map[string]any{"type": "string"},
+		"secret_access_key": map[string]any{"type": "string", "format": "password"},
+	},
+}
+
+type fakeSchemas struct{}
+
+func (fakeSchemas) GetConnectorProviderParams(_ context.Context, connectorType, providerID string) (map[string]any, error) {
+	if connectorType != "datasource" || providerID != connectors.ProviderObjectStorage {
+		return nil, errors.New("connector provider not found")
+	}
+
+	return testSchema, nil
+}
+
+// fakeConnectorRepo is an in-memory repository.ConnectorRepository.
+type fakeConnectorRepo struct {
+	rows map[uuid.UUID]*models.Connector
+}
+
+func (r *fakeConnectorRepo) Insert(_ context.Context, c *models.Connector) error {
+	for _, row := range r.rows {
+		if row.Name == c.Name {
+			return repository.ErrConnectorNameTaken
+		}
+	}
+	c.ID = uuid.New()
+	c.CreatedAt, c.UpdatedAt = time.Now(), time.Now()
+	stored := *c
+	r.rows[c.ID] = &stored
+
+	return nil
+}
+
+func (r *fakeConnectorRepo) GetByID(_ context.Context, id uuid.UUID, includeCreds bool) (*models.Connector, error) {
+	row, ok := r.rows[id]
+	if !ok {
+		return nil, repository.ErrConnectorNotFound
+	}
+	out := *row
+	if !includeCreds {
+		out.Metadata = nil
+	}
+
+	return &out, nil
+}
+
+func (r *fakeConnectorRepo) List(_ context.Context, filters *repository.ConnectorFilters) ([]models.Connector, error) {
+	var out []models.Connector
+	for _, row := range r.rows {
+		if filters.Provider == "" || row.Provider == filters.Provider {
+			c := *row
+			c.Metadata = nil
+			out = append(out, c)
+		}
+	}
+
+	return out, nil
+}
+
+func (r *fakeConnectorRepo) GetCount(ctx context.Context, filters *repository.ConnectorFilters) (int, error) {
+	rows, _ := r.List(ctx, filters)
+
+	return len(rows), nil
+}
+
+func (r *fakeConnectorRepo) Update(_ context.Context, id uuid.UUID, fields repository.ConnectorUpdateFields) (*models.Connector, error) {
+	row, ok := r.rows[id]
+	if !ok {
+		return nil, repository.ErrConnectorNotFound
+	}
+	row.Metadata = fields.Metadata
+	out := *row
+	out.Metadata = nil
+
+	return &out, nil
+}
+
+func (r *fakeConnectorRepo) Delete(_ context.Context, id uuid.UUID) error {
+	delete(r.rows, id)
+
+	return nil
+}
+
+func (r *fakeConnectorRepo) UpdateStatus(_ context.Context, id uuid.UUID, status models.ConnectorStatus, message string) error {
+	r.rows[id].Status, r.rows[id].Message = status, message
+
+	return nil
+}
+
+type fakeDeps struct {
+	repository.ServiceDependencyRepository
+	dependents []uuid.UUID
+}
+
+func (d *fakeDeps) GetServicesByDependency(context.Context, uuid.UUID, models.DependencyType) ([]uuid.UUID, error) {
+	return d.dependents, nil
+}
+
+func newTestService(checkErr error) (*connectorService, *fakeConnectorRepo, *fakeDeps, *map[string]any) {
+	repo := &fakeConnectorRepo{rows: map[uuid.UUID]*models.Connector{}}
+	deps := &fakeDeps{}
+	var checked map[string]any
+	checkers := map[string]connectors.Checker{
+		connectors.ProviderObjectStorage: connectors.CheckerFunc(func(_ context.Context, md map[string]any) error {
+			checked = md
+
+			return checkErr
+		}),
+	}
+	keys, _ := utils.NewKeyring(testKey)
+	svc := NewConnectorService(repo, deps, fakeSchemas{}, checkers, keys).(*connectorService)
+
+	return svc, repo, deps, &checked
+}
+
+func createTestConnector(t *testing.T, svc *connectorService) *ConnectorResponse {
+	t.Helper()
+
+	resp, err := svc.CreateConnector(context.Background(), "admin", CreateConnectorRequest{
+		Name:     "docs",
+		Type:     "datasource",
+		Provider: connectors.ProviderObjectStorage,
+		Metadata: map[string]any{"bucket_name": "docs", "secret_access_key": "s3cret"},
+	})
+	require.NoError(t, err)
+
+	return resp
+}
+
+func requireValidationCode(t *testing.T, err error, code int) {
+	t.Helper()
+
+	var valErr *validators.ValidationError
+	require.ErrorAs(t, err, &valErr)
+	assert.Equal(t, code, valErr.Code)
+}
+
+func TestCreateConnectorEncryptsSecrets(t *testing.T) {
+	svc, repo, _, _ := newTestService(nil)
+
+	resp := createTestConnector(t, svc)
+	assert.Equal(t, models.ConnectorStatusOffline, resp.Status)
+	assert.Equal(t, map[string]any{"bucket_name": "docs"}, resp.Metadata)
+
+	stored := repo.rows[resp.ID].Metadata
+	assert.Equal(t, "docs", stored["bucket_name"])
+	require.NotEqual(t, "s3cret", stored["secret_access_key"])
+	plaintext, err := utils.Decrypt(stored["secret_access_key"].(string), testKey)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", plaintext)
+}
+
+func TestCreateConnectorValidation(t *testing.T) {
+	svc, _, _, _ := newTestService(nil)
+	ctx := context.Background()
+
+	tests := map[string]CreateConnectorRequest{
+		"empty metadata":   {Name: "a", Type: "datasource", Provider: connectors.ProviderObjectStorage},
+		"unknown field":    {Name: "a", Type: "datasource", Provider: connectors.ProviderObjectStorage, Metadata: map[string]any{"bucket_name": "b", "secret_access_key": "s", "region": "x"}},
+		"unknown provider": {Name: "a", Type: "datasource", Provider: "ftp", Metadata: map[string]any{}},
+		"blank name":       {Name: " ", Type: "datasource", Provider: connectors.ProviderObjectStorage},
+	}
+	for name, req := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := svc.CreateConnector(ctx, "admin", req)
+			requireValidationCode(t, err, http.StatusBadRequest)
+		})
+	}
+
+	createTestConnector(t, svc)
+	_, err := svc.CreateConnector(ctx, "admin", CreateConnectorRequest{
+		Name: "docs", Type: "datasource", Provider: connectors.ProviderObjectStorage,
+		Metadata: map[string]any{"bucket_name": "b", "secret_access_key": "s"},
+	})
+	requireValidationCode(t, err, http.StatusConflict)
+}
+
+func TestUpdateConnectorKeepsOmittedSecrets(t *testing.T) {
+	svc, repo, _, _ := newTestService(nil)
+	ctx := context.Background()
+	created := createTestConnector(t, svc)
+	repo.rows[created.ID].Status = models.ConnectorStatusConnected
+
+	resp, err := svc.UpdateConnector(ctx, created.ID.String(), UpdateConnectorRequest{
+		Metadata: map[string]any{"bucket_name": "archive", "prefix": "2026/"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"bucket_name": "archive", "prefix": "2026/"}, resp.Metadata)
+	assert.Equal(t, models.ConnectorStatusOffline, repo.rows[created.ID].Status)
+
+	opened, err := svc.openMetadata(repo.rows[created.ID].Metadata, testSchema)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", opened["secret_access_key"])
+
+	_, err = svc.UpdateConnector(ctx, created.ID.String(), UpdateConnectorRequest{Metadata: map[string]any{"prefix": "x"}})
+	requireValidationCode(t, err, http.StatusBadRequest)
+}
+
+func TestDeleteConnector(t *testing.T) {
+	svc, repo, deps, _ := newTestService(nil)
+	ctx := context.Background()
+	created := createTestConnector(t, svc)
+
+	deps.dependents = []uuid.UUID{uuid.New()}
+	requireValidationCode(t, svc.DeleteConnector(ctx, created.ID.String()), http.StatusConflict)
+
+	deps.dependents = nil
+	require.NoError(t, svc.DeleteConnector(ctx, created.ID.String()))
+	assert.Empty(t, repo.rows)
+
+	requireValidationCode(t, svc.DeleteConnector(ctx, created.ID.String()), http.StatusNotFound)
+	requireValidationCode(t, svc.DeleteConnector(ctx, "not-a-uuid"), http.StatusNotFound)
+}
+
+func TestTestConnection(t *testing.T) {
+	svc, repo, _, checked := newTestService(nil)
+	ctx := context.Background()
+	created := createTestConnector(t, svc)
+
+	resp, err := svc.TestConnection(ctx, created.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, &ConnectorTestResponse{Status: models.ConnectorStatusConnected}, resp)
+	assert.Equal(t, "s3cret", (*checked)["secret_access_key"])
+	assert.Equal(t, models.ConnectorStatusConnected, repo.rows[created.ID].Status)
+	assert.Empty(t, repo.rows[created.ID].Message)
+}
+
+func TestTestConnectionOffline(t *testing.T) {
+	svc, repo, _, _ := newTestService(errors.New("NoSuchBucket"))
+	created := createTestConnector(t, svc)
+
+	resp, err := svc.TestConnection(context.Background(), created.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, models.ConnectorStatusOffline, resp.Status)
+	assert.Equal(t, "NoSuchBucket", resp.Message)
+	assert.Equal(t, "NoSuchBucket", repo.rows[created.ID].Message)
+}
+
+func TestListConnectors(t *testing.T) {
+	svc, _, _, _ := newTestService(nil)
+	createTestConnector(t, svc)
+
+	resp, err := svc.ListConnectors(context.Background(), ConnectorListRequest{Page: 1, PageSize: 20})
+	require.NoError(t, err)
+	require.Len(t, resp.Connectors, 1)
+	assert.Nil(t, resp.Connectors[0].Metadata)
+	assert.Equal(t, 1, resp.Pagination.TotalItems)
+
+	_, err = svc.ListConnectors(context.Background(), ConnectorListRequest{Page: 1, PageSize: 20, Status: "broken"})
+	requireValidationCode(t, err, http.StatusBadRequest)
+}
+
+// Made with Bob
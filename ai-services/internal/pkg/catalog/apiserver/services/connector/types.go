@@ -0,0 +1,94 @@
+// Package connector defines the service layer for data-source connector instances.
+package connector
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+)
+
+// ConnectorServiceInterface is the interface fulfilled by connectorService.
+// Errors meant for the caller are returned as *validators.ValidationError.
+type ConnectorServiceInterface interface {
+	// CreateConnector validates the metadata against the provider's schema.json,
+	// encrypts its secret fields and stores the connector as offline.
+	CreateConnector(ctx context.Context, userID string, req CreateConnectorRequest) (*ConnectorResponse, error)
+	// GetConnector returns a connector with its non-secret metadata.
+	GetConnector(ctx context.Context, id string) (*ConnectorResponse, error)
+	// ListConnectors returns one page of connectors, newest first, without metadata.
+	ListConnectors(ctx context.Context, req ConnectorListRequest) (*ConnectorListResponse, error)
+	// UpdateConnector replaces the metadata of a connector. Secret fields left out of
+	// the request keep their stored value.
+	UpdateConnector(ctx context.Context, id string, req UpdateConnectorRequest) (*ConnectorResponse, error)
+	// DeleteConnector deletes a connector that no application service depends on.
+	DeleteConnector(ctx context.Context, id string) error
+	// TestConnection checks that the connector can reach its content and records the
+	// outcome as the connector status.
+	TestConnection(ctx context.Context, id string) (*ConnectorTestResponse, error)
+}
+
+// SchemaProvider returns the JSON schema of a connector provider's configuration.
+// It is implemented by catalog.CatalogProvider.
+type SchemaProvider interface {
+	GetConnectorProviderParams(ctx context.Context, connectorType, providerID string) (map[string]any, error)
+}
+
+// CreateConnectorRequest is the payload of POST /connectors/instances.
+type CreateConnectorRequest struct {
+	Name string `json:"name" binding:"required"`
+	// Type is the connector type, e.g. "datasource".
+	Type string `json:"type" binding:"required"`
+	// Provider is the connector provider within the type, e.g. "object_storage".
+	Provider string `json:"provider" binding:"required"`
+	// Metadata is the provider configuration, validated against the provider's schema.json.
+	Metadata map[string]any `json:"metadata"`
+}
+
+// UpdateConnectorRequest is the payload of PUT /connectors/instances/{id}.
+type UpdateConnectorRequest struct {
+	// Metadata replaces the provider configuration. Secret fields that are left out keep
+	// their stored value.
+	Metadata map[string]any `json:"metadata" binding:"required"`
+}
+
+// ConnectorListRequest holds the validated pagination inputs and filters for ListConnectors.
+type ConnectorListRequest struct {
+	Page     int
+	PageSize int
+	Status   models.ConnectorStatus
+	Provider string
+}
+
+// ConnectorResponse is a connector as returned by the API. Secret metadata fields are
+// never included.
+type ConnectorResponse struct {
+	ID        uuid.UUID              `json:"id"`
+	Name      string                 `json:"name"`
+	Type      string                 `json:"type"`
+	Provider  string                 `json:"provider"`
+	Status    models.ConnectorStatus `json:"status"`
+	Message   string                 `json:"message,omitempty"`
+	Metadata  map[string]any         `json:"metadata,omitempty"`
+	CreatedBy string                 `json:"created_by"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at"`
+}
+
+// ConnectorListResponse is the paginated response of GET /connectors/instances.
+type ConnectorListResponse struct {
+	Connectors []ConnectorResponse      `json:"connectors"`
+	Pagination types.PaginationMetadata `json:"pagination"`
+}
+
+// ConnectorTestResponse is the response of POST /connectors/instances/{id}/test.
+type ConnectorTestResponse struct {
+	Status models.ConnectorStatus `json:"status"`
+	// Message explains why the connector is offline.
+	Message string `json:"message,omitempty"`
+}
+
+// Made with Bob
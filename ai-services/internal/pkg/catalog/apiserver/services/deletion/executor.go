@@ -2,7 +2,9 @@ package deletion
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/deletion/repository/openshift"
@@ -11,8 +13,6 @@ import (
 
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
 	catalogutils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
-	openshiftRuntime "github.com/project-ai-services/ai-services/internal/pkg/runtime/openshift"
-	podmanRuntime "github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
 	"github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
 )
 
@@ -39,9 +39,12 @@ func NewDeletionExecutor(
 	}
 }
 
+// Execute deletes the application's resources from the runtime hosting it.
+// workerID is the worker the application was placed on, or nil for the local runtime.
 func (e *DeletionExecutor) Execute(
 	ctx context.Context,
 	appID uuid.UUID,
+	workerID *uuid.UUID,
 	services []models.Service,
 	orphanedComponentIDs []uuid.UUID,
 	keepData bool,
@@ -50,9 +53,9 @@ func (e *DeletionExecutor) Execute(
 	// Execute deployment based on runtime type using the provided plan
 	switch runtimeType {
 	case types.RuntimeTypePodman:
-		return e.executePodmanDeletion(ctx, appID, services, orphanedComponentIDs, keepData)
+		return e.executePodmanDeletion(ctx, appID, workerID, services, orphanedComponentIDs, keepData)
 	case types.RuntimeTypeOpenShift:
-		return e.executeOpenShiftDeletion(ctx, appID, services, orphanedComponentIDs, keepData)
+		return e.executeOpenShiftDeletion(ctx, appID, workerID, services, orphanedComponentIDs, keepData)
 	default:
 		return fmt.Errorf("unsupported runtime type: %s", runtimeType)
 	}
@@ -62,12 +65,13 @@ func (e *DeletionExecutor) Execute(
 func (e *DeletionExecutor) executePodmanDeletion(
 	ctx context.Context,
 	appID uuid.UUID,
+	workerID *uuid.UUID,
 	services []models.Service,
 	orphanedComponentIDs []uuid.UUID,
 	keepData bool,
 ) error {
 	// Initialize Podman runtime client
-	rt, err := podmanRuntime.NewPodmanClient()
+	rt, err := catalogutils.AppRuntime(ctx, workerID, "")
 	if err != nil {
 		return fmt.Errorf("failed to initialize Podman runtime: %w", err)
 	}
@@ -90,12 +94,13 @@ func (e *DeletionExecutor) executePodmanDeletion(
 func (e *DeletionExecutor) executeOpenShiftDeletion(
 	ctx context.Context,
 	appID uuid.UUID,
+	workerID *uuid.UUID,
 	services []models.Service,
 	orphanedComponentIDs []uuid.UUID,
 	keepData bool,
 ) error {
 	ns := catalogutils.AppNamespace(appID)
-	rt, err := openshiftRuntime.NewOpenshiftClientWithNamespace(ns)
+	rt, err := catalogutils.AppRuntime(ctx, workerID, ns)
 	if err != nil {
 		return fmt.Errorf("failed to initialize openshift runtime: %w", err)
 	}
@@ -113,3 +118,72 @@ func (e *DeletionExecutor) executeOpenShiftDeletion(
 
 	return nil
 }
+
+// Prune deletes the services and components a reconfigured application no longer uses,
+// with their data, from the runtime hosting it. The application itself is kept.
+func (e *DeletionExecutor) Prune(
+	ctx context.Context,
+	appID uuid.UUID,
+	workerID *uuid.UUID,
+	services []models.Service,
+	componentIDs []uuid.UUID,
+	runtimeType types.RuntimeType,
+) error {
+	if len(services) == 0 && len(componentIDs) == 0 {
+		return nil
+	}
+
+	var errorMessages []string
+	switch runtimeType {
+	case types.RuntimeTypePodman:
+		rt, err := catalogutils.AppRuntime(ctx, workerID, "")
+		if err != nil {
+			return fmt.Errorf("failed to initialize Podman runtime: %w", err)
+		}
+		errorMessages = podman.NewPodmanDeletion(rt, e.appRepo, e.serviceRepo, e.componentRepo, e.serviceDependencyRepo).
+			PruneResources(ctx, services, componentIDs)
+	case types.RuntimeTypeOpenShift:
+		ns := catalogutils.AppNamespace(appID)
+		rt, err := catalogutils.AppRuntime(ctx, workerID, ns)
+		if err != nil {
+			return fmt.Errorf("failed to initialize openshift runtime: %w", err)
+		}
+		errorMessages = openshift.NewOpenshiftDeletion(rt, ns, e.appRepo, e.serviceRepo, e.componentRepo, e.serviceDependencyRepo).
+			PruneResources(ctx, appID, services, componentIDs)
+	default:
+		return fmt.Errorf("unsupported runtime type: %s", runtimeType)
+	}
+
+	return joinErrorMessages(errorMessages)
+}
+
+// RemoveServicePods deletes the pods of services that are redeployed with a new
+// configuration, keeping their data. Only Podman needs this: on OpenShift the Helm
+// upgrade of the release replaces the pods.
+func (e *DeletionExecutor) RemoveServicePods(
+	ctx context.Context,
+	workerID *uuid.UUID,
+	services []models.Service,
+	runtimeType types.RuntimeType,
+) error {
+	if runtimeType != types.RuntimeTypePodman || len(services) == 0 {
+		return nil
+	}
+
+	rt, err := catalogutils.AppRuntime(ctx, workerID, "")
+	if err != nil {
+		return fmt.Errorf("failed to initialize Podman runtime: %w", err)
+	}
+
+	return joinErrorMessages(podman.NewPodmanDeletion(rt, e.appRepo, e.serviceRepo, e.componentRepo, e.serviceDependencyRepo).
+		RemoveServicePods(ctx, services))
+}
+
+// joinErrorMessages returns the error messages collected by a deletion as one error, or nil.
+func joinErrorMessages(errorMessages []string) error {
+	if len(errorMessages) == 0 {
+		return nil
+	}
+
+	return errors.New(strings.Join(errorMessages, "; "))
+}
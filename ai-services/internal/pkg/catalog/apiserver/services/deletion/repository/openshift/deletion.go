@@ -91,6 +91,15 @@ func (s *OpenshiftDeletion) PerformDeletion(ctx context.Context, appID uuid.UUID
 	logger.InfofCtx(ctx, "Application '%s' deleted successfully", appID)
 }
 
+// PruneResources uninstalls the services and components a reconfigured application no
+// longer uses, deletes their data and DB records, and keeps the application and its
+// namespace. It returns any error messages.
+func (s *OpenshiftDeletion) PruneResources(ctx context.Context, appID uuid.UUID, services []models.Service, componentIDs []uuid.UUID) []string {
+	errorMessages := s.deleteServices(ctx, s.ns, appID, services, false)
+
+	return append(errorMessages, s.deleteComponents(ctx, s.ns, appID, componentIDs, false)...)
+}
+
 // deleteServices uninstalls all service Helm releases sequentially and removes their DB records.
 // Collects and returns all errors rather than stopping on the first failure.
 func (s *OpenshiftDeletion) deleteServices(ctx context.Context, ns string, appID uuid.UUID, services []models.Service, keepData bool) []string {
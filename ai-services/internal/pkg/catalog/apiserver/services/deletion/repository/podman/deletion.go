@@ -79,6 +79,52 @@ func (s *PodmanDeletion) PerformDeletion(ctx context.Context, appID uuid.UUID, s
 	logger.InfofCtx(ctx, "Application %s deleted successfully", appID)
 }
 
+// PruneResources deletes services and components a reconfigured application no longer
+// uses, including their data, and keeps the application. It returns any error messages.
+func (s *PodmanDeletion) PruneResources(ctx context.Context, services []models.Service, componentIDs []uuid.UUID) []string {
+	proxyManager, err := proxy.GetCaddyProxyManager()
+	if err != nil {
+		return []string{fmt.Sprintf("failed to get Caddy proxy manager: %s", err)}
+	}
+
+	errorMessages := s.deleteServices(ctx, services, false, proxyManager)
+
+	return append(errorMessages, s.deleteOrphanedComponents(ctx, componentIDs, false)...)
+}
+
+// RemoveServicePods deletes the pods of services so they can be redeployed with a new
+// configuration. Their routes are unregistered; volumes, secrets and DB records are kept.
+func (s *PodmanDeletion) RemoveServicePods(ctx context.Context, services []models.Service) []string {
+	proxyManager, err := proxy.GetCaddyProxyManager()
+	if err != nil {
+		return []string{fmt.Sprintf("failed to get Caddy proxy manager: %s", err)}
+	}
+
+	var errorMessages []string
+	for _, svc := range services {
+		pods, err := s.rt.ListPods(map[string][]string{
+			"label": {fmt.Sprintf("ai-services.io/template=%s", svc.ID)},
+		})
+		if err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("service %s: failed to list pods: %s", svc.ID, err))
+
+			continue
+		}
+
+		if err := s.unregisterServiceRoutes(ctx, proxyManager, svc); err != nil {
+			errorMessages = append(errorMessages, fmt.Sprintf("service %s: %s", svc.ID, err))
+
+			continue
+		}
+
+		for _, podErr := range s.deletePods(ctx, pods, true) {
+			errorMessages = append(errorMessages, fmt.Sprintf("service %s: %s", svc.ID, podErr))
+		}
+	}
+
+	return errorMessages
+}
+
 // unregisterServiceRoutes performs best-effort route cleanup for a service.
 // Updates DB status if route unregistration fails, but does not block deletion.
 func (s *PodmanDeletion) unregisterServiceRoutes(ctx context.Context, proxyManager proxy.ProxyManager, svc models.Service) error {
@@ -10,9 +10,9 @@ import (
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/deployment/repository/podman"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
 	catalogutils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
-	openshiftRuntime "github.com/project-ai-services/ai-services/internal/pkg/runtime/openshift"
-	podmanRuntime "github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
 	"github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
 )
 
 // DeploymentExecutor orchestrates the complete deployment process.
@@ -82,8 +82,8 @@ func (e *DeploymentExecutor) executePodmanDeployment(
 	plan *DeploymentPlan,
 	req apimodels.CreateApplicationRequest,
 ) error {
-	// Initialize Podman runtime client
-	rt, err := podmanRuntime.NewPodmanClient()
+	// Initialize Podman runtime client on the host the plan was placed on
+	rt, err := newPlanRuntime(ctx, plan, types.RuntimeTypePodman, "")
 	if err != nil {
 		return fmt.Errorf("failed to initialize Podman runtime: %w", err)
 	}
@@ -110,7 +110,7 @@ func (e *DeploymentExecutor) executeOpenShiftDeployment(
 	// Initialize OpenShift runtime client scoped to the application's namespace
 	// so that ListRoutes, ListPods etc. query the correct namespace.
 	ns := catalogutils.AppNamespace(plan.ApplicationID)
-	rt, err := openshiftRuntime.NewOpenshiftClientWithNamespace(ns)
+	rt, err := newPlanRuntime(ctx, plan, types.RuntimeTypeOpenShift, ns)
 	if err != nil {
 		return fmt.Errorf("failed to initialize OpenShift runtime: %w", err)
 	}
@@ -127,4 +127,18 @@ func (e *DeploymentExecutor) executeOpenShiftDeployment(
 	return deployer.ExecuteDeployment(ctx, plan, req)
 }
 
+// newPlanRuntime returns the runtime of the worker the plan was placed on, or the
+// local runtime when the plan has no worker. Plans of reconfigured applications
+// only carry the ID of the worker the application already runs on.
+func newPlanRuntime(ctx context.Context, plan *DeploymentPlan, runtimeType types.RuntimeType, namespace string) (runtime.Runtime, error) {
+	if plan.WorkerName != "" {
+		return vars.RuntimeFactory.CreateForWorker(plan.WorkerName, runtimeType, namespace)
+	}
+	if plan.WorkerID != nil {
+		return vars.RuntimeFactory.CreateForWorkerID(ctx, *plan.WorkerID, namespace)
+	}
+
+	return runtime.CreateRuntime(runtimeType, namespace)
+}
+
 // Made with Bob
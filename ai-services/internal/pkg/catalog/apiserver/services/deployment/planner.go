@@ -3,15 +3,19 @@ package deployment
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/google/uuid"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog"
 	apimodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/deployment/repository/podman"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/deployment/types"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/params"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
-	"github.com/project-ai-services/ai-services/internal/pkg/cli/helpers"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
 )
@@ -82,11 +86,10 @@ func (p *DeploymentPlanner) PlanDeployment(
 		}
 	}
 
-	// Calculate and allocate Spyre cards after all components are planned. Only needed for Podman.
-	if runtimeType == runtimeTypes.RuntimeTypePodman.String() {
-		if err := p.calculateAndAllocateSpyreCards(ctx, plan); err != nil {
-			return nil, fmt.Errorf("failed to allocate Spyre cards: %w", err)
-		}
+	// Aggregate resource requirements after all components are planned, so the
+	// scheduler can pick a host before Spyre cards are allocated.
+	if err := p.calculateResources(ctx, plan, runtimeType); err != nil {
+		return nil, fmt.Errorf("failed to calculate resource requirements: %w", err)
 	}
 
 	return plan, nil
@@ -141,6 +144,12 @@ func (p *DeploymentPlanner) processService(
 		}
 	}
 
+	// A service changes when its params or any component it uses change.
+	servicePlan.Hash = utils.CalculateComponentHash(svc.CatalogID, svc.Version, map[string]any{
+		"params":     svc.Params,
+		"components": slices.Sorted(slices.Values(servicePlan.ComponentRefs)),
+	})
+
 	// Add service to plan
 	plan.Services[svc.CatalogID] = servicePlan
 
@@ -195,22 +204,73 @@ func (p *DeploymentPlanner) processComponent(
 	return componentHash, nil
 }
 
-// calculateAndAllocateSpyreCards calculates required Spyre cards and creates allocation pool.
-func (p *DeploymentPlanner) calculateAndAllocateSpyreCards(ctx context.Context, plan *DeploymentPlan) error {
-	totalRequired := 0
+// calculateResources sums the resource requirements declared in the runtime metadata of
+// every planned service and component into plan.Resources. For Podman the Spyre card
+// count is taken from the rendered templates, which reflect the requested parameters.
+func (p *DeploymentPlanner) calculateResources(ctx context.Context, plan *DeploymentPlan, runtimeType string) error {
+	plan.Resources = templates.RuntimeResources{Accelerators: make(map[string]int)}
+
+	for serviceID := range plan.Services {
+		if metadata, err := p.catalogProvider.LoadServiceRuntimeMetadata(serviceID); err == nil {
+			addResources(&plan.Resources, metadata.Resources)
+		}
+	}
 
-	// Calculate total required Spyre cards from all components
+	for _, comp := range plan.Components {
+		if metadata, err := p.catalogProvider.LoadComponentRuntimeMetadata(comp.ComponentType, comp.ProviderID); err == nil {
+			addResources(&plan.Resources, metadata.Resources)
+		}
+	}
+
+	if runtimeType != runtimeTypes.RuntimeTypePodman.String() {
+		return nil
+	}
+
+	totalRequired := 0
 	for _, comp := range plan.Components {
 		required, err := p.getRequiredSpyreCardsForComponent(ctx, comp)
 		if err != nil {
 			return fmt.Errorf("failed to get Spyre card requirements for component %s: %w", comp.ComponentType, err)
 		}
+		comp.SpyreCards = required
 		totalRequired += required
 		if required > 0 {
 			logger.InfofCtx(ctx, "Component %s/%s requires %d Spyre cards\n", comp.ComponentType, comp.ProviderID, required)
 		}
 	}
 
+	if totalRequired > 0 {
+		plan.Resources.Accelerators[constants.SpyreResourceName] = totalRequired
+	} else {
+		delete(plan.Resources.Accelerators, constants.SpyreResourceName)
+	}
+
+	return nil
+}
+
+// addResources adds r to total. A nil r (no resources declared) is a no-op.
+func addResources(total *templates.RuntimeResources, r *templates.RuntimeResources) {
+	if r == nil {
+		return
+	}
+
+	total.CPU += r.CPU
+	total.Memory += r.Memory
+	total.Storage += r.Storage
+	for name, count := range r.Accelerators {
+		total.Accelerators[name] += count
+	}
+}
+
+// AllocateSpyreCards creates the Spyre card pool of a Podman plan once its host is known.
+// findFreeCards lists the PCI addresses of the free cards on that host; it is only called
+// when the plan needs Spyre cards.
+func (p *DeploymentPlanner) AllocateSpyreCards(
+	ctx context.Context,
+	plan *DeploymentPlan,
+	findFreeCards func(ctx context.Context) ([]string, error),
+) error {
+	totalRequired := plan.Resources.Accelerators[constants.SpyreResourceName]
 	if totalRequired == 0 {
 		logger.InfofCtx(ctx, "No Spyre cards required for this deployment\n")
 
@@ -220,7 +280,7 @@ func (p *DeploymentPlanner) calculateAndAllocateSpyreCards(ctx context.Context,
 	logger.InfofCtx(ctx, "Total Spyre cards required: %d\n", totalRequired)
 
 	// Find available Spyre cards
-	pciAddresses, err := helpers.FindFreeSpyreCards(ctx)
+	pciAddresses, err := findFreeCards(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to find free Spyre cards: %w", err)
 	}
@@ -241,6 +301,17 @@ func (p *DeploymentPlanner) calculateAndAllocateSpyreCards(ctx context.Context,
 	return nil
 }
 
+// Downloads returns the sorted container images and models that deploying a Podman plan
+// pulls onto its host, unless they are present there already.
+func (p *DeploymentPlanner) Downloads(ctx context.Context, plan *DeploymentPlan) ([]string, []string, error) {
+	images, err := podman.CollectImages(ctx, p.catalogProvider, plan)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return slices.Sorted(maps.Keys(images)), slices.Sorted(maps.Keys(podman.CollectModels(ctx, plan))), nil
+}
+
 // getRequiredSpyreCardsForComponent calculates Spyre cards needed for a component.
 func (p *DeploymentPlanner) getRequiredSpyreCardsForComponent(ctx context.Context, comp *ComponentPlan) (int, error) {
 	// Load component templates using catalog provider
@@ -13,6 +13,8 @@ import (
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog"
 	apimodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
 	deploymenttypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/deployment/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/events"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/jobs"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
 	catalogutils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
@@ -77,11 +79,15 @@ func (d *OpenShiftDeployer) ExecuteDeployment(
 		logger.ErrorfCtx(ctx, "Failed to update application status to Deploying: %v\n", err)
 	}
 
-	// Phase 0: Deploy prerequisites (ServingRuntimes etc.), idempotent, once per namespace
-	if err := d.deployPrerequisites(ctx, ns); err != nil {
-		catalogutils.HandleDeploymentStepError(ctx, d.appRepo, plan.ApplicationID, "Prerequisites deployment failed", err)
+	// Phase 0: Deploy prerequisites (ServingRuntimes etc.), idempotent, once per namespace.
+	// A resumed deployment job that already installed them skips this phase.
+	if !jobs.Completed(ctx, jobs.StepPrerequisites) {
+		if err := d.deployPrerequisites(ctx, ns); err != nil {
+			catalogutils.HandleDeploymentStepError(ctx, d.appRepo, plan.ApplicationID, "Prerequisites deployment failed", err)
 
-		return err
+			return err
+		}
+		jobs.Checkpoint(ctx, jobs.StepPrerequisites)
 	}
 
 	// Phase 1: Deploy components concurrently via Helm
@@ -90,6 +96,7 @@ func (d *OpenShiftDeployer) ExecuteDeployment(
 
 		return err
 	}
+	jobs.Checkpoint(ctx, jobs.StepComponents)
 
 	// Phase 2: Deploy services concurrently via Helm.
 	if err := d.deployServicesConcurrently(ctx, ns, plan); err != nil {
@@ -97,6 +104,7 @@ func (d *OpenShiftDeployer) ExecuteDeployment(
 
 		return err
 	}
+	jobs.Checkpoint(ctx, jobs.StepServices)
 
 	// Update application status to Running
 	if err := catalogutils.UpdateApplicationStatus(ctx, d.appRepo, plan.ApplicationID, models.ApplicationStatusRunning, "Deployment completed successfully"); err != nil {
@@ -240,9 +248,16 @@ func (d *OpenShiftDeployer) registerServiceEndpoints(ctx context.Context, ns, re
 			continue
 		}
 
+		url := fmt.Sprintf("https://%s", route.HostPort)
 		endpoints = append(endpoints, map[string]any{
 			"type": route.Labels["ai-services.io/endpoint-type"],
-			"url":  fmt.Sprintf("https://%s", route.HostPort),
+			"url":  url,
+		})
+		events.Emit(ctx, apimodels.ApplicationEvent{
+			Step:    apimodels.EventStepRouteRegistered,
+			Status:  apimodels.EventStatusCompleted,
+			Target:  url,
+			Message: fmt.Sprintf("route %s of service %s", route.Name, svc.CatalogID),
 		})
 	}
 
@@ -287,7 +302,8 @@ func (d *OpenShiftDeployer) updateComponentEndpoint(ctx context.Context, ns stri
 }
 
 // helmInstallOrUpgrade loads the chart at catalogPath from the embedded CatalogFS and
-// performs helm install (if the release doesn't exist) or upgrade.
+// performs helm install (if the release doesn't exist) or upgrade, emitting readiness_wait
+// events around the wait for the release to become ready.
 // templateID is injected as a --set override (not part of values.yaml) so that
 // ai-services.io/template labels carry the DB UUID, matching the Podman convention.
 func helmInstallOrUpgrade(ctx context.Context, namespace, release, catalogPath string, values map[string]any, templateID string) error {
@@ -310,5 +326,18 @@ func helmInstallOrUpgrade(ctx context.Context, namespace, release, catalogPath s
 		overrides["templateID"] = templateID
 	}
 
-	return helmClient.InstallOrUpgrade(ctx, release, chart, overrides, defaultHelmTimeout)
+	event := apimodels.ApplicationEvent{Step: apimodels.EventStepReadinessWait, Target: release, Status: apimodels.EventStatusStarted}
+	events.Emit(ctx, event)
+
+	if err := helmClient.InstallOrUpgrade(ctx, release, chart, overrides, defaultHelmTimeout); err != nil {
+		event.Status, event.Message = apimodels.EventStatusFailed, err.Error()
+		events.Emit(ctx, event)
+
+		return err
+	}
+
+	event.Status = apimodels.EventStatusCompleted
+	events.Emit(ctx, event)
+
+	return nil
 }
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -15,6 +16,8 @@ import (
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog"
 	apimodels "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
 	deploymenttypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/deployment/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/events"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/jobs"
 	catalogconstants "github.com/project-ai-services/ai-services/internal/pkg/catalog/constants"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
@@ -107,6 +110,7 @@ func (d *PodmanDeployer) ExecuteDeployment(
 			return fmt.Errorf("failed to deploy components: %w", err)
 		}
 	}
+	jobs.Checkpoint(ctx, jobs.StepComponents)
 
 	// Step 3: Deploy services if any
 	if len(plan.Services) > 0 {
@@ -116,6 +120,7 @@ func (d *PodmanDeployer) ExecuteDeployment(
 			return fmt.Errorf("failed to deploy services: %w", err)
 		}
 	}
+	jobs.Checkpoint(ctx, jobs.StepServices)
 
 	// Step 4: Register routes with Caddy proxy
 	if err := d.registerApplicationRoutes(ctx, plan); err != nil {
@@ -123,6 +128,7 @@ func (d *PodmanDeployer) ExecuteDeployment(
 
 		return fmt.Errorf("failed to register application routes: %w", err)
 	}
+	jobs.Checkpoint(ctx, jobs.StepRoutes)
 
 	// Step 5: Update application status to Running.
 	// Skip if the context was cancelled — deletion is now in charge of the status.
@@ -139,19 +145,23 @@ func (d *PodmanDeployer) ExecuteDeployment(
 
 // prepareDeployment pulls images, downloads models, and transitions the
 // application status to Deploying. It is a prerequisite for all deploy steps.
+// A resumed deployment job that already prepared only updates the status.
 func (d *PodmanDeployer) prepareDeployment(ctx context.Context, plan *DeploymentPlan) error {
-	// Step 1a: Pull container images for all components and services
-	if err := d.pullImagesForDeployment(ctx, plan); err != nil {
-		catalogutils.HandleDeploymentStepError(ctx, d.appRepo, plan.ApplicationID, "Image pull failed", err)
+	if !jobs.Completed(ctx, jobs.StepPrepared) {
+		// Step 1a: Pull container images for all components and services
+		if err := d.pullImagesForDeployment(ctx, plan); err != nil {
+			catalogutils.HandleDeploymentStepError(ctx, d.appRepo, plan.ApplicationID, "Image pull failed", err)
 
-		return fmt.Errorf("failed to pull images: %w", err)
-	}
+			return fmt.Errorf("failed to pull images: %w", err)
+		}
 
-	// Step 1b: Download models specified in parameters
-	if err := d.downloadModelsForDeployment(ctx, plan); err != nil {
-		catalogutils.HandleDeploymentStepError(ctx, d.appRepo, plan.ApplicationID, "Model download failed", err)
+		// Step 1b: Download models specified in parameters
+		if err := d.downloadModelsForDeployment(ctx, plan); err != nil {
+			catalogutils.HandleDeploymentStepError(ctx, d.appRepo, plan.ApplicationID, "Model download failed", err)
 
-		return fmt.Errorf("failed to download models: %w", err)
+			return fmt.Errorf("failed to download models: %w", err)
+		}
+		jobs.Checkpoint(ctx, jobs.StepPrepared)
 	}
 
 	// Transition status to Deploying before pod creation begins.
@@ -170,7 +180,7 @@ func (d *PodmanDeployer) prepareDeployment(ctx context.Context, plan *Deployment
 func (d *PodmanDeployer) downloadModelsForDeployment(ctx context.Context, plan *DeploymentPlan) error {
 	logger.InfofCtx(ctx, "Downloading models for application '%s'\n", plan.ApplicationName)
 
-	modelSet := d.collectModelsFromPlan(ctx, plan)
+	modelSet := CollectModels(ctx, plan)
 
 	if len(modelSet) == 0 {
 		logger.InfofCtx(ctx, "No models to download for application '%s'\n", plan.ApplicationName)
@@ -187,8 +197,9 @@ func (d *PodmanDeployer) downloadModelsForDeployment(ctx context.Context, plan *
 	return nil
 }
 
-// collectModelsFromPlan collects all unique model names from the deployment plan.
-func (d *PodmanDeployer) collectModelsFromPlan(ctx context.Context, plan *DeploymentPlan) map[string]bool {
+// CollectModels collects all unique model names the deployment of plan downloads.
+// Models are extracted from component params that contain "model" in their key name.
+func CollectModels(ctx context.Context, plan *DeploymentPlan) map[string]bool {
 	modelSet := make(map[string]bool)
 
 	// Extract models from component params
@@ -199,14 +210,14 @@ func (d *PodmanDeployer) collectModelsFromPlan(ctx context.Context, plan *Deploy
 
 			continue
 		}
-		d.extractModelsFromParams(comp.Params, modelSet)
+		extractModelsFromParams(comp.Params, modelSet)
 	}
 
 	return modelSet
 }
 
 // extractModelsFromParams extracts model names from parameter maps.
-func (d *PodmanDeployer) extractModelsFromParams(params map[string]any, modelSet map[string]bool) {
+func extractModelsFromParams(params map[string]any, modelSet map[string]bool) {
 	for key, value := range params {
 		if strings.Contains(strings.ToLower(key), "model") {
 			if modelName, ok := value.(string); ok && modelName != "" {
@@ -216,16 +227,23 @@ func (d *PodmanDeployer) extractModelsFromParams(params map[string]any, modelSet
 	}
 }
 
-// downloadModels downloads all models in the provided set.
+// downloadModels downloads all models in the provided set, emitting a model_download
+// event before and after each one.
 func (d *PodmanDeployer) downloadModels(ctx context.Context, modelSet map[string]bool) error {
 	modelsPath := utils.GetModelsPath()
+	modelNames := slices.Sorted(maps.Keys(modelSet))
 
-	for modelName := range modelSet {
+	for i, modelName := range modelNames {
 		logger.InfofCtx(ctx, "Downloading model: %s\n", modelName)
+		event := apimodels.ApplicationEvent{Step: apimodels.EventStepModelDownload, Target: modelName, Current: i + 1, Total: len(modelNames)}
+		emitStep(ctx, event, apimodels.EventStatusStarted, nil)
 
 		if err := helpers.DownloadModelContainer(ctx, modelName, modelsPath); err != nil {
+			emitStep(ctx, event, apimodels.EventStatusFailed, err)
+
 			return fmt.Errorf("failed to download model %s: %w", modelName, err)
 		}
+		emitStep(ctx, event, apimodels.EventStatusCompleted, nil)
 	}
 
 	return nil
@@ -235,7 +253,7 @@ func (d *PodmanDeployer) downloadModels(ctx context.Context, modelSet map[string
 func (d *PodmanDeployer) pullImagesForDeployment(ctx context.Context, plan *DeploymentPlan) error {
 	logger.InfofCtx(ctx, "Pulling container images for application '%s'\n", plan.ApplicationName)
 
-	imageSet, err := d.collectImagesFromPlan(ctx, plan)
+	imageSet, err := CollectImages(ctx, d.catalogProvider, plan)
 	if err != nil {
 		return fmt.Errorf("failed to collect images: %w", err)
 	}
@@ -255,8 +273,8 @@ func (d *PodmanDeployer) pullImagesForDeployment(ctx context.Context, plan *Depl
 	return nil
 }
 
-// collectImagesFromPlan collects all unique container images from the deployment plan.
-func (d *PodmanDeployer) collectImagesFromPlan(ctx context.Context, plan *DeploymentPlan) (map[string]bool, error) {
+// CollectImages collects all unique container images the deployment of plan pulls.
+func CollectImages(ctx context.Context, provider *catalog.CatalogProvider, plan *DeploymentPlan) (map[string]bool, error) {
 	imageSet := make(map[string]bool)
 
 	// Include tool image which is used for all housekeeping tasks
@@ -264,14 +282,14 @@ func (d *PodmanDeployer) collectImagesFromPlan(ctx context.Context, plan *Deploy
 
 	// Extract images from component templates
 	for _, comp := range plan.Components {
-		if err := d.extractImagesFromComponent(ctx, comp, imageSet); err != nil {
+		if err := extractImagesFromComponent(ctx, provider, comp, imageSet); err != nil {
 			return nil, err
 		}
 	}
 
 	// Extract images from service templates
 	for _, svc := range plan.Services {
-		if err := d.extractImagesFromService(ctx, svc, imageSet); err != nil {
+		if err := extractImagesFromService(ctx, provider, svc, imageSet); err != nil {
 			return nil, err
 		}
 	}
@@ -280,15 +298,15 @@ func (d *PodmanDeployer) collectImagesFromPlan(ctx context.Context, plan *Deploy
 }
 
 // extractImagesFromComponent extracts container images from a component's templates.
-func (d *PodmanDeployer) extractImagesFromComponent(ctx context.Context, comp *ComponentPlan, imageSet map[string]bool) error {
+func extractImagesFromComponent(ctx context.Context, provider *catalog.CatalogProvider, comp *ComponentPlan, imageSet map[string]bool) error {
 	// Load component templates
-	templates, err := d.catalogProvider.LoadComponentTemplates(comp.ComponentType, comp.ProviderID)
+	templates, err := provider.LoadComponentTemplates(comp.ComponentType, comp.ProviderID)
 	if err != nil {
 		return fmt.Errorf("failed to load component templates for %s/%s: %w", comp.ComponentType, comp.ProviderID, err)
 	}
 
 	// Extract images from templates with custom values directly into imageSet
-	if err := d.catalogProvider.CollectImagesFromTemplates(ctx, templates, comp.Values, imageSet); err != nil {
+	if err := provider.CollectImagesFromTemplates(ctx, templates, comp.Values, imageSet); err != nil {
 		return fmt.Errorf("failed to extract images from component %s/%s: %w", comp.ComponentType, comp.ProviderID, err)
 	}
 
@@ -296,37 +314,46 @@ func (d *PodmanDeployer) extractImagesFromComponent(ctx context.Context, comp *C
 }
 
 // extractImagesFromService extracts container images from a service's templates.
-func (d *PodmanDeployer) extractImagesFromService(ctx context.Context, svc *ServicePlan, imageSet map[string]bool) error {
+func extractImagesFromService(ctx context.Context, provider *catalog.CatalogProvider, svc *ServicePlan, imageSet map[string]bool) error {
 	// Load service templates
-	templates, err := d.catalogProvider.LoadServiceTemplates(svc.CatalogID)
+	templates, err := provider.LoadServiceTemplates(svc.CatalogID)
 	if err != nil {
 		return fmt.Errorf("failed to load service templates for %s: %w", svc.CatalogID, err)
 	}
 
 	// Extract images from templates with custom values directly into imageSet
-	if err := d.catalogProvider.CollectImagesFromTemplates(ctx, templates, svc.Values, imageSet); err != nil {
+	if err := provider.CollectImagesFromTemplates(ctx, templates, svc.Values, imageSet); err != nil {
 		return fmt.Errorf("failed to extract images from service %s: %w", svc.CatalogID, err)
 	}
 
 	return nil
 }
 
-// pullImages pulls only missing images from the provided set using the runtime.
-// Images that are already present locally are skipped.
+// pullImages pulls only missing images from the provided set using the runtime, emitting
+// an image_pull event before and after each one. Images that are already present locally
+// are skipped.
 func (d *PodmanDeployer) pullImages(ctx context.Context, imageSet map[string]bool) error {
-	// Convert map to slice
-	images := make([]string, 0, len(imageSet))
-	for img := range imageSet {
-		images = append(images, img)
+	missing, err := image.FetchImagesNotFound(d.runtime, slices.Sorted(maps.Keys(imageSet)))
+	if err != nil {
+		return fmt.Errorf("failed to pull images: %w", err)
 	}
 
-	// Use the image package's IfNotPresent method
-	imgHelper := &image.Images{
-		Runtime: d.runtime,
+	if len(missing) == 0 {
+		logger.InfolnCtx(ctx, "All required container images are already present locally.")
+
+		return nil
 	}
 
-	if err := imgHelper.IfNotPresent(ctx, images); err != nil {
-		return fmt.Errorf("failed to pull images: %w", err)
+	for i, img := range missing {
+		event := apimodels.ApplicationEvent{Step: apimodels.EventStepImagePull, Target: img, Current: i + 1, Total: len(missing)}
+		emitStep(ctx, event, apimodels.EventStatusStarted, nil)
+
+		if err := image.PullImageFromRegistry(ctx, d.runtime, []string{img}); err != nil {
+			emitStep(ctx, event, apimodels.EventStatusFailed, err)
+
+			return fmt.Errorf("failed to pull images: %w", err)
+		}
+		emitStep(ctx, event, apimodels.EventStatusCompleted, nil)
 	}
 
 	return nil
@@ -756,6 +783,20 @@ func (d *PodmanDeployer) deployComponentTemplate(
 		return err
 	}
 
+	// Check if pod already exists before allocating Spyre cards to it, so that the
+	// unchanged pods of a reconfigured application keep the cards they hold.
+	if podSpec.Name != "" {
+		if exists, err := d.runtime.PodExists(podSpec.Name); err != nil {
+			return fmt.Errorf("failed to check pod existence: %w", err)
+		} else if exists {
+			logger.InfofCtx(ctx, "Pod '%s' already exists, skipping deployment\n", podSpec.Name)
+			// Services deployed with this plan still need the endpoint of the running pod.
+			d.updateServiceParamsWithEndpoint(ctx, serviceParams, componentID, podSpec)
+
+			return nil
+		}
+	}
+
 	// Get environment parameters and render final template
 	finalPodSpec, renderedBytes, err := d.renderFinalPodTemplate(ctx, podTemplate, podTemplateName, initialParams, podSpec, plan)
 	if err != nil {
@@ -767,15 +808,6 @@ func (d *PodmanDeployer) deployComponentTemplate(
 		return nil
 	}
 
-	// Check if pod already exists
-	if exists, err := d.runtime.PodExists(finalPodSpec.Name); err != nil {
-		return fmt.Errorf("failed to check pod existence: %w", err)
-	} else if exists {
-		logger.InfofCtx(ctx, "Pod '%s' already exists, skipping deployment\n", podSpec.Name)
-
-		return nil
-	}
-
 	// Deploy the pod using rendered bytes directly
 	if err := d.deployPodSpec(ctx, finalPodSpec, renderedBytes, podTemplateName); err != nil {
 		return err
@@ -842,7 +874,8 @@ func (d *PodmanDeployer) renderFinalPodTemplate(
 	return &finalPodSpec, renderedBytes, nil
 }
 
-// deployPodSpec deploys a pod using the rendered YAML bytes directly.
+// deployPodSpec deploys a pod using the rendered YAML bytes directly and waits for it to
+// become ready, emitting pod_created and readiness_wait events.
 func (d *PodmanDeployer) deployPodSpec(ctx context.Context, podSpec *podmodels.PodSpec, renderedBytes []byte, templateName string) error {
 	// Use the rendered bytes directly instead of marshaling PodSpec
 
@@ -850,13 +883,36 @@ func (d *PodmanDeployer) deployPodSpec(ctx context.Context, podSpec *podmodels.P
 	podAnnotations := specs.FetchPodAnnotations(*podSpec)
 	podDeployOptions := clipodman.ConstructPodDeployOptions(podAnnotations)
 
-	if err := clipodman.DeployPodAndReadinessCheck(ctx, d.runtime, podSpec, templateName, reader, podDeployOptions); err != nil {
+	created := apimodels.ApplicationEvent{Step: apimodels.EventStepPodCreated, Target: podSpec.Name}
+	pods, err := d.runtime.CreatePod(ctx, reader, podDeployOptions)
+	if err != nil {
+		emitStep(ctx, created, apimodels.EventStatusFailed, err)
+
+		return fmt.Errorf("failed to deploy pod: failed pod creation: %w", err)
+	}
+	emitStep(ctx, created, apimodels.EventStatusCompleted, nil)
+
+	readiness := apimodels.ApplicationEvent{Step: apimodels.EventStepReadinessWait, Target: podSpec.Name}
+	emitStep(ctx, readiness, apimodels.EventStatusStarted, nil)
+	if err := clipodman.WaitForPodsReady(ctx, d.runtime, podSpec, templateName, pods); err != nil {
+		emitStep(ctx, readiness, apimodels.EventStatusFailed, err)
+
 		return fmt.Errorf("failed to deploy pod: %w", err)
 	}
+	emitStep(ctx, readiness, apimodels.EventStatusCompleted, nil)
 
 	return nil
 }
 
+// emitStep emits event with status; the cause of a failed step becomes its message.
+func emitStep(ctx context.Context, event apimodels.ApplicationEvent, status apimodels.EventStatus, cause error) {
+	event.Status = status
+	if cause != nil {
+		event.Message = cause.Error()
+	}
+	events.Emit(ctx, event)
+}
+
 // updateServiceParamsWithEndpoint updates service parameters with component endpoint information.
 func (d *PodmanDeployer) updateServiceParamsWithEndpoint(
 	ctx context.Context,
@@ -1150,6 +1206,7 @@ func (d *PodmanDeployer) registerServiceRoutes(
 		// Convert registered routes to endpoint format using route type
 		for _, route := range registeredRoutes {
 			url := catalogutils.BuildExternalURL(route.Domain, httpsPort)
+			emitStep(ctx, apimodels.ApplicationEvent{Step: apimodels.EventStepRouteRegistered, Target: url, Message: fmt.Sprintf("%s route of pod %s", route.Type, podName)}, apimodels.EventStatusCompleted, nil)
 
 			endpoint := map[string]any{
 				"type": route.Type,
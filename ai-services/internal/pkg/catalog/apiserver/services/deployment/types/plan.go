@@ -5,18 +5,22 @@ import (
 	"sync"
 
 	"github.com/google/uuid"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
 )
 
 // DeploymentPlan represents the complete deployment plan for an application.
 type DeploymentPlan struct {
-	ApplicationID   uuid.UUID                 // Generated application ID
-	ApplicationName string                    // Application name
-	CatalogID       string                    // Architecture or service catalog ID
-	Version         string                    // Application version from request
-	IsArchitecture  bool                      // true for architecture, false for standalone service
-	Components      map[string]*ComponentPlan // Key: component hash, Value: component plan
-	Services        map[string]*ServicePlan   // Key: service ID, Value: service plan
-	SpyreCardPool   *SpyreCardPool            // Allocated Spyre card pool (set after allocation)
+	ApplicationID   uuid.UUID                  // Generated application ID
+	ApplicationName string                     // Application name
+	CatalogID       string                     // Architecture or service catalog ID
+	Version         string                     // Application version from request
+	IsArchitecture  bool                       // true for architecture, false for standalone service
+	Components      map[string]*ComponentPlan  // Key: component hash, Value: component plan
+	Services        map[string]*ServicePlan    // Key: service ID, Value: service plan
+	SpyreCardPool   *SpyreCardPool             // Allocated Spyre card pool (set after allocation)
+	Resources       templates.RuntimeResources // Aggregated resource requirements of all services and components
+	WorkerID        *uuid.UUID                 // Worker the application is placed on; nil for the local runtime
+	WorkerName      string                     // Name of the worker the application is placed on
 }
 
 // ComponentPlan represents a single component deployment.
@@ -31,10 +35,12 @@ type ComponentPlan struct {
 	UsedByServices []string       // List of service IDs that use this component
 	Values         map[string]any // Structured values from LoadComponentValues
 	Endpoints      map[string]any // Extracted endpoints after deployment (populated by deployer)
+	SpyreCards     int            // Spyre cards requested by the component's pods (Podman only)
 }
 
 // ServicePlan represents a single service deployment.
 type ServicePlan struct {
+	Hash          string            // Hash of the service configuration and the components it uses
 	CatalogID     string            // Service catalog ID (e.g., "chat", "digitize")
 	CatalogPath   string            // Dynamic catalog path (e.g., "services/chat/podman")
 	DatabaseID    uuid.UUID         // Database UUID for this service record (set after DB insertion)
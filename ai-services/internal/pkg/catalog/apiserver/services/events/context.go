@@ -0,0 +1,33 @@
+package events
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+)
+
+// contextKey is the context key under which the event emitter of a deployment is stored.
+type contextKey struct{}
+
+// NewContext returns a copy of ctx whose Emit calls publish to r as events of the
+// application appID. A nil r returns ctx unchanged.
+func NewContext(ctx context.Context, r *Recorder, appID uuid.UUID) context.Context {
+	if r == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, contextKey{}, func(e models.ApplicationEvent) {
+		r.Publish(appID, e)
+	})
+}
+
+// Emit publishes e to the recorder attached to ctx by NewContext.
+// It does nothing when ctx carries no recorder, such as outside a deployment.
+func Emit(ctx context.Context, e models.ApplicationEvent) {
+	if emit, ok := ctx.Value(contextKey{}).(func(models.ApplicationEvent)); ok {
+		emit(e)
+	}
+}
+
+// Made with Bob
@@ -0,0 +1,150 @@
+// Package events records the progress events of application deployments. Deployers
+// emit structured step events through the deployment context; a Recorder keeps the
+// most recent ones of every application in a ring buffer and fans them out to the
+// clients following GET /api/v1/applications/:id/events.
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+)
+
+const (
+	// DefaultCapacity is how many events a Recorder keeps per application.
+	DefaultCapacity = 256
+
+	// subscriberBuffer is how many events a subscriber may fall behind before it is dropped.
+	subscriberBuffer = 64
+)
+
+// Recorder keeps the most recent events of every application and delivers new ones
+// to subscribers. It is safe for concurrent use.
+type Recorder struct {
+	mu       sync.Mutex
+	capacity int
+	streams  map[uuid.UUID]*stream
+}
+
+// stream holds the ring buffer and subscribers of one application.
+type stream struct {
+	ring    []models.ApplicationEvent
+	next    int // Slot the next event overwrites once the ring is full
+	lastSeq int64
+	subs    map[chan models.ApplicationEvent]struct{}
+}
+
+// NewRecorder creates a Recorder that keeps up to capacity events per application.
+func NewRecorder(capacity int) *Recorder {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+
+	return &Recorder{
+		capacity: capacity,
+		streams:  make(map[uuid.UUID]*stream),
+	}
+}
+
+// Publish numbers e, stamps it unless it already has a time, stores it and delivers it
+// to the application's subscribers. A subscriber whose buffer is full is dropped by
+// closing its channel; it can resubscribe from the last sequence number it received.
+func (r *Recorder) Publish(appID uuid.UUID, e models.ApplicationEvent) models.ApplicationEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := r.stream(appID)
+	s.lastSeq++
+	e.Seq = s.lastSeq
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+
+	if len(s.ring) < r.capacity {
+		s.ring = append(s.ring, e)
+	} else {
+		s.ring[s.next] = e
+		s.next = (s.next + 1) % r.capacity
+	}
+
+	for ch := range s.subs {
+		select {
+		case ch <- e:
+		default:
+			delete(s.subs, ch)
+			close(ch)
+		}
+	}
+
+	return e
+}
+
+// Subscribe returns the buffered events of the application numbered after afterSeq,
+// oldest first, and a channel receiving every event published from then on. The
+// channel is closed when the subscriber falls behind, when the application is
+// forgotten, or when cancel is called. An afterSeq beyond the last event, left over
+// from before a server restart, returns the whole buffer.
+func (r *Recorder) Subscribe(appID uuid.UUID, afterSeq int64) ([]models.ApplicationEvent, <-chan models.ApplicationEvent, func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := r.stream(appID)
+	if afterSeq > s.lastSeq {
+		afterSeq = 0
+	}
+
+	backlog := make([]models.ApplicationEvent, 0, len(s.ring))
+	for i := range s.ring {
+		if e := s.ring[(s.next+i)%len(s.ring)]; e.Seq > afterSeq {
+			backlog = append(backlog, e)
+		}
+	}
+
+	ch := make(chan models.ApplicationEvent, subscriberBuffer)
+	s.subs[ch] = struct{}{}
+
+	cancel := func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		if _, ok := s.subs[ch]; ok {
+			delete(s.subs, ch)
+			close(ch)
+		}
+	}
+
+	return backlog, ch, cancel
+}
+
+// Forget drops the events of the application and closes its subscribers' channels.
+// It is called once the application has been deleted.
+func (r *Recorder) Forget(appID uuid.UUID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.streams[appID]
+	if !ok {
+		return
+	}
+	for ch := range s.subs {
+		delete(s.subs, ch)
+		close(ch)
+	}
+	delete(r.streams, appID)
+}
+
+// stream returns the stream of the application, creating it on first use.
+// The caller must hold r.mu.
+func (r *Recorder) stream(appID uuid.UUID) *stream {
+	s, ok := r.streams[appID]
+	if !ok {
+		s = &stream{subs: make(map[chan models.ApplicationEvent]struct{})}
+		r.streams[appID] = s
+	}
+
+	return s
+}
+
+// Made with Bob
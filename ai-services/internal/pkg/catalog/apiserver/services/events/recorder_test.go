@@ -0,0 +1,119 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+)
+
+func seqs(events []models.ApplicationEvent) []int64 {
+	out := make([]int64, 0, len(events))
+	for _, e := range events {
+		out = append(out, e.Seq)
+	}
+
+	return out
+}
+
+func TestRecorderKeepsMostRecentEvents(t *testing.T) {
+	r := NewRecorder(3)
+	appID := uuid.New()
+
+	for range 5 {
+		r.Publish(appID, models.ApplicationEvent{Step: models.EventStepImagePull})
+	}
+	r.Publish(uuid.New(), models.ApplicationEvent{Step: models.EventStepImagePull})
+
+	backlog, _, cancel := r.Subscribe(appID, 0)
+	defer cancel()
+	assert.Equal(t, []int64{3, 4, 5}, seqs(backlog))
+	assert.False(t, backlog[0].Time.IsZero())
+
+	backlog, _, cancel2 := r.Subscribe(appID, 4)
+	defer cancel2()
+	assert.Equal(t, []int64{5}, seqs(backlog))
+
+	// A sequence number from before a restart replays the whole buffer.
+	backlog, _, cancel3 := r.Subscribe(appID, 42)
+	defer cancel3()
+	assert.Equal(t, []int64{3, 4, 5}, seqs(backlog))
+}
+
+func TestRecorderDeliversToSubscribers(t *testing.T) {
+	r := NewRecorder(DefaultCapacity)
+	appID := uuid.New()
+
+	backlog, ch, cancel := r.Subscribe(appID, 0)
+	assert.Empty(t, backlog)
+
+	r.Publish(appID, models.ApplicationEvent{Step: models.EventStepPodCreated, Target: "vllm"})
+	e := <-ch
+	assert.Equal(t, int64(1), e.Seq)
+	assert.Equal(t, "vllm", e.Target)
+
+	cancel()
+	_, open := <-ch
+	assert.False(t, open)
+	cancel()
+}
+
+func TestRecorderDropsSlowSubscribers(t *testing.T) {
+	r := NewRecorder(DefaultCapacity)
+	appID := uuid.New()
+
+	_, ch, cancel := r.Subscribe(appID, 0)
+	defer cancel()
+
+	for range subscriberBuffer + 1 {
+		r.Publish(appID, models.ApplicationEvent{Step: models.EventStepModelDownload})
+	}
+
+	received := 0
+	for range ch {
+		received++
+	}
+	assert.Equal(t, subscriberBuffer, received)
+}
+
+func TestRecorderForget(t *testing.T) {
+	r := NewRecorder(DefaultCapacity)
+	appID := uuid.New()
+
+	r.Publish(appID, models.ApplicationEvent{Step: models.EventStepDeployment})
+	_, ch, cancel := r.Subscribe(appID, 0)
+	defer cancel()
+
+	r.Forget(appID)
+	_, open := <-ch
+	assert.False(t, open)
+
+	backlog, _, cancel2 := r.Subscribe(appID, 0)
+	defer cancel2()
+	assert.Empty(t, backlog)
+}
+
+func TestEmit(t *testing.T) {
+	r := NewRecorder(DefaultCapacity)
+	appID := uuid.New()
+
+	// Without a recorder in the context Emit is a no-op.
+	Emit(context.Background(), models.ApplicationEvent{Step: models.EventStepDeployment})
+
+	ctx := NewContext(context.Background(), r, appID)
+	Emit(ctx, models.ApplicationEvent{Step: models.EventStepDeployment, Status: models.EventStatusFailed, Message: "boom"})
+
+	backlog, _, cancel := r.Subscribe(appID, 0)
+	defer cancel()
+	require.Len(t, backlog, 1)
+	assert.True(t, backlog[0].IsFinal())
+	assert.Equal(t, "boom", backlog[0].Message)
+
+	assert.Equal(t, context.Background(), NewContext(context.Background(), nil, appID))
+}
+
+// Made with Bob
@@ -0,0 +1,230 @@
+// Package httpproxy forwards HTTP requests received by the catalog API server to a
+// port of an application pod. Pods on the local runtime are reached directly; pods on
+// a worker are reached through the worker's COMMAND_TYPE_HTTP_PROXY tunnel.
+package httpproxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+
+	"github.com/google/uuid"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/remote"
+	workerpb "github.com/project-ai-services/ai-services/internal/pkg/worker/proto"
+)
+
+// MaxTunnelBodyBytes caps the body of a request sent through a worker tunnel. The body
+// travels inside a single Command message, which must stay below the gRPC message limit.
+const MaxTunnelBodyBytes = 2 << 20
+
+// hopHeaders are connection-scoped headers that must not be forwarded (RFC 9110 §7.6.1),
+// plus Authorization, which carries the caller's catalog token.
+var hopHeaders = []string{
+	"Authorization",
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Proxy-Connection",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// Target is the pod port a request is forwarded to.
+type Target struct {
+	// WorkerName is the worker running the pod; empty for the local runtime.
+	WorkerName string `json:"worker_name,omitempty"`
+	Pod        string `json:"pod"`
+	Port       string `json:"port"`
+}
+
+// Forwarder sends requests to targets and copies the responses back.
+type Forwarder struct {
+	dispatcher remote.Dispatcher
+	transport  http.RoundTripper
+}
+
+// NewForwarder creates a forwarder that reaches workers through dispatcher.
+// *registry.Registry satisfies remote.Dispatcher.
+func NewForwarder(dispatcher remote.Dispatcher) *Forwarder {
+	return &Forwarder{dispatcher: dispatcher, transport: http.DefaultTransport}
+}
+
+// Forward sends r to path on target and writes the pod's response to w. Failures to
+// reach the pod are answered with 502 Bad Gateway.
+func (f *Forwarder) Forward(w http.ResponseWriter, r *http.Request, target Target, path string) {
+	if target.WorkerName == "" {
+		f.forwardLocal(w, r, target, path)
+
+		return
+	}
+
+	f.forwardToWorker(w, r, target, path)
+}
+
+// forwardLocal proxies the request straight to the pod on the pod network.
+func (f *Forwarder) forwardLocal(w http.ResponseWriter, r *http.Request, target Target, path string) {
+	proxy := &httputil.ReverseProxy{
+		Transport: f.transport,
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.Out.URL.Scheme = "http"
+			pr.Out.URL.Host = net.JoinHostPort(target.Pod, target.Port)
+			pr.Out.URL.Path = path
+			pr.Out.URL.RawPath = ""
+			pr.Out.Host = ""
+			pr.Out.Header.Del("Authorization")
+			pr.SetXForwarded()
+		},
+		// Flush immediately so event streams are not held back.
+		FlushInterval: -1,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			logger.WarningfCtx(r.Context(), "Failed to proxy request to pod %s: %v", target.Pod, err)
+			writeError(w, http.StatusBadGateway, fmt.Sprintf("failed to reach pod %s", target.Pod))
+		},
+	}
+	proxy.ServeHTTP(w, r)
+}
+
+// forwardToWorker sends the request through the worker tunnel and streams the response.
+func (f *Forwarder) forwardToWorker(w http.ResponseWriter, r *http.Request, target Target, path string) {
+	cmd, err := newProxyCommand(w, r, target, path)
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			writeError(w, http.StatusRequestEntityTooLarge,
+				fmt.Sprintf("request body exceeds %d bytes", MaxTunnelBodyBytes))
+
+			return
+		}
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	rw := &responseWriter{w: w}
+	res, err := f.dispatcher.DispatchStream(r.Context(), target.WorkerName, cmd, rw.write)
+
+	switch {
+	case rw.started:
+		// The response is already on its way; a failure can only truncate it.
+		if err == nil && !res.GetSuccess() {
+			err = errors.New(res.GetError())
+		}
+		if err != nil {
+			logger.WarningfCtx(r.Context(), "Proxied response from worker %s was interrupted: %v", target.WorkerName, err)
+		}
+	case err != nil:
+		writeError(w, http.StatusBadGateway, err.Error())
+	case !res.GetSuccess():
+		writeError(w, http.StatusBadGateway, res.GetError())
+	default:
+		writeError(w, http.StatusBadGateway, "worker returned no response")
+	}
+}
+
+// newProxyCommand encodes r as a COMMAND_TYPE_HTTP_PROXY command.
+func newProxyCommand(w http.ResponseWriter, r *http.Request, target Target, path string) (*workerpb.Command, error) {
+	var body []byte
+	if r.Body != nil {
+		buf := &bytes.Buffer{}
+		if _, err := buf.ReadFrom(http.MaxBytesReader(w, r.Body, MaxTunnelBodyBytes)); err != nil {
+			return nil, err
+		}
+		body = buf.Bytes()
+	}
+
+	header := r.Header.Clone()
+	for _, h := range hopHeaders {
+		header.Del(h)
+	}
+
+	payload, err := json.Marshal(remote.HTTPProxyRequest{
+		Pod:      target.Pod,
+		Port:     target.Port,
+		Method:   r.Method,
+		Path:     path,
+		RawQuery: r.URL.RawQuery,
+		Header:   header,
+		Body:     body,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode proxy request: %w", err)
+	}
+
+	return &workerpb.Command{
+		CommandId: uuid.NewString(),
+		Type:      workerpb.CommandType_COMMAND_TYPE_HTTP_PROXY,
+		Payload:   payload,
+	}, nil
+}
+
+// responseWriter turns the tunnel output back into an HTTP response: the first line
+// is the remote.HTTPProxyResponse head, the rest is the body.
+type responseWriter struct {
+	w       http.ResponseWriter
+	head    []byte
+	started bool
+}
+
+// write consumes one chunk of tunnel output.
+func (rw *responseWriter) write(chunk []byte) error {
+	if !rw.started {
+		rw.head = append(rw.head, chunk...)
+		i := bytes.IndexByte(rw.head, '\n')
+		if i < 0 {
+			return nil
+		}
+
+		var head remote.HTTPProxyResponse
+		if err := json.Unmarshal(rw.head[:i], &head); err != nil {
+			return fmt.Errorf("invalid proxy response head: %w", err)
+		}
+		rw.writeHead(head)
+		chunk = rw.head[i+1:]
+		rw.head = nil
+	}
+
+	if len(chunk) == 0 {
+		return nil
+	}
+	if _, err := rw.w.Write(chunk); err != nil {
+		return err
+	}
+	if flusher, ok := rw.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+
+	return nil
+}
+
+// writeHead copies the pod's status and headers to the client.
+func (rw *responseWriter) writeHead(head remote.HTTPProxyResponse) {
+	for _, h := range hopHeaders {
+		head.Header.Del(h)
+	}
+	// The body length is unknown once it has been streamed in frames.
+	head.Header.Del("Content-Length")
+	for k, values := range head.Header {
+		for _, v := range values {
+			rw.w.Header().Add(k, v)
+		}
+	}
+	rw.w.WriteHeader(head.StatusCode)
+	rw.started = true
+}
+
+// writeError writes an error body in the API server's usual shape.
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
+
+// Made with Bob
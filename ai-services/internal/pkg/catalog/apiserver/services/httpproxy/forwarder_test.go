@@ -0,0 +1,136 @@
+package httpproxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/remote"
+	workerpb "github.com/project-ai-services/ai-services/internal/pkg/worker/proto"
+)
+
+// fakeDispatcher answers HTTP_PROXY commands with canned output chunks.
+type fakeDispatcher struct {
+	chunks []string
+	result *workerpb.CommandResult
+	err    error
+	got    remote.HTTPProxyRequest
+}
+
+func (f *fakeDispatcher) Dispatch(context.Context, string, *workerpb.Command) (*workerpb.CommandResult, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeDispatcher) DispatchStream(_ context.Context, _ string, cmd *workerpb.Command, onChunk func([]byte) error) (*workerpb.CommandResult, error) {
+	if err := json.Unmarshal(cmd.GetPayload(), &f.got); err != nil {
+		return nil, err
+	}
+	for _, c := range f.chunks {
+		if err := onChunk([]byte(c)); err != nil {
+			return nil, err
+		}
+	}
+
+	return f.result, f.err
+}
+
+func TestForward_ThroughWorker(t *testing.T) {
+	d := &fakeDispatcher{
+		// The head line is split across frames and shares a frame with the body.
+		chunks: []string{`{"status_code":201,"header":{"Content-Type":["text/event-stream"],`, `"Connection":["close"]}}` + "\ndata: 1\n\n", "data: 2\n\n"},
+		result: &workerpb.CommandResult{Success: true, EndOfStream: true},
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/applications/x/services/chat/proxy/v1/chat?stream=true", strings.NewReader(`{"q":"hi"}`))
+	req.Header.Set("Authorization", "Bearer catalog-token")
+	req.Header.Set("X-Request-ID", "abc")
+	rec := httptest.NewRecorder()
+
+	NewForwarder(d).Forward(rec, req, Target{WorkerName: "worker-1", Pod: "chat--backend", Port: "5000"}, "/v1/chat")
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, body %q", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("Content-Type = %q", got)
+	}
+	if rec.Header().Get("Connection") != "" {
+		t.Error("hop-by-hop header was copied to the client")
+	}
+	if rec.Body.String() != "data: 1\n\ndata: 2\n\n" {
+		t.Errorf("body = %q", rec.Body.String())
+	}
+
+	if d.got.Pod != "chat--backend" || d.got.Port != "5000" || d.got.Method != http.MethodPost ||
+		d.got.Path != "/v1/chat" || d.got.RawQuery != "stream=true" || string(d.got.Body) != `{"q":"hi"}` {
+		t.Errorf("forwarded request = %+v", d.got)
+	}
+	if d.got.Header.Get("Authorization") != "" {
+		t.Error("the catalog token was forwarded to the service")
+	}
+	if d.got.Header.Get("X-Request-ID") != "abc" {
+		t.Errorf("headers = %v", d.got.Header)
+	}
+}
+
+func TestForward_WorkerFailureBeforeResponse(t *testing.T) {
+	d := &fakeDispatcher{result: &workerpb.CommandResult{Error: "failed to reach pod chat on port 5000", EndOfStream: true}}
+	rec := httptest.NewRecorder()
+
+	NewForwarder(d).Forward(rec, httptest.NewRequest(http.MethodGet, "/", nil), Target{WorkerName: "w", Pod: "chat", Port: "5000"}, "/")
+
+	if rec.Code != http.StatusBadGateway || !strings.Contains(rec.Body.String(), "failed to reach pod") {
+		t.Errorf("got %d %q, want 502 with the worker error", rec.Code, rec.Body.String())
+	}
+}
+
+func TestForward_TunnelBodyLimit(t *testing.T) {
+	d := &fakeDispatcher{}
+	body := strings.NewReader(strings.Repeat("x", MaxTunnelBodyBytes+1))
+	rec := httptest.NewRecorder()
+
+	NewForwarder(d).Forward(rec, httptest.NewRequest(http.MethodPost, "/", body), Target{WorkerName: "w", Pod: "p", Port: "1"}, "/")
+
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want 413", rec.Code)
+	}
+}
+
+func TestForward_Local(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		fmt.Fprintf(w, "%s %s?%s auth=%q %s", r.Method, r.URL.Path, r.URL.RawQuery, r.Header.Get("Authorization"), body)
+	}))
+	defer upstream.Close()
+
+	host, port, _ := net.SplitHostPort(strings.TrimPrefix(upstream.URL, "http://"))
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/applications/x/services/chat/proxy/v1/items?id=7", strings.NewReader("data"))
+	req.Header.Set("Authorization", "Bearer catalog-token")
+	rec := httptest.NewRecorder()
+
+	NewForwarder(nil).Forward(rec, req, Target{Pod: host, Port: port}, "/v1/items")
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %q", rec.Code, rec.Body.String())
+	}
+	if want := `PUT /v1/items?id=7 auth="" data`; rec.Body.String() != want {
+		t.Errorf("body = %q, want %q", rec.Body.String(), want)
+	}
+}
+
+func TestForward_LocalUnreachable(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	NewForwarder(nil).Forward(rec, httptest.NewRequest(http.MethodGet, "/", nil), Target{Pod: "127.0.0.1", Port: "1"}, "/")
+
+	if rec.Code != http.StatusBadGateway {
+		t.Errorf("status = %d, want 502", rec.Code)
+	}
+}
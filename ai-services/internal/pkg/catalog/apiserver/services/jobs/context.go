@@ -0,0 +1,84 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+// Checkpoints of deployment jobs. A resumed deployment skips the steps that are
+// expensive to repeat; the others record progress, as their re-run finds what an
+// earlier attempt deployed and keeps it.
+const (
+	StepPruned        = "pruned"        // A reconfiguration removed what its new plan no longer contains
+	StepPrepared      = "prepared"      // Images were pulled and models downloaded (Podman)
+	StepPrerequisites = "prerequisites" // Prerequisite charts were installed (OpenShift)
+	StepComponents    = "components"    // Every component was deployed
+	StepServices      = "services"      // Every service was deployed
+	StepRoutes        = "routes"        // The routes of the services were registered (Podman)
+)
+
+type contextKey struct{}
+
+// jobState is the job a context belongs to, with the runner that records its checkpoints.
+type jobState struct {
+	runner *Runner
+	job    *models.Job
+
+	mu        sync.Mutex
+	completed []string
+}
+
+// newContext returns a copy of ctx carrying the job.
+func newContext(ctx context.Context, r *Runner, job *models.Job) context.Context {
+	return context.WithValue(ctx, contextKey{}, &jobState{runner: r, job: job, completed: slices.Clone(job.CompletedSteps)})
+}
+
+// Checkpoint records that the job running with ctx completed step, so that a resumed
+// attempt can skip it. It is a no-op outside a job. A checkpoint that cannot be
+// recorded only makes a resumed attempt repeat the step, so failures are logged.
+func Checkpoint(ctx context.Context, step string) {
+	state, ok := ctx.Value(contextKey{}).(*jobState)
+	if !ok {
+		return
+	}
+
+	state.mu.Lock()
+	if slices.Contains(state.completed, step) {
+		state.mu.Unlock()
+
+		return
+	}
+	state.completed = append(state.completed, step)
+	state.mu.Unlock()
+
+	if err := state.runner.repo.AddCheckpoint(context.WithoutCancel(ctx), state.job.ID, step); err != nil {
+		logger.WarningfCtx(ctx, "Failed to record checkpoint %q of job %s: %v", step, state.job.ID, err)
+	}
+}
+
+// Completed reports whether the job running with ctx passed step, in this attempt or
+// an earlier one. It is false outside a job.
+func Completed(ctx context.Context, step string) bool {
+	state, ok := ctx.Value(contextKey{}).(*jobState)
+	if !ok {
+		return false
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	return slices.Contains(state.completed, step)
+}
+
+// Cancelled reports whether the job running with ctx was cancelled by request, rather
+// than interrupted by a shutdown or by losing its lease.
+func Cancelled(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), ErrCancelled)
+}
+
+// Made with Bob
@@ -0,0 +1,371 @@
+// Package jobs runs application deployments and deletions as durable jobs. A job is
+// recorded in the database before it starts and holds a lease its runner renews while
+// it runs. When the apiserver restarts mid-job the lease expires, and the runner of the
+// next apiserver instance claims the job and either resumes it from its last checkpoint
+// or rolls it back.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	dbrepo "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+const (
+	// DefaultLeaseDuration is how long a job stays leased to its runner without a renewal.
+	DefaultLeaseDuration = 30 * time.Second
+
+	// MaxAttempts is how many times a job is started before an interrupted one is rolled
+	// back instead of resumed.
+	MaxAttempts = 3
+
+	// renewalsPerLease is how many times a lease is renewed within its duration, so that
+	// a late renewal does not let it expire.
+	renewalsPerLease = 3
+)
+
+var (
+	// ErrCancelled is the cause of the context of a job that was cancelled by request.
+	ErrCancelled = errors.New("job cancelled")
+
+	// errStopped is the cause of the context of a job interrupted by Stop. The job stays
+	// running in the database and is resumed once its lease expires.
+	errStopped = errors.New("job runner stopped")
+)
+
+// Handler runs the jobs of one kind.
+type Handler struct {
+	// Run performs the job. A job interrupted by a restart is run again, so Run must be
+	// idempotent; Completed tells it which checkpoints an earlier attempt passed.
+	Run func(ctx context.Context, job *models.Job) error
+	// RollBack settles the application of an interrupted job that is not resumed,
+	// because it was cancelled or has been started MaxAttempts times. reason says why.
+	RollBack func(ctx context.Context, job *models.Job, reason string) error
+}
+
+// Runner starts jobs, keeps their leases, and resumes or rolls back the jobs other
+// runners left behind. It is safe for concurrent use.
+type Runner struct {
+	repo     dbrepo.JobRepository
+	owner    string
+	lease    time.Duration
+	handlers map[models.JobKind]Handler
+	stopChan chan struct{}
+
+	mu      sync.Mutex
+	running map[uuid.UUID]context.CancelCauseFunc
+}
+
+// NewRunner creates a Runner whose jobs are leased for leaseDuration. Handlers must be
+// registered before Start.
+func NewRunner(repo dbrepo.JobRepository, leaseDuration time.Duration) *Runner {
+	if leaseDuration <= 0 {
+		leaseDuration = DefaultLeaseDuration
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "apiserver"
+	}
+
+	return &Runner{
+		repo:     repo,
+		owner:    hostname + "-" + uuid.NewString()[:8],
+		lease:    leaseDuration,
+		handlers: make(map[models.JobKind]Handler),
+		stopChan: make(chan struct{}),
+		running:  make(map[uuid.UUID]context.CancelCauseFunc),
+	}
+}
+
+// Register sets the handler of the jobs of the given kind.
+func (r *Runner) Register(kind models.JobKind, handler Handler) {
+	r.handlers[kind] = handler
+}
+
+// Submit records a job of the given kind for the application and starts it in the
+// background. The job runs detached from ctx but keeps its request ID.
+func (r *Runner) Submit(ctx context.Context, kind models.JobKind, appID uuid.UUID, createdBy string, payload any) (*models.Job, error) {
+	if _, ok := r.handlers[kind]; !ok {
+		return nil, fmt.Errorf("no handler registered for %s jobs", kind)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode job payload: %w", err)
+	}
+
+	leaseExpiresAt := time.Now().Add(r.lease)
+	job := &models.Job{
+		Kind:           kind,
+		ApplicationID:  appID,
+		Payload:        data,
+		LeaseOwner:     r.owner,
+		LeaseExpiresAt: &leaseExpiresAt,
+		CreatedBy:      createdBy,
+	}
+	if err := r.repo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+
+	base := context.Background()
+	if id, ok := ctx.Value(logger.RequestIDKey).(string); ok && id != "" {
+		base = context.WithValue(base, logger.RequestIDKey, id)
+	}
+
+	// Track the job before returning, so that a cancellation right after Submit reaches it.
+	jobCtx := r.track(base, job)
+	go r.execute(jobCtx, job)
+
+	return job, nil
+}
+
+// Get returns the job, or nil if it does not exist.
+func (r *Runner) Get(ctx context.Context, id uuid.UUID) (*models.Job, error) {
+	return r.repo.GetByID(ctx, id)
+}
+
+// Cancel requests the cancellation of a running job. A job running on this instance
+// stops right away; one running elsewhere stops when its runner next renews the lease.
+// Returns false if the job is not running.
+func (r *Runner) Cancel(ctx context.Context, id uuid.UUID) (bool, error) {
+	ok, err := r.repo.RequestCancel(ctx, id)
+	if err != nil || !ok {
+		return false, err
+	}
+	r.cancelLocal(id, ErrCancelled)
+
+	return true, nil
+}
+
+// CancelApplication cancels the running create and reconfigure jobs of an application.
+func (r *Runner) CancelApplication(ctx context.Context, appID uuid.UUID) error {
+	ids, err := r.repo.RequestCancelForApplication(ctx, appID, []models.JobKind{models.JobKindCreate, models.JobKindReconfigure})
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		r.cancelLocal(id, ErrCancelled)
+	}
+
+	return nil
+}
+
+// Start begins claiming the jobs whose lease expired: right away, to pick up the jobs
+// interrupted by the previous shutdown, and then once per lease duration.
+func (r *Runner) Start(ctx context.Context) {
+	go r.recoveryLoop(ctx)
+	logger.InfolnCtx(ctx, "Job runner started")
+}
+
+// Stop stops claiming jobs and interrupts the jobs running on this instance. They stay
+// running in the database and are resumed once their lease expires.
+func (r *Runner) Stop(ctx context.Context) {
+	close(r.stopChan)
+
+	r.mu.Lock()
+	for _, cancel := range r.running {
+		cancel(errStopped)
+	}
+	r.mu.Unlock()
+
+	logger.InfolnCtx(ctx, "Job runner stopped")
+}
+
+// recoveryLoop periodically claims the jobs whose lease expired.
+func (r *Runner) recoveryLoop(ctx context.Context) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			logger.ErrorfCtx(ctx, "Panic recovered in job recovery goroutine: %v", rec)
+		}
+	}()
+
+	ticker := time.NewTicker(r.lease)
+	defer ticker.Stop()
+
+	r.claimExpired(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			r.claimExpired(ctx)
+		case <-r.stopChan:
+			return
+		}
+	}
+}
+
+// claimExpired claims the jobs whose lease expired and resumes or rolls back each.
+func (r *Runner) claimExpired(ctx context.Context) {
+	jobs, err := r.repo.ClaimExpired(ctx, r.owner, time.Now().Add(r.lease))
+	if err != nil {
+		logger.ErrorfCtx(ctx, "Failed to claim interrupted jobs: %v", err)
+
+		return
+	}
+
+	for i := range jobs {
+		r.resume(ctx, &jobs[i])
+	}
+}
+
+// resume restarts a claimed job, or rolls it back when it was cancelled or has been
+// started too often.
+func (r *Runner) resume(ctx context.Context, job *models.Job) {
+	r.mu.Lock()
+	_, local := r.running[job.ID]
+	r.mu.Unlock()
+	if local {
+		// The lease of a job running here expired while the database was unreachable.
+		return
+	}
+
+	handler, ok := r.handlers[job.Kind]
+	if !ok {
+		r.finish(ctx, job, models.JobStatusFailed, fmt.Sprintf("no handler registered for %s jobs", job.Kind))
+
+		return
+	}
+
+	var reason string
+	switch {
+	case job.CancelRequested:
+		reason = "cancelled while the apiserver was down"
+	case job.Attempts > MaxAttempts:
+		reason = fmt.Sprintf("interrupted %d times", job.Attempts-1)
+	}
+
+	if reason != "" {
+		logger.InfofCtx(ctx, "Rolling back %s job %s of application %s: %s", job.Kind, job.ID, job.ApplicationID, reason)
+		if err := handler.RollBack(ctx, job, reason); err != nil {
+			logger.ErrorfCtx(ctx, "Failed to roll back job %s: %v", job.ID, err)
+		}
+		r.finish(ctx, job, models.JobStatusRolledBack, reason)
+
+		return
+	}
+
+	logger.InfofCtx(ctx, "Resuming %s job %s of application %s (attempt %d)", job.Kind, job.ID, job.ApplicationID, job.Attempts)
+	jobCtx := r.track(context.Background(), job)
+	go r.execute(jobCtx, job)
+}
+
+// track derives the cancellable context a job runs with and registers it, so that
+// Cancel and Stop reach the job.
+func (r *Runner) track(base context.Context, job *models.Job) context.Context {
+	ctx, cancel := context.WithCancelCause(newContext(base, r, job))
+
+	r.mu.Lock()
+	r.running[job.ID] = cancel
+	r.mu.Unlock()
+
+	return ctx
+}
+
+// cancelLocal cancels a job running on this instance with the given cause.
+func (r *Runner) cancelLocal(id uuid.UUID, cause error) {
+	r.mu.Lock()
+	cancel, ok := r.running[id]
+	r.mu.Unlock()
+
+	if ok {
+		cancel(cause)
+	}
+}
+
+// execute runs a tracked job, renewing its lease meanwhile, and records its outcome.
+func (r *Runner) execute(ctx context.Context, job *models.Job) {
+	defer func() {
+		r.mu.Lock()
+		cancel := r.running[job.ID]
+		delete(r.running, job.ID)
+		r.mu.Unlock()
+
+		if cancel != nil {
+			cancel(nil)
+		}
+	}()
+
+	go r.keepLease(ctx, job.ID)
+
+	err := r.run(ctx, job)
+
+	cause := context.Cause(ctx)
+	finishCtx := context.WithoutCancel(ctx)
+	switch {
+	case errors.Is(cause, dbrepo.ErrLeaseLost), errors.Is(cause, errStopped):
+		logger.InfofCtx(finishCtx, "Job %s interrupted: %v", job.ID, cause)
+	case errors.Is(cause, ErrCancelled):
+		r.finish(finishCtx, job, models.JobStatusCancelled, "cancelled")
+	case err != nil:
+		r.finish(finishCtx, job, models.JobStatusFailed, err.Error())
+	default:
+		r.finish(finishCtx, job, models.JobStatusSucceeded, "")
+	}
+}
+
+// run calls the handler of the job, turning a panic into an error.
+func (r *Runner) run(ctx context.Context, job *models.Job) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			logger.ErrorfCtx(ctx, "Panic recovered in job %s: %v", job.ID, rec)
+			err = fmt.Errorf("job panic: %v", rec)
+		}
+	}()
+
+	return r.handlers[job.Kind].Run(ctx, job)
+}
+
+// keepLease renews the lease of a running job until ctx is done. It cancels the job
+// when a cancellation was requested through another instance, or when the lease was
+// lost to another runner.
+func (r *Runner) keepLease(ctx context.Context, id uuid.UUID) {
+	ticker := time.NewTicker(r.lease / renewalsPerLease)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		cancelRequested, err := r.repo.RenewLease(ctx, id, r.owner, time.Now().Add(r.lease))
+		switch {
+		case errors.Is(err, dbrepo.ErrLeaseLost):
+			r.cancelLocal(id, dbrepo.ErrLeaseLost)
+
+			return
+		case err != nil:
+			if ctx.Err() == nil {
+				logger.WarningfCtx(ctx, "Failed to renew lease of job %s: %v", id, err)
+			}
+		case cancelRequested:
+			r.cancelLocal(id, ErrCancelled)
+
+			return
+		}
+	}
+}
+
+// finish records the final status of a job.
+func (r *Runner) finish(ctx context.Context, job *models.Job, status models.JobStatus, errMsg string) {
+	if err := r.repo.Finish(ctx, job.ID, r.owner, status, errMsg); err != nil {
+		logger.ErrorfCtx(ctx, "Failed to record %s outcome of job %s: %v", status, job.ID, err)
+
+		return
+	}
+	job.Status = status
+	job.Error = errMsg
+}
+
+// Made with Bob
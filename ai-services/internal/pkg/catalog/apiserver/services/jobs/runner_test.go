@@ -0,0 +1,302 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	dbrepo "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+)
+
+// fakeJobRepo is an in-memory JobRepository.
+type fakeJobRepo struct {
+	mu   sync.Mutex
+	jobs map[uuid.UUID]*models.Job
+}
+
+func newFakeJobRepo() *fakeJobRepo {
+	return &fakeJobRepo{jobs: make(map[uuid.UUID]*models.Job)}
+}
+
+func (f *fakeJobRepo) Create(_ context.Context, job *models.Job) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	job.ID = uuid.New()
+	job.Status = models.JobStatusRunning
+	job.Attempts = 1
+	stored := *job
+	f.jobs[job.ID] = &stored
+
+	return nil
+}
+
+func (f *fakeJobRepo) GetByID(_ context.Context, id uuid.UUID) (*models.Job, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	job, ok := f.jobs[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *job
+	copied.CompletedSteps = slices.Clone(job.CompletedSteps)
+
+	return &copied, nil
+}
+
+func (f *fakeJobRepo) RenewLease(_ context.Context, id uuid.UUID, owner string, until time.Time) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	job := f.jobs[id]
+	if job.LeaseOwner != owner || job.Status != models.JobStatusRunning {
+		return false, dbrepo.ErrLeaseLost
+	}
+	job.LeaseExpiresAt = &until
+
+	return job.CancelRequested, nil
+}
+
+func (f *fakeJobRepo) ClaimExpired(_ context.Context, owner string, until time.Time) ([]models.Job, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var claimed []models.Job
+	for _, job := range f.jobs {
+		if job.Status == models.JobStatusRunning && (job.LeaseExpiresAt == nil || job.LeaseExpiresAt.Before(time.Now())) {
+			job.LeaseOwner = owner
+			job.LeaseExpiresAt = &until
+			job.Attempts++
+			claimed = append(claimed, *job)
+		}
+	}
+
+	return claimed, nil
+}
+
+func (f *fakeJobRepo) AddCheckpoint(_ context.Context, id uuid.UUID, step string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if job := f.jobs[id]; !slices.Contains(job.CompletedSteps, step) {
+		job.CompletedSteps = append(job.CompletedSteps, step)
+	}
+
+	return nil
+}
+
+func (f *fakeJobRepo) RequestCancel(_ context.Context, id uuid.UUID) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	job, ok := f.jobs[id]
+	if !ok || job.Status != models.JobStatusRunning {
+		return false, nil
+	}
+	job.CancelRequested = true
+
+	return true, nil
+}
+
+func (f *fakeJobRepo) RequestCancelForApplication(_ context.Context, appID uuid.UUID, kinds []models.JobKind) ([]uuid.UUID, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var ids []uuid.UUID
+	for _, job := range f.jobs {
+		if job.ApplicationID == appID && job.Status == models.JobStatusRunning && slices.Contains(kinds, job.Kind) {
+			job.CancelRequested = true
+			ids = append(ids, job.ID)
+		}
+	}
+
+	return ids, nil
+}
+
+func (f *fakeJobRepo) Finish(_ context.Context, id uuid.UUID, owner string, status models.JobStatus, errMsg string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	job := f.jobs[id]
+	if job.LeaseOwner != owner || job.Status != models.JobStatusRunning {
+		return dbrepo.ErrLeaseLost
+	}
+	job.Status = status
+	job.Error = errMsg
+	job.LeaseOwner = ""
+	job.LeaseExpiresAt = nil
+
+	return nil
+}
+
+// insert stores a running job whose lease expired, as left behind by a stopped apiserver.
+func (f *fakeJobRepo) insert(job models.Job) uuid.UUID {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	job.ID = uuid.New()
+	job.Status = models.JobStatusRunning
+	f.jobs[job.ID] = &job
+
+	return job.ID
+}
+
+// waitForStatus waits until the job has the given status.
+func waitForStatus(t *testing.T, repo *fakeJobRepo, id uuid.UUID, status models.JobStatus) *models.Job {
+	t.Helper()
+
+	var job *models.Job
+	require.Eventually(t, func() bool {
+		job, _ = repo.GetByID(context.Background(), id)
+
+		return job.Status == status
+	}, 2*time.Second, 5*time.Millisecond, "job did not reach status %s", status)
+
+	return job
+}
+
+func TestRunnerSubmit(t *testing.T) {
+	repo := newFakeJobRepo()
+	runner := NewRunner(repo, time.Minute)
+	runner.Register(models.JobKindCreate, Handler{
+		Run: func(ctx context.Context, _ *models.Job) error {
+			Checkpoint(ctx, StepComponents)
+			Checkpoint(ctx, StepComponents)
+			assert.True(t, Completed(ctx, StepComponents))
+
+			return nil
+		},
+	})
+	runner.Register(models.JobKindDelete, Handler{
+		Run: func(context.Context, *models.Job) error { return errors.New("boom") },
+	})
+
+	job, err := runner.Submit(context.Background(), models.JobKindCreate, uuid.New(), "alice", map[string]string{"k": "v"})
+	require.NoError(t, err)
+	done := waitForStatus(t, repo, job.ID, models.JobStatusSucceeded)
+	assert.Equal(t, []string{StepComponents}, done.CompletedSteps)
+	assert.JSONEq(t, `{"k":"v"}`, string(done.Payload))
+
+	job, err = runner.Submit(context.Background(), models.JobKindDelete, uuid.New(), "alice", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "boom", waitForStatus(t, repo, job.ID, models.JobStatusFailed).Error)
+
+	_, err = runner.Submit(context.Background(), models.JobKindReconfigure, uuid.New(), "alice", nil)
+	assert.Error(t, err, "kinds without a handler are rejected")
+}
+
+func TestRunnerCancelApplication(t *testing.T) {
+	repo := newFakeJobRepo()
+	runner := NewRunner(repo, time.Minute)
+	cancelled := make(chan bool, 1)
+	runner.Register(models.JobKindCreate, Handler{
+		Run: func(ctx context.Context, _ *models.Job) error {
+			<-ctx.Done()
+			cancelled <- Cancelled(ctx)
+
+			return ctx.Err()
+		},
+	})
+
+	appID := uuid.New()
+	job, err := runner.Submit(context.Background(), models.JobKindCreate, appID, "alice", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, runner.CancelApplication(context.Background(), appID))
+	assert.True(t, <-cancelled)
+	assert.True(t, waitForStatus(t, repo, job.ID, models.JobStatusCancelled).CancelRequested)
+
+	ok, err := runner.Cancel(context.Background(), job.ID)
+	require.NoError(t, err)
+	assert.False(t, ok, "a finished job cannot be cancelled")
+}
+
+func TestRunnerResumesInterruptedJobs(t *testing.T) {
+	repo := newFakeJobRepo()
+	runner := NewRunner(repo, time.Minute)
+
+	var (
+		mu         sync.Mutex
+		resumed    []string
+		rolledBack = make(map[uuid.UUID]string)
+	)
+	runner.Register(models.JobKindCreate, Handler{
+		Run: func(ctx context.Context, job *models.Job) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if Completed(ctx, StepPrepared) {
+				resumed = append(resumed, string(job.Payload))
+			}
+
+			return nil
+		},
+		RollBack: func(_ context.Context, job *models.Job, reason string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			rolledBack[job.ID] = reason
+
+			return nil
+		},
+	})
+
+	resume := repo.insert(models.Job{Kind: models.JobKindCreate, Payload: []byte("resume"), Attempts: 1, CompletedSteps: []string{StepPrepared}})
+	cancelled := repo.insert(models.Job{Kind: models.JobKindCreate, Attempts: 1, CancelRequested: true})
+	exhausted := repo.insert(models.Job{Kind: models.JobKindCreate, Attempts: MaxAttempts})
+	unknown := repo.insert(models.Job{Kind: models.JobKindDelete, Attempts: 1})
+
+	runner.claimExpired(context.Background())
+
+	job := waitForStatus(t, repo, resume, models.JobStatusSucceeded)
+	assert.Equal(t, 2, job.Attempts)
+	waitForStatus(t, repo, cancelled, models.JobStatusRolledBack)
+	waitForStatus(t, repo, exhausted, models.JobStatusRolledBack)
+	waitForStatus(t, repo, unknown, models.JobStatusFailed)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"resume"}, resumed)
+	assert.Equal(t, map[uuid.UUID]string{
+		cancelled: "cancelled while the apiserver was down",
+		exhausted: "interrupted 3 times",
+	}, rolledBack)
+}
+
+func TestRunnerStopLeavesJobsRunning(t *testing.T) {
+	repo := newFakeJobRepo()
+	runner := NewRunner(repo, time.Minute)
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+	runner.Register(models.JobKindCreate, Handler{
+		Run: func(ctx context.Context, _ *models.Job) error {
+			close(started)
+			<-ctx.Done()
+			defer close(stopped)
+			assert.False(t, Cancelled(ctx))
+
+			return ctx.Err()
+		},
+	})
+
+	job, err := runner.Submit(context.Background(), models.JobKindCreate, uuid.New(), "alice", nil)
+	require.NoError(t, err)
+	<-started
+
+	runner.Stop(context.Background())
+	<-stopped
+
+	stored, err := repo.GetByID(context.Background(), job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.JobStatusRunning, stored.Status)
+	assert.Equal(t, runner.owner, stored.LeaseOwner)
+}
+
+// Made with Bob
@@ -0,0 +1,371 @@
+// Package scheduler places applications onto registered workers. It compares the
+// resources a deployment plan needs against the live capacity every ready worker
+// reports through GetSystemInfo and picks the worker with the most headroom.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	rtmodels "github.com/project-ai-services/ai-services/internal/pkg/models"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
+)
+
+// probeTimeout bounds how long the scheduler waits for a worker to report its capacity.
+const probeTimeout = 15 * time.Second
+
+// WorkerSource lists the workers known to the control plane.
+// *registry.Registry satisfies this interface.
+type WorkerSource interface {
+	List(ctx context.Context) ([]models.Worker, error)
+	IsConnected(workerName string) bool
+}
+
+// SystemInfoProber reads the current capacity of a worker.
+type SystemInfoProber func(ctx context.Context, w models.Worker) (*rtmodels.SystemInfo, error)
+
+// RuntimeProber calls GetSystemInfo on the worker through its remote runtime.
+func RuntimeProber(_ context.Context, w models.Worker) (*rtmodels.SystemInfo, error) {
+	rt, err := vars.RuntimeFactory.CreateForWorker(w.Name, runtimeTypes.RuntimeType(w.RuntimeType), "")
+	if err != nil {
+		return nil, err
+	}
+
+	return rt.GetSystemInfo()
+}
+
+// Request describes what an application needs from the worker it is placed on.
+type Request struct {
+	// WorkerID pins the application to one worker. Optional.
+	WorkerID *uuid.UUID
+	// Selector restricts placement to workers whose metadata contains every pair. Optional.
+	Selector map[string]string
+	// RuntimeType is the runtime the application was planned for; workers must match it.
+	RuntimeType runtimeTypes.RuntimeType
+	// Resources are the aggregated requirements computed by the DeploymentPlanner.
+	Resources templates.RuntimeResources
+}
+
+// constrained reports whether the caller asked for a specific worker or label set.
+func (r Request) constrained() bool {
+	return r.WorkerID != nil || len(r.Selector) > 0
+}
+
+// Placement is the worker chosen for an application, with the capacity it reported.
+type Placement struct {
+	Worker     models.Worker
+	SystemInfo *rtmodels.SystemInfo
+}
+
+// PlacementError is returned when no worker can host the application.
+// Reasons explains, per rejected worker, why it was not chosen.
+type PlacementError struct {
+	Message string
+	Reasons []string
+}
+
+func (e *PlacementError) Error() string {
+	if len(e.Reasons) == 0 {
+		return e.Message
+	}
+
+	return fmt.Sprintf("%s: %s", e.Message, strings.Join(e.Reasons, "; "))
+}
+
+// Scheduler chooses the worker an application is deployed on.
+type Scheduler struct {
+	workers WorkerSource
+	probe   SystemInfoProber
+}
+
+// New creates a scheduler over workers. probe reads a worker's capacity; pass
+// RuntimeProber to query workers through the gateway.
+func New(workers WorkerSource, probe SystemInfoProber) *Scheduler {
+	return &Scheduler{workers: workers, probe: probe}
+}
+
+// Schedule picks the worker for req. It returns (nil, nil) when the request is not
+// constrained and no worker is ready, meaning the application runs on the control
+// plane's local runtime. A *PlacementError is returned when workers are available
+// but none matches the constraints or has enough free capacity.
+func (s *Scheduler) Schedule(ctx context.Context, req Request) (*Placement, error) {
+	workers, err := s.workers.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workers: %w", err)
+	}
+
+	candidates, reasons := s.filterCandidates(workers, req)
+	if len(candidates) == 0 {
+		if !req.constrained() {
+			return nil, nil
+		}
+
+		return nil, &PlacementError{Message: noWorkerMessage(req), Reasons: reasons}
+	}
+
+	placements, probeReasons := s.probeCandidates(ctx, candidates, req.Resources)
+	reasons = append(reasons, probeReasons...)
+	if len(placements) == 0 {
+		return nil, &PlacementError{Message: noWorkerMessage(req), Reasons: reasons}
+	}
+
+	sort.SliceStable(placements, func(i, j int) bool {
+		return moreHeadroom(placements[i], placements[j])
+	})
+
+	best := placements[0]
+	logger.InfofCtx(ctx, "Scheduler placed application on worker %s\n", best.Worker.Name)
+
+	return &best, nil
+}
+
+// filterCandidates keeps the workers that match the request's constraints and can
+// accept commands. Matching workers that cannot are reported in reasons.
+func (s *Scheduler) filterCandidates(workers []models.Worker, req Request) ([]models.Worker, []string) {
+	var (
+		candidates []models.Worker
+		reasons    []string
+		found      bool
+	)
+
+	for _, w := range workers {
+		if req.WorkerID != nil && w.ID != *req.WorkerID {
+			continue
+		}
+		if !matchesSelector(w, req.Selector) {
+			continue
+		}
+		found = true
+
+		if reason := s.unavailableReason(w, req.RuntimeType); reason != "" {
+			reasons = append(reasons, fmt.Sprintf("%s: %s", w.Name, reason))
+
+			continue
+		}
+
+		candidates = append(candidates, w)
+	}
+
+	if req.WorkerID != nil && !found {
+		reasons = append(reasons, fmt.Sprintf("worker %s does not exist", req.WorkerID))
+	}
+
+	return candidates, reasons
+}
+
+// unavailableReason explains why w cannot receive the application, or returns "".
+func (s *Scheduler) unavailableReason(w models.Worker, runtimeType runtimeTypes.RuntimeType) string {
+	switch {
+	case w.Status != models.WorkerStatusReady:
+		return fmt.Sprintf("status is %s", w.Status)
+	case !w.Schedulable:
+		return "cordoned"
+	case !s.workers.IsConnected(w.Name):
+		return "not connected to this control plane"
+	case string(w.RuntimeType) != string(runtimeType):
+		return fmt.Sprintf("runs %s, application requires %s", w.RuntimeType, runtimeType)
+	default:
+		return ""
+	}
+}
+
+// probeCandidates reads the capacity of all candidates concurrently and returns those
+// with enough free resources.
+func (s *Scheduler) probeCandidates(ctx context.Context, candidates []models.Worker, res templates.RuntimeResources) ([]Placement, []string) {
+	type result struct {
+		placement Placement
+		reason    string
+	}
+
+	results := make([]result, len(candidates))
+
+	var wg sync.WaitGroup
+	for i, w := range candidates {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			info, err := ProbeWithTimeout(ctx, s.probe, w)
+			if err != nil {
+				results[i].reason = fmt.Sprintf("%s: failed to read capacity: %v", w.Name, err)
+
+				return
+			}
+			if reason := InsufficientReason(info, res); reason != "" {
+				results[i].reason = fmt.Sprintf("%s: %s", w.Name, reason)
+
+				return
+			}
+			results[i].placement = Placement{Worker: w, SystemInfo: info}
+		}()
+	}
+	wg.Wait()
+
+	placements := make([]Placement, 0, len(results))
+	var reasons []string
+	for _, r := range results {
+		if r.reason != "" {
+			reasons = append(reasons, r.reason)
+
+			continue
+		}
+		placements = append(placements, r.placement)
+	}
+
+	return placements, reasons
+}
+
+// ProbeWithTimeout calls probe for w, giving up after probeTimeout.
+func ProbeWithTimeout(ctx context.Context, probe SystemInfoProber, w models.Worker) (*rtmodels.SystemInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	type outcome struct {
+		info *rtmodels.SystemInfo
+		err  error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		info, err := probe(ctx, w)
+		done <- outcome{info: info, err: err}
+	}()
+
+	select {
+	case o := <-done:
+		if o.err == nil && o.info == nil {
+			return nil, fmt.Errorf("no system information reported")
+		}
+
+		return o.info, o.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// InsufficientReason compares the free capacity in info against res and describes the
+// first shortfall, or returns "" when the worker can host the application. A resource
+// the worker does not report counts as unavailable.
+func InsufficientReason(info *rtmodels.SystemInfo, res templates.RuntimeResources) string {
+	if res.CPU > 0 {
+		available := 0.0
+		if info.CPU != nil {
+			available = info.CPU.Available
+		}
+		if available < float64(res.CPU) {
+			return fmt.Sprintf("insufficient CPU: need %d cores, available %.1f", res.CPU, available)
+		}
+	}
+
+	if res.Memory > 0 {
+		var available int64
+		if info.Memory != nil {
+			available = info.Memory.AvailableBytes
+		}
+		if available < int64(res.Memory) {
+			return fmt.Sprintf("insufficient memory: need %s, available %s",
+				utils.FormatBytes(int64(res.Memory)), utils.FormatBytes(available))
+		}
+	}
+
+	names := make([]string, 0, len(res.Accelerators))
+	for name := range res.Accelerators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		need := res.Accelerators[name]
+		available := 0
+		if acc := info.Accelerators[name]; acc != nil {
+			available = acc.Available
+		}
+		if need > 0 && available < need {
+			return fmt.Sprintf("insufficient %s: need %d, available %d", name, need, available)
+		}
+	}
+
+	return ""
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// Internal helpers
+// ──────────────────────────────────────────────────────────────────────────────
+
+// matchesSelector reports whether the worker metadata contains every selector pair.
+func matchesSelector(w models.Worker, selector map[string]string) bool {
+	for k, v := range selector {
+		got, ok := w.Metadata[k]
+		if !ok || fmt.Sprint(got) != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// moreHeadroom orders placements by free memory, then free CPU, then name, so the
+// least loaded worker is chosen and ties are broken deterministically.
+func moreHeadroom(a, b Placement) bool {
+	memA, memB := freeMemory(a.SystemInfo), freeMemory(b.SystemInfo)
+	if memA != memB {
+		return memA > memB
+	}
+
+	cpuA, cpuB := freeCPU(a.SystemInfo), freeCPU(b.SystemInfo)
+	if cpuA != cpuB {
+		return cpuA > cpuB
+	}
+
+	return a.Worker.Name < b.Worker.Name
+}
+
+func freeMemory(info *rtmodels.SystemInfo) int64 {
+	if info.Memory == nil {
+		return 0
+	}
+
+	return info.Memory.AvailableBytes
+}
+
+func freeCPU(info *rtmodels.SystemInfo) float64 {
+	if info.CPU == nil {
+		return 0
+	}
+
+	return info.CPU.Available
+}
+
+// noWorkerMessage builds the headline of a PlacementError for req.
+func noWorkerMessage(req Request) string {
+	switch {
+	case req.WorkerID != nil:
+		return fmt.Sprintf("worker %s cannot host the application", req.WorkerID)
+	case len(req.Selector) > 0:
+		return fmt.Sprintf("no worker matching selector %s can host the application", formatSelector(req.Selector))
+	default:
+		return "no worker can host the application"
+	}
+}
+
+// formatSelector renders a selector as sorted k=v pairs.
+func formatSelector(selector map[string]string) string {
+	pairs := make([]string, 0, len(selector))
+	for k, v := range selector {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
+
+// Made with Bob
@@ -0,0 +1,242 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/cli/templates"
+	"github.com/project-ai-services/ai-services/internal/pkg/constants"
+	rtmodels "github.com/project-ai-services/ai-services/internal/pkg/models"
+	runtimeTypes "github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+)
+
+const gib = 1 << 30
+
+// fakeWorkers is an in-memory WorkerSource.
+type fakeWorkers struct {
+	workers   []models.Worker
+	connected map[string]bool
+}
+
+func (f *fakeWorkers) List(context.Context) ([]models.Worker, error) { return f.workers, nil }
+func (f *fakeWorkers) IsConnected(name string) bool                  { return f.connected[name] }
+
+// fakeProber returns canned system info per worker name.
+func fakeProber(infos map[string]*rtmodels.SystemInfo) SystemInfoProber {
+	return func(_ context.Context, w models.Worker) (*rtmodels.SystemInfo, error) {
+		info, ok := infos[w.Name]
+		if !ok {
+			return nil, errors.New("unreachable")
+		}
+		return info, nil
+	}
+}
+
+func readyWorker(name string, metadata map[string]any) models.Worker {
+	return models.Worker{
+		ID:          uuid.New(),
+		Name:        name,
+		RuntimeType: models.WorkerRuntimeTypePodman,
+		Status:      models.WorkerStatusReady,
+		Schedulable: true,
+		Metadata:    metadata,
+	}
+}
+
+func sysInfo(cpu float64, memGiB int64, spyre int) *rtmodels.SystemInfo {
+	info := &rtmodels.SystemInfo{
+		CPU:          &rtmodels.CPUInfo{Total: int(cpu), Available: cpu},
+		Memory:       &rtmodels.MemoryInfo{TotalBytes: memGiB * gib, AvailableBytes: memGiB * gib},
+		Accelerators: map[string]*rtmodels.AcceleratorInfo{},
+	}
+	if spyre > 0 {
+		info.Accelerators[constants.SpyreResourceName] = &rtmodels.AcceleratorInfo{Total: spyre, Available: spyre}
+	}
+	return info
+}
+
+func newTestScheduler(workers []models.Worker, infos map[string]*rtmodels.SystemInfo) *Scheduler {
+	connected := make(map[string]bool, len(workers))
+	for _, w := range workers {
+		connected[w.Name] = true
+	}
+	return New(&fakeWorkers{workers: workers, connected: connected}, fakeProber(infos))
+}
+
+func request(cpu int, memGiB int, spyre int) Request {
+	res := templates.RuntimeResources{CPU: cpu, Memory: memGiB * gib, Accelerators: map[string]int{}}
+	if spyre > 0 {
+		res.Accelerators[constants.SpyreResourceName] = spyre
+	}
+	return Request{RuntimeType: runtimeTypes.RuntimeTypePodman, Resources: res}
+}
+
+func TestSchedule_NoWorkersFallsBackToLocal(t *testing.T) {
+	s := newTestScheduler(nil, nil)
+
+	placement, err := s.Schedule(context.Background(), request(1, 1, 0))
+	if err != nil || placement != nil {
+		t.Fatalf("Schedule = %v, %v; want nil, nil", placement, err)
+	}
+}
+
+func TestSchedule_PicksWorkerWithMostHeadroom(t *testing.T) {
+	workers := []models.Worker{readyWorker("small", nil), readyWorker("large", nil)}
+	s := newTestScheduler(workers, map[string]*rtmodels.SystemInfo{
+		"small": sysInfo(8, 16, 0),
+		"large": sysInfo(8, 64, 0),
+	})
+
+	placement, err := s.Schedule(context.Background(), request(2, 8, 0))
+	if err != nil {
+		t.Fatalf("Schedule: %v", err)
+	}
+	if placement.Worker.Name != "large" {
+		t.Errorf("placed on %q, want large", placement.Worker.Name)
+	}
+}
+
+func TestSchedule_SkipsWorkersWithoutCapacity(t *testing.T) {
+	workers := []models.Worker{readyWorker("cpu-only", nil), readyWorker("spyre", nil)}
+	s := newTestScheduler(workers, map[string]*rtmodels.SystemInfo{
+		"cpu-only": sysInfo(32, 256, 0),
+		"spyre":    sysInfo(16, 200, 4),
+	})
+
+	placement, err := s.Schedule(context.Background(), request(8, 150, 4))
+	if err != nil {
+		t.Fatalf("Schedule: %v", err)
+	}
+	if placement.Worker.Name != "spyre" {
+		t.Errorf("placed on %q, want spyre", placement.Worker.Name)
+	}
+}
+
+func TestSchedule_NothingFits(t *testing.T) {
+	workers := []models.Worker{readyWorker("a", nil), readyWorker("b", nil)}
+	s := newTestScheduler(workers, map[string]*rtmodels.SystemInfo{
+		"a": sysInfo(2, 64, 0),
+		"b": sysInfo(16, 4, 0),
+	})
+
+	_, err := s.Schedule(context.Background(), request(4, 8, 0))
+
+	var placementErr *PlacementError
+	if !errors.As(err, &placementErr) {
+		t.Fatalf("expected a PlacementError, got %v", err)
+	}
+	msg := err.Error()
+	for _, want := range []string{"a: insufficient CPU", "b: insufficient memory"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("error %q does not mention %q", msg, want)
+		}
+	}
+}
+
+func TestSchedule_ExplicitWorkerID(t *testing.T) {
+	pinned := readyWorker("pinned", nil)
+	workers := []models.Worker{readyWorker("bigger", nil), pinned}
+	s := newTestScheduler(workers, map[string]*rtmodels.SystemInfo{
+		"bigger": sysInfo(64, 512, 0),
+		"pinned": sysInfo(8, 32, 0),
+	})
+
+	req := request(1, 1, 0)
+	req.WorkerID = &pinned.ID
+
+	placement, err := s.Schedule(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Schedule: %v", err)
+	}
+	if placement.Worker.ID != pinned.ID {
+		t.Errorf("placed on %q, want pinned", placement.Worker.Name)
+	}
+}
+
+func TestSchedule_UnknownWorkerID(t *testing.T) {
+	s := newTestScheduler([]models.Worker{readyWorker("a", nil)}, map[string]*rtmodels.SystemInfo{"a": sysInfo(8, 8, 0)})
+
+	req := request(1, 1, 0)
+	id := uuid.New()
+	req.WorkerID = &id
+
+	_, err := s.Schedule(context.Background(), req)
+	if err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Fatalf("expected an unknown-worker error, got %v", err)
+	}
+}
+
+func TestSchedule_Selector(t *testing.T) {
+	workers := []models.Worker{
+		readyWorker("lab", map[string]any{"zone": "lab"}),
+		readyWorker("prod", map[string]any{"zone": "prod"}),
+	}
+	s := newTestScheduler(workers, map[string]*rtmodels.SystemInfo{
+		"lab":  sysInfo(8, 512, 0),
+		"prod": sysInfo(8, 32, 0),
+	})
+
+	req := request(1, 1, 0)
+	req.Selector = map[string]string{"zone": "prod"}
+
+	placement, err := s.Schedule(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Schedule: %v", err)
+	}
+	if placement.Worker.Name != "prod" {
+		t.Errorf("placed on %q, want prod", placement.Worker.Name)
+	}
+
+	req.Selector = map[string]string{"zone": "edge"}
+	if _, err := s.Schedule(context.Background(), req); err == nil {
+		t.Error("expected an error when no worker matches the selector")
+	}
+}
+
+func TestSchedule_IgnoresUnusableWorkers(t *testing.T) {
+	disconnected := readyWorker("disconnected", nil)
+	disconnected.Status = models.WorkerStatusDisconnected
+	openshift := readyWorker("openshift", nil)
+	openshift.RuntimeType = models.WorkerRuntimeTypeOpenShift
+	stale := readyWorker("stale", nil) // ready in the DB but without a live stream
+	cordoned := readyWorker("cordoned", nil)
+	cordoned.Schedulable = false
+
+	workers := []models.Worker{disconnected, openshift, stale, cordoned}
+	s := New(&fakeWorkers{workers: workers, connected: map[string]bool{"disconnected": true, "openshift": true, "cordoned": true}},
+		fakeProber(map[string]*rtmodels.SystemInfo{
+			"disconnected": sysInfo(64, 512, 0),
+			"openshift":    sysInfo(64, 512, 0),
+			"stale":        sysInfo(64, 512, 0),
+			"cordoned":     sysInfo(64, 512, 0),
+		}))
+
+	placement, err := s.Schedule(context.Background(), request(1, 1, 0))
+	if err != nil || placement != nil {
+		t.Fatalf("Schedule = %v, %v; want local fallback", placement, err)
+	}
+
+	req := request(1, 1, 0)
+	req.WorkerID = &stale.ID
+	if _, err := s.Schedule(context.Background(), req); err == nil || !strings.Contains(err.Error(), "not connected") {
+		t.Errorf("expected a not-connected error, got %v", err)
+	}
+
+	req.WorkerID = &cordoned.ID
+	if _, err := s.Schedule(context.Background(), req); err == nil || !strings.Contains(err.Error(), "cordoned: cordoned") {
+		t.Errorf("expected a cordoned error, got %v", err)
+	}
+}
+
+func TestSchedule_UnreachableWorker(t *testing.T) {
+	s := newTestScheduler([]models.Worker{readyWorker("gone", nil)}, nil)
+
+	_, err := s.Schedule(context.Background(), request(1, 1, 0))
+	if err == nil || !strings.Contains(err.Error(), "failed to read capacity") {
+		t.Fatalf("expected a probe error, got %v", err)
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/uuid"
 	catalogpkg "github.com/project-ai-services/ai-services/internal/pkg/catalog"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/services/webhook"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
 	dbrepo "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
 	catalogutils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
@@ -54,6 +55,7 @@ type SyncService struct {
 	syncMutex       sync.Mutex  // Prevents overlapping sync cycles
 	isSyncing       bool        // Tracks if a sync is currently running
 	runtimeSync     RuntimeSync // Runtime-specific sync backend
+	notifier        webhook.Notifier
 }
 
 // newRuntimeSync constructs the appropriate RuntimeSync for the configured runtime type.
@@ -101,6 +103,13 @@ func NewSyncService(
 	}, nil
 }
 
+// SetNotifier sets the notifier that status transitions are sent to as webhook events:
+// an application or service turning from Running to Error, and an application recovering.
+// It must be called before Start; without a notifier no events are sent.
+func (s *SyncService) SetNotifier(notifier webhook.Notifier) {
+	s.notifier = notifier
+}
+
 // Start begins the sync goroutine.
 func (s *SyncService) Start(ctx context.Context) {
 	go s.syncLoop(ctx)
@@ -169,7 +178,8 @@ func (s *SyncService) performSync(ctx context.Context) {
 		return
 	}
 
-	// Filter applications that need syncing (Running or Error state)
+	// Filter applications that need syncing (Running or Error state). Stopped applications
+	// are left alone: their pods are not running on purpose.
 	for _, app := range applications {
 		if app.Status == models.ApplicationStatusRunning || app.Status == models.ApplicationStatusError {
 			if err := s.syncApplication(ctx, &app); err != nil {
@@ -186,8 +196,8 @@ func (s *SyncService) performSync(ctx context.Context) {
 // 2. Sync services
 // 3. Update application status based on collected errors.
 func (s *SyncService) syncApplication(ctx context.Context, app *models.Application) error {
-	// Initialize runtime client in the application namespace.
-	rt, err := vars.RuntimeFactory.Create(catalogutils.AppNamespace(app.ID))
+	// Initialize runtime client in the application namespace, on the worker hosting it.
+	rt, err := catalogutils.AppRuntime(ctx, app.WorkerID, catalogutils.AppNamespace(app.ID))
 	if err != nil {
 		return fmt.Errorf("failed to create runtime client: %w", err)
 	}
@@ -239,7 +249,8 @@ func (s *SyncService) syncApplication(ctx context.Context, app *models.Applicati
 
 // syncAllComponents syncs all components for an application.
 // Returns error messages and a pending flag — pending is true if any component was
-// skipped because it has not yet reached a stable (Running/Error) state.
+// skipped because it has not yet reached a stable (Running/Error) state. Components
+// stopped on purpose are skipped without holding back the application status.
 func (s *SyncService) syncAllComponents(ctx context.Context, rt runtime.Runtime, app *models.Application) ([]string, bool) { //nolint:cyclop
 	processedComponents := make(map[uuid.UUID]bool)
 	errorMessages := []string{}
@@ -273,6 +284,13 @@ func (s *SyncService) syncAllComponents(ctx context.Context, rt runtime.Runtime,
 				continue
 			}
 
+			// Stopped components are expected not to run.
+			if component.Status == models.ComponentStatusStopped {
+				processedComponents[dep.DependencyID] = true
+
+				continue
+			}
+
 			if component.Status != models.ComponentStatusRunning && component.Status != models.ComponentStatusError {
 				logger.InfofCtx(ctx, "Skipping component %s sync: status is %s", dep.DependencyID, component.Status)
 				processedComponents[dep.DependencyID] = true
@@ -300,12 +318,18 @@ func (s *SyncService) syncAllComponents(ctx context.Context, rt runtime.Runtime,
 // syncAllServices syncs all services for an application.
 // Service status is determined ONLY by the service pod health, not component health.
 // Returns error messages and a pending flag — pending is true if any service was
-// skipped because it has not yet reached a stable (Running/Error) state.
+// skipped because it has not yet reached a stable (Running/Error) state. Services
+// stopped on purpose are skipped without holding back the application status.
 func (s *SyncService) syncAllServices(ctx context.Context, rt runtime.Runtime, app *models.Application) ([]string, bool) {
 	errorMessages := []string{}
 	pending := false
 
 	for _, service := range app.Services {
+		// Stopped services are expected not to run.
+		if service.Status == models.ServiceStatusStopped {
+			continue
+		}
+
 		// Only sync services that are in a stable, observable state
 		if service.Status != models.ServiceStatusRunning && service.Status != models.ServiceStatusError {
 			logger.InfofCtx(ctx, "Skipping service %s sync: status is %s", service.ID, service.Status)
@@ -406,6 +430,17 @@ func (s *SyncService) updateServiceStatusIfChanged(ctx context.Context, service
 			return fmt.Errorf("failed to update service status: %w", err)
 		}
 		logger.InfofCtx(ctx, "Updated service %s status to %s", service.ID, newStatus)
+
+		if s.notifier != nil && service.Status == models.ServiceStatusRunning && newStatus == models.ServiceStatusError {
+			s.notifier.Notify(ctx, webhook.EventServiceFailed, webhook.ServiceEventData{
+				ApplicationID:  service.AppID,
+				ServiceID:      service.ID,
+				CatalogID:      service.CatalogID,
+				Status:         string(newStatus),
+				PreviousStatus: string(service.Status),
+				Message:        message,
+			})
+		}
 	}
 
 	return nil
@@ -543,7 +578,32 @@ func (s *SyncService) updateApplicationStatus(ctx context.Context, app *models.A
 			return fmt.Errorf("failed to update application status: %w", err)
 		}
 		logger.InfofCtx(ctx, "Updated application %s status to %s", app.Name, newStatus)
+		s.notifyApplicationTransition(ctx, app, newStatus, message)
 	}
 
 	return nil
 }
+
+// notifyApplicationTransition sends the webhook event of an application turning from
+// Running to Error or back.
+func (s *SyncService) notifyApplicationTransition(ctx context.Context, app *models.Application, newStatus models.ApplicationStatus, message string) {
+	var event webhook.EventType
+	switch {
+	case s.notifier == nil:
+		return
+	case app.Status == models.ApplicationStatusRunning && newStatus == models.ApplicationStatusError:
+		event = webhook.EventApplicationFailed
+	case app.Status == models.ApplicationStatusError && newStatus == models.ApplicationStatusRunning:
+		event = webhook.EventApplicationRecovered
+	default:
+		return
+	}
+
+	s.notifier.Notify(ctx, event, webhook.ApplicationEventData{
+		ApplicationID:   app.ID,
+		ApplicationName: app.Name,
+		Status:          string(newStatus),
+		PreviousStatus:  string(app.Status),
+		Message:         message,
+	})
+}
@@ -0,0 +1,177 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository"
+	catalogutils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+)
+
+// userService implements UserServiceInterface.
+type userService struct {
+	users repository.UserRepository
+}
+
+// NewUserService creates a new userService backed by the given UserRepository.
+func NewUserService(users repository.UserRepository) UserServiceInterface {
+	return &userService{users: users}
+}
+
+// CreateUser validates the request, hashes the password and stores the user.
+func (s *userService) CreateUser(ctx context.Context, req CreateUserRequest) (*UserResponse, error) {
+	username := strings.TrimSpace(req.Username)
+	if username == "" {
+		return nil, &validators.ValidationError{Code: http.StatusBadRequest, Message: "username is required"}
+	}
+
+	role := models.RoleViewer
+	if req.Role != "" {
+		r, err := models.ParseRole(req.Role)
+		if err != nil {
+			return nil, &validators.ValidationError{Code: http.StatusBadRequest, Message: err.Error()}
+		}
+		role = r
+	}
+
+	hash, err := hashPassword(req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	u := &models.User{UserName: username, Name: req.Name, PasswordHash: hash, Role: role}
+	if err := s.users.Create(ctx, u); err != nil {
+		if errors.Is(err, repository.ErrUserExists) {
+			return nil, &validators.ValidationError{Code: http.StatusConflict, Message: fmt.Sprintf("user %q already exists", username)}
+		}
+
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	resp := newUserResponse(u)
+
+	return &resp, nil
+}
+
+// ListUsers returns all users ordered by username.
+func (s *userService) ListUsers(ctx context.Context) (*UserListResponse, error) {
+	users, err := s.users.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	resp := &UserListResponse{Users: make([]UserResponse, 0, len(users))}
+	for _, u := range users {
+		resp.Users = append(resp.Users, newUserResponse(u))
+	}
+
+	return resp, nil
+}
+
+// GetUser returns a single user.
+func (s *userService) GetUser(ctx context.Context, ref string) (*UserResponse, error) {
+	u, err := s.lookup(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := newUserResponse(u)
+
+	return &resp, nil
+}
+
+// SetDisabled disables or re-enables a user.
+func (s *userService) SetDisabled(ctx context.Context, callerID, ref string, disabled bool) (*UserResponse, error) {
+	u, err := s.lookup(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if disabled && u.ID == callerID {
+		return nil, &validators.ValidationError{Code: http.StatusConflict, Message: "you cannot disable your own user"}
+	}
+
+	if err := s.users.SetDisabled(ctx, u.ID, disabled); err != nil {
+		return nil, s.writeError(err, ref)
+	}
+
+	return s.GetUser(ctx, u.ID)
+}
+
+// DeleteUser deletes a user.
+func (s *userService) DeleteUser(ctx context.Context, callerID, ref string) error {
+	u, err := s.lookup(ctx, ref)
+	if err != nil {
+		return err
+	}
+	if u.ID == callerID {
+		return &validators.ValidationError{Code: http.StatusConflict, Message: "you cannot delete your own user"}
+	}
+
+	if err := s.users.Delete(ctx, u.ID); err != nil {
+		return s.writeError(err, ref)
+	}
+
+	return nil
+}
+
+// ResetPassword sets a new password for a user.
+func (s *userService) ResetPassword(ctx context.Context, ref, password string) error {
+	u, err := s.lookup(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	if err := s.users.UpdatePassword(ctx, u.ID, hash); err != nil {
+		return s.writeError(err, ref)
+	}
+
+	return nil
+}
+
+// lookup resolves ref as a user ID first and then as a username.
+func (s *userService) lookup(ctx context.Context, ref string) (*models.User, error) {
+	u, err := s.users.GetByID(ctx, ref)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		u, err = s.users.GetByUserName(ctx, ref)
+	}
+	if err != nil {
+		return nil, s.writeError(err, ref)
+	}
+
+	return u, nil
+}
+
+// writeError maps ErrUserNotFound to a 404 ValidationError.
+func (s *userService) writeError(err error, ref string) error {
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return &validators.ValidationError{Code: http.StatusNotFound, Message: fmt.Sprintf("user %q not found", ref)}
+	}
+
+	return err
+}
+
+// hashPassword checks password against the password policy and returns its PBKDF2 hash.
+func hashPassword(password string) (string, error) {
+	if err := catalogutils.ValidatePassword(password); err != nil {
+		return "", &validators.ValidationError{Code: http.StatusBadRequest, Message: err.Error()}
+	}
+
+	hash, err := catalogutils.HashPasswordPBKDF2(password, catalogutils.DefaultPasswordIterations)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	return hash, nil
+}
+
+// Made with Bob
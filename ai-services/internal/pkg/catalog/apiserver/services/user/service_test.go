@@ -0,0 +1,117 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+)
+
+// validationCode returns the HTTP code of a *validators.ValidationError, or 0.
+func validationCode(err error) int {
+	var valErr *validators.ValidationError
+	if errors.As(err, &valErr) {
+		return valErr.Code
+	}
+
+	return 0
+}
+
+func TestCreateUser(t *testing.T) {
+	svc := NewUserService(repository.NewInMemoryUserRepo())
+	ctx := context.Background()
+
+	resp, err := svc.CreateUser(ctx, CreateUserRequest{Username: "bob", Password: "s3cret-pass", Name: "Bob"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.ID)
+	assert.Equal(t, models.RoleViewer, resp.Role, "role must default to viewer")
+
+	_, err = svc.CreateUser(ctx, CreateUserRequest{Username: "bob", Password: "s3cret-pass"})
+	assert.Equal(t, http.StatusConflict, validationCode(err))
+
+	_, err = svc.CreateUser(ctx, CreateUserRequest{Username: "carol", Password: "s3cret-pass", Role: "root"})
+	assert.Equal(t, http.StatusBadRequest, validationCode(err))
+
+	_, err = svc.CreateUser(ctx, CreateUserRequest{Username: "carol", Password: "short"})
+	assert.Equal(t, http.StatusBadRequest, validationCode(err))
+}
+
+func TestCreateUser_HashesPassword(t *testing.T) {
+	users := repository.NewInMemoryUserRepo()
+	svc := NewUserService(users)
+
+	_, err := svc.CreateUser(context.Background(), CreateUserRequest{Username: "bob", Password: "s3cret-pass", Role: "operator"})
+	require.NoError(t, err)
+
+	u, err := users.GetByUserName(context.Background(), "bob")
+	require.NoError(t, err)
+	assert.NotContains(t, u.PasswordHash, "s3cret-pass")
+	assert.Regexp(t, `^100000\.[^.]+\.[^.]+$`, u.PasswordHash)
+	assert.Equal(t, models.RoleOperator, u.Role)
+}
+
+func TestSetDisabledAndDelete(t *testing.T) {
+	users := repository.NewInMemoryUserRepoWithAdminHash("admin-id", "admin", "Admin", "hash")
+	svc := NewUserService(users)
+	ctx := context.Background()
+
+	bob, err := svc.CreateUser(ctx, CreateUserRequest{Username: "bob", Password: "s3cret-pass"})
+	require.NoError(t, err)
+
+	// Users are addressed by ID or username.
+	resp, err := svc.SetDisabled(ctx, "admin-id", "bob", true)
+	require.NoError(t, err)
+	assert.True(t, resp.Disabled)
+	resp, err = svc.SetDisabled(ctx, "admin-id", bob.ID, false)
+	require.NoError(t, err)
+	assert.False(t, resp.Disabled)
+
+	_, err = svc.SetDisabled(ctx, "admin-id", "admin", true)
+	assert.Equal(t, http.StatusConflict, validationCode(err), "an admin must not disable themselves")
+	assert.Equal(t, http.StatusConflict, validationCode(svc.DeleteUser(ctx, "admin-id", "admin-id")))
+
+	require.NoError(t, svc.DeleteUser(ctx, "admin-id", "bob"))
+	_, err = svc.GetUser(ctx, "bob")
+	assert.Equal(t, http.StatusNotFound, validationCode(err))
+	assert.Equal(t, http.StatusNotFound, validationCode(svc.DeleteUser(ctx, "admin-id", "bob")))
+}
+
+func TestResetPassword(t *testing.T) {
+	users := repository.NewInMemoryUserRepo()
+	svc := NewUserService(users)
+	ctx := context.Background()
+
+	_, err := svc.CreateUser(ctx, CreateUserRequest{Username: "bob", Password: "s3cret-pass"})
+	require.NoError(t, err)
+	before, err := users.GetByUserName(ctx, "bob")
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, validationCode(svc.ResetPassword(ctx, "bob", "short")))
+	require.NoError(t, svc.ResetPassword(ctx, "bob", "another-pass"))
+
+	after, err := users.GetByUserName(ctx, "bob")
+	require.NoError(t, err)
+	assert.NotEqual(t, before.PasswordHash, after.PasswordHash)
+	assert.Equal(t, http.StatusNotFound, validationCode(svc.ResetPassword(ctx, "nobody", "another-pass")))
+}
+
+func TestListUsers(t *testing.T) {
+	svc := NewUserService(repository.NewInMemoryUserRepo())
+	ctx := context.Background()
+	for _, name := range []string{"carol", "alice", "bob"} {
+		_, err := svc.CreateUser(ctx, CreateUserRequest{Username: name, Password: "s3cret-pass"})
+		require.NoError(t, err)
+	}
+
+	resp, err := svc.ListUsers(ctx)
+	require.NoError(t, err)
+	require.Len(t, resp.Users, 3)
+	assert.Equal(t, []string{"alice", "bob", "carol"}, []string{resp.Users[0].Username, resp.Users[1].Username, resp.Users[2].Username})
+}
@@ -0,0 +1,76 @@
+// Package user defines the service layer for managing catalog API users.
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
+)
+
+// UserServiceInterface is the interface fulfilled by userService.
+// It is the only dependency injected into UserHandler.
+//
+// Users are addressed by ref, which is either the user ID or the username.
+// Errors meant for the caller are returned as *validators.ValidationError.
+type UserServiceInterface interface {
+	// CreateUser creates a local user with a PBKDF2-hashed password.
+	CreateUser(ctx context.Context, req CreateUserRequest) (*UserResponse, error)
+	// ListUsers returns all users ordered by username.
+	ListUsers(ctx context.Context) (*UserListResponse, error)
+	// GetUser returns a single user.
+	GetUser(ctx context.Context, ref string) (*UserResponse, error)
+	// SetDisabled disables or re-enables a user. callerID is the user making the
+	// request, who cannot disable themselves.
+	SetDisabled(ctx context.Context, callerID, ref string, disabled bool) (*UserResponse, error)
+	// DeleteUser deletes a user. callerID is the user making the request, who cannot
+	// delete themselves.
+	DeleteUser(ctx context.Context, callerID, ref string) error
+	// ResetPassword sets a new password for a user without requiring the current one.
+	ResetPassword(ctx context.Context, ref, password string) error
+}
+
+// CreateUserRequest is the payload of POST /users.
+type CreateUserRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	Name     string `json:"name"`
+	// Role is one of viewer, operator or admin. Default: viewer
+	Role string `json:"role"`
+}
+
+// ResetPasswordRequest is the payload of POST /users/{id}/password.
+type ResetPasswordRequest struct {
+	Password string `json:"password" binding:"required"`
+}
+
+// UserResponse is a user as returned by the API. The password hash is never exposed.
+type UserResponse struct {
+	ID        string      `json:"id"`
+	Username  string      `json:"username"`
+	Name      string      `json:"name"`
+	Role      models.Role `json:"role" swaggertype:"string"`
+	Disabled  bool        `json:"disabled"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// UserListResponse is the response of GET /users.
+type UserListResponse struct {
+	Users []UserResponse `json:"users"`
+}
+
+// newUserResponse converts a user to its API representation.
+func newUserResponse(u *models.User) UserResponse {
+	return UserResponse{
+		ID:        u.ID,
+		Username:  u.UserName,
+		Name:      u.Name,
+		Role:      u.Role,
+		Disabled:  u.Disabled,
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: u.UpdatedAt,
+	}
+}
+
+// Made with Bob
@@ -0,0 +1,287 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	dbrepo "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+const (
+	// MaxAttempts is how many times a delivery is sent before it is marked failed.
+	MaxAttempts = 8
+
+	// Headers sent with every delivery.
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	// HeaderSignature holds "sha256=" followed by the hex HMAC-SHA256 of
+	// "<timestamp>.<body>", keyed with the webhook secret.
+	HeaderSignature = "X-Webhook-Signature"
+
+	signaturePrefix = "sha256="
+
+	// pollInterval is how often due deliveries are looked for when no event wakes the dispatcher.
+	pollInterval = 5 * time.Second
+	// requestTimeout bounds one attempt.
+	requestTimeout = 10 * time.Second
+	// claimDuration is how long a claimed delivery is kept from other dispatchers. It
+	// outlasts an attempt; a delivery whose dispatcher stopped is due again afterwards.
+	claimDuration = time.Minute
+	// claimBatch is how many deliveries are claimed and sent at once.
+	claimBatch = 20
+	// firstRetryDelay is the delay before the second attempt. It doubles after every
+	// failed attempt, up to maxRetryDelay.
+	firstRetryDelay = 30 * time.Second
+	maxRetryDelay   = time.Hour
+	// maxResponseBytes is how much of a response body is read before the connection is reused.
+	maxResponseBytes = 64 << 10
+)
+
+// Dispatcher records the deliveries of events to the webhooks subscribed to them and
+// sends the due deliveries in the background. Several apiserver instances may share
+// the deliveries; each claims disjoint batches. It is safe for concurrent use.
+type Dispatcher struct {
+	repo     dbrepo.WebhookRepository
+	keys     *utils.Keyring
+	client   *http.Client
+	now      func() time.Time
+	wake     chan struct{}
+	stopChan chan struct{}
+}
+
+// NewDispatcher creates a Dispatcher. Webhook secrets are decrypted with keys.
+func NewDispatcher(repo dbrepo.WebhookRepository, keys *utils.Keyring) *Dispatcher {
+	return &Dispatcher{
+		repo: repo,
+		keys: keys,
+		client: &http.Client{
+			Timeout: requestTimeout,
+			// A redirect is reported as the delivery's response rather than followed,
+			// since following it would resend the payload without the endpoint's consent.
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+		now:      time.Now,
+		wake:     make(chan struct{}, 1),
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Notify records a delivery of the event for each enabled webhook subscribed to it.
+func (d *Dispatcher) Notify(ctx context.Context, event EventType, data any) {
+	hooks, err := d.repo.ListSubscribed(ctx, string(event))
+	if err != nil {
+		logger.ErrorfCtx(ctx, "Failed to look up the webhooks subscribed to %s: %v", event, err)
+
+		return
+	}
+
+	for i := range hooks {
+		if _, err := d.enqueue(ctx, &hooks[i], event, data); err != nil {
+			logger.ErrorfCtx(ctx, "Failed to queue %s event for webhook %s: %v", event, hooks[i].Name, err)
+		}
+	}
+}
+
+// Start begins sending due deliveries: right away, to send the ones left pending by
+// the previous shutdown, then whenever an event is queued and every pollInterval.
+func (d *Dispatcher) Start(ctx context.Context) {
+	go d.loop(ctx)
+	logger.InfolnCtx(ctx, "Webhook dispatcher started")
+}
+
+// Stop stops sending deliveries. Pending deliveries are sent once a dispatcher runs again.
+func (d *Dispatcher) Stop(ctx context.Context) {
+	close(d.stopChan)
+	logger.InfolnCtx(ctx, "Webhook dispatcher stopped")
+}
+
+// enqueue records a pending delivery of the event to hook and wakes the dispatcher.
+func (d *Dispatcher) enqueue(ctx context.Context, hook *models.Webhook, event EventType, data any) (*models.WebhookDelivery, error) {
+	id := uuid.New()
+	body, err := json.Marshal(Payload{ID: id, Event: event, CreatedAt: d.now().UTC(), Data: data})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	delivery := &models.WebhookDelivery{ID: id, WebhookID: hook.ID, Event: string(event), Payload: body}
+	if err := d.repo.CreateDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+
+	return delivery, nil
+}
+
+// loop sends the due deliveries until Stop is called.
+func (d *Dispatcher) loop(ctx context.Context) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			logger.ErrorfCtx(ctx, "Panic recovered in webhook dispatcher goroutine: %v", rec)
+		}
+	}()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if d.sendDue(ctx) == claimBatch {
+			// A full batch suggests more deliveries are due.
+			continue
+		}
+
+		select {
+		case <-ticker.C:
+		case <-d.wake:
+		case <-d.stopChan:
+			return
+		}
+	}
+}
+
+// sendDue claims a batch of due deliveries, sends them concurrently and returns how
+// many were claimed.
+func (d *Dispatcher) sendDue(ctx context.Context) int {
+	deliveries, err := d.repo.ClaimDueDeliveries(ctx, d.now().Add(claimDuration), claimBatch)
+	if err != nil {
+		logger.ErrorfCtx(ctx, "Failed to claim webhook deliveries: %v", err)
+
+		return 0
+	}
+
+	var wg sync.WaitGroup
+	for i := range deliveries {
+		wg.Add(1)
+		go func(delivery *models.WebhookDelivery) {
+			defer wg.Done()
+			d.deliver(ctx, delivery)
+		}(&deliveries[i])
+	}
+	wg.Wait()
+
+	return len(deliveries)
+}
+
+// deliver makes one attempt at a claimed delivery and records its outcome.
+func (d *Dispatcher) deliver(ctx context.Context, delivery *models.WebhookDelivery) {
+	hook, err := d.repo.GetByID(ctx, delivery.WebhookID)
+	if errors.Is(err, dbrepo.ErrWebhookNotFound) {
+		// Deleted meanwhile, together with the delivery.
+		return
+	}
+	if err != nil {
+		// The claim expires and the delivery is tried again.
+		logger.ErrorfCtx(ctx, "Failed to get webhook of delivery %s: %v", delivery.ID, err)
+
+		return
+	}
+
+	var attempt dbrepo.WebhookDeliveryAttempt
+	if hook.Enabled {
+		statusCode, sendErr := d.send(ctx, hook, delivery)
+		attempt = nextAttempt(delivery.Attempts, statusCode, sendErr, d.now())
+	} else {
+		attempt = dbrepo.WebhookDeliveryAttempt{Status: models.WebhookDeliveryFailed, Error: "webhook is disabled", NextAttemptAt: d.now()}
+	}
+
+	if attempt.Status == models.WebhookDeliveryFailed {
+		logger.WarningfCtx(ctx, "Webhook delivery %s of %s event to %s failed after %d attempt(s): %s", delivery.ID, delivery.Event, hook.Name, delivery.Attempts, attempt.Error)
+	}
+	if err := d.repo.FinishAttempt(ctx, delivery.ID, attempt); err != nil {
+		logger.ErrorfCtx(ctx, "Failed to record attempt of webhook delivery %s: %v", delivery.ID, err)
+	}
+}
+
+// send POSTs the signed payload of delivery to hook. It returns the response status,
+// if any, and an error unless the endpoint answered with a 2xx status.
+func (d *Dispatcher) send(ctx context.Context, hook *models.Webhook, delivery *models.WebhookDelivery) (*int, error) {
+	secret, err := d.keys.Decrypt(hook.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt webhook secret: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	timestamp := strconv.FormatInt(d.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, delivery.ID.String())
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, sign(secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBytes))
+
+	statusCode := resp.StatusCode
+	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+		return &statusCode, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+
+	return &statusCode, nil
+}
+
+// sign returns the HeaderSignature value of a payload sent at timestamp.
+func sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// nextAttempt returns the outcome of the attempts-th attempt of a delivery, which
+// failed with sendErr unless it is nil: delivered, pending until the backoff delay
+// passed, or failed once MaxAttempts were made.
+func nextAttempt(attempts int, statusCode *int, sendErr error, now time.Time) dbrepo.WebhookDeliveryAttempt {
+	attempt := dbrepo.WebhookDeliveryAttempt{StatusCode: statusCode, NextAttemptAt: now}
+	switch {
+	case sendErr == nil:
+		attempt.Status = models.WebhookDeliveryDelivered
+	case attempts >= MaxAttempts:
+		attempt.Status, attempt.Error = models.WebhookDeliveryFailed, sendErr.Error()
+	default:
+		attempt.Status, attempt.Error = models.WebhookDeliveryPending, sendErr.Error()
+		attempt.NextAttemptAt = now.Add(retryDelay(attempts))
+	}
+
+	return attempt
+}
+
+// retryDelay returns the delay after the attempts-th failed attempt.
+func retryDelay(attempts int) time.Duration {
+	delay := firstRetryDelay
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxRetryDelay)
+}
+
+// Made with Bob
@@ -0,0 +1,338 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	catalogtypes "github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+)
+
+const (
+	// maxNameLength matches the webhooks.name column.
+	maxNameLength = 255
+	// minSecretLength is the shortest secret a caller may choose.
+	minSecretLength = 16
+	// secretRandomBytes is the amount of randomness in a generated secret (256 bits).
+	secretRandomBytes = 32
+	// secretPrefix starts every generated secret, so secrets are recognisable in configuration.
+	secretPrefix = "whsec_"
+	// pingMessage is the message of the ping events sent by TestWebhook.
+	pingMessage = "Test event sent from the AI Services catalog"
+)
+
+// webhookService implements WebhookServiceInterface.
+type webhookService struct {
+	repo       repository.WebhookRepository
+	keys       *utils.Keyring
+	dispatcher *Dispatcher
+}
+
+// NewWebhookService creates a new webhookService. Secrets are encrypted with the
+// primary key of keys; test events are queued with dispatcher.
+func NewWebhookService(repo repository.WebhookRepository, keys *utils.Keyring, dispatcher *Dispatcher) WebhookServiceInterface {
+	return &webhookService{repo: repo, keys: keys, dispatcher: dispatcher}
+}
+
+// CreateWebhook validates and stores a new webhook.
+func (s *webhookService) CreateWebhook(ctx context.Context, userID string, req CreateWebhookRequest) (*CreateWebhookResponse, error) {
+	hook := &models.Webhook{Enabled: req.Enabled == nil || *req.Enabled, CreatedBy: userID}
+	// A new webhook must subscribe to something; a nil list is checked like an empty one.
+	if err := applyFields(hook, &req.Name, &req.URL, append([]EventType{}, req.Events...)); err != nil {
+		return nil, err
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		var err error
+		if secret, err = generateSecret(); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.setSecret(hook, secret); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, hook); err != nil {
+		return nil, s.mapRepoError(err, hook.Name)
+	}
+
+	return &CreateWebhookResponse{WebhookResponse: *toResponse(hook), Secret: secret}, nil
+}
+
+// ListWebhooks returns every webhook, newest first.
+func (s *webhookService) ListWebhooks(ctx context.Context) (*WebhookListResponse, error) {
+	hooks, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &WebhookListResponse{Webhooks: make([]WebhookResponse, 0, len(hooks))}
+	for i := range hooks {
+		resp.Webhooks = append(resp.Webhooks, *toResponse(&hooks[i]))
+	}
+
+	return resp, nil
+}
+
+// GetWebhook returns a webhook without its secret.
+func (s *webhookService) GetWebhook(ctx context.Context, id string) (*WebhookResponse, error) {
+	hook, err := s.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toResponse(hook), nil
+}
+
+// UpdateWebhook changes the fields set in the request.
+func (s *webhookService) UpdateWebhook(ctx context.Context, id string, req UpdateWebhookRequest) (*WebhookResponse, error) {
+	hook, err := s.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyFields(hook, req.Name, req.URL, req.Events); err != nil {
+		return nil, err
+	}
+	if req.Secret != nil {
+		if err := s.setSecret(hook, *req.Secret); err != nil {
+			return nil, err
+		}
+	}
+	if req.Enabled != nil {
+		hook.Enabled = *req.Enabled
+	}
+
+	if err := s.repo.Update(ctx, hook); err != nil {
+		return nil, s.mapRepoError(err, hook.Name)
+	}
+
+	return toResponse(hook), nil
+}
+
+// DeleteWebhook deletes a webhook and its delivery log.
+func (s *webhookService) DeleteWebhook(ctx context.Context, id string) error {
+	webhookID, err := uuid.Parse(id)
+	if err != nil {
+		return notFound(id)
+	}
+	if err := s.repo.Delete(ctx, webhookID); err != nil {
+		return s.mapRepoError(err, id)
+	}
+
+	return nil
+}
+
+// TestWebhook queues a ping event for an enabled webhook.
+func (s *webhookService) TestWebhook(ctx context.Context, id string) (*DeliveryResponse, error) {
+	hook, err := s.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !hook.Enabled {
+		return nil, &validators.ValidationError{Code: http.StatusConflict, Message: fmt.Sprintf("webhook %q is disabled", hook.Name)}
+	}
+
+	delivery, err := s.dispatcher.enqueue(ctx, hook, EventPing, PingEventData{WebhookID: hook.ID, Message: pingMessage})
+	if err != nil {
+		return nil, err
+	}
+
+	return toDeliveryResponse(delivery), nil
+}
+
+// ListDeliveries returns one page of the delivery log of a webhook, newest first.
+func (s *webhookService) ListDeliveries(ctx context.Context, id string, page, pageSize int) (*DeliveryListResponse, error) {
+	hook, err := s.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.repo.CountDeliveries(ctx, hook.ID)
+	if err != nil {
+		return nil, err
+	}
+	deliveries, err := s.repo.ListDeliveries(ctx, hook.ID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &DeliveryListResponse{Deliveries: make([]DeliveryResponse, 0, len(deliveries))}
+	for i := range deliveries {
+		resp.Deliveries = append(resp.Deliveries, *toDeliveryResponse(&deliveries[i]))
+	}
+
+	totalPages := 0
+	if total > 0 {
+		totalPages = (total + pageSize - 1) / pageSize
+	}
+	resp.Pagination = catalogtypes.PaginationMetadata{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalItems: total,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}
+
+	return resp, nil
+}
+
+// get returns the webhook with the given ID, or a 404 error.
+func (s *webhookService) get(ctx context.Context, id string) (*models.Webhook, error) {
+	webhookID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, notFound(id)
+	}
+	hook, err := s.repo.GetByID(ctx, webhookID)
+	if err != nil {
+		return nil, s.mapRepoError(err, id)
+	}
+
+	return hook, nil
+}
+
+// setSecret validates secret and stores it encrypted in hook.
+func (s *webhookService) setSecret(hook *models.Webhook, secret string) error {
+	if len(secret) < minSecretLength {
+		return &validators.ValidationError{Code: http.StatusBadRequest, Message: fmt.Sprintf("secret must be at least %d characters", minSecretLength)}
+	}
+
+	ciphertext, err := s.keys.Encrypt(secret)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt webhook secret: %w", err)
+	}
+	hook.Secret = ciphertext
+
+	return nil
+}
+
+// mapRepoError turns the repository errors meant for the caller into validation errors.
+func (s *webhookService) mapRepoError(err error, ref string) error {
+	switch {
+	case errors.Is(err, repository.ErrWebhookNotFound):
+		return notFound(ref)
+	case errors.Is(err, repository.ErrWebhookNameTaken):
+		return &validators.ValidationError{Code: http.StatusConflict, Message: fmt.Sprintf("a webhook named %q already exists", ref)}
+	default:
+		return err
+	}
+}
+
+// applyFields validates the name, URL and events that are set and stores them in hook.
+func applyFields(hook *models.Webhook, name, rawURL *string, events []EventType) error {
+	if name != nil {
+		trimmed := strings.TrimSpace(*name)
+		if trimmed == "" || len(trimmed) > maxNameLength {
+			return &validators.ValidationError{Code: http.StatusBadRequest, Message: fmt.Sprintf("name must be between 1 and %d characters", maxNameLength)}
+		}
+		hook.Name = trimmed
+	}
+	if rawURL != nil {
+		if err := validateURL(*rawURL); err != nil {
+			return err
+		}
+		hook.URL = *rawURL
+	}
+	if events != nil {
+		names, err := parseEvents(events)
+		if err != nil {
+			return err
+		}
+		hook.Events = names
+	}
+
+	return nil
+}
+
+// validateURL checks that rawURL is an absolute http or https URL.
+func validateURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return &validators.ValidationError{Code: http.StatusBadRequest, Message: fmt.Sprintf("invalid url %q: must be an absolute http or https URL", rawURL)}
+	}
+
+	return nil
+}
+
+// parseEvents validates the subscribed event types and returns them without duplicates.
+func parseEvents(events []EventType) ([]string, error) {
+	if len(events) == 0 {
+		return nil, &validators.ValidationError{Code: http.StatusBadRequest, Message: "events must list at least one event type"}
+	}
+
+	names := make([]string, 0, len(events))
+	for _, event := range events {
+		if !slices.Contains(EventTypes, event) {
+			return nil, &validators.ValidationError{Code: http.StatusBadRequest, Message: fmt.Sprintf("unknown event type %q: must be one of %v", event, EventTypes)}
+		}
+		if !slices.Contains(names, string(event)) {
+			names = append(names, string(event))
+		}
+	}
+
+	return names, nil
+}
+
+// generateSecret returns a random signing secret.
+func generateSecret() (string, error) {
+	b := make([]byte, secretRandomBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	return secretPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func notFound(id string) error {
+	return &validators.ValidationError{Code: http.StatusNotFound, Message: fmt.Sprintf("webhook %q not found", id)}
+}
+
+// toResponse maps a DB row to a WebhookResponse.
+func toResponse(hook *models.Webhook) *WebhookResponse {
+	return &WebhookResponse{
+		ID:        hook.ID,
+		Name:      hook.Name,
+		URL:       hook.URL,
+		Events:    hook.Events,
+		Enabled:   hook.Enabled,
+		CreatedBy: hook.CreatedBy,
+		CreatedAt: hook.CreatedAt,
+		UpdatedAt: hook.UpdatedAt,
+	}
+}
+
+// toDeliveryResponse maps a DB row to a DeliveryResponse.
+func toDeliveryResponse(delivery *models.WebhookDelivery) *DeliveryResponse {
+	resp := &DeliveryResponse{
+		ID:             delivery.ID,
+		Event:          delivery.Event,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		Payload:        delivery.Payload,
+		CreatedAt:      delivery.CreatedAt,
+		DeliveredAt:    delivery.DeliveredAt,
+	}
+	if delivery.Status == models.WebhookDeliveryPending {
+		resp.NextAttemptAt = &delivery.NextAttemptAt
+	}
+
+	return resp
+}
+
+// Made with Bob
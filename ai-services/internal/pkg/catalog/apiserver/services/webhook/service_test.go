@@ -0,0 +1,320 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/validators"
+)
+
+const testKey = "test-encryption-key"
+
+// fakeWebhookRepo is an in-memory repository.WebhookRepository.
+type fakeWebhookRepo struct {
+	hooks      map[uuid.UUID]*models.Webhook
+	deliveries []*models.WebhookDelivery
+}
+
+func newFakeWebhookRepo() *fakeWebhookRepo {
+	return &fakeWebhookRepo{hooks: make(map[uuid.UUID]*models.Webhook)}
+}
+
+func (r *fakeWebhookRepo) Create(_ context.Context, hook *models.Webhook) error {
+	for _, h := range r.hooks {
+		if h.Name == hook.Name {
+			return repository.ErrWebhookNameTaken
+		}
+	}
+	hook.ID = uuid.New()
+	hook.CreatedAt, hook.UpdatedAt = time.Now(), time.Now()
+	stored := *hook
+	r.hooks[hook.ID] = &stored
+
+	return nil
+}
+
+func (r *fakeWebhookRepo) GetByID(_ context.Context, id uuid.UUID) (*models.Webhook, error) {
+	hook, ok := r.hooks[id]
+	if !ok {
+		return nil, repository.ErrWebhookNotFound
+	}
+	out := *hook
+
+	return &out, nil
+}
+
+func (r *fakeWebhookRepo) List(_ context.Context) ([]models.Webhook, error) {
+	var out []models.Webhook
+	for _, h := range r.hooks {
+		out = append(out, *h)
+	}
+
+	return out, nil
+}
+
+func (r *fakeWebhookRepo) ListSubscribed(_ context.Context, event string) ([]models.Webhook, error) {
+	var out []models.Webhook
+	for _, h := range r.hooks {
+		if h.Enabled && slices.Contains(h.Events, event) {
+			out = append(out, *h)
+		}
+	}
+
+	return out, nil
+}
+
+func (r *fakeWebhookRepo) Update(_ context.Context, hook *models.Webhook) error {
+	if _, ok := r.hooks[hook.ID]; !ok {
+		return repository.ErrWebhookNotFound
+	}
+	hook.UpdatedAt = time.Now()
+	stored := *hook
+	r.hooks[hook.ID] = &stored
+
+	return nil
+}
+
+func (r *fakeWebhookRepo) Delete(_ context.Context, id uuid.UUID) error {
+	if _, ok := r.hooks[id]; !ok {
+		return repository.ErrWebhookNotFound
+	}
+	delete(r.hooks, id)
+
+	return nil
+}
+
+func (r *fakeWebhookRepo) CreateDelivery(_ context.Context, delivery *models.WebhookDelivery) error {
+	delivery.Status = models.WebhookDeliveryPending
+	delivery.NextAttemptAt, delivery.CreatedAt = time.Now(), time.Now()
+	stored := *delivery
+	r.deliveries = append(r.deliveries, &stored)
+
+	return nil
+}
+
+func (r *fakeWebhookRepo) ClaimDueDeliveries(_ context.Context, until time.Time, limit int) ([]models.WebhookDelivery, error) {
+	var out []models.WebhookDelivery
+	for _, d := range r.deliveries {
+		if len(out) < limit && d.Status == models.WebhookDeliveryPending && !d.NextAttemptAt.After(time.Now()) {
+			d.Attempts++
+			d.NextAttemptAt = until
+			out = append(out, *d)
+		}
+	}
+
+	return out, nil
+}
+
+func (r *fakeWebhookRepo) FinishAttempt(_ context.Context, id uuid.UUID, attempt repository.WebhookDeliveryAttempt) error {
+	for _, d := range r.deliveries {
+		if d.ID == id {
+			d.Status, d.LastStatusCode, d.LastError, d.NextAttemptAt = attempt.Status, attempt.StatusCode, attempt.Error, attempt.NextAttemptAt
+		}
+	}
+
+	return nil
+}
+
+func (r *fakeWebhookRepo) ListDeliveries(_ context.Context, webhookID uuid.UUID, _, _ int) ([]models.WebhookDelivery, error) {
+	var out []models.WebhookDelivery
+	for _, d := range r.deliveries {
+		if d.WebhookID == webhookID {
+			out = append(out, *d)
+		}
+	}
+
+	return out, nil
+}
+
+func (r *fakeWebhookRepo) CountDeliveries(ctx context.Context, webhookID uuid.UUID) (int, error) {
+	deliveries, _ := r.ListDeliveries(ctx, webhookID, 0, 0)
+
+	return len(deliveries), nil
+}
+
+func newTestService(t *testing.T) (*webhookService, *fakeWebhookRepo) {
+	t.Helper()
+	keys, err := utils.NewKeyring(testKey)
+	require.NoError(t, err)
+	repo := newFakeWebhookRepo()
+
+	return NewWebhookService(repo, keys, NewDispatcher(repo, keys)).(*webhookService), repo
+}
+
+func requireValidationError(t *testing.T, err error, code int) {
+	t.Helper()
+	var valErr *validators.ValidationError
+	require.True(t, errors.As(err, &valErr), "expected a ValidationError, got %v", err)
+	assert.Equal(t, code, valErr.Code)
+}
+
+func TestCreateWebhook(t *testing.T) {
+	svc, repo := newTestService(t)
+	ctx := context.Background()
+
+	resp, err := svc.CreateWebhook(ctx, "admin", CreateWebhookRequest{
+		Name:   " on-call ",
+		URL:    "https://hooks.example.com/ai-services",
+		Events: []EventType{EventApplicationFailed, EventWorkerDisconnected, EventApplicationFailed},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "on-call", resp.Name)
+	assert.True(t, resp.Enabled)
+	assert.Equal(t, []string{"application.failed", "worker.disconnected"}, resp.Events)
+	assert.True(t, strings.HasPrefix(resp.Secret, secretPrefix))
+
+	stored := repo.hooks[resp.ID]
+	assert.NotEqual(t, resp.Secret, stored.Secret, "secret must be stored encrypted")
+	plaintext, err := utils.Decrypt(stored.Secret, testKey)
+	require.NoError(t, err)
+	assert.Equal(t, resp.Secret, plaintext)
+
+	_, err = svc.CreateWebhook(ctx, "admin", CreateWebhookRequest{Name: "on-call", URL: "https://example.com", Events: []EventType{EventPing}})
+	requireValidationError(t, err, http.StatusBadRequest)
+
+	_, err = svc.CreateWebhook(ctx, "admin", CreateWebhookRequest{Name: "on-call", URL: "https://example.com", Events: []EventType{EventApplicationFailed}})
+	requireValidationError(t, err, http.StatusConflict)
+}
+
+func TestCreateWebhook_Validation(t *testing.T) {
+	svc, _ := newTestService(t)
+
+	tests := []struct {
+		name string
+		req  CreateWebhookRequest
+	}{
+		{name: "blank name", req: CreateWebhookRequest{Name: " ", URL: "https://example.com", Events: []EventType{EventApplicationFailed}}},
+		{name: "relative url", req: CreateWebhookRequest{Name: "a", URL: "/hooks", Events: []EventType{EventApplicationFailed}}},
+		{name: "unsupported scheme", req: CreateWebhookRequest{Name: "a", URL: "ftp://example.com", Events: []EventType{EventApplicationFailed}}},
+		{name: "no events", req: CreateWebhookRequest{Name: "a", URL: "https://example.com"}},
+		{name: "unknown event", req: CreateWebhookRequest{Name: "a", URL: "https://example.com", Events: []EventType{"application.deleted"}}},
+		{name: "short secret", req: CreateWebhookRequest{Name: "a", URL: "https://example.com", Events: []EventType{EventApplicationFailed}, Secret: "short"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.CreateWebhook(context.Background(), "admin", tt.req)
+			requireValidationError(t, err, http.StatusBadRequest)
+		})
+	}
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	svc, repo := newTestService(t)
+	ctx := context.Background()
+	created, err := svc.CreateWebhook(ctx, "admin", CreateWebhookRequest{Name: "on-call", URL: "https://example.com", Events: []EventType{EventApplicationFailed}})
+	require.NoError(t, err)
+
+	disabled := false
+	secret := "a-rotated-secret-value"
+	resp, err := svc.UpdateWebhook(ctx, created.ID.String(), UpdateWebhookRequest{Enabled: &disabled, Secret: &secret})
+	require.NoError(t, err)
+	assert.False(t, resp.Enabled)
+	assert.Equal(t, "https://example.com", resp.URL, "fields left out keep their value")
+	plaintext, err := utils.Decrypt(repo.hooks[created.ID].Secret, testKey)
+	require.NoError(t, err)
+	assert.Equal(t, secret, plaintext)
+
+	_, err = svc.TestWebhook(ctx, created.ID.String())
+	requireValidationError(t, err, http.StatusConflict)
+
+	_, err = svc.UpdateWebhook(ctx, uuid.NewString(), UpdateWebhookRequest{})
+	requireValidationError(t, err, http.StatusNotFound)
+}
+
+func TestDispatcher_SignsAndRetries(t *testing.T) {
+	svc, repo := newTestService(t)
+	ctx := context.Background()
+
+	var (
+		got    *http.Request
+		body   []byte
+		status = http.StatusInternalServerError
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	created, err := svc.CreateWebhook(ctx, "admin", CreateWebhookRequest{Name: "on-call", URL: server.URL, Events: []EventType{EventApplicationFailed}})
+	require.NoError(t, err)
+	// Not subscribed, so not queued.
+	svc.dispatcher.Notify(ctx, EventWorkerDisconnected, WorkerEventData{WorkerName: "worker-1"})
+	appID := uuid.New()
+	svc.dispatcher.Notify(ctx, EventApplicationFailed, ApplicationEventData{ApplicationID: appID, ApplicationName: "rag", Status: "Error", PreviousStatus: "Running"})
+	require.Len(t, repo.deliveries, 1)
+	delivery := repo.deliveries[0]
+
+	require.Equal(t, 1, svc.dispatcher.sendDue(ctx))
+	assert.Equal(t, models.WebhookDeliveryPending, delivery.Status)
+	assert.Equal(t, http.StatusInternalServerError, *delivery.LastStatusCode)
+	assert.True(t, delivery.NextAttemptAt.After(time.Now().Add(firstRetryDelay-time.Second)), "retry must wait for the backoff delay")
+
+	mac := hmac.New(sha256.New, []byte(created.Secret))
+	mac.Write([]byte(got.Header.Get(HeaderTimestamp) + "." + string(body)))
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), got.Header.Get(HeaderSignature))
+	assert.Equal(t, "application.failed", got.Header.Get(HeaderEvent))
+	assert.Equal(t, delivery.ID.String(), got.Header.Get(HeaderDelivery))
+
+	var payload struct {
+		ID    uuid.UUID            `json:"id"`
+		Event EventType            `json:"event"`
+		Data  ApplicationEventData `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, delivery.ID, payload.ID)
+	assert.Equal(t, EventApplicationFailed, payload.Event)
+	assert.Equal(t, appID, payload.Data.ApplicationID)
+
+	// The retry is due once the backoff delay passed.
+	status = http.StatusNoContent
+	delivery.NextAttemptAt = time.Now()
+	require.Equal(t, 1, svc.dispatcher.sendDue(ctx))
+	assert.Equal(t, models.WebhookDeliveryDelivered, delivery.Status)
+	assert.Equal(t, 2, delivery.Attempts)
+}
+
+func TestNextAttempt(t *testing.T) {
+	now := time.Now()
+	failure := errors.New("connection refused")
+
+	delivered := nextAttempt(1, nil, nil, now)
+	assert.Equal(t, models.WebhookDeliveryDelivered, delivered.Status)
+
+	retried := nextAttempt(3, nil, failure, now)
+	assert.Equal(t, models.WebhookDeliveryPending, retried.Status)
+	assert.Equal(t, now.Add(4*firstRetryDelay), retried.NextAttemptAt)
+	assert.Equal(t, "connection refused", retried.Error)
+
+	failed := nextAttempt(MaxAttempts, nil, failure, now)
+	assert.Equal(t, models.WebhookDeliveryFailed, failed.Status)
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, firstRetryDelay, retryDelay(1))
+	assert.Equal(t, 2*firstRetryDelay, retryDelay(2))
+	assert.Equal(t, maxRetryDelay, retryDelay(MaxAttempts))
+	assert.Equal(t, maxRetryDelay, retryDelay(100))
+}
+
+// Made with Bob
@@ -0,0 +1,196 @@
+// Package webhook notifies the HTTP endpoints admins register of catalog events, such
+// as an application failing. Every event sent to an endpoint is recorded as a delivery
+// before it is sent, signed with the endpoint's secret and retried with exponential
+// backoff until the endpoint accepts it or the attempts are exhausted.
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+)
+
+// EventType is the type of an event webhooks subscribe to.
+type EventType string
+
+const (
+	// EventApplicationFailed is sent when a running application turns to Error.
+	EventApplicationFailed EventType = "application.failed"
+	// EventApplicationRecovered is sent when an application in Error is running again.
+	EventApplicationRecovered EventType = "application.recovered"
+	// EventServiceFailed is sent when a running service of an application turns to Error.
+	EventServiceFailed EventType = "service.failed"
+	// EventDeploymentFinished is sent when the deployment or reconfiguration of an
+	// application succeeds, fails or is cancelled.
+	EventDeploymentFinished EventType = "deployment.finished"
+	// EventWorkerDisconnected is sent when a worker's stream drops or its heartbeat times out.
+	EventWorkerDisconnected EventType = "worker.disconnected"
+	// EventPing is sent by POST /webhooks/{id}/test, whatever the webhook subscribes to.
+	EventPing EventType = "ping"
+)
+
+// EventTypes lists the event types a webhook may subscribe to, in the order they are documented.
+var EventTypes = []EventType{
+	EventApplicationFailed,
+	EventApplicationRecovered,
+	EventServiceFailed,
+	EventDeploymentFinished,
+	EventWorkerDisconnected,
+}
+
+// Notifier sends events to the webhooks subscribed to them. Notify records a delivery
+// for each webhook and returns; failures are logged, never returned, so a notification
+// cannot fail the operation that raised it.
+type Notifier interface {
+	Notify(ctx context.Context, event EventType, data any)
+}
+
+// WebhookServiceInterface is the interface fulfilled by webhookService.
+// Errors meant for the caller are returned as *validators.ValidationError.
+type WebhookServiceInterface interface {
+	// CreateWebhook stores a webhook and returns it with its signing secret, which
+	// is generated when the request does not set one.
+	CreateWebhook(ctx context.Context, userID string, req CreateWebhookRequest) (*CreateWebhookResponse, error)
+	// ListWebhooks returns every webhook, newest first.
+	ListWebhooks(ctx context.Context) (*WebhookListResponse, error)
+	// GetWebhook returns a webhook without its secret.
+	GetWebhook(ctx context.Context, id string) (*WebhookResponse, error)
+	// UpdateWebhook changes the fields set in the request.
+	UpdateWebhook(ctx context.Context, id string, req UpdateWebhookRequest) (*WebhookResponse, error)
+	// DeleteWebhook deletes a webhook and its delivery log.
+	DeleteWebhook(ctx context.Context, id string) error
+	// TestWebhook queues a ping event for the webhook and returns its delivery.
+	TestWebhook(ctx context.Context, id string) (*DeliveryResponse, error)
+	// ListDeliveries returns one page of the delivery log of a webhook, newest first.
+	ListDeliveries(ctx context.Context, id string, page, pageSize int) (*DeliveryListResponse, error)
+}
+
+// CreateWebhookRequest is the payload of POST /webhooks.
+type CreateWebhookRequest struct {
+	Name string `json:"name" binding:"required"`
+	// URL is the http or https endpoint events are POSTed to.
+	URL string `json:"url" binding:"required"`
+	// Events are the event types to send, e.g. ["application.failed", "worker.disconnected"].
+	Events []EventType `json:"events" binding:"required"`
+	// Secret is the key payloads are signed with, at least 16 characters. Empty generates one.
+	Secret string `json:"secret"`
+	// Enabled defaults to true.
+	Enabled *bool `json:"enabled"`
+}
+
+// UpdateWebhookRequest is the payload of PUT /webhooks/{id}. Fields left out keep their value.
+type UpdateWebhookRequest struct {
+	Name   *string     `json:"name"`
+	URL    *string     `json:"url"`
+	Events []EventType `json:"events"`
+	// Secret replaces the signing secret.
+	Secret  *string `json:"secret"`
+	Enabled *bool   `json:"enabled"`
+}
+
+// WebhookResponse is a webhook as returned by the API. The secret is never included.
+type WebhookResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Enabled   bool      `json:"enabled"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateWebhookResponse is the response of POST /webhooks. Secret is only returned here.
+type CreateWebhookResponse struct {
+	WebhookResponse
+	Secret string `json:"secret"`
+}
+
+// WebhookListResponse is the response of GET /webhooks.
+type WebhookListResponse struct {
+	Webhooks []WebhookResponse `json:"webhooks"`
+}
+
+// DeliveryResponse is an entry of the delivery log of a webhook.
+type DeliveryResponse struct {
+	ID             uuid.UUID                    `json:"id"`
+	Event          string                       `json:"event"`
+	Status         models.WebhookDeliveryStatus `json:"status"`
+	Attempts       int                          `json:"attempts"`
+	NextAttemptAt  *time.Time                   `json:"next_attempt_at,omitempty"` // Set while the delivery is pending
+	LastStatusCode *int                         `json:"last_status_code,omitempty"`
+	LastError      string                       `json:"last_error,omitempty"`
+	Payload        json.RawMessage              `json:"payload" swaggertype:"object"`
+	CreatedAt      time.Time                    `json:"created_at"`
+	DeliveredAt    *time.Time                   `json:"delivered_at,omitempty"`
+}
+
+// DeliveryListResponse is the paginated response of GET /webhooks/{id}/deliveries.
+type DeliveryListResponse struct {
+	Deliveries []DeliveryResponse       `json:"deliveries"`
+	Pagination types.PaginationMetadata `json:"pagination"`
+}
+
+// Payload is the JSON body POSTed to a webhook.
+type Payload struct {
+	// ID is the delivery ID; retries of a delivery send the same ID.
+	ID        uuid.UUID `json:"id"`
+	Event     EventType `json:"event"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// ApplicationEventData is the data of application.failed and application.recovered events.
+type ApplicationEventData struct {
+	ApplicationID   uuid.UUID `json:"application_id"`
+	ApplicationName string    `json:"application_name"`
+	Status          string    `json:"status"`
+	PreviousStatus  string    `json:"previous_status"`
+	Message         string    `json:"message,omitempty"`
+}
+
+// ServiceEventData is the data of service.failed events.
+type ServiceEventData struct {
+	ApplicationID  uuid.UUID `json:"application_id"`
+	ServiceID      uuid.UUID `json:"service_id"`
+	CatalogID      string    `json:"catalog_id"`
+	Status         string    `json:"status"`
+	PreviousStatus string    `json:"previous_status"`
+	Message        string    `json:"message,omitempty"`
+}
+
+// DeploymentOutcome is how a deployment finished.
+type DeploymentOutcome string
+
+const (
+	DeploymentSucceeded DeploymentOutcome = "succeeded"
+	DeploymentFailed    DeploymentOutcome = "failed"
+	DeploymentCancelled DeploymentOutcome = "cancelled"
+)
+
+// DeploymentEventData is the data of deployment.finished events.
+type DeploymentEventData struct {
+	ApplicationID   uuid.UUID         `json:"application_id"`
+	ApplicationName string            `json:"application_name"`
+	Outcome         DeploymentOutcome `json:"outcome"`
+	Message         string            `json:"message,omitempty"`
+}
+
+// WorkerEventData is the data of worker.disconnected events.
+type WorkerEventData struct {
+	WorkerID   uuid.UUID `json:"worker_id"`
+	WorkerName string    `json:"worker_name"`
+}
+
+// PingEventData is the data of ping events.
+type PingEventData struct {
+	WebhookID uuid.UUID `json:"webhook_id"`
+	Message   string    `json:"message"`
+}
+
+// Made with Bob
@@ -16,4 +16,5 @@ const (
 	ArgParamPodmanURI             = "backend.podman.uri"
 	ArgParamCaddyHTTPSPort        = "caddy.httpsPort"
 	ArgParamWorkerGatewayPort     = "backend.workerGatewayPort"
+	ArgParamWorkerGatewayHosts    = "backend.workerGatewayHosts"
 )
@@ -203,10 +203,26 @@ func generateArgParams(passwordHash string, httpsPort, workerGatewayPort int) (m
 	argParams[configure.ArgParamDBPassword] = dbPassword
 	argParams[configure.ArgParamCaddyHTTPSPort] = fmt.Sprintf("%d", httpsPort)
 	argParams[configure.ArgParamWorkerGatewayPort] = fmt.Sprintf("%d", workerGatewayPort)
+	argParams[configure.ArgParamWorkerGatewayHosts] = workerGatewayHosts()
 
 	return argParams, nil
 }
 
+// workerGatewayHosts returns the comma-separated names under which remote workers
+// reach the gateway on this host. The backend runs in a pod, so its own host name
+// would not match what workers dial; the names are resolved here on the host instead.
+func workerGatewayHosts() string {
+	hosts := []string{"localhost", "127.0.0.1"}
+	if hostIP, err := utils.GetHostIP(); err == nil && hostIP != "" {
+		hosts = append([]string{hostIP}, hosts...)
+	}
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		hosts = append([]string{hostname}, hosts...)
+	}
+
+	return strings.Join(hosts, ",")
+}
+
 // setupCaddyContext sets up the Caddy context with domain configuration and Caddyfile generation.
 // This function:
 // 1. Gets the Caddy pod name from deployment context templates
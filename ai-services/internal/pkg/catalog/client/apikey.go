@@ -0,0 +1,84 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+// API route constants for API key endpoints.
+const (
+	apiKeysRoute = "/api/v1/apikeys"
+	apiKeyRoute  = "/api/v1/apikeys/%s"
+)
+
+// APIKeyClient provides methods for interacting with the API keys API.
+type APIKeyClient struct {
+	client *Client
+}
+
+// NewAPIKeyClient creates a new APIKeyClient using the stored credentials.
+func NewAPIKeyClient() (*APIKeyClient, error) {
+	client, err := New()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize client: %w", err)
+	}
+
+	return &APIKeyClient{
+		client: client,
+	}, nil
+}
+
+// CreateAPIKey creates an API key owned by the logged-in user.
+func (c *APIKeyClient) CreateAPIKey(req *CreateAPIKeyRequest) (*CreateAPIKeyResponse, error) {
+	var result CreateAPIKeyResponse
+	resp, err := c.client.HTTPClient().R().
+		SetBody(req).
+		SetResult(&result).
+		Post(apiKeysRoute)
+	if err != nil {
+		return nil, fmt.Errorf("create API key: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("create API key: server returned HTTP %d: %s", resp.StatusCode(), utils.ParseErrorResponse(resp))
+	}
+
+	return &result, nil
+}
+
+// ListAPIKeys returns the API keys of the logged-in user, or of every user when all is true.
+func (c *APIKeyClient) ListAPIKeys(all bool) ([]APIKey, error) {
+	var result APIKeyListResponse
+	req := c.client.HTTPClient().R().SetResult(&result)
+	if all {
+		req.SetQueryParam("all", "true")
+	}
+
+	resp, err := req.Get(apiKeysRoute)
+	if err != nil {
+		return nil, fmt.Errorf("list API keys: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("list API keys: server returned HTTP %d: %s", resp.StatusCode(), utils.ParseErrorResponse(resp))
+	}
+
+	return result.APIKeys, nil
+}
+
+// RevokeAPIKey revokes an API key by ID.
+func (c *APIKeyClient) RevokeAPIKey(id string) error {
+	resp, err := c.client.HTTPClient().R().
+		Delete(fmt.Sprintf(apiKeyRoute, url.PathEscape(id)))
+	if err != nil {
+		return fmt.Errorf("revoke API key: %w", err)
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("revoke API key: server returned HTTP %d: %s", resp.StatusCode(), utils.ParseErrorResponse(resp))
+	}
+
+	return nil
+}
@@ -1,10 +1,15 @@
 package client
 
 import (
+	"bufio"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/models"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
@@ -14,8 +19,10 @@ import (
 // API route constants for application endpoints.
 const (
 	applicationsRoute       = "/api/v1/applications"
+	planApplicationRoute    = "/api/v1/applications/plan"
 	getApplicationPSRoute   = "/api/v1/applications/%s/ps"
 	getApplicationRoute     = "/api/v1/applications/%s"
+	applicationEventsRoute  = "/api/v1/applications/%s/events"
 	svcDeployOptionsRoute   = "/api/v1/services/%s/deploy-options"
 	archDeployOptionsRoute  = "/api/v1/architectures/%s/deploy-options"
 	compProviderParamsRoute = "/api/v1/components/%s/providers/%s/params"
@@ -211,6 +218,94 @@ func (c *ApplicationClient) CreateApplication(req *models.CreateApplicationReque
 	return &result, nil
 }
 
+// PlanApplication previews the deployment of a new application without creating it.
+func (c *ApplicationClient) PlanApplication(req *models.CreateApplicationRequest) (*models.PlanApplicationResponse, error) {
+	var result models.PlanApplicationResponse
+	resp, err := c.client.HTTPClient().R().
+		SetBody(req).
+		SetResult(&result).
+		Post(planApplicationRoute)
+
+	if err != nil {
+		return nil, fmt.Errorf("plan application: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("plan application: server returned HTTP %d: %s",
+			resp.StatusCode(), utils.ParseErrorResponse(resp))
+	}
+
+	return &result, nil
+}
+
+// FollowApplicationEvents streams the deployment events of an application recorded after
+// afterSeq and calls handle for each one until handle returns false, ctx is done or the
+// server closes the stream. It returns the sequence number of the last event received, from
+// which an interrupted stream can be resumed.
+func (c *ApplicationClient) FollowApplicationEvents(ctx context.Context, id string, afterSeq int64, handle func(models.ApplicationEvent) bool) (int64, error) {
+	resp, err := c.client.HTTPClient().R().
+		SetContext(ctx).
+		SetDoNotParseResponse(true).
+		SetHeader("Accept", "text/event-stream").
+		SetQueryParam("after", strconv.FormatInt(afterSeq, 10)).
+		Get(fmt.Sprintf(applicationEventsRoute, id))
+	if err != nil {
+		return afterSeq, fmt.Errorf("follow application events: %w", err)
+	}
+
+	body := resp.RawBody()
+	defer body.Close()
+
+	if resp.IsError() {
+		var errResp utils.ErrorResponse
+		msg, _ := io.ReadAll(body)
+		if json.Unmarshal(msg, &errResp) == nil && errResp.Error != "" {
+			msg = []byte(errResp.Error)
+		}
+
+		return afterSeq, &HTTPError{StatusCode: resp.StatusCode(), Message: string(msg)}
+	}
+
+	return readEventStream(body, afterSeq, handle)
+}
+
+// readEventStream decodes the Server-Sent Events in r and calls handle with each event.
+// The id and event fields repeat what the JSON data holds and comments only keep the
+// connection alive, so only data lines are read.
+func readEventStream(r io.Reader, lastSeq int64, handle func(models.ApplicationEvent) bool) (int64, error) {
+	scanner := bufio.NewScanner(r)
+	var data strings.Builder
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if value, ok := strings.CutPrefix(line, "data:"); ok {
+			data.WriteString(strings.TrimPrefix(value, " "))
+
+			continue
+		}
+		if line != "" || data.Len() == 0 {
+			continue
+		}
+
+		var event models.ApplicationEvent
+		if err := json.Unmarshal([]byte(data.String()), &event); err != nil {
+			return lastSeq, fmt.Errorf("decode application event: %w", err)
+		}
+		data.Reset()
+
+		lastSeq = event.Seq
+		if !handle(event) {
+			return lastSeq, nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return lastSeq, fmt.Errorf("read application events: %w", err)
+	}
+
+	return lastSeq, nil
+}
+
 // GetServiceDeployOptions retrieves deploy options for a specific service.
 // It returns available providers and dependency rules for the service and its components.
 func (c *ApplicationClient) GetServiceDeployOptions(serviceID string) (*types.DeployOptionsService, error) {
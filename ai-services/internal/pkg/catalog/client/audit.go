@@ -0,0 +1,73 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+// auditRoute is the API route of the audit log.
+const auditRoute = "/api/v1/audit"
+
+// AuditClient provides methods for reading the audit log.
+type AuditClient struct {
+	client *Client
+}
+
+// NewAuditClient creates a new AuditClient using the stored credentials.
+func NewAuditClient() (*AuditClient, error) {
+	client, err := New()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize client: %w", err)
+	}
+
+	return &AuditClient{
+		client: client,
+	}, nil
+}
+
+// ListAuditEvents returns one page of audit events, newest first. It requires the admin role.
+func (c *AuditClient) ListAuditEvents(params *ListAuditEventsParams) (*AuditListResponse, error) {
+	var result AuditListResponse
+	req := c.client.HTTPClient().R().
+		SetResult(&result)
+
+	if params != nil {
+		if params.Page > 0 {
+			req.SetQueryParam("page", strconv.Itoa(params.Page))
+		}
+		if params.PageSize > 0 {
+			req.SetQueryParam("page_size", strconv.Itoa(params.PageSize))
+		}
+		for name, value := range map[string]string{
+			"user_id":       params.UserID,
+			"action":        params.Action,
+			"resource_type": params.ResourceType,
+			"resource_id":   params.ResourceID,
+			"outcome":       params.Outcome,
+		} {
+			if value != "" {
+				req.SetQueryParam(name, value)
+			}
+		}
+		if params.Since != nil {
+			req.SetQueryParam("since", params.Since.Format(time.RFC3339))
+		}
+		if params.Until != nil {
+			req.SetQueryParam("until", params.Until.Format(time.RFC3339))
+		}
+	}
+
+	resp, err := req.Get(auditRoute)
+	if err != nil {
+		return nil, fmt.Errorf("list audit events: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("list audit events: server returned HTTP %d: %s", resp.StatusCode(), utils.ParseErrorResponse(resp))
+	}
+
+	return &result, nil
+}
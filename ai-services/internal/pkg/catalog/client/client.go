@@ -19,6 +19,9 @@ const (
 	// which a proactive refresh is triggered. If the token expires in less than
 	// this duration it is considered "about to expire".
 	tokenRefreshSkew = 30 * time.Second
+
+	// apiKeyAuthScheme is the Authorization scheme used to send an API key.
+	apiKeyAuthScheme = "ApiKey"
 )
 
 // Client is an authenticated HTTP client for the catalog API server.
@@ -45,6 +48,7 @@ type UserInfo struct {
 // New creates a Client using credentials loaded from the local config file.
 // It refreshes the access token only when it is about to expire (within
 // tokenRefreshSkew of its expiry time); otherwise the stored token is reused.
+// When the stored credentials hold an API key, it is sent instead and nothing is refreshed.
 // The insecure flag from stored credentials determines whether TLS verification is performed.
 func New() (*Client, error) {
 	creds, err := config.Load()
@@ -55,6 +59,9 @@ func New() (*Client, error) {
 	restyClient := resty.New().
 		SetBaseURL(creds.ServerURL).
 		SetAuthToken(creds.AccessToken)
+	if creds.APIKey != "" {
+		restyClient.SetAuthScheme(apiKeyAuthScheme).SetAuthToken(creds.APIKey)
+	}
 
 	// Configure TLS settings based on the insecure flag from credentials
 	if creds.Insecure {
@@ -69,7 +76,7 @@ func New() (*Client, error) {
 		creds:      creds,
 	}
 
-	if c.accessTokenNeedsRefresh() {
+	if creds.APIKey == "" && c.accessTokenNeedsRefresh() {
 		if err := c.RefreshToken(); err != nil {
 			return nil, fmt.Errorf("refresh token: %w", err)
 		}
@@ -221,6 +228,39 @@ func NewWithMIQToken(serverURL, miqToken string, insecure bool) (*Client, error)
 	return c, nil
 }
 
+// NewWithAPIKey creates a Client that authenticates with a catalog API key. The key is
+// checked against GET /api/v1/auth/me before it is saved to the local config file in
+// place of any stored tokens.
+func NewWithAPIKey(serverURL, apiKey string, insecure bool) (*Client, error) {
+	restyClient := resty.New().
+		SetBaseURL(serverURL).
+		SetAuthScheme(apiKeyAuthScheme).
+		SetAuthToken(apiKey)
+	if insecure {
+		restyClient.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true}) //nolint:gosec
+	}
+
+	c := &Client{
+		serverURL:  serverURL,
+		httpClient: restyClient,
+		creds: config.Credentials{
+			ServerURL: serverURL,
+			APIKey:    apiKey,
+			Insecure:  insecure,
+		},
+	}
+
+	if _, err := c.Me(); err != nil {
+		return nil, err
+	}
+
+	if err := config.Save(c.creds); err != nil {
+		return nil, fmt.Errorf("save credentials: %w", err)
+	}
+
+	return c, nil
+}
+
 // RefreshToken calls POST /api/v1/auth/refresh using the stored refresh token
 // and updates the in-memory credentials (and persists them to disk).
 func (c *Client) RefreshToken() error {
@@ -271,8 +311,13 @@ func (c *Client) Me() (UserInfo, error) {
 }
 
 // Logout calls POST /api/v1/auth/logout to invalidate the access token on the server,
-// then removes the local credentials file.
+// then removes the local credentials file. A stored API key stays valid until it is
+// revoked, so it is only removed locally.
 func (c *Client) Logout() error {
+	if c.creds.APIKey != "" {
+		return config.Delete()
+	}
+
 	// Best-effort server-side logout; ignore errors (token may already be expired).
 	_, _ = c.httpClient.R().
 		SetHeader("X-Refresh-Token", c.creds.RefreshToken).
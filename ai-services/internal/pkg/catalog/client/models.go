@@ -1,5 +1,11 @@
 package client
 
+import (
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/types"
+)
+
 // ListApplicationsParams holds optional query parameters for listing applications.
 type ListApplicationsParams struct {
 	// Page is the page number (1-indexed). Default: 1
@@ -50,3 +56,101 @@ type CreateApplicationResponse struct {
 	Status  string `json:"status"`
 	Message string `json:"message,omitempty"`
 }
+
+// CreateUserRequest represents the payload for creating a user.
+type CreateUserRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Name     string `json:"name,omitempty"`
+	Role     string `json:"role,omitempty"`
+}
+
+// User represents a catalog API user as returned by the users endpoints.
+type User struct {
+	ID        string    `json:"id"`
+	Username  string    `json:"username"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role"`
+	Disabled  bool      `json:"disabled"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// UserListResponse represents the response of the list users endpoint.
+type UserListResponse struct {
+	Users []User `json:"users"`
+}
+
+// CreateAPIKeyRequest represents the payload for creating an API key.
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// APIKey represents an API key as returned by the API key endpoints.
+type APIKey struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	UserID     string     `json:"user_id"`
+	Username   string     `json:"username"`
+	Scopes     []string   `json:"scopes"`
+	Status     string     `json:"status"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreateAPIKeyResponse represents the response of the create API key endpoint.
+// Key holds the plaintext API key, which the server only returns once.
+type CreateAPIKeyResponse struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// APIKeyListResponse represents the response of the list API keys endpoint.
+type APIKeyListResponse struct {
+	APIKeys []APIKey `json:"api_keys"`
+}
+
+// ListAuditEventsParams holds optional query parameters for listing audit events.
+type ListAuditEventsParams struct {
+	// Page is the page number (1-indexed). Default: 1
+	Page int
+	// PageSize is the number of items per page (max: 100). Default: 20
+	PageSize int
+	// UserID, Action, ResourceType, ResourceID and Outcome filter by exact match.
+	UserID       string
+	Action       string
+	ResourceType string
+	ResourceID   string
+	Outcome      string
+	// Since and Until restrict the events to a time range.
+	Since *time.Time
+	Until *time.Time
+}
+
+// AuditEvent represents a recorded mutating API call.
+type AuditEvent struct {
+	ID           string    `json:"id"`
+	UserID       string    `json:"user_id"`
+	Username     string    `json:"username,omitempty"`
+	APIKeyID     string    `json:"api_key_id,omitempty"`
+	Action       string    `json:"action"`
+	ResourceType string    `json:"resource_type"`
+	ResourceID   string    `json:"resource_id"`
+	Method       string    `json:"method"`
+	Path         string    `json:"path"`
+	StatusCode   int       `json:"status_code"`
+	Outcome      string    `json:"outcome"`
+	RequestID    string    `json:"request_id"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// AuditListResponse represents the response of the list audit events endpoint.
+type AuditListResponse struct {
+	Events     []AuditEvent             `json:"events"`
+	Pagination types.PaginationMetadata `json:"pagination"`
+}
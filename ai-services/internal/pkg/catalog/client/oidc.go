@@ -0,0 +1,135 @@
+package client
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/config"
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+const (
+	// defaultDevicePollInterval is the RFC 8628 polling interval used when the
+	// identity provider does not specify one.
+	defaultDevicePollInterval = 5 * time.Second
+	// slowDownIncrement is added to the polling interval on a slow_down response.
+	slowDownIncrement = 5 * time.Second
+)
+
+// DeviceAuthorization is the JSON body returned by POST /api/v1/auth/oidc/device.
+type DeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval,omitempty"`
+}
+
+// NewWithOIDCDevice creates a Client by signing in through the server's OIDC provider
+// with the device authorization grant. prompt is called once with the code the user
+// must enter at the verification URI; the server is then polled until the user has
+// signed in or the code expires. The resulting tokens are saved like NewWithLogin.
+func NewWithOIDCDevice(serverURL string, insecure bool, prompt func(DeviceAuthorization)) (*Client, error) {
+	restyClient := resty.New().SetBaseURL(serverURL)
+	if insecure {
+		restyClient.SetTLSClientConfig(&tls.Config{InsecureSkipVerify: true}) //nolint:gosec
+	}
+
+	c := &Client{
+		serverURL:  serverURL,
+		httpClient: restyClient,
+	}
+
+	device, err := c.StartOIDCDeviceLogin()
+	if err != nil {
+		return nil, err
+	}
+	prompt(device)
+
+	resp, err := c.waitForOIDCDeviceLogin(device)
+	if err != nil {
+		return nil, err
+	}
+
+	c.creds = config.Credentials{
+		ServerURL:    serverURL,
+		AccessToken:  resp.AccessToken,
+		RefreshToken: resp.RefreshToken,
+		Insecure:     insecure,
+	}
+	c.httpClient.SetAuthToken(resp.AccessToken)
+
+	if exp, err := jwtExpiry(resp.AccessToken); err == nil {
+		c.creds.AccessTokenExpiry = exp
+	}
+
+	if err := config.Save(c.creds); err != nil {
+		return nil, fmt.Errorf("save credentials: %w", err)
+	}
+
+	return c, nil
+}
+
+// StartOIDCDeviceLogin calls POST /api/v1/auth/oidc/device.
+func (c *Client) StartOIDCDeviceLogin() (DeviceAuthorization, error) {
+	var device DeviceAuthorization
+	resp, err := c.httpClient.R().
+		SetResult(&device).
+		Post("/api/v1/auth/oidc/device")
+	if err != nil {
+		return DeviceAuthorization{}, fmt.Errorf("OIDC device login request: %w", err)
+	}
+
+	switch {
+	case resp.StatusCode() == http.StatusNotFound:
+		return DeviceAuthorization{}, errors.New("OIDC login is not enabled on the server")
+	case resp.IsError():
+		return DeviceAuthorization{}, fmt.Errorf("OIDC device login failed: server returned HTTP %d: %s", resp.StatusCode(), utils.ParseErrorResponse(resp))
+	}
+
+	return device, nil
+}
+
+// waitForOIDCDeviceLogin polls POST /api/v1/auth/oidc/device/token until the user
+// has signed in, backing off when the server asks to slow down.
+func (c *Client) waitForOIDCDeviceLogin(device DeviceAuthorization) (LoginResponse, error) {
+	interval := defaultDevicePollInterval
+	if device.Interval > 0 {
+		interval = time.Duration(device.Interval) * time.Second
+	}
+	deadline := time.Now().Add(time.Duration(device.ExpiresIn) * time.Second)
+
+	for time.Now().Before(deadline) {
+		time.Sleep(interval)
+
+		var result LoginResponse
+		resp, err := c.httpClient.R().
+			SetBody(map[string]string{"device_code": device.DeviceCode}).
+			SetResult(&result).
+			Post("/api/v1/auth/oidc/device/token")
+		if err != nil {
+			return LoginResponse{}, fmt.Errorf("OIDC device token request: %w", err)
+		}
+		if !resp.IsError() {
+			return result, nil
+		}
+
+		switch msg := utils.ParseErrorResponse(resp); {
+		case resp.StatusCode() == http.StatusBadRequest && msg == "authorization_pending":
+		case resp.StatusCode() == http.StatusBadRequest && msg == "slow_down":
+			interval += slowDownIncrement
+		default:
+			return LoginResponse{}, fmt.Errorf("OIDC login failed: server returned HTTP %d: %s", resp.StatusCode(), msg)
+		}
+	}
+
+	return LoginResponse{}, errors.New("OIDC login failed: the device code expired before sign-in completed")
+}
+
+// Made with Bob
@@ -0,0 +1,142 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+)
+
+// API route constants for user endpoints.
+const (
+	usersRoute         = "/api/v1/users"
+	userRoute          = "/api/v1/users/%s"
+	userDisableRoute   = "/api/v1/users/%s/disable"
+	userEnableRoute    = "/api/v1/users/%s/enable"
+	userPasswordRoute  = "/api/v1/users/%s/password"
+	changePasswordPath = "/api/v1/auth/password"
+)
+
+// UserClient provides methods for interacting with the users API.
+// Users are addressed by ref, which is either the user ID or the username.
+type UserClient struct {
+	client *Client
+}
+
+// NewUserClient creates a new UserClient using the stored credentials.
+func NewUserClient() (*UserClient, error) {
+	client, err := New()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize client: %w", err)
+	}
+
+	return &UserClient{
+		client: client,
+	}, nil
+}
+
+// CreateUser creates a local user.
+func (c *UserClient) CreateUser(req *CreateUserRequest) (*User, error) {
+	var result User
+	resp, err := c.client.HTTPClient().R().
+		SetBody(req).
+		SetResult(&result).
+		Post(usersRoute)
+	if err != nil {
+		return nil, fmt.Errorf("create user: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("create user: server returned HTTP %d: %s", resp.StatusCode(), utils.ParseErrorResponse(resp))
+	}
+
+	return &result, nil
+}
+
+// ListUsers returns all users ordered by username.
+func (c *UserClient) ListUsers() ([]User, error) {
+	var result UserListResponse
+	resp, err := c.client.HTTPClient().R().
+		SetResult(&result).
+		Get(usersRoute)
+	if err != nil {
+		return nil, fmt.Errorf("list users: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("list users: server returned HTTP %d: %s", resp.StatusCode(), utils.ParseErrorResponse(resp))
+	}
+
+	return result.Users, nil
+}
+
+// SetDisabled disables or re-enables a user.
+func (c *UserClient) SetDisabled(ref string, disabled bool) (*User, error) {
+	route := userEnableRoute
+	if disabled {
+		route = userDisableRoute
+	}
+
+	var result User
+	resp, err := c.client.HTTPClient().R().
+		SetResult(&result).
+		Post(fmt.Sprintf(route, url.PathEscape(ref)))
+	if err != nil {
+		return nil, fmt.Errorf("update user: %w", err)
+	}
+
+	if resp.IsError() {
+		return nil, fmt.Errorf("update user: server returned HTTP %d: %s", resp.StatusCode(), utils.ParseErrorResponse(resp))
+	}
+
+	return &result, nil
+}
+
+// DeleteUser deletes a user.
+func (c *UserClient) DeleteUser(ref string) error {
+	resp, err := c.client.HTTPClient().R().
+		Delete(fmt.Sprintf(userRoute, url.PathEscape(ref)))
+	if err != nil {
+		return fmt.Errorf("delete user: %w", err)
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("delete user: server returned HTTP %d: %s", resp.StatusCode(), utils.ParseErrorResponse(resp))
+	}
+
+	return nil
+}
+
+// ResetPassword sets a new password for a user.
+func (c *UserClient) ResetPassword(ref, password string) error {
+	resp, err := c.client.HTTPClient().R().
+		SetBody(map[string]string{"password": password}).
+		Post(fmt.Sprintf(userPasswordRoute, url.PathEscape(ref)))
+	if err != nil {
+		return fmt.Errorf("reset password: %w", err)
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("reset password: server returned HTTP %d: %s", resp.StatusCode(), utils.ParseErrorResponse(resp))
+	}
+
+	return nil
+}
+
+// ChangePassword replaces the password of the logged-in user.
+func (c *UserClient) ChangePassword(currentPassword, newPassword string) error {
+	resp, err := c.client.HTTPClient().R().
+		SetBody(map[string]string{"current_password": currentPassword, "new_password": newPassword}).
+		Post(changePasswordPath)
+	if err != nil {
+		return fmt.Errorf("change password: %w", err)
+	}
+
+	if resp.IsError() {
+		return fmt.Errorf("change password: server returned HTTP %d: %s", resp.StatusCode(), utils.ParseErrorResponse(resp))
+	}
+
+	return nil
+}
+
+// Made with Bob
@@ -35,6 +35,9 @@ type Credentials struct {
 	AccessTokenExpiry time.Time `json:"access_token_expiry,omitempty"`
 	// Insecure indicates whether to skip TLS certificate verification.
 	Insecure bool `json:"insecure,omitempty"`
+	// APIKey is a catalog API key used instead of the token pair when set.
+	// API keys do not expire on a schedule and are never refreshed.
+	APIKey string `json:"api_key,omitempty"`
 }
 
 // configFilePath returns the absolute path to the credentials file.
@@ -0,0 +1,60 @@
+// Package connectors checks that data-source connectors can reach their remote
+// content: an S3 bucket for object_storage and an SFTP directory for file_system.
+package connectors
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Provider identifiers of the data-source connectors.
+const (
+	ProviderObjectStorage = "object_storage"
+	ProviderFileSystem    = "file_system"
+)
+
+// Checker checks that a connector configured with metadata can reach its content.
+// Metadata holds the decrypted values of the provider's schema.json fields.
+type Checker interface {
+	Check(ctx context.Context, metadata map[string]any) error
+}
+
+// CheckerFunc adapts a function to the Checker interface.
+type CheckerFunc func(ctx context.Context, metadata map[string]any) error
+
+// Check calls f.
+func (f CheckerFunc) Check(ctx context.Context, metadata map[string]any) error {
+	return f(ctx, metadata)
+}
+
+// DefaultCheckers returns the checkers of the built-in providers, keyed by provider ID.
+func DefaultCheckers() map[string]Checker {
+	return map[string]Checker{
+		ProviderObjectStorage: &ObjectStorageChecker{HTTPClient: http.DefaultClient},
+		ProviderFileSystem:    &FileSystemChecker{},
+	}
+}
+
+// stringField returns the string value of key in metadata.
+func stringField(metadata map[string]any, key string, required bool) (string, error) {
+	v, ok := metadata[key]
+	if !ok || v == nil {
+		if required {
+			return "", fmt.Errorf("%s is required", key)
+		}
+
+		return "", nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("%s must be a string", key)
+	}
+	if required && s == "" {
+		return "", fmt.Errorf("%s is required", key)
+	}
+
+	return s, nil
+}
+
+// Made with Bob
@@ -0,0 +1,232 @@
+package connectors
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultS3Region is used when the region cannot be derived from the endpoint.
+	// S3-compatible stores accept it for any bucket.
+	defaultS3Region = "us-east-1"
+
+	sigV4Algorithm   = "AWS4-HMAC-SHA256"
+	sigV4DateFormat  = "20060102T150405Z"
+	sigV4ShortFormat = "20060102"
+	// emptyPayloadHash is the SHA-256 of an empty request body.
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	// maxErrorBody limits how much of an error response is read.
+	maxErrorBody = 64 << 10
+)
+
+// ObjectStorageChecker checks an object_storage connector by listing at most one
+// object of the bucket with the S3 ListObjectsV2 API, using path-style addressing.
+type ObjectStorageChecker struct {
+	HTTPClient *http.Client
+	// Now returns the signing time; nil uses time.Now.
+	Now func() time.Time
+}
+
+type s3Config struct {
+	endpoint  *url.URL
+	bucket    string
+	accessKey string
+	secretKey string
+	prefix    string
+}
+
+// Check lists the bucket. A bucket in another AWS region than the one derived from
+// the endpoint is retried once in the region S3 reports.
+func (c *ObjectStorageChecker) Check(ctx context.Context, metadata map[string]any) error {
+	cfg, err := parseS3Config(metadata)
+	if err != nil {
+		return err
+	}
+
+	region := s3Region(cfg.endpoint.Hostname())
+	resp, err := c.listObjects(ctx, cfg, region)
+	if err != nil {
+		return err
+	}
+	if actual := resp.Header.Get("X-Amz-Bucket-Region"); resp.StatusCode != http.StatusOK && actual != "" && actual != region {
+		_ = resp.Body.Close()
+		if resp, err = c.listObjects(ctx, cfg, actual); err != nil {
+			return err
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("bucket %q: %s", cfg.bucket, s3ErrorMessage(resp))
+	}
+
+	return nil
+}
+
+func parseS3Config(metadata map[string]any) (*s3Config, error) {
+	cfg := &s3Config{}
+	fields := []struct {
+		key      string
+		dst      *string
+		required bool
+	}{
+		{"bucket_name", &cfg.bucket, true},
+		{"access_key_id", &cfg.accessKey, true},
+		{"secret_access_key", &cfg.secretKey, true},
+		{"prefix", &cfg.prefix, false},
+	}
+	for _, f := range fields {
+		v, err := stringField(metadata, f.key, f.required)
+		if err != nil {
+			return nil, err
+		}
+		*f.dst = v
+	}
+
+	raw, err := stringField(metadata, "endpoint_url", true)
+	if err != nil {
+		return nil, err
+	}
+	cfg.endpoint, err = url.Parse(raw)
+	if err != nil || (cfg.endpoint.Scheme != "http" && cfg.endpoint.Scheme != "https") || cfg.endpoint.Host == "" {
+		return nil, fmt.Errorf("endpoint_url %q is not an http or https URL", raw)
+	}
+
+	return cfg, nil
+}
+
+// listObjects sends a signed ListObjectsV2 request for a single key.
+func (c *ObjectStorageChecker) listObjects(ctx context.Context, cfg *s3Config, region string) (*http.Response, error) {
+	query := url.Values{"list-type": {"2"}, "max-keys": {"1"}}
+	if cfg.prefix != "" {
+		query.Set("prefix", cfg.prefix)
+	}
+
+	u := *cfg.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + cfg.bucket
+	u.RawQuery = canonicalQuery(query)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build S3 request: %w", err)
+	}
+	now := time.Now
+	if c.Now != nil {
+		now = c.Now
+	}
+	signSigV4(req, cfg.accessKey, cfg.secretKey, region, now().UTC())
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach %s: %w", cfg.endpoint.Host, err)
+	}
+
+	return resp, nil
+}
+
+// signSigV4 adds AWS Signature Version 4 headers for the s3 service to a request
+// without a body.
+func signSigV4(req *http.Request, accessKey, secretKey, region string, t time.Time) {
+	amzDate := t.Format(sigV4DateFormat)
+	shortDate := t.Format(sigV4ShortFormat)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", emptyPayloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host + "\n" + "x-amz-content-sha256:" + emptyPayloadHash + "\n" + "x-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		emptyPayloadHash,
+	}, "\n")
+
+	scope := shortDate + "/" + region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{sigV4Algorithm, amzDate, scope, sha256Hex(canonicalRequest)}, "\n")
+
+	key := []byte("AWS4" + secretKey)
+	for _, part := range []string{shortDate, region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		sigV4Algorithm, accessKey, scope, signedHeaders, signature))
+}
+
+// canonicalQuery encodes query sorted by key, with spaces as %20 as SigV4 requires.
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, sigV4Escape(k)+"="+sigV4Escape(query.Get(k)))
+	}
+
+	return strings.Join(parts, "&")
+}
+
+func sigV4Escape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
+
+// s3Region derives the AWS region from hosts such as s3.eu-west-1.amazonaws.com.
+func s3Region(host string) string {
+	if !strings.HasSuffix(host, ".amazonaws.com") {
+		return defaultS3Region
+	}
+	for _, label := range strings.Split(strings.TrimSuffix(host, ".amazonaws.com"), ".") {
+		if region, ok := strings.CutPrefix(label, "s3-"); ok && region != "external-1" {
+			return region
+		}
+		if label != "s3" && strings.Count(label, "-") >= 2 {
+			return label
+		}
+	}
+
+	return defaultS3Region
+}
+
+// s3ErrorMessage returns the code and message of an S3 error response.
+func s3ErrorMessage(resp *http.Response) string {
+	var s3Err struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	if err := xml.Unmarshal(body, &s3Err); err == nil && s3Err.Code != "" {
+		return fmt.Sprintf("%s: %s (HTTP %d)", s3Err.Code, s3Err.Message, resp.StatusCode)
+	}
+
+	return fmt.Sprintf("HTTP %d", resp.StatusCode)
+}
+
+// Made with Bob
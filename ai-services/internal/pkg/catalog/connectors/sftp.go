@@ -0,0 +1,135 @@
+package connectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	defaultSSHPort = "22"
+	sshDialTimeout = 15 * time.Second
+)
+
+// fingerprintPrefix starts the SHA256 fingerprints printed by ssh-keygen -l.
+const fingerprintPrefix = "SHA256:"
+
+// FileSystemChecker checks a file_system connector by signing in over SSH with the
+// configured private key and listing remote_path through SFTP. The server must
+// present the configured host_key.
+type FileSystemChecker struct{}
+
+// Check lists remote_path on the host. Without host_key, it fails after the
+// handshake and reports the fingerprint of the key the server presented.
+func (c *FileSystemChecker) Check(ctx context.Context, metadata map[string]any) error {
+	values := map[string]string{}
+	for _, key := range []string{"host", "username", "private_key", "remote_path"} {
+		v, err := stringField(metadata, key, true)
+		if err != nil {
+			return err
+		}
+		values[key] = v
+	}
+
+	signer, err := ssh.ParsePrivateKey([]byte(values["private_key"]))
+	if err != nil {
+		return fmt.Errorf("private_key: %w", err)
+	}
+	hostKey, err := stringField(metadata, "host_key", false)
+	if err != nil {
+		return err
+	}
+	hostKeyCallback, err := hostKeyCallback(hostKey)
+	if err != nil {
+		return fmt.Errorf("host_key: %w", err)
+	}
+
+	addr := values["host"]
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, defaultSSHPort)
+	}
+
+	client, err := dialSSH(ctx, addr, &ssh.ClientConfig{
+		User: values["username"],
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         sshDialTimeout,
+	})
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	sftpClient, err := sftp.NewClient(client)
+	if err != nil {
+		return fmt.Errorf("failed to start SFTP on %s: %w", addr, err)
+	}
+	defer sftpClient.Close()
+
+	if _, err := sftpClient.ReadDirContext(ctx, values["remote_path"]); err != nil {
+		return fmt.Errorf("cannot list %s on %s: %w", values["remote_path"], addr, err)
+	}
+
+	return nil
+}
+
+// errHostKeyNotSet rejects the server when the connector has no host_key to verify.
+var errHostKeyNotSet = errors.New("host_key is not set")
+
+// hostKeyCallback returns the callback that verifies the server against hostKey: a
+// public key in authorized_keys format or a SHA256 fingerprint. An empty hostKey
+// rejects every server, naming the fingerprint of the key it presented.
+func hostKeyCallback(hostKey string) (ssh.HostKeyCallback, error) {
+	hostKey = strings.TrimSpace(hostKey)
+	switch {
+	case hostKey == "":
+		return func(_ string, _ net.Addr, key ssh.PublicKey) error {
+			return fmt.Errorf("%w: cannot verify the %s host key %s", errHostKeyNotSet, key.Type(), ssh.FingerprintSHA256(key))
+		}, nil
+	case strings.HasPrefix(hostKey, fingerprintPrefix):
+		return func(_ string, _ net.Addr, key ssh.PublicKey) error {
+			if got := ssh.FingerprintSHA256(key); got != hostKey {
+				return fmt.Errorf("host key mismatch: server presented %s %s, expected %s", key.Type(), got, hostKey)
+			}
+
+			return nil
+		}, nil
+	default:
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(hostKey))
+		if err != nil {
+			return nil, fmt.Errorf("must be a public key such as \"ssh-ed25519 AAAA...\" or a %s fingerprint: %w", fingerprintPrefix, err)
+		}
+
+		return ssh.FixedHostKey(key), nil
+	}
+}
+
+// dialSSH connects to addr, giving up when ctx is done.
+func dialSSH(ctx context.Context, addr string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	conn, err := (&net.Dialer{Timeout: config.Timeout}).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach %s: %w", addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, addr, config)
+	if err != nil {
+		_ = conn.Close()
+
+		return nil, fmt.Errorf("SSH login to %s failed: %w", addr, err)
+	}
+	// Clear the handshake deadline; SFTP calls are bounded by ctx.
+	_ = conn.SetDeadline(time.Time{})
+
+	return ssh.NewClient(sshConn, chans, reqs), nil
+}
+
+// Made with Bob
@@ -0,0 +1,195 @@
+package connectors
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"net"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+// startSFTPServer serves SFTP on a loopback port, accepting only clientKey. It
+// returns the address and the server's host key.
+func startSFTPServer(t *testing.T, clientKey ssh.PublicKey) (string, ssh.PublicKey) {
+	t.Helper()
+
+	_, hostPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostSigner, err := ssh.NewSignerFromKey(hostPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if !bytes.Equal(key.Marshal(), clientKey.Marshal()) {
+				return nil, os.ErrPermission
+			}
+
+			return nil, nil
+		},
+	}
+	config.AddHostKey(hostSigner)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveSFTPConn(conn, config)
+		}
+	}()
+
+	return ln.Addr().String(), hostSigner.PublicKey()
+}
+
+func serveSFTPConn(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+
+	for newChan := range chans {
+		ch, chReqs, err := newChan.Accept()
+		if err != nil {
+			continue
+		}
+		go func() {
+			for req := range chReqs {
+				ok := req.Type == "subsystem" && string(req.Payload[4:]) == "sftp"
+				_ = req.Reply(ok, nil)
+				if ok {
+					server, err := sftp.NewServer(ch)
+					if err == nil {
+						_ = server.Serve()
+					}
+					_ = ch.Close()
+				}
+			}
+		}()
+	}
+}
+
+func clientKeyPair(t *testing.T) (ssh.PublicKey, string) {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sshPub, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return sshPub, string(pem.EncodeToMemory(block))
+}
+
+func TestFileSystemChecker(t *testing.T) {
+	pub, privPEM := clientKeyPair(t)
+	addr, hostKey := startSFTPServer(t, pub)
+
+	metadata := map[string]any{
+		"host":        addr,
+		"username":    "ingest",
+		"private_key": privPEM,
+		"host_key":    string(ssh.MarshalAuthorizedKey(hostKey)),
+		"remote_path": t.TempDir(),
+	}
+	c := &FileSystemChecker{}
+	if err := c.Check(context.Background(), metadata); err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+
+	metadata["remote_path"] = "/does/not/exist"
+	if err := c.Check(context.Background(), metadata); err == nil || !strings.Contains(err.Error(), "cannot list /does/not/exist") {
+		t.Fatalf("Check error = %v", err)
+	}
+}
+
+func TestFileSystemCheckerRejectedKey(t *testing.T) {
+	pub, _ := clientKeyPair(t)
+	addr, hostKey := startSFTPServer(t, pub)
+	_, otherPEM := clientKeyPair(t)
+
+	err := (&FileSystemChecker{}).Check(context.Background(), map[string]any{
+		"host":        addr,
+		"username":    "ingest",
+		"private_key": otherPEM,
+		"host_key":    ssh.FingerprintSHA256(hostKey),
+		"remote_path": "/",
+	})
+	if err == nil || !strings.Contains(err.Error(), "SSH login") {
+		t.Fatalf("Check error = %v", err)
+	}
+}
+
+func TestFileSystemCheckerHostKey(t *testing.T) {
+	pub, privPEM := clientKeyPair(t)
+	addr, hostKey := startSFTPServer(t, pub)
+	otherHostKey, _ := clientKeyPair(t)
+	metadata := map[string]any{
+		"host":        addr,
+		"username":    "ingest",
+		"private_key": privPEM,
+		"remote_path": t.TempDir(),
+	}
+	c := &FileSystemChecker{}
+
+	// Without a host key the server cannot be verified; the error names its fingerprint.
+	err := c.Check(context.Background(), metadata)
+	if err == nil || !strings.Contains(err.Error(), "host_key is not set") || !strings.Contains(err.Error(), ssh.FingerprintSHA256(hostKey)) {
+		t.Fatalf("Check without host_key error = %v", err)
+	}
+
+	metadata["host_key"] = ssh.FingerprintSHA256(hostKey)
+	if err := c.Check(context.Background(), metadata); err != nil {
+		t.Fatalf("Check with fingerprint: %v", err)
+	}
+
+	for _, wrong := range []string{string(ssh.MarshalAuthorizedKey(otherHostKey)), ssh.FingerprintSHA256(otherHostKey)} {
+		metadata["host_key"] = wrong
+		if err := c.Check(context.Background(), metadata); err == nil || !strings.Contains(err.Error(), "SSH login") {
+			t.Fatalf("Check with wrong host key %q error = %v", wrong, err)
+		}
+	}
+
+	metadata["host_key"] = "not a key"
+	if err := c.Check(context.Background(), metadata); err == nil || !strings.Contains(err.Error(), "host_key") {
+		t.Fatalf("Check with invalid host key error = %v", err)
+	}
+}
+
+func TestFileSystemCheckerInvalidKey(t *testing.T) {
+	err := (&FileSystemChecker{}).Check(context.Background(), map[string]any{
+		"host":        "127.0.0.1",
+		"username":    "ingest",
+		"private_key": "not a key",
+		"remote_path": "/",
+	})
+	if err == nil || !strings.Contains(err.Error(), "private_key") {
+		t.Fatalf("Check error = %v", err)
+	}
+}
+
+// Made with Bob
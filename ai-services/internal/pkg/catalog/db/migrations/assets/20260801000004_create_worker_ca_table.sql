@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- ── worker_ca ──────────────────────────────────────────────────────────────────
+-- Holds the internal certificate authority that signs worker client certificates
+-- and the WorkerGateway server certificate. There is at most one row.
+--
+-- cert_pem:          PEM-encoded CA certificate (public).
+-- key_pem_encrypted: PEM-encoded CA private key, encrypted with DB_ENCRYPTION_KEY.
+-- ──────────────────────────────────────────────────────────────────────────────
+CREATE TABLE worker_ca (
+    id                SMALLINT    PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    cert_pem          TEXT        NOT NULL,
+    key_pem_encrypted TEXT        NOT NULL,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS worker_ca;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- ── worker_tokens ──────────────────────────────────────────────────────────────
+-- Single-use bootstrap tokens a worker exchanges for its identity via Register.
+-- Only the SHA-256 hash of each token is stored.
+--
+-- used_at:    set when the worker registers with the token; NULL while unused.
+-- revoked_at: set when an admin revokes the token or a newer token supersedes it.
+-- ──────────────────────────────────────────────────────────────────────────────
+CREATE TABLE worker_tokens (
+    id         UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    worker_id  UUID        NOT NULL REFERENCES workers(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at    TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX ON worker_tokens(worker_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS worker_tokens;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Add the schedulable flag to workers. A cordoned worker (schedulable = FALSE)
+-- keeps running the applications it already hosts but receives no new placements.
+-- Existing workers stay schedulable.
+ALTER TABLE workers
+    ADD COLUMN schedulable BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE workers
+    DROP COLUMN IF EXISTS schedulable;
+-- +goose StatementEnd
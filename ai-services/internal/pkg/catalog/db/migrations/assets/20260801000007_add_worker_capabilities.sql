@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Record the protocol a worker speaks, as declared when it registers or opens its
+-- command stream. supported_commands holds CommandType names such as
+-- 'COMMAND_TYPE_LIST_PODS'. Existing workers stay at version 0 until they reconnect.
+ALTER TABLE workers
+    ADD COLUMN protocol_version   INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN supported_commands TEXT[]  NOT NULL DEFAULT '{}';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE workers
+    DROP COLUMN IF EXISTS supported_commands,
+    DROP COLUMN IF EXISTS protocol_version;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- ── users ──────────────────────────────────────────────────────────────────────
+-- Catalog API users.
+--
+-- id:             opaque identifier used as the JWT subject. Local users get a
+--                 generated UUID; users signed in through ManageIQ keep their
+--                 ManageIQ user ID.
+-- password_hash:  PBKDF2 hash (iterations.salt.hash); empty for users that
+--                 cannot log in with a password (e.g. ManageIQ users).
+-- role:           viewer | operator | admin.
+-- disabled:       disabled users cannot log in or refresh their tokens.
+-- bootstrap_hash: for the admin seeded from --admin-password-hash, the hash last
+--                 applied from the flag, so a new hash resets the password
+--                 while an unchanged one leaves API password changes in place.
+-- ──────────────────────────────────────────────────────────────────────────────
+CREATE TABLE users (
+    id             TEXT        PRIMARY KEY DEFAULT gen_random_uuid()::TEXT,
+    username       TEXT        NOT NULL UNIQUE,
+    name           TEXT        NOT NULL DEFAULT '',
+    password_hash  TEXT        NOT NULL DEFAULT '',
+    role           TEXT        NOT NULL DEFAULT 'viewer'
+                               CHECK (role IN ('viewer', 'operator', 'admin')),
+    disabled       BOOLEAN     NOT NULL DEFAULT FALSE,
+    bootstrap_hash TEXT,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS users;
+-- +goose StatementEnd
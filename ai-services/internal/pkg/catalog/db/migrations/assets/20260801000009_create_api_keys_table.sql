@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- ── api_keys ───────────────────────────────────────────────────────────────────
+-- Long-lived API keys that authenticate as their owner through an
+-- "Authorization: ApiKey <key>" header. Only the SHA-256 hash of each key is
+-- stored; prefix keeps its first characters so users can tell keys apart.
+--
+-- scopes:       API areas the key may use (e.g. applications:write); empty
+--               means every area the owner's role allows.
+-- expires_at:   NULL for keys that never expire.
+-- last_used_at: updated at most once a minute while the key is in use.
+-- revoked_at:   set when the key is revoked; revoked keys are kept for listing.
+-- ──────────────────────────────────────────────────────────────────────────────
+CREATE TABLE api_keys (
+    id           UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id      TEXT        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name         TEXT        NOT NULL,
+    prefix       TEXT        NOT NULL,
+    key_hash     VARCHAR(64) NOT NULL UNIQUE,
+    scopes       TEXT[]      NOT NULL DEFAULT '{}',
+    expires_at   TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    revoked_at   TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX ON api_keys(user_id);
+-- A user cannot have two active keys with the same name.
+CREATE UNIQUE INDEX api_keys_user_name_active_key ON api_keys(user_id, name) WHERE revoked_at IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS api_keys;
+-- +goose StatementEnd
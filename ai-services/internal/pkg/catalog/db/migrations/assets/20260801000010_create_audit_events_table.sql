@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- ── audit_events ──────────────────────────────────────────────────────────────
+-- One row per mutating catalog API call, written by the audit middleware.
+--
+-- user_id:       the caller; empty when the request was rejected before it
+--                was authenticated. Not a foreign key, so events outlive users.
+-- api_key_id:    set when the caller authenticated with an API key.
+-- action:        <resource>.<verb>, e.g. application.create.
+-- resource_id:   the ID of the affected resource; empty when unknown (for
+--                example a create that failed validation).
+-- outcome:       success, denied (401/403) or failure.
+-- ──────────────────────────────────────────────────────────────────────────────
+CREATE TABLE audit_events (
+    id            UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id       TEXT        NOT NULL DEFAULT '',
+    api_key_id    UUID,
+    action        TEXT        NOT NULL,
+    resource_type TEXT        NOT NULL,
+    resource_id   TEXT        NOT NULL DEFAULT '',
+    method        TEXT        NOT NULL,
+    path          TEXT        NOT NULL,
+    status_code   INTEGER     NOT NULL,
+    outcome       TEXT        NOT NULL CHECK (outcome IN ('success', 'denied', 'failure')),
+    request_id    TEXT        NOT NULL DEFAULT '',
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX ON audit_events(created_at DESC);
+CREATE INDEX ON audit_events(user_id, created_at DESC);
+CREATE INDEX ON audit_events(resource_type, resource_id, created_at DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS audit_events;
+-- +goose StatementEnd
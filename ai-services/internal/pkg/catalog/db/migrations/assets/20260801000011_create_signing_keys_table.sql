@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- ── signing_keys ───────────────────────────────────────────────────────────────
+-- Asymmetric keys that sign catalog access and refresh tokens. The newest key
+-- without retires_at signs new tokens; every key not yet retired verifies them
+-- and is published at /.well-known/jwks.json.
+--
+-- kid:               key ID (RFC 7638 thumbprint), sent in the JWT header.
+-- algorithm:         RS256 | EdDSA.
+-- public_key_pem:    PEM-encoded public key (PKIX).
+-- key_pem_encrypted: PEM-encoded private key (PKCS #8), encrypted with DB_ENCRYPTION_KEY.
+-- retires_at:        set when a newer key replaces this one; the key stops
+--                    verifying tokens at that time.
+-- ──────────────────────────────────────────────────────────────────────────────
+CREATE TABLE signing_keys (
+    kid               TEXT        PRIMARY KEY,
+    algorithm         TEXT        NOT NULL CHECK (algorithm IN ('RS256', 'EdDSA')),
+    public_key_pem    TEXT        NOT NULL,
+    key_pem_encrypted TEXT        NOT NULL,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    retires_at        TIMESTAMPTZ
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS signing_keys;
+-- +goose StatementEnd
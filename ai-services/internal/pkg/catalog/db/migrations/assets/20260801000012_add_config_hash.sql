@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Record the configuration each component and service was deployed with, so an
+-- application can be reconfigured by redeploying only what changed.
+--
+-- components.config_hash: CalculateComponentHash of type, provider and params.
+-- services.config_hash:   CalculateComponentHash of catalog ID, version, params
+--                         and the hashes of the components the service uses.
+--
+-- Rows created before this migration have no hash. On the first reconfiguration
+-- of their application, services are redeployed and components are taken over by
+-- the planned component of the same type and provider, which records its hash.
+ALTER TABLE components
+    ADD COLUMN config_hash TEXT;
+
+ALTER TABLE services
+    ADD COLUMN config_hash TEXT;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE services
+    DROP COLUMN IF EXISTS config_hash;
+
+ALTER TABLE components
+    DROP COLUMN IF EXISTS config_hash;
+-- +goose StatementEnd
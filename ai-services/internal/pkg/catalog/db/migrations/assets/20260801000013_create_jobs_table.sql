@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- ── jobs ──────────────────────────────────────────────────────────────────────
+-- Application deployments and deletions run as jobs, so that one interrupted by
+-- an apiserver restart is resumed or rolled back instead of leaving its
+-- application in Deploying or Deleting.
+--
+-- kind:             create | reconfigure | delete.
+-- application_id:   not a foreign key, so jobs outlive deleted applications.
+-- payload:          what the job needs to run again: the serialized deployment
+--                   plan and request, or the services and components to delete.
+-- completed_steps:  checkpoints the job passed; a resumed job skips them where
+--                   it can.
+-- attempts:         how many times the job was started, including resumptions.
+-- lease_owner:      the apiserver instance running the job. It renews
+--                   lease_expires_at while the job runs; a running job whose
+--                   lease expired is claimed by another instance.
+-- cancel_requested: set by POST /jobs/:id/cancel and by deleting the
+--                   application; the owner stops the job when it renews its lease.
+-- ──────────────────────────────────────────────────────────────────────────────
+CREATE TABLE jobs (
+    id               UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind             TEXT        NOT NULL CHECK (kind IN ('create', 'reconfigure', 'delete')),
+    application_id   UUID        NOT NULL,
+    status           TEXT        NOT NULL DEFAULT 'running'
+                                 CHECK (status IN ('running', 'succeeded', 'failed', 'cancelled', 'rolled_back')),
+    payload          JSONB       NOT NULL,
+    completed_steps  TEXT[]      NOT NULL DEFAULT '{}',
+    attempts         INTEGER     NOT NULL DEFAULT 1,
+    lease_owner      TEXT,
+    lease_expires_at TIMESTAMPTZ,
+    cancel_requested BOOLEAN     NOT NULL DEFAULT FALSE,
+    error            TEXT        NOT NULL DEFAULT '',
+    created_by       TEXT        NOT NULL DEFAULT '',
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at      TIMESTAMPTZ
+);
+
+CREATE INDEX ON jobs(application_id, created_at DESC);
+CREATE INDEX ON jobs(lease_expires_at) WHERE status = 'running';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS jobs;
+-- +goose StatementEnd
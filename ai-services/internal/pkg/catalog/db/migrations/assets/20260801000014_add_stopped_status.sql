@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Add 'Stopped' to the application status enum for applications whose pods were
+-- stopped on purpose, so that they are not reported as failed.
+-- Postgres does not allow removing enum values, so this migration is irreversible.
+ALTER TYPE status ADD VALUE IF NOT EXISTS 'Stopped';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- NOTE: Postgres does not support removing values from an enum type.
+-- Rolling back this migration is a no-op; 'Stopped' will remain in status.
+-- +goose StatementEnd
@@ -0,0 +1,59 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- ── webhooks ──────────────────────────────────────────────────────────────────
+-- HTTP endpoints admins register to be notified of catalog events.
+--
+-- secret:  the HMAC-SHA256 key payloads are signed with, encrypted with the
+--          catalog encryption key.
+-- events:  the event types the endpoint subscribes to, e.g. application.failed.
+-- ──────────────────────────────────────────────────────────────────────────────
+CREATE TABLE webhooks (
+    id         UUID         PRIMARY KEY DEFAULT gen_random_uuid(),
+    name       VARCHAR(255) NOT NULL UNIQUE,
+    url        TEXT         NOT NULL,
+    secret     TEXT         NOT NULL,
+    events     TEXT[]       NOT NULL,
+    enabled    BOOLEAN      NOT NULL DEFAULT TRUE,
+    created_by VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+-- ── webhook_deliveries ────────────────────────────────────────────────────────
+-- One row per event sent to a webhook, kept as its delivery log.
+--
+-- payload:          the signed JSON body, sent unchanged on every attempt.
+-- attempts:         how many times sending was started.
+-- next_attempt_at:  when a pending delivery is due. A dispatcher claiming it
+--                   moves it forward, so another apiserver instance does not send
+--                   it concurrently and an interrupted attempt is retried.
+-- last_status_code: the HTTP status of the last attempt; NULL when no response
+--                   was received.
+-- ──────────────────────────────────────────────────────────────────────────────
+CREATE TABLE webhook_deliveries (
+    id               UUID        PRIMARY KEY,
+    webhook_id       UUID        NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event            TEXT        NOT NULL,
+    payload          JSONB       NOT NULL,
+    status           TEXT        NOT NULL DEFAULT 'pending'
+                                 CHECK (status IN ('pending', 'delivered', 'failed')),
+    attempts         INTEGER     NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_status_code INTEGER,
+    last_error       TEXT        NOT NULL DEFAULT '',
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    delivered_at     TIMESTAMPTZ
+);
+
+CREATE INDEX ON webhook_deliveries(webhook_id, created_at DESC);
+CREATE INDEX ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhooks;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- ── signing_key_set ────────────────────────────────────────────────────────────
+-- Single row recording when the catalog first signed tokens with signing_keys.
+-- Retired keys are deleted, so the oldest key does not tell. HS256 tokens issued
+-- with AUTH_JWT_SECRET before that time are accepted for one refresh token TTL
+-- after it; later HS256 tokens are always rejected.
+-- ──────────────────────────────────────────────────────────────────────────────
+CREATE TABLE signing_key_set (
+    id         BOOLEAN     PRIMARY KEY DEFAULT TRUE CHECK (id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO signing_key_set (created_at)
+SELECT MIN(created_at) FROM signing_keys HAVING COUNT(*) > 0;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS signing_key_set;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Add 'Stopped' to the service and component status enums. Stopping part of an
+-- application records the stopped services and components, so that the sync service
+-- keeps checking the rest of the application and expects their pods not to run.
+-- Postgres does not allow removing enum values, so this migration is irreversible.
+ALTER TYPE service_status ADD VALUE IF NOT EXISTS 'Stopped';
+ALTER TYPE component_status ADD VALUE IF NOT EXISTS 'Stopped';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- NOTE: Postgres does not support removing values from an enum type.
+-- Rolling back this migration is a no-op; 'Stopped' will remain in service_status
+-- and component_status.
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKey is a long-lived credential that authenticates as its owner.
+// The key itself is never stored; only its SHA-256 hash.
+type APIKey struct {
+	ID         uuid.UUID  `json:"id"`
+	UserID     string     `json:"user_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	KeyHash    string     `json:"-"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+
+	// Username, UserRole and UserDisabled describe the owner. They are only
+	// populated by the lookups that join the users table.
+	Username     string `json:"username,omitempty"`
+	UserRole     string `json:"-"`
+	UserDisabled bool   `json:"-"`
+}
+
+// Made with Bob
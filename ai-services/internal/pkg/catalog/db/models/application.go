@@ -23,6 +23,7 @@ const (
 	ApplicationStatusRunning     ApplicationStatus = "Running"
 	ApplicationStatusDeleting    ApplicationStatus = "Deleting"
 	ApplicationStatusError       ApplicationStatus = "Error"
+	ApplicationStatusStopped     ApplicationStatus = "Stopped" // Pods stopped on purpose; not synced with the runtime
 )
 
 // ServiceStatus represents the status of a service.
@@ -32,6 +33,7 @@ const (
 	ServiceStatusInitializing ServiceStatus = "Initializing"
 	ServiceStatusRunning      ServiceStatus = "Running"
 	ServiceStatusError        ServiceStatus = "Error"
+	ServiceStatusStopped      ServiceStatus = "Stopped" // Pods stopped on purpose; not synced with the runtime
 )
 
 // ComponentStatus represents the status of a component.
@@ -41,6 +43,7 @@ const (
 	ComponentStatusInitializing ComponentStatus = "Initializing"
 	ComponentStatusRunning      ComponentStatus = "Running"
 	ComponentStatusError        ComponentStatus = "Error"
+	ComponentStatusStopped      ComponentStatus = "Stopped" // Pods stopped on purpose; not synced with the runtime
 )
 
 // Application represents an application in the catalog.
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditOutcome is the result of an audited API call.
+type AuditOutcome string
+
+const (
+	AuditOutcomeSuccess AuditOutcome = "success"
+	AuditOutcomeDenied  AuditOutcome = "denied"
+	AuditOutcomeFailure AuditOutcome = "failure"
+)
+
+// AuditEvent records one mutating catalog API call.
+type AuditEvent struct {
+	ID           uuid.UUID    `json:"id"`
+	UserID       string       `json:"user_id"`
+	APIKeyID     *uuid.UUID   `json:"api_key_id,omitempty"`
+	Action       string       `json:"action"`
+	ResourceType string       `json:"resource_type"`
+	ResourceID   string       `json:"resource_id"`
+	Method       string       `json:"method"`
+	Path         string       `json:"path"`
+	StatusCode   int          `json:"status_code"`
+	Outcome      AuditOutcome `json:"outcome"`
+	RequestID    string       `json:"request_id"`
+	CreatedAt    time.Time    `json:"created_at"`
+
+	// Username is the caller's current username. It is only populated by
+	// GetAll, and is empty for users that no longer exist.
+	Username string `json:"username,omitempty"`
+}
+
+// Made with Bob
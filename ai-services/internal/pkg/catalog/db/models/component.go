@@ -10,16 +10,17 @@ import (
 // Components are infrastructure pieces that can be shared across multiple services,
 // such as LLM servers, embedding models, vector databases, etc.
 type Component struct {
-	ID        uuid.UUID        `json:"id"`
-	Type      string           `json:"type"`     // e.g., "llm", "embedding", "vector_db", "reranker"
-	Provider  string           `json:"provider"` // e.g., "vllm-cpu", "vllm-spyre"
-	Status    ComponentStatus  `json:"status"`
-	Message   string           `json:"message,omitempty"`
-	Endpoints []map[string]any `json:"endpoints,omitempty"` // JSONB field for endpoint configurations
-	Version   string           `json:"version"`             // Component version
-	Metadata  map[string]any   `json:"metadata,omitempty"`  // JSONB field for additional metadata
-	CreatedAt time.Time        `json:"created_at"`
-	UpdatedAt time.Time        `json:"updated_at"`
+	ID         uuid.UUID        `json:"id"`
+	Type       string           `json:"type"`     // e.g., "llm", "embedding", "vector_db", "reranker"
+	Provider   string           `json:"provider"` // e.g., "vllm-cpu", "vllm-spyre"
+	Status     ComponentStatus  `json:"status"`
+	Message    string           `json:"message,omitempty"`
+	Endpoints  []map[string]any `json:"endpoints,omitempty"` // JSONB field for endpoint configurations
+	Version    string           `json:"version"`             // Component version
+	Metadata   map[string]any   `json:"metadata,omitempty"`  // JSONB field for additional metadata
+	ConfigHash string           `json:"-"`                   // Hash of the configuration the component was deployed with
+	CreatedAt  time.Time        `json:"created_at"`
+	UpdatedAt  time.Time        `json:"updated_at"`
 }
 
 // Made with Bob
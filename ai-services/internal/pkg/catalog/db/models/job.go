@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// JobKind is the operation a job performs on an application.
+type JobKind string
+
+const (
+	JobKindCreate      JobKind = "create"
+	JobKindReconfigure JobKind = "reconfigure"
+	JobKindDelete      JobKind = "delete"
+)
+
+// JobStatus is the state of a job.
+type JobStatus string
+
+const (
+	JobStatusRunning   JobStatus = "running"
+	JobStatusSucceeded JobStatus = "succeeded"
+	JobStatusFailed    JobStatus = "failed"
+	JobStatusCancelled JobStatus = "cancelled"
+	// JobStatusRolledBack marks a job that was interrupted and, instead of being resumed,
+	// had its application settled by its handler's roll back.
+	JobStatusRolledBack JobStatus = "rolled_back"
+)
+
+// Job is a durable application deployment or deletion. Its payload holds what the job
+// needs to run again after an apiserver restart.
+type Job struct {
+	ID              uuid.UUID  `json:"id"`
+	Kind            JobKind    `json:"kind"`
+	ApplicationID   uuid.UUID  `json:"application_id"`
+	Status          JobStatus  `json:"status"`
+	Payload         []byte     `json:"-"`
+	CompletedSteps  []string   `json:"completed_steps"`
+	Attempts        int        `json:"attempts"`
+	LeaseOwner      string     `json:"lease_owner,omitempty"`
+	LeaseExpiresAt  *time.Time `json:"lease_expires_at,omitempty"`
+	CancelRequested bool       `json:"cancel_requested"`
+	Error           string     `json:"error,omitempty"`
+	CreatedBy       string     `json:"created_by"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	FinishedAt      *time.Time `json:"finished_at,omitempty"`
+}
+
+// Made with Bob
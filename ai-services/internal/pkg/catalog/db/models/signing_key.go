@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// SigningKey is a key pair that signs catalog JWTs. The private key is stored
+// encrypted; callers decrypt it with the DB encryption key.
+type SigningKey struct {
+	KID             string     `json:"kid"`
+	Algorithm       string     `json:"algorithm"`
+	PublicKeyPEM    string     `json:"-"`
+	KeyPEMEncrypted string     `json:"-"`
+	CreatedAt       time.Time  `json:"created_at"`
+	RetiresAt       *time.Time `json:"retires_at,omitempty"`
+}
+
+// Made with Bob
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// User is a catalog API user. PasswordHash is empty for users that cannot log in
+// with a password, such as users signed in through ManageIQ.
+type User struct {
+	ID           string    `json:"id"`
+	Username     string    `json:"username"`
+	Name         string    `json:"name"`
+	PasswordHash string    `json:"-"`
+	Role         string    `json:"role"`
+	Disabled     bool      `json:"disabled"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Made with Bob
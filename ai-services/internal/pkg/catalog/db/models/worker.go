@@ -27,13 +27,21 @@ const (
 )
 
 // Worker represents a registered worker agent.
+// Schedulable is false while the worker is cordoned: it keeps the applications it
+// already hosts but the scheduler places no new ones on it.
+// ProtocolVersion and SupportedCommands are declared by the worker when it connects;
+// Outdated is not stored but derived by the worker registry from ProtocolVersion.
 type Worker struct {
-	ID            uuid.UUID         `json:"id"`
-	Name          string            `json:"name"`
-	RuntimeType   WorkerRuntimeType `json:"runtime_type"`
-	Status        WorkerStatus      `json:"status"`
-	LastHeartbeat *time.Time        `json:"last_heartbeat,omitempty"`
-	Metadata      map[string]any    `json:"metadata,omitempty"`
-	RegisteredAt  time.Time         `json:"registered_at"`
-	UpdatedAt     time.Time         `json:"updated_at"`
+	ID                uuid.UUID         `json:"id"`
+	Name              string            `json:"name"`
+	RuntimeType       WorkerRuntimeType `json:"runtime_type"`
+	Status            WorkerStatus      `json:"status"`
+	Schedulable       bool              `json:"schedulable"`
+	ProtocolVersion   int               `json:"protocol_version"`
+	SupportedCommands []string          `json:"supported_commands,omitempty"`
+	Outdated          bool              `json:"outdated"`
+	LastHeartbeat     *time.Time        `json:"last_heartbeat,omitempty"`
+	Metadata          map[string]any    `json:"metadata,omitempty"`
+	RegisteredAt      time.Time         `json:"registered_at"`
+	UpdatedAt         time.Time         `json:"updated_at"`
 }
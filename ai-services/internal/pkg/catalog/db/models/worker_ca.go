@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// WorkerCA is the internal certificate authority used to secure the worker gateway.
+// The private key is stored encrypted; callers decrypt it with the DB encryption key.
+type WorkerCA struct {
+	CertPEM         string    `json:"-"`
+	KeyPEMEncrypted string    `json:"-"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// Made with Bob
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkerTokenStatus is the derived state of a bootstrap token.
+type WorkerTokenStatus string
+
+const (
+	WorkerTokenStatusActive  WorkerTokenStatus = "active"
+	WorkerTokenStatusUsed    WorkerTokenStatus = "used"
+	WorkerTokenStatusRevoked WorkerTokenStatus = "revoked"
+	WorkerTokenStatusExpired WorkerTokenStatus = "expired"
+)
+
+// WorkerToken is a single-use bootstrap token issued for a worker.
+// The token itself is never stored; only its SHA-256 hash.
+type WorkerToken struct {
+	ID         uuid.UUID         `json:"id"`
+	WorkerID   uuid.UUID         `json:"worker_id"`
+	WorkerName string            `json:"-"`
+	TokenHash  string            `json:"-"`
+	Status     WorkerTokenStatus `json:"status"`
+	ExpiresAt  time.Time         `json:"expires_at"`
+	UsedAt     *time.Time        `json:"used_at,omitempty"`
+	RevokedAt  *time.Time        `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+// StatusAt derives the token status at now from its timestamps.
+func (t *WorkerToken) StatusAt(now time.Time) WorkerTokenStatus {
+	switch {
+	case t.UsedAt != nil:
+		return WorkerTokenStatusUsed
+	case t.RevokedAt != nil:
+		return WorkerTokenStatusRevoked
+	case now.After(t.ExpiresAt):
+		return WorkerTokenStatusExpired
+	default:
+		return WorkerTokenStatusActive
+	}
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+)
+
+// ErrAPIKeyNameTaken is returned by Create when the user already has an active key with the name.
+var ErrAPIKeyNameTaken = errors.New("an active API key with this name already exists")
+
+// APIKeyRepository defines the interface for API key data operations.
+// Keys are stored as SHA-256 hashes; callers hash before calling.
+type APIKeyRepository interface {
+	// Create inserts a key. ID and CreatedAt are populated via RETURNING.
+	Create(ctx context.Context, key *models.APIKey) error
+	// GetByHash returns the key with the given hash together with its owner's
+	// username, role and disabled flag, or (nil, nil) if none matches.
+	GetByHash(ctx context.Context, keyHash string) (*models.APIKey, error)
+	// GetByID returns the key with the given ID, or (nil, nil) if none exists.
+	GetByID(ctx context.Context, id uuid.UUID) (*models.APIKey, error)
+	// List returns the keys of userID, or of every user when userID is empty,
+	// newest first. Username is populated.
+	List(ctx context.Context, userID string) ([]models.APIKey, error)
+	// Revoke sets revoked_at on an unrevoked key. Returns false if the key does
+	// not exist or was already revoked.
+	Revoke(ctx context.Context, id uuid.UUID) (bool, error)
+	// TouchLastUsed records that the key was used, at most once a minute.
+	TouchLastUsed(ctx context.Context, id uuid.UUID) error
+}
+
+// apiKeyRepo implements APIKeyRepository using pgx.
+type apiKeyRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewAPIKeyRepository creates a new APIKeyRepository instance.
+func NewAPIKeyRepository(pool *pgxpool.Pool) APIKeyRepository {
+	return &apiKeyRepo{pool: pool}
+}
+
+const apiKeyColumns = `k.id, k.user_id, k.name, k.prefix, k.key_hash, k.scopes, k.expires_at, k.last_used_at, k.revoked_at, k.created_at, u.username`
+
+// Create inserts a key.
+func (r *apiKeyRepo) Create(ctx context.Context, key *models.APIKey) error {
+	query := `
+		INSERT INTO api_keys (user_id, name, prefix, key_hash, scopes, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+
+	err := r.pool.QueryRow(ctx, query, key.UserID, key.Name, key.Prefix, key.KeyHash, nonNilStrings(key.Scopes), key.ExpiresAt).
+		Scan(&key.ID, &key.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation && pgErr.ConstraintName == "api_keys_user_name_active_key" {
+			return fmt.Errorf("failed to create API key %q: %w", key.Name, ErrAPIKeyNameTaken)
+		}
+
+		return fmt.Errorf("failed to create API key %q: %w", key.Name, err)
+	}
+
+	return nil
+}
+
+// GetByHash returns the key with the given hash and its owner, or (nil, nil) if none matches.
+func (r *apiKeyRepo) GetByHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	query := `
+		SELECT ` + apiKeyColumns + `, u.role, u.disabled
+		FROM api_keys k
+		JOIN users u ON u.id = k.user_id
+		WHERE k.key_hash = $1
+	`
+
+	var k models.APIKey
+	err := r.pool.QueryRow(ctx, query, keyHash).Scan(
+		&k.ID, &k.UserID, &k.Name, &k.Prefix, &k.KeyHash, &k.Scopes, &k.ExpiresAt, &k.LastUsedAt, &k.RevokedAt, &k.CreatedAt, &k.Username,
+		&k.UserRole, &k.UserDisabled,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	return &k, nil
+}
+
+// GetByID returns the key with the given ID, or (nil, nil) if none exists.
+func (r *apiKeyRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys k JOIN users u ON u.id = k.user_id WHERE k.id = $1`
+
+	k, err := scanAPIKey(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get API key %q: %w", id, err)
+	}
+
+	return k, nil
+}
+
+// List returns the keys of userID, or of every user when userID is empty, newest first.
+func (r *apiKeyRepo) List(ctx context.Context, userID string) ([]models.APIKey, error) {
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM api_keys k
+		JOIN users u ON u.id = k.user_id
+		WHERE $1 = '' OR k.user_id = $1
+		ORDER BY k.created_at DESC
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query API keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []models.APIKey
+
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan API key row: %w", err)
+		}
+		keys = append(keys, *k)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating API key rows: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Revoke sets revoked_at on an unrevoked key.
+func (r *apiKeyRepo) Revoke(ctx context.Context, id uuid.UUID) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `UPDATE api_keys SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke API key %q: %w", id, err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// TouchLastUsed records that the key was used, at most once a minute.
+func (r *apiKeyRepo) TouchLastUsed(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE api_keys
+		SET last_used_at = NOW()
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')
+	`
+
+	if _, err := r.pool.Exec(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to update last use of API key %q: %w", id, err)
+	}
+
+	return nil
+}
+
+// scanAPIKey scans a row selected with apiKeyColumns.
+func scanAPIKey(row pgx.Row) (*models.APIKey, error) {
+	var k models.APIKey
+	if err := row.Scan(&k.ID, &k.UserID, &k.Name, &k.Prefix, &k.KeyHash, &k.Scopes, &k.ExpiresAt, &k.LastUsedAt, &k.RevokedAt, &k.CreatedAt, &k.Username); err != nil {
+		return nil, err
+	}
+
+	return &k, nil
+}
+
+// Made with Bob
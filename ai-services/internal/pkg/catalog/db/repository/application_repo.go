@@ -15,10 +15,11 @@ import (
 
 // ApplicationFilters defines optional filters for querying applications.
 type ApplicationFilters struct {
-	DeploymentType string // Optional: filter by deployment_type ("architectures" or "services")
-	CatalogID      string // Optional: filter by catalog_id (e.g., "rag", "chat", "digitize")
-	Limit          int    // Optional: number of records to return (for pagination)
-	Offset         int    // Optional: number of records to skip (for pagination)
+	DeploymentType string     // Optional: filter by deployment_type ("architectures" or "services")
+	CatalogID      string     // Optional: filter by catalog_id (e.g., "rag", "chat", "digitize")
+	WorkerID       *uuid.UUID // Optional: filter by the worker the application is placed on
+	Limit          int        // Optional: number of records to return (for pagination)
+	Offset         int        // Optional: number of records to skip (for pagination)
 }
 
 // ApplicationRepository defines the interface for application data operations.
@@ -55,6 +56,7 @@ type scannedServiceFields struct {
 	message   sql.NullString
 	endpoint  []byte
 	version   string
+	hash      sql.NullString
 	created   sql.NullTime
 	updated   sql.NullTime
 }
@@ -94,6 +96,11 @@ func (r *applicationRepo) buildGetAllQuery(filters *ApplicationFilters) (string,
 			whereClauses = append(whereClauses, fmt.Sprintf("a.catalog_id = $%d", len(args)+1))
 			args = append(args, filters.CatalogID)
 		}
+
+		if filters.WorkerID != nil {
+			whereClauses = append(whereClauses, fmt.Sprintf("a.worker_id = $%d", len(args)+1))
+			args = append(args, *filters.WorkerID)
+		}
 	}
 
 	query := `
@@ -126,7 +133,7 @@ func (r *applicationRepo) buildGetAllQuery(filters *ApplicationFilters) (string,
 		)
 		SELECT
 			a.id, a.name, a.catalog_id, a.deployment_type, a.status, a.message, a.version, a.created_by, a.worker_id, a.created_at, a.updated_at,
-			s.id, s.app_id, s.catalog_id, s.status, s.message, s.endpoints, s.version, s.created_at, s.updated_at
+			s.id, s.app_id, s.catalog_id, s.status, s.message, s.endpoints, s.version, s.config_hash, s.created_at, s.updated_at
 		FROM paged_applications a
 		INNER JOIN services s ON a.id = s.app_id
 		ORDER BY a.created_at DESC, s.created_at ASC
@@ -152,7 +159,7 @@ func (r *applicationRepo) scanApplicationsWithServices(rows pgx.Rows) ([]models.
 			&app.ID, &app.Name, &app.CatalogID, &app.DeploymentType, &app.Status,
 			&message, &app.Version, &app.CreatedBy, &workerID, &app.CreatedAt, &app.UpdatedAt,
 			&svc.id, &svc.appID, &svc.catalogID, &svc.status, &svc.message,
-			&svc.endpoint, &svc.version, &svc.created, &svc.updated,
+			&svc.endpoint, &svc.version, &svc.hash, &svc.created, &svc.updated,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan application with services: %w", err)
@@ -218,6 +225,18 @@ func (r *applicationRepo) GetCount(ctx context.Context, filters *ApplicationFilt
 			query += fmt.Sprintf(" WHERE a.catalog_id = $%d", argIndex)
 		}
 		args = append(args, filters.CatalogID)
+		argIndex++
+		whereAdded = true
+	}
+
+	// Add worker_id filter if provided
+	if filters != nil && filters.WorkerID != nil {
+		if whereAdded {
+			query += fmt.Sprintf(" AND a.worker_id = $%d", argIndex)
+		} else {
+			query += fmt.Sprintf(" WHERE a.worker_id = $%d", argIndex)
+		}
+		args = append(args, *filters.WorkerID)
 	}
 
 	var count int
@@ -232,13 +251,14 @@ func (r *applicationRepo) GetCount(ctx context.Context, filters *ApplicationFilt
 // toService converts scanned fields to a Service model.
 func (s *scannedServiceFields) toService() (*models.Service, error) {
 	service := &models.Service{
-		ID:        s.id,
-		AppID:     s.appID,
-		CatalogID: s.catalogID,
-		Status:    models.ServiceStatus(s.status),
-		Version:   s.version,
-		CreatedAt: s.created.Time,
-		UpdatedAt: s.updated.Time,
+		ID:         s.id,
+		AppID:      s.appID,
+		CatalogID:  s.catalogID,
+		Status:     models.ServiceStatus(s.status),
+		Version:    s.version,
+		ConfigHash: s.hash.String,
+		CreatedAt:  s.created.Time,
+		UpdatedAt:  s.updated.Time,
 	}
 
 	if s.message.Valid {
@@ -268,7 +288,7 @@ func scanApplicationWithService(rows pgx.Rows, app *models.Application) (*models
 		&app.ID, &app.Name, &app.CatalogID, &app.DeploymentType, &app.Status,
 		&message, &app.Version, &app.CreatedBy, &workerID, &app.CreatedAt, &app.UpdatedAt,
 		&svc.id, &svc.appID, &svc.catalogID, &svc.status, &svc.message,
-		&svc.endpoint, &svc.version, &svc.created, &svc.updated,
+		&svc.endpoint, &svc.version, &svc.hash, &svc.created, &svc.updated,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan application with services: %w", err)
@@ -317,7 +337,7 @@ func (r *applicationRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Ap
 	query := `
 		SELECT
 			a.id, a.name, a.catalog_id, a.deployment_type, a.status, a.message, a.version, a.created_by, a.worker_id, a.created_at, a.updated_at,
-			s.id, s.app_id, s.catalog_id, s.status, s.message, s.endpoints, s.version, s.created_at, s.updated_at
+			s.id, s.app_id, s.catalog_id, s.status, s.message, s.endpoints, s.version, s.config_hash, s.created_at, s.updated_at
 		FROM applications a
 		INNER JOIN services s ON a.id = s.app_id
 		WHERE a.id = $1
@@ -338,7 +358,7 @@ func (r *applicationRepo) GetByName(ctx context.Context, name string) (*models.A
 	query := `
 		SELECT
 			a.id, a.name, a.catalog_id, a.deployment_type, a.status, a.message, a.version, a.created_by, a.worker_id, a.created_at, a.updated_at,
-			s.id, s.app_id, s.catalog_id, s.status, s.message, s.endpoints, s.version, s.created_at, s.updated_at
+			s.id, s.app_id, s.catalog_id, s.status, s.message, s.endpoints, s.version, s.config_hash, s.created_at, s.updated_at
 		FROM applications a
 		LEFT JOIN services s ON a.id = s.app_id
 		WHERE LOWER(a.name) = LOWER($1)
@@ -357,8 +377,8 @@ func (r *applicationRepo) GetByName(ctx context.Context, name string) (*models.A
 // Insert creates a new application in the database.
 func (r *applicationRepo) Insert(ctx context.Context, app *models.Application) error {
 	query := `
-		INSERT INTO applications (id, name, catalog_id, deployment_type, status, message, version, created_by)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO applications (id, name, catalog_id, deployment_type, status, message, version, created_by, worker_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING created_at, updated_at
 	`
 
@@ -378,6 +398,7 @@ func (r *applicationRepo) Insert(ctx context.Context, app *models.Application) e
 		sql.NullString{String: app.Message, Valid: app.Message != ""},
 		sql.NullString{String: app.Version, Valid: app.Version != ""},
 		app.CreatedBy,
+		app.WorkerID,
 	).Scan(&app.CreatedAt, &app.UpdatedAt)
 
 	if err != nil {
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+)
+
+// AuditFilters defines optional filters for querying audit events.
+type AuditFilters struct {
+	UserID       string              // Optional: filter by caller
+	Action       string              // Optional: filter by action (e.g. "application.delete")
+	ResourceType string              // Optional: filter by resource type (e.g. "application")
+	ResourceID   string              // Optional: filter by resource ID
+	Outcome      models.AuditOutcome // Optional: filter by outcome
+	Since        *time.Time          // Optional: only events at or after this time
+	Until        *time.Time          // Optional: only events before this time
+	Limit        int                 // Optional: number of records to return (for pagination)
+	Offset       int                 // Optional: number of records to skip (for pagination)
+}
+
+// AuditRepository defines the interface for audit event data operations.
+type AuditRepository interface {
+	// Insert records an event. ID and CreatedAt are populated via RETURNING.
+	Insert(ctx context.Context, event *models.AuditEvent) error
+	// GetCount returns the number of events matching the filters.
+	GetCount(ctx context.Context, filters *AuditFilters) (int, error)
+	// GetAll returns the events matching the filters, newest first, applying the
+	// pagination in filters. Username is populated.
+	GetAll(ctx context.Context, filters *AuditFilters) ([]models.AuditEvent, error)
+}
+
+// auditRepo implements AuditRepository using pgx.
+type auditRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewAuditRepository creates a new AuditRepository instance.
+func NewAuditRepository(pool *pgxpool.Pool) AuditRepository {
+	return &auditRepo{pool: pool}
+}
+
+// Insert records an event.
+func (r *auditRepo) Insert(ctx context.Context, event *models.AuditEvent) error {
+	query := `
+		INSERT INTO audit_events (user_id, api_key_id, action, resource_type, resource_id, method, path, status_code, outcome, request_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at
+	`
+
+	err := r.pool.QueryRow(ctx, query,
+		event.UserID, event.APIKeyID, event.Action, event.ResourceType, event.ResourceID,
+		event.Method, event.Path, event.StatusCode, event.Outcome, event.RequestID,
+	).Scan(&event.ID, &event.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert audit event %q: %w", event.Action, err)
+	}
+
+	return nil
+}
+
+// GetCount returns the number of events matching the filters.
+func (r *auditRepo) GetCount(ctx context.Context, filters *AuditFilters) (int, error) {
+	where, args := buildAuditWhere(filters)
+
+	var count int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM audit_events e`+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to get audit event count: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetAll returns the events matching the filters, newest first.
+func (r *auditRepo) GetAll(ctx context.Context, filters *AuditFilters) ([]models.AuditEvent, error) {
+	where, args := buildAuditWhere(filters)
+
+	query := `
+		SELECT e.id, e.user_id, e.api_key_id, e.action, e.resource_type, e.resource_id, e.method, e.path,
+			e.status_code, e.outcome, e.request_id, e.created_at, COALESCE(u.username, '')
+		FROM audit_events e
+		LEFT JOIN users u ON u.id = e.user_id
+	` + where + ` ORDER BY e.created_at DESC, e.id`
+
+	if filters != nil {
+		if filters.Limit > 0 {
+			query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+			args = append(args, filters.Limit)
+		}
+		if filters.Offset > 0 {
+			query += fmt.Sprintf(" OFFSET $%d", len(args)+1)
+			args = append(args, filters.Offset)
+		}
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []models.AuditEvent
+
+	for rows.Next() {
+		var e models.AuditEvent
+		if err := rows.Scan(&e.ID, &e.UserID, &e.APIKeyID, &e.Action, &e.ResourceType, &e.ResourceID, &e.Method, &e.Path,
+			&e.StatusCode, &e.Outcome, &e.RequestID, &e.CreatedAt, &e.Username); err != nil {
+			return nil, fmt.Errorf("failed to scan audit event row: %w", err)
+		}
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating audit event rows: %w", err)
+	}
+
+	return events, nil
+}
+
+// buildAuditWhere builds the WHERE clause and arguments for the filters.
+func buildAuditWhere(filters *AuditFilters) (string, []interface{}) {
+	if filters == nil {
+		return "", nil
+	}
+
+	args := []interface{}{}
+	whereClauses := []string{}
+	add := func(clause string, arg interface{}) {
+		args = append(args, arg)
+		whereClauses = append(whereClauses, fmt.Sprintf(clause, len(args)))
+	}
+
+	if filters.UserID != "" {
+		add("e.user_id = $%d", filters.UserID)
+	}
+	if filters.Action != "" {
+		add("e.action = $%d", filters.Action)
+	}
+	if filters.ResourceType != "" {
+		add("e.resource_type = $%d", filters.ResourceType)
+	}
+	if filters.ResourceID != "" {
+		add("e.resource_id = $%d", filters.ResourceID)
+	}
+	if filters.Outcome != "" {
+		add("e.outcome = $%d", filters.Outcome)
+	}
+	if filters.Since != nil {
+		add("e.created_at >= $%d", *filters.Since)
+	}
+	if filters.Until != nil {
+		add("e.created_at < $%d", *filters.Until)
+	}
+
+	if len(whereClauses) == 0 {
+		return "", args
+	}
+
+	return " WHERE " + strings.Join(whereClauses, " AND "), args
+}
+
+// Made with Bob
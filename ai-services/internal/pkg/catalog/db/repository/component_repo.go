@@ -28,6 +28,8 @@ type ComponentRepository interface {
 	UpdateStatus(ctx context.Context, id uuid.UUID, status models.ComponentStatus, message string) error
 	// UpdateEndpoints updates only the endpoints of a component.
 	UpdateEndpoints(ctx context.Context, id uuid.UUID, endpoints []map[string]any) error
+	// UpdateConfigHash records the configuration hash of a component.
+	UpdateConfigHash(ctx context.Context, id uuid.UUID, configHash string) error
 	// Delete removes a component from the database.
 	Delete(ctx context.Context, id uuid.UUID) error
 }
@@ -45,8 +47,8 @@ func NewComponentRepository(pool *pgxpool.Pool) ComponentRepository {
 // Insert creates a new component in the database.
 func (r *componentRepo) Insert(ctx context.Context, component *models.Component) error {
 	query := `
-		INSERT INTO components (id, type, provider, status, message, endpoints, version, metadata)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO components (id, type, provider, status, message, endpoints, version, metadata, config_hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING created_at, updated_at
 	`
 
@@ -85,6 +87,7 @@ func (r *componentRepo) Insert(ctx context.Context, component *models.Component)
 		endpointsJSON,
 		sql.NullString{String: component.Version, Valid: component.Version != ""},
 		metadataJSON,
+		sql.NullString{String: component.ConfigHash, Valid: component.ConfigHash != ""},
 	).Scan(&component.CreatedAt, &component.UpdatedAt)
 
 	if err != nil {
@@ -97,7 +100,7 @@ func (r *componentRepo) Insert(ctx context.Context, component *models.Component)
 // GetByID retrieves a component by ID.
 func (r *componentRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Component, error) {
 	query := `
-		SELECT id, type, provider, status, message, endpoints, version, metadata, created_at, updated_at
+		SELECT id, type, provider, status, message, endpoints, version, metadata, config_hash, created_at, updated_at
 		FROM components
 		WHERE id = $1
 	`
@@ -108,6 +111,7 @@ func (r *componentRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Comp
 		metadataJSON  []byte
 		version       sql.NullString
 		message       sql.NullString
+		configHash    sql.NullString
 	)
 
 	err := r.pool.QueryRow(ctx, query, id).Scan(
@@ -119,6 +123,7 @@ func (r *componentRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Comp
 		&endpointsJSON,
 		&version,
 		&metadataJSON,
+		&configHash,
 		&component.CreatedAt,
 		&component.UpdatedAt,
 	)
@@ -139,6 +144,8 @@ func (r *componentRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Comp
 		component.Message = message.String
 	}
 
+	component.ConfigHash = configHash.String
+
 	if len(endpointsJSON) > 0 {
 		var endpoints []map[string]any
 		if err := json.Unmarshal(endpointsJSON, &endpoints); err != nil {
@@ -166,10 +173,11 @@ func scanComponent(rows pgx.Rows) (*models.Component, error) {
 		metadataJSON  []byte
 		version       sql.NullString
 		message       sql.NullString
+		configHash    sql.NullString
 	)
 
 	err := rows.Scan(&component.ID, &component.Type, &component.Provider, &component.Status, &message,
-		&endpointsJSON, &version, &metadataJSON, &component.CreatedAt, &component.UpdatedAt)
+		&endpointsJSON, &version, &metadataJSON, &configHash, &component.CreatedAt, &component.UpdatedAt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan component: %w", err)
 	}
@@ -182,6 +190,8 @@ func scanComponent(rows pgx.Rows) (*models.Component, error) {
 		component.Message = message.String
 	}
 
+	component.ConfigHash = configHash.String
+
 	if len(endpointsJSON) > 0 {
 		var endpoints []map[string]any
 		if err := json.Unmarshal(endpointsJSON, &endpoints); err != nil {
@@ -204,7 +214,7 @@ func scanComponent(rows pgx.Rows) (*models.Component, error) {
 // GetAll retrieves all components from the database.
 func (r *componentRepo) GetAll(ctx context.Context) ([]models.Component, error) {
 	query := `
-		SELECT id, type, provider, status, message, endpoints, version, metadata, created_at, updated_at
+		SELECT id, type, provider, status, message, endpoints, version, metadata, config_hash, created_at, updated_at
 		FROM components
 		ORDER BY created_at DESC
 	`
@@ -234,7 +244,7 @@ func (r *componentRepo) GetAll(ctx context.Context) ([]models.Component, error)
 // GetByType retrieves all components of a specific type.
 func (r *componentRepo) GetByType(ctx context.Context, componentType string) ([]models.Component, error) {
 	query := `
-		SELECT id, type, provider, status, message, endpoints, version, metadata, created_at, updated_at
+		SELECT id, type, provider, status, message, endpoints, version, metadata, config_hash, created_at, updated_at
 		FROM components
 		WHERE type = $1
 		ORDER BY created_at DESC
@@ -354,6 +364,22 @@ func (r *componentRepo) UpdateEndpoints(ctx context.Context, id uuid.UUID, endpo
 	return nil
 }
 
+// UpdateConfigHash records the configuration hash of a component.
+func (r *componentRepo) UpdateConfigHash(ctx context.Context, id uuid.UUID, configHash string) error {
+	query := `
+		UPDATE components
+		SET config_hash = $1, updated_at = NOW()
+		WHERE id = $2
+	`
+
+	_, err := r.pool.Exec(ctx, query, sql.NullString{String: configHash, Valid: configHash != ""}, id)
+	if err != nil {
+		return fmt.Errorf("failed to update component config hash: %w", err)
+	}
+
+	return nil
+}
+
 // Delete removes a component from the database.
 func (r *componentRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM components WHERE id = $1`
@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
 )
@@ -17,6 +18,9 @@ import (
 // ErrConnectorNotFound is returned when a connector cannot be located by its ID.
 var ErrConnectorNotFound = errors.New("connector not found")
 
+// ErrConnectorNameTaken is returned by Insert when another connector has the name.
+var ErrConnectorNameTaken = errors.New("connector name already exists")
+
 // ConnectorFilters defines optional filters and pagination parameters for List queries.
 type ConnectorFilters struct {
 	Status   models.ConnectorStatus // Optional: filter by connector status
@@ -123,8 +127,7 @@ func scanConnectorWithCreds(rows pgx.Rows) (*models.Connector, error) {
 }
 
 // Insert creates a new connector row, populating the ID, CreatedAt, and UpdatedAt fields on success.
-// Sensitive fields inside connector.Metadata are stored as-is for now.
-// TODO: encrypt sensitive fields inside connector.Metadata before write.
+// Metadata is stored as-is; the caller encrypts sensitive fields before write.
 func (r *connectorRepo) Insert(ctx context.Context, connector *models.Connector) error {
 	if connector.ID == uuid.Nil {
 		connector.ID = uuid.New()
@@ -154,6 +157,11 @@ func (r *connectorRepo) Insert(ctx context.Context, connector *models.Connector)
 	).Scan(&connector.CreatedAt, &connector.UpdatedAt)
 
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation && pgErr.ConstraintName == "connectors_name_key" {
+			return fmt.Errorf("failed to insert connector %q: %w", connector.Name, ErrConnectorNameTaken)
+		}
+
 		return fmt.Errorf("failed to insert connector: %w", err)
 	}
 
@@ -164,7 +172,6 @@ func (r *connectorRepo) Insert(ctx context.Context, connector *models.Connector)
 // Pass includeCreds=false for API responses (metadata omitted).
 // Pass includeCreds=true for internal paths that need credentials (sync job, Digitize propagation);
 // the caller must decrypt sensitive fields in-memory and must never forward the value to a response.
-// TODO: decrypt sensitive fields in Metadata when includeCreds is true.
 func (r *connectorRepo) GetByID(ctx context.Context, id uuid.UUID, includeCreds bool) (*models.Connector, error) {
 	var colList string
 	if includeCreds {
@@ -282,8 +289,7 @@ func (r *connectorRepo) List(ctx context.Context, filters *ConnectorFilters) ([]
 
 // Update replaces the metadata JSONB column for the given connector.
 // Name, type, and provider are immutable and are never touched here.
-// Sensitive fields inside fields.Metadata are stored as-is for now.
-// TODO: encrypt sensitive fields inside fields.Metadata before write.
+// Metadata is stored as-is; the caller encrypts sensitive fields before write.
 func (r *connectorRepo) Update(ctx context.Context, id uuid.UUID, fields ConnectorUpdateFields) (*models.Connector, error) {
 	metadataJSON, err := json.Marshal(fields.Metadata)
 	if err != nil {
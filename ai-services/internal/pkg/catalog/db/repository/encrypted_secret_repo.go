@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Reencryption describes how EncryptedSecretRepository.Reencrypt rewrites stored secrets.
+type Reencryption struct {
+	// Value returns a stored encrypted column value encrypted with the new key.
+	Value func(ciphertext string) (string, error)
+	// Metadata returns connector metadata with its encrypted fields encrypted with the new key.
+	Metadata func(metadata map[string]any) (map[string]any, error)
+	// BeforeCommit runs once every row is rewritten, before the transaction commits.
+	// Nothing is changed when it fails.
+	BeforeCommit func(ctx context.Context) error
+}
+
+// ReencryptionResult counts the rows rewritten by Reencrypt.
+type ReencryptionResult struct {
+	Connectors  int
+	SigningKeys int
+	WorkerCAs   int
+}
+
+// EncryptedSecretRepository rewrites every value encrypted with the DB encryption key.
+type EncryptedSecretRepository interface {
+	// Reencrypt rewrites the connector metadata, the signing keys and the worker CA key
+	// in a single transaction, locking the rows until it commits.
+	Reencrypt(ctx context.Context, r Reencryption) (*ReencryptionResult, error)
+}
+
+// encryptedSecretRepo implements EncryptedSecretRepository using pgx.
+type encryptedSecretRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewEncryptedSecretRepository creates a new EncryptedSecretRepository instance.
+func NewEncryptedSecretRepository(pool *pgxpool.Pool) EncryptedSecretRepository {
+	return &encryptedSecretRepo{pool: pool}
+}
+
+// Reencrypt rewrites every encrypted value in a single transaction.
+func (r *encryptedSecretRepo) Reencrypt(ctx context.Context, re Reencryption) (*ReencryptionResult, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	result := &ReencryptionResult{}
+	if result.Connectors, err = reencryptConnectors(ctx, tx, re.Metadata); err != nil {
+		return nil, err
+	}
+	if result.SigningKeys, err = reencryptColumn(ctx, tx, "signing_keys", "kid", re.Value); err != nil {
+		return nil, err
+	}
+	if result.WorkerCAs, err = reencryptColumn(ctx, tx, "worker_ca", "id", re.Value); err != nil {
+		return nil, err
+	}
+
+	if re.BeforeCommit != nil {
+		if err := re.BeforeCommit(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return result, nil
+}
+
+// reencryptConnectors rewrites the metadata of every connector.
+func reencryptConnectors(ctx context.Context, tx pgx.Tx, reencrypt func(map[string]any) (map[string]any, error)) (int, error) {
+	rows, err := tx.Query(ctx, `SELECT id, metadata FROM connectors FOR UPDATE`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query connectors: %w", err)
+	}
+
+	stored := map[uuid.UUID][]byte{}
+	for rows.Next() {
+		var (
+			id       uuid.UUID
+			metadata []byte
+		)
+		if err := rows.Scan(&id, &metadata); err != nil {
+			rows.Close()
+
+			return 0, fmt.Errorf("failed to scan connector: %w", err)
+		}
+		stored[id] = metadata
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to iterate connectors: %w", err)
+	}
+
+	for id, raw := range stored {
+		var metadata map[string]any
+		if err := json.Unmarshal(raw, &metadata); err != nil {
+			return 0, fmt.Errorf("failed to decode metadata of connector %s: %w", id, err)
+		}
+		rewritten, err := reencrypt(metadata)
+		if err != nil {
+			return 0, fmt.Errorf("connector %s: %w", id, err)
+		}
+		encoded, err := json.Marshal(rewritten)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode metadata of connector %s: %w", id, err)
+		}
+		if _, err := tx.Exec(ctx, `UPDATE connectors SET metadata = $2 WHERE id = $1`, id, encoded); err != nil {
+			return 0, fmt.Errorf("failed to update connector %s: %w", id, err)
+		}
+	}
+
+	return len(stored), nil
+}
+
+// reencryptColumn rewrites the key_pem_encrypted column of every row of table.
+// table and idColumn are never user input.
+func reencryptColumn(ctx context.Context, tx pgx.Tx, table, idColumn string, reencrypt func(string) (string, error)) (int, error) {
+	rows, err := tx.Query(ctx, fmt.Sprintf(`SELECT %s::text, key_pem_encrypted FROM %s FOR UPDATE`, idColumn, table))
+	if err != nil {
+		return 0, fmt.Errorf("failed to query %s: %w", table, err)
+	}
+
+	stored := map[string]string{}
+	for rows.Next() {
+		var id, ciphertext string
+		if err := rows.Scan(&id, &ciphertext); err != nil {
+			rows.Close()
+
+			return 0, fmt.Errorf("failed to scan %s: %w", table, err)
+		}
+		stored[id] = ciphertext
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to iterate %s: %w", table, err)
+	}
+
+	query := fmt.Sprintf(`UPDATE %s SET key_pem_encrypted = $2 WHERE %s::text = $1`, table, idColumn)
+	for id, ciphertext := range stored {
+		rewritten, err := reencrypt(ciphertext)
+		if err != nil {
+			return 0, fmt.Errorf("%s %s: %w", table, id, err)
+		}
+		if _, err := tx.Exec(ctx, query, id, rewritten); err != nil {
+			return 0, fmt.Errorf("failed to update %s %s: %w", table, id, err)
+		}
+	}
+
+	return len(stored), nil
+}
+
+// Made with Bob
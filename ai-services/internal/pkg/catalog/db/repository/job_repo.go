@@ -0,0 +1,257 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+)
+
+// JobRepository defines the interface for job data operations.
+// Only the instance holding a job's lease may renew or finish it.
+type JobRepository interface {
+	// Create inserts a running job leased to job.LeaseOwner. ID, Status, Attempts and the
+	// timestamps are populated via RETURNING.
+	Create(ctx context.Context, job *models.Job) error
+	// GetByID returns the job, or (nil, nil) if it does not exist.
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Job, error)
+	// RenewLease extends the lease of a running job held by owner. It returns whether
+	// cancellation was requested, and ErrLeaseLost when owner no longer holds the lease.
+	RenewLease(ctx context.Context, id uuid.UUID, owner string, until time.Time) (bool, error)
+	// ClaimExpired leases every running job whose lease expired to owner, increments its
+	// attempts and returns the claimed jobs.
+	ClaimExpired(ctx context.Context, owner string, until time.Time) ([]models.Job, error)
+	// AddCheckpoint records that the job completed step. Recording a step twice is a no-op.
+	AddCheckpoint(ctx context.Context, id uuid.UUID, step string) error
+	// RequestCancel flags a running job for cancellation. Returns false if the job is not running.
+	RequestCancel(ctx context.Context, id uuid.UUID) (bool, error)
+	// RequestCancelForApplication flags the running jobs of the given kinds of an
+	// application for cancellation and returns their IDs.
+	RequestCancelForApplication(ctx context.Context, appID uuid.UUID, kinds []models.JobKind) ([]uuid.UUID, error)
+	// Finish sets the final status of a running job held by owner and releases its lease.
+	// Returns ErrLeaseLost when owner no longer holds the lease.
+	Finish(ctx context.Context, id uuid.UUID, owner string, status models.JobStatus, errMsg string) error
+}
+
+// ErrLeaseLost is returned when a job's lease was taken over by another instance or the job finished.
+var ErrLeaseLost = errors.New("job lease lost")
+
+// jobRepo implements JobRepository using pgx.
+type jobRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewJobRepository creates a new JobRepository instance.
+func NewJobRepository(pool *pgxpool.Pool) JobRepository {
+	return &jobRepo{pool: pool}
+}
+
+const jobColumns = `id, kind, application_id, status, payload, completed_steps, attempts, lease_owner,
+	lease_expires_at, cancel_requested, error, created_by, created_at, updated_at, finished_at`
+
+// Create inserts a running job.
+func (r *jobRepo) Create(ctx context.Context, job *models.Job) error {
+	query := `
+		INSERT INTO jobs (kind, application_id, payload, lease_owner, lease_expires_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, status, attempts, completed_steps, created_at, updated_at
+	`
+
+	err := r.pool.QueryRow(ctx, query,
+		job.Kind, job.ApplicationID, job.Payload, job.LeaseOwner, job.LeaseExpiresAt, job.CreatedBy,
+	).Scan(&job.ID, &job.Status, &job.Attempts, &job.CompletedSteps, &job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create job: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID returns the job, or (nil, nil) if it does not exist.
+func (r *jobRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Job, error) {
+	query := `SELECT ` + jobColumns + ` FROM jobs WHERE id = $1`
+
+	job, err := scanJob(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	return job, nil
+}
+
+// RenewLease extends the lease of a running job held by owner.
+func (r *jobRepo) RenewLease(ctx context.Context, id uuid.UUID, owner string, until time.Time) (bool, error) {
+	query := `
+		UPDATE jobs
+		SET lease_expires_at = $3, updated_at = NOW()
+		WHERE id = $1 AND lease_owner = $2 AND status = 'running'
+		RETURNING cancel_requested
+	`
+
+	var cancelRequested bool
+	if err := r.pool.QueryRow(ctx, query, id, owner, until).Scan(&cancelRequested); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, ErrLeaseLost
+		}
+
+		return false, fmt.Errorf("failed to renew lease of job %q: %w", id, err)
+	}
+
+	return cancelRequested, nil
+}
+
+// ClaimExpired leases every running job whose lease expired to owner.
+func (r *jobRepo) ClaimExpired(ctx context.Context, owner string, until time.Time) ([]models.Job, error) {
+	// SKIP LOCKED lets concurrent instances claim disjoint sets of jobs.
+	query := `
+		UPDATE jobs
+		SET lease_owner = $1, lease_expires_at = $2, attempts = attempts + 1, updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM jobs
+			WHERE status = 'running' AND (lease_expires_at IS NULL OR lease_expires_at < NOW())
+			ORDER BY created_at
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + jobColumns
+
+	rows, err := r.pool.Query(ctx, query, owner, until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim expired jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []models.Job
+
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job row: %w", err)
+		}
+		jobs = append(jobs, *job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating job rows: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// AddCheckpoint records that the job completed step.
+func (r *jobRepo) AddCheckpoint(ctx context.Context, id uuid.UUID, step string) error {
+	query := `
+		UPDATE jobs
+		SET completed_steps = array_append(completed_steps, $2), updated_at = NOW()
+		WHERE id = $1 AND NOT ($2 = ANY(completed_steps))
+	`
+
+	if _, err := r.pool.Exec(ctx, query, id, step); err != nil {
+		return fmt.Errorf("failed to record checkpoint %q of job %q: %w", step, id, err)
+	}
+
+	return nil
+}
+
+// RequestCancel flags a running job for cancellation.
+func (r *jobRepo) RequestCancel(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `
+		UPDATE jobs
+		SET cancel_requested = TRUE, updated_at = NOW()
+		WHERE id = $1 AND status = 'running'
+	`
+
+	tag, err := r.pool.Exec(ctx, query, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to request cancellation of job %q: %w", id, err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// RequestCancelForApplication flags the running jobs of the given kinds of an application for cancellation.
+func (r *jobRepo) RequestCancelForApplication(ctx context.Context, appID uuid.UUID, kinds []models.JobKind) ([]uuid.UUID, error) {
+	query := `
+		UPDATE jobs
+		SET cancel_requested = TRUE, updated_at = NOW()
+		WHERE application_id = $1 AND status = 'running' AND kind = ANY($2)
+		RETURNING id
+	`
+
+	kindNames := make([]string, len(kinds))
+	for i, kind := range kinds {
+		kindNames[i] = string(kind)
+	}
+
+	rows, err := r.pool.Query(ctx, query, appID, kindNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request cancellation of jobs of application %q: %w", appID, err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan job id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating job rows: %w", err)
+	}
+
+	return ids, nil
+}
+
+// Finish sets the final status of a running job held by owner and releases its lease.
+func (r *jobRepo) Finish(ctx context.Context, id uuid.UUID, owner string, status models.JobStatus, errMsg string) error {
+	query := `
+		UPDATE jobs
+		SET status = $3, error = $4, lease_owner = NULL, lease_expires_at = NULL,
+		    finished_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND lease_owner = $2 AND status = 'running'
+	`
+
+	tag, err := r.pool.Exec(ctx, query, id, owner, status, errMsg)
+	if err != nil {
+		return fmt.Errorf("failed to finish job %q: %w", id, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrLeaseLost
+	}
+
+	return nil
+}
+
+// scanJob scans a row selected with jobColumns.
+func scanJob(row pgx.Row) (*models.Job, error) {
+	var (
+		job        models.Job
+		leaseOwner *string
+	)
+	if err := row.Scan(
+		&job.ID, &job.Kind, &job.ApplicationID, &job.Status, &job.Payload, &job.CompletedSteps, &job.Attempts,
+		&leaseOwner, &job.LeaseExpiresAt, &job.CancelRequested, &job.Error, &job.CreatedBy,
+		&job.CreatedAt, &job.UpdatedAt, &job.FinishedAt,
+	); err != nil {
+		return nil, err
+	}
+	if leaseOwner != nil {
+		job.LeaseOwner = *leaseOwner
+	}
+
+	return &job, nil
+}
+
+// Made with Bob
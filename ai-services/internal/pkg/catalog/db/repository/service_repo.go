@@ -26,6 +26,8 @@ type ServiceRepository interface {
 	UpdateStatus(ctx context.Context, id uuid.UUID, status models.ServiceStatus, message string) error
 	// UpdateEndpoints updates only the endpoints of a service.
 	UpdateEndpoints(ctx context.Context, id uuid.UUID, endpoints []map[string]any) error
+	// UpdateVersion updates the version and configuration hash of a reconfigured service.
+	UpdateVersion(ctx context.Context, id uuid.UUID, version, configHash string) error
 }
 
 // serviceRepo implements ServiceRepository using pgx.
@@ -41,8 +43,8 @@ func NewServiceRepository(pool *pgxpool.Pool) ServiceRepository {
 // Insert creates a new service in the database.
 func (r *serviceRepo) Insert(ctx context.Context, service *models.Service) error {
 	query := `
-		INSERT INTO services (id, app_id, catalog_id, status, message, endpoints, version)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO services (id, app_id, catalog_id, status, message, endpoints, version, config_hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING created_at, updated_at
 	`
 
@@ -71,6 +73,7 @@ func (r *serviceRepo) Insert(ctx context.Context, service *models.Service) error
 		sql.NullString{String: service.Message, Valid: service.Message != ""},
 		endpointsJSON,
 		sql.NullString{String: service.Version, Valid: service.Version != ""},
+		sql.NullString{String: service.ConfigHash, Valid: service.ConfigHash != ""},
 	).Scan(&service.CreatedAt, &service.UpdatedAt)
 
 	if err != nil {
@@ -99,6 +102,7 @@ func scanService(rows pgx.Rows) (*models.Service, error) {
 		endpointsJSON  []byte
 		serviceVersion sql.NullString
 		message        sql.NullString
+		configHash     sql.NullString
 	)
 
 	err := rows.Scan(
@@ -109,6 +113,7 @@ func scanService(rows pgx.Rows) (*models.Service, error) {
 		&message,
 		&endpointsJSON,
 		&serviceVersion,
+		&configHash,
 		&service.CreatedAt,
 		&service.UpdatedAt,
 	)
@@ -124,6 +129,8 @@ func scanService(rows pgx.Rows) (*models.Service, error) {
 		service.Message = message.String
 	}
 
+	service.ConfigHash = configHash.String
+
 	if len(endpointsJSON) > 0 {
 		var endpoints []map[string]any
 		if err := json.Unmarshal(endpointsJSON, &endpoints); err != nil {
@@ -138,7 +145,7 @@ func scanService(rows pgx.Rows) (*models.Service, error) {
 // GetByAppID retrieves all services for a specific application.
 func (r *serviceRepo) GetByAppID(ctx context.Context, appID uuid.UUID) ([]models.Service, error) {
 	query := `
-		SELECT id, app_id, catalog_id, status, message, endpoints, version, created_at, updated_at
+		SELECT id, app_id, catalog_id, status, message, endpoints, version, config_hash, created_at, updated_at
 		FROM services
 		WHERE app_id = $1
 		ORDER BY created_at
@@ -248,4 +255,24 @@ func (r *serviceRepo) UpdateEndpoints(ctx context.Context, id uuid.UUID, endpoin
 	return nil
 }
 
+// UpdateVersion updates the version and configuration hash of a reconfigured service.
+func (r *serviceRepo) UpdateVersion(ctx context.Context, id uuid.UUID, version, configHash string) error {
+	query := `
+		UPDATE services
+		SET version = $1, config_hash = $2, updated_at = NOW()
+		WHERE id = $3
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		sql.NullString{String: version, Valid: version != ""},
+		sql.NullString{String: configHash, Valid: configHash != ""},
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update service version: %w", err)
+	}
+
+	return nil
+}
+
 // Made with Bob
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+)
+
+// SigningKeyRepository defines persistence for JWT signing keys.
+type SigningKeyRepository interface {
+	// ListActive returns the keys that are not retired at now, newest first.
+	ListActive(ctx context.Context, now time.Time) ([]*models.SigningKey, error)
+	// InsertFirst stores key unless a key without a retirement time already exists.
+	// It returns false when another instance created one first.
+	InsertFirst(ctx context.Context, key *models.SigningKey) (bool, error)
+	// Rotate stores key and schedules every other unretired key to retire at retiresAt.
+	Rotate(ctx context.Context, key *models.SigningKey, retiresAt time.Time) error
+	// DeleteRetired removes the keys retired before now and returns how many were removed.
+	DeleteRetired(ctx context.Context, now time.Time) (int64, error)
+	// KeySetCreatedAt returns when the first signing key was stored, recording the
+	// current time on first call.
+	KeySetCreatedAt(ctx context.Context) (time.Time, error)
+}
+
+// signingKeyRepo implements SigningKeyRepository using pgx.
+type signingKeyRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewSigningKeyRepository creates a new SigningKeyRepository instance.
+func NewSigningKeyRepository(pool *pgxpool.Pool) SigningKeyRepository {
+	return &signingKeyRepo{pool: pool}
+}
+
+// ListActive returns the keys that are not retired at now, newest first.
+func (r *signingKeyRepo) ListActive(ctx context.Context, now time.Time) ([]*models.SigningKey, error) {
+	query := `
+		SELECT kid, algorithm, public_key_pem, key_pem_encrypted, created_at, retires_at
+		FROM signing_keys
+		WHERE retires_at IS NULL OR retires_at > $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.pool.Query(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*models.SigningKey
+	for rows.Next() {
+		var k models.SigningKey
+		if err := rows.Scan(&k.KID, &k.Algorithm, &k.PublicKeyPEM, &k.KeyPEMEncrypted, &k.CreatedAt, &k.RetiresAt); err != nil {
+			return nil, fmt.Errorf("failed to scan signing key: %w", err)
+		}
+		keys = append(keys, &k)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate signing keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// InsertFirst stores key unless a key without a retirement time already exists.
+func (r *signingKeyRepo) InsertFirst(ctx context.Context, key *models.SigningKey) (bool, error) {
+	query := `
+		INSERT INTO signing_keys (kid, algorithm, public_key_pem, key_pem_encrypted)
+		SELECT $1, $2, $3, $4
+		WHERE NOT EXISTS (SELECT 1 FROM signing_keys WHERE retires_at IS NULL)
+		RETURNING created_at
+	`
+
+	err := r.pool.QueryRow(ctx, query, key.KID, key.Algorithm, key.PublicKeyPEM, key.KeyPEMEncrypted).Scan(&key.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to insert signing key: %w", err)
+	}
+
+	return true, nil
+}
+
+// Rotate stores key and schedules every other unretired key to retire at retiresAt.
+func (r *signingKeyRepo) Rotate(ctx context.Context, key *models.SigningKey, retiresAt time.Time) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, `UPDATE signing_keys SET retires_at = $1 WHERE retires_at IS NULL`, retiresAt); err != nil {
+		return fmt.Errorf("failed to retire signing keys: %w", err)
+	}
+
+	query := `
+		INSERT INTO signing_keys (kid, algorithm, public_key_pem, key_pem_encrypted)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at
+	`
+	if err := tx.QueryRow(ctx, query, key.KID, key.Algorithm, key.PublicKeyPEM, key.KeyPEMEncrypted).Scan(&key.CreatedAt); err != nil {
+		return fmt.Errorf("failed to insert signing key: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteRetired removes the keys retired before now.
+func (r *signingKeyRepo) DeleteRetired(ctx context.Context, now time.Time) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM signing_keys WHERE retires_at <= $1`, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete retired signing keys: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// KeySetCreatedAt returns when the first signing key was stored.
+func (r *signingKeyRepo) KeySetCreatedAt(ctx context.Context) (time.Time, error) {
+	query := `
+		WITH inserted AS (
+			INSERT INTO signing_key_set (id) VALUES (TRUE)
+			ON CONFLICT (id) DO NOTHING
+			RETURNING created_at
+		)
+		SELECT created_at FROM inserted
+		UNION ALL
+		SELECT created_at FROM signing_key_set
+		LIMIT 1
+	`
+
+	var createdAt time.Time
+	if err := r.pool.QueryRow(ctx, query).Scan(&createdAt); err != nil {
+		return time.Time{}, fmt.Errorf("failed to get signing key set creation time: %w", err)
+	}
+
+	return createdAt, nil
+}
+
+// Made with Bob
@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+)
+
+// pgUniqueViolation is the PostgreSQL error code for a unique constraint violation.
+const pgUniqueViolation = "23505"
+
+// ErrUsernameTaken is returned by Create and Upsert when another user already has the username.
+var ErrUsernameTaken = errors.New("username already exists")
+
+// UserRepository defines the interface for user data operations.
+type UserRepository interface {
+	// Create inserts a user. ID (when empty), CreatedAt and UpdatedAt are populated via RETURNING.
+	Create(ctx context.Context, user *models.User) error
+	// Upsert inserts the user or updates the username, name and role of the user with
+	// the same ID. The password hash and disabled flag of an existing user are kept and
+	// written back to user.
+	Upsert(ctx context.Context, user *models.User) error
+	// GetByID returns the user with the given ID, or (nil, nil) if none exists.
+	GetByID(ctx context.Context, id string) (*models.User, error)
+	// GetByUsername returns the user with the given username, or (nil, nil) if none exists.
+	GetByUsername(ctx context.Context, username string) (*models.User, error)
+	// List returns all users ordered by username.
+	List(ctx context.Context) ([]models.User, error)
+	// UpdatePassword replaces the password hash. Returns false if the user does not exist.
+	UpdatePassword(ctx context.Context, id, passwordHash string) (bool, error)
+	// SetDisabled sets the disabled flag. Returns false if the user does not exist.
+	SetDisabled(ctx context.Context, id string, disabled bool) (bool, error)
+	// Delete removes a user. Returns false if the user does not exist.
+	Delete(ctx context.Context, id string) (bool, error)
+	// EnsureBootstrapAdmin creates the admin user seeded from the server flags, or
+	// resets its password, role and disabled flag when passwordHash differs from the
+	// hash last seeded. An unchanged hash leaves the user as it is.
+	EnsureBootstrapAdmin(ctx context.Context, username, passwordHash string) error
+}
+
+// userRepo implements UserRepository using pgx.
+type userRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewUserRepository creates a new UserRepository instance.
+func NewUserRepository(pool *pgxpool.Pool) UserRepository {
+	return &userRepo{pool: pool}
+}
+
+const userColumns = `id, username, name, password_hash, role, disabled, created_at, updated_at`
+
+// Create inserts a user.
+func (r *userRepo) Create(ctx context.Context, user *models.User) error {
+	query := `
+		INSERT INTO users (id, username, name, password_hash, role, disabled)
+		VALUES (COALESCE(NULLIF($1, ''), gen_random_uuid()::TEXT), $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.pool.QueryRow(ctx, query, user.ID, user.Username, user.Name, user.PasswordHash, user.Role, user.Disabled).
+		Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt)
+	if err != nil {
+		return wrapUserWriteError("create", user.Username, err)
+	}
+
+	return nil
+}
+
+// Upsert inserts the user or updates the username, name and role of an existing one.
+func (r *userRepo) Upsert(ctx context.Context, user *models.User) error {
+	query := `
+		INSERT INTO users (id, username, name, password_hash, role)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO UPDATE
+		SET username = EXCLUDED.username, name = EXCLUDED.name, role = EXCLUDED.role, updated_at = NOW()
+		RETURNING password_hash, disabled, created_at, updated_at
+	`
+
+	err := r.pool.QueryRow(ctx, query, user.ID, user.Username, user.Name, user.PasswordHash, user.Role).
+		Scan(&user.PasswordHash, &user.Disabled, &user.CreatedAt, &user.UpdatedAt)
+	if err != nil {
+		return wrapUserWriteError("upsert", user.Username, err)
+	}
+
+	return nil
+}
+
+// GetByID returns the user with the given ID, or (nil, nil) if none exists.
+func (r *userRepo) GetByID(ctx context.Context, id string) (*models.User, error) {
+	return r.getOne(ctx, `SELECT `+userColumns+` FROM users WHERE id = $1`, id)
+}
+
+// GetByUsername returns the user with the given username, or (nil, nil) if none exists.
+func (r *userRepo) GetByUsername(ctx context.Context, username string) (*models.User, error) {
+	return r.getOne(ctx, `SELECT `+userColumns+` FROM users WHERE username = $1`, username)
+}
+
+func (r *userRepo) getOne(ctx context.Context, query, arg string) (*models.User, error) {
+	u, err := scanUser(r.pool.QueryRow(ctx, query, arg))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get user %q: %w", arg, err)
+	}
+
+	return u, nil
+}
+
+// List returns all users ordered by username.
+func (r *userRepo) List(ctx context.Context) ([]models.User, error) {
+	rows, err := r.pool.Query(ctx, `SELECT `+userColumns+` FROM users ORDER BY username`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []models.User
+
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user row: %w", err)
+		}
+		users = append(users, *u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating user rows: %w", err)
+	}
+
+	return users, nil
+}
+
+// UpdatePassword replaces the password hash of a user.
+func (r *userRepo) UpdatePassword(ctx context.Context, id, passwordHash string) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `UPDATE users SET password_hash = $2, updated_at = NOW() WHERE id = $1`, id, passwordHash)
+	if err != nil {
+		return false, fmt.Errorf("failed to update password of user %q: %w", id, err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// SetDisabled sets the disabled flag of a user.
+func (r *userRepo) SetDisabled(ctx context.Context, id string, disabled bool) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `UPDATE users SET disabled = $2, updated_at = NOW() WHERE id = $1`, id, disabled)
+	if err != nil {
+		return false, fmt.Errorf("failed to update user %q: %w", id, err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// Delete removes a user.
+func (r *userRepo) Delete(ctx context.Context, id string) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM users WHERE id = $1`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete user %q: %w", id, err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// EnsureBootstrapAdmin creates or resets the admin user seeded from the server flags.
+func (r *userRepo) EnsureBootstrapAdmin(ctx context.Context, username, passwordHash string) error {
+	query := `
+		INSERT INTO users (username, name, password_hash, role, bootstrap_hash)
+		VALUES ($1, 'Admin', $2, 'admin', $2)
+		ON CONFLICT (username) DO UPDATE
+		SET password_hash = EXCLUDED.password_hash, role = 'admin', disabled = FALSE,
+		    bootstrap_hash = EXCLUDED.bootstrap_hash, updated_at = NOW()
+		WHERE users.bootstrap_hash IS DISTINCT FROM EXCLUDED.bootstrap_hash
+	`
+
+	if _, err := r.pool.Exec(ctx, query, username, passwordHash); err != nil {
+		return fmt.Errorf("failed to seed admin user %q: %w", username, err)
+	}
+
+	return nil
+}
+
+// scanUser scans a row selected with userColumns.
+func scanUser(row pgx.Row) (*models.User, error) {
+	var u models.User
+	if err := row.Scan(&u.ID, &u.Username, &u.Name, &u.PasswordHash, &u.Role, &u.Disabled, &u.CreatedAt, &u.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	return &u, nil
+}
+
+// wrapUserWriteError maps a unique violation on the username to ErrUsernameTaken.
+func wrapUserWriteError(op, username string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation && pgErr.ConstraintName == "users_username_key" {
+		return fmt.Errorf("failed to %s user %q: %w", op, username, ErrUsernameTaken)
+	}
+
+	return fmt.Errorf("failed to %s user %q: %w", op, username, err)
+}
+
+// Made with Bob
@@ -0,0 +1,323 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+)
+
+// ErrWebhookNotFound is returned when a webhook cannot be located by its ID.
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+// ErrWebhookNameTaken is returned by Create and Update when another webhook has the name.
+var ErrWebhookNameTaken = errors.New("webhook name already exists")
+
+// WebhookDeliveryAttempt is the outcome of sending a delivery, recorded by FinishAttempt.
+type WebhookDeliveryAttempt struct {
+	Status models.WebhookDeliveryStatus
+	// StatusCode is the HTTP status of the response; nil when none was received.
+	StatusCode *int
+	Error      string
+	// NextAttemptAt is when a delivery left pending is retried.
+	NextAttemptAt time.Time
+}
+
+// WebhookRepository defines the interface for webhook and webhook delivery data operations.
+type WebhookRepository interface {
+	// Create inserts a webhook, populating ID, CreatedAt and UpdatedAt.
+	Create(ctx context.Context, hook *models.Webhook) error
+	// GetByID returns a webhook with its encrypted secret, or ErrWebhookNotFound.
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Webhook, error)
+	// List returns every webhook, newest first.
+	List(ctx context.Context) ([]models.Webhook, error)
+	// ListSubscribed returns the enabled webhooks subscribed to event.
+	ListSubscribed(ctx context.Context, event string) ([]models.Webhook, error)
+	// Update stores the name, URL, secret, events and enabled flag of hook and
+	// refreshes its UpdatedAt. Returns ErrWebhookNotFound or ErrWebhookNameTaken.
+	Update(ctx context.Context, hook *models.Webhook) error
+	// Delete removes a webhook and its deliveries. Returns ErrWebhookNotFound.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// CreateDelivery inserts a pending delivery due now. delivery.ID must be set, as
+	// the payload refers to it; the timestamps are populated via RETURNING.
+	CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	// ClaimDueDeliveries moves up to limit pending deliveries that are due to until,
+	// increments their attempts and returns them, oldest first.
+	ClaimDueDeliveries(ctx context.Context, until time.Time, limit int) ([]models.WebhookDelivery, error)
+	// FinishAttempt records the outcome of sending a delivery.
+	FinishAttempt(ctx context.Context, id uuid.UUID, attempt WebhookDeliveryAttempt) error
+	// ListDeliveries returns a page of the deliveries of a webhook, newest first.
+	ListDeliveries(ctx context.Context, webhookID uuid.UUID, limit, offset int) ([]models.WebhookDelivery, error)
+	// CountDeliveries returns how many deliveries a webhook has.
+	CountDeliveries(ctx context.Context, webhookID uuid.UUID) (int, error)
+}
+
+// webhookRepo implements WebhookRepository using pgx.
+type webhookRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewWebhookRepository creates a new WebhookRepository backed by the provided connection pool.
+func NewWebhookRepository(pool *pgxpool.Pool) WebhookRepository {
+	return &webhookRepo{pool: pool}
+}
+
+const webhookColumns = "id, name, url, secret, events, enabled, created_by, created_at, updated_at"
+
+const webhookDeliveryColumns = `id, webhook_id, event, payload, status, attempts, next_attempt_at,
+	last_status_code, last_error, created_at, updated_at, delivered_at`
+
+// Create inserts a webhook.
+func (r *webhookRepo) Create(ctx context.Context, hook *models.Webhook) error {
+	query := `
+		INSERT INTO webhooks (name, url, secret, events, enabled, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.pool.QueryRow(ctx, query,
+		hook.Name, hook.URL, hook.Secret, hook.Events, hook.Enabled, hook.CreatedBy,
+	).Scan(&hook.ID, &hook.CreatedAt, &hook.UpdatedAt)
+	if err != nil {
+		if isWebhookNameViolation(err) {
+			return fmt.Errorf("failed to create webhook %q: %w", hook.Name, ErrWebhookNameTaken)
+		}
+
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID returns a webhook with its encrypted secret.
+func (r *webhookRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = $1`
+
+	hook, err := scanWebhook(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrWebhookNotFound
+		}
+
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	return hook, nil
+}
+
+// List returns every webhook, newest first.
+func (r *webhookRepo) List(ctx context.Context) ([]models.Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks ORDER BY created_at DESC`
+
+	return r.queryWebhooks(ctx, query)
+}
+
+// ListSubscribed returns the enabled webhooks subscribed to event.
+func (r *webhookRepo) ListSubscribed(ctx context.Context, event string) ([]models.Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE enabled AND $1 = ANY(events) ORDER BY created_at`
+
+	return r.queryWebhooks(ctx, query, event)
+}
+
+// Update stores the mutable fields of hook.
+func (r *webhookRepo) Update(ctx context.Context, hook *models.Webhook) error {
+	query := `
+		UPDATE webhooks
+		SET name = $2, url = $3, secret = $4, events = $5, enabled = $6, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at
+	`
+
+	err := r.pool.QueryRow(ctx, query,
+		hook.ID, hook.Name, hook.URL, hook.Secret, hook.Events, hook.Enabled,
+	).Scan(&hook.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrWebhookNotFound
+		}
+		if isWebhookNameViolation(err) {
+			return fmt.Errorf("failed to update webhook %q: %w", hook.Name, ErrWebhookNameTaken)
+		}
+
+		return fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a webhook; its deliveries are removed by the foreign key cascade.
+func (r *webhookRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// CreateDelivery inserts a pending delivery due now.
+func (r *webhookRepo) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (id, webhook_id, event, payload)
+		VALUES ($1, $2, $3, $4)
+		RETURNING status, attempts, next_attempt_at, created_at, updated_at
+	`
+
+	err := r.pool.QueryRow(ctx, query,
+		delivery.ID, delivery.WebhookID, delivery.Event, delivery.Payload,
+	).Scan(&delivery.Status, &delivery.Attempts, &delivery.NextAttemptAt, &delivery.CreatedAt, &delivery.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimDueDeliveries moves up to limit due pending deliveries to until and returns them.
+func (r *webhookRepo) ClaimDueDeliveries(ctx context.Context, until time.Time, limit int) ([]models.WebhookDelivery, error) {
+	// SKIP LOCKED lets concurrent instances claim disjoint sets of deliveries.
+	query := `
+		UPDATE webhook_deliveries
+		SET next_attempt_at = $1, attempts = attempts + 1, updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + webhookDeliveryColumns
+
+	deliveries, err := r.queryDeliveries(ctx, query, until, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// FinishAttempt records the outcome of sending a delivery.
+func (r *webhookRepo) FinishAttempt(ctx context.Context, id uuid.UUID, attempt WebhookDeliveryAttempt) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $2, last_status_code = $3, last_error = $4, next_attempt_at = $5, updated_at = NOW(),
+		    delivered_at = CASE WHEN $2 = 'delivered' THEN NOW() END
+		WHERE id = $1
+	`
+
+	if _, err := r.pool.Exec(ctx, query, id, attempt.Status, attempt.StatusCode, attempt.Error, attempt.NextAttemptAt); err != nil {
+		return fmt.Errorf("failed to record attempt of webhook delivery %q: %w", id, err)
+	}
+
+	return nil
+}
+
+// ListDeliveries returns a page of the deliveries of a webhook, newest first.
+func (r *webhookRepo) ListDeliveries(ctx context.Context, webhookID uuid.UUID, limit, offset int) ([]models.WebhookDelivery, error) {
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries
+		WHERE webhook_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3`
+
+	deliveries, err := r.queryDeliveries(ctx, query, webhookID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// CountDeliveries returns how many deliveries a webhook has.
+func (r *webhookRepo) CountDeliveries(ctx context.Context, webhookID uuid.UUID) (int, error) {
+	var count int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM webhook_deliveries WHERE webhook_id = $1`, webhookID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+
+	return count, nil
+}
+
+// queryWebhooks runs a query selecting webhookColumns.
+func (r *webhookRepo) queryWebhooks(ctx context.Context, query string, args ...any) ([]models.Webhook, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var hooks []models.Webhook
+
+	for rows.Next() {
+		hook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook row: %w", err)
+		}
+		hooks = append(hooks, *hook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook rows: %w", err)
+	}
+
+	return hooks, nil
+}
+
+// queryDeliveries runs a query returning webhookDeliveryColumns.
+func (r *webhookRepo) queryDeliveries(ctx context.Context, query string, args ...any) ([]models.WebhookDelivery, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []models.WebhookDelivery
+
+	for rows.Next() {
+		var d models.WebhookDelivery
+		if err := rows.Scan(
+			&d.ID, &d.WebhookID, &d.Event, &d.Payload, &d.Status, &d.Attempts, &d.NextAttemptAt,
+			&d.LastStatusCode, &d.LastError, &d.CreatedAt, &d.UpdatedAt, &d.DeliveredAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook delivery rows: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// scanWebhook scans a row selected with webhookColumns.
+func scanWebhook(row pgx.Row) (*models.Webhook, error) {
+	var hook models.Webhook
+	if err := row.Scan(
+		&hook.ID, &hook.Name, &hook.URL, &hook.Secret, &hook.Events, &hook.Enabled,
+		&hook.CreatedBy, &hook.CreatedAt, &hook.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &hook, nil
+}
+
+// isWebhookNameViolation reports whether err is a violation of the unique webhook name.
+func isWebhookNameViolation(err error) bool {
+	var pgErr *pgconn.PgError
+
+	return errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation && pgErr.ConstraintName == "webhooks_name_key"
+}
+
+// Made with Bob
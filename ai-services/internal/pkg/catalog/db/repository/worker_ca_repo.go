@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+)
+
+// WorkerCARepository defines persistence for the single worker CA row.
+type WorkerCARepository interface {
+	// Get returns the stored CA, or (nil, nil) if none has been created yet.
+	Get(ctx context.Context) (*models.WorkerCA, error)
+	// Insert stores ca unless a CA already exists. It returns false when another
+	// instance won the race, in which case the caller should Get the stored CA.
+	Insert(ctx context.Context, ca *models.WorkerCA) (bool, error)
+}
+
+// workerCARepo implements WorkerCARepository using pgx.
+type workerCARepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewWorkerCARepository creates a new WorkerCARepository instance.
+func NewWorkerCARepository(pool *pgxpool.Pool) WorkerCARepository {
+	return &workerCARepo{pool: pool}
+}
+
+// Get returns the stored CA, or (nil, nil) if none has been created yet.
+func (r *workerCARepo) Get(ctx context.Context) (*models.WorkerCA, error) {
+	query := `SELECT cert_pem, key_pem_encrypted, created_at FROM worker_ca WHERE id = 1`
+
+	var ca models.WorkerCA
+	err := r.pool.QueryRow(ctx, query).Scan(&ca.CertPEM, &ca.KeyPEMEncrypted, &ca.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get worker CA: %w", err)
+	}
+
+	return &ca, nil
+}
+
+// Insert stores ca unless a CA already exists.
+func (r *workerCARepo) Insert(ctx context.Context, ca *models.WorkerCA) (bool, error) {
+	query := `
+		INSERT INTO worker_ca (id, cert_pem, key_pem_encrypted)
+		VALUES (1, $1, $2)
+		ON CONFLICT (id) DO NOTHING
+		RETURNING created_at
+	`
+
+	err := r.pool.QueryRow(ctx, query, ca.CertPEM, ca.KeyPEMEncrypted).Scan(&ca.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to insert worker CA: %w", err)
+	}
+
+	return true, nil
+}
+
+// Made with Bob
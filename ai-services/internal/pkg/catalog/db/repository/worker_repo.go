@@ -4,10 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
 )
@@ -17,11 +19,18 @@ import (
 type WorkerUpdate struct {
 	Status        *models.WorkerStatus
 	LastHeartbeat *time.Time
+	Schedulable   *bool
+	// ProtocolVersion and SupportedCommands are updated together when
+	// SupportedCommands is non-nil.
+	ProtocolVersion   int
+	SupportedCommands []string
 }
 
 // WorkerRepository defines the interface for worker data operations.
 type WorkerRepository interface {
-	// Upsert inserts a new worker or updates its runtime_type, status, and metadata on name conflict.
+	// Upsert inserts a new worker or updates its runtime_type, status, metadata, and
+	// declared protocol on name conflict.
+	// The schedulable flag of an existing worker is preserved.
 	Upsert(ctx context.Context, worker *models.Worker) error
 	// Update applies a partial update to the fields set in WorkerUpdate; nil fields are left unchanged.
 	Update(ctx context.Context, id uuid.UUID, update WorkerUpdate) error
@@ -29,6 +38,10 @@ type WorkerRepository interface {
 	Delete(ctx context.Context, id uuid.UUID) (bool, error)
 	// GetAll returns all worker rows ordered by registered_at ascending.
 	GetAll(ctx context.Context) ([]models.Worker, error)
+	// GetByID returns a worker by ID, or (nil, nil) if no row matched.
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Worker, error)
+	// GetByName returns a worker by name, or (nil, nil) if no row matched.
+	GetByName(ctx context.Context, name string) (*models.Worker, error)
 }
 
 // workerRepo implements WorkerRepository using pgx.
@@ -42,7 +55,8 @@ func NewWorkerRepository(pool *pgxpool.Pool) WorkerRepository {
 }
 
 // Upsert inserts a worker or, on name conflict, updates runtime_type, status,
-// metadata, and timestamps. ID, RegisteredAt, and UpdatedAt are populated via RETURNING.
+// metadata, protocol_version, supported_commands, and timestamps. A cordoned worker stays cordoned when it re-registers.
+// ID, Schedulable, RegisteredAt, and UpdatedAt are populated via RETURNING.
 func (r *workerRepo) Upsert(ctx context.Context, worker *models.Worker) error {
 	var metadataJSON []byte
 	if worker.Metadata != nil {
@@ -54,15 +68,17 @@ func (r *workerRepo) Upsert(ctx context.Context, worker *models.Worker) error {
 	}
 
 	query := `
-		INSERT INTO workers (name, runtime_type, status, metadata)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO workers (name, runtime_type, status, metadata, protocol_version, supported_commands)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		ON CONFLICT (name) DO UPDATE
-			SET runtime_type   = EXCLUDED.runtime_type,
-			    status         = EXCLUDED.status,
-			    metadata       = EXCLUDED.metadata,
-			    registered_at  = NOW(),
-			    updated_at     = NOW()
-		RETURNING id, registered_at, updated_at
+			SET runtime_type       = EXCLUDED.runtime_type,
+			    status             = EXCLUDED.status,
+			    metadata           = EXCLUDED.metadata,
+			    protocol_version   = EXCLUDED.protocol_version,
+			    supported_commands = EXCLUDED.supported_commands,
+			    registered_at      = NOW(),
+			    updated_at         = NOW()
+		RETURNING id, schedulable, registered_at, updated_at
 	`
 
 	err := r.pool.QueryRow(ctx, query,
@@ -70,7 +86,9 @@ func (r *workerRepo) Upsert(ctx context.Context, worker *models.Worker) error {
 		worker.RuntimeType,
 		worker.Status,
 		metadataJSON,
-	).Scan(&worker.ID, &worker.RegisteredAt, &worker.UpdatedAt)
+		worker.ProtocolVersion,
+		nonNilStrings(worker.SupportedCommands),
+	).Scan(&worker.ID, &worker.Schedulable, &worker.RegisteredAt, &worker.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to upsert worker: %w", err)
 	}
@@ -91,15 +109,29 @@ func (r *workerRepo) Update(ctx context.Context, id uuid.UUID, update WorkerUpda
 		statusArg = *update.Status
 	}
 
+	var schedulableArg any
+	if update.Schedulable != nil {
+		schedulableArg = *update.Schedulable
+	}
+
+	var versionArg, commandsArg any
+	if update.SupportedCommands != nil {
+		versionArg = update.ProtocolVersion
+		commandsArg = update.SupportedCommands
+	}
+
 	query := `
 		UPDATE workers
-		SET status         = COALESCE($1, status),
-		    last_heartbeat = COALESCE($2, last_heartbeat),
-		    updated_at     = NOW()
-		WHERE id = $3
+		SET status             = COALESCE($1, status),
+		    last_heartbeat     = COALESCE($2, last_heartbeat),
+		    schedulable        = COALESCE($3, schedulable),
+		    protocol_version   = COALESCE($4, protocol_version),
+		    supported_commands = COALESCE($5, supported_commands),
+		    updated_at         = NOW()
+		WHERE id = $6
 	`
 
-	_, err := r.pool.Exec(ctx, query, statusArg, hb, id)
+	_, err := r.pool.Exec(ctx, query, statusArg, hb, schedulableArg, versionArg, commandsArg, id)
 	if err != nil {
 		return fmt.Errorf("failed to update worker %q: %w", id, err)
 	}
@@ -123,7 +155,7 @@ func (r *workerRepo) Delete(ctx context.Context, id uuid.UUID) (bool, error) {
 // GetAll returns all worker rows ordered by registered_at ascending.
 func (r *workerRepo) GetAll(ctx context.Context) ([]models.Worker, error) {
 	query := `
-		SELECT id, name, runtime_type, status, last_heartbeat, metadata, registered_at, updated_at
+		SELECT id, name, runtime_type, status, schedulable, protocol_version, supported_commands, last_heartbeat, metadata, registered_at, updated_at
 		FROM workers
 		ORDER BY registered_at ASC
 	`
@@ -137,29 +169,12 @@ func (r *workerRepo) GetAll(ctx context.Context) ([]models.Worker, error) {
 	var workers []models.Worker
 
 	for rows.Next() {
-		var (
-			w            models.Worker
-			hb           sql.NullTime
-			metadataJSON []byte
-		)
-
-		if err := rows.Scan(
-			&w.ID, &w.Name, &w.RuntimeType, &w.Status,
-			&hb, &metadataJSON, &w.RegisteredAt, &w.UpdatedAt,
-		); err != nil {
+		w, err := scanWorker(rows)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan worker row: %w", err)
 		}
 
-		if hb.Valid {
-			w.LastHeartbeat = &hb.Time
-		}
-		if len(metadataJSON) > 0 {
-			if err := json.Unmarshal(metadataJSON, &w.Metadata); err != nil {
-				return nil, fmt.Errorf("failed to unmarshal worker metadata: %w", err)
-			}
-		}
-
-		workers = append(workers, w)
+		workers = append(workers, *w)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -169,4 +184,80 @@ func (r *workerRepo) GetAll(ctx context.Context) ([]models.Worker, error) {
 	return workers, nil
 }
 
+// GetByID returns a worker by ID, or (nil, nil) if no row matched.
+func (r *workerRepo) GetByID(ctx context.Context, id uuid.UUID) (*models.Worker, error) {
+	query := `
+		SELECT id, name, runtime_type, status, schedulable, protocol_version, supported_commands, last_heartbeat, metadata, registered_at, updated_at
+		FROM workers
+		WHERE id = $1
+	`
+
+	w, err := scanWorker(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get worker %q: %w", id, err)
+	}
+
+	return w, nil
+}
+
+// GetByName returns a worker by name, or (nil, nil) if no row matched.
+func (r *workerRepo) GetByName(ctx context.Context, name string) (*models.Worker, error) {
+	query := `
+		SELECT id, name, runtime_type, status, schedulable, protocol_version, supported_commands, last_heartbeat, metadata, registered_at, updated_at
+		FROM workers
+		WHERE name = $1
+	`
+
+	w, err := scanWorker(r.pool.QueryRow(ctx, query, name))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get worker %q: %w", name, err)
+	}
+
+	return w, nil
+}
+
+// scanWorker scans one workers row selected with the column list used by GetAll.
+func scanWorker(row pgx.Row) (*models.Worker, error) {
+	var (
+		w            models.Worker
+		hb           sql.NullTime
+		metadataJSON []byte
+	)
+
+	if err := row.Scan(
+		&w.ID, &w.Name, &w.RuntimeType, &w.Status, &w.Schedulable,
+		&w.ProtocolVersion, &w.SupportedCommands, &hb, &metadataJSON, &w.RegisteredAt, &w.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if hb.Valid {
+		w.LastHeartbeat = &hb.Time
+	}
+	if len(metadataJSON) > 0 {
+		if err := json.Unmarshal(metadataJSON, &w.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal worker metadata: %w", err)
+		}
+	}
+
+	return &w, nil
+}
+
+// nonNilStrings returns s, or an empty slice for nil so it is stored as '{}' rather than NULL.
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+
+	return s
+}
+
 // Made with Bob
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+)
+
+// WorkerTokenRepository defines the interface for worker bootstrap token data operations.
+// Tokens are stored as SHA-256 hashes; callers hash before calling.
+type WorkerTokenRepository interface {
+	// Create inserts a token. ID and CreatedAt are populated via RETURNING.
+	Create(ctx context.Context, token *models.WorkerToken) error
+	// GetByHash returns the token with the given hash, including its worker name,
+	// or (nil, nil) if none matches.
+	GetByHash(ctx context.Context, tokenHash string) (*models.WorkerToken, error)
+	// MarkUsed sets used_at on an unused, unrevoked token. Returns false if the token
+	// was already used or revoked, so concurrent registrations cannot both succeed.
+	MarkUsed(ctx context.Context, id uuid.UUID) (bool, error)
+	// ListByWorker returns all tokens issued for a worker, newest first.
+	ListByWorker(ctx context.Context, workerID uuid.UUID) ([]models.WorkerToken, error)
+	// Revoke sets revoked_at on an unused, unrevoked token of the worker.
+	// Returns (false, nil) if no such token exists.
+	Revoke(ctx context.Context, workerID, tokenID uuid.UUID) (bool, error)
+	// RevokeAllForWorker revokes every unused, unrevoked token of the worker.
+	RevokeAllForWorker(ctx context.Context, workerID uuid.UUID) error
+}
+
+// workerTokenRepo implements WorkerTokenRepository using pgx.
+type workerTokenRepo struct {
+	pool *pgxpool.Pool
+}
+
+// NewWorkerTokenRepository creates a new WorkerTokenRepository instance.
+func NewWorkerTokenRepository(pool *pgxpool.Pool) WorkerTokenRepository {
+	return &workerTokenRepo{pool: pool}
+}
+
+// Create inserts a token.
+func (r *workerTokenRepo) Create(ctx context.Context, token *models.WorkerToken) error {
+	query := `
+		INSERT INTO worker_tokens (worker_id, token_hash, expires_at)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+
+	err := r.pool.QueryRow(ctx, query, token.WorkerID, token.TokenHash, token.ExpiresAt).
+		Scan(&token.ID, &token.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create worker token: %w", err)
+	}
+
+	return nil
+}
+
+// GetByHash returns the token with the given hash, or (nil, nil) if none matches.
+func (r *workerTokenRepo) GetByHash(ctx context.Context, tokenHash string) (*models.WorkerToken, error) {
+	query := `
+		SELECT t.id, t.worker_id, w.name, t.token_hash, t.expires_at, t.used_at, t.revoked_at, t.created_at
+		FROM worker_tokens t
+		JOIN workers w ON w.id = t.worker_id
+		WHERE t.token_hash = $1
+	`
+
+	var t models.WorkerToken
+	err := r.pool.QueryRow(ctx, query, tokenHash).Scan(
+		&t.ID, &t.WorkerID, &t.WorkerName, &t.TokenHash, &t.ExpiresAt, &t.UsedAt, &t.RevokedAt, &t.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get worker token: %w", err)
+	}
+
+	return &t, nil
+}
+
+// MarkUsed sets used_at on an unused, unrevoked token.
+func (r *workerTokenRepo) MarkUsed(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `
+		UPDATE worker_tokens
+		SET used_at = NOW()
+		WHERE id = $1 AND used_at IS NULL AND revoked_at IS NULL
+	`
+
+	tag, err := r.pool.Exec(ctx, query, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark worker token %q used: %w", id, err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// ListByWorker returns all tokens issued for a worker, newest first.
+func (r *workerTokenRepo) ListByWorker(ctx context.Context, workerID uuid.UUID) ([]models.WorkerToken, error) {
+	query := `
+		SELECT id, worker_id, token_hash, expires_at, used_at, revoked_at, created_at
+		FROM worker_tokens
+		WHERE worker_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.pool.Query(ctx, query, workerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query worker tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []models.WorkerToken
+
+	for rows.Next() {
+		var t models.WorkerToken
+		if err := rows.Scan(&t.ID, &t.WorkerID, &t.TokenHash, &t.ExpiresAt, &t.UsedAt, &t.RevokedAt, &t.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan worker token row: %w", err)
+		}
+		tokens = append(tokens, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating worker token rows: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// Revoke sets revoked_at on an unused, unrevoked token of the worker.
+func (r *workerTokenRepo) Revoke(ctx context.Context, workerID, tokenID uuid.UUID) (bool, error) {
+	query := `
+		UPDATE worker_tokens
+		SET revoked_at = NOW()
+		WHERE id = $1 AND worker_id = $2 AND used_at IS NULL AND revoked_at IS NULL
+	`
+
+	tag, err := r.pool.Exec(ctx, query, tokenID, workerID)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke worker token %q: %w", tokenID, err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// RevokeAllForWorker revokes every unused, unrevoked token of the worker.
+func (r *workerTokenRepo) RevokeAllForWorker(ctx context.Context, workerID uuid.UUID) error {
+	query := `
+		UPDATE worker_tokens
+		SET revoked_at = NOW()
+		WHERE worker_id = $1 AND used_at IS NULL AND revoked_at IS NULL
+	`
+
+	if _, err := r.pool.Exec(ctx, query, workerID); err != nil {
+		return fmt.Errorf("failed to revoke tokens for worker %q: %w", workerID, err)
+	}
+
+	return nil
+}
+
+// Made with Bob
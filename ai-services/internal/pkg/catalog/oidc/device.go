@@ -0,0 +1,132 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Device authorization grant errors returned by PollDeviceToken.
+var (
+	ErrDeviceNotSupported   = errors.New("identity provider does not support the device authorization grant")
+	ErrAuthorizationPending = errors.New("authorization_pending")
+	ErrSlowDown             = errors.New("slow_down")
+	ErrDeviceCodeExpired    = errors.New("device code expired")
+	ErrAccessDenied         = errors.New("access denied")
+)
+
+const deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// DeviceAuthorization is the provider's answer to a device authorization request.
+type DeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval,omitempty"`
+}
+
+// SupportsDeviceFlow reports whether the provider advertises a device authorization endpoint.
+func (p *Provider) SupportsDeviceFlow() bool {
+	return p.deviceURL != ""
+}
+
+// StartDeviceAuth requests a device and user code from the provider.
+func (p *Provider) StartDeviceAuth(ctx context.Context) (*DeviceAuthorization, error) {
+	if !p.SupportsDeviceFlow() {
+		return nil, ErrDeviceNotSupported
+	}
+
+	var result DeviceAuthorization
+	var oauthErr oauthError
+	resp, err := p.clientRequest(ctx).
+		SetFormData(map[string]string{"scope": joinScopes(p.cfg.Scopes)}).
+		SetResult(&result).
+		SetError(&oauthErr).
+		Post(p.deviceURL)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: failed to start device authorization: %w", err)
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("oidc: device authorization returned HTTP %d: %s", resp.StatusCode(), oauthErr)
+	}
+	if result.DeviceCode == "" || result.UserCode == "" || result.VerificationURI == "" {
+		return nil, errors.New("oidc: device authorization response is incomplete")
+	}
+
+	return &result, nil
+}
+
+// PollDeviceToken makes a single token request for deviceCode. It returns
+// ErrAuthorizationPending or ErrSlowDown while the user has not finished signing in,
+// and the verified identity once they have.
+func (p *Provider) PollDeviceToken(ctx context.Context, deviceCode string) (*Identity, error) {
+	var result struct {
+		IDToken string `json:"id_token"`
+	}
+	var oauthErr oauthError
+	resp, err := p.clientRequest(ctx).
+		SetFormData(map[string]string{"grant_type": deviceCodeGrantType, "device_code": deviceCode}).
+		SetResult(&result).
+		SetError(&oauthErr).
+		Post(p.oauth.Endpoint.TokenURL)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: failed to poll device token: %w", err)
+	}
+
+	if resp.IsError() {
+		switch oauthErr.Code {
+		case "authorization_pending":
+			return nil, ErrAuthorizationPending
+		case "slow_down":
+			return nil, ErrSlowDown
+		case "expired_token":
+			return nil, ErrDeviceCodeExpired
+		case "access_denied":
+			return nil, ErrAccessDenied
+		}
+
+		return nil, fmt.Errorf("oidc: token endpoint returned HTTP %d: %s", resp.StatusCode(), oauthErr)
+	}
+	if result.IDToken == "" {
+		return nil, fmt.Errorf("%w: token response has no id_token", ErrInvalidIDToken)
+	}
+
+	return p.VerifyIDToken(ctx, result.IDToken, "")
+}
+
+// oauthError is the RFC 6749 error response body.
+type oauthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func (e oauthError) String() string {
+	if e.Description != "" {
+		return e.Code + ": " + e.Description
+	}
+
+	return e.Code
+}
+
+// clientRequest returns a form request authenticated as the OIDC client: HTTP basic
+// auth for confidential clients, client_id alone for public ones.
+func (p *Provider) clientRequest(ctx context.Context) *resty.Request {
+	req := newRequest(ctx, p.http).SetFormData(map[string]string{"client_id": p.cfg.ClientID})
+	if p.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	return req
+}
+
+func joinScopes(scopes []string) string {
+	return strings.Join(scopes, " ")
+}
+
+// Made with Bob
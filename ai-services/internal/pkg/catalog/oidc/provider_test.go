@@ -0,0 +1,240 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testClientID = "catalog"
+	testKeyID    = "stub-key"
+)
+
+// stubIdP is a minimal OIDC provider serving discovery, JWKS, token and device endpoints.
+type stubIdP struct {
+	*httptest.Server
+	key *rsa.PrivateKey
+
+	// claims are added to every issued ID token.
+	claims jwt.MapClaims
+	// deviceErrors are returned, in order, by device code token requests.
+	deviceErrors []string
+	// lastAuthCode records the form of the last authorization code token request.
+	lastAuthCode url.Values
+}
+
+func newStubIdP(t *testing.T, device bool) *stubIdP {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	idp := &stubIdP{key: key, claims: jwt.MapClaims{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		doc := map[string]string{
+			"issuer":                 idp.URL,
+			"authorization_endpoint": idp.URL + "/authorize",
+			"token_endpoint":         idp.URL + "/token",
+			"jwks_uri":               idp.URL + "/jwks",
+		}
+		if device {
+			doc["device_authorization_endpoint"] = idp.URL + "/device"
+		}
+		_ = json.NewEncoder(w).Encode(doc)
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &key.PublicKey, KeyID: testKeyID, Algorithm: "RS256", Use: "sig"},
+		}})
+	})
+	mux.HandleFunc("/device", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(DeviceAuthorization{
+			DeviceCode: "dev-code", UserCode: "ABCD-EFGH", VerificationURI: idp.URL + "/activate", ExpiresIn: 600, Interval: 1,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		w.Header().Set("Content-Type", "application/json")
+		nonce := ""
+		if r.Form.Get("grant_type") == deviceCodeGrantType {
+			if len(idp.deviceErrors) > 0 {
+				code := idp.deviceErrors[0]
+				idp.deviceErrors = idp.deviceErrors[1:]
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": code})
+
+				return
+			}
+		} else {
+			idp.lastAuthCode = r.Form
+			nonce = r.Form.Get("code")
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "idp-access", "token_type": "Bearer", "id_token": idp.idToken(t, nonce, nil),
+		})
+	})
+	idp.Server = httptest.NewServer(mux)
+	t.Cleanup(idp.Close)
+
+	return idp
+}
+
+// idToken signs an ID token; extra overrides the default claims.
+func (idp *stubIdP) idToken(t *testing.T, nonce string, extra jwt.MapClaims) string {
+	t.Helper()
+
+	claims := jwt.MapClaims{
+		"iss": idp.URL, "aud": testClientID, "sub": "user-1",
+		"iat": time.Now().Unix(), "exp": time.Now().Add(time.Hour).Unix(),
+	}
+	if nonce != "" {
+		claims["nonce"] = nonce
+	}
+	for k, v := range idp.claims {
+		claims[k] = v
+	}
+	for k, v := range extra {
+		claims[k] = v
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = testKeyID
+	signed, err := token.SignedString(idp.key)
+	require.NoError(t, err)
+
+	return signed
+}
+
+func discover(t *testing.T, idp *stubIdP) *Provider {
+	t.Helper()
+
+	p, err := Discover(context.Background(), Config{
+		IssuerURL: idp.URL, ClientID: testClientID, ClientSecret: "secret", RedirectURL: "https://catalog/callback",
+	})
+	require.NoError(t, err)
+
+	return p
+}
+
+func TestDiscover(t *testing.T) {
+	idp := newStubIdP(t, false)
+
+	p := discover(t, idp)
+	assert.False(t, p.SupportsDeviceFlow())
+
+	_, err := Discover(context.Background(), Config{IssuerURL: idp.URL + "/missing", ClientID: testClientID})
+	assert.Error(t, err)
+
+	_, err = Discover(context.Background(), Config{IssuerURL: idp.URL})
+	assert.Error(t, err)
+}
+
+func TestAuthCodeFlow(t *testing.T) {
+	idp := newStubIdP(t, false)
+	idp.claims = jwt.MapClaims{"preferred_username": "jdoe", "email": "jdoe@example.com", "groups": []string{"ops"}}
+	p := discover(t, idp)
+
+	authURL, err := url.Parse(p.AuthCodeURL("state-1", "nonce-1", "verifier-1"))
+	require.NoError(t, err)
+	q := authURL.Query()
+	assert.Equal(t, "state-1", q.Get("state"))
+	assert.Equal(t, "nonce-1", q.Get("nonce"))
+	assert.Equal(t, "S256", q.Get("code_challenge_method"))
+	assert.NotEmpty(t, q.Get("code_challenge"))
+
+	// The stub echoes the code as the ID token nonce.
+	id, err := p.Exchange(context.Background(), "nonce-1", "verifier-1", "nonce-1")
+	require.NoError(t, err)
+	assert.Equal(t, "verifier-1", idp.lastAuthCode.Get("code_verifier"))
+	assert.Equal(t, &Identity{
+		Issuer: idp.URL, Subject: "user-1", Username: "jdoe", Email: "jdoe@example.com", Groups: []string{"ops"},
+	}, id)
+
+	_, err = p.Exchange(context.Background(), "nonce-1", "verifier-1", "other-nonce")
+	assert.ErrorIs(t, err, ErrInvalidIDToken)
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	idp := newStubIdP(t, false)
+	p := discover(t, idp)
+	ctx := context.Background()
+
+	id, err := p.VerifyIDToken(ctx, idp.idToken(t, "", jwt.MapClaims{"email": "a@example.com"}), "")
+	require.NoError(t, err)
+	assert.Equal(t, "a@example.com", id.Username, "username falls back to email")
+
+	id, err = p.VerifyIDToken(ctx, idp.idToken(t, "", nil), "")
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", id.Username, "username falls back to subject")
+
+	tests := map[string]jwt.MapClaims{
+		"wrong audience": {"aud": "someone-else"},
+		"wrong issuer":   {"iss": "https://evil.example.com"},
+		"expired":        {"exp": time.Now().Add(-time.Hour).Unix()},
+		"no subject":     {"sub": ""},
+	}
+	for name, claims := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := p.VerifyIDToken(ctx, idp.idToken(t, "", claims), "")
+			assert.ErrorIs(t, err, ErrInvalidIDToken)
+		})
+	}
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	forged := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss": idp.URL, "aud": testClientID, "sub": "user-1", "exp": time.Now().Add(time.Hour).Unix(),
+	})
+	forged.Header["kid"] = testKeyID
+	raw, err := forged.SignedString(otherKey)
+	require.NoError(t, err)
+	_, err = p.VerifyIDToken(ctx, raw, "")
+	assert.ErrorIs(t, err, ErrInvalidIDToken)
+}
+
+func TestDeviceFlow(t *testing.T) {
+	idp := newStubIdP(t, true)
+	idp.claims = jwt.MapClaims{"preferred_username": "jdoe"}
+	idp.deviceErrors = []string{"authorization_pending", "slow_down"}
+	p := discover(t, idp)
+	ctx := context.Background()
+
+	auth, err := p.StartDeviceAuth(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "ABCD-EFGH", auth.UserCode)
+
+	_, err = p.PollDeviceToken(ctx, auth.DeviceCode)
+	assert.ErrorIs(t, err, ErrAuthorizationPending)
+	_, err = p.PollDeviceToken(ctx, auth.DeviceCode)
+	assert.ErrorIs(t, err, ErrSlowDown)
+
+	id, err := p.PollDeviceToken(ctx, auth.DeviceCode)
+	require.NoError(t, err)
+	assert.Equal(t, "jdoe", id.Username)
+
+	idp.deviceErrors = []string{"expired_token", "access_denied"}
+	_, err = p.PollDeviceToken(ctx, auth.DeviceCode)
+	assert.ErrorIs(t, err, ErrDeviceCodeExpired)
+	_, err = p.PollDeviceToken(ctx, auth.DeviceCode)
+	assert.ErrorIs(t, err, ErrAccessDenied)
+}
+
+func TestStartDeviceAuthNotSupported(t *testing.T) {
+	p := discover(t, newStubIdP(t, false))
+
+	_, err := p.StartDeviceAuth(context.Background())
+	assert.True(t, errors.Is(err, ErrDeviceNotSupported))
+}
@@ -119,4 +119,12 @@ type PodContainer struct {
 	Healthy bool   `json:"healthy"`
 }
 
+// DrainedApplication reports what draining a worker did to one of the applications it hosts.
+type DrainedApplication struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	StoppedPods []string `json:"stopped_pods"`
+	Error       string   `json:"error,omitempty"`
+}
+
 // Made with Bob
@@ -13,14 +13,16 @@ import (
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
 	dbrepo "github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	"github.com/project-ai-services/ai-services/internal/pkg/vars"
 )
 
 // HandleDeploymentStepError updates the application status to Error and logs the failure.
-// If the context has already been cancelled (e.g. a mid-deployment delete), it exits
-// silently so the deletion goroutine retains ownership of the application status.
+// If the context has already been cancelled (the deployment job was cancelled or
+// interrupted), it exits silently and leaves the application status to the job.
 func HandleDeploymentStepError(ctx context.Context, appRepo dbrepo.ApplicationRepository, appID uuid.UUID, stepContext string, err error) {
 	if ctx.Err() != nil {
-		logger.WarningfCtx(ctx, "Deployment step %q for %s cancelled (deletion in progress)\n", stepContext, appID)
+		logger.WarningfCtx(ctx, "Deployment step %q for %s stopped: its job was cancelled or interrupted\n", stepContext, appID)
 
 		return
 	}
@@ -37,6 +39,16 @@ func AppNamespace(appID uuid.UUID) string {
 	return "ai-services-" + appID.String()[:8]
 }
 
+// AppRuntime returns the runtime hosting an application: the worker it was placed on
+// when workerID is set, otherwise the local runtime.
+func AppRuntime(ctx context.Context, workerID *uuid.UUID, namespace string) (runtime.Runtime, error) {
+	if workerID == nil {
+		return vars.RuntimeFactory.Create(namespace)
+	}
+
+	return vars.RuntimeFactory.CreateForWorkerID(ctx, *workerID, namespace)
+}
+
 // HelmReleaseName builds a Helm release name: "<id>-<first 8 chars of appID>".
 // e.g. "llm-2b4410e6", "vector-store-2b4410e6", "chat-c08f9a8b".
 func HelmReleaseName(appID uuid.UUID, id string) string {
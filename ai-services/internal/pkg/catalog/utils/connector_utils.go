@@ -7,34 +7,93 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"strings"
 )
 
-// deriveKey returns a 32-byte AES-256 key derived from the given secret string using SHA-256.
-func deriveKey(secret string) []byte {
-	sum := sha256.Sum256([]byte(secret))
+const (
+	// EncryptionKeyEnv holds the key that encrypts new values.
+	EncryptionKeyEnv = "DB_ENCRYPTION_KEY"
+	// PreviousEncryptionKeysEnv holds comma-separated keys that are only used to decrypt
+	// values written before the last key rotation.
+	PreviousEncryptionKeysEnv = "DB_ENCRYPTION_PREVIOUS_KEYS"
 
-	return sum[:]
+	// ciphertextVersion prefixes ciphertext that names the key it was encrypted with,
+	// as "v1:<key id>:<base64>". Ciphertext without the prefix predates key rotation.
+	ciphertextVersion = "v1"
+	// keyIDLength is the number of hex characters of a key ID.
+	keyIDLength = 8
+	// encryptionKeyBytes is the amount of randomness in a generated key.
+	encryptionKeyBytes = 32
+)
+
+// ErrNoMatchingKey is returned by Keyring.Decrypt when none of its keys can decrypt a value.
+var ErrNoMatchingKey = errors.New("no encryption key matches the ciphertext")
+
+// keyringKey is an AES-256 key and its ID.
+type keyringKey struct {
+	id  string
+	aes []byte
 }
 
-// Encrypt encrypts plaintext using AES-256-GCM with a random nonce.
-// The nonce is prepended to the ciphertext and the result is base64-encoded.
-// secret is any non-empty string; a 32-byte AES key is derived from it via SHA-256.
-func Encrypt(plaintext string, secret string) (string, error) {
-	if secret == "" {
-		return "", fmt.Errorf("secret must not be empty")
+// Keyring encrypts with a primary key and decrypts with the primary key or any
+// previous one, so values stay readable while a rotation is rolled out.
+type Keyring struct {
+	keys []keyringKey
+}
+
+// NewKeyring creates a keyring that encrypts with primary. Empty previous keys are ignored.
+func NewKeyring(primary string, previous ...string) (*Keyring, error) {
+	if primary == "" {
+		return nil, fmt.Errorf("secret must not be empty")
 	}
 
-	key := deriveKey(secret)
-	block, err := aes.NewCipher(key)
-	if err != nil {
-		return "", fmt.Errorf("failed to create cipher: %w", err)
+	k := &Keyring{keys: []keyringKey{newKeyringKey(primary)}}
+	for _, secret := range previous {
+		if secret = strings.TrimSpace(secret); secret != "" && secret != primary {
+			k.keys = append(k.keys, newKeyringKey(secret))
+		}
 	}
 
-	gcm, err := cipher.NewGCM(block)
+	return k, nil
+}
+
+// KeyringFromEnv creates a keyring from the DB_ENCRYPTION_KEY and
+// DB_ENCRYPTION_PREVIOUS_KEYS environment variables.
+func KeyringFromEnv() (*Keyring, error) {
+	primary := os.Getenv(EncryptionKeyEnv)
+	if primary == "" {
+		return nil, fmt.Errorf("%s environment variable is required", EncryptionKeyEnv)
+	}
+
+	return NewKeyring(primary, strings.Split(os.Getenv(PreviousEncryptionKeysEnv), ",")...)
+}
+
+// GenerateEncryptionKey returns a new random key suitable for DB_ENCRYPTION_KEY.
+func GenerateEncryptionKey() (string, error) {
+	b := make([]byte, encryptionKeyBytes)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", fmt.Errorf("failed to generate encryption key: %w", err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// KeyID returns the ID of the primary key. IDs are derived from the key and do not reveal it.
+func (k *Keyring) KeyID() string {
+	return k.keys[0].id
+}
+
+// Encrypt encrypts plaintext with the primary key using AES-256-GCM with a random nonce.
+func (k *Keyring) Encrypt(plaintext string) (string, error) {
+	primary := k.keys[0]
+	gcm, err := newGCM(primary.aes)
 	if err != nil {
-		return "", fmt.Errorf("failed to create GCM: %w", err)
+		return "", err
 	}
 
 	nonce := make([]byte, gcm.NonceSize())
@@ -45,30 +104,124 @@ func Encrypt(plaintext string, secret string) (string, error) {
 	// Seal appends the encrypted ciphertext and authentication tag to nonce.
 	ciphertext := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
 
-	return base64.StdEncoding.EncodeToString(ciphertext), nil
+	return ciphertextVersion + ":" + primary.id + ":" + base64.StdEncoding.EncodeToString(ciphertext), nil
 }
 
-// Decrypt decodes a base64-encoded value produced by Encrypt and returns the original plaintext.
-// secret is any non-empty string; the same SHA-256-derived key used during Encrypt must be supplied.
-func Decrypt(ciphertext string, secret string) (string, error) {
-	if secret == "" {
-		return "", fmt.Errorf("secret must not be empty")
+// Decrypt returns the plaintext of a value produced by Encrypt with any key of the
+// keyring. Unversioned ciphertext is tried against every key.
+func (k *Keyring) Decrypt(ciphertext string) (string, error) {
+	if id, payload, ok := splitVersioned(ciphertext); ok {
+		for _, key := range k.keys {
+			if key.id == id {
+				return open(payload, key.aes)
+			}
+		}
+
+		return "", fmt.Errorf("%w: key %s is not configured", ErrNoMatchingKey, id)
+	}
+
+	for _, key := range k.keys {
+		if plaintext, err := open(ciphertext, key.aes); err == nil {
+			return plaintext, nil
+		}
 	}
 
-	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	return "", ErrNoMatchingKey
+}
+
+// Reencrypt decrypts ciphertext with any key of the keyring and encrypts it with the primary key.
+func (k *Keyring) Reencrypt(ciphertext string) (string, error) {
+	plaintext, err := k.Decrypt(ciphertext)
 	if err != nil {
-		return "", fmt.Errorf("failed to base64-decode ciphertext: %w", err)
+		return "", err
+	}
+
+	return k.Encrypt(plaintext)
+}
+
+// ReencryptMetadata returns a copy of connector metadata with every encrypted field
+// encrypted with the primary key. Unversioned fields that no key decrypts are
+// plaintext and kept as they are.
+func (k *Keyring) ReencryptMetadata(metadata map[string]any) (map[string]any, error) {
+	out := make(map[string]any, len(metadata))
+	for field, v := range metadata {
+		out[field] = v
+		value, ok := v.(string)
+		if !ok {
+			continue
+		}
+		rewritten, err := k.Reencrypt(value)
+		if err != nil {
+			if _, _, versioned := splitVersioned(value); versioned {
+				return nil, fmt.Errorf("failed to decrypt field %s: %w", field, err)
+			}
+
+			continue
+		}
+		out[field] = rewritten
+	}
+
+	return out, nil
+}
+
+// Encrypt encrypts plaintext using AES-256-GCM with a random nonce.
+// The result names the key it was encrypted with, followed by the base64-encoded nonce and ciphertext.
+// secret is any non-empty string; a 32-byte AES key is derived from it via SHA-256.
+func Encrypt(plaintext string, secret string) (string, error) {
+	k, err := NewKeyring(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return k.Encrypt(plaintext)
+}
+
+// Decrypt decodes a value produced by Encrypt and returns the original plaintext.
+// secret is any non-empty string; the same SHA-256-derived key used during Encrypt must be supplied.
+func Decrypt(ciphertext string, secret string) (string, error) {
+	k, err := NewKeyring(secret)
+	if err != nil {
+		return "", err
 	}
 
+	return k.Decrypt(ciphertext)
+}
+
+// deriveKey returns a 32-byte AES-256 key derived from the given secret string using SHA-256.
+func deriveKey(secret string) []byte {
+	sum := sha256.Sum256([]byte(secret))
+
+	return sum[:]
+}
+
+// newKeyringKey derives the AES key of secret and its ID, a hash of the AES key.
+func newKeyringKey(secret string) keyringKey {
 	key := deriveKey(secret)
-	block, err := aes.NewCipher(key)
+	sum := sha256.Sum256(key)
+
+	return keyringKey{id: hex.EncodeToString(sum[:])[:keyIDLength], aes: key}
+}
+
+// splitVersioned splits versioned ciphertext into its key ID and payload.
+func splitVersioned(ciphertext string) (string, string, bool) {
+	parts := strings.SplitN(ciphertext, ":", 3)
+	if len(parts) != 3 || parts[0] != ciphertextVersion {
+		return "", "", false
+	}
+
+	return parts[1], parts[2], true
+}
+
+// open decrypts a base64-encoded nonce and ciphertext with key.
+func open(payload string, key []byte) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(payload)
 	if err != nil {
-		return "", fmt.Errorf("failed to create cipher: %w", err)
+		return "", fmt.Errorf("failed to base64-decode ciphertext: %w", err)
 	}
 
-	gcm, err := cipher.NewGCM(block)
+	gcm, err := newGCM(key)
 	if err != nil {
-		return "", fmt.Errorf("failed to create GCM: %w", err)
+		return "", err
 	}
 
 	nonceSize := gcm.NonceSize()
@@ -85,3 +238,20 @@ func Decrypt(ciphertext string, secret string) (string, error) {
 
 	return string(plaintext), nil
 }
+
+// newGCM returns an AES-GCM cipher for key.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	return gcm, nil
+}
+
+// Made with Bob
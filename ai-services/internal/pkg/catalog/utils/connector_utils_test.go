@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// legacyEncrypt produces ciphertext in the unversioned format written before key rotation.
+func legacyEncrypt(t *testing.T, plaintext, secret string) string {
+	t.Helper()
+	gcm, err := newGCM(deriveKey(secret))
+	require.NoError(t, err)
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = rand.Read(nonce)
+	require.NoError(t, err)
+
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(plaintext), nil))
+}
+
+func TestKeyring_EncryptNamesKey(t *testing.T) {
+	keys, err := NewKeyring("primary")
+	require.NoError(t, err)
+
+	ciphertext, err := keys.Encrypt("s3cret")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(ciphertext, "v1:"+keys.KeyID()+":"))
+	assert.NotContains(t, ciphertext, "s3cret")
+
+	plaintext, err := keys.Decrypt(ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", plaintext)
+}
+
+func TestKeyring_DecryptsWithPreviousKeys(t *testing.T) {
+	old, err := Encrypt("s3cret", "old")
+	require.NoError(t, err)
+
+	rotated, err := NewKeyring("new", "old")
+	require.NoError(t, err)
+	plaintext, err := rotated.Decrypt(old)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", plaintext)
+
+	_, err = Decrypt(old, "new")
+	assert.ErrorIs(t, err, ErrNoMatchingKey)
+}
+
+func TestKeyring_DecryptsUnversionedCiphertext(t *testing.T) {
+	legacy := legacyEncrypt(t, "s3cret", "old")
+
+	rotated, err := NewKeyring("new", "old")
+	require.NoError(t, err)
+	plaintext, err := rotated.Decrypt(legacy)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", plaintext)
+
+	_, err = Decrypt(legacy, "other")
+	assert.ErrorIs(t, err, ErrNoMatchingKey)
+}
+
+func TestKeyring_ReencryptMetadata(t *testing.T) {
+	versioned, err := Encrypt("versioned", "old")
+	require.NoError(t, err)
+	metadata := map[string]any{
+		"bucket":     "backups",
+		"port":       float64(22),
+		"access_key": legacyEncrypt(t, "legacy", "old"),
+		"secret_key": versioned,
+	}
+
+	keys, err := NewKeyring("new", "old")
+	require.NoError(t, err)
+	out, err := keys.ReencryptMetadata(metadata)
+	require.NoError(t, err)
+
+	assert.Equal(t, "backups", out["bucket"])
+	assert.Equal(t, float64(22), out["port"])
+	newOnly, err := NewKeyring("new")
+	require.NoError(t, err)
+	for field, want := range map[string]string{"access_key": "legacy", "secret_key": "versioned"} {
+		plaintext, err := newOnly.Decrypt(out[field].(string))
+		require.NoError(t, err, field)
+		assert.Equal(t, want, plaintext)
+	}
+
+	unknown, err := Encrypt("lost", "unknown")
+	require.NoError(t, err)
+	_, err = keys.ReencryptMetadata(map[string]any{"secret_key": unknown})
+	assert.ErrorIs(t, err, ErrNoMatchingKey)
+}
+
+func TestKeyringFromEnv(t *testing.T) {
+	t.Setenv(EncryptionKeyEnv, "")
+	_, err := KeyringFromEnv()
+	require.Error(t, err)
+
+	old, err := Encrypt("s3cret", "old")
+	require.NoError(t, err)
+	t.Setenv(EncryptionKeyEnv, "new")
+	t.Setenv(PreviousEncryptionKeysEnv, "older, old")
+	keys, err := KeyringFromEnv()
+	require.NoError(t, err)
+	plaintext, err := keys.Decrypt(old)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", plaintext)
+}
+
+// Made with Bob
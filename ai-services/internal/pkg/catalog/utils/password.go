@@ -14,7 +14,30 @@ import (
 	"golang.org/x/term"
 )
 
-const defaultPasswordIterations = 100000
+const (
+	// DefaultPasswordIterations is the PBKDF2 iteration count for passwords hashed by the catalog.
+	DefaultPasswordIterations = 100000
+	// MinPasswordLength is the minimum length of a password set through the API.
+	MinPasswordLength = 8
+)
+
+// PasswordError reports a password that does not meet the password policy.
+type PasswordError struct {
+	Reason string
+}
+
+func (e *PasswordError) Error() string {
+	return "invalid password: " + e.Reason
+}
+
+// ValidatePassword checks a new password against the password policy.
+func ValidatePassword(password string) error {
+	if len(password) < MinPasswordLength {
+		return &PasswordError{Reason: fmt.Sprintf("must be at least %d characters", MinPasswordLength)}
+	}
+
+	return nil
+}
 
 // HashPasswordPBKDF2 generates a PBKDF2 hash of the password with a random salt.
 // The hash is returned in the format: iterations.salt.hash (base64 encoded).
@@ -58,7 +81,7 @@ func PromptAndHashPassword() (string, error) {
 		return "", fmt.Errorf("failed to read admin password: %w", err)
 	}
 
-	passwordHash, err := HashPasswordPBKDF2(adminPassword, defaultPasswordIterations)
+	passwordHash, err := HashPasswordPBKDF2(adminPassword, DefaultPasswordIterations)
 	if err != nil {
 		return "", fmt.Errorf("failed to hash password: %w", err)
 	}
@@ -36,6 +36,25 @@ func ValidateParams(params map[string]any, schema map[string]any, contextName st
 	return validateAgainstSchema(compiledSchema, params, contextName)
 }
 
+// ValidateDocument validates params against a JSON schema like ValidateParams, but
+// also validates empty params, so that the schema's required fields are enforced.
+// It is used for documents that must always be complete, such as connector configurations.
+func ValidateDocument(params map[string]any, schema map[string]any, contextName string) error {
+	if len(schema) == 0 {
+		return nil
+	}
+	if params == nil {
+		params = map[string]any{}
+	}
+
+	compiledSchema, err := compileJSONSchema(schema, contextName)
+	if err != nil {
+		return err
+	}
+
+	return validateAgainstSchema(compiledSchema, params, contextName)
+}
+
 // compileJSONSchema prepares and compiles a JSON schema for validation.
 func compileJSONSchema(schema map[string]any, contextName string) (*jsonschema.Schema, error) {
 	// Wrap the schema in a proper JSON Schema structure if it doesn't have $schema
@@ -8,6 +8,7 @@ type CreateFlags struct {
 	Params         string
 	Values         string
 	Legacy         string
+	DryRun         string
 
 	// Podman-specific flags
 	SkipImageDownload string
@@ -26,6 +27,7 @@ var Create = CreateFlags{
 	Params:         "params",
 	Values:         "values",
 	Legacy:         "legacy",
+	DryRun:         "dry-run",
 
 	// Podman-specific flags
 	SkipImageDownload: "skip-image-download",
@@ -64,6 +66,9 @@ type LogsFlags struct {
 	Pod       string
 	Container string
 	Legacy    string
+	Follow    string
+	Tail      string
+	Since     string
 }
 
 // Logs holds the flag constants for the 'application logs' command.
@@ -71,6 +76,9 @@ var Logs = LogsFlags{
 	Pod:       "pod",
 	Container: "container",
 	Legacy:    "legacy",
+	Follow:    "follow",
+	Tail:      "tail",
+	Since:     "since",
 }
 
 // PsFlags contains all flag names for the 'application ps' command.
@@ -12,6 +12,7 @@ import (
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 	"github.com/project-ai-services/ai-services/internal/pkg/models"
 	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
 	"github.com/project-ai-services/ai-services/internal/pkg/specs"
 )
 
@@ -30,7 +31,11 @@ func DeployPodAndReadinessCheck(ctx context.Context, rt runtime.Runtime, podSpec
 
 	logger.DebugfCtx(ctx, "'%s': Successfully ran podman kube play\n", podTemplateName)
 
-	// ---- Pod Readiness Checks ----
+	return WaitForPodsReady(ctx, rt, podSpec, podTemplateName, pods)
+}
+
+// WaitForPodsReady waits until the containers of the pods created from podSpec exist and are ready.
+func WaitForPodsReady(ctx context.Context, rt runtime.Runtime, podSpec *models.PodSpec, podTemplateName string, pods []types.Pod) error {
 	for _, pod := range pods {
 		pInfo, err := rt.InspectPod(pod.ID)
 		if err != nil {
@@ -23,6 +23,9 @@ type MemoryInfo struct {
 type AcceleratorInfo struct {
 	Total     int `json:"total"`
 	Available int `json:"available"`
+	// Devices lists the addresses of the available devices (PCI addresses for Spyre
+	// on Podman) so cards can be assigned on a remote worker.
+	Devices []string `json:"devices,omitempty"`
 }
 
 // Made with Bob
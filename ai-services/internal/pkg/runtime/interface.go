@@ -22,7 +22,8 @@ type Runtime interface {
 	StartPod(id string) error
 	InspectPod(nameOrId string) (*types.Pod, error)
 	PodExists(nameOrID string) (bool, error)
-	PodLogs(nameOrID string) error
+	// PodLogs writes the logs of every container in the pod to w.
+	PodLogs(ctx context.Context, nameOrID string, w io.Writer, opts types.LogOptions) error
 	GetPodResources(nameOrID string) (*types.PodResources, error)
 	GetNamespace() (string, error)
 
@@ -40,8 +41,10 @@ type Runtime interface {
 	// ListContainers(filters map[string][]string) ([]types.Container, error)
 	InspectContainer(nameOrId string) (*types.Container, error)
 	ContainerExists(nameOrID string) (bool, error)
-	ContainerLogs(containerNameOrID string) error
-	ExecInContainerWithCmd(podName, containerName string, command []string) (string, error)
+	// ContainerLogs writes the logs of a single container to w.
+	ContainerLogs(ctx context.Context, containerNameOrID string, w io.Writer, opts types.LogOptions) error
+	// ExecInContainerWithCmd runs command in the container and writes its stdout to w as it is produced.
+	ExecInContainerWithCmd(ctx context.Context, podName, containerName string, command []string, w io.Writer) error
 
 	// Network operations
 	ListRoutes(labelSelector string) ([]types.Route, error)
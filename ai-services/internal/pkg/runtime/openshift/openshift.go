@@ -1,7 +1,6 @@
 package openshift
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -9,11 +8,9 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"os/signal"
 	"path/filepath"
 	"strings"
 	"sync"
-	"syscall"
 	"time"
 
 	routeclient "github.com/openshift/client-go/route/clientset/versioned"
@@ -282,19 +279,15 @@ func (kc *OpenshiftClient) StartPod(id string) error {
 	return nil
 }
 
-// PodLogs retrieves logs from a pod.
-func (kc *OpenshiftClient) PodLogs(podNameOrID string) error {
+// PodLogs writes the logs of a pod to w.
+func (kc *OpenshiftClient) PodLogs(ctx context.Context, podNameOrID string, w io.Writer, opts types.LogOptions) error {
 	podName, err := getPodNameWithPrefix(kc, podNameOrID)
 	if err != nil {
 		return fmt.Errorf("failed to get the pod: %w", err)
 	}
 
 	// Defaults to only container if there is one container in the pod.
-	opts := &corev1.PodLogOptions{
-		Follow: true,
-	}
-
-	return followLogs(kc, podName, opts)
+	return writeLogs(ctx, kc, podName, w, podLogOptions("", opts))
 }
 
 // InspectContainer inspects a container.
@@ -336,8 +329,8 @@ func (kc *OpenshiftClient) ContainerExists(nameOrID string) (bool, error) {
 	return false, nil
 }
 
-// ContainerLogs retrieves logs from a specific container.
-func (kc *OpenshiftClient) ContainerLogs(containerNameOrID string) error {
+// ContainerLogs writes the logs of a specific container to w.
+func (kc *OpenshiftClient) ContainerLogs(ctx context.Context, containerNameOrID string, w io.Writer, opts types.LogOptions) error {
 	if containerNameOrID == "" {
 		return fmt.Errorf("container name is required to fetch logs")
 	}
@@ -352,12 +345,7 @@ func (kc *OpenshiftClient) ContainerLogs(containerNameOrID string) error {
 	for _, pod := range pods.Items {
 		for _, container := range pod.Spec.Containers {
 			if container.Name == containerNameOrID {
-				opts := &corev1.PodLogOptions{
-					Container: containerNameOrID,
-					Follow:    true,
-				}
-
-				return followLogs(kc, pod.Name, opts)
+				return writeLogs(ctx, kc, pod.Name, w, podLogOptions(containerNameOrID, opts))
 			}
 		}
 	}
@@ -448,11 +436,27 @@ func getPodNameWithPrefix(kc *OpenshiftClient, nameOrID string) (string, error)
 	return "", fmt.Errorf("cannot find pod: %s", nameOrID)
 }
 
-func followLogs(kc *OpenshiftClient, podName string, opts *corev1.PodLogOptions) error {
-	// Create interrupt-aware context (Ctrl+C)
-	ctx, stop := signal.NotifyContext(kc.Ctx, os.Interrupt, syscall.SIGTERM)
-	defer stop()
+// podLogOptions converts runtime log options for container (empty for the only
+// container of the pod) to the Kubernetes representation.
+func podLogOptions(container string, opts types.LogOptions) *corev1.PodLogOptions {
+	logOpts := &corev1.PodLogOptions{
+		Container: container,
+		Follow:    opts.Follow,
+	}
+	if opts.Tail > 0 {
+		tail := int64(opts.Tail)
+		logOpts.TailLines = &tail
+	}
+	if opts.Since > 0 {
+		since := int64(opts.Since.Seconds())
+		logOpts.SinceSeconds = &since
+	}
+
+	return logOpts
+}
 
+// writeLogs copies the log stream of podName to w until it ends or ctx is cancelled.
+func writeLogs(ctx context.Context, kc *OpenshiftClient, podName string, w io.Writer, opts *corev1.PodLogOptions) error {
 	req := kc.KubeClient.CoreV1().Pods(kc.Namespace).GetLogs(podName, opts)
 
 	stream, err := req.Stream(ctx)
@@ -466,15 +470,9 @@ func followLogs(kc *OpenshiftClient, podName string, opts *corev1.PodLogOptions)
 		}
 	}()
 
-	scanner := bufio.NewScanner(stream)
-
-	for scanner.Scan() {
-		logger.Infoln(scanner.Text())
-	}
-
-	if err := scanner.Err(); err != nil {
+	if _, err := io.Copy(w, stream); err != nil {
 		if errors.Is(err, context.Canceled) ||
-			errors.Is(err, context.DeadlineExceeded) {
+			errors.Is(err, context.DeadlineExceeded) || ctx.Err() != nil {
 			return nil
 		}
 
@@ -509,6 +507,8 @@ func (kc *OpenshiftClient) SecretExists(nameOrID string) (bool, error) {
 	return true, nil
 }
 
+// UpdateSecret merges data into the secret and, unless deploymentName is empty,
+// restarts the deployment and waits for it to become ready.
 func (kc *OpenshiftClient) UpdateSecret(name, deploymentName string, data map[string][]byte) error {
 	secretClient := kc.KubeClient.CoreV1().Secrets(kc.Namespace)
 
@@ -517,6 +517,9 @@ func (kc *OpenshiftClient) UpdateSecret(name, deploymentName string, data map[st
 		return fmt.Errorf("failed to get existing secret: %w", err)
 	}
 
+	if existing.Data == nil {
+		existing.Data = map[string][]byte{}
+	}
 	for k, v := range data {
 		existing.Data[k] = v
 	}
@@ -525,6 +528,9 @@ func (kc *OpenshiftClient) UpdateSecret(name, deploymentName string, data map[st
 	if err != nil {
 		return fmt.Errorf("failed to update secret: %w", err)
 	}
+	if deploymentName == "" {
+		return nil
+	}
 
 	if err := kc.rolloutRestartDeployment(deploymentName); err != nil {
 		return fmt.Errorf("failed to restart deployment after secret update: %w", err)
@@ -763,7 +769,9 @@ func collectSpyreCardsFromPod(kc *OpenshiftClient, pod *corev1.Pod, spyreCards *
 	for i := range pod.Spec.Containers {
 		containerName := pod.Spec.Containers[i].Name
 
-		output, err := kc.ExecInContainerWithCmd(pod.Name, containerName, []string{"env"})
+		var stdout bytes.Buffer
+		err := kc.ExecInContainerWithCmd(kc.Ctx, pod.Name, containerName, []string{"env"}, &stdout)
+		output := stdout.String()
 		if err != nil {
 			logger.Warningf("collectSpyreCardsFromPod: exec failed for container %s in pod %s/%s: %v", containerName, pod.Namespace, pod.Name, err)
 
@@ -776,10 +784,10 @@ func collectSpyreCardsFromPod(kc *OpenshiftClient, pod *corev1.Pod, spyreCards *
 }
 
 // ExecInContainerWithCmd runs the given command inside the named container via
-// the Kubernetes API server pod/exec subresource and returns the combined stdout.
+// the Kubernetes API server pod/exec subresource and streams its stdout to w.
 // This works from inside the cluster without requiring the `oc` binary to be
 // present in the catalog-backend pod.
-func (kc *OpenshiftClient) ExecInContainerWithCmd(podName, containerName string, command []string) (string, error) {
+func (kc *OpenshiftClient) ExecInContainerWithCmd(ctx context.Context, podName, containerName string, command []string, w io.Writer) error {
 	req := kc.KubeClient.CoreV1().RESTClient().Post().
 		Resource("pods").
 		Name(podName).
@@ -794,23 +802,21 @@ func (kc *OpenshiftClient) ExecInContainerWithCmd(podName, containerName string,
 
 	config, err := getKubeConfig()
 	if err != nil {
-		return "", fmt.Errorf("failed to get kube config for exec: %w", err)
+		return fmt.Errorf("failed to get kube config for exec: %w", err)
 	}
 
 	executor, err := remotecommand.NewSPDYExecutor(config, "POST", req.URL())
 	if err != nil {
-		return "", fmt.Errorf("failed to create SPDY executor for %s/%s: %w", podName, containerName, err)
+		return fmt.Errorf("failed to create SPDY executor for %s/%s: %w", podName, containerName, err)
 	}
 
-	var stdout bytes.Buffer
-
-	if err = executor.StreamWithContext(kc.Ctx, remotecommand.StreamOptions{
-		Stdout: &stdout,
+	if err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: w,
 	}); err != nil {
-		return "", fmt.Errorf("exec stream failed for %s/%s: %w", podName, containerName, err)
+		return fmt.Errorf("exec stream failed for %s/%s: %w", podName, containerName, err)
 	}
 
-	return stdout.String(), nil
+	return nil
 }
 
 // isDeploymentReady reports whether a rollout of the named deployment has fully completed.
@@ -1,15 +1,16 @@
 package podman
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
-	"os/signal"
+	"strconv"
 	"strings"
-	"syscall"
+	"time"
 
 	"github.com/containers/podman/v5/libpod/define"
 	"github.com/containers/podman/v5/pkg/bindings"
@@ -28,7 +29,9 @@ import (
 	"github.com/project-ai-services/ai-services/internal/pkg/models"
 	"github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
 	"github.com/project-ai-services/ai-services/internal/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
 )
 
 const (
@@ -251,76 +254,106 @@ func (pc *PodmanClient) InspectPod(nameOrID string) (*types.Pod, error) {
 	return toPodInspectReport(podInspectReport), nil
 }
 
-// streamContainerLogs streams logs from a container using channels.
-func (pc *PodmanClient) streamContainerLogs(ctx context.Context, containerNameOrID string) error {
-	opts := &containers.LogOptions{
-		Follow: utils.BoolPtr(true),
-		Stderr: utils.BoolPtr(true),
-		Stdout: utils.BoolPtr(true),
-	}
-
-	stdoutChan := make(chan string, logChannelBufferSize)
-	stderrChan := make(chan string, logChannelBufferSize)
-
-	logsCtx, cancelLogs := context.WithCancel(ctx)
+// writeContainerLogs copies the logs of a container to w. With opts.Follow it keeps
+// writing until the container exits or ctx is cancelled.
+func (pc *PodmanClient) writeContainerLogs(ctx context.Context, containerNameOrID string, w io.Writer, opts types.LogOptions) error {
+	// The bindings need the connection carried by pc.Context; ctx only bounds the call.
+	logsCtx, cancelLogs := context.WithCancel(pc.Context)
 	defer cancelLogs()
+	stop := context.AfterFunc(ctx, cancelLogs)
+	defer stop()
 
-	// Channel to signal goroutine completion
-	done := make(chan struct{})
-
-	go func() {
-		defer close(done)
-		waitDone := make(chan struct{})
+	if opts.Follow {
 		go func() {
-			defer close(waitDone)
-			_, err := containers.Wait(ctx, containerNameOrID, nil)
-			if err == nil {
-				// Container exited, cancel the logs streaming
+			// Container exited, cancel the logs streaming
+			if _, err := containers.Wait(logsCtx, containerNameOrID, nil); err == nil {
 				cancelLogs()
 			}
 		}()
+	}
 
-		// Stream logs
-		_ = containers.Logs(logsCtx, containerNameOrID, opts, stdoutChan, stderrChan)
+	stdoutChan := make(chan string, logChannelBufferSize)
+	stderrChan := make(chan string, logChannelBufferSize)
+	errCh := make(chan error, 1)
 
-		// Wait for container wait to complete
-		<-waitDone
+	go func() {
+		errCh <- containers.Logs(logsCtx, containerNameOrID, podmanLogOptions(opts), stdoutChan, stderrChan)
 	}()
 
-	// passing both contexts so it respects Ctrl+C and container exit
-	pc.printLogsFromChannels(ctx, logsCtx, stdoutChan, stderrChan)
-
-	// Wait for goroutine to complete
-	<-done
+	// containers.Logs never closes the channels, so they are drained until it returns.
+	// After a failed write the remaining lines are discarded.
+	var writeErr error
+	write := func(line string) {
+		if writeErr != nil {
+			return
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			writeErr = err
+			cancelLogs()
+		}
+	}
 
-	return nil
+	for {
+		select {
+		case line := <-stdoutChan:
+			write(line)
+		case line := <-stderrChan:
+			write(line)
+		case err := <-errCh:
+			drainLogChannels(stdoutChan, stderrChan, write)
+
+			return logsError(logsCtx, err, writeErr)
+		}
+	}
 }
 
-// printLogsFromChannels reads from stdout and stderr channels and prints logs.
-func (pc *PodmanClient) printLogsFromChannels(parentCtx, logsCtx context.Context, stdoutChan, stderrChan <-chan string) {
+// drainLogChannels writes the lines still buffered once containers.Logs has returned.
+func drainLogChannels(stdoutChan, stderrChan <-chan string, write func(string)) {
 	for {
 		select {
-		case <-parentCtx.Done():
-			// Parent context cancelled (e.g., Ctrl+C)
-			return
-		case <-logsCtx.Done():
-			// Logs context cancelled (e.g., container exited)
+		case line := <-stdoutChan:
+			write(line)
+		case line := <-stderrChan:
+			write(line)
+		default:
 			return
-		case line, ok := <-stdoutChan:
-			if !ok {
-				return
-			}
-			logger.Infoln(line)
-		case line, ok := <-stderrChan:
-			if !ok {
-				return
-			}
-			logger.Infoln(line)
 		}
 	}
 }
 
-func (pc *PodmanClient) PodLogs(podNameOrID string) error {
+// logsError maps the outcome of a log stream to the error returned to the caller.
+// A stream ended by cancellation (Ctrl+C, caller gone, container exited) is not an error.
+func logsError(logsCtx context.Context, err, writeErr error) error {
+	if writeErr != nil {
+		return fmt.Errorf("failed to write logs: %w", writeErr)
+	}
+	if err != nil && logsCtx.Err() == nil {
+		return err
+	}
+
+	return nil
+}
+
+// podmanLogOptions converts runtime log options to the bindings' representation.
+func podmanLogOptions(opts types.LogOptions) *containers.LogOptions {
+	logOpts := &containers.LogOptions{
+		Follow: utils.BoolPtr(opts.Follow),
+		Stderr: utils.BoolPtr(true),
+		Stdout: utils.BoolPtr(true),
+	}
+	if opts.Tail > 0 {
+		logOpts.Tail = utils.Ptr(strconv.Itoa(opts.Tail))
+	}
+	if opts.Since > 0 {
+		logOpts.Since = utils.Ptr(time.Now().Add(-opts.Since).Format(time.RFC3339))
+	}
+
+	return logOpts
+}
+
+// PodLogs writes the logs of every non-infra container in the pod to w, each preceded
+// by a header line. In follow mode the containers are followed one after another.
+func (pc *PodmanClient) PodLogs(ctx context.Context, podNameOrID string, w io.Writer, opts types.LogOptions) error {
 	if podNameOrID == "" {
 		return errors.New("pod name or ID cannot be empty")
 	}
@@ -334,24 +367,22 @@ func (pc *PodmanClient) PodLogs(podNameOrID string) error {
 		return errors.New("no containers found in pod")
 	}
 
-	// creating context here that listens for Ctrl+C
-	ctx, stop := signal.NotifyContext(pc.Context, os.Interrupt, syscall.SIGTERM)
-	defer stop()
-
 	for _, container := range podInspect.Containers {
 		// Skip infra container
 		if container.ID == podInspect.InfraContainerID {
 			continue
 		}
 
-		logger.Infof("Streaming logs for container: %s", container.Name)
+		if _, err := fmt.Fprintf(w, "Logs for container: %s\n", container.Name); err != nil {
+			return fmt.Errorf("failed to write logs: %w", err)
+		}
 
-		if err := pc.streamContainerLogs(ctx, container.ID); err != nil {
+		if err := pc.writeContainerLogs(ctx, container.ID, w, opts); err != nil {
 			return fmt.Errorf("error reading logs for container %s: %w", container.Name, err)
 		}
 
 		// Check if context was cancelled
-		if ctx.Err() == context.Canceled || ctx.Err() == context.DeadlineExceeded {
+		if ctx.Err() != nil {
 			return nil
 		}
 	}
@@ -363,16 +394,13 @@ func (pc *PodmanClient) PodExists(nameOrID string) (bool, error) {
 	return pods.Exists(pc.Context, nameOrID, nil)
 }
 
-func (pc *PodmanClient) ContainerLogs(containerNameOrID string) error {
+// ContainerLogs writes the logs of a single container to w.
+func (pc *PodmanClient) ContainerLogs(ctx context.Context, containerNameOrID string, w io.Writer, opts types.LogOptions) error {
 	if containerNameOrID == "" {
 		return fmt.Errorf("container name or ID required to fetch logs")
 	}
 
-	// Creating context here that listens for Ctrl+C
-	ctx, stop := signal.NotifyContext(pc.Context, os.Interrupt, syscall.SIGTERM)
-	defer stop()
-
-	return pc.streamContainerLogs(ctx, containerNameOrID)
+	return pc.writeContainerLogs(ctx, containerNameOrID, w, opts)
 }
 
 func (pc *PodmanClient) ContainerExists(nameOrID string) (bool, error) {
@@ -491,10 +519,39 @@ func (pc *PodmanClient) SecretExists(nameOrID string) (bool, error) {
 	return secrets.Exists(pc.Context, nameOrID)
 }
 
+// UpdateSecret merges data into a secret created by kube play, keeping its labels.
+// deploymentName is ignored: pods load secrets when they are played, so they must
+// be recreated to pick up the change.
 func (pc *PodmanClient) UpdateSecret(name, deploymentName string, data map[string][]byte) error {
-	logger.ErrorfCtx(pc.Context, "unsupported method called!")
+	report, err := secrets.Inspect(pc.Context, name, new(secrets.InspectOptions).WithShowSecret(true))
+	if err != nil {
+		return fmt.Errorf("failed to get existing secret: %w", err)
+	}
 
-	return fmt.Errorf("unsupported method")
+	var secret corev1.Secret
+	if err := yaml.Unmarshal([]byte(report.SecretData), &secret); err != nil {
+		return fmt.Errorf("secret %s was not created by kube play: %w", name, err)
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	for k, v := range data {
+		secret.Data[k] = v
+		// stringData takes precedence over data.
+		delete(secret.StringData, k)
+	}
+
+	manifest, err := yaml.Marshal(&secret)
+	if err != nil {
+		return fmt.Errorf("failed to encode secret: %w", err)
+	}
+
+	opts := new(secrets.CreateOptions).WithName(name).WithLabels(report.Spec.Labels).WithReplace(true)
+	if _, err := secrets.Create(pc.Context, bytes.NewReader(manifest), opts); err != nil {
+		return fmt.Errorf("failed to update secret: %w", err)
+	}
+
+	return nil
 }
 
 func (pc *PodmanClient) GetNamespace() (string, error) {
@@ -582,6 +639,7 @@ func getAcceleratorInfo(ctx context.Context) map[string]*models.AcceleratorInfo
 	accelerators[constants.SpyreResourceName] = &models.AcceleratorInfo{
 		Total:     totalCount,
 		Available: availableCount,
+		Devices:   availableCards,
 	}
 
 	return accelerators
@@ -810,8 +868,8 @@ func (pc *PodmanClient) ManageSidecarLifecycle(podID, sidecarName, image string,
 }
 
 // ExecInContainerWithCmd is not implemented for the Podman runtime.
-func (pc *PodmanClient) ExecInContainerWithCmd(_, _ string, _ []string) (string, error) {
+func (pc *PodmanClient) ExecInContainerWithCmd(_ context.Context, _, _ string, _ []string, _ io.Writer) error {
 	logger.Errorf("unsupported method called!")
 
-	return "", fmt.Errorf("unsupported method")
+	return fmt.Errorf("unsupported method")
 }
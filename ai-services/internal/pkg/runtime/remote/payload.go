@@ -0,0 +1,156 @@
+package remote
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+)
+
+// The structs below are the JSON wire format carried in Command.payload and
+// CommandResult.data. They are shared by RemoteRuntime (control plane) and the
+// worker daemon, so field names must stay stable across releases.
+
+// ──────────────────────────────────────────────────────────────────────────────
+// Request payloads (Command.payload)
+// ──────────────────────────────────────────────────────────────────────────────
+
+// NameRequest is the payload for every command that targets a single object by
+// name or ID (stop/start/inspect pod, delete secret, volume exists, …).
+type NameRequest struct {
+	Name string `json:"name"`
+}
+
+// PullImageRequest is the payload for COMMAND_TYPE_PULL_IMAGE.
+type PullImageRequest struct {
+	Image string `json:"image"`
+}
+
+// FiltersRequest is the payload for list commands that accept label filters.
+type FiltersRequest struct {
+	Filters map[string][]string `json:"filters,omitempty"`
+}
+
+// CreatePodRequest is the payload for COMMAND_TYPE_CREATE_POD.
+// Body is the raw kube YAML that the local runtime would otherwise read from an io.Reader.
+type CreatePodRequest struct {
+	Body []byte            `json:"body"`
+	Opts map[string]string `json:"opts,omitempty"`
+}
+
+// DeletePodRequest is the payload for COMMAND_TYPE_DELETE_POD.
+type DeletePodRequest struct {
+	Name  string `json:"name"`
+	Force *bool  `json:"force,omitempty"`
+}
+
+// UpdateSecretRequest is the payload for COMMAND_TYPE_UPDATE_SECRET.
+type UpdateSecretRequest struct {
+	Name           string            `json:"name"`
+	DeploymentName string            `json:"deployment_name"`
+	Data           map[string][]byte `json:"data"`
+}
+
+// LogsRequest is the payload for COMMAND_TYPE_POD_LOGS and COMMAND_TYPE_CONTAINER_LOGS.
+// The log itself is streamed back as CommandResult frames.
+type LogsRequest struct {
+	Name         string `json:"name"`
+	Follow       bool   `json:"follow,omitempty"`
+	Tail         int    `json:"tail,omitempty"`
+	SinceSeconds int64  `json:"since_seconds,omitempty"`
+}
+
+// LogOptions converts the request back to the runtime's log options.
+func (r LogsRequest) LogOptions() types.LogOptions {
+	return types.LogOptions{
+		Follow: r.Follow,
+		Tail:   r.Tail,
+		Since:  time.Duration(r.SinceSeconds) * time.Second,
+	}
+}
+
+// ExecRequest is the payload for COMMAND_TYPE_EXEC_IN_CONTAINER.
+// The command's stdout is streamed back as CommandResult frames.
+type ExecRequest struct {
+	PodName       string   `json:"pod_name"`
+	ContainerName string   `json:"container_name"`
+	Command       []string `json:"command"`
+}
+
+// ListRoutesRequest is the payload for COMMAND_TYPE_LIST_ROUTES.
+type ListRoutesRequest struct {
+	LabelSelector string `json:"label_selector"`
+}
+
+// ListCRDRequest is the payload for COMMAND_TYPE_LIST_CRD.
+// APIVersion and Kind identify the list type the caller prepared.
+type ListCRDRequest struct {
+	APIVersion string              `json:"api_version"`
+	Kind       string              `json:"kind"`
+	Filters    map[string][]string `json:"filters,omitempty"`
+}
+
+// DeletePVCsRequest is the payload for COMMAND_TYPE_DELETE_PVCS.
+type DeletePVCsRequest struct {
+	AppLabel string `json:"app_label"`
+}
+
+// HTTPProxyRequest is the payload for COMMAND_TYPE_HTTP_PROXY. The worker sends the
+// request to Port of Pod and streams back an HTTPProxyResponse line followed by the
+// response body.
+type HTTPProxyRequest struct {
+	Pod      string      `json:"pod"`
+	Port     string      `json:"port"`
+	Method   string      `json:"method"`
+	Path     string      `json:"path"`
+	RawQuery string      `json:"raw_query,omitempty"`
+	Header   http.Header `json:"header,omitempty"`
+	Body     []byte      `json:"body,omitempty"`
+}
+
+// CancelRequest is the payload for COMMAND_TYPE_CANCEL.
+type CancelRequest struct {
+	CommandID string `json:"command_id"`
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// Response payloads (CommandResult.data)
+// ──────────────────────────────────────────────────────────────────────────────
+
+// ExistsResponse is returned by the *_EXISTS commands.
+type ExistsResponse struct {
+	Exists bool `json:"exists"`
+}
+
+// NamespaceResponse is returned by COMMAND_TYPE_GET_NAMESPACE.
+type NamespaceResponse struct {
+	Namespace string `json:"namespace"`
+}
+
+// RuntimeTypeResponse is returned by COMMAND_TYPE_RUNTIME_TYPE.
+type RuntimeTypeResponse struct {
+	RuntimeType types.RuntimeType `json:"runtime_type"`
+}
+
+// SecretsResponse is returned by COMMAND_TYPE_LIST_SECRETS.
+type SecretsResponse struct {
+	Secrets []string `json:"secrets"`
+}
+
+// ListCRDResponse is returned by COMMAND_TYPE_LIST_CRD.
+// List is the JSON encoding of the populated unstructured list.
+type ListCRDResponse struct {
+	List      json.RawMessage     `json:"list"`
+	Resources []types.CRDResource `json:"resources"`
+}
+
+// HTTPProxyResponse is the first line of the COMMAND_TYPE_HTTP_PROXY output: the
+// status and headers of the pod's response, JSON-encoded and terminated by '\n'.
+// Everything after it is the response body.
+type HTTPProxyResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+}
+
+// Made with Bob
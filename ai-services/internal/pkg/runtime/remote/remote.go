@@ -0,0 +1,430 @@
+// Package remote implements runtime.Runtime on top of the WorkerGateway command
+// stream. Every call is encoded as a workerpb.Command with a JSON payload, sent to
+// a connected worker daemon, and the CommandResult is decoded back into the
+// return values the local runtimes would produce. Log and exec output is streamed
+// back as result frames and copied into the caller's io.Writer as it arrives.
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-ai-services/ai-services/internal/pkg/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+	workerpb "github.com/project-ai-services/ai-services/internal/pkg/worker/proto"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// defaultCommandTimeout bounds a single round trip to the worker when the
+	// runtime.Runtime method does not carry a context of its own.
+	defaultCommandTimeout = 2 * time.Minute
+
+	// longCommandTimeout is used for operations that routinely take minutes
+	// on the worker (image pulls, pod creation).
+	longCommandTimeout = 30 * time.Minute
+)
+
+// Dispatcher delivers a command to a named worker and waits for its result.
+// DispatchStream additionally passes the output frames of streamed commands to onChunk.
+// *registry.Registry satisfies this interface.
+type Dispatcher interface {
+	Dispatch(ctx context.Context, workerName string, cmd *workerpb.Command) (*workerpb.CommandResult, error)
+	DispatchStream(ctx context.Context, workerName string, cmd *workerpb.Command, onChunk func([]byte) error) (*workerpb.CommandResult, error)
+}
+
+// CommandError is returned when the worker executed a command and reported failure.
+type CommandError struct {
+	WorkerName string
+	Type       workerpb.CommandType
+	Message    string
+}
+
+func (e *CommandError) Error() string {
+	return fmt.Sprintf("worker %s: %s failed: %s", e.WorkerName, e.Type, e.Message)
+}
+
+// RemoteRuntime executes runtime.Runtime operations on a remote worker.
+type RemoteRuntime struct {
+	dispatcher  Dispatcher
+	workerName  string
+	runtimeType types.RuntimeType
+}
+
+// NewRemoteRuntime returns a RemoteRuntime that sends commands to workerName through dispatcher.
+// runtimeType is the runtime the worker declared at registration and is returned by Type().
+func NewRemoteRuntime(dispatcher Dispatcher, workerName string, runtimeType types.RuntimeType) (*RemoteRuntime, error) {
+	if dispatcher == nil {
+		return nil, fmt.Errorf("remote runtime: no dispatcher configured")
+	}
+	if workerName == "" {
+		return nil, fmt.Errorf("remote runtime: worker name is required")
+	}
+
+	return &RemoteRuntime{
+		dispatcher:  dispatcher,
+		workerName:  workerName,
+		runtimeType: runtimeType,
+	}, nil
+}
+
+// WorkerName returns the name of the worker this runtime targets.
+func (r *RemoteRuntime) WorkerName() string {
+	return r.workerName
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// Image operations
+// ──────────────────────────────────────────────────────────────────────────────
+
+func (r *RemoteRuntime) ListImages() ([]types.Image, error) {
+	var images []types.Image
+	if err := r.call(context.Background(), workerpb.CommandType_COMMAND_TYPE_LIST_IMAGES, nil, &images); err != nil {
+		return nil, err
+	}
+
+	return images, nil
+}
+
+func (r *RemoteRuntime) PullImage(ctx context.Context, image string) error {
+	return r.callWithTimeout(ctx, longCommandTimeout, workerpb.CommandType_COMMAND_TYPE_PULL_IMAGE, PullImageRequest{Image: image}, nil)
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// Pod operations
+// ──────────────────────────────────────────────────────────────────────────────
+
+func (r *RemoteRuntime) ListPods(filters map[string][]string) ([]types.Pod, error) {
+	var pods []types.Pod
+	if err := r.call(context.Background(), workerpb.CommandType_COMMAND_TYPE_LIST_PODS, FiltersRequest{Filters: filters}, &pods); err != nil {
+		return nil, err
+	}
+
+	return pods, nil
+}
+
+func (r *RemoteRuntime) CreatePod(ctx context.Context, body io.Reader, opts map[string]string) ([]types.Pod, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pod spec: %w", err)
+	}
+
+	var pods []types.Pod
+	if err := r.callWithTimeout(ctx, longCommandTimeout, workerpb.CommandType_COMMAND_TYPE_CREATE_POD, CreatePodRequest{Body: data, Opts: opts}, &pods); err != nil {
+		return nil, err
+	}
+
+	return pods, nil
+}
+
+func (r *RemoteRuntime) DeletePod(id string, force *bool) error {
+	return r.call(context.Background(), workerpb.CommandType_COMMAND_TYPE_DELETE_POD, DeletePodRequest{Name: id, Force: force}, nil)
+}
+
+func (r *RemoteRuntime) StopPod(id string) error {
+	return r.call(context.Background(), workerpb.CommandType_COMMAND_TYPE_STOP_POD, NameRequest{Name: id}, nil)
+}
+
+func (r *RemoteRuntime) StartPod(id string) error {
+	return r.call(context.Background(), workerpb.CommandType_COMMAND_TYPE_START_POD, NameRequest{Name: id}, nil)
+}
+
+func (r *RemoteRuntime) InspectPod(nameOrID string) (*types.Pod, error) {
+	var pod types.Pod
+	if err := r.call(context.Background(), workerpb.CommandType_COMMAND_TYPE_INSPECT_POD, NameRequest{Name: nameOrID}, &pod); err != nil {
+		return nil, err
+	}
+
+	return &pod, nil
+}
+
+func (r *RemoteRuntime) PodExists(nameOrID string) (bool, error) {
+	return r.exists(workerpb.CommandType_COMMAND_TYPE_POD_EXISTS, nameOrID)
+}
+
+func (r *RemoteRuntime) PodLogs(ctx context.Context, nameOrID string, w io.Writer, opts types.LogOptions) error {
+	return r.logs(ctx, workerpb.CommandType_COMMAND_TYPE_POD_LOGS, nameOrID, w, opts)
+}
+
+func (r *RemoteRuntime) GetPodResources(nameOrID string) (*types.PodResources, error) {
+	var res types.PodResources
+	if err := r.call(context.Background(), workerpb.CommandType_COMMAND_TYPE_GET_POD_RESOURCES, NameRequest{Name: nameOrID}, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func (r *RemoteRuntime) GetNamespace() (string, error) {
+	var res NamespaceResponse
+	if err := r.call(context.Background(), workerpb.CommandType_COMMAND_TYPE_GET_NAMESPACE, nil, &res); err != nil {
+		return "", err
+	}
+
+	return res.Namespace, nil
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// Secret operations
+// ──────────────────────────────────────────────────────────────────────────────
+
+func (r *RemoteRuntime) ListSecrets(filters map[string][]string) ([]string, error) {
+	var res SecretsResponse
+	if err := r.call(context.Background(), workerpb.CommandType_COMMAND_TYPE_LIST_SECRETS, FiltersRequest{Filters: filters}, &res); err != nil {
+		return nil, err
+	}
+
+	return res.Secrets, nil
+}
+
+func (r *RemoteRuntime) DeleteSecret(name string) error {
+	return r.call(context.Background(), workerpb.CommandType_COMMAND_TYPE_DELETE_SECRET, NameRequest{Name: name}, nil)
+}
+
+func (r *RemoteRuntime) SecretExists(nameOrID string) (bool, error) {
+	return r.exists(workerpb.CommandType_COMMAND_TYPE_SECRET_EXISTS, nameOrID)
+}
+
+func (r *RemoteRuntime) UpdateSecret(name, deploymentName string, data map[string][]byte) error {
+	req := UpdateSecretRequest{Name: name, DeploymentName: deploymentName, Data: data}
+
+	return r.call(context.Background(), workerpb.CommandType_COMMAND_TYPE_UPDATE_SECRET, req, nil)
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// Volume operations
+// ──────────────────────────────────────────────────────────────────────────────
+
+func (r *RemoteRuntime) DeleteVolume(name string) error {
+	return r.call(context.Background(), workerpb.CommandType_COMMAND_TYPE_DELETE_VOLUME, NameRequest{Name: name}, nil)
+}
+
+func (r *RemoteRuntime) VolumeExists(nameOrID string) (bool, error) {
+	return r.exists(workerpb.CommandType_COMMAND_TYPE_VOLUME_EXISTS, nameOrID)
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// Container operations
+// ──────────────────────────────────────────────────────────────────────────────
+
+func (r *RemoteRuntime) InspectContainer(nameOrID string) (*types.Container, error) {
+	var container types.Container
+	if err := r.call(context.Background(), workerpb.CommandType_COMMAND_TYPE_INSPECT_CONTAINER, NameRequest{Name: nameOrID}, &container); err != nil {
+		return nil, err
+	}
+
+	return &container, nil
+}
+
+func (r *RemoteRuntime) ContainerExists(nameOrID string) (bool, error) {
+	return r.exists(workerpb.CommandType_COMMAND_TYPE_CONTAINER_EXISTS, nameOrID)
+}
+
+func (r *RemoteRuntime) ContainerLogs(ctx context.Context, containerNameOrID string, w io.Writer, opts types.LogOptions) error {
+	return r.logs(ctx, workerpb.CommandType_COMMAND_TYPE_CONTAINER_LOGS, containerNameOrID, w, opts)
+}
+
+func (r *RemoteRuntime) ExecInContainerWithCmd(ctx context.Context, podName, containerName string, command []string, w io.Writer) error {
+	req := ExecRequest{PodName: podName, ContainerName: containerName, Command: command}
+
+	return r.stream(ctx, defaultCommandTimeout, workerpb.CommandType_COMMAND_TYPE_EXEC_IN_CONTAINER, req, w)
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// Network, CRD, namespace and PVC operations
+// ──────────────────────────────────────────────────────────────────────────────
+
+func (r *RemoteRuntime) ListRoutes(labelSelector string) ([]types.Route, error) {
+	var routes []types.Route
+	if err := r.call(context.Background(), workerpb.CommandType_COMMAND_TYPE_LIST_ROUTES, ListRoutesRequest{LabelSelector: labelSelector}, &routes); err != nil {
+		return nil, err
+	}
+
+	return routes, nil
+}
+
+func (r *RemoteRuntime) ListCRD(list *unstructured.UnstructuredList, filters map[string][]string) ([]types.CRDResource, error) {
+	if list == nil {
+		return nil, fmt.Errorf("unstructured list cannot be nil")
+	}
+
+	req := ListCRDRequest{APIVersion: list.GetAPIVersion(), Kind: list.GetKind(), Filters: filters}
+
+	var res ListCRDResponse
+	if err := r.call(context.Background(), workerpb.CommandType_COMMAND_TYPE_LIST_CRD, req, &res); err != nil {
+		return nil, err
+	}
+
+	if len(res.List) > 0 {
+		if err := list.UnmarshalJSON(res.List); err != nil {
+			return nil, fmt.Errorf("failed to decode %s list from worker %s: %w", req.Kind, r.workerName, err)
+		}
+	}
+
+	return res.Resources, nil
+}
+
+func (r *RemoteRuntime) DeleteNamespace(name string) error {
+	return r.call(context.Background(), workerpb.CommandType_COMMAND_TYPE_DELETE_NAMESPACE, NameRequest{Name: name}, nil)
+}
+
+func (r *RemoteRuntime) DeletePVCs(appLabel string) error {
+	return r.call(context.Background(), workerpb.CommandType_COMMAND_TYPE_DELETE_PVCS, DeletePVCsRequest{AppLabel: appLabel}, nil)
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// System information
+// ──────────────────────────────────────────────────────────────────────────────
+
+func (r *RemoteRuntime) GetSystemInfo() (*models.SystemInfo, error) {
+	var info models.SystemInfo
+	if err := r.call(context.Background(), workerpb.CommandType_COMMAND_TYPE_GET_SYSTEM_INFO, nil, &info); err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// Type returns the runtime type the worker declared at registration.
+func (r *RemoteRuntime) Type() types.RuntimeType {
+	return r.runtimeType
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// Internal helpers
+// ──────────────────────────────────────────────────────────────────────────────
+
+// exists runs one of the *_EXISTS commands and decodes the boolean result.
+func (r *RemoteRuntime) exists(cmdType workerpb.CommandType, nameOrID string) (bool, error) {
+	var res ExistsResponse
+	if err := r.call(context.Background(), cmdType, NameRequest{Name: nameOrID}, &res); err != nil {
+		return false, err
+	}
+
+	return res.Exists, nil
+}
+
+// logs streams the log of a pod or container from the worker into w.
+// A followed log is not bounded by a timeout; it ends when ctx is cancelled.
+func (r *RemoteRuntime) logs(ctx context.Context, cmdType workerpb.CommandType, nameOrID string, w io.Writer, opts types.LogOptions) error {
+	if nameOrID == "" {
+		return fmt.Errorf("name or ID required to fetch logs")
+	}
+
+	req := LogsRequest{
+		Name:         nameOrID,
+		Follow:       opts.Follow,
+		Tail:         opts.Tail,
+		SinceSeconds: int64(opts.Since / time.Second),
+	}
+
+	timeout := defaultCommandTimeout
+	if opts.Follow {
+		timeout = 0
+	}
+
+	return r.stream(ctx, timeout, cmdType, req, w)
+}
+
+// call runs a command with the default timeout.
+func (r *RemoteRuntime) call(ctx context.Context, cmdType workerpb.CommandType, req, resp any) error {
+	return r.callWithTimeout(ctx, defaultCommandTimeout, cmdType, req, resp)
+}
+
+// callWithTimeout encodes req, dispatches the command and decodes the result into resp.
+// If ctx already carries a deadline it is left untouched; otherwise timeout is applied.
+// A nil req sends an empty payload and a nil resp ignores the result data.
+func (r *RemoteRuntime) callWithTimeout(ctx context.Context, timeout time.Duration, cmdType workerpb.CommandType, req, resp any) error {
+	cmd, err := newCommand(cmdType, req)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := withTimeout(ctx, timeout)
+	defer cancel()
+
+	res, err := r.dispatcher.Dispatch(ctx, r.workerName, cmd)
+	if err != nil {
+		return err
+	}
+
+	if !res.GetSuccess() {
+		return &CommandError{WorkerName: r.workerName, Type: cmdType, Message: res.GetError()}
+	}
+
+	if resp == nil || len(res.GetData()) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(res.GetData(), resp); err != nil {
+		return fmt.Errorf("failed to decode %s result from worker %s: %w", cmdType, r.workerName, err)
+	}
+
+	return nil
+}
+
+// stream dispatches a streamed command and copies its output frames into w as they
+// arrive. A zero timeout leaves ctx unbounded.
+func (r *RemoteRuntime) stream(ctx context.Context, timeout time.Duration, cmdType workerpb.CommandType, req any, w io.Writer) error {
+	cmd, err := newCommand(cmdType, req)
+	if err != nil {
+		return err
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = withTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	res, err := r.dispatcher.DispatchStream(ctx, r.workerName, cmd, func(chunk []byte) error {
+		if _, err := w.Write(chunk); err != nil {
+			return fmt.Errorf("failed to write %s output: %w", cmdType, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !res.GetSuccess() {
+		return &CommandError{WorkerName: r.workerName, Type: cmdType, Message: res.GetError()}
+	}
+
+	return nil
+}
+
+// newCommand builds a Command with a fresh ID and req encoded as its payload.
+// A nil req sends an empty payload.
+func newCommand(cmdType workerpb.CommandType, req any) (*workerpb.Command, error) {
+	var payload []byte
+	if req != nil {
+		var err error
+		payload, err = json.Marshal(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s payload: %w", cmdType, err)
+		}
+	}
+
+	return &workerpb.Command{
+		CommandId: uuid.NewString(),
+		Type:      cmdType,
+		Payload:   payload,
+	}, nil
+}
+
+// withTimeout applies timeout to ctx unless it already carries a deadline.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// Made with Bob
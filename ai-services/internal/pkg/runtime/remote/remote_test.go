@@ -0,0 +1,236 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+	workerpb "github.com/project-ai-services/ai-services/internal/pkg/worker/proto"
+)
+
+// fakeDispatcher records the last command and replies with a canned result.
+// Streamed commands first receive the canned chunks.
+type fakeDispatcher struct {
+	last   *workerpb.Command
+	worker string
+	result *workerpb.CommandResult
+	chunks []string
+	err    error
+}
+
+func (d *fakeDispatcher) Dispatch(_ context.Context, workerName string, cmd *workerpb.Command) (*workerpb.CommandResult, error) {
+	d.last = cmd
+	d.worker = workerName
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	res := d.result
+	if res == nil {
+		res = &workerpb.CommandResult{Success: true}
+	}
+	res.CommandId = cmd.GetCommandId()
+
+	return res, nil
+}
+
+func (d *fakeDispatcher) DispatchStream(ctx context.Context, workerName string, cmd *workerpb.Command, onChunk func([]byte) error) (*workerpb.CommandResult, error) {
+	for _, chunk := range d.chunks {
+		if err := onChunk([]byte(chunk)); err != nil {
+			return nil, err
+		}
+	}
+
+	return d.Dispatch(ctx, workerName, cmd)
+}
+
+func mustJSON(t *testing.T, v any) []byte {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return b
+}
+
+func newTestRuntime(t *testing.T, d *fakeDispatcher) *RemoteRuntime {
+	t.Helper()
+	r, err := NewRemoteRuntime(d, "worker-1", types.RuntimeTypePodman)
+	if err != nil {
+		t.Fatalf("NewRemoteRuntime: %v", err)
+	}
+	return r
+}
+
+func TestNewRemoteRuntime_Validation(t *testing.T) {
+	if _, err := NewRemoteRuntime(nil, "w", types.RuntimeTypePodman); err == nil {
+		t.Error("expected error for nil dispatcher")
+	}
+	if _, err := NewRemoteRuntime(&fakeDispatcher{}, "", types.RuntimeTypePodman); err == nil {
+		t.Error("expected error for empty worker name")
+	}
+}
+
+func TestListPods_EncodesFiltersAndDecodesPods(t *testing.T) {
+	d := &fakeDispatcher{result: &workerpb.CommandResult{
+		Success: true,
+		Data:    []byte(`[{"ID":"p1","Name":"app--vllm"}]`),
+	}}
+	r := newTestRuntime(t, d)
+
+	pods, err := r.ListPods(map[string][]string{"label": {"ai-services.io/application=app"}})
+	if err != nil {
+		t.Fatalf("ListPods: %v", err)
+	}
+
+	if d.worker != "worker-1" {
+		t.Errorf("dispatched to %q, want worker-1", d.worker)
+	}
+	if d.last.GetType() != workerpb.CommandType_COMMAND_TYPE_LIST_PODS {
+		t.Errorf("type = %v, want LIST_PODS", d.last.GetType())
+	}
+	if d.last.GetCommandId() == "" {
+		t.Error("expected a command_id to be assigned")
+	}
+
+	var req FiltersRequest
+	if err := json.Unmarshal(d.last.GetPayload(), &req); err != nil {
+		t.Fatalf("payload: %v", err)
+	}
+	if got := req.Filters["label"]; len(got) != 1 || got[0] != "ai-services.io/application=app" {
+		t.Errorf("filters = %v", req.Filters)
+	}
+
+	if len(pods) != 1 || pods[0].Name != "app--vllm" {
+		t.Errorf("pods = %+v", pods)
+	}
+}
+
+func TestCreatePod_SendsBody(t *testing.T) {
+	d := &fakeDispatcher{result: &workerpb.CommandResult{Success: true, Data: mustJSON(t, []types.Pod{{ID: "p1"}})}}
+	r := newTestRuntime(t, d)
+
+	pods, err := r.CreatePod(context.Background(), strings.NewReader("kind: Pod"), map[string]string{"start": "true"})
+	if err != nil {
+		t.Fatalf("CreatePod: %v", err)
+	}
+	if len(pods) != 1 || pods[0].ID != "p1" {
+		t.Errorf("pods = %+v", pods)
+	}
+
+	var req CreatePodRequest
+	if err := json.Unmarshal(d.last.GetPayload(), &req); err != nil {
+		t.Fatalf("payload: %v", err)
+	}
+	if string(req.Body) != "kind: Pod" || req.Opts["start"] != "true" {
+		t.Errorf("request = %+v", req)
+	}
+}
+
+func TestExists_DecodesBool(t *testing.T) {
+	d := &fakeDispatcher{result: &workerpb.CommandResult{Success: true, Data: mustJSON(t, ExistsResponse{Exists: true})}}
+	r := newTestRuntime(t, d)
+
+	ok, err := r.SecretExists("my-secret")
+	if err != nil {
+		t.Fatalf("SecretExists: %v", err)
+	}
+	if !ok {
+		t.Error("expected exists=true")
+	}
+	if d.last.GetType() != workerpb.CommandType_COMMAND_TYPE_SECRET_EXISTS {
+		t.Errorf("type = %v", d.last.GetType())
+	}
+}
+
+func TestGetSystemInfo(t *testing.T) {
+	d := &fakeDispatcher{result: &workerpb.CommandResult{
+		Success: true,
+		Data:    []byte(`{"cpu":{"total_cpu":8,"available_cpu":6.5},"memory":{"total_bytes":1024,"available_bytes":512}}`),
+	}}
+	r := newTestRuntime(t, d)
+
+	info, err := r.GetSystemInfo()
+	if err != nil {
+		t.Fatalf("GetSystemInfo: %v", err)
+	}
+	if info.CPU == nil || info.CPU.Total != 8 || info.Memory.AvailableBytes != 512 {
+		t.Errorf("info = %+v", info)
+	}
+}
+
+func TestWorkerFailure_SurfacesCommandError(t *testing.T) {
+	d := &fakeDispatcher{result: &workerpb.CommandResult{Success: false, Error: "no such pod"}}
+	r := newTestRuntime(t, d)
+
+	err := r.StopPod("missing")
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) {
+		t.Fatalf("expected CommandError, got %v", err)
+	}
+	if cmdErr.Message != "no such pod" || cmdErr.Type != workerpb.CommandType_COMMAND_TYPE_STOP_POD {
+		t.Errorf("CommandError = %+v", cmdErr)
+	}
+}
+
+func TestDispatchError_IsReturned(t *testing.T) {
+	d := &fakeDispatcher{err: errors.New("worker worker-1 not connected")}
+	r := newTestRuntime(t, d)
+
+	if _, err := r.ListImages(); err == nil || !strings.Contains(err.Error(), "not connected") {
+		t.Errorf("expected dispatch error, got %v", err)
+	}
+}
+
+func TestContainerLogs_StreamsChunksToWriter(t *testing.T) {
+	d := &fakeDispatcher{chunks: []string{"line 1\n", "line 2\n"}, result: &workerpb.CommandResult{Success: true, EndOfStream: true}}
+	r := newTestRuntime(t, d)
+
+	var out bytes.Buffer
+	opts := types.LogOptions{Follow: true, Tail: 50, Since: 10 * time.Minute}
+	if err := r.ContainerLogs(context.Background(), "app--vllm-server", &out, opts); err != nil {
+		t.Fatalf("ContainerLogs: %v", err)
+	}
+	if out.String() != "line 1\nline 2\n" {
+		t.Errorf("output = %q", out.String())
+	}
+
+	var req LogsRequest
+	if err := json.Unmarshal(d.last.GetPayload(), &req); err != nil {
+		t.Fatalf("payload: %v", err)
+	}
+	if req.Name != "app--vllm-server" || req.LogOptions() != opts {
+		t.Errorf("request = %+v", req)
+	}
+}
+
+func TestExecInContainer_FailureAfterOutput(t *testing.T) {
+	d := &fakeDispatcher{chunks: []string{"partial"}, result: &workerpb.CommandResult{Error: "exit status 1", EndOfStream: true}}
+	r := newTestRuntime(t, d)
+
+	var out bytes.Buffer
+	err := r.ExecInContainerWithCmd(context.Background(), "pod", "ctr", []string{"env"}, &out)
+
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) || cmdErr.Type != workerpb.CommandType_COMMAND_TYPE_EXEC_IN_CONTAINER {
+		t.Fatalf("expected CommandError for exec, got %v", err)
+	}
+	if out.String() != "partial" {
+		t.Errorf("output = %q", out.String())
+	}
+}
+
+func TestType_ReturnsDeclaredRuntime(t *testing.T) {
+	r, err := NewRemoteRuntime(&fakeDispatcher{}, "w", types.RuntimeTypeOpenShift)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Type() != types.RuntimeTypeOpenShift {
+		t.Errorf("Type() = %v", r.Type())
+	}
+}
@@ -1,17 +1,30 @@
 package runtime
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/google/uuid"
+
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
 	"github.com/project-ai-services/ai-services/internal/pkg/runtime/openshift"
 	"github.com/project-ai-services/ai-services/internal/pkg/runtime/podman"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/remote"
 	"github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
 )
 
+var _ Runtime = (*remote.RemoteRuntime)(nil)
+
 // RuntimeFactory creates runtime instances based on configuration.
 type RuntimeFactory struct {
 	runtimeType types.RuntimeType
+	dispatcher  remote.Dispatcher
+	resolver    WorkerResolver
+}
+
+// WorkerResolver maps the worker ID stored on an application to the worker's name.
+type WorkerResolver interface {
+	WorkerName(ctx context.Context, id uuid.UUID) (string, error)
 }
 
 // NewRuntimeFactory creates a new runtime factory with the specified runtime type.
@@ -26,13 +39,79 @@ func (f *RuntimeFactory) Create(namespace string) (Runtime, error) {
 	return CreateRuntime(f.runtimeType, namespace)
 }
 
+// SetWorkerDispatcher configures the dispatcher used to reach remote workers.
+// The catalog server sets this to its worker registry once the gateway is up.
+func (f *RuntimeFactory) SetWorkerDispatcher(dispatcher remote.Dispatcher) {
+	f.dispatcher = dispatcher
+}
+
+// CreateForWorker creates a runtime that executes on the named remote worker.
+// runtimeType is the runtime the worker declared when it registered.
+func (f *RuntimeFactory) CreateForWorker(workerName string, runtimeType types.RuntimeType, namespace string) (Runtime, error) {
+	return CreateRuntime(runtimeType, namespace, WithWorker(f.dispatcher, workerName))
+}
+
+// SetWorkerResolver configures how CreateForWorkerID finds the worker of an application.
+func (f *RuntimeFactory) SetWorkerResolver(resolver WorkerResolver) {
+	f.resolver = resolver
+}
+
+// CreateForWorkerID creates a runtime that executes on the worker with the given ID.
+// Workers always run the control plane's runtime type; the scheduler only places
+// applications on such workers.
+func (f *RuntimeFactory) CreateForWorkerID(ctx context.Context, workerID uuid.UUID, namespace string) (Runtime, error) {
+	if f.resolver == nil {
+		return nil, fmt.Errorf("no worker resolver configured")
+	}
+
+	name, err := f.resolver.WorkerName(ctx, workerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.CreateForWorker(name, f.runtimeType, namespace)
+}
+
 // GetRuntimeType returns the configured runtime type.
 func (f *RuntimeFactory) GetRuntimeType() types.RuntimeType {
 	return f.runtimeType
 }
 
+// CreateOption customises the runtime returned by CreateRuntime.
+type CreateOption func(*createOptions)
+
+type createOptions struct {
+	dispatcher remote.Dispatcher
+	workerName string
+}
+
+// WithWorker makes CreateRuntime return a RemoteRuntime that executes every call
+// on the named worker through dispatcher instead of the local runtime.
+func WithWorker(dispatcher remote.Dispatcher, workerName string) CreateOption {
+	return func(o *createOptions) {
+		o.dispatcher = dispatcher
+		o.workerName = workerName
+	}
+}
+
 // CreateRuntime creates a runtime instance based on the specified type.
-func CreateRuntime(runtimeType types.RuntimeType, namespace string) (Runtime, error) {
+// When WithWorker is supplied, the returned runtime proxies to that remote worker.
+func CreateRuntime(runtimeType types.RuntimeType, namespace string, opts ...CreateOption) (Runtime, error) {
+	var o createOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.workerName != "" {
+		logger.Debugf("Initializing remote runtime for worker %s\n", o.workerName)
+		client, err := remote.NewRemoteRuntime(o.dispatcher, o.workerName, runtimeType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create remote runtime: %w", err)
+		}
+
+		return client, nil
+	}
+
 	switch runtimeType {
 	case types.RuntimeTypePodman:
 		logger.Debugf("Initializing Podman runtime\n")
@@ -72,3 +72,13 @@ type CRDResource struct {
 	Name   string
 	Labels map[string]string
 }
+
+// LogOptions controls which part of a container log is returned and whether it is followed.
+type LogOptions struct {
+	// Follow keeps writing new lines until the container exits or the context is cancelled.
+	Follow bool
+	// Tail limits the output to the last Tail lines of each container. Zero returns the whole log.
+	Tail int
+	// Since only returns lines written within this window. Zero returns the whole log.
+	Since time.Duration
+}
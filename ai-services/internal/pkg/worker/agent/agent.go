@@ -0,0 +1,343 @@
+// Package agent implements the worker daemon side of the WorkerGateway protocol.
+// A worker joins the control plane once with a bootstrap token, then keeps a
+// bidirectional CommandStream open, sends periodic heartbeats and executes every
+// Command it receives against the local container runtime. The client certificate
+// received at join time is renewed before it expires.
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/remote"
+	"github.com/project-ai-services/ai-services/internal/pkg/worker/pki"
+	workerpb "github.com/project-ai-services/ai-services/internal/pkg/worker/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultHeartbeatInterval is how often the worker reports liveness. It must stay
+	// well below the gateway's heartbeat timeout (90s) so a single lost beat is tolerated.
+	DefaultHeartbeatInterval = 30 * time.Second
+
+	// registerTimeout bounds the Register RPC issued by Join.
+	registerTimeout = 30 * time.Second
+
+	// initialReconnectDelay and maxReconnectDelay bound the exponential backoff
+	// used when the CommandStream drops for a transient reason.
+	initialReconnectDelay = 1 * time.Second
+	maxReconnectDelay     = 30 * time.Second
+
+	// renewCheckInterval is how often the agent checks whether its client certificate
+	// is due for renewal, and how long it waits before retrying a failed renewal.
+	renewCheckInterval = 1 * time.Hour
+
+	// renewTimeout bounds the RenewCertificate RPC.
+	renewTimeout = 30 * time.Second
+)
+
+// ErrReregistrationRequired is returned by Run when the gateway no longer knows the
+// worker (codes.Unauthenticated). The operator must issue a new token and join again.
+var ErrReregistrationRequired = errors.New("control plane does not recognise this worker: issue a new token and run 'ai-services worker join' again")
+
+// JoinOptions carries the inputs for Join.
+type JoinOptions struct {
+	Token       string
+	RuntimeType string
+	Metadata    map[string]string
+	// Capabilities are declared to the control plane; see Executor.Capabilities.
+	Capabilities *workerpb.Capabilities
+}
+
+// Join calls Register on the gateway and returns the identity assigned by the control plane.
+func Join(ctx context.Context, conn grpc.ClientConnInterface, opts JoinOptions) (*workerpb.RegisterResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, registerTimeout)
+	defer cancel()
+
+	resp, err := workerpb.NewWorkerGatewayClient(conn).Register(ctx, &workerpb.RegisterRequest{
+		PreSharedToken: opts.Token,
+		RuntimeType:    opts.RuntimeType,
+		Metadata:       opts.Metadata,
+		Capabilities:   opts.Capabilities,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to register with worker gateway: %w", err)
+	}
+
+	return resp, nil
+}
+
+// Agent keeps the CommandStream to the gateway open and serves commands.
+type Agent struct {
+	conn              grpc.ClientConnInterface
+	workerName        string
+	executor          *Executor
+	commands          *commandTracker
+	heartbeatInterval time.Duration
+
+	// keyPair and onRenew are set by EnableCertificateRenewal.
+	keyPair *pki.KeyPair
+	onRenew func(*workerpb.RegisterResponse) error
+	now     func() time.Time
+}
+
+// New returns an Agent for workerName that executes commands with executor.
+func New(conn grpc.ClientConnInterface, workerName string, executor *Executor) *Agent {
+	return &Agent{
+		conn:              conn,
+		workerName:        workerName,
+		executor:          executor,
+		commands:          newCommandTracker(),
+		heartbeatInterval: DefaultHeartbeatInterval,
+		now:               time.Now,
+	}
+}
+
+// SetHeartbeatInterval overrides DefaultHeartbeatInterval.
+func (a *Agent) SetHeartbeatInterval(d time.Duration) {
+	a.heartbeatInterval = d
+}
+
+// EnableCertificateRenewal makes Run renew the client certificate held in kp once it
+// is due (see pki.RenewalDue). The new certificate is swapped into kp, so subsequent
+// TLS handshakes on the same connection use it, and passed to onRenew for persistence.
+func (a *Agent) EnableCertificateRenewal(kp *pki.KeyPair, onRenew func(*workerpb.RegisterResponse) error) {
+	a.keyPair = kp
+	a.onRenew = onRenew
+}
+
+// Run serves the CommandStream until ctx is cancelled, reconnecting with exponential
+// backoff after transient failures. It returns ErrReregistrationRequired if the
+// gateway rejects the worker as unknown, and nil on a clean shutdown.
+func (a *Agent) Run(ctx context.Context) error {
+	if a.keyPair != nil {
+		go a.renewLoop(ctx)
+	}
+
+	delay := initialReconnectDelay
+
+	for {
+		start := time.Now()
+		err := a.serveStream(ctx)
+
+		if ctx.Err() != nil {
+			return nil
+		}
+		if status.Code(err) == codes.Unauthenticated {
+			return ErrReregistrationRequired
+		}
+
+		// A stream that stayed up for a while was healthy; start backing off afresh.
+		if time.Since(start) > maxReconnectDelay {
+			delay = initialReconnectDelay
+		}
+
+		logger.WarningfCtx(ctx, "worker %s: command stream closed: %v — reconnecting in %s", a.workerName, err, delay)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+
+		delay = min(delay*2, maxReconnectDelay) //nolint:mnd
+	}
+}
+
+// serveStream opens one CommandStream and serves it until it fails or ctx is cancelled.
+func (a *Agent) serveStream(ctx context.Context) error {
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := workerpb.NewWorkerGatewayClient(a.conn).CommandStream(streamCtx)
+	if err != nil {
+		return err
+	}
+
+	// gRPC streams do not allow concurrent Send calls; all writers share sendMu.
+	var sendMu sync.Mutex
+	send := func(res *workerpb.CommandResult) error {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		res.WorkerName = a.workerName
+
+		return stream.Send(res)
+	}
+
+	// The first message identifies the worker to the gateway and declares what it runs.
+	if err := send(&workerpb.CommandResult{IsHeartbeat: true, Capabilities: a.executor.Capabilities()}); err != nil {
+		return err
+	}
+
+	logger.InfofCtx(ctx, "worker %s: command stream established", a.workerName)
+
+	heartbeatErrCh := make(chan error, 1)
+	go a.heartbeatLoop(streamCtx, send, heartbeatErrCh)
+
+	// Streamed commands are cancelled with the stream: their output could not be
+	// delivered anyway. Unary commands run on, so the control plane can collect their
+	// result from the cache after it resends them on the next stream.
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	for {
+		cmd, err := stream.Recv()
+		if err != nil {
+			select {
+			case hbErr := <-heartbeatErrCh:
+				return hbErr
+			default:
+			}
+
+			return err
+		}
+
+		switch {
+		case cmd.GetType() == workerpb.CommandType_COMMAND_TYPE_CANCEL:
+			a.cancelCommand(ctx, cmd)
+		case a.executor.IsStreamed(cmd.GetType()):
+			wg.Go(func() { a.runStreamed(streamCtx, cmd, send) })
+		default:
+			go a.runUnary(ctx, cmd, send)
+		}
+	}
+}
+
+// runUnary executes a unary command once and sends its result. A command that is
+// received again, e.g. resent after a reconnect, is answered with the result of the
+// first execution instead.
+func (a *Agent) runUnary(ctx context.Context, cmd *workerpb.Command, send func(*workerpb.CommandResult) error) {
+	cmdCtx, cancel := commandContext(ctx, cmd)
+	defer cancel()
+
+	tracked, first := a.commands.begin(cmd.GetCommandId(), cancel, true)
+	if first {
+		a.commands.finish(cmd.GetCommandId(), tracked, a.executor.Execute(cmdCtx, cmd, nil))
+	} else {
+		logger.InfofCtx(ctx, "worker %s: command %s received again, answering from cache", a.workerName, cmd.GetCommandId())
+	}
+
+	select {
+	case <-tracked.done:
+	case <-ctx.Done():
+		return
+	}
+
+	if err := send(tracked.res); err != nil {
+		logger.WarningfCtx(ctx, "worker %s: failed to send result for %s: %v", a.workerName, cmd.GetCommandId(), err)
+	}
+}
+
+// runStreamed executes a command whose output is streamed back in frames.
+func (a *Agent) runStreamed(ctx context.Context, cmd *workerpb.Command, send func(*workerpb.CommandResult) error) {
+	cmdCtx, cancel := commandContext(ctx, cmd)
+	defer cancel()
+
+	tracked, first := a.commands.begin(cmd.GetCommandId(), cancel, false)
+	if !first {
+		return
+	}
+
+	emit := func(chunk []byte) error {
+		return send(&workerpb.CommandResult{CommandId: cmd.GetCommandId(), Chunk: chunk})
+	}
+	res := a.executor.Execute(cmdCtx, cmd, emit)
+	a.commands.finish(cmd.GetCommandId(), tracked, res)
+
+	if err := send(res); err != nil {
+		logger.WarningfCtx(ctx, "worker %s: failed to send result for %s: %v", a.workerName, cmd.GetCommandId(), err)
+	}
+}
+
+// cancelCommand stops the command named in a COMMAND_TYPE_CANCEL payload.
+func (a *Agent) cancelCommand(ctx context.Context, cmd *workerpb.Command) {
+	var req remote.CancelRequest
+	if err := decode(cmd.GetPayload(), &req); err != nil {
+		logger.WarningfCtx(ctx, "worker %s: ignoring cancel command %s: %v", a.workerName, cmd.GetCommandId(), err)
+
+		return
+	}
+
+	if a.commands.cancel(req.CommandID) {
+		logger.InfofCtx(ctx, "worker %s: command %s cancelled by the control plane", a.workerName, req.CommandID)
+	}
+}
+
+// heartbeatLoop sends a heartbeat every interval until ctx is cancelled or a send fails.
+func (a *Agent) heartbeatLoop(ctx context.Context, send func(*workerpb.CommandResult) error, errCh chan<- error) {
+	ticker := time.NewTicker(a.heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := send(&workerpb.CommandResult{IsHeartbeat: true}); err != nil {
+				errCh <- fmt.Errorf("heartbeat failed: %w", err)
+
+				return
+			}
+		}
+	}
+}
+
+// renewLoop renews the client certificate whenever it is due, until ctx is cancelled.
+func (a *Agent) renewLoop(ctx context.Context) {
+	ticker := time.NewTicker(renewCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		if pki.RenewalDue(a.keyPair.Leaf(), a.now()) {
+			if err := a.renewCertificate(ctx); err != nil {
+				logger.WarningfCtx(ctx, "worker %s: certificate renewal failed (expires %s): %v",
+					a.workerName, a.keyPair.Leaf().NotAfter.Format(time.RFC3339), err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// renewCertificate obtains a fresh client certificate and installs it.
+func (a *Agent) renewCertificate(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, renewTimeout)
+	defer cancel()
+
+	resp, err := workerpb.NewWorkerGatewayClient(a.conn).RenewCertificate(ctx, &workerpb.RenewCertificateRequest{
+		WorkerName: a.workerName,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := a.keyPair.Set([]byte(resp.GetTlsCertPem()), []byte(resp.GetTlsKeyPem())); err != nil {
+		return err
+	}
+
+	if a.onRenew != nil {
+		if err := a.onRenew(resp); err != nil {
+			return fmt.Errorf("failed to persist renewed certificate: %w", err)
+		}
+	}
+
+	logger.InfofCtx(ctx, "worker %s: client certificate renewed, valid until %s",
+		a.workerName, a.keyPair.Leaf().NotAfter.Format(time.RFC3339))
+
+	return nil
+}
+
+// Made with Bob
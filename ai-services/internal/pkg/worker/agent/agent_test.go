@@ -0,0 +1,637 @@
+package agent
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/remote"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+	"github.com/project-ai-services/ai-services/internal/pkg/worker/gateway"
+	"github.com/project-ai-services/ai-services/internal/pkg/worker/pki"
+	workerpb "github.com/project-ai-services/ai-services/internal/pkg/worker/proto"
+	"github.com/project-ai-services/ai-services/internal/pkg/worker/registry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const bufSize = 1 << 20 // 1 MiB
+
+// ──────────────────────────────────────────────────────────────────────────────
+// Fake runtime: only the methods exercised by the tests are implemented; any
+// other call panics via the nil embedded interface.
+// ──────────────────────────────────────────────────────────────────────────────
+
+type fakeRuntime struct {
+	runtime.Runtime
+
+	pods      []types.Pod
+	stopped   []string
+	stopErr   error
+	lastImage string
+	logOpts   types.LogOptions
+	podPorts  map[string][]string
+}
+
+func (f *fakeRuntime) ListPods(_ map[string][]string) ([]types.Pod, error) { return f.pods, nil }
+func (f *fakeRuntime) StopPod(id string) error {
+	f.stopped = append(f.stopped, id)
+	return f.stopErr
+}
+func (f *fakeRuntime) PullImage(ctx context.Context, image string) error {
+	f.lastImage = image
+	if image == "slow" {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return nil
+}
+func (f *fakeRuntime) PodExists(name string) (bool, error) { return name == "known", nil }
+func (f *fakeRuntime) GetSystemInfo() (*models.SystemInfo, error) {
+	return &models.SystemInfo{CPU: &models.CPUInfo{Total: 4, Available: 2}}, nil
+}
+func (f *fakeRuntime) Type() types.RuntimeType { return types.RuntimeTypePodman }
+func (f *fakeRuntime) InspectPod(name string) (*types.Pod, error) {
+	return &types.Pod{Name: name, Ports: f.podPorts}, nil
+}
+func (f *fakeRuntime) PodLogs(ctx context.Context, name string, w io.Writer, opts types.LogOptions) error {
+	f.logOpts = opts
+	if name != "known" {
+		return errors.New("no such pod")
+	}
+	if opts.Follow {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	_, err := fmt.Fprintf(w, "hello from %s\n", name)
+	return err
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// Executor
+// ──────────────────────────────────────────────────────────────────────────────
+
+func TestExecutor_ListPods(t *testing.T) {
+	e := NewExecutor(&fakeRuntime{pods: []types.Pod{{ID: "p1", Name: "app--db"}}})
+
+	res := e.Execute(context.Background(), &workerpb.Command{
+		CommandId: "c1",
+		Type:      workerpb.CommandType_COMMAND_TYPE_LIST_PODS,
+		Payload:   []byte(`{"filters":{"label":["x"]}}`),
+	}, nil)
+	if !res.GetSuccess() {
+		t.Fatalf("expected success, got error %q", res.GetError())
+	}
+	if res.GetCommandId() != "c1" {
+		t.Errorf("command_id = %q", res.GetCommandId())
+	}
+
+	var pods []types.Pod
+	if err := json.Unmarshal(res.GetData(), &pods); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(pods) != 1 || pods[0].Name != "app--db" {
+		t.Errorf("pods = %+v", pods)
+	}
+}
+
+func TestExecutor_Capabilities(t *testing.T) {
+	caps := NewExecutor(&fakeRuntime{}).Capabilities()
+
+	if caps.GetProtocolVersion() != workerpb.ProtocolVersion {
+		t.Errorf("protocol_version = %d, want %d", caps.GetProtocolVersion(), workerpb.ProtocolVersion)
+	}
+	for _, typ := range []workerpb.CommandType{
+		workerpb.CommandType_COMMAND_TYPE_LIST_PODS,        // unary
+		workerpb.CommandType_COMMAND_TYPE_HTTP_PROXY,       // streamed
+		workerpb.CommandType_COMMAND_TYPE_CANCEL,           // served by the Agent
+		workerpb.CommandType_COMMAND_TYPE_DELETE_NAMESPACE, // newest unary command
+	} {
+		if !caps.Supports(typ) {
+			t.Errorf("capabilities do not list %s", typ)
+		}
+	}
+	if caps.Supports(workerpb.CommandType_COMMAND_TYPE_UNSPECIFIED) {
+		t.Error("capabilities list COMMAND_TYPE_UNSPECIFIED")
+	}
+}
+
+func TestExecutor_RuntimeErrorIsReported(t *testing.T) {
+	e := NewExecutor(&fakeRuntime{stopErr: errors.New("boom")})
+
+	res := e.Execute(context.Background(), &workerpb.Command{
+		CommandId: "c2",
+		Type:      workerpb.CommandType_COMMAND_TYPE_STOP_POD,
+		Payload:   []byte(`{"name":"p1"}`),
+	}, nil)
+	if res.GetSuccess() || res.GetError() != "boom" {
+		t.Errorf("expected failure with %q, got success=%v error=%q", "boom", res.GetSuccess(), res.GetError())
+	}
+}
+
+func TestExecutor_UnsupportedCommand(t *testing.T) {
+	e := NewExecutor(&fakeRuntime{})
+
+	res := e.Execute(context.Background(), &workerpb.Command{
+		CommandId: "c3",
+		Type:      workerpb.CommandType_COMMAND_TYPE_UNSPECIFIED,
+	}, nil)
+	if res.GetSuccess() || res.GetError() == "" {
+		t.Errorf("expected unsupported-command error, got %+v", res)
+	}
+}
+
+func TestExecutor_InvalidPayload(t *testing.T) {
+	e := NewExecutor(&fakeRuntime{})
+
+	res := e.Execute(context.Background(), &workerpb.Command{
+		CommandId: "c4",
+		Type:      workerpb.CommandType_COMMAND_TYPE_PULL_IMAGE,
+		Payload:   []byte(`not-json`),
+	}, nil)
+	if res.GetSuccess() {
+		t.Error("expected failure for malformed payload")
+	}
+}
+
+func TestExecutor_StreamsLogFrames(t *testing.T) {
+	fake := &fakeRuntime{}
+	e := NewExecutor(fake)
+
+	var chunks []string
+	emit := func(chunk []byte) error {
+		chunks = append(chunks, string(chunk))
+		return nil
+	}
+
+	res := e.Execute(context.Background(), &workerpb.Command{
+		CommandId: "c5",
+		Type:      workerpb.CommandType_COMMAND_TYPE_POD_LOGS,
+		Payload:   []byte(`{"name":"known","tail":20,"since_seconds":60}`),
+	}, emit)
+	if !res.GetSuccess() || !res.GetEndOfStream() {
+		t.Fatalf("expected a successful end-of-stream result, got %+v", res)
+	}
+	if strings.Join(chunks, "") != "hello from known\n" {
+		t.Errorf("chunks = %q", chunks)
+	}
+	if fake.logOpts != (types.LogOptions{Tail: 20, Since: time.Minute}) {
+		t.Errorf("log options = %+v", fake.logOpts)
+	}
+
+	res = e.Execute(context.Background(), &workerpb.Command{
+		CommandId: "c6",
+		Type:      workerpb.CommandType_COMMAND_TYPE_POD_LOGS,
+		Payload:   []byte(`{"name":"missing"}`),
+	}, emit)
+	if res.GetSuccess() || res.GetError() != "no such pod" || !res.GetEndOfStream() {
+		t.Errorf("expected a failed end-of-stream result, got %+v", res)
+	}
+}
+
+func TestExecutor_HTTPProxy(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Upstream", "yes")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, "%s %s?%s %s %s", r.Method, r.URL.Path, r.URL.RawQuery, r.Header.Get("X-Test"), body)
+	}))
+	defer upstream.Close()
+
+	_, port, _ := net.SplitHostPort(strings.TrimPrefix(upstream.URL, "http://"))
+	e := NewExecutor(&fakeRuntime{podPorts: map[string][]string{"5000/tcp": {port}}})
+
+	payload, _ := json.Marshal(remote.HTTPProxyRequest{
+		Pod:      "chat--backend",
+		Port:     "5000",
+		Method:   http.MethodPost,
+		Path:     "/v1/echo",
+		RawQuery: "stream=true",
+		Header:   http.Header{"X-Test": {"header"}},
+		Body:     []byte("hi"),
+	})
+
+	var out strings.Builder
+	res := e.Execute(context.Background(), &workerpb.Command{
+		CommandId: "p1",
+		Type:      workerpb.CommandType_COMMAND_TYPE_HTTP_PROXY,
+		Payload:   payload,
+	}, func(chunk []byte) error {
+		out.Write(chunk)
+		return nil
+	})
+	if !res.GetSuccess() || !res.GetEndOfStream() {
+		t.Fatalf("expected a successful end-of-stream result, got %+v", res)
+	}
+
+	headLine, body, ok := strings.Cut(out.String(), "\n")
+	if !ok {
+		t.Fatalf("output has no head line: %q", out.String())
+	}
+	var head remote.HTTPProxyResponse
+	if err := json.Unmarshal([]byte(headLine), &head); err != nil {
+		t.Fatalf("invalid head %q: %v", headLine, err)
+	}
+	if head.StatusCode != http.StatusCreated || head.Header.Get("X-Upstream") != "yes" {
+		t.Errorf("head = %+v", head)
+	}
+	if body != "POST /v1/echo?stream=true header hi" {
+		t.Errorf("body = %q", body)
+	}
+}
+
+// resultSink collects the results an agent sends.
+type resultSink struct {
+	ch chan *workerpb.CommandResult
+}
+
+func newResultSink() *resultSink {
+	return &resultSink{ch: make(chan *workerpb.CommandResult, 8)}
+}
+
+func (s *resultSink) send(res *workerpb.CommandResult) error {
+	if len(res.GetChunk()) == 0 {
+		s.ch <- res
+	}
+	return nil
+}
+
+func (s *resultSink) next(t *testing.T) *workerpb.CommandResult {
+	t.Helper()
+	select {
+	case res := <-s.ch:
+		return res
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for a result")
+		return nil
+	}
+}
+
+func TestAgent_RepeatedCommandAnsweredFromCache(t *testing.T) {
+	fake := &fakeRuntime{}
+	a := New(nil, "worker-1", NewExecutor(fake))
+	sink := newResultSink()
+
+	cmd := &workerpb.Command{
+		CommandId: "stop-1",
+		Type:      workerpb.CommandType_COMMAND_TYPE_STOP_POD,
+		Payload:   []byte(`{"name":"p1"}`),
+	}
+	a.runUnary(context.Background(), cmd, sink.send)
+	a.runUnary(context.Background(), cmd, sink.send)
+
+	for range 2 {
+		if res := sink.next(t); !res.GetSuccess() || res.GetCommandId() != "stop-1" {
+			t.Errorf("unexpected result %+v", res)
+		}
+	}
+	if len(fake.stopped) != 1 {
+		t.Errorf("pod stopped %d times, want once", len(fake.stopped))
+	}
+}
+
+func TestAgent_CommandTimeout(t *testing.T) {
+	a := New(nil, "worker-1", NewExecutor(&fakeRuntime{}))
+	sink := newResultSink()
+
+	a.runUnary(context.Background(), &workerpb.Command{
+		CommandId: "pull-1",
+		Type:      workerpb.CommandType_COMMAND_TYPE_PULL_IMAGE,
+		Payload:   []byte(`{"image":"slow"}`),
+		TimeoutMs: 20,
+	}, sink.send)
+
+	if res := sink.next(t); res.GetSuccess() || !strings.Contains(res.GetError(), "deadline exceeded") {
+		t.Errorf("expected a deadline error, got %+v", res)
+	}
+}
+
+func TestAgent_CancelStopsFollowedLog(t *testing.T) {
+	a := New(nil, "worker-1", NewExecutor(&fakeRuntime{}))
+	sink := newResultSink()
+
+	go a.runStreamed(context.Background(), &workerpb.Command{
+		CommandId: "logs-1",
+		Type:      workerpb.CommandType_COMMAND_TYPE_POD_LOGS,
+		Payload:   []byte(`{"name":"known","follow":true}`),
+	}, sink.send)
+
+	// Wait until the log is being followed, then cancel it as the control plane would.
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		payload, _ := json.Marshal(remote.CancelRequest{CommandID: "logs-1"})
+		a.cancelCommand(context.Background(), &workerpb.Command{Type: workerpb.CommandType_COMMAND_TYPE_CANCEL, Payload: payload})
+		select {
+		case res := <-sink.ch:
+			if res.GetSuccess() || !res.GetEndOfStream() || !strings.Contains(res.GetError(), "canceled") {
+				t.Errorf("expected a cancelled end-of-stream result, got %+v", res)
+			}
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("followed log was not cancelled")
+		}
+	}
+}
+
+func TestFrameWriter_SplitsLargeOutput(t *testing.T) {
+	var chunks [][]byte
+	fw := newFrameWriter(func(chunk []byte) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+
+	payload := strings.Repeat("x", 2*maxFrameSize+10)
+	if _, err := fw.Write([]byte(payload)); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := fw.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	var total strings.Builder
+	for _, c := range chunks {
+		if len(c) > maxFrameSize {
+			t.Errorf("chunk of %d bytes exceeds maxFrameSize", len(c))
+		}
+		total.Write(c)
+	}
+	if len(chunks) != 3 || total.String() != payload {
+		t.Errorf("got %d chunks totalling %d bytes", len(chunks), total.Len())
+	}
+}
+
+func TestFrameWriter_EmitErrorStopsWrites(t *testing.T) {
+	fw := newFrameWriter(func([]byte) error { return errors.New("stream closed") })
+
+	if _, err := fw.Write([]byte(strings.Repeat("x", maxFrameSize))); err == nil {
+		t.Error("expected the emit error from Write")
+	}
+	if _, err := fw.Write([]byte("more")); err == nil {
+		t.Error("expected later writes to fail")
+	}
+	if err := fw.Close(); err == nil {
+		t.Error("expected Close to report the emit error")
+	}
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// End-to-end: RemoteRuntime → Registry → Gateway → Agent → fake runtime
+// ──────────────────────────────────────────────────────────────────────────────
+
+// startGateway serves a real gateway on bufconn and returns a client connection to it.
+func startGateway(t *testing.T, reg *registry.Registry) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(bufSize)
+	srv := grpc.NewServer()
+	workerpb.RegisterWorkerGatewayServer(srv, gateway.New(reg, nil, nil))
+	go srv.Serve(lis) //nolint:errcheck
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient: %v", err)
+	}
+
+	t.Cleanup(func() {
+		conn.Close() //nolint:errcheck
+		srv.Stop()
+		lis.Close() //nolint:errcheck
+	})
+
+	return conn
+}
+
+func TestAgent_ServesRemoteRuntimeCalls(t *testing.T) {
+	reg := registry.New(nil, nil)
+	if _, err := reg.Register(context.Background(), "worker-1", "podman", nil, nil); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	conn := startGateway(t, reg)
+
+	fake := &fakeRuntime{pods: []types.Pod{{ID: "p1", Name: "app--vllm"}}}
+	a := New(conn, "worker-1", NewExecutor(fake))
+	a.SetHeartbeatInterval(50 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- a.Run(ctx) }()
+
+	rt, err := remote.NewRemoteRuntime(reg, "worker-1", types.RuntimeTypePodman)
+	if err != nil {
+		t.Fatalf("NewRemoteRuntime: %v", err)
+	}
+
+	// Commands issued before the stream is up are queued on the worker's CommandCh.
+	info, err := rt.GetSystemInfo()
+	if err != nil {
+		t.Fatalf("GetSystemInfo: %v", err)
+	}
+	if info.CPU.Total != 4 {
+		t.Errorf("CPU total = %d", info.CPU.Total)
+	}
+
+	pods, err := rt.ListPods(nil)
+	if err != nil {
+		t.Fatalf("ListPods: %v", err)
+	}
+	if len(pods) != 1 || pods[0].Name != "app--vllm" {
+		t.Errorf("pods = %+v", pods)
+	}
+
+	if err := rt.PullImage(context.Background(), "registry/img:1"); err != nil {
+		t.Fatalf("PullImage: %v", err)
+	}
+	if fake.lastImage != "registry/img:1" {
+		t.Errorf("image pulled = %q", fake.lastImage)
+	}
+
+	ok, err := rt.PodExists("known")
+	if err != nil || !ok {
+		t.Errorf("PodExists = %v, %v", ok, err)
+	}
+
+	var logs strings.Builder
+	if err := rt.PodLogs(context.Background(), "known", &logs, types.LogOptions{Tail: 10}); err != nil {
+		t.Fatalf("PodLogs: %v", err)
+	}
+	if logs.String() != "hello from known\n" || fake.logOpts.Tail != 10 {
+		t.Errorf("logs = %q, options = %+v", logs.String(), fake.logOpts)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run returned %v on clean shutdown", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+}
+
+func TestAgent_UnknownWorkerRequiresReregistration(t *testing.T) {
+	reg := registry.New(nil, nil)
+	conn := startGateway(t, reg)
+
+	a := New(conn, "ghost", NewExecutor(&fakeRuntime{}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := a.Run(ctx); !errors.Is(err, ErrReregistrationRequired) {
+		t.Fatalf("expected ErrReregistrationRequired, got %v", err)
+	}
+}
+
+func TestJoin_RejectsUnknownToken(t *testing.T) {
+	reg := registry.New(nil, nil)
+	conn := startGateway(t, reg)
+
+	if _, err := Join(context.Background(), conn, JoinOptions{Token: "bogus", RuntimeType: "podman"}); err == nil {
+		t.Fatal("expected Join to fail with an unknown token")
+	}
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// Mutual TLS and certificate renewal
+// ──────────────────────────────────────────────────────────────────────────────
+
+// startTLSGateway serves a gateway with a fresh CA on bufconn and returns a dial
+// func that connects with the given client TLS config.
+func startTLSGateway(t *testing.T, reg *registry.Registry) (*pki.CA, func(*tls.Config) *grpc.ClientConn) {
+	t.Helper()
+
+	ca, err := pki.NewCA()
+	if err != nil {
+		t.Fatalf("NewCA: %v", err)
+	}
+	serverTLS, err := ca.ServerTLSConfig([]string{"gateway.test"})
+	if err != nil {
+		t.Fatalf("ServerTLSConfig: %v", err)
+	}
+
+	lis := bufconn.Listen(bufSize)
+	srv := grpc.NewServer(grpc.Creds(credentials.NewTLS(serverTLS)))
+	workerpb.RegisterWorkerGatewayServer(srv, gateway.New(reg, ca, []string{"gateway.test"}))
+	go srv.Serve(lis) //nolint:errcheck
+	t.Cleanup(func() {
+		srv.Stop()
+		lis.Close() //nolint:errcheck
+	})
+
+	dial := func(cfg *tls.Config) *grpc.ClientConn {
+		cfg.ServerName = "gateway.test"
+		conn, err := grpc.NewClient(
+			"passthrough:///bufnet",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return lis.DialContext(ctx)
+			}),
+			grpc.WithTransportCredentials(credentials.NewTLS(cfg)),
+		)
+		if err != nil {
+			t.Fatalf("grpc.NewClient: %v", err)
+		}
+		t.Cleanup(func() { conn.Close() }) //nolint:errcheck
+
+		return conn
+	}
+
+	return ca, dial
+}
+
+func TestAgent_TLS_JoinRunAndRenew(t *testing.T) {
+	reg := registry.New(nil, nil)
+	ca, dial := startTLSGateway(t, reg)
+
+	// A worker without a client certificate can still reach Register over TLS;
+	// here it is rejected only because of its token.
+	joinConn := dial(&tls.Config{RootCAs: ca.Pool(), MinVersion: tls.VersionTLS12})
+	if _, err := Join(context.Background(), joinConn, JoinOptions{Token: "bogus", RuntimeType: "podman"}); err == nil || status.Code(err) == codes.Unavailable {
+		t.Fatalf("expected Join to reach the gateway and be rejected for its token, got %v", err)
+	}
+
+	// registry.New(nil, nil) cannot preregister, so register directly and issue the
+	// certificate the gateway would have returned.
+	if _, err := reg.Register(context.Background(), "worker-1", "podman", nil, nil); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	certPEM, keyPEM, err := ca.IssueClientCert("worker-1")
+	if err != nil {
+		t.Fatalf("IssueClientCert: %v", err)
+	}
+
+	kp, err := pki.NewKeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatalf("NewKeyPair: %v", err)
+	}
+	clientTLS, err := pki.ClientTLSConfig(ca.CertPEM(), kp.GetClientCertificate)
+	if err != nil {
+		t.Fatalf("ClientTLSConfig: %v", err)
+	}
+
+	a := New(dial(clientTLS), "worker-1", NewExecutor(&fakeRuntime{}))
+	a.SetHeartbeatInterval(50 * time.Millisecond)
+	// Pretend most of the certificate lifetime has elapsed so renewal is due immediately.
+	a.now = func() time.Time { return time.Now().Add(25 * 24 * time.Hour) }
+
+	renewed := make(chan *workerpb.RegisterResponse, 1)
+	a.EnableCertificateRenewal(kp, func(resp *workerpb.RegisterResponse) error {
+		select {
+		case renewed <- resp:
+		default:
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go a.Run(ctx) //nolint:errcheck
+
+	// Commands flow over the mutually authenticated stream.
+	rt, err := remote.NewRemoteRuntime(reg, "worker-1", types.RuntimeTypePodman)
+	if err != nil {
+		t.Fatalf("NewRemoteRuntime: %v", err)
+	}
+	if _, err := rt.GetSystemInfo(); err != nil {
+		t.Fatalf("GetSystemInfo over mTLS: %v", err)
+	}
+
+	select {
+	case resp := <-renewed:
+		newCert, err := pki.ParseCertificatePEM([]byte(resp.GetTlsCertPem()))
+		if err != nil {
+			t.Fatalf("ParseCertificatePEM: %v", err)
+		}
+		if kp.Leaf().SerialNumber.Cmp(newCert.SerialNumber) != 0 {
+			t.Error("expected the renewed certificate to be installed in the key pair")
+		}
+		if newCert.Subject.CommonName != "worker-1" {
+			t.Errorf("renewed CN = %q", newCert.Subject.CommonName)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("certificate was not renewed")
+	}
+}
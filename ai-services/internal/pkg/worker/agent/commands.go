@@ -0,0 +1,115 @@
+package agent
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	workerpb "github.com/project-ai-services/ai-services/internal/pkg/worker/proto"
+)
+
+// resultCacheTTL is how long the result of a finished command is kept. It comfortably
+// covers a reconnect with backoff (at most maxReconnectDelay) and the control plane's
+// resend, so a command interrupted by a dropped stream is answered without running twice.
+const resultCacheTTL = 10 * time.Minute
+
+// commandTracker keeps the commands the worker is running, so they can be cancelled,
+// and the results of finished unary commands, keyed by command_id.
+type commandTracker struct {
+	mu       sync.Mutex
+	commands map[string]*trackedCommand
+	now      func() time.Time
+}
+
+// trackedCommand is a running or finished command.
+type trackedCommand struct {
+	cancel context.CancelFunc
+	// cached is set for unary commands, whose result is kept after they finish.
+	cached bool
+	// done is closed once res is set.
+	done     chan struct{}
+	res      *workerpb.CommandResult
+	finished time.Time
+}
+
+func newCommandTracker() *commandTracker {
+	return &commandTracker{commands: make(map[string]*trackedCommand), now: time.Now}
+}
+
+// begin registers a command about to run with cancel as its cancel function. It returns
+// false together with the existing entry if the command is already running or finished,
+// in which case the caller must not run it again but wait for the entry's result.
+func (t *commandTracker) begin(id string, cancel context.CancelFunc, cached bool) (*trackedCommand, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.evictLocked()
+
+	if c, ok := t.commands[id]; ok {
+		return c, false
+	}
+
+	c := &trackedCommand{cancel: cancel, cached: cached, done: make(chan struct{})}
+	t.commands[id] = c
+
+	return c, true
+}
+
+// finish records the result of a command. Streamed commands are forgotten at once.
+func (t *commandTracker) finish(id string, c *trackedCommand, res *workerpb.CommandResult) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	c.res = res
+	c.finished = t.now()
+	close(c.done)
+
+	if !c.cached {
+		delete(t.commands, id)
+	}
+}
+
+// cancel stops a running command. It reports whether the command was running.
+func (t *commandTracker) cancel(id string) bool {
+	t.mu.Lock()
+	c, ok := t.commands[id]
+	t.mu.Unlock()
+
+	if !ok || c.isDone() {
+		return false
+	}
+	c.cancel()
+
+	return true
+}
+
+// evictLocked drops the results that have outlived resultCacheTTL. t.mu must be held.
+func (t *commandTracker) evictLocked() {
+	now := t.now()
+	for id, c := range t.commands {
+		if !c.finished.IsZero() && now.Sub(c.finished) > resultCacheTTL {
+			delete(t.commands, id)
+		}
+	}
+}
+
+// isDone reports whether the command has finished.
+func (c *trackedCommand) isDone() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// commandContext derives the context a command runs in, bounded by its timeout_ms.
+func commandContext(ctx context.Context, cmd *workerpb.Command) (context.Context, context.CancelFunc) {
+	if ms := cmd.GetTimeoutMs(); ms > 0 {
+		return context.WithTimeout(ctx, time.Duration(ms)*time.Millisecond)
+	}
+
+	return context.WithCancel(ctx)
+}
+
+// Made with Bob
@@ -0,0 +1,429 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/remote"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/types"
+	workerpb "github.com/project-ai-services/ai-services/internal/pkg/worker/proto"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// handlerFunc executes one command type. It returns the value to JSON-encode into
+// CommandResult.data, or nil for commands without a result body.
+type handlerFunc func(ctx context.Context, payload []byte) (any, error)
+
+// streamHandlerFunc executes a command whose output is streamed back to the control
+// plane: everything written to w is sent as CommandResult frames.
+type streamHandlerFunc func(ctx context.Context, payload []byte, w io.Writer) error
+
+// Executor decodes gateway Commands and runs them against a local runtime.
+type Executor struct {
+	rt             runtime.Runtime
+	httpClient     *http.Client
+	handlers       map[workerpb.CommandType]handlerFunc
+	streamHandlers map[workerpb.CommandType]streamHandlerFunc
+}
+
+// NewExecutor returns an Executor that runs commands against rt.
+func NewExecutor(rt runtime.Runtime) *Executor {
+	e := &Executor{
+		rt: rt,
+		// Redirects are returned to the caller as-is instead of being followed here.
+		httpClient: &http.Client{
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+	}
+	e.handlers = e.buildHandlers()
+	e.streamHandlers = e.buildStreamHandlers()
+
+	return e
+}
+
+// Execute runs cmd and always returns a CommandResult carrying the same command_id.
+// Failures are reported in the result rather than as a Go error, so the control
+// plane can surface them to the original caller. The output of streamed commands
+// (logs, exec) is passed to emit in chunks before the final result is returned.
+func (e *Executor) Execute(ctx context.Context, cmd *workerpb.Command, emit func(chunk []byte) error) *workerpb.CommandResult {
+	if handler, ok := e.streamHandlers[cmd.GetType()]; ok {
+		return executeStream(ctx, cmd, handler, emit)
+	}
+
+	res := &workerpb.CommandResult{CommandId: cmd.GetCommandId()}
+
+	handler, ok := e.handlers[cmd.GetType()]
+	if !ok {
+		res.Error = fmt.Sprintf("unsupported command type %s", cmd.GetType())
+
+		return res
+	}
+
+	out, err := runHandler(ctx, handler, cmd.GetPayload())
+	if err != nil {
+		res.Error = err.Error()
+
+		return res
+	}
+
+	if out != nil {
+		data, err := json.Marshal(out)
+		if err != nil {
+			res.Error = fmt.Sprintf("failed to encode result: %v", err)
+
+			return res
+		}
+		res.Data = data
+	}
+	res.Success = true
+
+	return res
+}
+
+// IsStreamed reports whether commands of type t stream their output.
+func (e *Executor) IsStreamed(t workerpb.CommandType) bool {
+	_, ok := e.streamHandlers[t]
+
+	return ok
+}
+
+// Capabilities returns the protocol version and the command types the executor runs,
+// in ascending order. COMMAND_TYPE_CANCEL is served by the Agent itself.
+func (e *Executor) Capabilities() *workerpb.Capabilities {
+	commands := make([]workerpb.CommandType, 0, len(e.handlers)+len(e.streamHandlers)+1)
+	for t := range e.handlers {
+		commands = append(commands, t)
+	}
+	for t := range e.streamHandlers {
+		commands = append(commands, t)
+	}
+	commands = append(commands, workerpb.CommandType_COMMAND_TYPE_CANCEL)
+	slices.Sort(commands)
+
+	return &workerpb.Capabilities{ProtocolVersion: workerpb.ProtocolVersion, SupportedCommands: commands}
+}
+
+// runHandler runs a unary handler and gives up once ctx ends, i.e. when the command
+// times out or is cancelled. Runtime calls that take no context cannot be interrupted;
+// they finish in the background and their result is discarded.
+func runHandler(ctx context.Context, handler handlerFunc, payload []byte) (any, error) {
+	type outcome struct {
+		out any
+		err error
+	}
+
+	done := make(chan outcome, 1)
+	go func() {
+		out, err := handler(ctx, payload)
+		done <- outcome{out: out, err: err}
+	}()
+
+	select {
+	case o := <-done:
+		return o.out, o.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("command aborted: %w", ctx.Err())
+	}
+}
+
+// executeStream runs a streamed command. The final result is marked end_of_stream
+// once all output has been emitted.
+func executeStream(ctx context.Context, cmd *workerpb.Command, handler streamHandlerFunc, emit func([]byte) error) *workerpb.CommandResult {
+	res := &workerpb.CommandResult{CommandId: cmd.GetCommandId(), EndOfStream: true}
+
+	w := newFrameWriter(emit)
+	err := handler(ctx, cmd.GetPayload(), w)
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		res.Error = err.Error()
+
+		return res
+	}
+	res.Success = true
+
+	return res
+}
+
+// decode unmarshals a command payload into v. An empty payload leaves v untouched.
+func decode(payload []byte, v any) error {
+	if len(payload) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(payload, v); err != nil {
+		return fmt.Errorf("invalid payload: %w", err)
+	}
+
+	return nil
+}
+
+// named wraps a handler for commands whose payload is a remote.NameRequest.
+func named(fn func(name string) (any, error)) handlerFunc {
+	return func(_ context.Context, payload []byte) (any, error) {
+		var req remote.NameRequest
+		if err := decode(payload, &req); err != nil {
+			return nil, err
+		}
+
+		return fn(req.Name)
+	}
+}
+
+// exists adapts a *Exists runtime method to an ExistsResponse.
+func exists(fn func(string) (bool, error)) handlerFunc {
+	return named(func(name string) (any, error) {
+		ok, err := fn(name)
+		if err != nil {
+			return nil, err
+		}
+
+		return remote.ExistsResponse{Exists: ok}, nil
+	})
+}
+
+// noResult adapts a runtime method that only returns an error.
+func noResult(fn func(string) error) handlerFunc {
+	return named(func(name string) (any, error) {
+		return nil, fn(name)
+	})
+}
+
+// logs adapts PodLogs/ContainerLogs to a stream handler.
+func logs(fn func(ctx context.Context, name string, w io.Writer, opts types.LogOptions) error) streamHandlerFunc {
+	return func(ctx context.Context, payload []byte, w io.Writer) error {
+		var req remote.LogsRequest
+		if err := decode(payload, &req); err != nil {
+			return err
+		}
+
+		return fn(ctx, req.Name, w, req.LogOptions())
+	}
+}
+
+// buildHandlers maps each supported CommandType to its handler.
+func (e *Executor) buildHandlers() map[workerpb.CommandType]handlerFunc {
+	return map[workerpb.CommandType]handlerFunc{
+		workerpb.CommandType_COMMAND_TYPE_LIST_IMAGES: func(context.Context, []byte) (any, error) {
+			return e.rt.ListImages()
+		},
+		workerpb.CommandType_COMMAND_TYPE_PULL_IMAGE:        e.pullImage,
+		workerpb.CommandType_COMMAND_TYPE_LIST_PODS:         e.listPods,
+		workerpb.CommandType_COMMAND_TYPE_CREATE_POD:        e.createPod,
+		workerpb.CommandType_COMMAND_TYPE_DELETE_POD:        e.deletePod,
+		workerpb.CommandType_COMMAND_TYPE_STOP_POD:          noResult(e.rt.StopPod),
+		workerpb.CommandType_COMMAND_TYPE_START_POD:         noResult(e.rt.StartPod),
+		workerpb.CommandType_COMMAND_TYPE_INSPECT_POD:       named(func(n string) (any, error) { return e.rt.InspectPod(n) }),
+		workerpb.CommandType_COMMAND_TYPE_POD_EXISTS:        exists(e.rt.PodExists),
+		workerpb.CommandType_COMMAND_TYPE_GET_POD_RESOURCES: named(func(n string) (any, error) { return e.rt.GetPodResources(n) }),
+		workerpb.CommandType_COMMAND_TYPE_GET_NAMESPACE:     e.getNamespace,
+		workerpb.CommandType_COMMAND_TYPE_LIST_SECRETS:      e.listSecrets,
+		workerpb.CommandType_COMMAND_TYPE_DELETE_SECRET:     noResult(e.rt.DeleteSecret),
+		workerpb.CommandType_COMMAND_TYPE_SECRET_EXISTS:     exists(e.rt.SecretExists),
+		workerpb.CommandType_COMMAND_TYPE_UPDATE_SECRET:     e.updateSecret,
+		workerpb.CommandType_COMMAND_TYPE_DELETE_VOLUME:     noResult(e.rt.DeleteVolume),
+		workerpb.CommandType_COMMAND_TYPE_VOLUME_EXISTS:     exists(e.rt.VolumeExists),
+		workerpb.CommandType_COMMAND_TYPE_INSPECT_CONTAINER: named(func(n string) (any, error) { return e.rt.InspectContainer(n) }),
+		workerpb.CommandType_COMMAND_TYPE_CONTAINER_EXISTS:  exists(e.rt.ContainerExists),
+		workerpb.CommandType_COMMAND_TYPE_LIST_ROUTES:       e.listRoutes,
+		workerpb.CommandType_COMMAND_TYPE_LIST_CRD:          e.listCRD,
+		workerpb.CommandType_COMMAND_TYPE_DELETE_NAMESPACE:  noResult(e.rt.DeleteNamespace),
+		workerpb.CommandType_COMMAND_TYPE_DELETE_PVCS:       e.deletePVCs,
+		workerpb.CommandType_COMMAND_TYPE_GET_SYSTEM_INFO: func(context.Context, []byte) (any, error) {
+			return e.rt.GetSystemInfo()
+		},
+		workerpb.CommandType_COMMAND_TYPE_RUNTIME_TYPE: func(context.Context, []byte) (any, error) {
+			return remote.RuntimeTypeResponse{RuntimeType: e.rt.Type()}, nil
+		},
+	}
+}
+
+// buildStreamHandlers maps each CommandType whose output is streamed to its handler.
+func (e *Executor) buildStreamHandlers() map[workerpb.CommandType]streamHandlerFunc {
+	return map[workerpb.CommandType]streamHandlerFunc{
+		workerpb.CommandType_COMMAND_TYPE_POD_LOGS:          logs(e.rt.PodLogs),
+		workerpb.CommandType_COMMAND_TYPE_CONTAINER_LOGS:    logs(e.rt.ContainerLogs),
+		workerpb.CommandType_COMMAND_TYPE_EXEC_IN_CONTAINER: e.execInContainer,
+		workerpb.CommandType_COMMAND_TYPE_HTTP_PROXY:        e.httpProxy,
+	}
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// Handlers with dedicated payloads
+// ──────────────────────────────────────────────────────────────────────────────
+
+func (e *Executor) pullImage(ctx context.Context, payload []byte) (any, error) {
+	var req remote.PullImageRequest
+	if err := decode(payload, &req); err != nil {
+		return nil, err
+	}
+
+	return nil, e.rt.PullImage(ctx, req.Image)
+}
+
+func (e *Executor) listPods(_ context.Context, payload []byte) (any, error) {
+	var req remote.FiltersRequest
+	if err := decode(payload, &req); err != nil {
+		return nil, err
+	}
+
+	return e.rt.ListPods(req.Filters)
+}
+
+func (e *Executor) createPod(ctx context.Context, payload []byte) (any, error) {
+	var req remote.CreatePodRequest
+	if err := decode(payload, &req); err != nil {
+		return nil, err
+	}
+
+	return e.rt.CreatePod(ctx, bytes.NewReader(req.Body), req.Opts)
+}
+
+func (e *Executor) deletePod(_ context.Context, payload []byte) (any, error) {
+	var req remote.DeletePodRequest
+	if err := decode(payload, &req); err != nil {
+		return nil, err
+	}
+
+	return nil, e.rt.DeletePod(req.Name, req.Force)
+}
+
+func (e *Executor) getNamespace(context.Context, []byte) (any, error) {
+	ns, err := e.rt.GetNamespace()
+	if err != nil {
+		return nil, err
+	}
+
+	return remote.NamespaceResponse{Namespace: ns}, nil
+}
+
+func (e *Executor) listSecrets(_ context.Context, payload []byte) (any, error) {
+	var req remote.FiltersRequest
+	if err := decode(payload, &req); err != nil {
+		return nil, err
+	}
+
+	secrets, err := e.rt.ListSecrets(req.Filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return remote.SecretsResponse{Secrets: secrets}, nil
+}
+
+func (e *Executor) updateSecret(_ context.Context, payload []byte) (any, error) {
+	var req remote.UpdateSecretRequest
+	if err := decode(payload, &req); err != nil {
+		return nil, err
+	}
+
+	return nil, e.rt.UpdateSecret(req.Name, req.DeploymentName, req.Data)
+}
+
+func (e *Executor) execInContainer(ctx context.Context, payload []byte, w io.Writer) error {
+	var req remote.ExecRequest
+	if err := decode(payload, &req); err != nil {
+		return err
+	}
+
+	return e.rt.ExecInContainerWithCmd(ctx, req.PodName, req.ContainerName, req.Command, w)
+}
+
+func (e *Executor) listRoutes(_ context.Context, payload []byte) (any, error) {
+	var req remote.ListRoutesRequest
+	if err := decode(payload, &req); err != nil {
+		return nil, err
+	}
+
+	return e.rt.ListRoutes(req.LabelSelector)
+}
+
+func (e *Executor) listCRD(_ context.Context, payload []byte) (any, error) {
+	var req remote.ListCRDRequest
+	if err := decode(payload, &req); err != nil {
+		return nil, err
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetAPIVersion(req.APIVersion)
+	list.SetKind(req.Kind)
+
+	resources, err := e.rt.ListCRD(list, req.Filters)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := list.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s list: %w", req.Kind, err)
+	}
+
+	return remote.ListCRDResponse{List: raw, Resources: resources}, nil
+}
+
+func (e *Executor) deletePVCs(_ context.Context, payload []byte) (any, error) {
+	var req remote.DeletePVCsRequest
+	if err := decode(payload, &req); err != nil {
+		return nil, err
+	}
+
+	return nil, e.rt.DeletePVCs(req.AppLabel)
+}
+
+// httpProxy sends the request to the pod and streams back the response head as one
+// JSON line followed by the body, so event streams reach the caller as they are produced.
+func (e *Executor) httpProxy(ctx context.Context, payload []byte, w io.Writer) error {
+	var req remote.HTTPProxyRequest
+	if err := decode(payload, &req); err != nil {
+		return err
+	}
+
+	target := url.URL{Scheme: "http", Host: e.podAddress(req.Pod, req.Port), Path: req.Path, RawQuery: req.RawQuery}
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, target.String(), bytes.NewReader(req.Body))
+	if err != nil {
+		return fmt.Errorf("invalid proxy request: %w", err)
+	}
+	if req.Header != nil {
+		httpReq.Header = req.Header
+	}
+
+	resp, err := e.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to reach pod %s on port %s: %w", req.Pod, req.Port, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	head, err := json.Marshal(remote.HTTPProxyResponse{StatusCode: resp.StatusCode, Header: resp.Header})
+	if err != nil {
+		return fmt.Errorf("failed to encode response head: %w", err)
+	}
+	if _, err := w.Write(append(head, '\n')); err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, resp.Body)
+
+	return err
+}
+
+// podAddress returns the address the worker reaches port of pod on: the host port it
+// is published on when there is one, otherwise the pod name on the pod network.
+func (e *Executor) podAddress(pod, port string) string {
+	if info, err := e.rt.InspectPod(pod); err == nil && info != nil {
+		if hostPorts := info.Ports[port+"/tcp"]; len(hostPorts) > 0 && hostPorts[0] != "" {
+			return net.JoinHostPort("127.0.0.1", hostPorts[0])
+		}
+	}
+
+	return net.JoinHostPort(pod, port)
+}
+
+// Made with Bob
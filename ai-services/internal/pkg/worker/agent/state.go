@@ -0,0 +1,101 @@
+package agent
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// stateDirName is the subdirectory under os.UserConfigDir() used by ai-services.
+	stateDirName = "ai-services"
+	// stateFileName is the name of the worker identity file written by `worker join`.
+	stateFileName = "worker-state.json"
+)
+
+// ErrNotJoined is returned when no worker state is found on disk.
+var ErrNotJoined = errors.New("worker has not joined a control plane: run 'ai-services worker join' first")
+
+// State is the identity a worker receives from the control plane at join time.
+// It is persisted so `worker run` can reconnect without a new bootstrap token.
+type State struct {
+	// WorkerName is the name assigned by the control plane (taken from the token).
+	WorkerName string `json:"worker_name"`
+	// GatewayAddr is the host:port of the WorkerGateway gRPC endpoint.
+	GatewayAddr string `json:"gateway_addr"`
+	// RuntimeType is the local runtime this worker executes commands against.
+	RuntimeType string `json:"runtime_type"`
+	// TLSCertPEM and TLSKeyPEM are the client certificate (CN = WorkerName) and key
+	// issued by the control plane CA. They are replaced in place when renewed.
+	TLSCertPEM string `json:"tls_cert_pem,omitempty"`
+	TLSKeyPEM  string `json:"tls_key_pem,omitempty"`
+	// CACertPEM is the control plane CA certificate used to verify the gateway.
+	CACertPEM string `json:"ca_cert_pem,omitempty"`
+}
+
+// statePath returns the absolute path to the worker state file.
+func statePath() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine user config directory: %w", err)
+	}
+
+	return filepath.Join(base, stateDirName, stateFileName), nil
+}
+
+// SaveState persists the worker state to disk, creating the config directory if needed.
+func SaveState(state State) error {
+	const (
+		stateDirPerm  = 0o700
+		stateFilePerm = 0o600
+	)
+
+	path, err := statePath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), stateDirPerm); err != nil {
+		return fmt.Errorf("create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal worker state: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, stateFilePerm); err != nil {
+		return fmt.Errorf("write worker state file: %w", err)
+	}
+
+	return nil
+}
+
+// LoadState reads the worker state from disk.
+// Returns ErrNotJoined if the file does not exist.
+func LoadState() (State, error) {
+	path, err := statePath()
+	if err != nil {
+		return State{}, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return State{}, ErrNotJoined
+		}
+
+		return State{}, fmt.Errorf("read worker state file: %w", err)
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return State{}, fmt.Errorf("parse worker state file: %w", err)
+	}
+
+	return state, nil
+}
+
+// Made with Bob
@@ -0,0 +1,109 @@
+package agent
+
+import (
+	"bytes"
+	"sync"
+	"time"
+)
+
+const (
+	// maxFrameSize caps the output carried by a single result frame.
+	maxFrameSize = 32 * 1024
+
+	// frameFlushInterval bounds how long buffered output waits before it is sent,
+	// so a followed log reaches the caller promptly even when it is quiet.
+	frameFlushInterval = 200 * time.Millisecond
+)
+
+// frameWriter collects the output of a streamed command and emits it in chunks of at
+// most maxFrameSize bytes, whenever the buffer fills and every frameFlushInterval.
+// Coalescing writes keeps a chatty log from producing one gRPC message per line.
+// Once emit fails every further Write returns that error, which stops the runtime.
+type frameWriter struct {
+	mu   sync.Mutex
+	buf  bytes.Buffer
+	emit func(chunk []byte) error
+	err  error
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// newFrameWriter returns a frameWriter that sends its chunks through emit.
+// Close must be called to flush the remaining output.
+func newFrameWriter(emit func(chunk []byte) error) *frameWriter {
+	fw := &frameWriter{
+		emit: emit,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	go fw.flushLoop()
+
+	return fw
+}
+
+// Write buffers p and emits every full frame.
+func (fw *frameWriter) Write(p []byte) (int, error) {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+
+	if fw.err != nil {
+		return 0, fw.err
+	}
+
+	fw.buf.Write(p)
+	for fw.buf.Len() >= maxFrameSize && fw.err == nil {
+		fw.emitLocked()
+	}
+	if fw.err != nil {
+		return 0, fw.err
+	}
+
+	return len(p), nil
+}
+
+// Close stops the periodic flush, emits the remaining output and reports the first
+// emit error, if any.
+func (fw *frameWriter) Close() error {
+	close(fw.stop)
+	<-fw.done
+
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	fw.flushLocked()
+
+	return fw.err
+}
+
+func (fw *frameWriter) flushLoop() {
+	defer close(fw.done)
+
+	ticker := time.NewTicker(frameFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-fw.stop:
+			return
+		case <-ticker.C:
+			fw.mu.Lock()
+			fw.flushLocked()
+			fw.mu.Unlock()
+		}
+	}
+}
+
+// flushLocked emits everything buffered. fw.mu must be held.
+func (fw *frameWriter) flushLocked() {
+	for fw.buf.Len() > 0 && fw.err == nil {
+		fw.emitLocked()
+	}
+}
+
+// emitLocked sends the next chunk of the buffer. fw.mu must be held.
+func (fw *frameWriter) emitLocked() {
+	chunk := bytes.Clone(fw.buf.Next(maxFrameSize))
+	fw.err = fw.emit(chunk)
+}
+
+// Made with Bob
@@ -6,15 +6,19 @@ package gateway
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"time"
 
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/worker/pki"
 	workerpb "github.com/project-ai-services/ai-services/internal/pkg/worker/proto"
 	"github.com/project-ai-services/ai-services/internal/pkg/worker/registry"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -31,13 +35,18 @@ const (
 type Gateway struct {
 	workerpb.UnimplementedWorkerGatewayServer
 
-	registry   *registry.Registry
-	grpcServer *grpc.Server
+	registry    *registry.Registry
+	ca          *pki.CA
+	serverHosts []string
+	grpcServer  *grpc.Server
 }
 
 // New creates a Gateway backed by the given registry.
-func New(reg *registry.Registry) *Gateway {
-	return &Gateway{registry: reg}
+// ca issues worker client certificates and the gateway server certificate for
+// serverHosts; when ca is nil the gateway serves plaintext and does not check worker
+// identities, which is only suitable for tests.
+func New(reg *registry.Registry, ca *pki.CA, serverHosts []string) *Gateway {
+	return &Gateway{registry: reg, ca: ca, serverHosts: serverHosts}
 }
 
 // Start begins listening on addr (e.g. ":9090") and serves gRPC in a background goroutine.
@@ -50,7 +59,20 @@ func (g *Gateway) Start(ctx context.Context, cancel context.CancelCauseFunc, add
 		return fmt.Errorf("worker gateway: listen on %s: %w", addr, err)
 	}
 
-	g.grpcServer = grpc.NewServer()
+	var serverOpts []grpc.ServerOption
+	if g.ca != nil {
+		tlsConfig, err := g.ca.ServerTLSConfig(g.serverHosts)
+		if err != nil {
+			lis.Close() //nolint:errcheck
+
+			return fmt.Errorf("worker gateway: TLS setup: %w", err)
+		}
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	} else {
+		logger.WarningfCtx(ctx, "WorkerGateway: no CA configured — serving without TLS")
+	}
+
+	g.grpcServer = grpc.NewServer(serverOpts...)
 	workerpb.RegisterWorkerGatewayServer(g.grpcServer, g)
 
 	go func() {
@@ -98,27 +120,107 @@ func (g *Gateway) runSweeper(ctx context.Context) {
 // The worker name is taken from the token, not from the request — the worker
 // cannot self-assign a name different from what was pre-registered by an admin.
 // Metadata supplied in the request is persisted to the DB metadata JSON column.
+// The response carries a client certificate (CN = worker name) signed by the
+// internal CA; the worker must present it on every CommandStream.
 func (g *Gateway) Register(ctx context.Context, req *workerpb.RegisterRequest) (*workerpb.RegisterResponse, error) {
 	logger.InfofCtx(ctx, "WorkerGateway: Register request received")
 
 	// Validate token and recover the pre-registered worker name.
-	workerName, err := g.registry.ValidateToken(req.GetPreSharedToken())
+	workerName, err := g.registry.ValidateToken(ctx, req.GetPreSharedToken())
 	if err != nil {
 		logger.WarningfCtx(ctx, "WorkerGateway: rejected registration: %v", err)
 
 		return nil, fmt.Errorf("registration rejected: %w", err)
 	}
 
-	if _, err := g.registry.Register(ctx, workerName, req.GetRuntimeType(), req.GetMetadata()); err != nil {
+	if _, err := g.registry.Register(ctx, workerName, req.GetRuntimeType(), req.GetMetadata(), req.GetCapabilities()); err != nil {
 		return nil, fmt.Errorf("failed to register worker: %w", err)
 	}
 
+	resp, err := g.issueCertificate(workerName)
+	if err != nil {
+		return nil, err
+	}
+
 	logger.InfofCtx(ctx, "WorkerGateway: worker %s registered", workerName)
 
-	return &workerpb.RegisterResponse{
-		WorkerName: workerName,
-		// TlsCertPem / TlsKeyPem intentionally empty; mTLS added in a future iteration.
-	}, nil
+	return resp, nil
+}
+
+// RenewCertificate implements WorkerGatewayServer. A connected worker calls it with its
+// current, still valid client certificate to obtain a fresh one before expiry.
+func (g *Gateway) RenewCertificate(ctx context.Context, req *workerpb.RenewCertificateRequest) (*workerpb.RegisterResponse, error) {
+	if g.ca == nil {
+		return nil, status.Error(codes.FailedPrecondition, "RenewCertificate: gateway has no certificate authority")
+	}
+
+	workerName := req.GetWorkerName()
+	if err := g.authorizeWorker(ctx, workerName); err != nil {
+		return nil, err
+	}
+	if err := g.registry.CheckRegistered(ctx, workerName); err != nil {
+		return nil, registrationError("RenewCertificate", workerName, err)
+	}
+
+	resp, err := g.issueCertificate(workerName)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.InfofCtx(ctx, "WorkerGateway: renewed certificate for worker %s", workerName)
+
+	return resp, nil
+}
+
+// issueCertificate builds a RegisterResponse carrying a new client certificate for workerName.
+func (g *Gateway) issueCertificate(workerName string) (*workerpb.RegisterResponse, error) {
+	resp := &workerpb.RegisterResponse{WorkerName: workerName}
+	if g.ca == nil {
+		return resp, nil
+	}
+
+	certPEM, keyPEM, err := g.ca.IssueClientCert(workerName)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to issue certificate for worker %s: %v", workerName, err)
+	}
+	resp.TlsCertPem = string(certPEM)
+	resp.TlsKeyPem = string(keyPEM)
+	resp.CaCertPem = string(g.ca.CertPEM())
+
+	return resp, nil
+}
+
+// authorizeWorker checks that the caller presented a CA-verified client certificate
+// whose CommonName is workerName. It is a no-op when the gateway has no CA.
+func (g *Gateway) authorizeWorker(ctx context.Context, workerName string) error {
+	if g.ca == nil {
+		return nil
+	}
+
+	cn, ok := peerCommonName(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "client certificate required — call Register to obtain one")
+	}
+	if cn != workerName {
+		return status.Errorf(codes.PermissionDenied, "client certificate issued to %q cannot act as worker %q", cn, workerName)
+	}
+
+	return nil
+}
+
+// peerCommonName returns the CommonName of the verified client certificate on ctx.
+func peerCommonName(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+
+	return info.State.VerifiedChains[0][0].Subject.CommonName, true
 }
 
 // CommandStream implements WorkerGatewayServer.
@@ -126,13 +228,17 @@ func (g *Gateway) Register(ctx context.Context, req *workerpb.RegisterRequest) (
 //  1. Reads the first CommandResult to identify which worker connected.
 //  2. Routes incoming results to the waiting RemoteRuntime callers.
 //  3. Drains the worker's CommandCh and writes Commands to the stream.
+//
+// A worker that reconnects after its stream dropped resumes with the same registry
+// entry, so commands that were in flight are resent rather than lost.
 func (g *Gateway) CommandStream(stream grpc.BidiStreamingServer[workerpb.CommandResult, workerpb.Command]) error { //nolint:gocognit
 	ctx := stream.Context()
 
-	workerName, entry, err := g.identifyWorker(ctx, stream)
+	workerName, att, err := g.identifyWorker(ctx, stream)
 	if err != nil {
 		return err
 	}
+	defer g.registry.Detach(context.Background(), att)
 
 	// goroutine: read results from the worker and dispatch to waiting callers.
 	recvErrCh := make(chan error, 1)
@@ -142,38 +248,42 @@ func (g *Gateway) CommandStream(stream grpc.BidiStreamingServer[workerpb.Command
 	for {
 		select {
 		case <-ctx.Done():
-			g.registry.Disconnect(context.Background(), workerName)
 			logger.InfofCtx(ctx, "WorkerGateway: context done for worker %s", workerName)
 
 			return ctx.Err()
 
 		case err := <-recvErrCh:
-			g.registry.Disconnect(context.Background(), workerName)
 			logger.InfofCtx(ctx, "WorkerGateway: worker %s disconnected: %v", workerName, err)
 
 			return err
 
-		case cmd, ok := <-entry.CommandCh:
+		case <-att.Superseded:
+			logger.InfofCtx(ctx, "WorkerGateway: CommandStream of worker %s replaced", workerName)
+
+			return status.Errorf(codes.Aborted, "CommandStream: superseded by a newer stream of worker %s", workerName)
+
+		case cmd, ok := <-att.Entry.CommandCh:
 			if !ok {
 				return fmt.Errorf("CommandStream: command channel closed for worker %s", workerName)
 			}
+			att.Entry.MarkSent(cmd.GetCommandId())
 			if err := stream.Send(cmd); err != nil {
-				g.registry.Disconnect(context.Background(), workerName)
-
 				return fmt.Errorf("CommandStream: send to worker %s: %w", workerName, err)
 			}
 		}
 	}
 }
 
-// identifyWorker reads the first message from the stream, validates the worker is known,
-// and returns the worker name and registry entry.
+// identifyWorker reads the first message from the stream, validates the worker is registered,
+// and attaches the stream to its registry entry.
 //
 // Error codes used by the worker daemon to decide its retry strategy:
-//   - codes.Unauthenticated — worker not in registry; must call Register before retrying CommandStream.
+//   - codes.Unauthenticated — worker never registered, or no client certificate presented;
+//     must call Register before retrying CommandStream.
+//   - codes.PermissionDenied — the client certificate belongs to a different worker.
 //   - codes.InvalidArgument  — first message is malformed; worker has a bug.
 //   - any other error        — transient; retry CommandStream with backoff (no re-registration needed).
-func (g *Gateway) identifyWorker(ctx context.Context, stream grpc.BidiStreamingServer[workerpb.CommandResult, workerpb.Command]) (string, *registry.WorkerEntry, error) {
+func (g *Gateway) identifyWorker(ctx context.Context, stream grpc.BidiStreamingServer[workerpb.CommandResult, workerpb.Command]) (string, *registry.Attachment, error) {
 	firstMsg, err := stream.Recv()
 	if err != nil {
 		return "", nil, fmt.Errorf("CommandStream: failed to receive first message: %w", err)
@@ -182,15 +292,15 @@ func (g *Gateway) identifyWorker(ctx context.Context, stream grpc.BidiStreamingS
 	if workerName == "" {
 		return "", nil, status.Error(codes.InvalidArgument, "CommandStream: first message missing worker_name")
 	}
+	if err := g.authorizeWorker(ctx, workerName); err != nil {
+		logger.WarningfCtx(ctx, "WorkerGateway: rejected CommandStream for worker %s: %v", workerName, err)
 
-	entry, ok := g.registry.Get(workerName)
-	if !ok {
-		// The control plane has no in-memory entry for this worker — either it never
-		// registered or the control plane restarted and lost its registry.
-		// Return Unauthenticated so the worker knows it must call Register again
-		// before opening a new CommandStream.
-		return "", nil, status.Errorf(codes.Unauthenticated,
-			"CommandStream: worker %s not registered — call Register first", workerName)
+		return "", nil, err
+	}
+
+	att, err := g.registry.Attach(ctx, workerName, firstMsg.GetCapabilities())
+	if err != nil {
+		return "", nil, registrationError("CommandStream", workerName, err)
 	}
 
 	logger.InfofCtx(ctx, "WorkerGateway: CommandStream opened for worker %s", workerName)
@@ -202,7 +312,18 @@ func (g *Gateway) identifyWorker(ctx context.Context, stream grpc.BidiStreamingS
 		g.registry.DeliverResult(firstMsg)
 	}
 
-	return workerName, entry, nil
+	return workerName, att, nil
+}
+
+// registrationError maps a failed registry lookup of a worker to its gRPC status. A worker
+// the control plane does not know gets codes.Unauthenticated, so it calls Register again
+// before retrying; a failed lookup gets codes.Unavailable, so it retries with backoff.
+func registrationError(method, workerName string, err error) error {
+	if errors.Is(err, registry.ErrWorkerNotRegistered) {
+		return status.Errorf(codes.Unauthenticated, "%s: worker %s not registered — call Register first", method, workerName)
+	}
+
+	return status.Errorf(codes.Unavailable, "%s: failed to look up worker %s: %v", method, workerName, err)
 }
 
 // recvLoop reads CommandResults from the stream and dispatches them to waiting callers.
@@ -216,9 +337,9 @@ func (g *Gateway) recvLoop(ctx context.Context, stream grpc.BidiStreamingServer[
 
 			return
 		}
-		if res.WorkerName == "" {
-			res.WorkerName = workerName
-		}
+		// The stream is bound to the identity checked in identifyWorker; a worker
+		// cannot deliver results on behalf of another.
+		res.WorkerName = workerName
 
 		if res.GetIsHeartbeat() {
 			g.registry.UpdateHeartbeat(ctx, workerName)
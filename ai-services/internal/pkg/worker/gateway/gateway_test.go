@@ -2,6 +2,7 @@ package gateway
 
 import (
 	"context"
+	"crypto/tls"
 	"net"
 	"testing"
 	"time"
@@ -9,10 +10,14 @@ import (
 	"github.com/google/uuid"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	"github.com/project-ai-services/ai-services/internal/pkg/worker/pki"
 	workerpb "github.com/project-ai-services/ai-services/internal/pkg/worker/proto"
 	"github.com/project-ai-services/ai-services/internal/pkg/worker/registry"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 )
 
@@ -37,8 +42,10 @@ func newFakeWorkerRepo() *fakeWorkerRepo {
 func (r *fakeWorkerRepo) Upsert(_ context.Context, w *models.Worker) error {
 	if existing, ok := r.workers[w.Name]; ok {
 		w.ID = existing.ID
+		w.Schedulable = existing.Schedulable
 	} else {
 		w.ID = uuid.New()
+		w.Schedulable = true
 	}
 	w.RegisteredAt = time.Now()
 	w.UpdatedAt = time.Now()
@@ -59,6 +66,9 @@ func (r *fakeWorkerRepo) Update(_ context.Context, id uuid.UUID, u repository.Wo
 	if u.LastHeartbeat != nil {
 		w.LastHeartbeat = u.LastHeartbeat
 	}
+	if u.Schedulable != nil {
+		w.Schedulable = *u.Schedulable
+	}
 	return nil
 }
 
@@ -80,12 +90,112 @@ func (r *fakeWorkerRepo) GetAll(_ context.Context) ([]models.Worker, error) {
 	return out, nil
 }
 
+func (r *fakeWorkerRepo) GetByID(_ context.Context, id uuid.UUID) (*models.Worker, error) {
+	w, ok := r.byID[id]
+	if !ok {
+		return nil, nil
+	}
+	cp := *w
+	return &cp, nil
+}
+
+func (r *fakeWorkerRepo) GetByName(_ context.Context, name string) (*models.Worker, error) {
+	w, ok := r.workers[name]
+	if !ok {
+		return nil, nil
+	}
+	cp := *w
+	return &cp, nil
+}
+
 var _ repository.WorkerRepository = (*fakeWorkerRepo)(nil)
 
+// fakeTokenRepo is an in-memory WorkerTokenRepository. Worker names are resolved
+// through the companion fakeWorkerRepo, as the SQL implementation joins workers.
+type fakeTokenRepo struct {
+	workers *fakeWorkerRepo
+	tokens  []*models.WorkerToken
+}
+
+func newFakeTokenRepo(workers *fakeWorkerRepo) *fakeTokenRepo {
+	return &fakeTokenRepo{workers: workers}
+}
+
+func (r *fakeTokenRepo) Create(_ context.Context, t *models.WorkerToken) error {
+	t.ID = uuid.New()
+	t.CreatedAt = time.Now()
+	cp := *t
+	r.tokens = append(r.tokens, &cp)
+	return nil
+}
+
+func (r *fakeTokenRepo) GetByHash(_ context.Context, hash string) (*models.WorkerToken, error) {
+	for _, t := range r.tokens {
+		if t.TokenHash == hash {
+			cp := *t
+			if w, ok := r.workers.byID[t.WorkerID]; ok {
+				cp.WorkerName = w.Name
+			}
+			return &cp, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeTokenRepo) MarkUsed(_ context.Context, id uuid.UUID) (bool, error) {
+	for _, t := range r.tokens {
+		if t.ID == id && t.UsedAt == nil && t.RevokedAt == nil {
+			now := time.Now()
+			t.UsedAt = &now
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeTokenRepo) ListByWorker(_ context.Context, workerID uuid.UUID) ([]models.WorkerToken, error) {
+	var out []models.WorkerToken
+	for i := len(r.tokens) - 1; i >= 0; i-- {
+		if r.tokens[i].WorkerID == workerID {
+			out = append(out, *r.tokens[i])
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeTokenRepo) Revoke(_ context.Context, workerID, tokenID uuid.UUID) (bool, error) {
+	for _, t := range r.tokens {
+		if t.ID == tokenID && t.WorkerID == workerID && t.UsedAt == nil && t.RevokedAt == nil {
+			now := time.Now()
+			t.RevokedAt = &now
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeTokenRepo) RevokeAllForWorker(_ context.Context, workerID uuid.UUID) error {
+	now := time.Now()
+	for _, t := range r.tokens {
+		if t.WorkerID == workerID && t.UsedAt == nil && t.RevokedAt == nil {
+			t.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+var _ repository.WorkerTokenRepository = (*fakeTokenRepo)(nil)
+
 // ──────────────────────────────────────────────────────────────────────────────
 // Test helpers
 // ──────────────────────────────────────────────────────────────────────────────
 
+// newTestRegistry returns a registry backed by the in-memory fakes.
+func newTestRegistry() *registry.Registry {
+	repo := newFakeWorkerRepo()
+	return registry.New(repo, newFakeTokenRepo(repo))
+}
+
 // preregister calls registry.Preregister and returns the bootstrap token,
 // failing the test on any error.
 func preregister(t *testing.T, reg *registry.Registry, workerName string) string {
@@ -103,7 +213,7 @@ func startTestGateway(t *testing.T, reg *registry.Registry) (workerpb.WorkerGate
 	t.Helper()
 
 	lis := bufconn.Listen(bufSize)
-	gw := New(reg)
+	gw := New(reg, nil, nil)
 	gw.grpcServer = grpc.NewServer()
 	workerpb.RegisterWorkerGatewayServer(gw.grpcServer, gw)
 
@@ -134,7 +244,7 @@ func startTestGateway(t *testing.T, reg *registry.Registry) (workerpb.WorkerGate
 // ──────────────────────────────────────────────────────────────────────────────
 
 func TestGateway_Register_ValidToken(t *testing.T) {
-	reg := registry.New(newFakeWorkerRepo())
+	reg := newTestRegistry()
 	token := preregister(t, reg, "worker-1")
 
 	client, stop := startTestGateway(t, reg)
@@ -158,7 +268,7 @@ func TestGateway_Register_ValidToken(t *testing.T) {
 }
 
 func TestGateway_Register_InvalidToken(t *testing.T) {
-	reg := registry.New(newFakeWorkerRepo())
+	reg := newTestRegistry()
 
 	client, stop := startTestGateway(t, reg)
 	defer stop()
@@ -172,7 +282,7 @@ func TestGateway_Register_InvalidToken(t *testing.T) {
 }
 
 func TestGateway_Register_TokenSingleUse(t *testing.T) {
-	reg := registry.New(newFakeWorkerRepo())
+	reg := newTestRegistry()
 	token := preregister(t, reg, "worker-1")
 
 	client, stop := startTestGateway(t, reg)
@@ -200,7 +310,7 @@ func TestGateway_Register_TokenSingleUse(t *testing.T) {
 // ──────────────────────────────────────────────────────────────────────────────
 
 func TestGateway_CommandStream_UnregisteredWorker(t *testing.T) {
-	reg := registry.New(newFakeWorkerRepo())
+	reg := newTestRegistry()
 
 	client, stop := startTestGateway(t, reg)
 	defer stop()
@@ -222,7 +332,7 @@ func TestGateway_CommandStream_UnregisteredWorker(t *testing.T) {
 }
 
 func TestGateway_CommandStream_MissingWorkerName(t *testing.T) {
-	reg := registry.New(newFakeWorkerRepo())
+	reg := newTestRegistry()
 
 	client, stop := startTestGateway(t, reg)
 	defer stop()
@@ -244,8 +354,7 @@ func TestGateway_CommandStream_MissingWorkerName(t *testing.T) {
 }
 
 func TestGateway_CommandStream_CommandDelivered(t *testing.T) {
-	repo := newFakeWorkerRepo()
-	reg := registry.New(repo)
+	reg := newTestRegistry()
 	token := preregister(t, reg, "worker-2")
 
 	client, stop := startTestGateway(t, reg)
@@ -299,8 +408,7 @@ func TestGateway_CommandStream_CommandDelivered(t *testing.T) {
 }
 
 func TestGateway_CommandStream_ResultRouted(t *testing.T) {
-	repo := newFakeWorkerRepo()
-	reg := registry.New(repo)
+	reg := newTestRegistry()
 	token := preregister(t, reg, "worker-3")
 
 	client, stop := startTestGateway(t, reg)
@@ -360,8 +468,7 @@ func TestGateway_CommandStream_ResultRouted(t *testing.T) {
 }
 
 func TestGateway_CommandStream_Disconnect(t *testing.T) {
-	repo := newFakeWorkerRepo()
-	reg := registry.New(repo)
+	reg := newTestRegistry()
 	token := preregister(t, reg, "worker-4")
 
 	client, stop := startTestGateway(t, reg)
@@ -405,3 +512,271 @@ func TestGateway_CommandStream_Disconnect(t *testing.T) {
 		t.Error("expected worker-4 to be removed from registry after disconnect")
 	}
 }
+
+func TestGateway_CommandStream_ReconnectResendsInFlightCommand(t *testing.T) {
+	reg := newTestRegistry()
+	token := preregister(t, reg, "worker-5")
+
+	client, stop := startTestGateway(t, reg)
+	defer stop()
+
+	if _, err := client.Register(context.Background(), &workerpb.RegisterRequest{
+		PreSharedToken: token,
+		RuntimeType:    "podman",
+	}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	open := func(ctx context.Context) workerpb.WorkerGateway_CommandStreamClient {
+		stream, err := client.CommandStream(ctx)
+		if err != nil {
+			t.Fatalf("CommandStream: %v", err)
+		}
+		if err := stream.Send(&workerpb.CommandResult{WorkerName: "worker-5", IsHeartbeat: true}); err != nil {
+			t.Fatalf("Send identify: %v", err)
+		}
+		return stream
+	}
+
+	firstCtx, dropFirst := context.WithCancel(context.Background())
+	first := open(firstCtx)
+	time.Sleep(20 * time.Millisecond)
+
+	resCh := make(chan *workerpb.CommandResult, 1)
+	go func() {
+		res, _ := reg.Dispatch(context.Background(), "worker-5", &workerpb.Command{CommandId: "cmd-create", Type: workerpb.CommandType_COMMAND_TYPE_CREATE_POD})
+		resCh <- res
+	}()
+
+	if cmd, err := first.Recv(); err != nil || cmd.GetCommandId() != "cmd-create" {
+		t.Fatalf("first stream Recv = %v, %v", cmd, err)
+	}
+
+	// The stream drops before the worker answers.
+	dropFirst()
+	time.Sleep(50 * time.Millisecond)
+	if reg.IsConnected("worker-5") {
+		t.Fatal("expected worker-5 to be disconnected")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	second := open(ctx)
+
+	cmd, err := second.Recv()
+	if err != nil || cmd.GetCommandId() != "cmd-create" {
+		t.Fatalf("expected cmd-create to be resent, got %v, %v", cmd, err)
+	}
+	if err := second.Send(&workerpb.CommandResult{WorkerName: "worker-5", CommandId: "cmd-create", Success: true}); err != nil {
+		t.Fatalf("Send result: %v", err)
+	}
+
+	select {
+	case res := <-resCh:
+		if !res.GetSuccess() {
+			t.Errorf("unexpected result %+v", res)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for the resent command's result")
+	}
+}
+
+// ──────────────────────────────────────────────────────────────────────────────
+// Mutual TLS
+// ──────────────────────────────────────────────────────────────────────────────
+
+// tlsTestGateway serves a gateway with a CA on bufconn. dial opens a client
+// connection presenting kp, or no client certificate when kp is nil.
+type tlsTestGateway struct {
+	ca  *pki.CA
+	lis *bufconn.Listener
+}
+
+func startTLSTestGateway(t *testing.T, reg *registry.Registry) *tlsTestGateway {
+	t.Helper()
+
+	ca, err := pki.NewCA()
+	if err != nil {
+		t.Fatalf("NewCA: %v", err)
+	}
+	tlsConfig, err := ca.ServerTLSConfig([]string{"gateway.test"})
+	if err != nil {
+		t.Fatalf("ServerTLSConfig: %v", err)
+	}
+
+	lis := bufconn.Listen(bufSize)
+	gw := New(reg, ca, []string{"gateway.test"})
+	gw.grpcServer = grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+	workerpb.RegisterWorkerGatewayServer(gw.grpcServer, gw)
+	go gw.grpcServer.Serve(lis) //nolint:errcheck
+
+	t.Cleanup(func() {
+		gw.grpcServer.Stop()
+		lis.Close() //nolint:errcheck
+	})
+
+	return &tlsTestGateway{ca: ca, lis: lis}
+}
+
+func (g *tlsTestGateway) dial(t *testing.T, kp *pki.KeyPair) workerpb.WorkerGatewayClient {
+	t.Helper()
+
+	getCert := func(*tls.CertificateRequestInfo) (*tls.Certificate, error) { return &tls.Certificate{}, nil }
+	if kp != nil {
+		getCert = kp.GetClientCertificate
+	}
+	tlsConfig, err := pki.ClientTLSConfig(g.ca.CertPEM(), getCert)
+	if err != nil {
+		t.Fatalf("ClientTLSConfig: %v", err)
+	}
+	tlsConfig.ServerName = "gateway.test"
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return g.lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() }) //nolint:errcheck
+
+	return workerpb.NewWorkerGatewayClient(conn)
+}
+
+// registerTLS registers workerName through the RPC and returns its issued key pair.
+func registerTLS(t *testing.T, reg *registry.Registry, g *tlsTestGateway, workerName string) *pki.KeyPair {
+	t.Helper()
+
+	resp, err := g.dial(t, nil).Register(context.Background(), &workerpb.RegisterRequest{
+		PreSharedToken: preregister(t, reg, workerName),
+		RuntimeType:    "podman",
+	})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+
+	kp, err := pki.NewKeyPair([]byte(resp.GetTlsCertPem()), []byte(resp.GetTlsKeyPem()))
+	if err != nil {
+		t.Fatalf("NewKeyPair: %v", err)
+	}
+	return kp
+}
+
+// openStream opens a CommandStream and sends the identifying heartbeat for workerName.
+func openStream(t *testing.T, client workerpb.WorkerGatewayClient, workerName string) workerpb.WorkerGateway_CommandStreamClient {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	t.Cleanup(cancel)
+
+	stream, err := client.CommandStream(ctx)
+	if err != nil {
+		t.Fatalf("CommandStream: %v", err)
+	}
+	if err := stream.Send(&workerpb.CommandResult{WorkerName: workerName, IsHeartbeat: true}); err != nil {
+		t.Fatalf("Send identify: %v", err)
+	}
+	return stream
+}
+
+func TestGateway_TLS_RegisterIssuesWorkerCertificate(t *testing.T) {
+	reg := newTestRegistry()
+	g := startTLSTestGateway(t, reg)
+
+	resp, err := g.dial(t, nil).Register(context.Background(), &workerpb.RegisterRequest{
+		PreSharedToken: preregister(t, reg, "worker-1"),
+		RuntimeType:    "podman",
+	})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if resp.GetCaCertPem() != string(g.ca.CertPEM()) {
+		t.Error("expected the CA certificate in the response")
+	}
+
+	cert, err := pki.ParseCertificatePEM([]byte(resp.GetTlsCertPem()))
+	if err != nil {
+		t.Fatalf("ParseCertificatePEM: %v", err)
+	}
+	if cert.Subject.CommonName != "worker-1" {
+		t.Errorf("CN = %q, want worker-1", cert.Subject.CommonName)
+	}
+}
+
+func TestGateway_TLS_CommandStreamRequiresClientCert(t *testing.T) {
+	reg := newTestRegistry()
+	g := startTLSTestGateway(t, reg)
+	registerTLS(t, reg, g, "worker-1")
+
+	stream := openStream(t, g.dial(t, nil), "worker-1")
+	if _, err := stream.Recv(); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated, got %v", err)
+	}
+}
+
+func TestGateway_TLS_CommandStreamRejectsMismatchedName(t *testing.T) {
+	reg := newTestRegistry()
+	g := startTLSTestGateway(t, reg)
+	kpA := registerTLS(t, reg, g, "worker-a")
+	registerTLS(t, reg, g, "worker-b")
+
+	// worker-a's certificate must not open worker-b's stream.
+	stream := openStream(t, g.dial(t, kpA), "worker-b")
+	if _, err := stream.Recv(); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied, got %v", err)
+	}
+}
+
+func TestGateway_TLS_CommandStreamAcceptsMatchingCert(t *testing.T) {
+	reg := newTestRegistry()
+	g := startTLSTestGateway(t, reg)
+	kp := registerTLS(t, reg, g, "worker-1")
+
+	stream := openStream(t, g.dial(t, kp), "worker-1")
+	time.Sleep(20 * time.Millisecond)
+
+	entry, ok := reg.Get("worker-1")
+	if !ok {
+		t.Fatal("expected worker-1 in registry")
+	}
+	entry.CommandCh <- &workerpb.Command{CommandId: "tls-cmd", Type: workerpb.CommandType_COMMAND_TYPE_LIST_PODS}
+
+	got, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv: %v", err)
+	}
+	if got.GetCommandId() != "tls-cmd" {
+		t.Errorf("command_id = %q", got.GetCommandId())
+	}
+}
+
+func TestGateway_TLS_RenewCertificate(t *testing.T) {
+	reg := newTestRegistry()
+	g := startTLSTestGateway(t, reg)
+	kp := registerTLS(t, reg, g, "worker-1")
+	registerTLS(t, reg, g, "worker-2")
+	client := g.dial(t, kp)
+
+	if _, err := client.RenewCertificate(context.Background(), &workerpb.RenewCertificateRequest{WorkerName: "worker-2"}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("renewing another worker's certificate: expected PermissionDenied, got %v", err)
+	}
+
+	resp, err := client.RenewCertificate(context.Background(), &workerpb.RenewCertificateRequest{WorkerName: "worker-1"})
+	if err != nil {
+		t.Fatalf("RenewCertificate: %v", err)
+	}
+	cert, err := pki.ParseCertificatePEM([]byte(resp.GetTlsCertPem()))
+	if err != nil {
+		t.Fatalf("ParseCertificatePEM: %v", err)
+	}
+	if cert.Subject.CommonName != "worker-1" || cert.SerialNumber.Cmp(kp.Leaf().SerialNumber) == 0 {
+		t.Errorf("expected a fresh certificate for worker-1, got CN=%q serial=%s", cert.Subject.CommonName, cert.SerialNumber)
+	}
+
+	if _, err := g.dial(t, nil).RenewCertificate(context.Background(), &workerpb.RenewCertificateRequest{WorkerName: "worker-1"}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("renewing without a certificate: expected Unauthenticated, got %v", err)
+	}
+}
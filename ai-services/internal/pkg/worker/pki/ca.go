@@ -0,0 +1,262 @@
+// Package pki implements the internal certificate authority that secures the
+// WorkerGateway. The CA signs the gateway's server certificate and one client
+// certificate per worker (CommonName = worker name), so the gateway can bind each
+// CommandStream to the identity it issued at Register time.
+package pki
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
+)
+
+const (
+	// caValidity is the lifetime of the self-signed CA certificate.
+	caValidity = 10 * 365 * 24 * time.Hour
+
+	// ClientCertValidity is the lifetime of a worker client certificate.
+	// Workers renew well before expiry (see RenewalDue).
+	ClientCertValidity = 30 * 24 * time.Hour
+
+	// serverCertValidity is the lifetime of the gateway server certificate.
+	serverCertValidity = 90 * 24 * time.Hour
+
+	// clockSkew backdates NotBefore so freshly issued certificates are accepted by
+	// peers whose clocks run slightly behind.
+	clockSkew = 5 * time.Minute
+
+	// serialBits is the size of the random certificate serial number.
+	serialBits = 128
+
+	caCommonName = "ai-services worker CA"
+
+	pemTypeCertificate = "CERTIFICATE"
+	pemTypePrivateKey  = "PRIVATE KEY"
+)
+
+// CA is an in-memory certificate authority able to issue worker and gateway certificates.
+type CA struct {
+	cert    *x509.Certificate
+	key     crypto.Signer
+	certPEM []byte
+}
+
+// NewCA generates a new self-signed CA with an ECDSA P-256 key.
+func NewCA() (*CA, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate CA key: %w", err)
+	}
+
+	serial, err := newSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: caCommonName},
+		NotBefore:             now.Add(-clockSkew),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CA certificate: %w", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA certificate: %w", err)
+	}
+
+	return &CA{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: pemTypeCertificate, Bytes: der}),
+	}, nil
+}
+
+// ParseCA loads a CA from its PEM-encoded certificate and PKCS#8 private key.
+func ParseCA(certPEM, keyPEM []byte) (*CA, error) {
+	cert, err := ParseCertificatePEM(certPEM)
+	if err != nil {
+		return nil, err
+	}
+	if !cert.IsCA {
+		return nil, errors.New("certificate is not a CA")
+	}
+
+	block, _ := pem.Decode(keyPEM)
+	if block == nil || block.Type != pemTypePrivateKey {
+		return nil, errors.New("invalid CA private key PEM")
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA private key: %w", err)
+	}
+
+	key, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("CA private key cannot sign")
+	}
+
+	return &CA{cert: cert, key: key, certPEM: certPEM}, nil
+}
+
+// CertPEM returns the PEM-encoded CA certificate. Workers use it to verify the gateway.
+func (ca *CA) CertPEM() []byte {
+	return ca.certPEM
+}
+
+// KeyPEM returns the PKCS#8 PEM-encoded CA private key, for persistence.
+func (ca *CA) KeyPEM() ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(ca.key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode CA private key: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: pemTypePrivateKey, Bytes: der}), nil
+}
+
+// Pool returns a certificate pool containing only this CA.
+func (ca *CA) Pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+
+	return pool
+}
+
+// IssueClientCert issues a client-authentication certificate whose CommonName is
+// commonName (the worker name) and returns the certificate and key as PEM.
+// Certificates are not revoked: the gateway only accepts one while the worker it names
+// is registered, so deregistering a worker or issuing it a new token shuts it out.
+func (ca *CA) IssueClientCert(commonName string) ([]byte, []byte, error) {
+	if commonName == "" {
+		return nil, nil, errors.New("client certificate common name must not be empty")
+	}
+
+	tmpl := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: commonName},
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	return ca.issue(tmpl, ClientCertValidity)
+}
+
+// IssueServerCert issues a server-authentication certificate for hosts, which may
+// contain DNS names and IP addresses.
+func (ca *CA) IssueServerCert(hosts []string) (tls.Certificate, error) {
+	if len(hosts) == 0 {
+		return tls.Certificate{}, errors.New("server certificate requires at least one host")
+	}
+
+	tmpl := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: hosts[0]},
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			tmpl.IPAddresses = append(tmpl.IPAddresses, ip)
+		} else {
+			tmpl.DNSNames = append(tmpl.DNSNames, h)
+		}
+	}
+
+	certPEM, keyPEM, err := ca.issue(tmpl, serverCertValidity)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to load server key pair: %w", err)
+	}
+
+	return cert, nil
+}
+
+// issue signs tmpl with a fresh ECDSA P-256 key and returns the certificate and key as PEM.
+func (ca *CA) issue(tmpl *x509.Certificate, validity time.Duration) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	serial, err := newSerial()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	tmpl.SerialNumber = serial
+	tmpl.NotBefore = now.Add(-clockSkew)
+	tmpl.NotAfter = now.Add(validity)
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, key.Public(), ca.key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign certificate for %s: %w", tmpl.Subject.CommonName, err)
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode private key: %w", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: pemTypeCertificate, Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: pemTypePrivateKey, Bytes: keyDER})
+
+	return certPEM, keyPEM, nil
+}
+
+// newSerial returns a random positive certificate serial number.
+func newSerial() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), serialBits))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate certificate serial: %w", err)
+	}
+
+	return serial, nil
+}
+
+// ParseCertificatePEM decodes the first certificate in a PEM block.
+func ParseCertificatePEM(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != pemTypeCertificate {
+		return nil, errors.New("invalid certificate PEM")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	return cert, nil
+}
+
+// RenewalDue reports whether cert has less than a third of its lifetime left at now.
+func RenewalDue(cert *x509.Certificate, now time.Time) bool {
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+
+	return cert.NotAfter.Sub(now) < lifetime/3 //nolint:mnd
+}
+
+// Made with Bob
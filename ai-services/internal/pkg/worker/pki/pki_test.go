@@ -0,0 +1,184 @@
+package pki
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	catalogutils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
+)
+
+// fakeCARepo is an in-memory WorkerCARepository.
+type fakeCARepo struct {
+	stored *models.WorkerCA
+}
+
+func (r *fakeCARepo) Get(context.Context) (*models.WorkerCA, error) { return r.stored, nil }
+func (r *fakeCARepo) Insert(_ context.Context, ca *models.WorkerCA) (bool, error) {
+	if r.stored != nil {
+		return false, nil
+	}
+	cp := *ca
+	r.stored = &cp
+	return true, nil
+}
+
+var _ repository.WorkerCARepository = (*fakeCARepo)(nil)
+
+func newTestCA(t *testing.T) *CA {
+	t.Helper()
+	ca, err := NewCA()
+	if err != nil {
+		t.Fatalf("NewCA: %v", err)
+	}
+	return ca
+}
+
+func TestIssueClientCert_VerifiesAgainstCA(t *testing.T) {
+	ca := newTestCA(t)
+
+	certPEM, keyPEM, err := ca.IssueClientCert("worker-1")
+	if err != nil {
+		t.Fatalf("IssueClientCert: %v", err)
+	}
+	if len(keyPEM) == 0 {
+		t.Fatal("expected a private key")
+	}
+
+	cert, err := ParseCertificatePEM(certPEM)
+	if err != nil {
+		t.Fatalf("ParseCertificatePEM: %v", err)
+	}
+	if cert.Subject.CommonName != "worker-1" {
+		t.Errorf("CN = %q", cert.Subject.CommonName)
+	}
+
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:     ca.Pool(),
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}); err != nil {
+		t.Errorf("client cert does not verify against CA: %v", err)
+	}
+
+	// A client certificate must not be usable as a server certificate.
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:     ca.Pool(),
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}); err == nil {
+		t.Error("client cert unexpectedly valid for server auth")
+	}
+}
+
+func TestIssueClientCert_RejectsOtherCA(t *testing.T) {
+	certPEM, _, err := newTestCA(t).IssueClientCert("worker-1")
+	if err != nil {
+		t.Fatalf("IssueClientCert: %v", err)
+	}
+	cert, _ := ParseCertificatePEM(certPEM)
+
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:     newTestCA(t).Pool(),
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}); err == nil {
+		t.Error("expected verification against an unrelated CA to fail")
+	}
+}
+
+func TestIssueServerCert_SANs(t *testing.T) {
+	cert, err := newTestCA(t).IssueServerCert([]string{"gateway.example", "127.0.0.1"})
+	if err != nil {
+		t.Fatalf("IssueServerCert: %v", err)
+	}
+	if err := cert.Leaf.VerifyHostname("gateway.example"); err != nil {
+		t.Errorf("DNS SAN: %v", err)
+	}
+	if err := cert.Leaf.VerifyHostname("127.0.0.1"); err != nil {
+		t.Errorf("IP SAN: %v", err)
+	}
+}
+
+func TestParseCA_RoundTrip(t *testing.T) {
+	ca := newTestCA(t)
+	keyPEM, err := ca.KeyPEM()
+	if err != nil {
+		t.Fatalf("KeyPEM: %v", err)
+	}
+
+	loaded, err := ParseCA(ca.CertPEM(), keyPEM)
+	if err != nil {
+		t.Fatalf("ParseCA: %v", err)
+	}
+
+	// Certificates issued by the reloaded CA must verify against the original.
+	certPEM, _, err := loaded.IssueClientCert("worker-1")
+	if err != nil {
+		t.Fatalf("IssueClientCert: %v", err)
+	}
+	cert, _ := ParseCertificatePEM(certPEM)
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:     ca.Pool(),
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}); err != nil {
+		t.Errorf("verify: %v", err)
+	}
+}
+
+func TestRenewalDue(t *testing.T) {
+	now := time.Now()
+	cert := &x509.Certificate{NotBefore: now, NotAfter: now.Add(30 * 24 * time.Hour)}
+
+	if RenewalDue(cert, now.Add(10*24*time.Hour)) {
+		t.Error("renewal should not be due after a third of the lifetime")
+	}
+	if !RenewalDue(cert, now.Add(21*24*time.Hour)) {
+		t.Error("renewal should be due with less than a third of the lifetime left")
+	}
+}
+
+func TestLoadOrCreate_PersistsEncryptedKey(t *testing.T) {
+	repo := &fakeCARepo{}
+	keys := mustKeyring(t, "secret")
+
+	first, err := LoadOrCreate(context.Background(), repo, keys)
+	if err != nil {
+		t.Fatalf("LoadOrCreate: %v", err)
+	}
+	if repo.stored == nil {
+		t.Fatal("expected the CA to be persisted")
+	}
+	if bytes.Contains([]byte(repo.stored.KeyPEMEncrypted), []byte("PRIVATE KEY")) {
+		t.Error("CA key stored in plaintext")
+	}
+
+	second, err := LoadOrCreate(context.Background(), repo, keys)
+	if err != nil {
+		t.Fatalf("second LoadOrCreate: %v", err)
+	}
+	if !bytes.Equal(first.CertPEM(), second.CertPEM()) {
+		t.Error("expected the stored CA to be reused")
+	}
+
+	if _, err := LoadOrCreate(context.Background(), repo, mustKeyring(t, "wrong")); err == nil {
+		t.Error("expected a wrong encryption key to fail")
+	}
+	if _, err := LoadOrCreate(context.Background(), repo, mustKeyring(t, "rotated", "secret")); err != nil {
+		t.Errorf("expected a previous encryption key to decrypt the CA: %v", err)
+	}
+	if _, err := LoadOrCreate(context.Background(), repo, nil); err == nil {
+		t.Error("expected a missing encryption key to be rejected")
+	}
+}
+
+func mustKeyring(t *testing.T, primary string, previous ...string) *catalogutils.Keyring {
+	t.Helper()
+	keys, err := catalogutils.NewKeyring(primary, previous...)
+	if err != nil {
+		t.Fatalf("NewKeyring: %v", err)
+	}
+
+	return keys
+}
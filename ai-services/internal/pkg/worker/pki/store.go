@@ -0,0 +1,77 @@
+package pki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
+	catalogutils "github.com/project-ai-services/ai-services/internal/pkg/catalog/utils"
+	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+)
+
+// LoadOrCreate returns the CA persisted in repo, generating and storing a new one on
+// first start. The CA private key is stored encrypted with keys, so every
+// control-plane replica sharing the database issues certificates from the same CA.
+func LoadOrCreate(ctx context.Context, repo repository.WorkerCARepository, keys *catalogutils.Keyring) (*CA, error) {
+	if keys == nil {
+		return nil, errors.New("an encryption key is required to protect the worker CA")
+	}
+
+	stored, err := repo.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if stored != nil {
+		return decodeStored(stored, keys)
+	}
+
+	ca, err := NewCA()
+	if err != nil {
+		return nil, err
+	}
+
+	keyPEM, err := ca.KeyPEM()
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := keys.Encrypt(string(keyPEM))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt worker CA key: %w", err)
+	}
+
+	inserted, err := repo.Insert(ctx, &models.WorkerCA{
+		CertPEM:         string(ca.CertPEM()),
+		KeyPEMEncrypted: encrypted,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !inserted {
+		// Another replica created the CA concurrently; use theirs.
+		return LoadOrCreate(ctx, repo, keys)
+	}
+
+	logger.InfolnCtx(ctx, "Generated a new worker certificate authority")
+
+	return ca, nil
+}
+
+// decodeStored decrypts and parses a persisted CA.
+func decodeStored(stored *models.WorkerCA, keys *catalogutils.Keyring) (*CA, error) {
+	keyPEM, err := keys.Decrypt(stored.KeyPEMEncrypted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt worker CA key: %w", err)
+	}
+
+	ca, err := ParseCA([]byte(stored.CertPEM), []byte(keyPEM))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load worker CA: %w", err)
+	}
+
+	return ca, nil
+}
+
+// Made with Bob
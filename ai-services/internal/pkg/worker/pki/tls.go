@@ -0,0 +1,120 @@
+package pki
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ServerTLSConfig returns the gateway TLS configuration. The server certificate is
+// issued for hosts and transparently re-issued once RenewalDue reports it is ageing.
+//
+// Client certificates are verified against the CA when presented but not required at
+// the handshake, because Register is called with a bootstrap token before the worker
+// holds a certificate. RPCs that need a worker identity enforce it themselves.
+func (ca *CA) ServerTLSConfig(hosts []string) (*tls.Config, error) {
+	sc := &serverCert{ca: ca, hosts: hosts}
+	if _, err := sc.get(nil); err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		ClientAuth:     tls.VerifyClientCertIfGiven,
+		ClientCAs:      ca.Pool(),
+		GetCertificate: sc.get,
+	}, nil
+}
+
+// serverCert caches the gateway server certificate and re-issues it when due.
+type serverCert struct {
+	ca    *CA
+	hosts []string
+
+	mu   sync.Mutex
+	cert *tls.Certificate
+}
+
+func (s *serverCert) get(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cert != nil && !RenewalDue(s.cert.Leaf, time.Now()) {
+		return s.cert, nil
+	}
+
+	cert, err := s.ca.IssueServerCert(s.hosts)
+	if err != nil {
+		return nil, err
+	}
+	s.cert = &cert
+
+	return s.cert, nil
+}
+
+// ClientTLSConfig returns the worker TLS configuration. caCertPEM verifies the
+// gateway; getCert supplies the current client certificate so it can be swapped
+// after renewal without re-dialling.
+func ClientTLSConfig(caCertPEM []byte, getCert func(*tls.CertificateRequestInfo) (*tls.Certificate, error)) (*tls.Config, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCertPEM) {
+		return nil, errors.New("no valid CA certificate found")
+	}
+
+	return &tls.Config{
+		MinVersion:           tls.VersionTLS12,
+		RootCAs:              pool,
+		GetClientCertificate: getCert,
+	}, nil
+}
+
+// KeyPair is a client certificate that can be replaced concurrently with TLS handshakes.
+type KeyPair struct {
+	mu   sync.RWMutex
+	cert *tls.Certificate
+}
+
+// NewKeyPair parses a PEM certificate and key into a KeyPair.
+func NewKeyPair(certPEM, keyPEM []byte) (*KeyPair, error) {
+	kp := &KeyPair{}
+	if err := kp.Set(certPEM, keyPEM); err != nil {
+		return nil, err
+	}
+
+	return kp, nil
+}
+
+// Set replaces the held certificate.
+func (k *KeyPair) Set(certPEM, keyPEM []byte) error {
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return fmt.Errorf("failed to load client key pair: %w", err)
+	}
+
+	k.mu.Lock()
+	k.cert = &cert
+	k.mu.Unlock()
+
+	return nil
+}
+
+// Leaf returns the parsed held certificate.
+func (k *KeyPair) Leaf() *x509.Certificate {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.cert.Leaf
+}
+
+// GetClientCertificate implements tls.Config.GetClientCertificate.
+func (k *KeyPair) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.cert, nil
+}
+
+// Made with Bob
@@ -0,0 +1,34 @@
+package proto
+
+// ProtocolVersion is the version of the worker protocol implemented by this build.
+// Bump it whenever a command type is added or a payload changes, so workers running
+// an older build are reported as outdated.
+//
+// Version 0 stands for workers that predate capability negotiation and send no
+// Capabilities at all; see LegacyCapabilities.
+const ProtocolVersion uint32 = 1
+
+// LegacyCapabilities returns the capabilities assumed for a worker that declared none:
+// protocol version 0 with the command types up to COMMAND_TYPE_RUNTIME_TYPE, the set
+// every worker build has always executed.
+func LegacyCapabilities() *Capabilities {
+	commands := make([]CommandType, 0, CommandType_COMMAND_TYPE_RUNTIME_TYPE)
+	for t := CommandType_COMMAND_TYPE_LIST_IMAGES; t <= CommandType_COMMAND_TYPE_RUNTIME_TYPE; t++ {
+		commands = append(commands, t)
+	}
+
+	return &Capabilities{SupportedCommands: commands}
+}
+
+// Supports reports whether t is among the supported commands of c.
+func (c *Capabilities) Supports(t CommandType) bool {
+	for _, s := range c.GetSupportedCommands() {
+		if s == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Made with Bob
@@ -62,6 +62,15 @@ const (
 	// The control plane sends an HTTP request; the worker executes it locally
 	// against a pod endpoint and returns the response.
 	CommandType_COMMAND_TYPE_HTTP_PROXY CommandType = 29
+	// Remaining runtime.Runtime operations executed by RemoteRuntime.
+	CommandType_COMMAND_TYPE_GET_NAMESPACE     CommandType = 30
+	CommandType_COMMAND_TYPE_UPDATE_SECRET     CommandType = 31
+	CommandType_COMMAND_TYPE_EXEC_IN_CONTAINER CommandType = 32
+	CommandType_COMMAND_TYPE_LIST_CRD          CommandType = 33
+	CommandType_COMMAND_TYPE_DELETE_NAMESPACE  CommandType = 34
+	// Stops the command named in the payload, e.g. a followed log whose caller went
+	// away. The worker sends no result for it.
+	CommandType_COMMAND_TYPE_CANCEL CommandType = 35
 )
 
 // Enum value maps for CommandType.
@@ -97,6 +106,12 @@ var (
 		27: "COMMAND_TYPE_GET_PROXY_ROUTE",
 		28: "COMMAND_TYPE_PROXY_HEALTH_CHECK",
 		29: "COMMAND_TYPE_HTTP_PROXY",
+		30: "COMMAND_TYPE_GET_NAMESPACE",
+		31: "COMMAND_TYPE_UPDATE_SECRET",
+		32: "COMMAND_TYPE_EXEC_IN_CONTAINER",
+		33: "COMMAND_TYPE_LIST_CRD",
+		34: "COMMAND_TYPE_DELETE_NAMESPACE",
+		35: "COMMAND_TYPE_CANCEL",
 	}
 	CommandType_value = map[string]int32{
 		"COMMAND_TYPE_UNSPECIFIED":             0,
@@ -129,6 +144,12 @@ var (
 		"COMMAND_TYPE_GET_PROXY_ROUTE":         27,
 		"COMMAND_TYPE_PROXY_HEALTH_CHECK":      28,
 		"COMMAND_TYPE_HTTP_PROXY":              29,
+		"COMMAND_TYPE_GET_NAMESPACE":           30,
+		"COMMAND_TYPE_UPDATE_SECRET":           31,
+		"COMMAND_TYPE_EXEC_IN_CONTAINER":       32,
+		"COMMAND_TYPE_LIST_CRD":                33,
+		"COMMAND_TYPE_DELETE_NAMESPACE":        34,
+		"COMMAND_TYPE_CANCEL":                  35,
 	}
 )
 
@@ -166,7 +187,10 @@ type RegisterRequest struct {
 	Metadata       map[string]string      `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// runtime_type declares the execution environment of the worker
 	// (e.g. "podman", "openshift"). The control plane persists this to the DB.
-	RuntimeType   string `protobuf:"bytes,4,opt,name=runtime_type,json=runtimeType,proto3" json:"runtime_type,omitempty"`
+	RuntimeType string `protobuf:"bytes,4,opt,name=runtime_type,json=runtimeType,proto3" json:"runtime_type,omitempty"`
+	// capabilities declares the protocol the worker speaks. Workers built before it
+	// existed leave it unset.
+	Capabilities  *Capabilities `protobuf:"bytes,5,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -229,19 +253,85 @@ func (x *RegisterRequest) GetRuntimeType() string {
 	return ""
 }
 
+func (x *RegisterRequest) GetCapabilities() *Capabilities {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+// Capabilities describes the protocol version and the command types a worker build
+// executes. The control plane persists them and refuses to send a worker a command
+// type it does not list.
+type Capabilities struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// protocol_version is bumped whenever command types are added or their payloads change.
+	ProtocolVersion   uint32        `protobuf:"varint,1,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	SupportedCommands []CommandType `protobuf:"varint,2,rep,packed,name=supported_commands,json=supportedCommands,proto3,enum=worker.v1.CommandType" json:"supported_commands,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Capabilities) Reset() {
+	*x = Capabilities{}
+	mi := &file_internal_pkg_worker_proto_worker_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Capabilities) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Capabilities) ProtoMessage() {}
+
+func (x *Capabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pkg_worker_proto_worker_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Capabilities.ProtoReflect.Descriptor instead.
+func (*Capabilities) Descriptor() ([]byte, []int) {
+	return file_internal_pkg_worker_proto_worker_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Capabilities) GetProtocolVersion() uint32 {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return 0
+}
+
+func (x *Capabilities) GetSupportedCommands() []CommandType {
+	if x != nil {
+		return x.SupportedCommands
+	}
+	return nil
+}
+
 type RegisterResponse struct {
 	state      protoimpl.MessageState `protogen:"open.v1"`
 	WorkerName string                 `protobuf:"bytes,1,opt,name=worker_name,json=workerName,proto3" json:"worker_name,omitempty"`
-	// tls_cert_pem / tls_key_pem are reserved for future mTLS support.
-	TlsCertPem    string `protobuf:"bytes,2,opt,name=tls_cert_pem,json=tlsCertPem,proto3" json:"tls_cert_pem,omitempty"`
-	TlsKeyPem     string `protobuf:"bytes,3,opt,name=tls_key_pem,json=tlsKeyPem,proto3" json:"tls_key_pem,omitempty"`
+	// tls_cert_pem / tls_key_pem are the worker's client certificate and private key,
+	// issued by the control plane CA with CN = worker_name. CommandStream requires them.
+	TlsCertPem string `protobuf:"bytes,2,opt,name=tls_cert_pem,json=tlsCertPem,proto3" json:"tls_cert_pem,omitempty"`
+	TlsKeyPem  string `protobuf:"bytes,3,opt,name=tls_key_pem,json=tlsKeyPem,proto3" json:"tls_key_pem,omitempty"`
+	// ca_cert_pem is the control plane CA; the worker uses it to verify the gateway.
+	CaCertPem     string `protobuf:"bytes,4,opt,name=ca_cert_pem,json=caCertPem,proto3" json:"ca_cert_pem,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RegisterResponse) Reset() {
 	*x = RegisterResponse{}
-	mi := &file_internal_pkg_worker_proto_worker_proto_msgTypes[1]
+	mi := &file_internal_pkg_worker_proto_worker_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -253,7 +343,7 @@ func (x *RegisterResponse) String() string {
 func (*RegisterResponse) ProtoMessage() {}
 
 func (x *RegisterResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_pkg_worker_proto_worker_proto_msgTypes[1]
+	mi := &file_internal_pkg_worker_proto_worker_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -266,7 +356,7 @@ func (x *RegisterResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterResponse.ProtoReflect.Descriptor instead.
 func (*RegisterResponse) Descriptor() ([]byte, []int) {
-	return file_internal_pkg_worker_proto_worker_proto_rawDescGZIP(), []int{1}
+	return file_internal_pkg_worker_proto_worker_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *RegisterResponse) GetWorkerName() string {
@@ -290,18 +380,78 @@ func (x *RegisterResponse) GetTlsKeyPem() string {
 	return ""
 }
 
+func (x *RegisterResponse) GetCaCertPem() string {
+	if x != nil {
+		return x.CaCertPem
+	}
+	return ""
+}
+
+type RenewCertificateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// worker_name must match the CN of the client certificate presented on the call.
+	WorkerName    string `protobuf:"bytes,1,opt,name=worker_name,json=workerName,proto3" json:"worker_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenewCertificateRequest) Reset() {
+	*x = RenewCertificateRequest{}
+	mi := &file_internal_pkg_worker_proto_worker_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenewCertificateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenewCertificateRequest) ProtoMessage() {}
+
+func (x *RenewCertificateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pkg_worker_proto_worker_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenewCertificateRequest.ProtoReflect.Descriptor instead.
+func (*RenewCertificateRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pkg_worker_proto_worker_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RenewCertificateRequest) GetWorkerName() string {
+	if x != nil {
+		return x.WorkerName
+	}
+	return ""
+}
+
+// The worker keeps the result of every unary command for a while, keyed by
+// command_id. A command received again with the same command_id, e.g. resent by the
+// control plane after the stream reconnected, is answered from that cache instead of
+// being executed twice.
 type Command struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	CommandId     string                 `protobuf:"bytes,1,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
-	Type          CommandType            `protobuf:"varint,2,opt,name=type,proto3,enum=worker.v1.CommandType" json:"type,omitempty"`
-	Payload       []byte                 `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"` // JSON-encoded type-specific payload
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	CommandId string                 `protobuf:"bytes,1,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	Type      CommandType            `protobuf:"varint,2,opt,name=type,proto3,enum=worker.v1.CommandType" json:"type,omitempty"`
+	Payload   []byte                 `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"` // JSON-encoded type-specific payload
+	// timeout_ms bounds how long the worker may spend on the command, measured from
+	// receipt. The control plane sets it to the time its caller is still willing to
+	// wait; 0 means no limit. A command resent after a reconnect carries the time left.
+	TimeoutMs     int64 `protobuf:"varint,4,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Command) Reset() {
 	*x = Command{}
-	mi := &file_internal_pkg_worker_proto_worker_proto_msgTypes[2]
+	mi := &file_internal_pkg_worker_proto_worker_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -313,7 +463,7 @@ func (x *Command) String() string {
 func (*Command) ProtoMessage() {}
 
 func (x *Command) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_pkg_worker_proto_worker_proto_msgTypes[2]
+	mi := &file_internal_pkg_worker_proto_worker_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -326,7 +476,7 @@ func (x *Command) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Command.ProtoReflect.Descriptor instead.
 func (*Command) Descriptor() ([]byte, []int) {
-	return file_internal_pkg_worker_proto_worker_proto_rawDescGZIP(), []int{2}
+	return file_internal_pkg_worker_proto_worker_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Command) GetCommandId() string {
@@ -350,21 +500,37 @@ func (x *Command) GetPayload() []byte {
 	return nil
 }
 
+func (x *Command) GetTimeoutMs() int64 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
 type CommandResult struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	CommandId     string                 `protobuf:"bytes,1,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
-	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
-	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"` // JSON-encoded response payload
-	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
-	IsHeartbeat   bool                   `protobuf:"varint,5,opt,name=is_heartbeat,json=isHeartbeat,proto3" json:"is_heartbeat,omitempty"`
-	WorkerName    string                 `protobuf:"bytes,6,opt,name=worker_name,json=workerName,proto3" json:"worker_name,omitempty"` // set on first result and every heartbeat
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	CommandId   string                 `protobuf:"bytes,1,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	Success     bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Data        []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"` // JSON-encoded response payload
+	Error       string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	IsHeartbeat bool                   `protobuf:"varint,5,opt,name=is_heartbeat,json=isHeartbeat,proto3" json:"is_heartbeat,omitempty"`
+	WorkerName  string                 `protobuf:"bytes,6,opt,name=worker_name,json=workerName,proto3" json:"worker_name,omitempty"` // set on first result and every heartbeat
+	// Streamed commands (logs, exec) send their output as a sequence of frames:
+	// results carrying a chunk with end_of_stream = false. The stream is closed by
+	// a final result with end_of_stream = true that carries success/error.
+	// Unary commands never set chunk, so their single result is always final.
+	Chunk       []byte `protobuf:"bytes,7,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	EndOfStream bool   `protobuf:"varint,8,opt,name=end_of_stream,json=endOfStream,proto3" json:"end_of_stream,omitempty"`
+	// capabilities is set on the first message of every stream, so a worker upgraded
+	// in place is recognised without joining again.
+	Capabilities  *Capabilities `protobuf:"bytes,9,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CommandResult) Reset() {
 	*x = CommandResult{}
-	mi := &file_internal_pkg_worker_proto_worker_proto_msgTypes[3]
+	mi := &file_internal_pkg_worker_proto_worker_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -376,7 +542,7 @@ func (x *CommandResult) String() string {
 func (*CommandResult) ProtoMessage() {}
 
 func (x *CommandResult) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_pkg_worker_proto_worker_proto_msgTypes[3]
+	mi := &file_internal_pkg_worker_proto_worker_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -389,7 +555,7 @@ func (x *CommandResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CommandResult.ProtoReflect.Descriptor instead.
 func (*CommandResult) Descriptor() ([]byte, []int) {
-	return file_internal_pkg_worker_proto_worker_proto_rawDescGZIP(), []int{3}
+	return file_internal_pkg_worker_proto_worker_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CommandResult) GetCommandId() string {
@@ -434,31 +600,62 @@ func (x *CommandResult) GetWorkerName() string {
 	return ""
 }
 
+func (x *CommandResult) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *CommandResult) GetEndOfStream() bool {
+	if x != nil {
+		return x.EndOfStream
+	}
+	return false
+}
+
+func (x *CommandResult) GetCapabilities() *Capabilities {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
 var File_internal_pkg_worker_proto_worker_proto protoreflect.FileDescriptor
 
 const file_internal_pkg_worker_proto_worker_proto_rawDesc = "" +
 	"\n" +
-	"&internal/pkg/worker/proto/worker.proto\x12\tworker.v1\"\x82\x02\n" +
+	"&internal/pkg/worker/proto/worker.proto\x12\tworker.v1\"\xbf\x02\n" +
 	"\x0fRegisterRequest\x12\x1f\n" +
 	"\vworker_name\x18\x01 \x01(\tR\n" +
 	"workerName\x12(\n" +
 	"\x10pre_shared_token\x18\x02 \x01(\tR\x0epreSharedToken\x12D\n" +
 	"\bmetadata\x18\x03 \x03(\v2(.worker.v1.RegisterRequest.MetadataEntryR\bmetadata\x12!\n" +
-	"\fruntime_type\x18\x04 \x01(\tR\vruntimeType\x1a;\n" +
+	"\fruntime_type\x18\x04 \x01(\tR\vruntimeType\x12;\n" +
+	"\fcapabilities\x18\x05 \x01(\v2\x17.worker.v1.CapabilitiesR\fcapabilities\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"u\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x80\x01\n" +
+	"\fCapabilities\x12)\n" +
+	"\x10protocol_version\x18\x01 \x01(\rR\x0fprotocolVersion\x12E\n" +
+	"\x12supported_commands\x18\x02 \x03(\x0e2\x16.worker.v1.CommandTypeR\x11supportedCommands\"\x95\x01\n" +
 	"\x10RegisterResponse\x12\x1f\n" +
 	"\vworker_name\x18\x01 \x01(\tR\n" +
 	"workerName\x12 \n" +
 	"\ftls_cert_pem\x18\x02 \x01(\tR\n" +
 	"tlsCertPem\x12\x1e\n" +
-	"\vtls_key_pem\x18\x03 \x01(\tR\ttlsKeyPem\"n\n" +
+	"\vtls_key_pem\x18\x03 \x01(\tR\ttlsKeyPem\x12\x1e\n" +
+	"\vca_cert_pem\x18\x04 \x01(\tR\tcaCertPem\":\n" +
+	"\x17RenewCertificateRequest\x12\x1f\n" +
+	"\vworker_name\x18\x01 \x01(\tR\n" +
+	"workerName\"\x8d\x01\n" +
 	"\aCommand\x12\x1d\n" +
 	"\n" +
 	"command_id\x18\x01 \x01(\tR\tcommandId\x12*\n" +
 	"\x04type\x18\x02 \x01(\x0e2\x16.worker.v1.CommandTypeR\x04type\x12\x18\n" +
-	"\apayload\x18\x03 \x01(\fR\apayload\"\xb6\x01\n" +
+	"\apayload\x18\x03 \x01(\fR\apayload\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\x04 \x01(\x03R\ttimeoutMs\"\xad\x02\n" +
 	"\rCommandResult\x12\x1d\n" +
 	"\n" +
 	"command_id\x18\x01 \x01(\tR\tcommandId\x12\x18\n" +
@@ -467,7 +664,10 @@ const file_internal_pkg_worker_proto_worker_proto_rawDesc = "" +
 	"\x05error\x18\x04 \x01(\tR\x05error\x12!\n" +
 	"\fis_heartbeat\x18\x05 \x01(\bR\visHeartbeat\x12\x1f\n" +
 	"\vworker_name\x18\x06 \x01(\tR\n" +
-	"workerName*\xcf\a\n" +
+	"workerName\x12\x14\n" +
+	"\x05chunk\x18\a \x01(\fR\x05chunk\x12\"\n" +
+	"\rend_of_stream\x18\b \x01(\bR\vendOfStream\x12;\n" +
+	"\fcapabilities\x18\t \x01(\v2\x17.worker.v1.CapabilitiesR\fcapabilities*\x8a\t\n" +
 	"\vCommandType\x12\x1c\n" +
 	"\x18COMMAND_TYPE_UNSPECIFIED\x10\x00\x12\x1c\n" +
 	"\x18COMMAND_TYPE_LIST_IMAGES\x10\x01\x12\x1b\n" +
@@ -499,10 +699,17 @@ const file_internal_pkg_worker_proto_worker_proto_rawDesc = "" +
 	"#COMMAND_TYPE_UNREGISTER_PROXY_ROUTE\x10\x1a\x12 \n" +
 	"\x1cCOMMAND_TYPE_GET_PROXY_ROUTE\x10\x1b\x12#\n" +
 	"\x1fCOMMAND_TYPE_PROXY_HEALTH_CHECK\x10\x1c\x12\x1b\n" +
-	"\x17COMMAND_TYPE_HTTP_PROXY\x10\x1d2\x97\x01\n" +
+	"\x17COMMAND_TYPE_HTTP_PROXY\x10\x1d\x12\x1e\n" +
+	"\x1aCOMMAND_TYPE_GET_NAMESPACE\x10\x1e\x12\x1e\n" +
+	"\x1aCOMMAND_TYPE_UPDATE_SECRET\x10\x1f\x12\"\n" +
+	"\x1eCOMMAND_TYPE_EXEC_IN_CONTAINER\x10 \x12\x19\n" +
+	"\x15COMMAND_TYPE_LIST_CRD\x10!\x12!\n" +
+	"\x1dCOMMAND_TYPE_DELETE_NAMESPACE\x10\"\x12\x17\n" +
+	"\x13COMMAND_TYPE_CANCEL\x10#2\xec\x01\n" +
 	"\rWorkerGateway\x12C\n" +
 	"\bRegister\x12\x1a.worker.v1.RegisterRequest\x1a\x1b.worker.v1.RegisterResponse\x12A\n" +
-	"\rCommandStream\x12\x18.worker.v1.CommandResult\x1a\x12.worker.v1.Command(\x010\x01BFZDgithub.com/project-ai-services/ai-services/internal/pkg/worker/protob\x06proto3"
+	"\rCommandStream\x12\x18.worker.v1.CommandResult\x1a\x12.worker.v1.Command(\x010\x01\x12S\n" +
+	"\x10RenewCertificate\x12\".worker.v1.RenewCertificateRequest\x1a\x1b.worker.v1.RegisterResponseBFZDgithub.com/project-ai-services/ai-services/internal/pkg/worker/protob\x06proto3"
 
 var (
 	file_internal_pkg_worker_proto_worker_proto_rawDescOnce sync.Once
@@ -517,27 +724,34 @@ func file_internal_pkg_worker_proto_worker_proto_rawDescGZIP() []byte {
 }
 
 var file_internal_pkg_worker_proto_worker_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_internal_pkg_worker_proto_worker_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_internal_pkg_worker_proto_worker_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_internal_pkg_worker_proto_worker_proto_goTypes = []any{
-	(CommandType)(0),         // 0: worker.v1.CommandType
-	(*RegisterRequest)(nil),  // 1: worker.v1.RegisterRequest
-	(*RegisterResponse)(nil), // 2: worker.v1.RegisterResponse
-	(*Command)(nil),          // 3: worker.v1.Command
-	(*CommandResult)(nil),    // 4: worker.v1.CommandResult
-	nil,                      // 5: worker.v1.RegisterRequest.MetadataEntry
+	(CommandType)(0),                // 0: worker.v1.CommandType
+	(*RegisterRequest)(nil),         // 1: worker.v1.RegisterRequest
+	(*Capabilities)(nil),            // 2: worker.v1.Capabilities
+	(*RegisterResponse)(nil),        // 3: worker.v1.RegisterResponse
+	(*RenewCertificateRequest)(nil), // 4: worker.v1.RenewCertificateRequest
+	(*Command)(nil),                 // 5: worker.v1.Command
+	(*CommandResult)(nil),           // 6: worker.v1.CommandResult
+	nil,                             // 7: worker.v1.RegisterRequest.MetadataEntry
 }
 var file_internal_pkg_worker_proto_worker_proto_depIdxs = []int32{
-	5, // 0: worker.v1.RegisterRequest.metadata:type_name -> worker.v1.RegisterRequest.MetadataEntry
-	0, // 1: worker.v1.Command.type:type_name -> worker.v1.CommandType
-	1, // 2: worker.v1.WorkerGateway.Register:input_type -> worker.v1.RegisterRequest
-	4, // 3: worker.v1.WorkerGateway.CommandStream:input_type -> worker.v1.CommandResult
-	2, // 4: worker.v1.WorkerGateway.Register:output_type -> worker.v1.RegisterResponse
-	3, // 5: worker.v1.WorkerGateway.CommandStream:output_type -> worker.v1.Command
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	7, // 0: worker.v1.RegisterRequest.metadata:type_name -> worker.v1.RegisterRequest.MetadataEntry
+	2, // 1: worker.v1.RegisterRequest.capabilities:type_name -> worker.v1.Capabilities
+	0, // 2: worker.v1.Capabilities.supported_commands:type_name -> worker.v1.CommandType
+	0, // 3: worker.v1.Command.type:type_name -> worker.v1.CommandType
+	2, // 4: worker.v1.CommandResult.capabilities:type_name -> worker.v1.Capabilities
+	1, // 5: worker.v1.WorkerGateway.Register:input_type -> worker.v1.RegisterRequest
+	6, // 6: worker.v1.WorkerGateway.CommandStream:input_type -> worker.v1.CommandResult
+	4, // 7: worker.v1.WorkerGateway.RenewCertificate:input_type -> worker.v1.RenewCertificateRequest
+	3, // 8: worker.v1.WorkerGateway.Register:output_type -> worker.v1.RegisterResponse
+	5, // 9: worker.v1.WorkerGateway.CommandStream:output_type -> worker.v1.Command
+	3, // 10: worker.v1.WorkerGateway.RenewCertificate:output_type -> worker.v1.RegisterResponse
+	8, // [8:11] is the sub-list for method output_type
+	5, // [5:8] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_internal_pkg_worker_proto_worker_proto_init() }
@@ -551,7 +765,7 @@ func file_internal_pkg_worker_proto_worker_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_pkg_worker_proto_worker_proto_rawDesc), len(file_internal_pkg_worker_proto_worker_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   5,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -23,8 +23,9 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	WorkerGateway_Register_FullMethodName      = "/worker.v1.WorkerGateway/Register"
-	WorkerGateway_CommandStream_FullMethodName = "/worker.v1.WorkerGateway/CommandStream"
+	WorkerGateway_Register_FullMethodName         = "/worker.v1.WorkerGateway/Register"
+	WorkerGateway_CommandStream_FullMethodName    = "/worker.v1.WorkerGateway/CommandStream"
+	WorkerGateway_RenewCertificate_FullMethodName = "/worker.v1.WorkerGateway/RenewCertificate"
 )
 
 // WorkerGatewayClient is the client API for WorkerGateway service.
@@ -37,6 +38,9 @@ type WorkerGatewayClient interface {
 	// The worker initiates the stream and sends CommandResult messages;
 	// the control plane sends Command messages.
 	CommandStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[CommandResult, Command], error)
+	// RenewCertificate issues a fresh client certificate for an already-registered
+	// worker. The caller must authenticate with its current (unexpired) certificate.
+	RenewCertificate(ctx context.Context, in *RenewCertificateRequest, opts ...grpc.CallOption) (*RegisterResponse, error)
 }
 
 type workerGatewayClient struct {
@@ -70,6 +74,16 @@ func (c *workerGatewayClient) CommandStream(ctx context.Context, opts ...grpc.Ca
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type WorkerGateway_CommandStreamClient = grpc.BidiStreamingClient[CommandResult, Command]
 
+func (c *workerGatewayClient) RenewCertificate(ctx context.Context, in *RenewCertificateRequest, opts ...grpc.CallOption) (*RegisterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterResponse)
+	err := c.cc.Invoke(ctx, WorkerGateway_RenewCertificate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WorkerGatewayServer is the server API for WorkerGateway service.
 // All implementations must embed UnimplementedWorkerGatewayServer
 // for forward compatibility.
@@ -80,6 +94,9 @@ type WorkerGatewayServer interface {
 	// The worker initiates the stream and sends CommandResult messages;
 	// the control plane sends Command messages.
 	CommandStream(grpc.BidiStreamingServer[CommandResult, Command]) error
+	// RenewCertificate issues a fresh client certificate for an already-registered
+	// worker. The caller must authenticate with its current (unexpired) certificate.
+	RenewCertificate(context.Context, *RenewCertificateRequest) (*RegisterResponse, error)
 	mustEmbedUnimplementedWorkerGatewayServer()
 }
 
@@ -96,6 +113,9 @@ func (UnimplementedWorkerGatewayServer) Register(context.Context, *RegisterReque
 func (UnimplementedWorkerGatewayServer) CommandStream(grpc.BidiStreamingServer[CommandResult, Command]) error {
 	return status.Error(codes.Unimplemented, "method CommandStream not implemented")
 }
+func (UnimplementedWorkerGatewayServer) RenewCertificate(context.Context, *RenewCertificateRequest) (*RegisterResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RenewCertificate not implemented")
+}
 func (UnimplementedWorkerGatewayServer) mustEmbedUnimplementedWorkerGatewayServer() {}
 func (UnimplementedWorkerGatewayServer) testEmbeddedByValue()                       {}
 
@@ -142,6 +162,24 @@ func _WorkerGateway_CommandStream_Handler(srv interface{}, stream grpc.ServerStr
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type WorkerGateway_CommandStreamServer = grpc.BidiStreamingServer[CommandResult, Command]
 
+func _WorkerGateway_RenewCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerGatewayServer).RenewCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkerGateway_RenewCertificate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerGatewayServer).RenewCertificate(ctx, req.(*RenewCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WorkerGateway_ServiceDesc is the grpc.ServiceDesc for WorkerGateway service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,6 +191,10 @@ var WorkerGateway_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Register",
 			Handler:    _WorkerGateway_Register_Handler,
 		},
+		{
+			MethodName: "RenewCertificate",
+			Handler:    _WorkerGateway_RenewCertificate_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
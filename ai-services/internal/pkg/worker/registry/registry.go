@@ -6,14 +6,18 @@ package registry
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
 	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
 	"github.com/project-ai-services/ai-services/internal/pkg/logger"
+	"github.com/project-ai-services/ai-services/internal/pkg/runtime/remote"
 	"github.com/project-ai-services/ai-services/internal/pkg/utils"
 	workerpb "github.com/project-ai-services/ai-services/internal/pkg/worker/proto"
 )
@@ -26,6 +30,15 @@ const (
 	// 32 allows up to 32 simultaneous deployments targeting the same worker
 	// without any back-pressure on the HTTP layer.
 	commandChannelSize = 32
+
+	// streamBufferSize is the number of output frames buffered per streamed command.
+	// A consumer that falls further behind loses its stream rather than stalling the
+	// worker's receive loop, which also carries the results of every other command.
+	streamBufferSize = 256
+
+	// resendTimeout bounds how long a reattached stream waits for room in the
+	// command channel when it resends the commands interrupted by a reconnect.
+	resendTimeout = 30 * time.Second
 )
 
 // WorkerEntry holds the in-process gRPC plumbing for a single connected worker.
@@ -42,52 +55,284 @@ type WorkerEntry struct {
 	CommandCh chan *workerpb.Command
 
 	resultsMu sync.Mutex
-	results   map[string]chan *workerpb.CommandResult
+	results   map[string]*pendingResult
+
+	// stream numbers the command streams attached to this entry, and superseded is
+	// closed when a newer stream replaces the current one. Both are guarded by Registry.mu.
+	stream     uint64
+	superseded chan struct{}
+
+	// caps is what the worker declared when it registered or last attached a stream.
+	caps atomic.Pointer[workerpb.Capabilities]
+}
+
+// pendingResult is the delivery slot of a dispatched command.
+type pendingResult struct {
+	ch chan *workerpb.CommandResult
+	// stream is set for commands that reply with output frames before their final result.
+	stream bool
+	// overflow is closed when a frame could not be buffered and the stream was dropped.
+	overflow chan struct{}
+
+	// cmd is the dispatched command, kept so it can be resent after a reconnect.
+	// It is nil for slots registered through WaitForResult.
+	cmd *workerpb.Command
+	// deadline is when the caller stops waiting; zero if it waits indefinitely.
+	deadline time.Time
+	// sent is set once the command has been taken off CommandCh by a stream.
+	sent bool
+}
+
+// isFrame reports whether res is an intermediate output frame of a streamed command
+// rather than a final result.
+func isFrame(res *workerpb.CommandResult) bool {
+	return len(res.GetChunk()) > 0 && !res.GetEndOfStream()
 }
 
 // waitForResult registers a result channel for commandID and returns it.
 func (w *WorkerEntry) waitForResult(commandID string) chan *workerpb.CommandResult {
-	ch := make(chan *workerpb.CommandResult, 1)
+	p := &pendingResult{ch: make(chan *workerpb.CommandResult, 1)}
+
 	w.resultsMu.Lock()
-	w.results[commandID] = ch
+	w.results[commandID] = p
 	w.resultsMu.Unlock()
 
-	return ch
+	return p.ch
+}
+
+// expectResult registers the delivery slot for cmd and stamps the command with the
+// time the caller is willing to wait, taken from ctx. A stream slot buffers output
+// frames as well as the final result.
+func (w *WorkerEntry) expectResult(ctx context.Context, cmd *workerpb.Command, stream bool) *pendingResult {
+	p := &pendingResult{ch: make(chan *workerpb.CommandResult, 1), stream: stream, cmd: cmd}
+	if stream {
+		p.ch = make(chan *workerpb.CommandResult, streamBufferSize)
+		p.overflow = make(chan struct{})
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		p.deadline = deadline
+		cmd.TimeoutMs = timeoutMillis(deadline)
+	}
+
+	w.resultsMu.Lock()
+	w.results[cmd.GetCommandId()] = p
+	w.resultsMu.Unlock()
+
+	return p
+}
+
+// setCapabilities records the capabilities the worker declared. A worker that
+// declared none predates capability negotiation and gets LegacyCapabilities.
+func (w *WorkerEntry) setCapabilities(caps *workerpb.Capabilities) *workerpb.Capabilities {
+	if len(caps.GetSupportedCommands()) == 0 {
+		caps = workerpb.LegacyCapabilities()
+	}
+	w.caps.Store(caps)
+
+	return caps
+}
+
+// supports reports whether the worker declared support for commands of type t.
+func (w *WorkerEntry) supports(t workerpb.CommandType) bool {
+	return w.caps.Load().Supports(t)
+}
+
+// checkSupported refuses a command type the worker did not declare, so an outdated
+// worker is never sent a command it cannot handle.
+func (w *WorkerEntry) checkSupported(t workerpb.CommandType) error {
+	if w.supports(t) {
+		return nil
+	}
+
+	return fmt.Errorf("worker %s (protocol version %d) does not support %s; upgrade it to protocol version %d: %w",
+		w.WorkerName, w.caps.Load().GetProtocolVersion(), t, workerpb.ProtocolVersion, ErrCommandUnsupported)
 }
 
-// deliverResult routes an incoming result to the waiting caller.
+// MarkSent records that the command has been taken off CommandCh and written to the
+// worker's stream. Only sent commands are resent or failed when the stream is replaced.
+func (w *WorkerEntry) MarkSent(commandID string) {
+	w.resultsMu.Lock()
+	if p, ok := w.results[commandID]; ok {
+		p.sent = true
+	}
+	w.resultsMu.Unlock()
+}
+
+// deliverResult routes an incoming result or output frame to the waiting caller.
+// Frames for a caller that did not ask for a stream are dropped.
 func (w *WorkerEntry) deliverResult(res *workerpb.CommandResult) {
 	id := res.GetCommandId()
+	frame := isFrame(res)
+
 	w.resultsMu.Lock()
-	ch, ok := w.results[id]
-	if ok {
+	defer w.resultsMu.Unlock()
+
+	p, ok := w.results[id]
+	if !ok || (frame && !p.stream) {
+		return
+	}
+	if !frame {
 		delete(w.results, id)
 	}
+
+	select {
+	case p.ch <- res:
+	default:
+		if p.stream {
+			delete(w.results, id)
+			close(p.overflow)
+		}
+	}
+}
+
+// queue writes cmd to the command channel. The pending result slot is released
+// if ctx ends first.
+func (w *WorkerEntry) queue(ctx context.Context, cmd *workerpb.Command) error {
+	select {
+	case w.CommandCh <- cmd:
+		return nil
+	case <-ctx.Done():
+		w.forgetResult(cmd.GetCommandId())
+
+		return fmt.Errorf("worker %s: failed to queue command %s: %w", w.WorkerName, cmd.GetType(), ctx.Err())
+	}
+}
+
+// forgetResult drops the result channel for commandID, e.g. when the caller gave up waiting.
+func (w *WorkerEntry) forgetResult(commandID string) {
+	w.resultsMu.Lock()
+	delete(w.results, commandID)
 	w.resultsMu.Unlock()
-	if ok {
+}
+
+// abandon drops the result slot of cmd and asks the worker to stop executing it,
+// because the caller gave up waiting. The cancellation is best effort: it is skipped
+// when the command channel is full.
+func (w *WorkerEntry) abandon(cmd *workerpb.Command) {
+	w.forgetResult(cmd.GetCommandId())
+	if !w.supports(workerpb.CommandType_COMMAND_TYPE_CANCEL) {
+		return
+	}
+
+	payload, err := json.Marshal(remote.CancelRequest{CommandID: cmd.GetCommandId()})
+	if err != nil {
+		return
+	}
+
+	select {
+	case w.CommandCh <- &workerpb.Command{CommandId: uuid.NewString(), Type: workerpb.CommandType_COMMAND_TYPE_CANCEL, Payload: payload}:
+	default:
+		logger.Warningf("worker %s: command channel full, command %s not cancelled", w.WorkerName, cmd.GetCommandId())
+	}
+}
+
+// interruptSent handles the commands that were in flight on a stream that is gone.
+// Streamed commands fail at once: their output cannot be replayed. Unary commands are
+// returned so they can be resent on the next stream; the worker answers a command it
+// already ran from its result cache, so nothing is executed twice.
+func (w *WorkerEntry) interruptSent() []*workerpb.Command {
+	w.resultsMu.Lock()
+	defer w.resultsMu.Unlock()
+
+	var resend []*workerpb.Command
+	for id, p := range w.results {
+		if !p.sent {
+			continue
+		}
+
+		if !p.stream {
+			if p.cmd != nil {
+				p.sent = false
+				if !p.deadline.IsZero() {
+					p.cmd.TimeoutMs = timeoutMillis(p.deadline)
+				}
+				resend = append(resend, p.cmd)
+			}
+
+			continue
+		}
+
+		delete(w.results, id)
+		res := &workerpb.CommandResult{
+			CommandId:   id,
+			Error:       fmt.Sprintf("worker %s: command stream interrupted", w.WorkerName),
+			EndOfStream: true,
+		}
 		select {
-		case ch <- res:
+		case p.ch <- res:
 		default:
+			close(p.overflow)
+		}
+	}
+
+	return resend
+}
+
+// resend queues cmds again, giving up on a command that finds no room within resendTimeout.
+func (w *WorkerEntry) resend(cmds []*workerpb.Command) {
+	for _, cmd := range cmds {
+		select {
+		case w.CommandCh <- cmd:
+		case <-time.After(resendTimeout):
+			logger.Warningf("worker %s: failed to resend command %s: command channel full", w.WorkerName, cmd.GetCommandId())
 		}
 	}
 }
 
+// timeoutMillis converts a deadline to the Command.timeout_ms the worker enforces.
+// A deadline that has passed yields 1 ms rather than 0, which would mean no limit.
+func timeoutMillis(deadline time.Time) int64 {
+	return max(time.Until(deadline).Milliseconds(), 1)
+}
+
+var (
+	// ErrWorkerNotFound is returned when no worker row matches the given ID.
+	ErrWorkerNotFound = errors.New("worker not found")
+
+	// ErrWorkerNotRegistered is returned by Attach and CheckRegistered for a worker that
+	// has not registered with this control plane, was deregistered, or was given a new
+	// bootstrap token it has not registered with yet.
+	ErrWorkerNotRegistered = errors.New("worker not registered")
+
+	// ErrCommandUnsupported is returned by Dispatch and DispatchStream for a command
+	// type the worker did not declare in its capabilities.
+	ErrCommandUnsupported = errors.New("command not supported by worker")
+)
+
 // Registry tracks all currently-connected workers by name.
 type Registry struct {
-	mu         sync.RWMutex
-	workers    map[string]*WorkerEntry
+	mu      sync.RWMutex
+	workers map[string]*WorkerEntry
+	// detached holds the entries of workers whose stream dropped, so commands still
+	// waiting for a result are resumed when the worker reconnects.
+	detached   map[string]*WorkerEntry
 	repo       repository.WorkerRepository // may be nil in tests
-	tokenStore *TokenStore
+	tokenStore *TokenStore                 // nil when no token repository is configured
+	// onDisconnect is called after a worker is marked disconnected; nil when not set.
+	onDisconnect func(ctx context.Context, workerID uuid.UUID, workerName string)
 }
 
-// New creates a new Registry backed by the given WorkerRepository.
-// Pass nil for tests that do not need DB persistence.
-func New(repo repository.WorkerRepository) *Registry {
-	return &Registry{
-		workers:    make(map[string]*WorkerEntry),
-		repo:       repo,
-		tokenStore: NewTokenStore(),
+// New creates a new Registry backed by the given worker and bootstrap token repositories.
+// Pass nil for tests that do not need DB persistence; without a token repository
+// no bootstrap tokens can be issued or validated.
+func New(repo repository.WorkerRepository, tokenRepo repository.WorkerTokenRepository) *Registry {
+	r := &Registry{
+		workers:  make(map[string]*WorkerEntry),
+		detached: make(map[string]*WorkerEntry),
+		repo:     repo,
+	}
+	if tokenRepo != nil {
+		r.tokenStore = NewTokenStore(tokenRepo)
 	}
+
+	return r
+}
+
+// SetDisconnectHook sets the function called after a worker is marked disconnected,
+// whether its stream ended or its heartbeat timed out. It must be set before the
+// gateway starts.
+func (r *Registry) SetDisconnectHook(hook func(ctx context.Context, workerID uuid.UUID, workerName string)) {
+	r.onDisconnect = hook
 }
 
 // Register upserts the worker into the DB (status=ready, with provided metadata)
@@ -95,8 +340,9 @@ func New(repo repository.WorkerRepository) *Registry {
 // workerName must come from the validated token — callers must not trust the name
 // the worker declares in its RegisterRequest.
 // runtimeType must be one of the supported values ("podman", "openshift");
-// an unsupported or empty value is rejected with an error.
-func (r *Registry) Register(ctx context.Context, workerName, runtimeType string, metadata map[string]string) (*WorkerEntry, error) {
+// an unsupported or empty value is rejected with an error. caps are the capabilities
+// the worker declared; nil means it predates capability negotiation.
+func (r *Registry) Register(ctx context.Context, workerName, runtimeType string, metadata map[string]string, caps *workerpb.Capabilities) (*WorkerEntry, error) {
 	rt, err := runtimeTypeFromString(runtimeType)
 	if err != nil {
 		return nil, err
@@ -105,21 +351,25 @@ func (r *Registry) Register(ctx context.Context, workerName, runtimeType string,
 	r.mu.Lock()
 	entry, exists := r.workers[workerName]
 	if !exists {
-		entry = &WorkerEntry{
-			WorkerName: workerName,
-			CommandCh:  make(chan *workerpb.Command, commandChannelSize),
-			results:    make(map[string]chan *workerpb.CommandResult),
-		}
-		r.workers[workerName] = entry
+		entry, exists = r.detached[workerName]
+		delete(r.detached, workerName)
+	}
+	if !exists {
+		entry = newWorkerEntry(workerName, uuid.Nil)
 	}
+	r.workers[workerName] = entry
 	r.mu.Unlock()
 
+	caps = entry.setCapabilities(caps)
+
 	if r.repo != nil {
 		w := &models.Worker{
-			Name:        workerName,
-			RuntimeType: rt,
-			Status:      models.WorkerStatusReady,
-			Metadata:    metadataToAny(metadata),
+			Name:              workerName,
+			RuntimeType:       rt,
+			Status:            models.WorkerStatusReady,
+			Metadata:          metadataToAny(metadata),
+			ProtocolVersion:   int(caps.GetProtocolVersion()),
+			SupportedCommands: commandNames(caps),
 		}
 		if err := r.repo.Upsert(ctx, w); err != nil {
 			logger.WarningfCtx(ctx, "worker registry: DB upsert failed for %s: %v", workerName, err)
@@ -136,10 +386,11 @@ func (r *Registry) Register(ctx context.Context, workerName, runtimeType string,
 // Preregister creates a pending DB row for a named worker and returns a single-use
 // bootstrap token the operator passes to the worker daemon at startup.
 // If a row already exists (re-registration), it is reset to pending and a new token
-// supersedes the old one. The registry's in-memory map is not touched — the worker
-// is not "connected" until it calls Register via gRPC.
+// supersedes the old one; the worker's command stream is closed and its client
+// certificate is refused until it registers with the new token. The worker is not
+// "connected" until it calls Register via gRPC.
 func (r *Registry) Preregister(ctx context.Context, workerName string) (string, error) {
-	if r.repo == nil {
+	if r.repo == nil || r.tokenStore == nil {
 		return "", fmt.Errorf("worker registry: no repository configured")
 	}
 
@@ -152,7 +403,138 @@ func (r *Registry) Preregister(ctx context.Context, workerName string) (string,
 		return "", fmt.Errorf("worker registry: DB upsert failed for %s: %w", workerName, err)
 	}
 
-	return r.tokenStore.IssueToken(workerName), nil
+	r.closeStream(workerName)
+
+	token, _, err := r.tokenStore.IssueToken(ctx, w.ID)
+	if err != nil {
+		return "", fmt.Errorf("worker registry: failed to issue token for %s: %w", workerName, err)
+	}
+
+	return token, nil
+}
+
+// ReissueToken revokes the outstanding bootstrap tokens of an existing worker and
+// issues a new one. It lets an admin recover a worker whose token expired or was lost.
+// A registered worker goes back to pending: its command stream is closed and its
+// client certificate is refused until it registers with the new token.
+// Returns ErrWorkerNotFound for an unknown ID.
+func (r *Registry) ReissueToken(ctx context.Context, id uuid.UUID) (*models.Worker, string, *models.WorkerToken, error) {
+	w, err := r.lookupWorker(ctx, id)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	if w.Status != models.WorkerStatusPending {
+		status := models.WorkerStatusPending
+		if err := r.repo.Update(ctx, w.ID, repository.WorkerUpdate{Status: &status}); err != nil {
+			return nil, "", nil, fmt.Errorf("worker registry: DB status update to %s failed for %s: %w", status, w.Name, err)
+		}
+		w.Status = status
+		r.closeStream(w.Name)
+	}
+
+	token, rec, err := r.tokenStore.IssueToken(ctx, w.ID)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("worker registry: failed to issue token for %s: %w", w.Name, err)
+	}
+
+	return w, token, rec, nil
+}
+
+// ListTokens returns the bootstrap tokens issued for a worker, newest first.
+// Returns ErrWorkerNotFound for an unknown ID.
+func (r *Registry) ListTokens(ctx context.Context, id uuid.UUID) ([]models.WorkerToken, error) {
+	if _, err := r.lookupWorker(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return r.tokenStore.List(ctx, id)
+}
+
+// RevokeToken revokes an unused bootstrap token of a worker.
+// Returns (false, nil) if the worker has no such active token.
+func (r *Registry) RevokeToken(ctx context.Context, workerID, tokenID uuid.UUID) (bool, error) {
+	if r.tokenStore == nil {
+		return false, fmt.Errorf("worker registry: no repository configured")
+	}
+
+	return r.tokenStore.Revoke(ctx, workerID, tokenID)
+}
+
+// closeStream ends the command stream of a worker that must register again. Its entry is
+// moved to the detached ones without marking the worker disconnected, so its row stays
+// pending, and commands still waiting for a result are resumed once it registers.
+func (r *Registry) closeStream(workerName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if entry, ok := r.workers[workerName]; ok {
+		r.detachLocked(entry)
+	}
+}
+
+// lookupWorker fetches a worker row by ID for the token management calls.
+func (r *Registry) lookupWorker(ctx context.Context, id uuid.UUID) (*models.Worker, error) {
+	if r.repo == nil || r.tokenStore == nil {
+		return nil, fmt.Errorf("worker registry: no repository configured")
+	}
+
+	w, err := r.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("worker registry: DB lookup failed for %s: %w", id, err)
+	}
+	if w == nil {
+		return nil, ErrWorkerNotFound
+	}
+
+	return w, nil
+}
+
+// GetWorker returns the worker row with the given ID.
+// Returns ErrWorkerNotFound for an unknown ID.
+func (r *Registry) GetWorker(ctx context.Context, id uuid.UUID) (*models.Worker, error) {
+	if r.repo == nil {
+		return nil, fmt.Errorf("worker registry: no repository configured")
+	}
+
+	w, err := r.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("worker registry: DB lookup failed for %s: %w", id, err)
+	}
+	if w == nil {
+		return nil, ErrWorkerNotFound
+	}
+	markOutdated(w)
+
+	return w, nil
+}
+
+// WorkerName returns the name of the worker with the given ID.
+// Returns ErrWorkerNotFound for an unknown ID.
+func (r *Registry) WorkerName(ctx context.Context, id uuid.UUID) (string, error) {
+	w, err := r.GetWorker(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	return w.Name, nil
+}
+
+// SetSchedulable cordons (false) or uncordons (true) a worker and returns the updated
+// row. A cordoned worker keeps its applications but receives no new placements.
+// Returns ErrWorkerNotFound for an unknown ID.
+func (r *Registry) SetSchedulable(ctx context.Context, id uuid.UUID, schedulable bool) (*models.Worker, error) {
+	w, err := r.GetWorker(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.repo.Update(ctx, id, repository.WorkerUpdate{Schedulable: &schedulable}); err != nil {
+		return nil, fmt.Errorf("worker registry: DB update failed for %s: %w", w.Name, err)
+	}
+	w.Schedulable = schedulable
+
+	return w, nil
 }
 
 // List returns all worker rows from the database ordered by registered_at ascending.
@@ -161,7 +543,54 @@ func (r *Registry) List(ctx context.Context) ([]models.Worker, error) {
 		return nil, nil
 	}
 
-	return r.repo.GetAll(ctx)
+	workers, err := r.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range workers {
+		markOutdated(&workers[i])
+	}
+
+	return workers, nil
+}
+
+// markOutdated flags a worker that speaks an older protocol than this control plane.
+// A pending worker has not declared a protocol yet and is not flagged.
+func markOutdated(w *models.Worker) {
+	w.Outdated = w.Status != models.WorkerStatusPending && w.ProtocolVersion < int(workerpb.ProtocolVersion)
+}
+
+// commandNames returns the names of the supported commands in caps, as stored in the DB.
+func commandNames(caps *workerpb.Capabilities) []string {
+	names := make([]string, 0, len(caps.GetSupportedCommands()))
+	for _, t := range caps.GetSupportedCommands() {
+		names = append(names, t.String())
+	}
+
+	return names
+}
+
+// newWorkerEntry returns the in-memory entry of a worker with no command stream yet.
+func newWorkerEntry(workerName string, dbID uuid.UUID) *WorkerEntry {
+	return &WorkerEntry{
+		DBID:       dbID,
+		WorkerName: workerName,
+		CommandCh:  make(chan *workerpb.Command, commandChannelSize),
+		results:    make(map[string]*pendingResult),
+	}
+}
+
+// capabilitiesFromNames rebuilds the capabilities persisted by commandNames. Unknown
+// command names are skipped.
+func capabilitiesFromNames(protocolVersion int, names []string) *workerpb.Capabilities {
+	caps := &workerpb.Capabilities{ProtocolVersion: uint32(protocolVersion)} //nolint:gosec
+	for _, name := range names {
+		if t, ok := workerpb.CommandType_value[name]; ok {
+			caps.SupportedCommands = append(caps.SupportedCommands, workerpb.CommandType(t))
+		}
+	}
+
+	return caps
 }
 
 // Get returns the in-memory entry for a connected worker, or false if not found.
@@ -173,21 +602,214 @@ func (r *Registry) Get(workerName string) (*WorkerEntry, bool) {
 	return e, ok
 }
 
+// IsConnected reports whether the named worker currently holds an open command stream.
+func (r *Registry) IsConnected(workerName string) bool {
+	_, ok := r.Get(workerName)
+
+	return ok
+}
+
+// InFlight returns the number of commands dispatched to the named worker that are
+// still waiting for their result. It is 0 for a worker that is not connected.
+func (r *Registry) InFlight(workerName string) int {
+	entry, ok := r.Get(workerName)
+	if !ok {
+		return 0
+	}
+
+	entry.resultsMu.Lock()
+	defer entry.resultsMu.Unlock()
+
+	return len(entry.results)
+}
+
+// Attachment is a command stream bound to a worker entry by Attach.
+type Attachment struct {
+	Entry *WorkerEntry
+	// Superseded is closed when a newer stream of the same worker replaces this one.
+	Superseded <-chan struct{}
+
+	stream uint64
+}
+
+// Attach binds a new command stream to the named worker. A worker whose previous
+// stream dropped is reconnected and marked ready again; its in-flight streamed
+// commands fail and its in-flight unary commands are resent on the new stream.
+// A stream the worker still holds is superseded. A worker that registered before
+// the control plane restarted has no in-memory entry yet; it gets one from its DB row.
+// caps are the capabilities declared on the new stream; nil keeps those known from
+// registration. Attach returns ErrWorkerNotRegistered for a worker that has not
+// registered with this control plane.
+func (r *Registry) Attach(ctx context.Context, workerName string, caps *workerpb.Capabilities) (*Attachment, error) {
+	w, err := r.lookupWorkerByName(ctx, workerName)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	entry, ok := r.workers[workerName]
+	reconnected := false
+	if !ok {
+		entry, ok = r.detached[workerName]
+		switch {
+		case ok:
+			delete(r.detached, workerName)
+		case w != nil:
+			entry = newWorkerEntry(workerName, w.ID)
+			entry.setCapabilities(capabilitiesFromNames(w.ProtocolVersion, w.SupportedCommands))
+		default:
+			r.mu.Unlock()
+
+			return nil, fmt.Errorf("worker %s: %w", workerName, ErrWorkerNotRegistered)
+		}
+		r.workers[workerName] = entry
+		reconnected = true
+	}
+
+	if entry.superseded != nil {
+		close(entry.superseded)
+	}
+	superseded := make(chan struct{})
+	entry.superseded = superseded
+	entry.stream++
+	att := &Attachment{Entry: entry, Superseded: superseded, stream: entry.stream}
+	r.mu.Unlock()
+
+	if caps != nil {
+		r.setCapabilities(ctx, entry, caps)
+	}
+	if reconnected {
+		r.setStatus(ctx, entry, models.WorkerStatusReady)
+		logger.InfofCtx(ctx, "worker registry: worker %s reconnected", workerName)
+	}
+	if resend := entry.interruptSent(); len(resend) > 0 {
+		logger.InfofCtx(ctx, "worker registry: resending %d in-flight commands to worker %s", len(resend), workerName)
+		go entry.resend(resend)
+	}
+
+	return att, nil
+}
+
+// CheckRegistered returns ErrWorkerNotRegistered unless the named worker has registered
+// with this control plane, e.g. before it is issued a new client certificate.
+func (r *Registry) CheckRegistered(ctx context.Context, workerName string) error {
+	w, err := r.lookupWorkerByName(ctx, workerName)
+	if err != nil || w != nil {
+		return err
+	}
+
+	r.mu.RLock()
+	_, connected := r.workers[workerName]
+	_, detached := r.detached[workerName]
+	r.mu.RUnlock()
+	if !connected && !detached {
+		return fmt.Errorf("worker %s: %w", workerName, ErrWorkerNotRegistered)
+	}
+
+	return nil
+}
+
+// lookupWorkerByName returns the DB row of a registered worker. A worker without a row,
+// e.g. one that was deregistered, or with a pending row, because it was given a new
+// bootstrap token, yields ErrWorkerNotRegistered: client certificates are not revoked,
+// so the row decides whether the one it presents is still accepted. Without a
+// repository it returns (nil, nil) and only the in-memory entries are known.
+func (r *Registry) lookupWorkerByName(ctx context.Context, workerName string) (*models.Worker, error) {
+	if r.repo == nil {
+		return nil, nil
+	}
+
+	w, err := r.repo.GetByName(ctx, workerName)
+	if err != nil {
+		return nil, fmt.Errorf("worker registry: DB lookup failed for %s: %w", workerName, err)
+	}
+	if w == nil || w.Status == models.WorkerStatusPending {
+		return nil, fmt.Errorf("worker %s: %w", workerName, ErrWorkerNotRegistered)
+	}
+
+	return w, nil
+}
+
+// Detach marks the worker of att disconnected once its stream ends. It is a no-op
+// when a newer stream has been attached in the meantime.
+func (r *Registry) Detach(ctx context.Context, att *Attachment) {
+	r.mu.Lock()
+	entry, ok := r.workers[att.Entry.WorkerName]
+	if !ok || entry != att.Entry || entry.stream != att.stream {
+		r.mu.Unlock()
+
+		return
+	}
+	r.detachLocked(entry)
+	r.mu.Unlock()
+
+	r.markDisconnected(ctx, entry)
+}
+
 // Disconnect removes the worker from the in-memory map and marks it disconnected in the DB.
-// The DB row is kept so the worker can reconnect and its history is preserved.
+// The DB row is kept so the worker can reconnect and its history is preserved; commands
+// still waiting for a result are resumed if it does.
 func (r *Registry) Disconnect(ctx context.Context, workerName string) {
 	r.mu.Lock()
 	entry, ok := r.workers[workerName]
 	if ok {
-		delete(r.workers, workerName)
+		r.detachLocked(entry)
 	}
 	r.mu.Unlock()
 
-	if ok && r.repo != nil && entry.DBID != uuid.Nil {
-		if err := r.repo.Update(ctx, entry.DBID, repository.WorkerUpdate{Status: utils.Ptr(models.WorkerStatusDisconnected)}); err != nil {
-			logger.WarningfCtx(ctx, "worker registry: DB disconnect update failed for %s: %v", workerName, err)
+	if ok {
+		r.markDisconnected(ctx, entry)
+	}
+}
+
+// detachLocked moves entry from the connected to the detached workers. r.mu must be held.
+func (r *Registry) detachLocked(entry *WorkerEntry) {
+	delete(r.workers, entry.WorkerName)
+	r.detached[entry.WorkerName] = entry
+	if entry.superseded != nil {
+		close(entry.superseded)
+		entry.superseded = nil
+	}
+}
+
+// setStatus records the connection status of a worker in the DB.
+func (r *Registry) setStatus(ctx context.Context, entry *WorkerEntry, status models.WorkerStatus) {
+	if r.repo == nil || entry.DBID == uuid.Nil {
+		return
+	}
+
+	if err := r.repo.Update(ctx, entry.DBID, repository.WorkerUpdate{Status: &status}); err != nil {
+		logger.WarningfCtx(ctx, "worker registry: DB status update to %s failed for %s: %v", status, entry.WorkerName, err)
+	}
+}
+
+// markDisconnected records that the worker of entry is disconnected and calls the
+// disconnect hook, unless the sweeper already marked the worker disconnected.
+func (r *Registry) markDisconnected(ctx context.Context, entry *WorkerEntry) {
+	notify := r.onDisconnect != nil
+	if notify && r.repo != nil && entry.DBID != uuid.Nil {
+		if w, err := r.repo.GetByID(ctx, entry.DBID); err == nil && w != nil && w.Status == models.WorkerStatusDisconnected {
+			notify = false
 		}
 	}
+
+	r.setStatus(ctx, entry, models.WorkerStatusDisconnected)
+	if notify {
+		r.onDisconnect(ctx, entry.DBID, entry.WorkerName)
+	}
+}
+
+// setCapabilities records the capabilities declared on a new stream, in memory and in the DB.
+func (r *Registry) setCapabilities(ctx context.Context, entry *WorkerEntry, caps *workerpb.Capabilities) {
+	caps = entry.setCapabilities(caps)
+	if r.repo == nil || entry.DBID == uuid.Nil {
+		return
+	}
+
+	update := repository.WorkerUpdate{ProtocolVersion: int(caps.GetProtocolVersion()), SupportedCommands: commandNames(caps)}
+	if err := r.repo.Update(ctx, entry.DBID, update); err != nil {
+		logger.WarningfCtx(ctx, "worker registry: DB capabilities update failed for %s: %v", entry.WorkerName, err)
+	}
 }
 
 // SweepStale fetches all workers from the DB and marks any whose last heartbeat
@@ -207,13 +829,19 @@ func (r *Registry) SweepStale(ctx context.Context, timeout time.Duration) {
 	now := time.Now()
 
 	for _, w := range workers {
-		if w.Status == models.WorkerStatusDisconnected {
+		// Pending workers have not registered yet, or must register again.
+		if w.Status == models.WorkerStatusDisconnected || w.Status == models.WorkerStatusPending {
 			continue
 		}
 		if w.LastHeartbeat == nil || now.Sub(*w.LastHeartbeat) > timeout {
 			logger.WarningfCtx(ctx, "worker registry: worker %s heartbeat timed out — marking disconnected", w.Name)
 			if err := r.repo.Update(ctx, w.ID, repository.WorkerUpdate{Status: utils.Ptr(models.WorkerStatusDisconnected)}); err != nil {
 				logger.WarningfCtx(ctx, "worker registry: failed to update stale worker %s: %v", w.Name, err)
+
+				continue
+			}
+			if r.onDisconnect != nil {
+				r.onDisconnect(ctx, w.ID, w.Name)
 			}
 		}
 	}
@@ -242,14 +870,19 @@ func (r *Registry) UpdateHeartbeat(ctx context.Context, workerName string) {
 
 // Deregister removes the worker from the in-memory map and hard-deletes its DB row by UUID.
 // Use this when a worker is permanently decommissioned, not just temporarily offline.
+// Its command stream is closed and its client certificate is refused from then on.
 // Returns (true, nil) if a row was deleted, (false, nil) if not found.
 func (r *Registry) Deregister(ctx context.Context, id uuid.UUID) (bool, error) {
 	r.mu.Lock()
-	for name, entry := range r.workers {
-		if entry.DBID == id {
-			delete(r.workers, name)
-
-			break
+	for _, workers := range []map[string]*WorkerEntry{r.workers, r.detached} {
+		for name, entry := range workers {
+			if entry.DBID == id {
+				delete(workers, name)
+				if entry.superseded != nil {
+					close(entry.superseded)
+					entry.superseded = nil
+				}
+			}
 		}
 	}
 	r.mu.Unlock()
@@ -288,6 +921,84 @@ func (r *Registry) WaitForResult(workerName, commandID string) (chan *workerpb.C
 	return entry.waitForResult(commandID), nil
 }
 
+// Dispatch queues cmd on the named worker's CommandCh and blocks until the matching
+// CommandResult arrives or ctx is done. It is the transport used by RemoteRuntime.
+// The deadline of ctx is sent along as the command's timeout. When ctx ends first the
+// pending result slot is released, so late results are dropped, and the worker is
+// told to cancel the command. If the worker's stream drops while the command is in
+// flight, the command is resent once the worker reconnects. A command type the worker
+// did not declare is refused with ErrCommandUnsupported.
+func (r *Registry) Dispatch(ctx context.Context, workerName string, cmd *workerpb.Command) (*workerpb.CommandResult, error) {
+	entry, ok := r.Get(workerName)
+	if !ok {
+		return nil, fmt.Errorf("worker %s not connected", workerName)
+	}
+	if err := entry.checkSupported(cmd.GetType()); err != nil {
+		return nil, err
+	}
+
+	pending := entry.expectResult(ctx, cmd, false)
+
+	if err := entry.queue(ctx, cmd); err != nil {
+		return nil, err
+	}
+
+	select {
+	case res := <-pending.ch:
+		return res, nil
+	case <-ctx.Done():
+		entry.abandon(cmd)
+
+		return nil, fmt.Errorf("worker %s: no result for command %s: %w", workerName, cmd.GetType(), ctx.Err())
+	}
+}
+
+// DispatchStream queues a streamed command (logs, exec) on the named worker and passes
+// the output of every frame to onChunk, in order, until the final result arrives and is
+// returned. An error from onChunk or the end of ctx stops the wait and cancels the
+// command on the worker, so e.g. a followed log does not outlive its reader; output
+// that is still in flight is then dropped. A streamed command fails if the worker's
+// stream drops. Like Dispatch, it refuses command types the worker did not declare.
+func (r *Registry) DispatchStream(ctx context.Context, workerName string, cmd *workerpb.Command, onChunk func([]byte) error) (*workerpb.CommandResult, error) {
+	entry, ok := r.Get(workerName)
+	if !ok {
+		return nil, fmt.Errorf("worker %s not connected", workerName)
+	}
+	if err := entry.checkSupported(cmd.GetType()); err != nil {
+		return nil, err
+	}
+
+	pending := entry.expectResult(ctx, cmd, true)
+
+	if err := entry.queue(ctx, cmd); err != nil {
+		return nil, err
+	}
+
+	for {
+		select {
+		case res := <-pending.ch:
+			if len(res.GetChunk()) > 0 {
+				if err := onChunk(res.GetChunk()); err != nil {
+					entry.abandon(cmd)
+
+					return nil, err
+				}
+			}
+			if !isFrame(res) {
+				return res, nil
+			}
+		case <-pending.overflow:
+			entry.abandon(cmd)
+
+			return nil, fmt.Errorf("worker %s: output of command %s dropped: reader too slow", workerName, cmd.GetType())
+		case <-ctx.Done():
+			entry.abandon(cmd)
+
+			return nil, fmt.Errorf("worker %s: output of command %s interrupted: %w", workerName, cmd.GetType(), ctx.Err())
+		}
+	}
+}
+
 // ──────────────────────────────────────────────────────────────────────────────
 // Internal helpers
 // ──────────────────────────────────────────────────────────────────────────────
@@ -323,6 +1034,10 @@ func runtimeTypeFromString(s string) (models.WorkerRuntimeType, error) {
 // ValidateToken checks a bootstrap token, marks it used, and returns the worker name it was
 // issued for. Exposed on Registry so callers (gateway, tests) do not need to hold a
 // separate TokenStore reference.
-func (r *Registry) ValidateToken(token string) (string, error) {
-	return r.tokenStore.Validate(token)
+func (r *Registry) ValidateToken(ctx context.Context, token string) (string, error) {
+	if r.tokenStore == nil {
+		return "", fmt.Errorf("bootstrap token not found")
+	}
+
+	return r.tokenStore.Validate(ctx, token)
 }
@@ -2,6 +2,8 @@ package registry
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -30,8 +32,10 @@ func newFakeWorkerRepo() *fakeWorkerRepo {
 func (r *fakeWorkerRepo) Upsert(_ context.Context, w *models.Worker) error {
 	if existing, ok := r.workers[w.Name]; ok {
 		w.ID = existing.ID
+		w.Schedulable = existing.Schedulable
 	} else {
 		w.ID = uuid.New()
+		w.Schedulable = true
 	}
 	w.RegisteredAt = time.Now()
 	w.UpdatedAt = time.Now()
@@ -52,6 +56,13 @@ func (r *fakeWorkerRepo) Update(_ context.Context, id uuid.UUID, u repository.Wo
 	if u.LastHeartbeat != nil {
 		w.LastHeartbeat = u.LastHeartbeat
 	}
+	if u.Schedulable != nil {
+		w.Schedulable = *u.Schedulable
+	}
+	if u.SupportedCommands != nil {
+		w.ProtocolVersion = u.ProtocolVersion
+		w.SupportedCommands = u.SupportedCommands
+	}
 	return nil
 }
 
@@ -73,14 +84,125 @@ func (r *fakeWorkerRepo) GetAll(_ context.Context) ([]models.Worker, error) {
 	return out, nil
 }
 
+func (r *fakeWorkerRepo) GetByID(_ context.Context, id uuid.UUID) (*models.Worker, error) {
+	w, ok := r.byID[id]
+	if !ok {
+		return nil, nil
+	}
+	cp := *w
+	return &cp, nil
+}
+
+func (r *fakeWorkerRepo) GetByName(_ context.Context, name string) (*models.Worker, error) {
+	w, ok := r.workers[name]
+	if !ok {
+		return nil, nil
+	}
+	cp := *w
+	return &cp, nil
+}
+
 var _ repository.WorkerRepository = (*fakeWorkerRepo)(nil)
 
+// currentCapabilities declares every command type at the current protocol version.
+func currentCapabilities() *workerpb.Capabilities {
+	caps := &workerpb.Capabilities{ProtocolVersion: workerpb.ProtocolVersion}
+	for t := range workerpb.CommandType_name {
+		if t != 0 {
+			caps.SupportedCommands = append(caps.SupportedCommands, workerpb.CommandType(t))
+		}
+	}
+	return caps
+}
+
+// fakeTokenRepo is an in-memory WorkerTokenRepository. Worker names are resolved
+// through the companion fakeWorkerRepo, as the SQL implementation joins workers.
+type fakeTokenRepo struct {
+	workers *fakeWorkerRepo
+	tokens  []*models.WorkerToken
+}
+
+func newFakeTokenRepo(workers *fakeWorkerRepo) *fakeTokenRepo {
+	return &fakeTokenRepo{workers: workers}
+}
+
+func (r *fakeTokenRepo) Create(_ context.Context, t *models.WorkerToken) error {
+	t.ID = uuid.New()
+	t.CreatedAt = time.Now()
+	cp := *t
+	r.tokens = append(r.tokens, &cp)
+	return nil
+}
+
+func (r *fakeTokenRepo) GetByHash(_ context.Context, hash string) (*models.WorkerToken, error) {
+	for _, t := range r.tokens {
+		if t.TokenHash == hash {
+			cp := *t
+			if w, ok := r.workers.byID[t.WorkerID]; ok {
+				cp.WorkerName = w.Name
+			}
+			return &cp, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeTokenRepo) MarkUsed(_ context.Context, id uuid.UUID) (bool, error) {
+	for _, t := range r.tokens {
+		if t.ID == id && t.UsedAt == nil && t.RevokedAt == nil {
+			now := time.Now()
+			t.UsedAt = &now
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeTokenRepo) ListByWorker(_ context.Context, workerID uuid.UUID) ([]models.WorkerToken, error) {
+	var out []models.WorkerToken
+	for i := len(r.tokens) - 1; i >= 0; i-- {
+		if r.tokens[i].WorkerID == workerID {
+			out = append(out, *r.tokens[i])
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeTokenRepo) Revoke(_ context.Context, workerID, tokenID uuid.UUID) (bool, error) {
+	for _, t := range r.tokens {
+		if t.ID == tokenID && t.WorkerID == workerID && t.UsedAt == nil && t.RevokedAt == nil {
+			now := time.Now()
+			t.RevokedAt = &now
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeTokenRepo) RevokeAllForWorker(_ context.Context, workerID uuid.UUID) error {
+	now := time.Now()
+	for _, t := range r.tokens {
+		if t.WorkerID == workerID && t.UsedAt == nil && t.RevokedAt == nil {
+			t.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+var _ repository.WorkerTokenRepository = (*fakeTokenRepo)(nil)
+
+// newTestRegistry returns a registry backed by the in-memory fakes.
+func newTestRegistry() *Registry {
+	repo := newFakeWorkerRepo()
+	return New(repo, newFakeTokenRepo(repo))
+}
+
 // ──────────────────────────────────────────────────────────────────────────────
 // Preregister tests
 // ──────────────────────────────────────────────────────────────────────────────
 
 func TestRegistry_Preregister_IssuesToken(t *testing.T) {
-	reg := New(newFakeWorkerRepo())
+	reg := newTestRegistry()
 
 	token, err := reg.Preregister(context.Background(), "worker-a")
 	if err != nil {
@@ -93,7 +215,7 @@ func TestRegistry_Preregister_IssuesToken(t *testing.T) {
 
 func TestRegistry_Preregister_CreatesPendingRow(t *testing.T) {
 	repo := newFakeWorkerRepo()
-	reg := New(repo)
+	reg := New(repo, newFakeTokenRepo(repo))
 
 	if _, err := reg.Preregister(context.Background(), "worker-a"); err != nil {
 		t.Fatalf("Preregister: %v", err)
@@ -109,7 +231,7 @@ func TestRegistry_Preregister_CreatesPendingRow(t *testing.T) {
 }
 
 func TestRegistry_Preregister_NoRepo(t *testing.T) {
-	reg := New(nil)
+	reg := New(nil, nil)
 
 	if _, err := reg.Preregister(context.Background(), "worker-a"); err == nil {
 		t.Fatal("expected error when no repository is configured")
@@ -117,14 +239,14 @@ func TestRegistry_Preregister_NoRepo(t *testing.T) {
 }
 
 func TestRegistry_Preregister_TokenIsValidatable(t *testing.T) {
-	reg := New(newFakeWorkerRepo())
+	reg := newTestRegistry()
 
 	token, err := reg.Preregister(context.Background(), "worker-a")
 	if err != nil {
 		t.Fatalf("Preregister: %v", err)
 	}
 
-	name, err := reg.ValidateToken(token)
+	name, err := reg.ValidateToken(context.Background(), token)
 	if err != nil {
 		t.Fatalf("ValidateToken: %v", err)
 	}
@@ -134,30 +256,135 @@ func TestRegistry_Preregister_TokenIsValidatable(t *testing.T) {
 }
 
 func TestRegistry_Preregister_TokenNotReusable(t *testing.T) {
-	reg := New(newFakeWorkerRepo())
+	reg := newTestRegistry()
 
 	token, err := reg.Preregister(context.Background(), "worker-a")
 	if err != nil {
 		t.Fatalf("Preregister: %v", err)
 	}
 
-	if _, err := reg.ValidateToken(token); err != nil {
+	if _, err := reg.ValidateToken(context.Background(), token); err != nil {
 		t.Fatalf("first ValidateToken: %v", err)
 	}
 
-	if _, err := reg.ValidateToken(token); err == nil {
+	if _, err := reg.ValidateToken(context.Background(), token); err == nil {
 		t.Fatal("second ValidateToken: expected error for already-used token")
 	}
 }
 
+// ──────────────────────────────────────────────────────────────────────────────
+// Token management tests
+// ──────────────────────────────────────────────────────────────────────────────
+
+func TestRegistry_ReissueToken_RecoversPendingWorker(t *testing.T) {
+	repo := newFakeWorkerRepo()
+	reg := New(repo, newFakeTokenRepo(repo))
+
+	oldToken, err := reg.Preregister(context.Background(), "worker-a")
+	if err != nil {
+		t.Fatalf("Preregister: %v", err)
+	}
+	id := repo.workers["worker-a"].ID
+
+	w, newToken, rec, err := reg.ReissueToken(context.Background(), id)
+	if err != nil {
+		t.Fatalf("ReissueToken: %v", err)
+	}
+	if w.Name != "worker-a" || rec.Status != models.WorkerTokenStatusActive {
+		t.Errorf("unexpected reissue result: worker=%q status=%q", w.Name, rec.Status)
+	}
+
+	if _, err := reg.ValidateToken(context.Background(), oldToken); err == nil {
+		t.Error("expected the old token to be revoked by the reissue")
+	}
+	if name, err := reg.ValidateToken(context.Background(), newToken); err != nil || name != "worker-a" {
+		t.Errorf("ValidateToken(new) = %q, %v", name, err)
+	}
+
+	// The worker row is left untouched.
+	if repo.workers["worker-a"].Status != models.WorkerStatusPending {
+		t.Errorf("status = %q, want pending", repo.workers["worker-a"].Status)
+	}
+}
+
+func TestRegistry_ListAndRevokeTokens(t *testing.T) {
+	repo := newFakeWorkerRepo()
+	reg := New(repo, newFakeTokenRepo(repo))
+
+	token, err := reg.Preregister(context.Background(), "worker-a")
+	if err != nil {
+		t.Fatalf("Preregister: %v", err)
+	}
+	id := repo.workers["worker-a"].ID
+
+	tokens, err := reg.ListTokens(context.Background(), id)
+	if err != nil {
+		t.Fatalf("ListTokens: %v", err)
+	}
+	if len(tokens) != 1 || tokens[0].Status != models.WorkerTokenStatusActive {
+		t.Fatalf("tokens = %+v", tokens)
+	}
+
+	revoked, err := reg.RevokeToken(context.Background(), id, tokens[0].ID)
+	if err != nil || !revoked {
+		t.Fatalf("RevokeToken = %v, %v", revoked, err)
+	}
+	if revoked, _ := reg.RevokeToken(context.Background(), id, tokens[0].ID); revoked {
+		t.Error("revoking twice should report no active token")
+	}
+	if _, err := reg.ValidateToken(context.Background(), token); err == nil {
+		t.Error("expected the revoked token to be rejected")
+	}
+}
+
+func TestRegistry_TokenCallsUnknownWorker(t *testing.T) {
+	reg := newTestRegistry()
+
+	if _, err := reg.ListTokens(context.Background(), uuid.New()); !errors.Is(err, ErrWorkerNotFound) {
+		t.Errorf("ListTokens: expected ErrWorkerNotFound, got %v", err)
+	}
+	if _, _, _, err := reg.ReissueToken(context.Background(), uuid.New()); !errors.Is(err, ErrWorkerNotFound) {
+		t.Errorf("ReissueToken: expected ErrWorkerNotFound, got %v", err)
+	}
+}
+
+func TestRegistry_SetSchedulable(t *testing.T) {
+	repo := newFakeWorkerRepo()
+	reg := New(repo, newFakeTokenRepo(repo))
+	if _, err := reg.Preregister(context.Background(), "worker-a"); err != nil {
+		t.Fatalf("Preregister: %v", err)
+	}
+	id := repo.workers["worker-a"].ID
+
+	w, err := reg.SetSchedulable(context.Background(), id, false)
+	if err != nil {
+		t.Fatalf("SetSchedulable: %v", err)
+	}
+	if w.Schedulable || repo.byID[id].Schedulable {
+		t.Fatal("expected the worker to be cordoned")
+	}
+
+	// A cordoned worker stays cordoned when it reconnects.
+	if _, err := reg.Register(context.Background(), "worker-a", "podman", nil, currentCapabilities()); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if repo.byID[id].Schedulable {
+		t.Error("expected re-registration to keep the worker cordoned")
+	}
+
+	if _, err := reg.SetSchedulable(context.Background(), uuid.New(), true); !errors.Is(err, ErrWorkerNotFound) {
+		t.Errorf("expected ErrWorkerNotFound, got %v", err)
+	}
+}
+
 // ──────────────────────────────────────────────────────────────────────────────
 // Registry tests (nil repo — no DB)
 // ──────────────────────────────────────────────────────────────────────────────
 
 func TestRegistry_RegisterAddsEntry(t *testing.T) {
-	reg := New(nil)
+	reg := New(nil, nil)
 
-	entry, err := reg.Register(context.Background(), "worker-1", "podman", nil)
+	entry, err := reg.Register(context.Background(), "worker-1", "podman", nil, currentCapabilities())
 	if err != nil {
 		t.Fatalf("Register: unexpected error: %v", err)
 	}
@@ -173,19 +400,19 @@ func TestRegistry_RegisterAddsEntry(t *testing.T) {
 }
 
 func TestRegistry_Register_InvalidRuntimeType(t *testing.T) {
-	reg := New(nil)
+	reg := New(nil, nil)
 
-	_, err := reg.Register(context.Background(), "worker-1", "docker", nil)
+	_, err := reg.Register(context.Background(), "worker-1", "docker", nil, nil)
 	if err == nil {
 		t.Fatal("expected error for unsupported runtime_type")
 	}
 }
 
 func TestRegistry_RegisterIdempotent(t *testing.T) {
-	reg := New(nil)
+	reg := New(nil, nil)
 
-	e1, _ := reg.Register(context.Background(), "worker-1", "podman", nil)
-	e2, _ := reg.Register(context.Background(), "worker-1", "podman", nil)
+	e1, _ := reg.Register(context.Background(), "worker-1", "podman", nil, currentCapabilities())
+	e2, _ := reg.Register(context.Background(), "worker-1", "podman", nil, currentCapabilities())
 
 	// Both calls must return the same in-memory entry pointer.
 	if e1 != e2 {
@@ -194,8 +421,8 @@ func TestRegistry_RegisterIdempotent(t *testing.T) {
 }
 
 func TestRegistry_GetKnownWorker(t *testing.T) {
-	reg := New(nil)
-	reg.Register(context.Background(), "worker-1", "podman", nil) //nolint:errcheck
+	reg := New(nil, nil)
+	reg.Register(context.Background(), "worker-1", "podman", nil, currentCapabilities()) //nolint:errcheck
 
 	entry, ok := reg.Get("worker-1")
 	if !ok {
@@ -207,7 +434,7 @@ func TestRegistry_GetKnownWorker(t *testing.T) {
 }
 
 func TestRegistry_GetUnknownWorker(t *testing.T) {
-	reg := New(nil)
+	reg := New(nil, nil)
 
 	_, ok := reg.Get("ghost")
 	if ok {
@@ -216,8 +443,8 @@ func TestRegistry_GetUnknownWorker(t *testing.T) {
 }
 
 func TestRegistry_Disconnect(t *testing.T) {
-	reg := New(nil)
-	reg.Register(context.Background(), "worker-1", "podman", nil) //nolint:errcheck
+	reg := New(nil, nil)
+	reg.Register(context.Background(), "worker-1", "podman", nil, currentCapabilities()) //nolint:errcheck
 
 	reg.Disconnect(context.Background(), "worker-1")
 
@@ -227,14 +454,35 @@ func TestRegistry_Disconnect(t *testing.T) {
 	}
 }
 
+func TestRegistry_DisconnectHook(t *testing.T) {
+	repo := newFakeWorkerRepo()
+	reg := New(repo, nil)
+	var disconnected []string
+	reg.SetDisconnectHook(func(_ context.Context, _ uuid.UUID, workerName string) {
+		disconnected = append(disconnected, workerName)
+	})
+	reg.Register(context.Background(), "worker-1", "podman", nil, currentCapabilities()) //nolint:errcheck
+	reg.Register(context.Background(), "worker-2", "podman", nil, currentCapabilities()) //nolint:errcheck
+
+	// worker-2 never sent a heartbeat, so the sweeper marks both stale.
+	reg.Disconnect(context.Background(), "worker-1")
+	reg.SweepStale(context.Background(), time.Minute)
+	// Its stream ending afterwards is not reported again.
+	reg.Disconnect(context.Background(), "worker-2")
+
+	if strings.Join(disconnected, ",") != "worker-1,worker-2" {
+		t.Errorf("disconnect hook called for %v, want [worker-1 worker-2]", disconnected)
+	}
+}
+
 func TestRegistry_DisconnectUnknownIsNoop(t *testing.T) {
-	reg := New(nil)
+	reg := New(nil, nil)
 	// Must not panic.
 	reg.Disconnect(context.Background(), "never-registered")
 }
 
 func TestRegistry_DeregisterUnknown(t *testing.T) {
-	reg := New(nil)
+	reg := New(nil, nil)
 
 	deleted, err := reg.Deregister(context.Background(), uuid.New())
 	if err != nil {
@@ -246,7 +494,7 @@ func TestRegistry_DeregisterUnknown(t *testing.T) {
 }
 
 func TestRegistry_WaitForResult_WorkerNotConnected(t *testing.T) {
-	reg := New(nil)
+	reg := New(nil, nil)
 
 	_, err := reg.WaitForResult("ghost", "cmd-1")
 	if err == nil {
@@ -255,8 +503,8 @@ func TestRegistry_WaitForResult_WorkerNotConnected(t *testing.T) {
 }
 
 func TestRegistry_DeliverResult_Routing(t *testing.T) {
-	reg := New(nil)
-	reg.Register(context.Background(), "worker-1", "podman", nil) //nolint:errcheck
+	reg := New(nil, nil)
+	reg.Register(context.Background(), "worker-1", "podman", nil, currentCapabilities()) //nolint:errcheck
 
 	ch, err := reg.WaitForResult("worker-1", "cmd-42")
 	if err != nil {
@@ -280,9 +528,369 @@ func TestRegistry_DeliverResult_Routing(t *testing.T) {
 	}
 }
 
+func TestRegistry_Dispatch_RoundTrip(t *testing.T) {
+	reg := New(nil, nil)
+	entry, _ := reg.Register(context.Background(), "worker-1", "podman", nil, currentCapabilities())
+
+	// Simulate the gateway: read the queued command and deliver a result for it.
+	go func() {
+		cmd := <-entry.CommandCh
+		reg.DeliverResult(&workerpb.CommandResult{
+			WorkerName: "worker-1",
+			CommandId:  cmd.GetCommandId(),
+			Success:    true,
+		})
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	res, err := reg.Dispatch(ctx, "worker-1", &workerpb.Command{CommandId: "cmd-7", Type: workerpb.CommandType_COMMAND_TYPE_LIST_PODS})
+	if err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+	if res.GetCommandId() != "cmd-7" || !res.GetSuccess() {
+		t.Errorf("unexpected result: %+v", res)
+	}
+}
+
+func TestRegistry_InFlight(t *testing.T) {
+	reg := New(nil, nil)
+	entry, _ := reg.Register(context.Background(), "worker-1", "podman", nil, currentCapabilities())
+
+	if n := reg.InFlight("worker-1"); n != 0 {
+		t.Fatalf("InFlight = %d before dispatch, want 0", n)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		reg.Dispatch(context.Background(), "worker-1", &workerpb.Command{CommandId: "cmd-1", Type: workerpb.CommandType_COMMAND_TYPE_LIST_PODS}) //nolint:errcheck
+	}()
+
+	cmd := <-entry.CommandCh
+	if n := reg.InFlight("worker-1"); n != 1 {
+		t.Errorf("InFlight = %d while waiting, want 1", n)
+	}
+
+	reg.DeliverResult(&workerpb.CommandResult{WorkerName: "worker-1", CommandId: cmd.GetCommandId(), Success: true})
+	<-done
+
+	if n := reg.InFlight("worker-1"); n != 0 {
+		t.Errorf("InFlight = %d after the result, want 0", n)
+	}
+	if n := reg.InFlight("ghost"); n != 0 {
+		t.Errorf("InFlight = %d for an unknown worker, want 0", n)
+	}
+}
+
+func TestRegistry_Dispatch_WorkerNotConnected(t *testing.T) {
+	reg := New(nil, nil)
+
+	if _, err := reg.Dispatch(context.Background(), "ghost", &workerpb.Command{CommandId: "cmd-1", Type: workerpb.CommandType_COMMAND_TYPE_LIST_PODS}); err == nil {
+		t.Fatal("expected error for unconnected worker")
+	}
+}
+
+func TestRegistry_Dispatch_TimeoutReleasesWaiter(t *testing.T) {
+	reg := New(nil, nil)
+	entry, _ := reg.Register(context.Background(), "worker-1", "podman", nil, currentCapabilities())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := reg.Dispatch(ctx, "worker-1", &workerpb.Command{CommandId: "cmd-slow", Type: workerpb.CommandType_COMMAND_TYPE_LIST_PODS}); err == nil {
+		t.Fatal("expected timeout error")
+	}
+
+	entry.resultsMu.Lock()
+	_, pending := entry.results["cmd-slow"]
+	entry.resultsMu.Unlock()
+	if pending {
+		t.Error("expected result slot to be released after timeout")
+	}
+}
+
+func TestRegistry_Dispatch_SendsDeadlineAndCancel(t *testing.T) {
+	reg := New(nil, nil)
+	entry, _ := reg.Register(context.Background(), "worker-1", "podman", nil, currentCapabilities())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := reg.Dispatch(ctx, "worker-1", &workerpb.Command{CommandId: "cmd-slow", Type: workerpb.CommandType_COMMAND_TYPE_LIST_PODS})
+		errCh <- err
+	}()
+
+	cmd := <-entry.CommandCh
+	if ms := cmd.GetTimeoutMs(); ms <= 0 || ms > 100 {
+		t.Errorf("timeout_ms = %d, want the caller's remaining time", ms)
+	}
+	if err := <-errCh; err == nil {
+		t.Fatal("expected timeout error")
+	}
+
+	cancelCmd := <-entry.CommandCh
+	if cancelCmd.GetType() != workerpb.CommandType_COMMAND_TYPE_CANCEL || !strings.Contains(string(cancelCmd.GetPayload()), "cmd-slow") {
+		t.Errorf("expected a cancel command for cmd-slow, got %+v", cancelCmd)
+	}
+}
+
+func TestRegistry_Attach_ResendsInFlightCommands(t *testing.T) {
+	reg := New(nil, nil)
+	entry, _ := reg.Register(context.Background(), "worker-1", "podman", nil, currentCapabilities())
+	if _, err := reg.Attach(context.Background(), "worker-1", nil); err != nil {
+		t.Fatalf("Attach failed for a registered worker: %v", err)
+	}
+
+	type outcome struct {
+		res *workerpb.CommandResult
+		err error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		res, err := reg.Dispatch(context.Background(), "worker-1", &workerpb.Command{CommandId: "cmd-create", Type: workerpb.CommandType_COMMAND_TYPE_CREATE_POD})
+		done <- outcome{res, err}
+	}()
+
+	// The command reaches the worker, then the stream drops before the result arrives.
+	cmd := <-entry.CommandCh
+	entry.MarkSent(cmd.GetCommandId())
+	reg.Disconnect(context.Background(), "worker-1")
+	if reg.IsConnected("worker-1") {
+		t.Fatal("worker still connected after Disconnect")
+	}
+
+	att, err := reg.Attach(context.Background(), "worker-1", nil)
+	if err != nil || att.Entry != entry {
+		t.Fatal("expected the reconnecting worker to resume its entry")
+	}
+
+	select {
+	case resent := <-entry.CommandCh:
+		if resent.GetCommandId() != "cmd-create" {
+			t.Fatalf("resent %q, want cmd-create", resent.GetCommandId())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("in-flight command was not resent")
+	}
+
+	reg.DeliverResult(&workerpb.CommandResult{WorkerName: "worker-1", CommandId: "cmd-create", Success: true})
+	if o := <-done; o.err != nil || !o.res.GetSuccess() {
+		t.Errorf("Dispatch = %+v, %v", o.res, o.err)
+	}
+}
+
+func TestRegistry_Attach_FailsInFlightStreams(t *testing.T) {
+	reg := New(nil, nil)
+	entry, _ := reg.Register(context.Background(), "worker-1", "podman", nil, currentCapabilities())
+	reg.Attach(context.Background(), "worker-1", nil) //nolint:errcheck
+
+	errCh := make(chan error, 1)
+	resCh := make(chan *workerpb.CommandResult, 1)
+	go func() {
+		res, err := reg.DispatchStream(context.Background(), "worker-1", &workerpb.Command{CommandId: "cmd-logs", Type: workerpb.CommandType_COMMAND_TYPE_POD_LOGS}, func([]byte) error { return nil })
+		resCh <- res
+		errCh <- err
+	}()
+
+	cmd := <-entry.CommandCh
+	entry.MarkSent(cmd.GetCommandId())
+
+	// A second stream of the same worker replaces the first one.
+	reg.Attach(context.Background(), "worker-1", nil) //nolint:errcheck
+
+	res := <-resCh
+	if err := <-errCh; err != nil || res.GetSuccess() || !strings.Contains(res.GetError(), "interrupted") {
+		t.Errorf("DispatchStream = %+v, %v; want an interrupted result", res, err)
+	}
+}
+
+func TestRegistry_Detach_IgnoresSupersededStream(t *testing.T) {
+	reg := New(nil, nil)
+	reg.Register(context.Background(), "worker-1", "podman", nil, currentCapabilities()) //nolint:errcheck
+
+	old, _ := reg.Attach(context.Background(), "worker-1", nil)
+	current, _ := reg.Attach(context.Background(), "worker-1", nil)
+
+	select {
+	case <-old.Superseded:
+	default:
+		t.Fatal("expected the first stream to be superseded")
+	}
+
+	reg.Detach(context.Background(), old)
+	if !reg.IsConnected("worker-1") {
+		t.Fatal("detaching a superseded stream disconnected the worker")
+	}
+
+	reg.Detach(context.Background(), current)
+	if reg.IsConnected("worker-1") {
+		t.Error("expected the worker to be disconnected once its current stream ends")
+	}
+	if _, err := reg.Attach(context.Background(), "ghost", nil); !errors.Is(err, ErrWorkerNotRegistered) {
+		t.Errorf("Attach of an unknown worker = %v, want ErrWorkerNotRegistered", err)
+	}
+}
+
+func TestRegistry_Attach_AfterRestart(t *testing.T) {
+	repo := newFakeWorkerRepo()
+	New(repo, nil).Register(context.Background(), "worker-1", "podman", nil, currentCapabilities()) //nolint:errcheck
+
+	// A new registry on the same repository stands for a restarted control plane.
+	reg := New(repo, nil)
+	if err := reg.CheckRegistered(context.Background(), "worker-1"); err != nil {
+		t.Fatalf("CheckRegistered after restart: %v", err)
+	}
+
+	att, err := reg.Attach(context.Background(), "worker-1", nil)
+	if err != nil {
+		t.Fatalf("Attach after restart: %v", err)
+	}
+	if att.Entry.DBID != repo.workers["worker-1"].ID {
+		t.Errorf("DBID = %s, want %s", att.Entry.DBID, repo.workers["worker-1"].ID)
+	}
+	if !reg.IsConnected("worker-1") {
+		t.Error("expected the worker to be connected")
+	}
+	if !att.Entry.supports(workerpb.CommandType_COMMAND_TYPE_HTTP_PROXY) {
+		t.Error("capabilities persisted at registration were not restored")
+	}
+
+	if err := reg.CheckRegistered(context.Background(), "ghost"); !errors.Is(err, ErrWorkerNotRegistered) {
+		t.Errorf("CheckRegistered of an unknown worker = %v, want ErrWorkerNotRegistered", err)
+	}
+}
+
+func TestRegistry_Attach_RefusesRevokedWorkers(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeWorkerRepo()
+	reg := New(repo, newFakeTokenRepo(repo))
+	reg.Register(ctx, "worker-1", "podman", nil, currentCapabilities()) //nolint:errcheck
+	id := repo.workers["worker-1"].ID
+
+	att, err := reg.Attach(ctx, "worker-1", nil)
+	if err != nil {
+		t.Fatalf("Attach: %v", err)
+	}
+
+	// A new bootstrap token sends the worker back to pending and closes its stream.
+	if _, _, _, err := reg.ReissueToken(ctx, id); err != nil {
+		t.Fatalf("ReissueToken: %v", err)
+	}
+	select {
+	case <-att.Superseded:
+	default:
+		t.Fatal("expected the stream to be closed")
+	}
+	if got := repo.workers["worker-1"].Status; got != models.WorkerStatusPending {
+		t.Errorf("status = %q, want pending", got)
+	}
+	if _, err := reg.Attach(ctx, "worker-1", nil); !errors.Is(err, ErrWorkerNotRegistered) {
+		t.Errorf("Attach after ReissueToken = %v, want ErrWorkerNotRegistered", err)
+	}
+	if err := reg.CheckRegistered(ctx, "worker-1"); !errors.Is(err, ErrWorkerNotRegistered) {
+		t.Errorf("CheckRegistered after ReissueToken = %v, want ErrWorkerNotRegistered", err)
+	}
+
+	// Registering with the new token lets it attach again.
+	reg.Register(ctx, "worker-1", "podman", nil, currentCapabilities()) //nolint:errcheck
+	if _, err := reg.Attach(ctx, "worker-1", nil); err != nil {
+		t.Fatalf("Attach after registering again: %v", err)
+	}
+
+	if _, err := reg.Deregister(ctx, id); err != nil {
+		t.Fatalf("Deregister: %v", err)
+	}
+	for _, r := range []*Registry{reg, New(repo, nil)} {
+		if _, err := r.Attach(ctx, "worker-1", nil); !errors.Is(err, ErrWorkerNotRegistered) {
+			t.Errorf("Attach after Deregister = %v, want ErrWorkerNotRegistered", err)
+		}
+	}
+}
+
+func TestRegistry_DispatchStream_DeliversFramesInOrder(t *testing.T) {
+	reg := New(nil, nil)
+	entry, _ := reg.Register(context.Background(), "worker-1", "podman", nil, currentCapabilities())
+
+	go func() {
+		cmd := <-entry.CommandCh
+		for _, chunk := range []string{"a", "b", "c"} {
+			reg.DeliverResult(&workerpb.CommandResult{WorkerName: "worker-1", CommandId: cmd.GetCommandId(), Chunk: []byte(chunk)})
+		}
+		reg.DeliverResult(&workerpb.CommandResult{WorkerName: "worker-1", CommandId: cmd.GetCommandId(), Success: true, EndOfStream: true})
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var got string
+	res, err := reg.DispatchStream(ctx, "worker-1", &workerpb.Command{CommandId: "cmd-logs", Type: workerpb.CommandType_COMMAND_TYPE_POD_LOGS}, func(chunk []byte) error {
+		got += string(chunk)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("DispatchStream: %v", err)
+	}
+	if got != "abc" || !res.GetSuccess() {
+		t.Errorf("output = %q, result = %+v", got, res)
+	}
+}
+
+func TestRegistry_DispatchStream_Overflow(t *testing.T) {
+	reg := New(nil, nil)
+	entry, _ := reg.Register(context.Background(), "worker-1", "podman", nil, currentCapabilities())
+
+	// The consumer blocks on the first chunk while the worker keeps sending.
+	release := make(chan struct{})
+	go func() {
+		cmd := <-entry.CommandCh
+		for range streamBufferSize + 2 {
+			reg.DeliverResult(&workerpb.CommandResult{WorkerName: "worker-1", CommandId: cmd.GetCommandId(), Chunk: []byte("x")})
+		}
+		close(release)
+	}()
+
+	first := true
+	_, err := reg.DispatchStream(context.Background(), "worker-1", &workerpb.Command{CommandId: "cmd-flood", Type: workerpb.CommandType_COMMAND_TYPE_POD_LOGS}, func([]byte) error {
+		if first {
+			first = false
+			<-release
+		}
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "too slow") {
+		t.Fatalf("expected an overflow error, got %v", err)
+	}
+}
+
+func TestRegistry_Dispatch_IgnoresFrames(t *testing.T) {
+	reg := New(nil, nil)
+	entry, _ := reg.Register(context.Background(), "worker-1", "podman", nil, currentCapabilities())
+
+	go func() {
+		cmd := <-entry.CommandCh
+		reg.DeliverResult(&workerpb.CommandResult{WorkerName: "worker-1", CommandId: cmd.GetCommandId(), Chunk: []byte("noise")})
+		reg.DeliverResult(&workerpb.CommandResult{WorkerName: "worker-1", CommandId: cmd.GetCommandId(), Success: true})
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	res, err := reg.Dispatch(ctx, "worker-1", &workerpb.Command{CommandId: "cmd-unary", Type: workerpb.CommandType_COMMAND_TYPE_LIST_PODS})
+	if err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+	if !res.GetSuccess() || len(res.GetChunk()) != 0 {
+		t.Errorf("expected the final result, got %+v", res)
+	}
+}
+
 func TestRegistry_DeliverResult_NoWaiter(t *testing.T) {
-	reg := New(nil)
-	reg.Register(context.Background(), "worker-1", "podman", nil) //nolint:errcheck
+	reg := New(nil, nil)
+	reg.Register(context.Background(), "worker-1", "podman", nil, currentCapabilities()) //nolint:errcheck
 
 	// Delivering a result with no waiter must not block or panic.
 	reg.DeliverResult(&workerpb.CommandResult{
@@ -292,7 +900,7 @@ func TestRegistry_DeliverResult_NoWaiter(t *testing.T) {
 }
 
 func TestRegistry_DeliverResult_UnknownWorker(t *testing.T) {
-	reg := New(nil)
+	reg := New(nil, nil)
 	// Delivering for a worker that has never registered must not panic.
 	reg.DeliverResult(&workerpb.CommandResult{
 		WorkerName: "ghost",
@@ -301,10 +909,18 @@ func TestRegistry_DeliverResult_UnknownWorker(t *testing.T) {
 }
 
 func TestRegistry_ValidateToken(t *testing.T) {
-	reg := New(nil)
-	token := reg.tokenStore.IssueToken("worker-x")
+	repo := newFakeWorkerRepo()
+	reg := New(repo, newFakeTokenRepo(repo))
+	w := &models.Worker{Name: "worker-x"}
+	if err := repo.Upsert(context.Background(), w); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+	token, _, err := reg.tokenStore.IssueToken(context.Background(), w.ID)
+	if err != nil {
+		t.Fatalf("IssueToken: %v", err)
+	}
 
-	name, err := reg.ValidateToken(token)
+	name, err := reg.ValidateToken(context.Background(), token)
 	if err != nil {
 		t.Fatalf("ValidateToken: %v", err)
 	}
@@ -314,9 +930,90 @@ func TestRegistry_ValidateToken(t *testing.T) {
 }
 
 func TestRegistry_ValidateToken_Invalid(t *testing.T) {
-	reg := New(nil)
+	reg := New(nil, nil)
 
-	if _, err := reg.ValidateToken("garbage"); err == nil {
+	if _, err := reg.ValidateToken(context.Background(), "garbage"); err == nil {
 		t.Fatal("expected error for invalid token")
 	}
 }
+
+// ──────────────────────────────────────────────────────────────────────────────
+// Capabilities
+// ──────────────────────────────────────────────────────────────────────────────
+
+func TestRegistry_Dispatch_RefusesUnsupportedCommand(t *testing.T) {
+	reg := New(nil, nil)
+	// A worker that declares no capabilities predates negotiation.
+	entry, _ := reg.Register(context.Background(), "worker-1", "podman", nil, nil)
+
+	_, err := reg.Dispatch(context.Background(), "worker-1", &workerpb.Command{
+		CommandId: "c1",
+		Type:      workerpb.CommandType_COMMAND_TYPE_HTTP_PROXY,
+	})
+	if !errors.Is(err, ErrCommandUnsupported) {
+		t.Fatalf("expected ErrCommandUnsupported, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "protocol version 0") {
+		t.Errorf("error %q does not name the worker's protocol version", err)
+	}
+	if len(entry.CommandCh) != 0 {
+		t.Error("an unsupported command was queued")
+	}
+	if reg.InFlight("worker-1") != 0 {
+		t.Error("an unsupported command left a pending result")
+	}
+}
+
+func TestRegistry_Capabilities_PersistedAndOutdated(t *testing.T) {
+	repo := newFakeWorkerRepo()
+	reg := New(repo, nil)
+
+	if _, err := reg.Register(context.Background(), "worker-1", "podman", nil, nil); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if err := repo.Upsert(context.Background(), &models.Worker{Name: "pending", Status: models.WorkerStatusPending}); err != nil {
+		t.Fatal(err)
+	}
+
+	outdated := func() map[string]bool {
+		workers, err := reg.List(context.Background())
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		flags := map[string]bool{}
+		for _, w := range workers {
+			flags[w.Name] = w.Outdated
+		}
+		return flags
+	}
+
+	if got := outdated(); !got["worker-1"] || got["pending"] {
+		t.Fatalf("outdated = %v, want only worker-1 flagged", got)
+	}
+	if cmds := repo.workers["worker-1"].SupportedCommands; len(cmds) == 0 || cmds[0] != "COMMAND_TYPE_LIST_IMAGES" {
+		t.Errorf("legacy commands not persisted: %v", cmds)
+	}
+
+	// The worker is upgraded in place and declares its capabilities on the next stream.
+	if _, err := reg.Attach(context.Background(), "worker-1", currentCapabilities()); err != nil {
+		t.Fatalf("Attach failed: %v", err)
+	}
+	if got := repo.workers["worker-1"].ProtocolVersion; got != int(workerpb.ProtocolVersion) {
+		t.Errorf("protocol_version = %d, want %d", got, workerpb.ProtocolVersion)
+	}
+	if outdated()["worker-1"] {
+		t.Error("upgraded worker is still flagged as outdated")
+	}
+
+	entry, _ := reg.Get("worker-1")
+	go func() {
+		cmd := <-entry.CommandCh
+		reg.DeliverResult(&workerpb.CommandResult{WorkerName: "worker-1", CommandId: cmd.GetCommandId(), Success: true})
+	}()
+	if _, err := reg.Dispatch(context.Background(), "worker-1", &workerpb.Command{
+		CommandId: "c1",
+		Type:      workerpb.CommandType_COMMAND_TYPE_HTTP_PROXY,
+	}); err != nil {
+		t.Errorf("Dispatch after upgrade: %v", err)
+	}
+}
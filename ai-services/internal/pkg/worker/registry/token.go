@@ -1,68 +1,104 @@
 package registry
 
 import (
+	"context"
 	"fmt"
-	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	apirepository "github.com/project-ai-services/ai-services/internal/pkg/catalog/apiserver/repository"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/repository"
 )
 
 // tokenTTLHours is the validity window for single-use bootstrap tokens.
 const tokenTTLHours = 24
 
-// TokenRecord holds a single-use bootstrap token bound to a specific worker name.
-type TokenRecord struct {
-	Token      string
-	WorkerName string
-	ExpiresAt  time.Time
-	Used       bool
-}
-
-// TokenStore is an in-memory single-use bootstrap token store.
+// TokenStore issues and validates single-use bootstrap tokens.
+// Tokens are persisted as SHA-256 hashes (like revoked JWTs in DBTokenBlacklist), so
+// outstanding tokens survive an API server restart and the plaintext never hits the DB.
 type TokenStore struct {
-	mu     sync.Mutex
-	tokens map[string]*TokenRecord
+	repo repository.WorkerTokenRepository
 }
 
-// NewTokenStore creates an empty token store.
-func NewTokenStore() *TokenStore {
-	return &TokenStore{tokens: make(map[string]*TokenRecord)}
+// NewTokenStore creates a token store backed by repo.
+func NewTokenStore(repo repository.WorkerTokenRepository) *TokenStore {
+	return &TokenStore{repo: repo}
 }
 
-// IssueToken generates a new 24-hour single-use token bound to workerName and returns it.
+// IssueToken generates a new 24-hour single-use token bound to the worker and returns it.
+// Any still-active token of the worker is revoked: the newest token supersedes older ones.
 // The worker must present this exact token when calling Register; the name supplied in
 // the RegisterRequest is ignored — the name bound to the token is authoritative.
-func (ts *TokenStore) IssueToken(workerName string) string {
+func (ts *TokenStore) IssueToken(ctx context.Context, workerID uuid.UUID) (string, *models.WorkerToken, error) {
+	if err := ts.repo.RevokeAllForWorker(ctx, workerID); err != nil {
+		return "", nil, err
+	}
+
 	token := uuid.NewString()
-	ts.mu.Lock()
-	ts.tokens[token] = &TokenRecord{
-		Token:      token,
-		WorkerName: workerName,
-		ExpiresAt:  time.Now().Add(tokenTTLHours * time.Hour),
+	rec := &models.WorkerToken{
+		WorkerID:  workerID,
+		TokenHash: apirepository.HashToken(token),
+		ExpiresAt: time.Now().Add(tokenTTLHours * time.Hour),
+	}
+	if err := ts.repo.Create(ctx, rec); err != nil {
+		return "", nil, err
 	}
-	ts.mu.Unlock()
+	rec.Status = models.WorkerTokenStatusActive
 
-	return token
+	return token, rec, nil
 }
 
 // Validate checks token validity, marks it used, and returns the worker name it was
-// issued for. Returns an error if the token is unknown, already used, or expired.
-func (ts *TokenStore) Validate(token string) (string, error) {
-	ts.mu.Lock()
-	defer ts.mu.Unlock()
-
-	rec, ok := ts.tokens[token]
-	if !ok {
+// issued for. Returns an error if the token is unknown, already used, revoked, or expired.
+func (ts *TokenStore) Validate(ctx context.Context, token string) (string, error) {
+	rec, err := ts.repo.GetByHash(ctx, apirepository.HashToken(token))
+	if err != nil {
+		return "", err
+	}
+	if rec == nil {
 		return "", fmt.Errorf("bootstrap token not found")
 	}
-	if rec.Used {
+
+	switch rec.StatusAt(time.Now()) {
+	case models.WorkerTokenStatusUsed:
 		return "", fmt.Errorf("bootstrap token already used")
-	}
-	if time.Now().After(rec.ExpiresAt) {
+	case models.WorkerTokenStatusRevoked:
+		return "", fmt.Errorf("bootstrap token revoked")
+	case models.WorkerTokenStatusExpired:
 		return "", fmt.Errorf("bootstrap token expired")
 	}
-	rec.Used = true
+
+	// The conditional update loses if a concurrent Register consumed or an admin
+	// revoked the token after the read above.
+	ok, err := ts.repo.MarkUsed(ctx, rec.ID)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", fmt.Errorf("bootstrap token already used")
+	}
 
 	return rec.WorkerName, nil
 }
+
+// List returns the tokens issued for a worker, newest first, with their status.
+func (ts *TokenStore) List(ctx context.Context, workerID uuid.UUID) ([]models.WorkerToken, error) {
+	tokens, err := ts.repo.ListByWorker(ctx, workerID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range tokens {
+		tokens[i].Status = tokens[i].StatusAt(now)
+	}
+
+	return tokens, nil
+}
+
+// Revoke invalidates an unused token of the worker.
+// Returns (false, nil) if the token does not exist or was already used or revoked.
+func (ts *TokenStore) Revoke(ctx context.Context, workerID, tokenID uuid.UUID) (bool, error) {
+	return ts.repo.Revoke(ctx, workerID, tokenID)
+}
@@ -1,19 +1,50 @@
 package registry
 
 import (
+	"context"
 	"testing"
 	"time"
+
+	"github.com/google/uuid"
+	"github.com/project-ai-services/ai-services/internal/pkg/catalog/db/models"
 )
 
+// newTestTokenStore returns a TokenStore over the in-memory fakes plus a helper
+// that creates a worker row and returns its ID.
+func newTestTokenStore(t *testing.T) (*TokenStore, *fakeTokenRepo, func(name string) uuid.UUID) {
+	t.Helper()
+
+	workers := newFakeWorkerRepo()
+	tokens := newFakeTokenRepo(workers)
+	addWorker := func(name string) uuid.UUID {
+		w := &models.Worker{Name: name}
+		if err := workers.Upsert(context.Background(), w); err != nil {
+			t.Fatalf("Upsert: %v", err)
+		}
+		return w.ID
+	}
+
+	return NewTokenStore(tokens), tokens, addWorker
+}
+
+func issue(t *testing.T, ts *TokenStore, workerID uuid.UUID) string {
+	t.Helper()
+	token, _, err := ts.IssueToken(context.Background(), workerID)
+	if err != nil {
+		t.Fatalf("IssueToken: %v", err)
+	}
+	return token
+}
+
 func TestTokenStore_IssueAndValidate(t *testing.T) {
-	ts := NewTokenStore()
-	token := ts.IssueToken("worker-a")
+	ts, _, addWorker := newTestTokenStore(t)
+	token := issue(t, ts, addWorker("worker-a"))
 
 	if token == "" {
 		t.Fatal("expected non-empty token")
 	}
 
-	name, err := ts.Validate(token)
+	name, err := ts.Validate(context.Background(), token)
 	if err != nil {
 		t.Fatalf("Validate: unexpected error: %v", err)
 	}
@@ -22,55 +53,102 @@ func TestTokenStore_IssueAndValidate(t *testing.T) {
 	}
 }
 
+func TestTokenStore_StoresOnlyHash(t *testing.T) {
+	ts, repo, addWorker := newTestTokenStore(t)
+	token := issue(t, ts, addWorker("worker-a"))
+
+	if len(repo.tokens) != 1 {
+		t.Fatalf("expected 1 stored token, got %d", len(repo.tokens))
+	}
+	if got := repo.tokens[0].TokenHash; got == token || len(got) != 64 {
+		t.Errorf("expected a SHA-256 hex hash to be stored, got %q", got)
+	}
+}
+
 func TestTokenStore_SingleUse(t *testing.T) {
-	ts := NewTokenStore()
-	token := ts.IssueToken("worker-b")
+	ts, _, addWorker := newTestTokenStore(t)
+	token := issue(t, ts, addWorker("worker-b"))
 
-	if _, err := ts.Validate(token); err != nil {
+	if _, err := ts.Validate(context.Background(), token); err != nil {
 		t.Fatalf("first Validate: unexpected error: %v", err)
 	}
 
-	if _, err := ts.Validate(token); err == nil {
+	if _, err := ts.Validate(context.Background(), token); err == nil {
 		t.Fatal("second Validate: expected error for already-used token")
 	}
 }
 
 func TestTokenStore_UnknownToken(t *testing.T) {
-	ts := NewTokenStore()
+	ts, _, _ := newTestTokenStore(t)
 
-	if _, err := ts.Validate("not-a-real-token"); err == nil {
+	if _, err := ts.Validate(context.Background(), "not-a-real-token"); err == nil {
 		t.Fatal("expected error for unknown token")
 	}
 }
 
 func TestTokenStore_ExpiredToken(t *testing.T) {
-	ts := NewTokenStore()
+	ts, repo, addWorker := newTestTokenStore(t)
+	token := issue(t, ts, addWorker("worker-exp"))
 
-	// Manually inject an expired record.
-	ts.mu.Lock()
-	ts.tokens["expired"] = &TokenRecord{
-		Token:      "expired",
-		WorkerName: "worker-exp",
-		ExpiresAt:  time.Now().Add(-time.Hour),
-	}
-	ts.mu.Unlock()
+	// Manually expire the stored record.
+	repo.tokens[0].ExpiresAt = time.Now().Add(-time.Hour)
 
-	if _, err := ts.Validate("expired"); err == nil {
+	if _, err := ts.Validate(context.Background(), token); err == nil {
 		t.Fatal("expected error for expired token")
 	}
 }
 
+func TestTokenStore_RevokedToken(t *testing.T) {
+	ts, repo, addWorker := newTestTokenStore(t)
+	workerID := addWorker("worker-r")
+	token := issue(t, ts, workerID)
+
+	revoked, err := ts.Revoke(context.Background(), workerID, repo.tokens[0].ID)
+	if err != nil || !revoked {
+		t.Fatalf("Revoke = %v, %v", revoked, err)
+	}
+
+	if _, err := ts.Validate(context.Background(), token); err == nil {
+		t.Fatal("expected error for revoked token")
+	}
+}
+
+func TestTokenStore_NewTokenSupersedesOld(t *testing.T) {
+	ts, _, addWorker := newTestTokenStore(t)
+	workerID := addWorker("worker-s")
+	oldToken := issue(t, ts, workerID)
+	newToken := issue(t, ts, workerID)
+
+	if _, err := ts.Validate(context.Background(), oldToken); err == nil {
+		t.Error("expected the superseded token to be rejected")
+	}
+	if _, err := ts.Validate(context.Background(), newToken); err != nil {
+		t.Errorf("Validate new token: %v", err)
+	}
+
+	tokens, err := ts.List(context.Background(), workerID)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(tokens) != 2 {
+		t.Fatalf("expected 2 tokens, got %d", len(tokens))
+	}
+	if tokens[0].Status != models.WorkerTokenStatusUsed || tokens[1].Status != models.WorkerTokenStatusRevoked {
+		t.Errorf("statuses = %q, %q; want used, revoked", tokens[0].Status, tokens[1].Status)
+	}
+}
+
 func TestTokenStore_MultipleWorkers(t *testing.T) {
-	ts := NewTokenStore()
+	ts, _, addWorker := newTestTokenStore(t)
 
-	tokenA := ts.IssueToken("worker-a")
-	tokenB := ts.IssueToken("worker-b")
+	tokenA := issue(t, ts, addWorker("worker-a"))
+	tokenB := issue(t, ts, addWorker("worker-b"))
 
-	nameA, err := ts.Validate(tokenA)
+	nameA, err := ts.Validate(context.Background(), tokenA)
 	if err != nil {
 		t.Fatalf("Validate tokenA: %v", err)
 	}
-	nameB, err := ts.Validate(tokenB)
+	nameB, err := ts.Validate(context.Background(), tokenB)
 	if err != nil {
 		t.Fatalf("Validate tokenB: %v", err)
 	}